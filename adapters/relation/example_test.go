@@ -0,0 +1,40 @@
+package relation_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/adapters/relation"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+)
+
+func ExampleCartesianProduct() {
+	sizes := orderedset.Of("S", "M")
+	colours := orderedset.Of("blue", "red")
+	iter := relation.CartesianProduct[string, string](sizes, colours)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Println(e.Value().First, e.Value().Second)
+	}
+	// Output:
+	// M blue
+	// M red
+	// S blue
+	// S red
+}
+
+func ExamplePowerSet() {
+	iter := relation.PowerSet[int](orderedset.Of(1, 2, 3), relation.MaxPowerSetElements)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Println(e.Value())
+	}
+	// Output:
+	// []
+	// [1]
+	// [2]
+	// [1 2]
+	// [3]
+	// [1 3]
+	// [2 3]
+	// [1 2 3]
+}
@@ -0,0 +1,204 @@
+/*
+Package relation provides lazy combinatorial iterators over sets, treating them as
+mathematical relations, for uses such as generating test cases.
+
+	colours := hashset.Of("red", "green")
+	sizes := orderedset.Of(1, 2, 3)
+	iter := relation.CartesianProduct[string, int](colours, sizes)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Println(e.Value().First, e.Value().Second)
+	}
+
+Results are computed as the iterators advance, so only the results consumed are materialized.
+As with the iterators of the collections themselves, the sets must not be modified during iteration.
+*/
+package relation
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets"
+)
+
+// MaxPowerSetElements is the largest set for which a power set may be iterated.
+const MaxPowerSetElements = 62
+
+// Pair is an ordered pair of values.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// element is an Element whose value is computed rather than stored in a collection.
+type element[T any] struct {
+	value T
+	local.InternalImpl
+}
+
+// Value returns the value of this element.
+func (e *element[T]) Value() T {
+	return e.value
+}
+
+// ValuePtr panics, as the values are derived from sets, and modifying them would break the sets.
+func (e *element[T]) ValuePtr() *T {
+	panic(messages.SET_POINTER_MODIFICATION)
+}
+
+// IsValid returns true, as the value is held by the element rather than referring into a collection.
+func (e *element[T]) IsValid() bool {
+	return true
+}
+
+// Refresh returns true, as the element is always valid.
+func (e *element[T]) Refresh() bool {
+	return true
+}
+
+// ProductIterator iterates the Cartesian product of two sets.
+type ProductIterator[A, B any] struct {
+	outer   collections.Iterator[A]
+	inner   collections.Iterator[B]
+	current collections.Element[A]
+	local.InternalImpl
+}
+
+// CartesianProduct returns an iterator over every pair of a value from a with a value from b.
+// The pairs are ordered by the iteration order of a, then of b, so for each value of a,
+// b is iterated in full.
+//
+// The product has a.Count() * b.Count() pairs, which are not stored.
+//
+// Panics if either set is nil.
+func CartesianProduct[A, B any](a sets.Set[A], b sets.Set[B]) *ProductIterator[A, B] {
+	if a == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "a"))
+	}
+
+	if b == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "b"))
+	}
+
+	return &ProductIterator[A, B]{
+		outer: a.Iterator(),
+		inner: b.Iterator(),
+	}
+}
+
+// Start begins the iteration returning the first pair,
+// which will be nil if either set is empty.
+//
+// Panics if either set has been modified since creation of the iterator.
+func (i *ProductIterator[A, B]) Start() collections.Element[Pair[A, B]] {
+	if i.current = i.outer.Start(); i.current == nil {
+		return nil
+	}
+
+	return i.pair(i.inner.Start())
+}
+
+// Next returns the next pair, which will be nil if the end has been reached.
+//
+// Panics if either set has been modified since creation of the iterator.
+func (i *ProductIterator[A, B]) Next() collections.Element[Pair[A, B]] {
+	if i.current == nil {
+		return nil
+	}
+
+	return i.pair(i.inner.Next())
+}
+
+// pair returns the pair of the current value of a with the given value of b. If b has been
+// iterated in full for the current value of a, moves to the next value of a and restarts b.
+func (i *ProductIterator[A, B]) pair(inner collections.Element[B]) collections.Element[Pair[A, B]] {
+	if inner == nil {
+		if i.current = i.outer.Next(); i.current == nil {
+			return nil
+		}
+
+		if inner = i.inner.Start(); inner == nil {
+			// b is empty
+			i.current = nil
+			return nil
+		}
+	}
+
+	return &element[Pair[A, B]]{value: Pair[A, B]{First: i.current.Value(), Second: inner.Value()}}
+}
+
+// PowerSetIterator iterates the subsets of a set.
+type PowerSetIterator[T any] struct {
+	set         sets.Set[T]
+	maxElements int
+	values      []T
+	mask        uint64
+	local.InternalImpl
+}
+
+// PowerSet returns an iterator over every subset of s, including the empty set and s itself.
+// Each subset is a new slice of values in the iteration order of s.
+//
+// Subsets are produced in binary counting order, i.e. the nth subset holds the values
+// at the positions of the bits set in n. A set of n values has 2^n subsets, so maxElements
+// bounds the size of set that is expected, guarding against iterations that would never complete.
+//
+// The values of s are read when iteration starts.
+//
+// Panics if s is nil, if maxElements is negative or greater than [MaxPowerSetElements],
+// or if s has more than maxElements values when iteration starts.
+func PowerSet[T any](s sets.Set[T], maxElements int) *PowerSetIterator[T] {
+	if s == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "s"))
+	}
+
+	if maxElements < 0 || maxElements > MaxPowerSetElements {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxElements"))
+	}
+
+	return &PowerSetIterator[T]{
+		set:         s,
+		maxElements: maxElements,
+	}
+}
+
+// Start reads the values of the set and begins the iteration, returning the empty subset.
+//
+// Panics if the set has more values than the maximum given to [PowerSet].
+func (i *PowerSetIterator[T]) Start() collections.Element[[]T] {
+	values := i.set.ToSlice()
+
+	if len(values) > i.maxElements {
+		panic(fmt.Sprintf(messages.POWER_SET_TOO_LARGE_FMT, len(values), i.maxElements))
+	}
+
+	i.values = values
+	i.mask = 0
+	return i.subset()
+}
+
+// Next returns the next subset, which will be nil if the end has been reached.
+func (i *PowerSetIterator[T]) Next() collections.Element[[]T] {
+	if i.values == nil || i.mask >= 1<<len(i.values)-1 {
+		return nil
+	}
+
+	i.mask++
+	return i.subset()
+}
+
+func (i *PowerSetIterator[T]) subset() collections.Element[[]T] {
+	subset := make([]T, 0, bits.OnesCount64(i.mask))
+
+	for bit, value := range i.values {
+		if i.mask&(1<<bit) != 0 {
+			subset = append(subset, value)
+		}
+	}
+
+	return &element[[]T]{value: subset}
+}
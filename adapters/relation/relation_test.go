@@ -0,0 +1,128 @@
+package relation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/stretchr/testify/require"
+)
+
+func collect[T any](iter collections.Iterator[T]) []T {
+	values := []T{}
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, e.Value())
+	}
+	return values
+}
+
+func TestCartesianProduct(t *testing.T) {
+
+	t.Run("Every pair is produced in order", func(t *testing.T) {
+		iter := CartesianProduct[string, int](orderedset.Of("a", "b"), orderedset.Of(1, 2, 3))
+
+		require.Equal(t, []Pair[string, int]{
+			{"a", 1}, {"a", 2}, {"a", 3},
+			{"b", 1}, {"b", 2}, {"b", 3},
+		}, collect[Pair[string, int]](iter))
+	})
+
+	t.Run("Sets of different types", func(t *testing.T) {
+		iter := CartesianProduct[int, string](hashset.Of(1, 2, 3), hashset.Of("x", "y"))
+		pairs := collect[Pair[int, string]](iter)
+
+		require.Len(t, pairs, 6)
+		require.Contains(t, pairs, Pair[int, string]{3, "y"})
+	})
+
+	t.Run("Empty set produces no pairs", func(t *testing.T) {
+		require.Empty(t, collect[Pair[int, int]](CartesianProduct[int, int](orderedset.Of(1, 2), orderedset.New[int]())))
+		require.Empty(t, collect[Pair[int, int]](CartesianProduct[int, int](orderedset.New[int](), orderedset.Of(1, 2))))
+	})
+
+	t.Run("Start restarts iteration", func(t *testing.T) {
+		iter := CartesianProduct[int, int](orderedset.Of(1, 2), orderedset.Of(3, 4))
+		collect[Pair[int, int]](iter)
+
+		require.Equal(t, Pair[int, int]{1, 3}, iter.Start().Value())
+	})
+
+	t.Run("Pairs are produced lazily", func(t *testing.T) {
+		large := orderedset.New[int]()
+		for i := 0; i < 10000; i++ {
+			large.Add(i)
+		}
+
+		iter := CartesianProduct[int, int](large, large)
+		iter.Start()
+
+		allocs := testing.AllocsPerRun(100, func() { iter.Next() })
+		require.LessOrEqual(t, allocs, float64(2))
+	})
+
+	t.Run("Modifying a set panics", func(t *testing.T) {
+		a := orderedset.Of(1, 2)
+		iter := CartesianProduct[int, int](a, orderedset.Of(3, 4))
+		iter.Start()
+		a.Add(5)
+
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() {
+			iter.Next()
+			iter.Next()
+		})
+	})
+
+	t.Run("Element pointer panics", func(t *testing.T) {
+		e := CartesianProduct[int, int](orderedset.Of(1), orderedset.Of(2)).Start()
+		require.PanicsWithValue(t, messages.SET_POINTER_MODIFICATION, func() { e.ValuePtr() })
+	})
+
+	t.Run("Nil set panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "b"), func() { CartesianProduct[int, int](orderedset.Of(1), nil) })
+	})
+}
+
+func TestPowerSet(t *testing.T) {
+
+	t.Run("Every subset is produced", func(t *testing.T) {
+		iter := PowerSet[int](orderedset.Of(1, 2, 3), 3)
+
+		require.Equal(t, [][]int{
+			{}, {1}, {2}, {1, 2}, {3}, {1, 3}, {2, 3}, {1, 2, 3},
+		}, collect[[]int](iter))
+	})
+
+	t.Run("Empty set has one subset", func(t *testing.T) {
+		require.Equal(t, [][]int{{}}, collect[[]int](PowerSet[int](orderedset.New[int](), 0)))
+	})
+
+	t.Run("Set larger than limit panics", func(t *testing.T) {
+		iter := PowerSet[int](orderedset.Of(1, 2, 3), 2)
+		require.PanicsWithValue(t, fmt.Sprintf(messages.POWER_SET_TOO_LARGE_FMT, 3, 2), func() { iter.Start() })
+	})
+
+	t.Run("Limit out of range panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxElements"), func() { PowerSet[int](orderedset.Of(1), MaxPowerSetElements+1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxElements"), func() { PowerSet[int](orderedset.Of(1), -1) })
+	})
+
+	t.Run("Large set is iterated lazily", func(t *testing.T) {
+		s := orderedset.New[int]()
+		for i := 0; i < 40; i++ {
+			s.Add(i)
+		}
+
+		iter := PowerSet[int](s, MaxPowerSetElements)
+		require.Empty(t, iter.Start().Value())
+		require.Equal(t, []int{0}, iter.Next().Value())
+		require.Equal(t, []int{1}, iter.Next().Value())
+		require.Equal(t, []int{0, 1}, iter.Next().Value())
+	})
+
+	t.Run("Next before Start returns nil", func(t *testing.T) {
+		require.Nil(t, PowerSet[int](orderedset.Of(1), 1).Next())
+	})
+}
@@ -0,0 +1,124 @@
+/*
+Package sortadapter adapts the collections in this module to the interfaces
+of the standard library's sort and container/heap packages.
+
+This eases incremental migration of code written against the standard library.
+For example, an existing call to sort.Sort can operate directly on a stack
+
+	s := stack.New[int]()
+	s.AddRange(values)
+	sort.Sort(sortadapter.Wrap[int](s))
+
+Values are compared using the comparer of the wrapped collection.
+
+Each call made through an adapter takes the wrapped collection's lock if it is thread-safe,
+however a sort or heap operation as a whole is not atomic. Synchronize access externally
+if other goroutines may modify the collection while it is being sorted.
+*/
+package sortadapter
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/fireflycons/generic_collections/collections"
+)
+
+// Assert interface implementations
+var _ sort.Interface = (*SortAdapter[int])(nil)
+var _ heap.Interface = (*HeapAdapter[int])(nil)
+
+// SortAdapter implements sort.Interface over an Indexable collection.
+//
+// Sorting in ascending order places the smallest value at position 0,
+// i.e. the top of a stack or the head of a queue.
+type SortAdapter[T any] struct {
+	collection collections.Indexable[T]
+}
+
+// HeapCollection defines collections that can store a binary heap
+// maintained by the container/heap package.
+//
+// Implemented by Stack[T].
+type HeapCollection[T any] interface {
+	collections.Indexable[T]
+
+	// Push adds a value at position 0.
+	Push(value T)
+
+	// Pop removes and returns the value at position 0.
+	Pop() T
+}
+
+// HeapAdapter implements heap.Interface over a HeapCollection.
+//
+// After heap.Init, the minimum value is at the bottom of the stack,
+// and is removed by heap.Pop.
+type HeapAdapter[T any] struct {
+	collection HeapCollection[T]
+}
+
+// Wrap returns a sort.Interface that operates directly on the given collection.
+func Wrap[T any](collection collections.Indexable[T]) *SortAdapter[T] {
+	return &SortAdapter[T]{
+		collection: collection,
+	}
+}
+
+// WrapHeap returns a heap.Interface that operates directly on the given collection.
+func WrapHeap[T any](collection HeapCollection[T]) *HeapAdapter[T] {
+	return &HeapAdapter[T]{
+		collection: collection,
+	}
+}
+
+// Len returns the number of values in the collection.
+func (a *SortAdapter[T]) Len() int {
+	return a.collection.Count()
+}
+
+// Less returns true if the value at position i is less than the value at position j.
+func (a *SortAdapter[T]) Less(i, j int) bool {
+	return a.collection.CompareAt(i, j) < 0
+}
+
+// Swap exchanges the values at positions i and j.
+func (a *SortAdapter[T]) Swap(i, j int) {
+	a.collection.Swap(i, j)
+}
+
+// Len returns the number of values in the collection.
+func (a *HeapAdapter[T]) Len() int {
+	return a.collection.Count()
+}
+
+// Less returns true if the value at heap index i is less than the value at heap index j.
+func (a *HeapAdapter[T]) Less(i, j int) bool {
+	return a.collection.CompareAt(a.position(i), a.position(j)) < 0
+}
+
+// Swap exchanges the values at heap indexes i and j.
+func (a *HeapAdapter[T]) Swap(i, j int) {
+	a.collection.Swap(a.position(i), a.position(j))
+}
+
+// Push is called by the heap package to add a value at heap index Len().
+//
+// Panics if x is not of type T.
+func (a *HeapAdapter[T]) Push(x any) {
+	a.collection.Push(x.(T))
+}
+
+// Pop is called by the heap package to remove the value at heap index Len()-1.
+func (a *HeapAdapter[T]) Pop() any {
+	return a.collection.Pop()
+}
+
+// position converts a heap index to a collection position.
+//
+// The heap package pushes and pops at the end of its index range,
+// whereas the wrapped collection pushes and pops at position 0,
+// so heap indexes run in the opposite direction to positions.
+func (a *HeapAdapter[T]) position(index int) int {
+	return a.collection.Count() - 1 - index
+}
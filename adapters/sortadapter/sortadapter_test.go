@@ -0,0 +1,175 @@
+package sortadapter
+
+import (
+	"container/heap"
+	"sort"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/lists/arraylist"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/queues/ringbuffer"
+	"github.com/fireflycons/generic_collections/stacks/stack"
+	"github.com/stretchr/testify/require"
+)
+
+const elementCount = 1000
+
+func TestSortAdapter(t *testing.T) {
+
+	seed := int64(2163)
+	data := util.CreateSingleIntListData(elementCount, &seed)
+	expected := make([]int, len(data))
+	copy(expected, data)
+	sort.Ints(expected)
+	descending := make([]int, len(expected))
+	copy(descending, expected)
+	util.Reverse(descending)
+
+	t.Run("Sort stack", func(t *testing.T) {
+		s := stack.New[int]()
+		for _, v := range data {
+			s.Push(v)
+		}
+
+		sort.Sort(Wrap[int](s))
+		require.Equal(t, expected, s.ToSlice())
+		require.Equal(t, expected[0], s.Peek())
+	})
+
+	t.Run("Sort queue", func(t *testing.T) {
+		q := queue.New[int]()
+
+		// Move the head away from the start of the buffer
+		for i := 0; i < 10; i++ {
+			q.Enqueue(i)
+		}
+		for i := 0; i < 10; i++ {
+			q.Dequeue()
+		}
+
+		q.AddRange(data)
+		sort.Sort(Wrap[int](q))
+		require.Equal(t, expected, q.ToSlice())
+	})
+
+	t.Run("Sort ring buffer", func(t *testing.T) {
+		buf := ringbuffer.New[int](elementCount)
+
+		// Wrap the buffer
+		for i := 0; i < 10; i++ {
+			buf.Add(i)
+		}
+		buf.AddRange(data)
+
+		sort.Sort(Wrap[int](buf))
+		require.Equal(t, expected, buf.ToSlice())
+	})
+
+	t.Run("Sort descending with sort.Reverse", func(t *testing.T) {
+		s := stack.New[int]()
+		s.AddRange(data)
+
+		sort.Sort(sort.Reverse(Wrap[int](s)))
+		require.Equal(t, descending, s.ToSlice())
+	})
+
+	t.Run("Sort uses collection comparer", func(t *testing.T) {
+		s := stack.New(stack.WithComparer(func(a, b int) int { return b - a }))
+		s.AddRange(data)
+
+		sort.Sort(Wrap[int](s))
+		require.Equal(t, descending, s.ToSlice())
+	})
+}
+
+func TestSortRecording(t *testing.T) {
+
+	seed := int64(2163)
+	data := util.CreateSingleIntListData(elementCount, &seed)
+	expected := make([]int, len(data))
+	copy(expected, data)
+	sort.Ints(expected)
+
+	type recordable interface {
+		collections.Indexable[int]
+		StartRecording()
+		StopRecording() []ops.Op[int]
+		ApplyOps([]ops.Op[int])
+	}
+
+	constructors := map[string]func() recordable{
+		"Stack":       func() recordable { return stack.New[int]() },
+		"Queue":       func() recordable { return queue.New[int]() },
+		"Ring buffer": func() recordable { return ringbuffer.New[int](elementCount) },
+		"Array list":  func() recordable { return arraylist.New[int]() },
+	}
+
+	for name, create := range constructors {
+		t.Run(name+" logs the swaps of a sort as a single reset", func(t *testing.T) {
+			c := create()
+			c.AddRange(data)
+			c.StartRecording()
+			sort.Sort(Wrap[int](c))
+			log := c.StopRecording()
+			require.Equal(t, expected, c.ToSlice())
+
+			require.Len(t, log, elementCount+1)
+			require.Equal(t, ops.KindClear, log[0].Kind)
+
+			replayed := create()
+			replayed.AddRange([]int{-1})
+			replayed.ApplyOps(log)
+			require.Equal(t, c.ToSlice(), replayed.ToSlice())
+		})
+	}
+}
+
+func TestHeapAdapter(t *testing.T) {
+
+	seed := int64(2163)
+	data := util.CreateSingleIntListData(elementCount, &seed)
+	expected := make([]int, len(data))
+	copy(expected, data)
+	sort.Ints(expected)
+
+	t.Run("Heap sort via stack", func(t *testing.T) {
+		s := stack.New[int]()
+		s.AddRange(data)
+		h := WrapHeap[int](s)
+		heap.Init(h)
+
+		actual := make([]int, 0, elementCount)
+		for h.Len() > 0 {
+			actual = append(actual, heap.Pop(h).(int))
+		}
+
+		require.Equal(t, expected, actual)
+		require.True(t, s.IsEmpty())
+	})
+
+	t.Run("Push maintains heap order", func(t *testing.T) {
+		s := stack.New[int]()
+		h := WrapHeap[int](s)
+
+		for _, v := range data {
+			heap.Push(h, v)
+		}
+
+		require.Equal(t, elementCount, s.Count())
+
+		actual := make([]int, 0, elementCount)
+		for h.Len() > 0 {
+			actual = append(actual, heap.Pop(h).(int))
+		}
+
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("Push of wrong type panics", func(t *testing.T) {
+		h := WrapHeap[int](stack.New[int]())
+		require.Panics(t, func() { heap.Push(h, "string") })
+	})
+}
@@ -0,0 +1,64 @@
+/*
+Package caches defines types common to the cache implementations. Caches hold a bounded
+number of key/value pairs, evicting or expiring entries to make room for new ones.
+Sub-packages contain implementations.
+*/
+package caches
+
+import "fmt"
+
+// LoaderFunc is the signature of a function that loads the value for a key
+// on a cache miss, e.g. from a database or remote service.
+type LoaderFunc[K any, V any] func(key K) (V, error)
+
+// BatchLoaderFunc is the signature of a function that loads the values for several keys at once,
+// returning them in the same order as the keys.
+type BatchLoaderFunc[K any, V any] func(keys []K) ([]V, error)
+
+// WriterFunc is the signature of a function that persists the value for a key to a backing store.
+type WriterFunc[K any, V any] func(key K, value V) error
+
+// DeleterFunc is the signature of a function that deletes a key from a backing store.
+type DeleterFunc[K any] func(key K) error
+
+// Stats holds the counters maintained by a cache since it was constructed or its counters were last reset.
+type Stats struct {
+	// Hits is the number of lookups that found a value.
+	Hits uint64
+
+	// Misses is the number of lookups that found no value, including those that found an expired value.
+	Misses uint64
+
+	// Loads is the number of calls made to a loader. Concurrent misses on the same key
+	// are served by a single call.
+	Loads uint64
+
+	// LoadErrors is the number of calls to a loader that returned an error.
+	LoadErrors uint64
+
+	// Evictions is the number of entries removed to make room for others.
+	Evictions uint64
+
+	// Expirations is the number of entries removed because they had outlived their time to live.
+	Expirations uint64
+}
+
+// HitRatio returns the proportion of lookups that found a value,
+// or zero if there have been no lookups.
+func (s Stats) HitRatio() float64 {
+	lookups := s.Hits + s.Misses
+
+	if lookups == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(lookups)
+}
+
+// String returns a string representation of the counters.
+func (s Stats) String() string {
+	return fmt.Sprintf(
+		"Hits: %d, Misses: %d, Loads: %d, LoadErrors: %d, Evictions: %d, Expirations: %d",
+		s.Hits, s.Misses, s.Loads, s.LoadErrors, s.Evictions, s.Expirations,
+	)
+}
@@ -0,0 +1,527 @@
+/*
+Package lru provides a cache of key/value pairs that evicts the least recently used entries.
+
+A cache may be bounded by the number of its entries, by the total weight of its values
+(e.g. their size in bytes), or both, and entries may be given a time to live after which
+they expire. The cache counts hits, misses, loads, evictions and expirations, and [Cache.GetOrLoad]
+populates the cache on a miss, ensuring that concurrent misses on the same key are served by a single load.
+*/
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fireflycons/generic_collections/caches"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// CacheOptionFunc is the signature of a function
+// for providing options to the Cache constructor.
+type CacheOptionFunc[K comparable, V any] func(*Cache[K, V])
+
+// Cache implements a least recently used cache of key/value pairs.
+//
+// Unlike the collections, a cache is always thread-safe, as [Cache.GetOrLoad]
+// exists to coordinate concurrent callers.
+type Cache[K comparable, V any] struct {
+	lock       sync.Mutex
+	entries    map[K]*entry[K, V]
+	head       *entry[K, V]
+	tail       *entry[K, V]
+	maxEntries int
+	weigher    *util.Weigher[V]
+	ttl        time.Duration
+	clock      functions.Clock
+	stats      caches.Stats
+	loads      map[K]*load[V]
+}
+
+// entry is a single key/value pair within the cache, linked in order of use
+// from the most recently used at the head to the least recently used at the tail.
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	weight  int
+	expires time.Time
+	prev    *entry[K, V]
+	next    *entry[K, V]
+}
+
+// load is a call to a loader in progress, on which concurrent callers for the same key wait.
+type load[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+
+	// Set if the key is modified while loading, so the loaded value is stale.
+	superseded bool
+}
+
+// New constructs a new, empty Cache. Without options the cache is unbounded and entries never expire,
+// so at least one of [WithMaxEntries], [WithWeigher] or [WithTTL] is normally given.
+func New[K comparable, V any](options ...CacheOptionFunc[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		entries: make(map[K]*entry[K, V]),
+		loads:   make(map[K]*load[V]),
+		clock:   functions.SystemClock,
+	}
+
+	for _, o := range options {
+		o(c)
+	}
+
+	return c
+}
+
+// Option function for New to bound the cache to the given number of entries.
+// Adding an entry to a full cache evicts the least recently used.
+//
+// Panics if maxEntries is less than 1.
+func WithMaxEntries[K comparable, V any](maxEntries int) CacheOptionFunc[K, V] {
+	if maxEntries < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxEntries"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.maxEntries = maxEntries
+	}
+}
+
+// Option function for New to weigh values, e.g. by their size in bytes, so that the cache may be
+// bounded by the total weight of its values. Adding an entry evicts the least recently used
+// until the total weight is within maxWeight. A value heavier than maxWeight is not cached at all.
+//
+//	c := lru.New(lru.WithWeigher[string, []byte](func(p []byte) int { return len(p) }, 64<<20))
+//
+// Panics if weigher is nil or maxWeight is less than 1. Put panics if weigher returns a negative weight.
+func WithWeigher[K comparable, V any](weigher func(V) int, maxWeight int) CacheOptionFunc[K, V] {
+	if weigher == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "weigher"))
+	}
+
+	if maxWeight < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxWeight"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.weigher = util.NewWeigher(weigher, maxWeight)
+	}
+}
+
+// Option function for New to expire entries the given duration after they were put.
+// Reading an entry does not extend its life. Expired entries are removed as they are found,
+// or all at once by [Cache.PurgeExpired].
+//
+// Panics if ttl is not positive.
+func WithTTL[K comparable, V any](ttl time.Duration) CacheOptionFunc[K, V] {
+	if ttl <= 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "ttl"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.ttl = ttl
+	}
+}
+
+// Option function for New to supply the clock against which entries expire.
+// Defaults to [functions.SystemClock].
+//
+// Panics if clock is nil.
+func WithClock[K comparable, V any](clock functions.Clock) CacheOptionFunc[K, V] {
+	if clock == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "clock"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.clock = clock
+	}
+}
+
+// Get returns the value for the key and true, marking it as the most recently used,
+// if the key is present and has not expired; else zero value of V and false.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+// Peek returns the value for the key and true if the key is present and has not expired;
+// else zero value of V and false. Unlike Get, it neither marks the entry as used nor updates the counters.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.entries[key]; ok && !c.expired(e, c.clock.Now()) {
+		return e.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Contains returns true if the key is present and has not expired,
+// without marking it as used.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
+// Put associates the value with the key as the most recently used entry,
+// replacing any value already associated with it, then evicts the least recently used
+// entries as required to bring the cache within its bounds.
+//
+// Returns false if the value is heavier than the maximum weight given by [WithWeigher],
+// in which case it is not cached and any existing entry for the key is removed; else true.
+func (c *Cache[K, V]) Put(key K, value V) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.put(key, value)
+}
+
+// GetOrLoad returns the value for the key if it is present and has not expired, as Get.
+// Otherwise it calls loader for the value, caches it as Put, and returns it.
+//
+// Concurrent calls for a key that is being loaded wait for that load and share its result,
+// rather than calling a loader of their own. If the loader returns an error, nothing is cached and
+// the error is returned to all those waiting. If the key is put or removed while it is being loaded,
+// the loaded value is returned but not cached, as it may be stale.
+//
+// Panics if loader is nil. If loader panics, the panic propagates to the caller that invoked it
+// and those waiting receive an error.
+func (c *Cache[K, V]) GetOrLoad(key K, loader caches.LoaderFunc[K, V]) (V, error) {
+	if loader == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "loader"))
+	}
+
+	c.lock.Lock()
+
+	if value, ok := c.get(key); ok {
+		c.lock.Unlock()
+		return value, nil
+	}
+
+	if l, ok := c.loads[key]; ok {
+		c.lock.Unlock()
+		<-l.done
+		return l.value, l.err
+	}
+
+	l := &load[V]{
+		done: make(chan struct{}),
+		err:  errors.New(messages.LOADER_PANICKED),
+	}
+
+	c.loads[key] = l
+	c.stats.Loads++
+	c.lock.Unlock()
+
+	defer func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		delete(c.loads, key)
+
+		if l.err != nil {
+			c.stats.LoadErrors++
+		} else if !l.superseded {
+			c.put(key, l.value)
+		}
+
+		close(l.done)
+	}()
+
+	// Should the loader panic, the deferred function releases waiters with the error set above.
+	l.value, l.err = loader(key)
+	return l.value, l.err
+}
+
+// Remove removes the key and its value.
+//
+// Returns true if the key was removed; false if it was not present.
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.supersede(key)
+	e, ok := c.entries[key]
+
+	if ok {
+		c.remove(e)
+	}
+
+	return ok
+}
+
+// Clear removes all entries. The counters are not reset.
+func (c *Cache[K, V]) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, l := range c.loads {
+		l.superseded = true
+	}
+
+	c.entries = make(map[K]*entry[K, V])
+	c.head = nil
+	c.tail = nil
+
+	if c.weigher != nil {
+		c.weigher.Adjust(-c.weigher.Total())
+	}
+}
+
+// PurgeExpired removes all expired entries, returning the number removed.
+func (c *Cache[K, V]) PurgeExpired() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.ttl == 0 {
+		return 0
+	}
+
+	now := c.clock.Now()
+	purged := 0
+
+	for e := c.head; e != nil; {
+		next := e.next
+
+		if c.expired(e, now) {
+			c.remove(e)
+			c.stats.Expirations++
+			purged++
+		}
+
+		e = next
+	}
+
+	return purged
+}
+
+// Count returns the number of entries in the cache,
+// including any that have expired but have not yet been removed.
+func (c *Cache[K, V]) Count() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.entries)
+}
+
+// Weight returns the total weight of the values in the cache,
+// or zero if the cache was not created [WithWeigher].
+func (c *Cache[K, V]) Weight() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.weigher == nil {
+		return 0
+	}
+
+	return c.weigher.Total()
+}
+
+// Keys returns the keys of the entries that have not expired,
+// from the most recently used to the least recently used.
+func (c *Cache[K, V]) Keys() []K {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.clock.Now()
+	keys := make([]K, 0, len(c.entries))
+
+	for e := c.head; e != nil; e = e.next {
+		if !c.expired(e, now) {
+			keys = append(keys, e.key)
+		}
+	}
+
+	return keys
+}
+
+// Stats returns a snapshot of the counters.
+func (c *Cache[K, V]) Stats() caches.Stats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.stats
+}
+
+// ResetStats sets all the counters to zero.
+func (c *Cache[K, V]) ResetStats() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.stats = caches.Stats{}
+}
+
+// String returns a string representation of the cache,
+// from the most recently used entry to the least recently used.
+func (c *Cache[K, V]) String() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var sb strings.Builder
+	sb.WriteString("Cache\n")
+
+	for e := c.head; e != nil; e = e.next {
+		if e != c.head {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(fmt.Sprintf("%v: %v", e.key, e.value))
+	}
+
+	return sb.String()
+}
+
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	e, ok := c.entries[key]
+
+	if ok && c.expired(e, c.clock.Now()) {
+		c.remove(e)
+		c.stats.Expirations++
+		ok = false
+	}
+
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+
+	c.stats.Hits++
+	c.moveToFront(e)
+	return e.value, true
+}
+
+func (c *Cache[K, V]) put(key K, value V) bool {
+	c.supersede(key)
+	weight := 0
+
+	if c.weigher != nil {
+		weight = c.weigher.Weigh(value)
+	}
+
+	e, exists := c.entries[key]
+
+	if c.weigher != nil && weight > c.weigher.Max() {
+		if exists {
+			c.remove(e)
+		}
+
+		return false
+	}
+
+	if exists {
+		e.value = value
+		c.moveToFront(e)
+	} else {
+		e = &entry[K, V]{
+			key:   key,
+			value: value,
+		}
+
+		c.entries[key] = e
+		c.link(e)
+	}
+
+	if c.weigher != nil {
+		c.weigher.Adjust(weight - e.weight)
+	}
+
+	e.weight = weight
+
+	if c.ttl > 0 {
+		e.expires = c.clock.Now().Add(c.ttl)
+	}
+
+	c.evict()
+	return true
+}
+
+// evict removes the least recently used entries until the cache is within its bounds.
+// The new entry is at the head, and is never evicted as it is known to fit by itself.
+func (c *Cache[K, V]) evict() {
+	var now time.Time
+
+	if c.ttl > 0 {
+		now = c.clock.Now()
+	}
+
+	for (c.maxEntries > 0 && len(c.entries) > c.maxEntries) || (c.weigher != nil && !c.weigher.Fits(0)) {
+		e := c.tail
+		c.remove(e)
+
+		if c.expired(e, now) {
+			c.stats.Expirations++
+		} else {
+			c.stats.Evictions++
+		}
+	}
+}
+
+// supersede marks any load in progress for the key as stale.
+func (c *Cache[K, V]) supersede(key K) {
+	if l, ok := c.loads[key]; ok {
+		l.superseded = true
+	}
+}
+
+func (c *Cache[K, V]) expired(e *entry[K, V], now time.Time) bool {
+	return c.ttl > 0 && !now.Before(e.expires)
+}
+
+func (c *Cache[K, V]) remove(e *entry[K, V]) {
+	c.unlink(e)
+	delete(c.entries, e.key)
+
+	if c.weigher != nil {
+		c.weigher.Adjust(-e.weight)
+	}
+}
+
+func (c *Cache[K, V]) moveToFront(e *entry[K, V]) {
+	if e != c.head {
+		c.unlink(e)
+		c.link(e)
+	}
+}
+
+// link adds an entry at the head of the list.
+func (c *Cache[K, V]) link(e *entry[K, V]) {
+	e.prev = nil
+	e.next = c.head
+
+	if c.head == nil {
+		c.tail = e
+	} else {
+		c.head.prev = e
+	}
+
+	c.head = e
+}
+
+// unlink removes an entry from the list.
+func (c *Cache[K, V]) unlink(e *entry[K, V]) {
+	if e.prev == nil {
+		c.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+
+	if e.next == nil {
+		c.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+
+	e.prev = nil
+	e.next = nil
+}
@@ -0,0 +1,323 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/caches"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructor(t *testing.T) {
+
+	t.Run("Invalid options panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxEntries"), func() { WithMaxEntries[int, int](0) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "weigher"), func() { WithWeigher[int, int](nil, 1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxWeight"), func() {
+			WithWeigher[int](func(int) int { return 1 }, 0)
+		})
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "ttl"), func() { WithTTL[int, int](0) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "clock"), func() { WithClock[int, int](nil) })
+	})
+
+	t.Run("Unbounded cache keeps all entries", func(t *testing.T) {
+		c := New[int, int]()
+		for i := 0; i < 1000; i++ {
+			c.Put(i, i)
+		}
+		require.Equal(t, 1000, c.Count())
+	})
+}
+
+func TestMaxEntries(t *testing.T) {
+
+	t.Run("Least recently used is evicted", func(t *testing.T) {
+		c := New(WithMaxEntries[string, int](3))
+		c.Put("a", 1)
+		c.Put("b", 2)
+		c.Put("c", 3)
+
+		// Use a, so b becomes least recently used
+		_, ok := c.Get("a")
+		require.True(t, ok)
+
+		c.Put("d", 4)
+		require.False(t, c.Contains("b"))
+		require.Equal(t, []string{"d", "a", "c"}, c.Keys())
+		require.Equal(t, uint64(1), c.Stats().Evictions)
+	})
+
+	t.Run("Replacing value does not evict", func(t *testing.T) {
+		c := New(WithMaxEntries[string, int](2))
+		c.Put("a", 1)
+		c.Put("b", 2)
+		c.Put("a", 10)
+		require.Equal(t, 2, c.Count())
+		require.Equal(t, []string{"a", "b"}, c.Keys())
+		v, _ := c.Peek("a")
+		require.Equal(t, 10, v)
+	})
+
+	t.Run("Peek does not mark as used", func(t *testing.T) {
+		c := New(WithMaxEntries[string, int](2))
+		c.Put("a", 1)
+		c.Put("b", 2)
+		c.Peek("a")
+		c.Put("c", 3)
+		require.False(t, c.Contains("a"))
+	})
+}
+
+func TestWeigher(t *testing.T) {
+
+	weigh := func(s string) int { return len(s) }
+
+	t.Run("Evicts until within weight", func(t *testing.T) {
+		c := New(WithWeigher[int](weigh, 10))
+		c.Put(1, "aaaa")
+		c.Put(2, "bbbb")
+		require.Equal(t, 8, c.Weight())
+
+		c.Put(3, "cccccc")
+		require.Equal(t, []int{3, 2}, c.Keys())
+		require.Equal(t, 10, c.Weight())
+	})
+
+	t.Run("Replacing value reweighs", func(t *testing.T) {
+		c := New(WithWeigher[int](weigh, 10))
+		c.Put(1, "aaaa")
+		c.Put(1, "a")
+		require.Equal(t, 1, c.Weight())
+	})
+
+	t.Run("Value heavier than maximum is not cached", func(t *testing.T) {
+		c := New(WithWeigher[int](weigh, 3))
+		require.True(t, c.Put(1, "aaa"))
+		require.False(t, c.Put(1, "aaaa"))
+		require.False(t, c.Contains(1))
+		require.Equal(t, 0, c.Weight())
+	})
+
+	t.Run("Combined with max entries", func(t *testing.T) {
+		c := New(WithWeigher[int](weigh, 100), WithMaxEntries[int, string](2))
+		c.Put(1, "a")
+		c.Put(2, "b")
+		c.Put(3, "c")
+		require.Equal(t, []int{3, 2}, c.Keys())
+		require.Equal(t, 2, c.Weight())
+	})
+
+	t.Run("Negative weight panics", func(t *testing.T) {
+		c := New(WithWeigher[int](func(string) int { return -1 }, 3))
+		require.PanicsWithValue(t, messages.WEIGHT_NEGATIVE, func() { c.Put(1, "a") })
+	})
+}
+
+func TestTTL(t *testing.T) {
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := functions.ClockFunc(func() time.Time { return now })
+
+	t.Run("Entry expires", func(t *testing.T) {
+		c := New(WithTTL[string, int](time.Minute), WithClock[string, int](clock))
+		c.Put("a", 1)
+
+		now = now.Add(59 * time.Second)
+		_, ok := c.Get("a")
+		require.True(t, ok)
+
+		now = now.Add(time.Second)
+		_, ok = c.Get("a")
+		require.False(t, ok)
+		require.Equal(t, 0, c.Count())
+
+		stats := c.Stats()
+		require.Equal(t, uint64(1), stats.Hits)
+		require.Equal(t, uint64(1), stats.Misses)
+		require.Equal(t, uint64(1), stats.Expirations)
+	})
+
+	t.Run("Put renews expiry", func(t *testing.T) {
+		c := New(WithTTL[string, int](time.Minute), WithClock[string, int](clock))
+		c.Put("a", 1)
+		now = now.Add(30 * time.Second)
+		c.Put("a", 2)
+		now = now.Add(45 * time.Second)
+		require.True(t, c.Contains("a"))
+	})
+
+	t.Run("PurgeExpired removes expired entries", func(t *testing.T) {
+		c := New(WithTTL[string, int](time.Minute), WithClock[string, int](clock))
+		c.Put("a", 1)
+		now = now.Add(30 * time.Second)
+		c.Put("b", 2)
+		now = now.Add(30 * time.Second)
+
+		require.Equal(t, []string{"b"}, c.Keys())
+		require.Equal(t, 2, c.Count())
+		require.Equal(t, 1, c.PurgeExpired())
+		require.Equal(t, 1, c.Count())
+	})
+
+	t.Run("Expired entry is counted as expiration when evicted", func(t *testing.T) {
+		c := New(WithTTL[string, int](time.Minute), WithClock[string, int](clock), WithMaxEntries[string, int](1))
+		c.Put("a", 1)
+		now = now.Add(time.Minute)
+		c.Put("b", 2)
+
+		stats := c.Stats()
+		require.Equal(t, uint64(0), stats.Evictions)
+		require.Equal(t, uint64(1), stats.Expirations)
+	})
+}
+
+func TestRemoveClear(t *testing.T) {
+
+	t.Run("Remove", func(t *testing.T) {
+		c := New(WithWeigher[int](func(v int) int { return v }, 100))
+		c.Put(1, 10)
+		c.Put(2, 20)
+		require.True(t, c.Remove(1))
+		require.False(t, c.Remove(1))
+		require.Equal(t, 20, c.Weight())
+		require.Equal(t, []int{2}, c.Keys())
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		c := New(WithWeigher[int](func(v int) int { return v }, 100))
+		c.Put(1, 10)
+		c.Put(2, 20)
+		c.Clear()
+		require.Equal(t, 0, c.Count())
+		require.Equal(t, 0, c.Weight())
+		require.Empty(t, c.Keys())
+	})
+}
+
+func TestGetOrLoad(t *testing.T) {
+
+	t.Run("Miss loads and caches value", func(t *testing.T) {
+		c := New[string, int]()
+		v, err := c.GetOrLoad("a", func(string) (int, error) { return 1, nil })
+		require.NoError(t, err)
+		require.Equal(t, 1, v)
+
+		v, err = c.GetOrLoad("a", func(string) (int, error) { return 2, nil })
+		require.NoError(t, err)
+		require.Equal(t, 1, v)
+
+		stats := c.Stats()
+		require.Equal(t, uint64(1), stats.Hits)
+		require.Equal(t, uint64(1), stats.Misses)
+		require.Equal(t, uint64(1), stats.Loads)
+		require.Equal(t, 0.5, stats.HitRatio())
+	})
+
+	t.Run("Error is returned and not cached", func(t *testing.T) {
+		c := New[string, int]()
+		loadErr := errors.New("unavailable")
+		_, err := c.GetOrLoad("a", func(string) (int, error) { return 0, loadErr })
+		require.ErrorIs(t, err, loadErr)
+		require.False(t, c.Contains("a"))
+		require.Equal(t, uint64(1), c.Stats().LoadErrors)
+	})
+
+	t.Run("Concurrent misses share a single load", func(t *testing.T) {
+		c := New[string, int]()
+		var calls int32
+		release := make(chan struct{})
+		loader := func(string) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return 42, nil
+		}
+
+		const callers = 10
+		wg := sync.WaitGroup{}
+		results := make([]int, callers)
+
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = c.GetOrLoad("a", loader)
+			}(i)
+		}
+
+		// Wait until all callers have missed before the load completes.
+		require.Eventually(t, func() bool { return c.Stats().Misses == callers }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, int32(1), calls)
+		for _, r := range results {
+			require.Equal(t, 42, r)
+		}
+		require.Equal(t, uint64(1), c.Stats().Loads)
+	})
+
+	t.Run("Value put during load is not overwritten", func(t *testing.T) {
+		c := New[string, int]()
+		v, err := c.GetOrLoad("a", func(string) (int, error) {
+			c.Put("a", 2)
+			return 1, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, v)
+
+		v, _ = c.Get("a")
+		require.Equal(t, 2, v)
+	})
+
+	t.Run("Panicking loader releases waiters", func(t *testing.T) {
+		c := New[string, int]()
+		started := make(chan struct{})
+		release := make(chan struct{})
+		waiterErr := make(chan error)
+
+		go func() {
+			defer func() { recover() }()
+			c.GetOrLoad("a", func(string) (int, error) {
+				close(started)
+				<-release
+				panic("boom")
+			})
+		}()
+
+		<-started
+		go func() {
+			_, err := c.GetOrLoad("a", func(string) (int, error) { return 1, nil })
+			waiterErr <- err
+		}()
+
+		require.Eventually(t, func() bool { return c.Stats().Misses == 2 }, time.Second, time.Millisecond)
+		close(release)
+		require.EqualError(t, <-waiterErr, messages.LOADER_PANICKED)
+		require.False(t, c.Contains("a"))
+	})
+
+	t.Run("Nil loader panics", func(t *testing.T) {
+		c := New[string, int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "loader"), func() { c.GetOrLoad("a", nil) })
+	})
+}
+
+func TestStats(t *testing.T) {
+
+	c := New[int, int]()
+	c.Get(1)
+	c.Put(1, 1)
+	c.Get(1)
+	require.Equal(t, caches.Stats{Hits: 1, Misses: 1}, c.Stats())
+	require.Equal(t, "Hits: 1, Misses: 1, Loads: 0, LoadErrors: 0, Evictions: 0, Expirations: 0", c.Stats().String())
+
+	c.ResetStats()
+	require.Equal(t, caches.Stats{}, c.Stats())
+	require.Equal(t, 0.0, c.Stats().HitRatio())
+}
@@ -0,0 +1,580 @@
+/*
+Package readthrough provides a cache that sits in front of a backing store such as a database or remote service.
+
+On a miss, [Cache.Get] loads the value from the store with a loader function and caches it (read-through),
+and if a writer function is given, [Cache.Put] persists the value to the store before caching it (write-through).
+Entries may be given a time to live, after which they are reloaded, and optionally a further window during which
+the stale value continues to be served while it is reloaded in the background (stale-while-revalidate).
+Stale entries may also be refreshed in bulk with a batch loader.
+
+Entries are held in a [hashmap.HashMap], so keys may be of any type that HashMap supports.
+*/
+package readthrough
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fireflycons/generic_collections/caches"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/maps/hashmap"
+)
+
+// CacheOptionFunc is the signature of a function
+// for providing options to the Cache constructor.
+type CacheOptionFunc[K any, V any] func(*Cache[K, V])
+
+// Cache implements a read-through, optionally write-through, cache of key/value pairs over a backing store.
+//
+// A cache is always thread-safe, as concurrent misses on the same key are served by a single load.
+type Cache[K any, V any] struct {
+	lock        sync.Mutex
+	writeLock   sync.Mutex
+	entries     *hashmap.HashMap[K, *item[V]]
+	loads       *hashmap.HashMap[K, *load[V]]
+	hasher      functions.HashFunc[K]
+	compare     functions.ComparerFunc[K]
+	loader      caches.LoaderFunc[K, V]
+	batchLoader caches.BatchLoaderFunc[K, V]
+	writer      caches.WriterFunc[K, V]
+	deleter     caches.DeleterFunc[K]
+	onError     func(K, error)
+	ttl         time.Duration
+	staleWindow time.Duration
+	clock       functions.Clock
+	stats       caches.Stats
+	background  sync.WaitGroup
+}
+
+// item is a cached value and the time at which it was loaded or put.
+type item[V any] struct {
+	value  V
+	loaded time.Time
+}
+
+// load is a call to a loader in progress, on which concurrent callers for the same key wait.
+type load[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+
+	// Set if the key is modified while loading, so the loaded value is stale.
+	superseded bool
+}
+
+// freshness is the state of a cached value relative to its time to live.
+type freshness int
+
+const (
+	fresh freshness = iota
+	stale
+	expired
+)
+
+// New constructs a new, empty Cache that loads values on a miss with loader.
+//
+// Panics if loader is nil, or if no hasher is given and there is no default hash function for the key type.
+func New[K any, V any](loader caches.LoaderFunc[K, V], options ...CacheOptionFunc[K, V]) *Cache[K, V] {
+	if loader == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "loader"))
+	}
+
+	c := &Cache[K, V]{
+		loader: loader,
+		clock:  functions.SystemClock,
+	}
+
+	for _, o := range options {
+		o(c)
+	}
+
+	c.entries = hashmap.New(mapOptions[K, *item[V]](c.hasher, c.compare)...)
+	c.loads = hashmap.New(mapOptions[K, *load[V]](c.hasher, c.compare)...)
+	return c
+}
+
+// Option function for New to provide a hash function for keys of type K.
+// Required if the key type is not numeric, bool, pointer, string or time.Time.
+func WithHasher[K any, V any](hasher functions.HashFunc[K]) CacheOptionFunc[K, V] {
+	if hasher == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "hasher"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.hasher = hasher
+	}
+}
+
+// Option function for New to provide a comparer function for keys of type K.
+// Required if the key type is not numeric, bool, pointer or string.
+func WithComparer[K any, V any](comparer functions.ComparerFunc[K]) CacheOptionFunc[K, V] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+
+	return func(c *Cache[K, V]) {
+		c.compare = comparer
+	}
+}
+
+// Option function for New to persist values to the backing store as they are put, making the cache write-through.
+// Without a writer, Put only updates the cache.
+//
+// Panics if writer is nil.
+func WithWriter[K any, V any](writer caches.WriterFunc[K, V]) CacheOptionFunc[K, V] {
+	if writer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "writer"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.writer = writer
+	}
+}
+
+// Option function for New to delete keys from the backing store as they are removed.
+// Without a deleter, Remove only updates the cache.
+//
+// Panics if deleter is nil.
+func WithDeleter[K any, V any](deleter caches.DeleterFunc[K]) CacheOptionFunc[K, V] {
+	if deleter == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "deleter"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.deleter = deleter
+	}
+}
+
+// Option function for New to load several keys with one call to the backing store
+// when entries are refreshed by [Cache.Refresh] or [Cache.RefreshStale].
+// Without a batch loader, each key is loaded in turn with the loader.
+//
+// Panics if batchLoader is nil.
+func WithBatchLoader[K any, V any](batchLoader caches.BatchLoaderFunc[K, V]) CacheOptionFunc[K, V] {
+	if batchLoader == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "batchLoader"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.batchLoader = batchLoader
+	}
+}
+
+// Option function for New to reload values the given duration after they were loaded or put.
+// By default values are cached until removed or invalidated.
+//
+// Panics if ttl is not positive.
+func WithTTL[K any, V any](ttl time.Duration) CacheOptionFunc[K, V] {
+	if ttl <= 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "ttl"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.ttl = ttl
+	}
+}
+
+// Option function for New to continue serving a value for the given duration after its time to live
+// has passed, while reloading it in the background. Callers are thus not held up by the backing store
+// for values that are still in use. Beyond the window, the value is reloaded before being returned.
+// Has no effect unless the cache is created [WithTTL].
+//
+// Panics if window is not positive.
+func WithStaleWhileRevalidate[K any, V any](window time.Duration) CacheOptionFunc[K, V] {
+	if window <= 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "window"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.staleWindow = window
+	}
+}
+
+// Option function for New to receive the errors returned by the loader when reloading
+// stale values in the background, which would otherwise only be counted in [caches.Stats].
+// The stale value is retained, and reloaded again on the next Get.
+//
+// Panics if handler is nil.
+func WithErrorHandler[K any, V any](handler func(key K, err error)) CacheOptionFunc[K, V] {
+	if handler == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "handler"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.onError = handler
+	}
+}
+
+// Option function for New to supply the clock against which values become stale.
+// Defaults to [functions.SystemClock].
+//
+// Panics if clock is nil.
+func WithClock[K any, V any](clock functions.Clock) CacheOptionFunc[K, V] {
+	if clock == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "clock"))
+	}
+
+	return func(c *Cache[K, V]) {
+		c.clock = clock
+	}
+}
+
+// Get returns the cached value for the key if it has not outlived its time to live. Otherwise the value is loaded,
+// cached and returned, unless it is within the window given by [WithStaleWhileRevalidate], in which case
+// the stale value is returned at once and reloaded in the background.
+//
+// Concurrent calls for a key that is being loaded wait for that load and share its result.
+// If the loader returns an error, nothing is cached and the error is returned.
+func (c *Cache[K, V]) Get(key K) (V, error) {
+	c.lock.Lock()
+
+	if it, ok := c.entries.Get(key); ok {
+		switch c.freshness(it, c.clock.Now()) {
+		case fresh:
+			c.stats.Hits++
+			c.lock.Unlock()
+			return it.value, nil
+
+		case stale:
+			c.stats.Hits++
+
+			if l, started := c.beginLoad(key); started {
+				c.stats.Loads++
+				c.background.Add(1)
+				go c.revalidate(key, l)
+			}
+
+			c.lock.Unlock()
+			return it.value, nil
+
+		default:
+			c.entries.Remove(key)
+			c.stats.Expirations++
+		}
+	}
+
+	c.stats.Misses++
+	l, started := c.beginLoad(key)
+
+	if started {
+		c.stats.Loads++
+	}
+
+	c.lock.Unlock()
+
+	if started {
+		c.runLoad(key, l)
+	} else {
+		<-l.done
+	}
+
+	return l.value, l.err
+}
+
+// Put persists the value for the key with the writer given by [WithWriter], if any, then caches it.
+// Puts are serialised, so the cache and the backing store receive them in the same order.
+//
+// If the writer returns an error, the cache is not updated and the error is returned.
+func (c *Cache[K, V]) Put(key K, value V) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if c.writer != nil {
+		if err := c.writer(key, value); err != nil {
+			return err
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.supersede(key)
+	c.entries.Put(key, &item[V]{value: value, loaded: c.clock.Now()})
+	return nil
+}
+
+// Remove deletes the key from the backing store with the deleter given by [WithDeleter], if any,
+// then removes it from the cache.
+//
+// If the deleter returns an error, the cache is not updated and the error is returned.
+func (c *Cache[K, V]) Remove(key K) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if c.deleter != nil {
+		if err := c.deleter(key); err != nil {
+			return err
+		}
+	}
+
+	c.Invalidate(key)
+	return nil
+}
+
+// Invalidate removes the key from the cache without affecting the backing store,
+// so that it is loaded again on the next Get.
+//
+// Returns true if the key was cached.
+func (c *Cache[K, V]) Invalidate(key K) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.supersede(key)
+	return c.entries.Remove(key)
+}
+
+// InvalidateAll removes all keys from the cache without affecting the backing store.
+func (c *Cache[K, V]) InvalidateAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.loads.ForEach(func(_ K, l *load[V]) {
+		l.superseded = true
+	})
+
+	c.entries.Clear()
+}
+
+// Refresh reloads the given keys and caches their values, whether or not they are already cached.
+// The keys are loaded with a single call to the batch loader given by [WithBatchLoader], or otherwise
+// each with the loader in turn. Keys that are already being loaded are not loaded again.
+//
+// Returns any errors returned by the loaders, joined with errors.Join. The keys for which
+// an error was returned keep their cached values, if any.
+func (c *Cache[K, V]) Refresh(keys ...K) error {
+	c.lock.Lock()
+
+	pending := make([]K, 0, len(keys))
+	started := make([]*load[V], 0, len(keys))
+
+	for _, key := range keys {
+		if l, ok := c.beginLoad(key); ok {
+			pending = append(pending, key)
+			started = append(started, l)
+		}
+	}
+
+	if c.batchLoader != nil && len(pending) > 0 {
+		c.stats.Loads++
+	} else {
+		c.stats.Loads += uint64(len(pending))
+	}
+
+	c.lock.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if c.batchLoader != nil {
+		return c.runBatchLoad(pending, started)
+	}
+
+	var errs []error
+	loaded := 0
+
+	// Should a loader panic, waiters for the keys not yet loaded are released with the error set by beginLoad.
+	defer func() {
+		if loaded < len(pending) {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+
+			for i := loaded + 1; i < len(pending); i++ {
+				c.endLoad(pending[i], started[i])
+			}
+		}
+	}()
+
+	for ; loaded < len(pending); loaded++ {
+		c.runLoad(pending[loaded], started[loaded])
+
+		if err := started[loaded].err; err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RefreshStale reloads all cached values that have outlived their time to live as [Cache.Refresh],
+// e.g. periodically, so that Get seldom has to wait for the backing store.
+// Does nothing unless the cache was created [WithTTL].
+func (c *Cache[K, V]) RefreshStale() error {
+	c.lock.Lock()
+
+	var keys []K
+
+	if c.ttl > 0 {
+		now := c.clock.Now()
+		c.entries.ForEach(func(key K, it *item[V]) {
+			if c.freshness(it, now) != fresh {
+				keys = append(keys, key)
+			}
+		})
+	}
+
+	c.lock.Unlock()
+	return c.Refresh(keys...)
+}
+
+// Wait waits for any values being reloaded in the background to be cached.
+func (c *Cache[K, V]) Wait() {
+	c.background.Wait()
+}
+
+// Count returns the number of keys in the cache, including any that are stale.
+func (c *Cache[K, V]) Count() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.entries.Count()
+}
+
+// Stats returns a snapshot of the counters. A stale value returned while it is reloaded counts as a hit.
+func (c *Cache[K, V]) Stats() caches.Stats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.stats
+}
+
+// ResetStats sets all the counters to zero.
+func (c *Cache[K, V]) ResetStats() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.stats = caches.Stats{}
+}
+
+// beginLoad registers a load for the key and returns it and true,
+// or returns the load already in progress for the key and false.
+// Must be called with the lock held.
+func (c *Cache[K, V]) beginLoad(key K) (*load[V], bool) {
+	if l, ok := c.loads.Get(key); ok {
+		return l, false
+	}
+
+	l := &load[V]{
+		done: make(chan struct{}),
+		err:  errors.New(messages.LOADER_PANICKED),
+	}
+
+	c.loads.Put(key, l)
+	return l, true
+}
+
+// runLoad calls the loader for a load begun by beginLoad and caches the result.
+func (c *Cache[K, V]) runLoad(key K, l *load[V]) {
+	// Should the loader panic, endLoad releases waiters with the error set by beginLoad.
+	defer func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if l.err != nil {
+			c.stats.LoadErrors++
+		}
+
+		c.endLoad(key, l)
+	}()
+
+	l.value, l.err = c.loader(key)
+}
+
+// runBatchLoad calls the batch loader for loads begun by beginLoad and caches the results.
+func (c *Cache[K, V]) runBatchLoad(keys []K, started []*load[V]) (err error) {
+	// Should the batch loader panic, waiters are released with the error set by beginLoad.
+	defer func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err != nil {
+			c.stats.LoadErrors++
+		}
+
+		for i, key := range keys {
+			if err != nil {
+				started[i].err = err
+			}
+
+			c.endLoad(key, started[i])
+		}
+	}()
+
+	values, err := c.batchLoader(keys)
+
+	if err == nil && len(values) != len(keys) {
+		err = fmt.Errorf(messages.BATCH_LOAD_MISMATCH_FMT, len(values), len(keys))
+	}
+
+	if err == nil {
+		for i, l := range started {
+			l.value, l.err = values[i], nil
+		}
+	}
+
+	return err
+}
+
+// endLoad caches the result of a load unless it has failed or been superseded,
+// and releases any callers waiting for it. Must be called with the lock held.
+func (c *Cache[K, V]) endLoad(key K, l *load[V]) {
+	c.loads.Remove(key)
+
+	if l.err == nil && !l.superseded {
+		c.entries.Put(key, &item[V]{value: l.value, loaded: c.clock.Now()})
+	}
+
+	close(l.done)
+}
+
+// revalidate reloads a stale value in the background.
+func (c *Cache[K, V]) revalidate(key K, l *load[V]) {
+	defer c.background.Done()
+	c.runLoad(key, l)
+
+	if l.err != nil && c.onError != nil {
+		c.onError(key, l.err)
+	}
+}
+
+// supersede marks any load in progress for the key as stale. Must be called with the lock held.
+func (c *Cache[K, V]) supersede(key K) {
+	if l, ok := c.loads.Get(key); ok {
+		l.superseded = true
+	}
+}
+
+func (c *Cache[K, V]) freshness(it *item[V], now time.Time) freshness {
+	if c.ttl == 0 {
+		return fresh
+	}
+
+	age := now.Sub(it.loaded)
+
+	switch {
+	case age < c.ttl:
+		return fresh
+	case age < c.ttl+c.staleWindow:
+		return stale
+	default:
+		return expired
+	}
+}
+
+// mapOptions returns the options for a HashMap keyed as the cache.
+func mapOptions[K any, V any](hasher functions.HashFunc[K], compare functions.ComparerFunc[K]) []hashmap.HashMapOptionFunc[K, V] {
+	var options []hashmap.HashMapOptionFunc[K, V]
+
+	if hasher != nil {
+		options = append(options, hashmap.WithHasher[K, V](hasher))
+	}
+
+	if compare != nil {
+		options = append(options, hashmap.WithComparer[K, V](compare))
+	}
+
+	return options
+}
@@ -0,0 +1,413 @@
+package readthrough
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+// store is a backing store that counts its calls.
+type store struct {
+	lock    sync.Mutex
+	values  map[string]int
+	loads   int
+	batches int
+	fail    error
+}
+
+func newStore(values map[string]int) *store {
+	return &store{values: values}
+}
+
+func (s *store) load(key string) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.loads++
+
+	if s.fail != nil {
+		return 0, s.fail
+	}
+
+	v, ok := s.values[key]
+
+	if !ok {
+		return 0, fmt.Errorf("%s not found", key)
+	}
+
+	return v, nil
+}
+
+func (s *store) loadBatch(keys []string) ([]int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.batches++
+
+	if s.fail != nil {
+		return nil, s.fail
+	}
+
+	values := make([]int, len(keys))
+
+	for i, k := range keys {
+		values[i] = s.values[k]
+	}
+
+	return values, nil
+}
+
+func (s *store) write(key string, value int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.fail != nil {
+		return s.fail
+	}
+
+	s.values[key] = value
+	return nil
+}
+
+func (s *store) delete(key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.fail != nil {
+		return s.fail
+	}
+
+	delete(s.values, key)
+	return nil
+}
+
+func (s *store) set(key string, value int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.values[key] = value
+}
+
+func TestConstructor(t *testing.T) {
+
+	t.Run("Nil arguments panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "loader"), func() { New[string, int](nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "writer"), func() { WithWriter[string, int](nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "deleter"), func() { WithDeleter[string, int](nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "batchLoader"), func() { WithBatchLoader[string, int](nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "handler"), func() { WithErrorHandler[string, int](nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "clock"), func() { WithClock[string, int](nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "hasher"), func() { WithHasher[string, int](nil) })
+		require.PanicsWithValue(t, messages.COMP_FN_NIL, func() { WithComparer[string, int](nil) })
+	})
+
+	t.Run("Durations must be positive", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "ttl"), func() { WithTTL[string, int](0) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "window"), func() { WithStaleWhileRevalidate[string, int](-1) })
+	})
+
+	t.Run("Keys of any type with hasher and comparer", func(t *testing.T) {
+		c := New(
+			func(key []string) (string, error) { return strings.Join(key, "/"), nil },
+			WithHasher[[]string, string](functions.HashSlice(func(s string) uintptr { return uintptr(len(s)) })),
+			WithComparer[[]string, string](functions.CompareSlices(strings.Compare)),
+		)
+
+		v, err := c.Get([]string{"a", "b"})
+		require.NoError(t, err)
+		require.Equal(t, "a/b", v)
+
+		c.Get([]string{"a", "b"})
+		require.Equal(t, uint64(1), c.Stats().Hits)
+	})
+}
+
+func TestReadThrough(t *testing.T) {
+
+	t.Run("Miss loads and caches", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1})
+		c := New(s.load)
+
+		for i := 0; i < 3; i++ {
+			v, err := c.Get("a")
+			require.NoError(t, err)
+			require.Equal(t, 1, v)
+		}
+
+		require.Equal(t, 1, s.loads)
+		stats := c.Stats()
+		require.Equal(t, uint64(2), stats.Hits)
+		require.Equal(t, uint64(1), stats.Misses)
+		require.Equal(t, uint64(1), stats.Loads)
+	})
+
+	t.Run("Load error is returned and not cached", func(t *testing.T) {
+		s := newStore(map[string]int{})
+		c := New(s.load)
+		_, err := c.Get("a")
+		require.EqualError(t, err, "a not found")
+		require.Equal(t, 0, c.Count())
+		require.Equal(t, uint64(1), c.Stats().LoadErrors)
+	})
+
+	t.Run("Concurrent misses share a single load", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		c := New(func(string) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return 7, nil
+		})
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v, err := c.Get("a")
+				require.NoError(t, err)
+				require.Equal(t, 7, v)
+			}()
+		}
+
+		require.Eventually(t, func() bool { return c.Stats().Misses == 5 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+		require.Equal(t, int32(1), calls)
+	})
+
+	t.Run("Invalidate forces reload", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1})
+		c := New(s.load)
+		c.Get("a")
+		s.set("a", 2)
+		require.True(t, c.Invalidate("a"))
+		require.False(t, c.Invalidate("a"))
+		v, _ := c.Get("a")
+		require.Equal(t, 2, v)
+
+		c.InvalidateAll()
+		require.Equal(t, 0, c.Count())
+	})
+}
+
+func TestWriteThrough(t *testing.T) {
+
+	t.Run("Put writes to store and caches", func(t *testing.T) {
+		s := newStore(map[string]int{})
+		c := New(s.load, WithWriter(s.write))
+		require.NoError(t, c.Put("a", 5))
+		require.Equal(t, 5, s.values["a"])
+
+		v, _ := c.Get("a")
+		require.Equal(t, 5, v)
+		require.Equal(t, 0, s.loads)
+	})
+
+	t.Run("Failed write does not update cache", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1})
+		c := New(s.load, WithWriter(s.write))
+		c.Get("a")
+		s.fail = errors.New("read only")
+		require.Error(t, c.Put("a", 2))
+		s.fail = nil
+
+		v, _ := c.Get("a")
+		require.Equal(t, 1, v)
+	})
+
+	t.Run("Put without writer only caches", func(t *testing.T) {
+		s := newStore(map[string]int{})
+		c := New(s.load)
+		require.NoError(t, c.Put("a", 5))
+		require.Empty(t, s.values)
+		v, _ := c.Get("a")
+		require.Equal(t, 5, v)
+	})
+
+	t.Run("Remove deletes from store and cache", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1})
+		c := New(s.load, WithDeleter[string, int](s.delete))
+		c.Get("a")
+		require.NoError(t, c.Remove("a"))
+		require.Empty(t, s.values)
+		_, err := c.Get("a")
+		require.Error(t, err)
+	})
+
+	t.Run("Put during load is not overwritten", func(t *testing.T) {
+		var c *Cache[string, int]
+		c = New(func(string) (int, error) {
+			c.Put("a", 2)
+			return 1, nil
+		})
+
+		v, err := c.Get("a")
+		require.NoError(t, err)
+		require.Equal(t, 1, v)
+
+		v, _ = c.Get("a")
+		require.Equal(t, 2, v)
+	})
+}
+
+func TestTTL(t *testing.T) {
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := functions.ClockFunc(func() time.Time { return now })
+
+	t.Run("Value is reloaded after ttl", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1})
+		c := New(s.load, WithTTL[string, int](time.Minute), WithClock[string, int](clock))
+		c.Get("a")
+		s.set("a", 2)
+
+		now = now.Add(59 * time.Second)
+		v, _ := c.Get("a")
+		require.Equal(t, 1, v)
+
+		now = now.Add(time.Second)
+		v, _ = c.Get("a")
+		require.Equal(t, 2, v)
+		require.Equal(t, uint64(1), c.Stats().Expirations)
+	})
+
+	t.Run("Stale value is served while revalidating", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1})
+		c := New(s.load,
+			WithTTL[string, int](time.Minute),
+			WithStaleWhileRevalidate[string, int](time.Minute),
+			WithClock[string, int](clock),
+		)
+
+		c.Get("a")
+		s.set("a", 2)
+		now = now.Add(90 * time.Second)
+
+		v, err := c.Get("a")
+		require.NoError(t, err)
+		require.Equal(t, 1, v)
+
+		c.Wait()
+		v, _ = c.Get("a")
+		require.Equal(t, 2, v)
+		require.Equal(t, 2, s.loads)
+		require.Equal(t, uint64(1), c.Stats().Misses)
+	})
+
+	t.Run("Value beyond stale window is reloaded before return", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1})
+		c := New(s.load,
+			WithTTL[string, int](time.Minute),
+			WithStaleWhileRevalidate[string, int](time.Minute),
+			WithClock[string, int](clock),
+		)
+
+		c.Get("a")
+		s.set("a", 2)
+		now = now.Add(2 * time.Minute)
+
+		v, _ := c.Get("a")
+		require.Equal(t, 2, v)
+	})
+
+	t.Run("Background error is reported and stale value kept", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1})
+		var reported error
+		c := New(s.load,
+			WithTTL[string, int](time.Minute),
+			WithStaleWhileRevalidate[string, int](time.Minute),
+			WithClock[string, int](clock),
+			WithErrorHandler[string, int](func(_ string, err error) { reported = err }),
+		)
+
+		c.Get("a")
+		s.fail = errors.New("down")
+		now = now.Add(90 * time.Second)
+
+		v, _ := c.Get("a")
+		c.Wait()
+		require.Equal(t, 1, v)
+		require.Equal(t, s.fail, reported)
+		require.Equal(t, 1, c.Count())
+		require.Equal(t, uint64(1), c.Stats().LoadErrors)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := functions.ClockFunc(func() time.Time { return now })
+
+	t.Run("Batch refresh loads keys in one call", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1, "b": 2, "c": 3})
+		c := New(s.load, WithBatchLoader(s.loadBatch))
+
+		require.NoError(t, c.Refresh("a", "b", "c"))
+		require.Equal(t, 1, s.batches)
+		require.Equal(t, 0, s.loads)
+		require.Equal(t, uint64(1), c.Stats().Loads)
+
+		v, _ := c.Get("b")
+		require.Equal(t, 2, v)
+	})
+
+	t.Run("Refresh without batch loader loads each key", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1, "b": 2})
+		c := New(s.load)
+
+		err := c.Refresh("a", "b", "x")
+		require.EqualError(t, err, "x not found")
+		require.Equal(t, 3, s.loads)
+		require.Equal(t, 2, c.Count())
+	})
+
+	t.Run("Batch error keeps cached values", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1})
+		c := New(s.load, WithBatchLoader(s.loadBatch))
+		c.Get("a")
+		s.fail = errors.New("down")
+		require.ErrorIs(t, c.Refresh("a"), s.fail)
+		s.fail = nil
+		require.Equal(t, 1, c.Count())
+		require.Equal(t, uint64(1), c.Stats().LoadErrors)
+	})
+
+	t.Run("Batch loader returning wrong number of values", func(t *testing.T) {
+		c := New(
+			func(string) (int, error) { return 0, nil },
+			WithBatchLoader(func([]string) ([]int, error) { return []int{1}, nil }),
+		)
+		require.EqualError(t, c.Refresh("a", "b"), fmt.Sprintf(messages.BATCH_LOAD_MISMATCH_FMT, 1, 2))
+		require.Equal(t, 0, c.Count())
+	})
+
+	t.Run("RefreshStale refreshes only stale keys", func(t *testing.T) {
+		s := newStore(map[string]int{"a": 1, "b": 2})
+		var refreshed []string
+		c := New(s.load,
+			WithTTL[string, int](time.Minute),
+			WithClock[string, int](clock),
+			WithBatchLoader(func(keys []string) ([]int, error) {
+				refreshed = keys
+				return s.loadBatch(keys)
+			}),
+		)
+
+		c.Get("a")
+		now = now.Add(30 * time.Second)
+		c.Get("b")
+		now = now.Add(30 * time.Second)
+
+		require.NoError(t, c.RefreshStale())
+		require.Equal(t, []string{"a"}, refreshed)
+	})
+}
@@ -0,0 +1,227 @@
+/*
+Package collectionassert provides test assertions that work on any collection in this module.
+
+Each assertion reports a failure via the given testing.TB, describing how the actual values
+differ from those expected, and returns true if it passed. Values are compared with reflect.DeepEqual.
+
+	func TestMyQueue(t *testing.T) {
+		q := queue.Of(1, 2, 3)
+		collectionassert.AssertElements(t, q, []int{1, 2, 3})
+		collectionassert.AssertInvariants(t, q)
+	}
+*/
+package collectionassert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Maximum number of differences listed in a failure message.
+const maxDiffs = 10
+
+// AssertElements asserts that the collection contains exactly the expected values
+// in the same order as returned by the collection's ToSlice method.
+func AssertElements[T any](t testing.TB, col collections.Collection[T], expected []T) bool {
+	t.Helper()
+
+	if diff := diffOrdered(expected, col.ToSlice()); diff != "" {
+		t.Errorf("%T values not as expected:\n%s", col, diff)
+		return false
+	}
+
+	return true
+}
+
+// AssertSameElements asserts that the collection contains exactly the expected values,
+// in any order. Duplicate values must occur the same number of times in both.
+func AssertSameElements[T any](t testing.TB, col collections.Collection[T], expected []T) bool {
+	t.Helper()
+
+	if diff := diffUnordered(expected, col.ToSlice()); diff != "" {
+		t.Errorf("%T values not as expected:\n%s", col, diff)
+		return false
+	}
+
+	return true
+}
+
+// AssertOrdered asserts that the values of the collection are in ascending order
+// according to the default comparer for T.
+//
+// Panics if T is not one of the types supported by the default comparer.
+func AssertOrdered[T any](t testing.TB, col collections.Collection[T]) bool {
+	t.Helper()
+	return AssertOrderedFunc(t, col, util.GetDefaultComparer[T]())
+}
+
+// AssertOrderedFunc asserts that the values of the collection are in ascending order
+// according to the given comparer. For descending order, negate the comparer.
+func AssertOrderedFunc[T any](t testing.TB, col collections.Collection[T], compare functions.ComparerFunc[T]) bool {
+	t.Helper()
+
+	values := col.ToSlice()
+
+	for i := 1; i < len(values); i++ {
+		if compare(values[i-1], values[i]) > 0 {
+			t.Errorf("%T values not ordered: values at index %d and %d are out of order: %v > %v\nvalues: %v",
+				col, i-1, i, values[i-1], values[i], values)
+			return false
+		}
+	}
+
+	return true
+}
+
+// AssertInvariants asserts that the methods of the collection agree with each other:
+//
+//   - Count and IsEmpty agree with the number of values returned by ToSlice.
+//   - The iterator, and the reverse iterator if the collection has one, yield the values of ToSlice in order.
+//     For a HashSet, whose order is undefined, the iterator need only yield the same values.
+//   - ToSliceDeep returns values equal to those of ToSlice, likewise.
+//   - Contains is true for every value.
+//
+// All invariants are checked, and each that does not hold is reported.
+func AssertInvariants[T any](t testing.TB, col collections.Collection[T]) bool {
+	t.Helper()
+
+	values := col.ToSlice()
+	ok := true
+	diffValues := diffOrdered[T]
+
+	if col.Type() == collections.COLLECTION_HASHSET {
+		diffValues = diffUnordered[T]
+	}
+
+	check := func(held bool, format string, args ...any) {
+		t.Helper()
+
+		if !held {
+			t.Errorf("%T invariant does not hold: "+format, append([]any{col}, args...)...)
+			ok = false
+		}
+	}
+
+	check(col.Count() == len(values), "Count is %d, ToSlice returned %d values", col.Count(), len(values))
+	check(col.IsEmpty() == (len(values) == 0), "IsEmpty is %t, ToSlice returned %d values", col.IsEmpty(), len(values))
+
+	diff := diffValues(values, iterate(col.Iterator()))
+	check(diff == "", "Iterator does not match ToSlice:\n%s", diff)
+
+	if r, isReverse := col.(collections.ReverseIterable[T]); isReverse {
+		diff = diffOrdered(util.Reverse(append([]T{}, values...)), iterate(r.ReverseIterator()))
+		check(diff == "", "ReverseIterator does not match ToSlice:\n%s", diff)
+	}
+
+	diff = diffValues(values, col.ToSliceDeep())
+	check(diff == "", "ToSliceDeep does not match ToSlice:\n%s", diff)
+
+	for i, v := range values {
+		check(col.Contains(v), "Contains is false for value %v at index %d", v, i)
+	}
+
+	return ok
+}
+
+func iterate[T any](iter collections.Iterator[T]) []T {
+	values := []T{}
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, e.Value())
+	}
+
+	return values
+}
+
+// diffOrdered describes the differences between two sequences of values,
+// or returns an empty string if they are equal.
+func diffOrdered[T any](expected, actual []T) string {
+	var sb strings.Builder
+	diffs := 0
+
+	if len(expected) != len(actual) {
+		fmt.Fprintf(&sb, "  expected %d values, actual %d\n", len(expected), len(actual))
+	}
+
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		switch {
+		case i >= len(actual):
+			fmt.Fprintf(&sb, "  [%d] missing: %v\n", i, expected[i])
+		case i >= len(expected):
+			fmt.Fprintf(&sb, "  [%d] extra:   %v\n", i, actual[i])
+		case !reflect.DeepEqual(expected[i], actual[i]):
+			fmt.Fprintf(&sb, "  [%d] expected: %v, actual: %v\n", i, expected[i], actual[i])
+		default:
+			continue
+		}
+
+		if diffs++; diffs == maxDiffs {
+			sb.WriteString("  ...\n")
+			break
+		}
+	}
+
+	return summarise(&sb, expected, actual)
+}
+
+// diffUnordered describes the values that are in only one of expected and actual, counting duplicates,
+// or returns an empty string if they contain the same values.
+func diffUnordered[T any](expected, actual []T) string {
+	var sb strings.Builder
+	matched := make([]bool, len(actual))
+	missing := []T{}
+
+outer:
+	for _, e := range expected {
+		for i, a := range actual {
+			if !matched[i] && reflect.DeepEqual(e, a) {
+				matched[i] = true
+				continue outer
+			}
+		}
+
+		missing = append(missing, e)
+	}
+
+	extra := []T{}
+
+	for i, a := range actual {
+		if !matched[i] {
+			extra = append(extra, a)
+		}
+	}
+
+	if len(missing) > 0 {
+		fmt.Fprintf(&sb, "  missing: %v\n", truncate(missing))
+	}
+
+	if len(extra) > 0 {
+		fmt.Fprintf(&sb, "  extra:   %v\n", truncate(extra))
+	}
+
+	return summarise(&sb, expected, actual)
+}
+
+// summarise appends the expected and actual values to a non-empty diff.
+func summarise[T any](sb *strings.Builder, expected, actual []T) string {
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	fmt.Fprintf(sb, "expected: %v\nactual:   %v", expected, actual)
+	return sb.String()
+}
+
+func truncate[T any](values []T) string {
+	if len(values) > maxDiffs {
+		return fmt.Sprintf("%v ... (%d more)", values[:maxDiffs], len(values)-maxDiffs)
+	}
+
+	return fmt.Sprint(values)
+}
@@ -0,0 +1,73 @@
+package collectionassert
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/fireflycons/generic_collections/stacks/stack"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT captures failures rather than failing the test.
+type recordingT struct {
+	testing.TB
+	messages []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+
+	t.Run("Passing assertions", func(t *testing.T) {
+		for _, col := range []collections.Collection[int]{
+			dlist.Of(1, 2, 3),
+			queue.Of(1, 2, 3),
+			orderedset.Of(3, 2, 1),
+		} {
+			require.True(t, AssertElements(t, col, []int{1, 2, 3}))
+			require.True(t, AssertSameElements(t, col, []int{3, 1, 2}))
+			require.True(t, AssertOrdered(t, col))
+			require.True(t, AssertInvariants(t, col))
+		}
+
+		require.True(t, AssertSameElements[int](t, hashset.Of(1, 2, 3), []int{3, 1, 2}))
+		require.True(t, AssertInvariants[int](t, hashset.Of(1, 2, 3)))
+		require.True(t, AssertElements[int](t, stack.Of[int](), nil))
+		require.True(t, AssertInvariants[int](t, stack.New[int]()))
+		require.True(t, AssertOrderedFunc[int](t, stack.Of(1, 2, 3), func(a, b int) int { return b - a }))
+	})
+
+	t.Run("Failing assertions report diff", func(t *testing.T) {
+		rt := &recordingT{TB: t}
+
+		require.False(t, AssertElements[int](rt, dlist.Of(1, 2, 3), []int{1, 3, 2}))
+		require.Len(t, rt.messages, 1)
+		require.Contains(t, rt.messages[0], "[1] expected: 3, actual: 2")
+
+		require.False(t, AssertSameElements[int](rt, dlist.Of(1, 2, 4), []int{1, 2, 2}))
+		require.Len(t, rt.messages, 2)
+		require.Contains(t, rt.messages[1], "missing: [2]")
+		require.Contains(t, rt.messages[1], "extra:   [4]")
+
+		require.False(t, AssertElements[int](rt, dlist.Of(1), []int{1, 2}))
+		require.Contains(t, rt.messages[2], "expected 2 values, actual 1")
+		require.Contains(t, rt.messages[2], "[1] missing: 2")
+	})
+
+	t.Run("Failing order reports index", func(t *testing.T) {
+		rt := &recordingT{TB: t}
+
+		require.False(t, AssertOrdered[int](rt, dlist.Of(1, 3, 2)))
+		require.Len(t, rt.messages, 1)
+		require.Contains(t, rt.messages[0], "values at index 1 and 2 are out of order: 3 > 2")
+	})
+}
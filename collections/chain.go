@@ -0,0 +1,486 @@
+package collections
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// Assert chain implements required interfaces.
+var _ Collection[int] = (*chain[int])(nil)
+
+// chain is a read-only view of a sequence of collections.
+type chain[T any] struct {
+	sources   []Collection[T]
+	compare   functions.ComparerFunc[T]
+	cacheLock sync.RWMutex
+	immutable []T
+	cachedOf  [][]T
+
+	local.InternalImpl
+}
+
+// Chain returns a read-only view of the given collections as a single collection,
+// whose values are those of each collection in turn, in the order each collection iterates them.
+//
+// The view does not copy the collections, so it reflects any later modification of them.
+// Count, Contains, iteration and enumeration are delegated to each collection in turn,
+// and the elements yielded belong to the underlying collections. Methods that would modify
+// the view panic, though the underlying collections may still be modified directly.
+//
+// Map, Select, SelectDeep, Clone and CloneDeep return a new chain of the results of calling the method
+// on each underlying collection. Min and Max panic if more than one of the collections
+// holds values, as the view has no comparer with which to choose between them. Use [ChainFunc] instead.
+//
+// The view is safe for concurrent use if all the underlying collections are thread-safe,
+// though it does not lock them all at once, so a concurrent modification may be
+// observed part way through an operation on the view.
+func Chain[T any](sources ...Collection[T]) Collection[T] {
+	for _, s := range sources {
+		if s == nil {
+			panic(fmt.Sprintf(messages.ARG_NIL_FMT, "sources"))
+		}
+	}
+
+	return &chain[T]{
+		sources: append([]Collection[T](nil), sources...),
+	}
+}
+
+// ChainFunc is as [Chain], using compare to choose between the minimum or maximum values
+// of the underlying collections in Min and Max.
+//
+// Panics if compare is nil.
+func ChainFunc[T any](compare functions.ComparerFunc[T], sources ...Collection[T]) Collection[T] {
+	if compare == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+
+	c := Chain(sources...).(*chain[T])
+	c.compare = compare
+	return c
+}
+
+// Add panics as the view is read-only.
+func (c *chain[T]) Add(T) bool {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// AddRange panics as the view is read-only.
+func (c *chain[T]) AddRange([]T) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// AddCollection panics as the view is read-only.
+func (c *chain[T]) AddCollection(Collection[T]) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// ReplaceAll panics as the view is read-only.
+func (c *chain[T]) ReplaceAll(Collection[T]) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Clear panics as the view is read-only.
+func (c *chain[T]) Clear() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Remove panics as the view is read-only.
+func (c *chain[T]) Remove(T) bool {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// ApplyOps panics as the view is read-only.
+func (c *chain[T]) ApplyOps([]ops.Op[T]) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// StartRecording panics as the view is read-only, so there is nothing to record.
+func (c *chain[T]) StartRecording() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// StopRecording returns nil as the view never records.
+func (c *chain[T]) StopRecording() []ops.Op[T] {
+	return nil
+}
+
+// IsRecording returns false as the view never records.
+func (c *chain[T]) IsRecording() bool {
+	return false
+}
+
+// Contains returns true if the value is present in any of the underlying collections.
+func (c *chain[T]) Contains(value T) bool {
+	for _, s := range c.sources {
+		if s.Contains(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Count returns the total number of values in the underlying collections.
+func (c *chain[T]) Count() int {
+	count := 0
+
+	for _, s := range c.sources {
+		count += s.Count()
+	}
+
+	return count
+}
+
+// IsEmpty returns true if all the underlying collections are empty.
+func (c *chain[T]) IsEmpty() bool {
+	for _, s := range c.sources {
+		if !s.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ToSlice returns the values of the underlying collections in turn as a slice.
+func (c *chain[T]) ToSlice() []T {
+	return c.concat(Collection[T].ToSlice)
+}
+
+// ToSliceDeep returns the values of the underlying collections in turn as a slice,
+// each copied by the deep copy function of its collection.
+func (c *chain[T]) ToSliceDeep() []T {
+	return c.concat(Collection[T].ToSliceDeep)
+}
+
+// ToImmutableSlice returns the values of the underlying collections in turn as a slice.
+//
+// The slice is recomputed only when the immutable slice of any underlying collection has changed,
+// so it is shared by all callers and must not be modified.
+func (c *chain[T]) ToImmutableSlice() []T {
+	parts := make([][]T, len(c.sources))
+
+	for i, s := range c.sources {
+		parts[i] = s.ToImmutableSlice()
+	}
+
+	// A cached slice is served under the read lock, so that readers do not block one another.
+	if immutable, ok := c.cachedSlice(parts); ok {
+		return immutable
+	}
+
+	c.cacheLock.Lock()
+	defer c.cacheLock.Unlock()
+
+	if c.immutable != nil && sameSlices(parts, c.cachedOf) {
+		return c.immutable
+	}
+
+	size := 0
+
+	for _, p := range parts {
+		size += len(p)
+	}
+
+	c.immutable = make([]T, 0, size)
+
+	for _, p := range parts {
+		c.immutable = append(c.immutable, p...)
+	}
+
+	c.cachedOf = parts
+	return c.immutable
+}
+
+// cachedSlice returns the cached slice and true if it was built from the given parts.
+func (c *chain[T]) cachedSlice(parts [][]T) ([]T, bool) {
+	c.cacheLock.RLock()
+	defer c.cacheLock.RUnlock()
+
+	if c.immutable != nil && sameSlices(parts, c.cachedOf) {
+		return c.immutable, true
+	}
+
+	return nil, false
+}
+
+// Type returns the type of this collection.
+func (*chain[T]) Type() CollectionType {
+	return COLLECTION_CHAIN
+}
+
+// Any returns true for the first value in any of the underlying collections for which the predicate returns true.
+func (c *chain[T]) Any(predicate functions.PredicateFunc[T]) bool {
+	for _, s := range c.sources {
+		if s.Any(predicate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All returns true if the predicate returns true for all values in the underlying collections.
+func (c *chain[T]) All(predicate functions.PredicateFunc[T]) bool {
+	for _, s := range c.sources {
+		if !s.All(predicate) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Find returns the first element of the underlying collections in turn for which the predicate returns true;
+// else nil.
+func (c *chain[T]) Find(predicate functions.PredicateFunc[T]) Element[T] {
+	for _, s := range c.sources {
+		if e := s.Find(predicate); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// FindAll returns all elements of the underlying collections in turn for which the predicate returns true.
+func (c *chain[T]) FindAll(predicate functions.PredicateFunc[T]) []Element[T] {
+	found := []Element[T]{}
+
+	for _, s := range c.sources {
+		found = append(found, s.FindAll(predicate)...)
+	}
+
+	return found
+}
+
+// ForEach calls f for each element of the underlying collections in turn.
+func (c *chain[T]) ForEach(f func(Element[T])) {
+	for _, s := range c.sources {
+		s.ForEach(f)
+	}
+}
+
+// TryForEach calls f for each value of the underlying collections in turn, stopping at the first error
+// returned by f, which is returned. Returns nil if f returned no error.
+func (c *chain[T]) TryForEach(f func(T) error) error {
+	for _, s := range c.sources {
+		if err := s.TryForEach(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value of the underlying collections in turn, continuing after any error
+// returned by f. Returns all errors returned by f joined with errors.Join, or nil if there were none.
+func (c *chain[T]) TryForEachAll(f func(T) error) error {
+	var errs []error
+
+	for _, s := range c.sources {
+		if err := s.TryForEachAll(f); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Min returns the least of the minimum values of the underlying collections.
+//
+// Panics if all the collections are empty, or if more than one holds values
+// and the view was not created by [ChainFunc].
+func (c *chain[T]) Min() T {
+	return c.extreme(Collection[T].Min, -1)
+}
+
+// Max returns the greatest of the maximum values of the underlying collections.
+//
+// Panics if all the collections are empty, or if more than one holds values
+// and the view was not created by [ChainFunc].
+func (c *chain[T]) Max() T {
+	return c.extreme(Collection[T].Max, 1)
+}
+
+// Map returns a new chain of the results of calling Map with f on each underlying collection.
+func (c *chain[T]) Map(f func(T) T) Collection[T] {
+	return c.derive(func(s Collection[T]) Collection[T] {
+		return s.Map(f)
+	})
+}
+
+// Select returns a new chain of the results of calling Select with the predicate on each underlying collection.
+func (c *chain[T]) Select(predicate functions.PredicateFunc[T]) Collection[T] {
+	return c.derive(func(s Collection[T]) Collection[T] {
+		return s.Select(predicate)
+	})
+}
+
+// SelectDeep returns a new chain of the results of calling SelectDeep with the predicate on each underlying collection.
+func (c *chain[T]) SelectDeep(predicate functions.PredicateFunc[T]) Collection[T] {
+	return c.derive(func(s Collection[T]) Collection[T] {
+		return s.SelectDeep(predicate)
+	})
+}
+
+// Clone returns a new chain of the results of calling Clone on each underlying collection.
+func (c *chain[T]) Clone() Collection[T] {
+	return c.derive(Collection[T].Clone)
+}
+
+// CloneDeep returns a new chain of the results of calling CloneDeep on each underlying collection.
+func (c *chain[T]) CloneDeep() Collection[T] {
+	return c.derive(Collection[T].CloneDeep)
+}
+
+// CopyTo copies the values of the underlying collections in turn for which the predicate is true
+// into the given collection, each copied by the deep copy function of its collection.
+func (c *chain[T]) CopyTo(dest Collection[T], predicate functions.PredicateFunc[T]) {
+	for _, s := range c.sources {
+		s.CopyTo(dest, predicate)
+	}
+}
+
+// Iterator returns an iterator that walks each of the underlying collections in turn.
+func (c *chain[T]) Iterator() Iterator[T] {
+	return c.iterator(Collection[T].Iterator)
+}
+
+// TakeWhile returns an iterator that walks each of the underlying collections in turn,
+// returning only those elements for which predicate returns true.
+func (c *chain[T]) TakeWhile(predicate functions.PredicateFunc[T]) Iterator[T] {
+	return c.iterator(func(s Collection[T]) Iterator[T] {
+		return s.TakeWhile(predicate)
+	})
+}
+
+// SampleIterator returns an iterator that yields n values chosen at random from the underlying collections,
+// without replacement and in random order. If the collections hold no more than n values in total, all are yielded.
+//
+// Panics if n is negative.
+func (c *chain[T]) SampleIterator(n int, r *rand.Rand) Iterator[T] {
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	return &chainSampleIterator[T]{
+		chain: c,
+		size:  n,
+		rand:  r,
+	}
+}
+
+// SnapshotIterator returns an iterator that walks a snapshot of each of the underlying collections in turn,
+// taken from each collection when this method is called, so that the collections may be modified while iterating.
+func (c *chain[T]) SnapshotIterator() Iterator[T] {
+	snapshots := make([]Iterator[T], len(c.sources))
+
+	for i, s := range c.sources {
+		snapshots[i] = s.SnapshotIterator()
+	}
+
+	iter := &chainIterator[T]{chain: c}
+	iter.next = func(Collection[T]) Iterator[T] { return snapshots[iter.index] }
+	return iter
+}
+
+// String returns a string representation of the view.
+func (c *chain[T]) String() string {
+	values := c.ToSlice()
+	strs := make([]string, len(values))
+
+	for i, v := range values {
+		strs[i] = fmt.Sprint(v)
+	}
+
+	return "Chain\n" + strings.Join(strs, ", ")
+}
+
+func (c *chain[T]) concat(f func(Collection[T]) []T) []T {
+	values := make([]T, 0, c.Count())
+
+	for _, s := range c.sources {
+		values = append(values, f(s)...)
+	}
+
+	return values
+}
+
+func (c *chain[T]) derive(f func(Collection[T]) Collection[T]) Collection[T] {
+	sources := make([]Collection[T], len(c.sources))
+
+	for i, s := range c.sources {
+		sources[i] = f(s)
+	}
+
+	return &chain[T]{
+		sources: sources,
+		compare: c.compare,
+	}
+}
+
+// extreme returns the result of f for the non-empty collections, choosing between them
+// with the comparer by the sign of the result.
+func (c *chain[T]) extreme(f func(Collection[T]) T, sign int) T {
+	var result T
+	found := false
+
+	for _, s := range c.sources {
+		if s.IsEmpty() {
+			continue
+		}
+
+		v := f(s)
+
+		if !found {
+			result, found = v, true
+			continue
+		}
+
+		if c.compare == nil {
+			panic(messages.COMP_FN_NIL)
+		}
+
+		if c.compare(v, result)*sign > 0 {
+			result = v
+		}
+	}
+
+	if !found {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return result
+}
+
+func (c *chain[T]) iterator(f func(Collection[T]) Iterator[T]) Iterator[T] {
+	return &chainIterator[T]{
+		chain: c,
+		next:  f,
+	}
+}
+
+// sameSlices returns true if each slice in a is the same slice as that at the same position in b.
+func sameSlices[T any](a, b [][]T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if len(a[i]) != len(b[i]) || (len(a[i]) > 0 && &a[i][0] != &b[i][0]) {
+			return false
+		}
+	}
+
+	return true
+}
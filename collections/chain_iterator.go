@@ -0,0 +1,119 @@
+package collections
+
+import (
+	"math/rand"
+
+	"github.com/fireflycons/generic_collections/internal/local"
+)
+
+// chainIterator walks an iterator of each of the collections of a chain in turn.
+type chainIterator[T any] struct {
+	chain   *chain[T]
+	next    func(Collection[T]) Iterator[T]
+	index   int
+	current Iterator[T]
+
+	local.InternalImpl
+}
+
+// Start begins iteration at the first element of the first non-empty collection
+// and returns that element, which will be nil if all the collections are empty.
+func (i *chainIterator[T]) Start() Element[T] {
+	i.index = -1
+	i.current = nil
+	return i.advance()
+}
+
+// Next returns the next element, moving to the next collection at the end of each collection,
+// which will be nil if the end of the last collection has been reached.
+func (i *chainIterator[T]) Next() Element[T] {
+	if i.current == nil {
+		return nil
+	}
+
+	if e := i.current.Next(); e != nil {
+		return e
+	}
+
+	return i.advance()
+}
+
+// advance starts iteration of each collection after the current one until one yields an element.
+func (i *chainIterator[T]) advance() Element[T] {
+	for i.index++; i.index < len(i.chain.sources); i.index++ {
+		i.current = i.next(i.chain.sources[i.index])
+
+		if e := i.current.Start(); e != nil {
+			return e
+		}
+	}
+
+	i.current = nil
+	return nil
+}
+
+// chainSampleIterator yields a random sample of the elements of a chain.
+type chainSampleIterator[T any] struct {
+	chain  *chain[T]
+	size   int
+	rand   *rand.Rand
+	sample []Element[T]
+	index  int
+
+	local.InternalImpl
+}
+
+// Start draws a new sample by reservoir sampling over the collections of the chain,
+// and returns its first element, which will be nil if the sample is empty.
+func (i *chainSampleIterator[T]) Start() Element[T] {
+	i.sample = i.sample[:0]
+	i.index = 0
+
+	if i.size == 0 {
+		return nil
+	}
+
+	iter := i.chain.Iterator()
+	seen := 0
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if seen < i.size {
+			i.sample = append(i.sample, e)
+		} else if j := i.intn(seen + 1); j < i.size {
+			i.sample[j] = e
+		}
+
+		seen++
+	}
+
+	// The reservoir is filled in chain order, so shuffle it.
+	for j := len(i.sample) - 1; j > 0; j-- {
+		k := i.intn(j + 1)
+		i.sample[j], i.sample[k] = i.sample[k], i.sample[j]
+	}
+
+	return i.current()
+}
+
+// Next returns the next element of the sample,
+// which will be nil if the end has been reached.
+func (i *chainSampleIterator[T]) Next() Element[T] {
+	i.index++
+	return i.current()
+}
+
+func (i *chainSampleIterator[T]) current() Element[T] {
+	if i.index >= len(i.sample) {
+		return nil
+	}
+
+	return i.sample[i.index]
+}
+
+func (i *chainSampleIterator[T]) intn(n int) int {
+	if i.rand == nil {
+		return rand.Intn(n)
+	}
+
+	return i.rand.Intn(n)
+}
@@ -0,0 +1,158 @@
+package collections_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/queues/ringbuffer"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+
+	t.Run("Iterates each collection in turn", func(t *testing.T) {
+		c := collections.Chain[int](dlist.Of(1, 2), dlist.New[int](), ringbuffer.Of(3, 4))
+
+		var values []int
+		iter := c.Iterator()
+
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+		}
+
+		require.Equal(t, []int{1, 2, 3, 4}, values)
+		require.Equal(t, []int{1, 2, 3, 4}, c.ToSlice())
+		require.Equal(t, 4, c.Count())
+		require.Equal(t, collections.COLLECTION_CHAIN, c.Type())
+	})
+
+	t.Run("Reflects modification of the underlying collections", func(t *testing.T) {
+		recent := ringbuffer.New[int](2)
+		archive := dlist.Of(1, 2)
+		c := collections.Chain[int](archive, recent)
+
+		require.False(t, c.Contains(3))
+		immutable := c.ToImmutableSlice()
+		require.Equal(t, []int{1, 2}, immutable)
+		require.Same(t, &immutable[0], &c.ToImmutableSlice()[0])
+
+		recent.Add(3)
+		require.True(t, c.Contains(3))
+		require.Equal(t, 3, c.Count())
+		require.Equal(t, []int{1, 2, 3}, c.ToImmutableSlice())
+	})
+
+	t.Run("Cached slice is served while readers hold the locks of the underlying collections", func(t *testing.T) {
+		first := dlist.Of(1, 2)
+		second := dlist.OfWith([]dlist.DListOptionFunc[int]{dlist.WithThreadSafe[int]()}, 3, 4)
+		c := collections.Chain[int](first, second)
+		cached := c.ToImmutableSlice()
+
+		lock := util.GetLock[int](second)
+		lock.RLock()
+		defer lock.RUnlock()
+		done := make(chan []int)
+
+		go func() {
+			done <- c.ToImmutableSlice()
+		}()
+
+		select {
+		case slc := <-done:
+			require.Same(t, &cached[0], &slc[0])
+		case <-time.After(time.Second):
+			require.Fail(t, "ToImmutableSlice blocked on a read-locked collection")
+		}
+	})
+
+	t.Run("SnapshotIterator tolerates modification of the underlying collections", func(t *testing.T) {
+		first := dlist.Of(1, 2)
+		second := ringbuffer.Of(3, 4)
+		c := collections.Chain[int](first, dlist.New[int](), second)
+
+		var values []int
+		iter := c.SnapshotIterator()
+
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+			first.Clear()
+			second.Add(5)
+		}
+
+		require.Equal(t, []int{1, 2, 3, 4}, values)
+		require.Equal(t, []int{5, 5}, c.ToSlice(), "Ring buffer should hold the last two values added")
+	})
+
+	t.Run("Empty chain", func(t *testing.T) {
+		c := collections.Chain[int]()
+
+		require.True(t, c.IsEmpty())
+		require.Nil(t, c.Iterator().Start())
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { c.Min() })
+	})
+
+	t.Run("Is read-only", func(t *testing.T) {
+		c := collections.Chain[int](dlist.Of(1))
+
+		require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { c.Add(2) })
+		require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { c.Remove(1) })
+		require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { c.Clear() })
+		require.False(t, c.IsRecording())
+	})
+
+	t.Run("Enumerates each collection in turn", func(t *testing.T) {
+		c := collections.Chain[int](dlist.Of(1, 2), hashset.Of(3))
+
+		require.True(t, c.Any(func(v int) bool { return v == 3 }))
+		require.True(t, c.All(func(v int) bool { return v > 0 }))
+		require.Equal(t, 3, c.Find(func(v int) bool { return v > 2 }).Value())
+		require.Len(t, c.FindAll(func(v int) bool { return v != 2 }), 2)
+		require.Equal(t, []int{2, 4, 6}, c.Map(func(v int) int { return v * 2 }).ToSlice())
+		require.Equal(t, []int{2, 3}, c.Select(func(v int) bool { return v > 1 }).ToSlice())
+
+		dest := dlist.New[int]()
+		c.CopyTo(dest, func(v int) bool { return v != 1 })
+		require.Equal(t, []int{2, 3}, dest.ToSlice())
+
+		errOdd := errors.New("odd")
+		err := c.TryForEachAll(func(v int) error {
+			if v%2 == 1 {
+				return errOdd
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, errOdd)
+	})
+
+	t.Run("Min and Max", func(t *testing.T) {
+		require.Equal(t, 1, collections.Chain[int](dlist.New[int](), dlist.Of(2, 1)).Min())
+
+		c := collections.ChainFunc[int](compareInt, dlist.Of(5, 3), ringbuffer.Of(4, 9), dlist.New[int]())
+		require.Equal(t, 3, c.Min())
+		require.Equal(t, 9, c.Max())
+
+		require.PanicsWithValue(t, messages.COMP_FN_NIL, func() {
+			collections.Chain[int](dlist.Of(1), dlist.Of(2)).Max()
+		})
+	})
+
+	t.Run("SampleIterator yields values without replacement", func(t *testing.T) {
+		c := collections.Chain[int](dlist.Of(1, 2, 3), ringbuffer.Of(4, 5))
+		iter := c.SampleIterator(3, rand.New(rand.NewSource(1)))
+		seen := map[int]bool{}
+
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			require.False(t, seen[e.Value()])
+			seen[e.Value()] = true
+		}
+
+		require.Len(t, seen, 3)
+	})
+}
@@ -4,10 +4,13 @@ Package collections provides the interfaces that define the collections in this
 package collections
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/ops"
 )
 
 type CollectionType int
@@ -23,8 +26,40 @@ const (
 	COLLECTION_RINGBUFFER
 	COLLECTION_HASHSET
 	COLLECTION_ORDEREDSET
+	COLLECTION_PRIORITYQUEUE
+	COLLECTION_PRIORITYFAIR
+	COLLECTION_CHAIN
+	COLLECTION_SKIPLISTSET
+	COLLECTION_LINKEDHASHSET
+	COLLECTION_ARRAYLIST
+	COLLECTION_EMPTY
 )
 
+var collectionTypeNames = [...]string{
+	COLLECTION_STACK:         "Stack",
+	COLLECTION_DLIST:         "DList",
+	COLLECTION_SLIST:         "SList",
+	COLLECTION_QUEUE:         "Queue",
+	COLLECTION_RINGBUFFER:    "RingBuffer",
+	COLLECTION_HASHSET:       "HashSet",
+	COLLECTION_ORDEREDSET:    "OrderedSet",
+	COLLECTION_PRIORITYQUEUE: "PriorityQueue",
+	COLLECTION_PRIORITYFAIR:  "PriorityFair",
+	COLLECTION_CHAIN:         "Chain",
+	COLLECTION_SKIPLISTSET:   "SkipListSet",
+	COLLECTION_LINKEDHASHSET: "LinkedHashSet",
+	COLLECTION_ARRAYLIST:     "ArrayList",
+	COLLECTION_EMPTY:         "Empty",
+}
+
+// String returns the name of the collection type, e.g. "Queue".
+func (t CollectionType) String() string {
+	if t <= 0 || int(t) >= len(collectionTypeNames) {
+		return fmt.Sprintf("CollectionType(%d)", int(t))
+	}
+	return collectionTypeNames[t]
+}
+
 // Collection is the abstract interface to all collection types defined in this package.
 // Defines methods implemented by all collections.
 type Collection[T any] interface {
@@ -35,6 +70,9 @@ type Collection[T any] interface {
 	// All collections are iterable.
 	Iterable[T]
 
+	// All collections are cloneable.
+	Cloneable[T]
+
 	// All collections have a string representation.
 	fmt.Stringer
 
@@ -60,6 +98,14 @@ type Collection[T any] interface {
 	// for that collection.
 	AddCollection(Collection[T])
 
+	// ReplaceAll replaces the values of this collection with the values of the given collection.
+	//
+	// The effect is that of calling Clear followed by AddCollection, except that the
+	// replacement is made under a single lock so that other readers never observe the
+	// collection in an intermediate (e.g. empty) state. Where the collection has a backing
+	// store that is large enough to receive the new values, it is reused.
+	ReplaceAll(Collection[T])
+
 	// Clear removes all elements from the collection
 	Clear()
 
@@ -86,13 +132,55 @@ type Collection[T any] interface {
 	// else a by-value copy is made, i.e. works the same as ToSlice.
 	ToSliceDeep() []T
 
+	// ToImmutableSlice returns the content of the collection as a slice, in the same order as ToSlice.
+	//
+	// The slice is cached and only recomputed when the collection has been modified, so repeated
+	// calls between modifications return the same backing array. It is shared by all callers and must not be modified.
+	ToImmutableSlice() []T
+
 	// Type returns the type of the collection (to avoid unnecessary reflecting).
 	Type() CollectionType
 
+	// ApplyOps applies the given operations to the collection in order, under a single lock.
+	// Each op has the same effect as calling Add, Remove or Clear with its value.
+	//
+	// Panics if an op has an invalid kind. Ops preceding the invalid op will have been applied.
+	ApplyOps([]ops.Op[T])
+
+	// StartRecording begins capturing mutations of the collection as ops,
+	// discarding any ops previously recorded.
+	//
+	// Mutations that cannot be expressed as a single Add or Remove, such as sorting or
+	// inserting at a position other than that used by Add, are recorded as a Clear followed by
+	// an Add of each value, so that replaying the ops on an empty collection of the same type
+	// and configuration reproduces this collection. Values modified in place, e.g. via
+	// [Element.ValuePtr] or a list node's SetValue, are not recorded.
+	StartRecording()
+
+	// StopRecording ends recording and returns the ops recorded since StartRecording.
+	StopRecording() []ops.Op[T]
+
+	// IsRecording returns true if the collection is recording mutations.
+	IsRecording() bool
+
 	// Prevent external implementations of this interface
 	local.InternalInter
 }
 
+// Cloneable describes methods that copy a collection without knowledge of its concrete type.
+type Cloneable[T any] interface {
+
+	// Clone returns a new collection of the same type holding the same values,
+	// copied by value. The result is that of Select with a predicate that is always true.
+	Clone() Collection[T]
+
+	// CloneDeep returns a new collection of the same type holding the same values.
+	//
+	// If a DeepCopyFunc[T] was provided to the collection constructor it will be used,
+	// else a by-value copy is made, i.e. works the same as Clone.
+	CloneDeep() Collection[T]
+}
+
 // Enumerable describes methods that enumerate across a collection.
 type Enumerable[T any] interface {
 
@@ -121,6 +209,14 @@ type Enumerable[T any] interface {
 	// values in a set breaks the set structure.
 	ForEach(func(Element[T]))
 
+	// TryForEach calls f for each value in the collection, stopping at the first error
+	// returned by f, which is returned. Returns nil if f returned no error.
+	TryForEach(f func(T) error) error
+
+	// TryForEachAll calls f for each value in the collection, continuing after any error
+	// returned by f. Returns all errors returned by f joined with errors.Join, or nil if there were none.
+	TryForEachAll(f func(T) error) error
+
 	// Min returns the minimum value in the collection according to the Comparer function.
 	Min() T
 
@@ -143,6 +239,14 @@ type Enumerable[T any] interface {
 	// else a by-value copy is made, i.e. works the same as Select.
 	SelectDeep(functions.PredicateFunc[T]) Collection[T]
 
+	// CopyTo copies the items for which predicate is true into the given collection
+	// in a single pass over this collection.
+	//
+	// If a DeepCopyFunc[T] was provided to the collection constructor it will be used,
+	// else a by-value copy is made. Items are inserted according to the rules of the
+	// destination collection.
+	CopyTo(Collection[T], functions.PredicateFunc[T])
+
 	// Prevent external implementations of this interface
 	local.InternalInter
 }
@@ -156,6 +260,25 @@ type Iterable[T any] interface {
 	// those elements for which predicate returns true.
 	TakeWhile(functions.PredicateFunc[T]) Iterator[T]
 
+	// SampleIterator returns an iterator that yields n values chosen at random from the collection,
+	// without replacement and in random order. If the collection holds no more than n values, all are yielded.
+	//
+	// The sample is drawn by reservoir sampling when Start is called, in a single pass over the collection
+	// that holds only the chosen elements, so the collection is not copied. Each call to Start draws a new sample.
+	// If r is nil, the default source of the math/rand package is used.
+	//
+	// Panics if n is negative.
+	SampleIterator(n int, r *rand.Rand) Iterator[T]
+
+	// SnapshotIterator returns an iterator that yields the values of the collection at the time of the call,
+	// in the same order as ToSlice, so that the collection may be modified while iterating, e.g. by handlers
+	// in an event loop, without the iterator panicking.
+	//
+	// The snapshot is the collection's immutable slice (see ToImmutableSlice), so it is only copied if the
+	// collection has been modified since the last snapshot was taken. The elements yielded do not belong to
+	// the collection. They remain valid whatever modifications are made, and ValuePtr panics.
+	SnapshotIterator() Iterator[T]
+
 	// Prevent external implementations of this interface
 	local.InternalInter
 }
@@ -166,6 +289,95 @@ type ReverseIterable[T any] interface {
 	ReverseIterator() Iterator[T]
 }
 
+// BidirectionalIterable defines collections that can be iterated in either direction from any position.
+type BidirectionalIterable[T any] interface {
+	// BidirectionalIterator returns an iterator that can move forwards and backwards across the collection.
+	BidirectionalIterator() BidirectionalIterator[T]
+}
+
+// BulkLoader defines collections that can be loaded from a pipeline of batches of values.
+type BulkLoader[T any] interface {
+	// BulkAdd adds each batch of values received from src until src is closed or ctx is done.
+	//
+	// Batches are received one at a time and are not retained, so a producer may reuse a batch
+	// once it has sent the next. Values in flight are bounded by the capacity of src plus the batch
+	// being added, giving predictable memory use when loading large volumes of data.
+	//
+	// Returns nil once src is closed, else the error of ctx. The values of batches added before
+	// ctx is done remain in the collection.
+	//
+	// Panics if src is nil.
+	BulkAdd(ctx context.Context, src <-chan []T) error
+}
+
+// TryAdder defines collections that report why a value could not be added, rather than
+// returning only false or panicking.
+type TryAdder[T any] interface {
+	// TryAdd adds a value to the collection without panicking or blocking.
+	//
+	// Returns true and nil if the value was added; else false and an error for which errors.Is
+	// matches one of [ErrDuplicate], [ErrFull], [ErrClosed] or [ErrInvalidValue]. A value rejected by the
+	// validator is reported with the error of the validator, whatever the validation policy.
+	TryAdd(value T) (bool, error)
+}
+
+// Retainer defines collections from which the values not matching a condition
+// may be removed in a single pass, without building an intermediate collection.
+type Retainer[T any] interface {
+	// RetainWhere removes the values for which the predicate returns false,
+	// keeping the remaining values in their order, and returns the number of values removed.
+	RetainWhere(predicate functions.PredicateFunc[T]) int
+
+	// RetainAll removes the values for which Contains of other returns false, as an in-place
+	// intersection of this collection with other, and returns the number of values removed.
+	// Other is read while this collection is locked, so it must not concurrently retain from, or
+	// otherwise lock, this collection. If other is this collection, nothing is removed.
+	//
+	// Panics if other is nil.
+	RetainAll(other Collection[T]) int
+}
+
+// Pageable defines collections whose values have a defined order
+// and may be retrieved a page at a time.
+type Pageable[T any] interface {
+	// Page returns a copy of the values on the given zero-based page,
+	// where each page holds pageSize values. The final page may hold fewer
+	// than pageSize values, and pages beyond the end of the collection are empty.
+	//
+	// Panics if pageIndex is negative or pageSize is less than 1.
+	Page(pageIndex, pageSize int) []T
+}
+
+// Indexable defines collections whose values may be accessed by position,
+// where position 0 is the first value visited by the collection's forward iterator.
+//
+// Indexable is implemented by collections that can access any position in constant time,
+// and allows them to be adapted to interfaces such as sort.Interface.
+type Indexable[T any] interface {
+
+	// All Indexables are collections.
+	Collection[T]
+
+	// At returns the value at the given position.
+	//
+	// Panics if index is out of range.
+	At(index int) T
+
+	// Swap exchanges the values at the given positions.
+	//
+	// Panics if either index is out of range.
+	Swap(i, j int)
+
+	// CompareAt compares the values at the given positions using the collection's comparer.
+	// Returns less than zero if the value at i is less than that at j, zero if equal, else greater than zero.
+	//
+	// Panics if either index is out of range.
+	CompareAt(i, j int) int
+
+	// Prevent external implementations of this interface
+	local.InternalInter
+}
+
 // Sortable defines collections that can have their values sorted.
 //
 // Built-in implementation from sort package is used, which is a variation of introspective sort
@@ -177,6 +389,10 @@ type ReverseIterable[T any] interface {
 //
 // Note that List types use a merge sort moving node pointers around, as this is faster
 // than converting to a slice and using pdqsort, then back to a list. Still worst O(n log n).
+//
+// Sorting uses the collection's comparer. For floats the default comparer orders NaN before
+// every other value, so ascending sorts place NaNs first and descending sorts place them last.
+// Construct the collection with [functions.TotalOrderFloat] to choose otherwise.
 type Sortable[T any] interface {
 
 	// All Sortables are collections.
@@ -211,6 +427,29 @@ type Iterator[T any] interface {
 	local.InternalInter
 }
 
+// BidirectionalIterator describes an iterator that can move in either direction from its current position.
+//
+// Moving beyond either end of the collection returns nil and leaves the iterator positioned beyond that end,
+// from where moving in the opposite direction returns the element at that end.
+// A new iterator is positioned before the start, so Next returns the first element.
+type BidirectionalIterator[T any] interface {
+	Iterator[T]
+
+	// End begins iteration at the last item in the collection and returns that element.
+	End() Element[T]
+
+	// Moves the iteration to the previous element in the collection and returns it.
+	Prev() Element[T]
+
+	// Seek positions the iterator at the element located by the given value and returns it.
+	// Each collection defines how the element is located. If there is no such element,
+	// nil is returned and the iterator is positioned beyond the end.
+	Seek(value T) Element[T]
+
+	// Prevent external implementations of this interface
+	local.InternalInter
+}
+
 // Element is an interface describing an element of a collection at a given position.
 type Element[T any] interface {
 	// Gets the value of this element.
@@ -220,9 +459,23 @@ type Element[T any] interface {
 	// allowing modification of the stored value.
 	//
 	// Note that this method will panic if the element represents a value in any implementation of Set[T],
-	// since modifying the value will break the set implementation.
+	// or in a PriorityQueue, since modifying the value will break the set or heap implementation.
 	ValuePtr() *T
 
+	// IsValid returns true if the element may still be used, i.e. the collection has not been modified
+	// since the element was obtained or last refreshed. Value and ValuePtr panic if the element is not valid,
+	// as a modification may have moved or removed the value, e.g. by reallocating the collection's buffer.
+	IsValid() bool
+
+	// Refresh revalidates an element that has been invalidated by a modification of the collection,
+	// by locating a value in the collection equal, according to the collection's comparer, to the value
+	// the element held when it was obtained or last found to be valid by IsValid or Refresh. A value modified
+	// via ValuePtr is therefore located only if one of those has been called since. If there is more than one
+	// equal value, the first in iteration order is located.
+	//
+	// Returns true if the element is valid after the call, or false if the value is no longer in the collection.
+	Refresh() bool
+
 	// Prevent external implementations of this interface
 	local.InternalInter
 }
@@ -0,0 +1,245 @@
+package collections
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// Assert empty implements required interfaces.
+var _ Collection[int] = (*empty[int])(nil)
+
+// empty is an immutable collection with no values.
+type empty[T any] struct {
+	// version and lock are never set, but are laid out as in every other
+	// collection as they are read by the internal element and lock helpers.
+	version int
+	lock    *sync.RWMutex
+
+	local.InternalImpl
+}
+
+// Empty returns an immutable collection with no values, that may be returned
+// instead of nil by functions returning a Collection, so that callers need not check for nil.
+//
+// All methods that read the collection behave as those of any other empty collection.
+// Methods that would modify it panic, as for a read-only collection.
+// Map, Select, SelectDeep, Clone and CloneDeep return the collection itself.
+//
+// There is one empty collection for each type of value, so the result may be compared with ==.
+// The sets, lists, queues and stacks packages each provide an Empty that also implements their interface.
+func Empty[T any]() Collection[T] {
+	return local.Singleton(func() *empty[T] { return &empty[T]{} })
+}
+
+// Add panics as the collection is read-only.
+func (*empty[T]) Add(T) bool {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// AddRange panics as the collection is read-only.
+func (*empty[T]) AddRange([]T) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// AddCollection panics as the collection is read-only.
+func (*empty[T]) AddCollection(Collection[T]) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// ReplaceAll panics as the collection is read-only.
+func (*empty[T]) ReplaceAll(Collection[T]) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Clear panics as the collection is read-only.
+func (*empty[T]) Clear() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Remove panics as the collection is read-only.
+func (*empty[T]) Remove(T) bool {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// ApplyOps panics as the collection is read-only.
+func (*empty[T]) ApplyOps([]ops.Op[T]) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// StartRecording panics as the collection is read-only, so there is nothing to record.
+func (*empty[T]) StartRecording() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// StopRecording returns nil as the collection never records.
+func (*empty[T]) StopRecording() []ops.Op[T] {
+	return nil
+}
+
+// IsRecording returns false as the collection never records.
+func (*empty[T]) IsRecording() bool {
+	return false
+}
+
+// Contains returns false.
+func (*empty[T]) Contains(T) bool {
+	return false
+}
+
+// Count returns zero.
+func (*empty[T]) Count() int {
+	return 0
+}
+
+// IsEmpty returns true.
+func (*empty[T]) IsEmpty() bool {
+	return true
+}
+
+// ToSlice returns an empty slice.
+func (*empty[T]) ToSlice() []T {
+	return []T{}
+}
+
+// ToSliceDeep returns an empty slice.
+func (*empty[T]) ToSliceDeep() []T {
+	return []T{}
+}
+
+// ToImmutableSlice returns an empty slice.
+func (*empty[T]) ToImmutableSlice() []T {
+	return []T{}
+}
+
+// Type returns the type of this collection.
+func (*empty[T]) Type() CollectionType {
+	return COLLECTION_EMPTY
+}
+
+// Any returns false, as there are no values for the predicate to match.
+func (*empty[T]) Any(functions.PredicateFunc[T]) bool {
+	return false
+}
+
+// All returns true, as there are no values for the predicate not to match.
+func (*empty[T]) All(functions.PredicateFunc[T]) bool {
+	return true
+}
+
+// Find returns nil.
+func (*empty[T]) Find(functions.PredicateFunc[T]) Element[T] {
+	return nil
+}
+
+// FindAll returns an empty slice.
+func (*empty[T]) FindAll(functions.PredicateFunc[T]) []Element[T] {
+	return []Element[T]{}
+}
+
+// ForEach does nothing.
+func (*empty[T]) ForEach(func(Element[T])) {}
+
+// TryForEach returns nil.
+func (*empty[T]) TryForEach(func(T) error) error {
+	return nil
+}
+
+// TryForEachAll returns nil.
+func (*empty[T]) TryForEachAll(func(T) error) error {
+	return nil
+}
+
+// Min panics as the collection is empty.
+func (*empty[T]) Min() T {
+	panic(messages.COLLECTION_EMPTY)
+}
+
+// Max panics as the collection is empty.
+func (*empty[T]) Max() T {
+	panic(messages.COLLECTION_EMPTY)
+}
+
+// Map returns this collection.
+func (e *empty[T]) Map(func(T) T) Collection[T] {
+	return e
+}
+
+// Select returns this collection.
+func (e *empty[T]) Select(functions.PredicateFunc[T]) Collection[T] {
+	return e
+}
+
+// SelectDeep returns this collection.
+func (e *empty[T]) SelectDeep(functions.PredicateFunc[T]) Collection[T] {
+	return e
+}
+
+// Clone returns this collection.
+func (e *empty[T]) Clone() Collection[T] {
+	return e
+}
+
+// CloneDeep returns this collection.
+func (e *empty[T]) CloneDeep() Collection[T] {
+	return e
+}
+
+// CopyTo does nothing.
+func (*empty[T]) CopyTo(Collection[T], functions.PredicateFunc[T]) {}
+
+// Iterator returns an iterator that yields no elements.
+func (*empty[T]) Iterator() Iterator[T] {
+	return emptyIteratorOf[T]()
+}
+
+// TakeWhile returns an iterator that yields no elements.
+func (*empty[T]) TakeWhile(functions.PredicateFunc[T]) Iterator[T] {
+	return emptyIteratorOf[T]()
+}
+
+// SampleIterator returns an iterator that yields no elements.
+//
+// Panics if n is negative.
+func (*empty[T]) SampleIterator(n int, _ *rand.Rand) Iterator[T] {
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	return emptyIteratorOf[T]()
+}
+
+// SnapshotIterator returns an iterator that yields no elements.
+func (*empty[T]) SnapshotIterator() Iterator[T] {
+	return emptyIteratorOf[T]()
+}
+
+// String returns a string representation of the collection.
+func (*empty[T]) String() string {
+	return "Empty\n"
+}
+
+// emptyIterator is an iterator that yields no elements.
+type emptyIterator[T any] struct {
+	local.InternalImpl
+}
+
+// emptyIteratorOf returns the iterator that yields no elements for values of type T.
+func emptyIteratorOf[T any]() Iterator[T] {
+	return local.Singleton(func() *emptyIterator[T] { return &emptyIterator[T]{} })
+}
+
+// Start returns nil.
+func (*emptyIterator[T]) Start() Element[T] {
+	return nil
+}
+
+// Next returns nil.
+func (*emptyIterator[T]) Next() Element[T] {
+	return nil
+}
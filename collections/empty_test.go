@@ -0,0 +1,145 @@
+package collections_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/queues"
+	"github.com/fireflycons/generic_collections/sets"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/stacks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmpty(t *testing.T) {
+
+	empties := map[string]collections.Collection[int]{
+		"Collection": collections.Empty[int](),
+		"Set":        sets.Empty[int](),
+		"List":       lists.Empty[int](),
+		"Queue":      queues.Empty[int](),
+		"Stack":      stacks.Empty[int](),
+	}
+
+	for name, e := range empties {
+		t.Run(name+" reads as empty", func(t *testing.T) {
+			require.Equal(t, 0, e.Count())
+			require.True(t, e.IsEmpty())
+			require.False(t, e.Contains(1))
+			require.Empty(t, e.ToSlice())
+			require.Empty(t, e.ToSliceDeep())
+			require.Empty(t, e.ToImmutableSlice())
+			require.Nil(t, e.Iterator().Start())
+			require.Nil(t, e.Iterator().Next())
+			require.Nil(t, e.TakeWhile(func(int) bool { return true }).Start())
+			require.Nil(t, e.SampleIterator(2, rand.New(rand.NewSource(1))).Start())
+			require.Nil(t, e.SnapshotIterator().Start())
+			require.Nil(t, e.Find(func(int) bool { return true }))
+			require.Empty(t, e.FindAll(func(int) bool { return true }))
+			require.False(t, e.Any(func(int) bool { return true }))
+			require.True(t, e.All(func(int) bool { return false }))
+			require.NoError(t, e.TryForEach(func(int) error { return nil }))
+			require.NoError(t, e.TryForEachAll(func(int) error { return nil }))
+			require.False(t, e.IsRecording())
+			require.Nil(t, e.StopRecording())
+			require.Equal(t, collections.COLLECTION_EMPTY, e.Type())
+			require.Equal(t, "Empty\n", e.String())
+			require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { e.Min() })
+			require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { e.Max() })
+
+			called := false
+			e.ForEach(func(collections.Element[int]) { called = true })
+			require.False(t, called)
+
+			dest := dlist.Of(1)
+			e.CopyTo(dest, func(int) bool { return true })
+			require.Equal(t, []int{1}, dest.ToSlice())
+		})
+
+		t.Run(name+" panics on modification", func(t *testing.T) {
+			require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { e.Add(1) })
+			require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { e.AddRange([]int{1}) })
+			require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { e.AddCollection(dlist.Of(1)) })
+			require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { e.ReplaceAll(dlist.Of(1)) })
+			require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { e.Clear() })
+			require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { e.Remove(1) })
+			require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { e.StartRecording() })
+		})
+
+		t.Run(name+" derives itself", func(t *testing.T) {
+			require.Same(t, e, e.Clone())
+			require.Same(t, e, e.CloneDeep())
+			require.Same(t, e, e.Select(func(int) bool { return true }))
+			require.Same(t, e, e.SelectDeep(func(int) bool { return true }))
+			require.Same(t, e, e.Map(func(v int) int { return v }))
+		})
+	}
+
+	t.Run("Is a singleton for each type", func(t *testing.T) {
+		require.Same(t, collections.Empty[int](), collections.Empty[int]())
+		require.Same(t, sets.Empty[int](), sets.Empty[int]())
+		require.Same(t, queues.Empty[string](), queues.Empty[string]())
+		require.NotEqual(t, collections.Empty[int]().(any), collections.Empty[string]().(any))
+	})
+
+	t.Run("May be added to other collections", func(t *testing.T) {
+		l := dlist.Of(1, 2)
+		l.AddCollection(lists.Empty[int]())
+		require.Equal(t, []int{1, 2}, l.ToSlice())
+
+		l.ReplaceAll(collections.Empty[int]())
+		require.True(t, l.IsEmpty())
+	})
+
+	t.Run("Set operations", func(t *testing.T) {
+		e := sets.Empty[int]()
+		s := hashset.Of(1, 2)
+
+		require.ElementsMatch(t, []int{1, 2}, e.Union(s).ToSlice())
+		require.ElementsMatch(t, []int{1, 2}, s.Union(e).ToSlice())
+		require.Same(t, e, e.Intersection(s))
+		require.Same(t, e, e.Difference(s))
+		require.Empty(t, s.Intersection(e).ToSlice())
+		require.ElementsMatch(t, []int{1, 2}, s.Difference(e).ToSlice())
+		require.Nil(t, e.Get(1))
+		require.False(t, e.UnlockedContains(1))
+
+		snap := e.Snapshot()
+		require.Equal(t, 0, snap.Count())
+		require.Empty(t, snap.ToSlice())
+
+		added, removed := sets.DiffSnapshots(snap, e.Snapshot())
+		require.Empty(t, added)
+		require.Empty(t, removed)
+		require.PanicsWithValue(t, messages.SNAPSHOT_TYPE_MISMATCH, func() { snap.Diff(s.Snapshot()) })
+	})
+
+	t.Run("List, queue and stack operations", func(t *testing.T) {
+		l := lists.Empty[int]()
+		require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { l.AddItemFirst(1) })
+		require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { l.TryRemoveLast() })
+		require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { l.Sort() })
+		require.Same(t, l, l.Sorted())
+
+		q := queues.Empty[int]()
+		require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { q.Enqueue(1) })
+		require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { q.Close() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { q.Peek() })
+		require.False(t, q.IsClosed())
+
+		_, ok := q.TryPeek()
+		require.False(t, ok)
+
+		s := stacks.Empty[int]()
+		require.PanicsWithValue(t, messages.COLLECTION_READ_ONLY, func() { s.Push(1) })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { s.Peek() })
+		require.Same(t, s, s.SortedDescending())
+
+		_, ok = s.TryPeek()
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,18 @@
+package collections
+
+import (
+	"errors"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// ErrDuplicate is returned by TryAdd when a set already holds the value.
+var ErrDuplicate = errors.New(messages.VALUE_DUPLICATE)
+
+// ErrFull is returned by TryAdd when a bounded collection has no room for the value,
+// either because it holds as many values as it may, or because the value would take
+// the total weight of the collection over its budget.
+var ErrFull = errors.New(messages.COLLECTION_FULL)
+
+// ErrClosed is returned by TryAdd when the collection has been closed.
+var ErrClosed = errors.New(messages.COLLECTION_CLOSED)
@@ -0,0 +1,73 @@
+package collections_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/fireflycons/generic_collections/stacks/stack"
+)
+
+// Values may be moved between any types of collection. Each collection supplies its values
+// in its own retrieval order, and the receiving collection inserts them by its own rules.
+func Example_interop() {
+	q := queue.Of(3, 1, 2, 3)
+
+	// A set removes the duplicate and orders the values.
+	set := orderedset.New[int]()
+	set.AddCollection(q)
+	fmt.Println(set.ToSlice())
+
+	// A list appends the values in the order of the set.
+	list := dlist.Of(0)
+	list.AddCollection(set)
+	fmt.Println(list.ToSlice())
+
+	// A stack pushes the values in the order of the list, so the last is on top.
+	stk := stack.New[int]()
+	stk.AddCollection(list)
+	fmt.Println(stk.Peek())
+	// Output:
+	// [1 2 3]
+	// [0 1 2 3]
+	// 3
+}
+
+// Functions may be written against the abstract Collection interface.
+func Example_abstract() {
+	sum := func(c collections.Collection[int]) int {
+		total := 0
+		iter := c.Iterator()
+
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			total += e.Value()
+		}
+
+		return total
+	}
+
+	fmt.Println(sum(queue.Of(1, 2, 3)), sum(orderedset.Of(1, 2, 2, 3)), sum(dlist.Of(4, 5)))
+	fmt.Println(queue.Of(1).Type(), orderedset.Of(1).Type())
+	// Output:
+	// 6 6 9
+	// Queue OrderedSet
+}
+
+// Mutations recorded on one collection may be replayed on another of the same type.
+func Example_recording() {
+	primary := queue.New[string]()
+	primary.StartRecording()
+	primary.Enqueue("a")
+	primary.Enqueue("b")
+	primary.Dequeue()
+	primary.Enqueue("c")
+
+	replica := queue.New[string]()
+	replica.ApplyOps(primary.StopRecording())
+
+	fmt.Println(replica.ToSlice())
+	// Output:
+	// [b c]
+}
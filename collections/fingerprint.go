@@ -0,0 +1,81 @@
+package collections
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Fingerprint returns a hash of the values in a collection, built from a HashFunc for the values.
+//
+// The fingerprint is canonical: it does not depend on the order in which the collection holds its values,
+// nor on the type of the collection, so collections holding the same values have the same fingerprint.
+// Computing it is O(n log n).
+//
+// Panics if hasher is nil.
+func Fingerprint[T any](collection Collection[T], hasher functions.HashFunc[T]) uintptr {
+
+	if hasher == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "hasher"))
+	}
+
+	hashes := make([]uintptr, 0, collection.Count())
+
+	for _, v := range collection.ToSlice() {
+		hashes = append(hashes, hasher(v))
+	}
+
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+
+	return functions.HashSlice(func(h uintptr) uintptr { return h })(hashes)
+}
+
+// FingerprintHasher returns a HashFunc that hashes collections by their [Fingerprint],
+// so that collections may themselves be stored in a HashSet. It should be paired with
+// the comparer returned by [ContentComparer].
+//
+//	set := hashset.New(
+//		hashset.WithHasher(collections.FingerprintHasher[*orderedset.OrderedSet[int]](hashInt)),
+//		hashset.WithComparer(collections.ContentComparer[*orderedset.OrderedSet[int]](compareInt)),
+//	)
+//
+// Panics if hasher is nil.
+func FingerprintHasher[C Collection[T], T any](hasher functions.HashFunc[T]) functions.HashFunc[C] {
+
+	if hasher == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "hasher"))
+	}
+
+	return func(collection C) uintptr {
+		return Fingerprint[T](collection, hasher)
+	}
+}
+
+// ContentComparer returns a ComparerFunc that compares collections by the values they hold,
+// built from a ComparerFunc for the values. As with [Fingerprint], the order in which a collection
+// holds its values and the type of the collection do not affect the result.
+//
+// Collections holding fewer values are the lesser. Otherwise the values of each are sorted
+// and compared as by [functions.CompareSlices]. Comparing is O(n log n).
+//
+// Panics if compare is nil.
+func ContentComparer[C Collection[T], T any](compare functions.ComparerFunc[T]) functions.ComparerFunc[C] {
+
+	compareSlices := functions.CompareSlices(compare)
+
+	sorted := func(collection C) []T {
+		values := collection.ToSlice()
+		sort.Slice(values, func(i, j int) bool { return compare(values[i], values[j]) < 0 })
+		return values
+	}
+
+	return func(a, b C) int {
+		if c := a.Count() - b.Count(); c != 0 {
+			return c
+		}
+
+		return compareSlices(sorted(a), sorted(b))
+	}
+}
@@ -0,0 +1,75 @@
+package collections_test
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/stretchr/testify/require"
+)
+
+func hashInt(v int) uintptr {
+	return hashset.HashQword(uint64(v))
+}
+
+func compareInt(a, b int) int {
+	return a - b
+}
+
+func TestFingerprint(t *testing.T) {
+
+	t.Run("Independent of order and collection type", func(t *testing.T) {
+		fp := collections.Fingerprint[int](dlist.Of(3, 1, 2), hashInt)
+
+		require.Equal(t, fp, collections.Fingerprint[int](dlist.Of(1, 2, 3), hashInt))
+		require.Equal(t, fp, collections.Fingerprint[int](orderedset.Of(2, 3, 1), hashInt))
+		require.Equal(t, fp, collections.Fingerprint[int](hashset.Of(1, 3, 2), hashInt))
+	})
+
+	t.Run("Depends on values", func(t *testing.T) {
+		fp := collections.Fingerprint[int](dlist.Of(1, 2, 3), hashInt)
+
+		require.NotEqual(t, fp, collections.Fingerprint[int](dlist.Of(1, 2, 4), hashInt))
+		require.NotEqual(t, fp, collections.Fingerprint[int](dlist.Of(1, 2, 3, 3), hashInt))
+		require.NotEqual(t, fp, collections.Fingerprint[int](dlist.New[int](), hashInt))
+	})
+}
+
+func TestContentComparer(t *testing.T) {
+	compare := collections.ContentComparer[collections.Collection[int]](compareInt)
+
+	require.Zero(t, compare(dlist.Of(3, 1, 2), orderedset.Of(1, 2, 3)))
+	require.Less(t, compare(dlist.Of(1, 2), dlist.Of(0, 0, 0)), 0)
+	require.Less(t, compare(dlist.Of(2, 1), dlist.Of(3, 1)), 0)
+	require.Less(t, compare(dlist.Of(1, 1, 2), dlist.Of(2, 1, 1, 0)), 0)
+	require.Greater(t, compare(dlist.Of(2, 3), dlist.Of(1, 3)), 0)
+}
+
+func TestSetOfSets(t *testing.T) {
+	set := hashset.New(
+		hashset.WithHasher(collections.FingerprintHasher[*orderedset.OrderedSet[int]](hashInt)),
+		hashset.WithComparer(collections.ContentComparer[*orderedset.OrderedSet[int]](compareInt)),
+	)
+
+	require.True(t, set.Add(orderedset.Of(1, 2, 3)))
+	require.False(t, set.Add(orderedset.Of(3, 2, 1)))
+	require.True(t, set.Add(orderedset.Of(1, 2)))
+	require.True(t, set.Contains(orderedset.Of(2, 1)))
+	require.Equal(t, 2, set.Count())
+}
+
+func TestSetOfSlices(t *testing.T) {
+	set := hashset.New(
+		hashset.WithHasher(functions.HashSlice(hashInt)),
+		hashset.WithComparer(functions.CompareSlices(compareInt)),
+	)
+
+	require.True(t, set.Add([]int{1, 2, 3}))
+	require.False(t, set.Add([]int{1, 2, 3}))
+	require.True(t, set.Add([]int{3, 2, 1}))
+	require.True(t, set.Contains([]int{3, 2, 1}))
+	require.Equal(t, 2, set.Count())
+}
@@ -0,0 +1,105 @@
+package collections
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// interleaveIterator yields one element from each of its source iterators in turn.
+type interleaveIterator[T any] struct {
+	sources   []Iterator[T]
+	started   []bool
+	exhausted []bool
+	remaining int
+	position  int
+
+	local.InternalImpl
+}
+
+// Interleave returns an iterator that yields one element from each of the given iterators in turn,
+// skipping those that have been exhausted, until all are exhausted. For example, interleaving
+// iterators over 1, 2, 3 and 10, 20 yields 1, 10, 2, 20, 3.
+//
+// Unlike merging by order, interleaving is fair: each source advances at the same rate
+// regardless of its values. The sources are advanced only as the interleaved iterator advances,
+// and Start restarts all of them. As with any iterator, the collections being iterated
+// must not be modified during iteration.
+//
+// Panics if any iterator is nil.
+func Interleave[T any](iters ...Iterator[T]) Iterator[T] {
+	for _, iter := range iters {
+		if iter == nil {
+			panic(fmt.Sprintf(messages.ARG_NIL_FMT, "iters"))
+		}
+	}
+
+	return &interleaveIterator[T]{
+		sources:   append([]Iterator[T](nil), iters...),
+		started:   make([]bool, len(iters)),
+		exhausted: make([]bool, len(iters)),
+	}
+}
+
+// RoundRobin returns an iterator that yields one value from each of the given collections in turn,
+// in the order each collection iterates them, skipping those that have been exhausted. It is as
+// [Interleave] applied to the forward iterator of each collection.
+//
+// Panics if any collection is nil.
+func RoundRobin[T any](collections ...Collection[T]) Iterator[T] {
+	iters := make([]Iterator[T], len(collections))
+
+	for i, c := range collections {
+		if c == nil {
+			panic(fmt.Sprintf(messages.ARG_NIL_FMT, "collections"))
+		}
+
+		iters[i] = c.Iterator()
+	}
+
+	return Interleave(iters...)
+}
+
+// Start restarts all the source iterators and returns the first element of the first source,
+// which will be nil if all the sources are empty.
+func (i *interleaveIterator[T]) Start() Element[T] {
+	for j := range i.sources {
+		i.started[j] = false
+		i.exhausted[j] = false
+	}
+
+	i.remaining = len(i.sources)
+	i.position = -1
+	return i.Next()
+}
+
+// Next returns the next element of the next source that is not exhausted,
+// which will be nil if all the sources are exhausted.
+func (i *interleaveIterator[T]) Next() Element[T] {
+	for i.remaining > 0 {
+		i.position = (i.position + 1) % len(i.sources)
+
+		if i.exhausted[i.position] {
+			continue
+		}
+
+		var e Element[T]
+
+		if i.started[i.position] {
+			e = i.sources[i.position].Next()
+		} else {
+			i.started[i.position] = true
+			e = i.sources[i.position].Start()
+		}
+
+		if e != nil {
+			return e
+		}
+
+		i.exhausted[i.position] = true
+		i.remaining--
+	}
+
+	return nil
+}
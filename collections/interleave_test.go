@@ -0,0 +1,52 @@
+package collections_test
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/stacks/stack"
+	"github.com/stretchr/testify/require"
+)
+
+func collect[T any](iter collections.Iterator[T]) []T {
+	values := []T{}
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, e.Value())
+	}
+	return values
+}
+
+func TestInterleave(t *testing.T) {
+
+	t.Run("Yields one element from each source in turn", func(t *testing.T) {
+		iter := collections.Interleave(dlist.Of(1, 2, 3).Iterator(), dlist.Of(10, 20).Iterator(), dlist.Of(100).Iterator())
+
+		require.Equal(t, []int{1, 10, 100, 2, 20, 3}, collect(iter))
+	})
+
+	t.Run("Skips empty sources", func(t *testing.T) {
+		iter := collections.Interleave(dlist.New[int]().Iterator(), dlist.Of(1, 2).Iterator(), dlist.New[int]().Iterator())
+
+		require.Equal(t, []int{1, 2}, collect(iter))
+	})
+
+	t.Run("No sources", func(t *testing.T) {
+		require.Nil(t, collections.Interleave[int]().Start())
+	})
+
+	t.Run("Start restarts all sources", func(t *testing.T) {
+		iter := collections.Interleave(dlist.Of(1, 2).Iterator(), dlist.Of(3).Iterator())
+		collect(iter)
+
+		require.Equal(t, []int{1, 3, 2}, collect(iter))
+	})
+}
+
+func TestRoundRobin(t *testing.T) {
+	iter := collections.RoundRobin[int](queue.Of(1, 2, 3), stack.Of(4, 5), dlist.New[int]())
+
+	require.ElementsMatch(t, []int{1, 2, 3, 4, 5}, collect(iter))
+	require.Equal(t, 1, iter.Start().Value())
+}
@@ -0,0 +1,61 @@
+package collections
+
+import "github.com/fireflycons/generic_collections/functions"
+
+// CopyPolicy determines when a collection deep copies its values with its [functions.DeepCopyFunc].
+//
+// Values are exported by ToSliceDeep, SelectDeep, Sorted, SortedDescending, CloneDeep and CloneInto,
+// and imported from another collection by AddCollection and ReplaceAll.
+// Values given to methods such as Add are always stored as given.
+type CopyPolicy int
+
+const (
+	// Deep copies values both on export and on import from another collection,
+	// such that the collections share no memory. This is the default.
+	Deep CopyPolicy = iota
+
+	// DeepOnExport copies values on export, but imports values from another
+	// collection as they are returned by its ToSlice.
+	DeepOnExport
+
+	// Shallow never deep copies values. The deep copy function of the collection
+	// is ignored, and ToSliceDeep, SelectDeep etc. copy only the values themselves.
+	Shallow
+)
+
+// CommonOptions declares the options shared by the collections in this module,
+// so that several collections can be constructed with identical settings.
+//
+// Pass it to the WithOptions option function of each collection package, e.g.
+//
+//	opts := collections.CommonOptions[Person]{ThreadSafe: true, Comparer: comparePeople}
+//	list := dlist.New(dlist.WithOptions(opts))
+//	set := orderedset.New(orderedset.WithOptions(opts))
+//
+// Zero-valued fields leave the collection's default in place, and fields that do not
+// apply to a collection (e.g. Capacity for a linked list) are ignored by it.
+type CommonOptions[T any] struct {
+	// ThreadSafe makes the collection thread-safe.
+	ThreadSafe bool
+
+	// Concurrent enables the concurrency feature where supported.
+	Concurrent bool
+
+	// Capacity is the initial capacity where supported. Must not be negative.
+	Capacity int
+
+	// Comparer is the comparer function for values of type T.
+	Comparer functions.ComparerFunc[T]
+
+	// DeepCopy is the deep copy implementation for values of type T.
+	DeepCopy functions.DeepCopyFunc[T]
+
+	// CopyPolicy determines when values are deep copied.
+	CopyPolicy CopyPolicy
+
+	// Validator, if not nil, checks each value inserted into the collection.
+	Validator functions.ValidatorFunc[T]
+
+	// ValidationPolicy determines what is done with values rejected by Validator.
+	ValidationPolicy ValidationPolicy
+}
@@ -0,0 +1,197 @@
+package collections
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Pipeline is a lazily evaluated chain of operations over the values yielded by an iterator.
+//
+// Map and Filter return a new pipeline that applies the operation to each value as it is
+// yielded, so no intermediate collection is created however many operations are chained.
+// Nothing is evaluated until a terminal operation, such as Reduce, CountWhere or ToSlice,
+// or iteration of the pipeline's Iterator, walks the source. Each terminal operation walks
+// the source again from the start, so a pipeline created by [Query] reflects the current content
+// of its collection, but as with any iterator, the collection must not be modified while it is being walked.
+//
+//	total := collections.Query[int](l).
+//		Filter(func(v int) bool { return v%2 == 0 }).
+//		Map(func(v int) int { return v * v }).
+//		Reduce(0, func(acc, v int) int { return acc + v })
+type Pipeline[T any] struct {
+	source func() Iterator[T]
+}
+
+// From returns a pipeline over the values yielded by the given iterator.
+// Each terminal operation restarts the iterator.
+//
+// Panics if iter is nil.
+func From[T any](iter Iterator[T]) *Pipeline[T] {
+	if iter == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "iter"))
+	}
+
+	return &Pipeline[T]{source: func() Iterator[T] { return iter }}
+}
+
+// Query returns a pipeline over the values of the given collection,
+// in the order the collection iterates them.
+//
+// Panics if collection is nil.
+func Query[T any](collection Collection[T]) *Pipeline[T] {
+	if collection == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "collection"))
+	}
+
+	return &Pipeline[T]{source: collection.Iterator}
+}
+
+// Map returns a pipeline that yields the result of f applied to each value of this pipeline.
+//
+// The elements yielded hold the results of f, not values of the source, so modifying a value
+// through ValuePtr does not modify the source.
+func (p *Pipeline[T]) Map(f func(T) T) *Pipeline[T] {
+
+	return &Pipeline[T]{source: func() Iterator[T] { return &transformIterator[T, T]{source: p.source(), f: f} }}
+}
+
+// Filter returns a pipeline that yields only the values of this pipeline for which predicate is true.
+func (p *Pipeline[T]) Filter(predicate functions.PredicateFunc[T]) *Pipeline[T] {
+
+	return &Pipeline[T]{source: func() Iterator[T] { return &filterIterator[T]{source: p.source(), predicate: predicate} }}
+}
+
+// Reduce combines the values of the pipeline into one, by calling f with the result so far
+// and each value in turn, starting with seed. Returns seed if the pipeline yields no values.
+func (p *Pipeline[T]) Reduce(seed T, f func(T, T) T) T {
+
+	acc := seed
+	iter := p.source()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		acc = f(acc, e.Value())
+	}
+
+	return acc
+}
+
+// Any returns true for the first value found where the predicate function returns true.
+// It returns false if no value matches the predicate. Values after the first match are not evaluated.
+func (p *Pipeline[T]) Any(predicate functions.PredicateFunc[T]) bool {
+
+	iter := p.source()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if predicate(e.Value()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All returns true if all values of the pipeline match the predicate.
+// Values after the first that does not match are not evaluated.
+func (p *Pipeline[T]) All(predicate functions.PredicateFunc[T]) bool {
+
+	iter := p.source()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if !predicate(e.Value()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CountWhere returns the number of values of the pipeline for which predicate is true.
+func (p *Pipeline[T]) CountWhere(predicate functions.PredicateFunc[T]) int {
+
+	count := 0
+	iter := p.source()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if predicate(e.Value()) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// ToSlice returns the values of the pipeline as a slice.
+func (p *Pipeline[T]) ToSlice() []T {
+
+	var values []T
+	iter := p.source()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, e.Value())
+	}
+
+	return values
+}
+
+// Iterator returns an iterator that yields the values of the pipeline.
+// Any operations of the pipeline are applied as it advances.
+func (p *Pipeline[T]) Iterator() Iterator[T] {
+
+	return p.source()
+}
+
+// filterIterator yields the elements of its source for which a predicate is true.
+type filterIterator[T any] struct {
+	source    Iterator[T]
+	predicate functions.PredicateFunc[T]
+
+	local.InternalImpl
+}
+
+// Start restarts the source and returns its first matching element,
+// which will be nil if there is none.
+func (i *filterIterator[T]) Start() Element[T] {
+	return i.skip(i.source.Start())
+}
+
+// Next returns the next matching element of the source,
+// which will be nil if the end has been reached.
+func (i *filterIterator[T]) Next() Element[T] {
+	return i.skip(i.source.Next())
+}
+
+// skip advances the source from e to the first element that matches.
+func (i *filterIterator[T]) skip(e Element[T]) Element[T] {
+	for e != nil && !i.predicate(e.Value()) {
+		e = i.source.Next()
+	}
+
+	return e
+}
+
+// valueElement is an element holding a computed value that is not held by any collection,
+// so it is always valid.
+type valueElement[T any] struct {
+	value T
+
+	local.InternalImpl
+}
+
+func (e *valueElement[T]) Value() T {
+	return e.value
+}
+
+func (e *valueElement[T]) ValuePtr() *T {
+	return &e.value
+}
+
+func (e *valueElement[T]) IsValid() bool {
+	return true
+}
+
+func (e *valueElement[T]) Refresh() bool {
+	return true
+}
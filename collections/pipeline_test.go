@@ -0,0 +1,79 @@
+package collections_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+
+	isEven := func(v int) bool { return v%2 == 0 }
+	square := func(v int) int { return v * v }
+	sum := func(acc, v int) int { return acc + v }
+
+	t.Run("Operations are chained", func(t *testing.T) {
+		l := dlist.Of(1, 2, 3, 4, 5, 6)
+		p := collections.Query[int](l).Filter(isEven).Map(square)
+
+		require.Equal(t, []int{4, 16, 36}, p.ToSlice())
+		require.Equal(t, 56, p.Reduce(0, sum))
+		require.Equal(t, 2, p.CountWhere(func(v int) bool { return v > 10 }))
+		require.True(t, p.Any(func(v int) bool { return v == 16 }))
+		require.True(t, p.All(isEven))
+		require.False(t, p.All(func(v int) bool { return v < 36 }))
+		require.Equal(t, []int{4, 16, 36}, collect(p.Iterator()))
+	})
+
+	t.Run("Evaluation is lazy", func(t *testing.T) {
+		calls := 0
+		p := collections.Query[int](orderedset.Of(1, 2, 3, 4)).Map(func(v int) int { calls++; return v })
+		require.Zero(t, calls)
+
+		require.True(t, p.Any(func(v int) bool { return v == 2 }))
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("Pipeline reflects the current source", func(t *testing.T) {
+		l := dlist.Of(1, 2)
+		p := collections.Query[int](l).Map(square)
+		require.Equal(t, []int{1, 4}, p.ToSlice())
+
+		l.Add(3)
+		require.Equal(t, []int{1, 4, 9}, p.ToSlice())
+	})
+
+	t.Run("Empty source", func(t *testing.T) {
+		p := collections.From(dlist.New[int]().Iterator()).Filter(isEven).Map(square)
+
+		require.Empty(t, p.ToSlice())
+		require.Equal(t, 7, p.Reduce(7, sum))
+		require.Zero(t, p.CountWhere(isEven))
+		require.False(t, p.Any(isEven))
+		require.True(t, p.All(isEven))
+		require.Nil(t, p.Iterator().Start())
+	})
+
+	t.Run("Filtered elements are elements of the source", func(t *testing.T) {
+		l := dlist.Of(1, 2, 3)
+		*collections.Query[int](l).Filter(isEven).Iterator().Start().ValuePtr() = 20
+		require.Equal(t, []int{1, 20, 3}, l.ToSlice())
+
+		// Mapped values are not
+		e := collections.Query[int](l).Map(square).Iterator().Start()
+		*e.ValuePtr() = 100
+		require.Equal(t, 100, e.Value())
+		require.True(t, e.IsValid())
+		require.Equal(t, []int{1, 20, 3}, l.ToSlice())
+	})
+
+	t.Run("Nil source panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "iter"), func() { collections.From[int](nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "collection"), func() { collections.Query[int](nil) })
+	})
+}
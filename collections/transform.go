@@ -0,0 +1,97 @@
+package collections
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Map adds the result of f applied to each value of src to dst, in the order src iterates them.
+// Unlike the Map method of each collection, the results may be of a different type to the values of src,
+// and dst may be any kind of collection, e.g.
+//
+//	lengths := queue.New[int]()
+//	collections.Map[string, int](names, func(s string) int { return len(s) }, lengths)
+//
+// The values of src are read under a single read lock of a thread-safe src, and the results added under
+// a single lock of a thread-safe dst, so src and dst may be the same collection.
+//
+// Panics if src, f or dst is nil.
+func Map[T, U any](src Collection[T], f func(T) U, dst Collection[U]) {
+	if dst == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dst"))
+	}
+
+	dst.AddRange(MapToSlice(src, f))
+}
+
+// MapToSlice returns the result of f applied to each value of src, in the order src iterates them.
+//
+// Panics if src or f is nil.
+func MapToSlice[T, U any](src Collection[T], f func(T) U) []U {
+	if src == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "src"))
+	}
+
+	if f == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "f"))
+	}
+
+	results := make([]U, 0, src.Count())
+
+	_ = src.TryForEach(func(v T) error {
+		results = append(results, f(v))
+		return nil
+	})
+
+	return results
+}
+
+// Transform returns a pipeline that yields the result of f applied to each value of p,
+// where the results may be of a different type to the values of p, e.g.
+//
+//	lengths := collections.Transform(collections.Query[string](names), func(s string) int { return len(s) })
+//
+// As with [Pipeline.Map], nothing is evaluated until a terminal operation walks the pipeline.
+//
+// Panics if p or f is nil.
+func Transform[T, U any](p *Pipeline[T], f func(T) U) *Pipeline[U] {
+	if p == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "p"))
+	}
+
+	if f == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "f"))
+	}
+
+	return &Pipeline[U]{source: func() Iterator[U] { return &transformIterator[T, U]{source: p.source(), f: f} }}
+}
+
+// transformIterator yields the result of a function applied to each value of its source.
+type transformIterator[T, U any] struct {
+	source Iterator[T]
+	f      func(T) U
+
+	local.InternalImpl
+}
+
+// Start restarts the source and returns the result for its first element,
+// which will be nil if the source is empty.
+func (i *transformIterator[T, U]) Start() Element[U] {
+	return i.apply(i.source.Start())
+}
+
+// Next returns the result for the next element of the source,
+// which will be nil if the end has been reached.
+func (i *transformIterator[T, U]) Next() Element[U] {
+	return i.apply(i.source.Next())
+}
+
+func (i *transformIterator[T, U]) apply(e Element[T]) Element[U] {
+	if e == nil {
+		return nil
+	}
+
+	return &valueElement[U]{value: i.f(e.Value())}
+}
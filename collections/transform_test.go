@@ -0,0 +1,72 @@
+package collections_test
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+
+	atoi := func(s string) int { v, _ := strconv.Atoi(s); return v }
+
+	t.Run("Map adds results to a collection of another type", func(t *testing.T) {
+		src := hashset.New[string]()
+		src.AddRange([]string{"3", "1", "2"})
+		dst := queue.New[int]()
+		dst.Add(0)
+
+		collections.Map[string, int](src, atoi, dst)
+
+		values := dst.ToSlice()
+		require.Equal(t, 0, values[0])
+		sort.Ints(values)
+		require.Equal(t, []int{0, 1, 2, 3}, values)
+	})
+
+	t.Run("Map into the source collection", func(t *testing.T) {
+		l := dlist.Of(1, 2, 3)
+
+		collections.Map[int, int](l, func(v int) int { return v * 10 }, l)
+		require.Equal(t, []int{1, 2, 3, 10, 20, 30}, l.ToSlice())
+	})
+
+	t.Run("MapToSlice preserves iteration order", func(t *testing.T) {
+		l := dlist.Of(3, 1, 2)
+
+		require.Equal(t, []string{"3", "1", "2"}, collections.MapToSlice[int, string](l, strconv.Itoa))
+		require.Empty(t, collections.MapToSlice[int, string](dlist.New[int](), strconv.Itoa))
+	})
+
+	t.Run("Transform is lazy and chains", func(t *testing.T) {
+		l := dlist.Of(1, 2, 3, 4)
+		calls := 0
+		p := collections.Transform(collections.Query[int](l), func(v int) string { calls++; return strconv.Itoa(v * v) }).
+			Filter(func(s string) bool { return len(s) == 1 })
+
+		require.Zero(t, calls)
+		require.Equal(t, []string{"1", "4", "9"}, p.ToSlice())
+		require.Equal(t, 4, calls)
+
+		l.Add(2)
+		require.Equal(t, []string{"1", "4", "9", "4"}, collect(p.Iterator()))
+	})
+
+	t.Run("Nil arguments panic", func(t *testing.T) {
+		l := dlist.Of(1)
+
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "src"), func() { collections.MapToSlice[int, string](nil, strconv.Itoa) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "f"), func() { collections.MapToSlice[int, string](l, nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dst"), func() { collections.Map[int, string](l, strconv.Itoa, nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "p"), func() { collections.Transform[int, string](nil, strconv.Itoa) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "f"), func() { collections.Transform[int, string](collections.Query[int](l), nil) })
+	})
+}
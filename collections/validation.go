@@ -0,0 +1,26 @@
+package collections
+
+import (
+	"errors"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// ErrInvalidValue is wrapped together with the error of the validator by the value
+// a collection panics with when a value is rejected by its validator, and by the error returned by TryAdd.
+var ErrInvalidValue = errors.New(messages.VALUE_INVALID)
+
+// ValidationPolicy determines what a collection constructed with a validator
+// does with a value that the validator rejects.
+type ValidationPolicy int
+
+const (
+	// PanicOnInvalid panics with an error wrapping both [ErrInvalidValue] and the error of the validator.
+	// Values given to AddRange, AddCollection or ReplaceAll are all validated before any is inserted,
+	// so the collection is unchanged. This is the default.
+	PanicOnInvalid ValidationPolicy = iota
+
+	// RejectInvalid discards the value. Methods that report whether a value was added, e.g. Add, return false,
+	// and AddRange, AddCollection and ReplaceAll insert only the valid values.
+	RejectInvalid
+)
@@ -0,0 +1,506 @@
+/*
+Package collectiontest provides a conformance test suite that any implementation of
+[collections.Collection] should pass, so that the behaviour of the collections in this
+module does not drift apart as they evolve.
+
+The suite is run from an ordinary test function:
+
+	func TestConformance(t *testing.T) {
+		collectiontest.RunCollectionTests(t, collectiontest.Config[int]{
+			New:    func() collections.Collection[int] { return queue.New[int]() },
+			Values: []int{1, 2, 3, 4},
+		})
+	}
+
+Each test is run as a subtest of t against new collections made by Config.New.
+Values are compared with reflect.DeepEqual, and the order of values is checked only
+where it is defined, i.e. not for a HashSet.
+*/
+package collectiontest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collectionassert"
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Minimum number of values in Config.Values.
+const minValues = 3
+
+// Config describes the collection under test.
+type Config[T any] struct {
+	// New returns a new, empty collection of the type under test.
+	// The collection must be able to hold all of Values at once.
+	New func() collections.Collection[T]
+
+	// Values are at least three distinct values to store in the collection.
+	Values []T
+
+	// DeepCopied, if not nil, returns true if c is a deep copy of v rather than v itself,
+	// e.g. for pointer values, that c points to a different value equal to that pointed to by v.
+	// When set, the collections returned by New must have been given a deep copy function,
+	// and the suite checks that ToSliceDeep, SelectDeep, CloneDeep and AddCollection deep copy values.
+	DeepCopied func(v, c T) bool
+
+	// NewValidated, if not nil, returns a new, empty collection of the type under test constructed with
+	// the given validator and validation policy, and the suite checks that values rejected by the validator
+	// are not inserted by Add, AddRange, AddCollection and ReplaceAll, nor by TryAdd, which must report them
+	// without panicking, if the collection is a [collections.TryAdder].
+	NewValidated func(validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) collections.Collection[T]
+
+	// SnapshotElements is true if the elements of the collection belong to immutable snapshots of its values,
+	// as for copy-on-write collections, so the suite checks that they are not invalidated by modification.
+	SnapshotElements bool
+}
+
+// RunCollectionTests runs the conformance tests against the collection described by cfg.
+// It covers Add, AddRange, Remove, Clear, AddCollection, ReplaceAll, Clone, iteration,
+// ToSlice, ToSliceDeep, ToImmutableSlice, the invalidation of elements by modification,
+// snapshot iteration, RetainWhere and RetainAll of a [collections.Retainer] and,
+// where configured, deep copies and validation.
+//
+// Panics if cfg.New is nil or cfg.Values has fewer than three values.
+func RunCollectionTests[T any](t *testing.T, cfg Config[T]) {
+	t.Helper()
+
+	if cfg.New == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "cfg.New"))
+	}
+
+	if len(cfg.Values) < minValues {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "cfg.Values"))
+	}
+
+	values := cfg.Values
+
+	// filled returns a new collection holding the given values.
+	filled := func(vals []T) collections.Collection[T] {
+		col := cfg.New()
+		col.AddRange(vals)
+		return col
+	}
+
+	t.Run("New collection is empty", func(t *testing.T) {
+		col := cfg.New()
+
+		if !col.IsEmpty() || col.Count() != 0 {
+			t.Errorf("%T: new collection has Count %d, IsEmpty %t", col, col.Count(), col.IsEmpty())
+		}
+
+		if col.Contains(values[0]) {
+			t.Errorf("%T: new collection contains %v", col, values[0])
+		}
+
+		collectionassert.AssertInvariants(t, col)
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		col := cfg.New()
+
+		for i, v := range values {
+			if !col.Add(v) {
+				t.Errorf("%T: Add(%v) returned false for a distinct value", col, v)
+			}
+
+			if col.Count() != i+1 {
+				t.Errorf("%T: Count is %d after %d adds", col, col.Count(), i+1)
+			}
+
+			if !col.Contains(v) {
+				t.Errorf("%T: Contains(%v) is false after Add", col, v)
+			}
+		}
+
+		collectionassert.AssertSameElements(t, col, values)
+		collectionassert.AssertInvariants(t, col)
+	})
+
+	t.Run("AddRange is as Add", func(t *testing.T) {
+		col := cfg.New()
+
+		for _, v := range values {
+			col.Add(v)
+		}
+
+		assertContent(t, filled(values), col.ToSlice())
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		last := len(values) - 1
+		col := filled(values[:last])
+
+		if col.Remove(values[last]) {
+			t.Errorf("%T: Remove(%v) returned true for an absent value", col, values[last])
+		}
+
+		if !col.Remove(values[0]) {
+			t.Errorf("%T: Remove(%v) returned false for a present value", col, values[0])
+		}
+
+		if col.Contains(values[0]) {
+			t.Errorf("%T: Contains(%v) is true after Remove", col, values[0])
+		}
+
+		collectionassert.AssertSameElements(t, col, values[1:last])
+		collectionassert.AssertInvariants(t, col)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		col := filled(values)
+		col.Clear()
+
+		collectionassert.AssertSameElements(t, col, []T{})
+		collectionassert.AssertInvariants(t, col)
+
+		col.AddRange(values)
+		collectionassert.AssertSameElements(t, col, values)
+	})
+
+	t.Run("AddCollection", func(t *testing.T) {
+		col := filled(values[:1])
+		col.AddCollection(filled(values[1:]))
+
+		collectionassert.AssertSameElements(t, col, values)
+		collectionassert.AssertInvariants(t, col)
+	})
+
+	t.Run("ReplaceAll", func(t *testing.T) {
+		col := filled(values[:1])
+		col.ReplaceAll(filled(values[1:]))
+
+		collectionassert.AssertSameElements(t, col, values[1:])
+		collectionassert.AssertInvariants(t, col)
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		col := filled(values)
+		clone := col.Clone()
+
+		if clone.Type() != col.Type() {
+			t.Errorf("%T: Clone returned a %v", col, clone.Type())
+		}
+
+		assertContent(t, clone, col.ToSlice())
+		collectionassert.AssertInvariants(t, clone)
+
+		clone.Remove(values[0])
+		collectionassert.AssertSameElements(t, col, values)
+	})
+
+	t.Run("Slices", func(t *testing.T) {
+		col := filled(values)
+		slc := col.ToSlice()
+
+		if len(slc) > 0 {
+			var empty T
+			slc[0] = empty
+			collectionassert.AssertSameElements(t, col, values)
+		}
+
+		immutable := col.ToImmutableSlice()
+		assertContent(t, col, immutable)
+
+		if again := col.ToImmutableSlice(); len(again) > 0 && &again[0] != &immutable[0] {
+			t.Errorf("%T: ToImmutableSlice was recomputed without modification", col)
+		}
+
+		col.Remove(values[0])
+		assertContent(t, col, col.ToImmutableSlice())
+		assertContent(t, col, col.ToSliceDeep())
+	})
+
+	t.Run("Elements are invalidated by modification", func(t *testing.T) {
+		col := filled(values[1:])
+		iterable, ok := col.(collections.Iterable[T])
+
+		if !ok {
+			t.Skipf("%T does not implement Iterable", col)
+		}
+
+		e := iterable.Iterator().Start()
+
+		if e == nil || !e.IsValid() {
+			t.Fatalf("%T: iterator did not start at a valid element", col)
+		}
+
+		col.Add(values[0])
+
+		if cfg.SnapshotElements {
+			if !e.IsValid() {
+				t.Errorf("%T: snapshot element is not valid after Add", col)
+			}
+
+			return
+		}
+
+		if e.IsValid() {
+			t.Errorf("%T: element is valid after Add", col)
+		}
+
+		if !e.Refresh() || !e.IsValid() {
+			t.Errorf("%T: element could not be refreshed to a value still present", col)
+		}
+	})
+
+	t.Run("Snapshot iterator tolerates modification", func(t *testing.T) {
+		col := filled(values)
+		expected := col.ToSlice()
+		iter := col.SnapshotIterator()
+		var got []T
+
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			got = append(got, e.Value())
+			col.Remove(e.Value())
+
+			if !e.IsValid() {
+				t.Errorf("%T: snapshot element is not valid after modification", col)
+			}
+		}
+
+		if !col.IsEmpty() {
+			t.Errorf("%T: values remain after removing each value of the snapshot", col)
+		}
+
+		collectionassert.AssertSameElements(t, filled(got), values)
+
+		if col.Type() != collections.COLLECTION_HASHSET && !reflect.DeepEqual(got, expected) {
+			t.Errorf("%T: snapshot yielded %v, not in the order of ToSlice %v", col, got, expected)
+		}
+
+		if e := iter.Start(); e == nil || recovered(func() { e.ValuePtr() }) == nil {
+			t.Errorf("%T: snapshot could not be restarted, or ValuePtr did not panic", col)
+		}
+	})
+
+	if _, ok := cfg.New().(collections.Retainer[T]); ok {
+		runRetainTests(t, cfg, filled)
+	}
+
+	if cfg.NewValidated != nil {
+		runValidationTests(t, cfg, filled)
+	}
+
+	if cfg.DeepCopied == nil {
+		return
+	}
+
+	t.Run("Deep copies", func(t *testing.T) {
+		col := filled(values)
+		assertDeepCopied := func(method string, copies []T) {
+			t.Helper()
+
+			stored := col.ToSlice()
+
+			if len(copies) != len(stored) {
+				t.Errorf("%T: %s returned %d values, expected %d", col, method, len(copies), len(stored))
+				return
+			}
+
+		outer:
+			for i, c := range copies {
+				for _, v := range stored {
+					if cfg.DeepCopied(v, c) {
+						continue outer
+					}
+				}
+
+				t.Errorf("%T: %s value %v at index %d is not a deep copy of a stored value", col, method, c, i)
+			}
+		}
+
+		assertDeepCopied("ToSliceDeep", col.ToSliceDeep())
+
+		if enumerable, ok := col.(collections.Enumerable[T]); ok {
+			assertDeepCopied("SelectDeep", enumerable.SelectDeep(func(T) bool { return true }).ToSlice())
+		}
+
+		assertDeepCopied("CloneDeep", col.CloneDeep().ToSlice())
+
+		other := cfg.New()
+		other.AddCollection(col)
+		assertDeepCopied("AddCollection", other.ToSlice())
+	})
+}
+
+// runRetainTests checks that RetainWhere and RetainAll of a [collections.Retainer] remove
+// the values they should, and record their mutations so that they can be replayed.
+func runRetainTests[T any](t *testing.T, cfg Config[T], filled func([]T) collections.Collection[T]) {
+	t.Helper()
+
+	values := cfg.Values
+
+	t.Run("RetainWhere", func(t *testing.T) {
+		col := filled(values)
+		retainer := col.(collections.Retainer[T])
+
+		if removed := retainer.RetainWhere(func(T) bool { return true }); removed != 0 {
+			t.Errorf("%T: RetainWhere removed %d values when retaining all", col, removed)
+		}
+
+		col.StartRecording()
+
+		if removed := retainer.RetainWhere(func(v T) bool { return !reflect.DeepEqual(v, values[0]) }); removed != 1 {
+			t.Errorf("%T: RetainWhere removed %d values, expected 1", col, removed)
+		}
+
+		collectionassert.AssertSameElements(t, col, values[1:])
+		collectionassert.AssertInvariants(t, col)
+
+		replayed := filled(values)
+		replayed.ApplyOps(col.StopRecording())
+
+		// The layout of a heap depends on the order in which values were removed, so only its values are compared.
+		if col.Type() == collections.COLLECTION_PRIORITYQUEUE {
+			collectionassert.AssertSameElements(t, replayed, col.ToSlice())
+		} else {
+			assertContent(t, replayed, col.ToSlice())
+		}
+
+		if removed := retainer.RetainWhere(func(T) bool { return false }); removed != len(values)-1 {
+			t.Errorf("%T: RetainWhere removed %d values when retaining none, expected %d", col, removed, len(values)-1)
+		}
+
+		collectionassert.AssertSameElements(t, col, []T{})
+		collectionassert.AssertInvariants(t, col)
+
+		col.AddRange(values)
+		collectionassert.AssertSameElements(t, col, values)
+	})
+
+	t.Run("RetainAll", func(t *testing.T) {
+		col := filled(values)
+		retainer := col.(collections.Retainer[T])
+
+		if removed := retainer.RetainAll(col); removed != 0 {
+			t.Errorf("%T: RetainAll of itself removed %d values", col, removed)
+		}
+
+		if removed := retainer.RetainAll(filled(values[1:2])); removed != len(values)-1 {
+			t.Errorf("%T: RetainAll removed %d values, expected %d", col, removed, len(values)-1)
+		}
+
+		collectionassert.AssertSameElements(t, col, values[1:2])
+		collectionassert.AssertInvariants(t, col)
+
+		if recovered(func() { retainer.RetainAll(nil) }) == nil {
+			t.Errorf("%T: RetainAll(nil) did not panic", col)
+		}
+	})
+}
+
+// runValidationTests checks that a collection constructed with a validator
+// rejecting the first of the configured values never holds that value.
+func runValidationTests[T any](t *testing.T, cfg Config[T], filled func([]T) collections.Collection[T]) {
+	t.Helper()
+
+	values := cfg.Values
+	errRejected := errors.New("rejected")
+	validator := func(v T) error {
+		if reflect.DeepEqual(v, values[0]) {
+			return errRejected
+		}
+
+		return nil
+	}
+
+	t.Run("Validation rejects invalid values", func(t *testing.T) {
+		col := cfg.NewValidated(validator, collections.RejectInvalid)
+
+		if col.Add(values[0]) {
+			t.Errorf("%T: Add returned true for an invalid value", col)
+		}
+
+		if !col.Add(values[1]) {
+			t.Errorf("%T: Add returned false for a valid value", col)
+		}
+
+		col.Clear()
+		col.AddRange(values)
+		collectionassert.AssertSameElements(t, col, values[1:])
+
+		col.Clear()
+		col.AddCollection(filled(values))
+		collectionassert.AssertSameElements(t, col, values[1:])
+
+		col.ReplaceAll(filled(values))
+		collectionassert.AssertSameElements(t, col, values[1:])
+		collectionassert.AssertInvariants(t, col)
+	})
+
+	t.Run("Validation panics on invalid values", func(t *testing.T) {
+		col := cfg.NewValidated(validator, collections.PanicOnInvalid)
+
+		assertPanicsInvalid := func(method string, f func()) {
+			t.Helper()
+
+			err, _ := recovered(f).(error)
+
+			if !errors.Is(err, collections.ErrInvalidValue) || !errors.Is(err, errRejected) {
+				t.Errorf("%T: %s did not panic with the validator's error", col, method)
+			}
+
+			if col.Count() != 0 {
+				t.Errorf("%T: %s inserted values before panicking", col, method)
+			}
+		}
+
+		assertPanicsInvalid("Add", func() { col.Add(values[0]) })
+		assertPanicsInvalid("AddRange", func() { col.AddRange(values) })
+		assertPanicsInvalid("ReplaceAll", func() { col.ReplaceAll(filled(values)) })
+	})
+
+	if _, ok := cfg.NewValidated(validator, collections.RejectInvalid).(collections.TryAdder[T]); !ok {
+		return
+	}
+
+	t.Run("TryAdd reports invalid values", func(t *testing.T) {
+		for _, policy := range []collections.ValidationPolicy{collections.RejectInvalid, collections.PanicOnInvalid} {
+			col := cfg.NewValidated(validator, policy)
+			adder := col.(collections.TryAdder[T])
+
+			var added bool
+			var err error
+
+			if r := recovered(func() { added, err = adder.TryAdd(values[0]) }); r != nil {
+				t.Errorf("%T: TryAdd panicked with policy %d: %v", col, policy, r)
+				continue
+			}
+
+			if added || !errors.Is(err, collections.ErrInvalidValue) || !errors.Is(err, errRejected) {
+				t.Errorf("%T: TryAdd returned %v, %v for an invalid value with policy %d", col, added, err, policy)
+			}
+
+			if added, err = adder.TryAdd(values[1]); !added || err != nil {
+				t.Errorf("%T: TryAdd returned %v, %v for a valid value with policy %d", col, added, err, policy)
+			}
+
+			collectionassert.AssertSameElements(t, col, values[1:2])
+		}
+	})
+}
+
+// recovered calls f, returning the value it panicked with, if any.
+func recovered(f func()) (r any) {
+	defer func() { r = recover() }()
+	f()
+	return nil
+}
+
+// assertContent asserts that the collection contains the expected values in the order of its ToSlice,
+// or in any order for a HashSet, whose order is undefined.
+func assertContent[T any](t *testing.T, col collections.Collection[T], expected []T) {
+	t.Helper()
+
+	if col.Type() == collections.COLLECTION_HASHSET {
+		collectionassert.AssertSameElements(t, col, expected)
+		return
+	}
+
+	collectionassert.AssertElements(t, col, expected)
+}
@@ -0,0 +1,161 @@
+/*
+Package collate provides comparers that order strings by the conventions of a language,
+backed by golang.org/x/text/collate, so that collections such as OrderedSet, and Sort,
+order user-visible strings as a reader of that language expects.
+
+	set := orderedset.NewFunc(collate.Comparer(language.Swedish, collate.IgnoreCase()))
+
+Comparing strings by collation is much slower than comparing their bytes. Where the same strings
+are compared repeatedly, [WithKeyCache] caches the collation key of each, and where a collection
+is sorted once, SortByKey with the function returned by [Key] computes each key only once.
+*/
+package collate
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/caches/lru"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// OptionFunc is the signature of a function
+// for providing options to Comparer and Key.
+type OptionFunc func(*config)
+
+type config struct {
+	options   []collate.Option
+	cacheSize int
+}
+
+// IgnoreCase is an option to treat strings that differ only in case as equal.
+func IgnoreCase() OptionFunc {
+	return func(c *config) {
+		c.options = append(c.options, collate.IgnoreCase)
+	}
+}
+
+// IgnoreDiacritics is an option to disregard accents and other diacritics when ordering strings.
+// Strings that differ only in diacritics, e.g. "resume" and "résumé", are equal if [IgnoreCase] is also given.
+func IgnoreDiacritics() OptionFunc {
+	return func(c *config) {
+		c.options = append(c.options, collate.IgnoreDiacritics)
+	}
+}
+
+// IgnoreWidth is an option to treat full-width and half-width forms of the same character as equal.
+func IgnoreWidth() OptionFunc {
+	return func(c *config) {
+		c.options = append(c.options, collate.IgnoreWidth)
+	}
+}
+
+// Numeric is an option to order sequences of digits by their numeric value, e.g. "file9" before "file10".
+func Numeric() OptionFunc {
+	return func(c *config) {
+		c.options = append(c.options, collate.Numeric)
+	}
+}
+
+// WithKeyCache is an option to cache the collation keys of up to size of the most recently compared strings,
+// so that comparing a string again does not collate it again. Comparisons are then of the cached keys.
+//
+// Panics if size is less than 1.
+func WithKeyCache(size int) OptionFunc {
+	if size < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"))
+	}
+
+	return func(c *config) {
+		c.cacheSize = size
+	}
+}
+
+// Comparer returns a ComparerFunc that orders strings by the collation rules of the given language.
+//
+// The comparer is safe for concurrent use, e.g. by a thread-safe collection.
+func Comparer(tag language.Tag, options ...OptionFunc) functions.ComparerFunc[string] {
+
+	c := newCollator(tag, options)
+
+	if c.cache != nil {
+		return func(a, b string) int {
+			return strings.Compare(c.key(a), c.key(b))
+		}
+	}
+
+	return c.compare
+}
+
+// Key returns a function that computes the collation key of a string by the collation rules of the given language.
+// Ordering keys by their bytes orders the strings from which they were computed as [Comparer] would,
+// so the function may be given to the SortByKey function of a collection's package, e.g.
+//
+//	dlist.SortByKey(names, collate.Key(language.German))
+//
+// The function is safe for concurrent use.
+func Key(tag language.Tag, options ...OptionFunc) func(string) string {
+
+	return newCollator(tag, options).key
+}
+
+// collator shares collators of the x/text package, which are not safe for concurrent use, between goroutines.
+type collator struct {
+	pool  sync.Pool
+	cache *lru.Cache[string, string]
+}
+
+// pooled is a collator of the x/text package with its own buffer for keys.
+type pooled struct {
+	collator *collate.Collator
+	buffer   collate.Buffer
+}
+
+func newCollator(tag language.Tag, options []OptionFunc) *collator {
+	cfg := &config{}
+
+	for _, o := range options {
+		o(cfg)
+	}
+
+	c := &collator{}
+	c.pool.New = func() any {
+		return &pooled{collator: collate.New(tag, cfg.options...)}
+	}
+
+	if cfg.cacheSize > 0 {
+		c.cache = lru.New(lru.WithMaxEntries[string, string](cfg.cacheSize))
+	}
+
+	return c
+}
+
+func (c *collator) compare(a, b string) int {
+	p := c.pool.Get().(*pooled)
+	defer c.pool.Put(p)
+
+	return p.collator.CompareString(a, b)
+}
+
+func (c *collator) key(s string) string {
+	if c.cache != nil {
+		if key, ok := c.cache.Get(s); ok {
+			return key
+		}
+	}
+
+	p := c.pool.Get().(*pooled)
+	key := string(p.collator.KeyFromString(&p.buffer, s))
+	p.buffer.Reset()
+	c.pool.Put(p)
+
+	if c.cache != nil {
+		c.cache.Put(s, key)
+	}
+
+	return key
+}
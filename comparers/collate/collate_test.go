@@ -0,0 +1,97 @@
+package collate
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func sorted(values []string, compare func(a, b string) int) []string {
+	result := append([]string(nil), values...)
+	sort.Slice(result, func(i, j int) bool { return compare(result[i], result[j]) < 0 })
+	return result
+}
+
+func TestComparer(t *testing.T) {
+
+	words := []string{"zebra", "äpple", "apple"}
+
+	t.Run("Language rules", func(t *testing.T) {
+		require.Equal(t, []string{"apple", "äpple", "zebra"}, sorted(words, Comparer(language.German)))
+		require.Equal(t, []string{"apple", "zebra", "äpple"}, sorted(words, Comparer(language.Swedish)))
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		require.NotZero(t, Comparer(language.English)("Apple", "apple"))
+		require.Zero(t, Comparer(language.English, IgnoreCase())("Apple", "apple"))
+		require.Zero(t, Comparer(language.French, IgnoreDiacritics(), IgnoreCase())("resume", "résumé"))
+		require.Positive(t, Comparer(language.English)("file9", "file10"))
+		require.Negative(t, Comparer(language.English, Numeric())("file9", "file10"))
+	})
+
+	t.Run("Key cache", func(t *testing.T) {
+		compare := Comparer(language.Swedish, WithKeyCache(2))
+
+		for i := 0; i < 3; i++ {
+			require.Equal(t, []string{"apple", "zebra", "äpple"}, sorted(words, compare))
+		}
+
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"), func() { WithKeyCache(0) })
+	})
+
+	t.Run("Ordered set", func(t *testing.T) {
+		set := orderedset.NewFunc(Comparer(language.German, IgnoreCase()))
+		set.AddRange([]string{"Zebra", "äpfel", "Apfel", "apfel"})
+
+		require.Equal(t, []string{"Apfel", "äpfel", "Zebra"}, set.ToSlice())
+	})
+
+	t.Run("Concurrent use", func(t *testing.T) {
+		compare := Comparer(language.German)
+		var wg sync.WaitGroup
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					require.Negative(t, compare("äpple", "zebra"))
+				}
+			}()
+		}
+
+		wg.Wait()
+	})
+}
+
+func TestKey(t *testing.T) {
+
+	words := []string{"zebra", "äpple", "apple", "Zebra"}
+
+	for _, tag := range []language.Tag{language.German, language.Swedish} {
+		key := Key(tag)
+		byKey := sorted(words, func(a, b string) int { return compareStrings(key(a), key(b)) })
+		require.Equal(t, sorted(words, Comparer(tag)), byKey)
+	}
+
+	l := dlist.Of(words...)
+	dlist.SortByKey(l, Key(language.Swedish, IgnoreCase(), WithKeyCache(10)))
+	require.Equal(t, "äpple", l.Last().Value())
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,156 @@
+/*
+Package concurrent provides helpers for using collections from multiple goroutines.
+
+A [Collector] fans in the results of a number of goroutines into any collection.
+
+	results := hashset.New[string]()
+	c := concurrent.NewCollector[string](results)
+
+	for _, url := range urls {
+		url := url
+		c.Go(func(emit func(string)) error {
+			links, err := crawl(url)
+			for _, link := range links {
+				emit(link)
+			}
+			return err
+		})
+	}
+
+	err := c.Wait()
+
+Each goroutine buffers the values it emits and adds them to the collection a batch at a time,
+so the collection need not be thread-safe and lock contention is far lower than if every
+goroutine were to add values directly to a collection created WithThreadSafe.
+*/
+package concurrent
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// DefaultBatchSize is the number of values a goroutine buffers before adding them to the
+// destination collection, unless set otherwise with [WithBatchSize].
+const DefaultBatchSize = 64
+
+// CollectorOptionFunc is the signature of a function
+// for providing options to the Collector constructor.
+type CollectorOptionFunc[T any] func(*Collector[T])
+
+// Collector gathers the values emitted by a group of goroutines into a collection.
+type Collector[T any] struct {
+	lock      sync.Mutex
+	wg        sync.WaitGroup
+	dest      collections.Collection[T]
+	batchSize int
+	errs      []error
+}
+
+// NewCollector constructs a Collector that adds the values emitted by its goroutines to dest.
+//
+// Until [Collector.Wait] returns, dest is modified by the collector's goroutines and should only
+// be accessed elsewhere if it is thread-safe.
+//
+// Panics if dest is nil.
+func NewCollector[T any](dest collections.Collection[T], options ...CollectorOptionFunc[T]) *Collector[T] {
+	if dest == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dest"))
+	}
+
+	c := &Collector[T]{
+		dest:      dest,
+		batchSize: DefaultBatchSize,
+	}
+
+	for _, o := range options {
+		o(c)
+	}
+
+	return c
+}
+
+// Option function for NewCollector to set the number of values each goroutine buffers
+// before adding them to the destination collection. A batch size of 1 adds each value as it is emitted.
+//
+// Panics if size is less than 1.
+func WithBatchSize[T any](size int) CollectorOptionFunc[T] {
+	if size < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"))
+	}
+	return func(c *Collector[T]) {
+		c.batchSize = size
+	}
+}
+
+// Go calls f in a new goroutine. The emit function passed to f adds a value to the
+// destination collection. Values are added with the destination's AddRange, so are
+// inserted according to the rules of that collection.
+//
+// emit must only be called by the goroutine running f, and not after f returns.
+// Values emitted by a single goroutine are added in the order they were emitted.
+// Values from different goroutines may be interleaved a batch at a time.
+//
+// Any error returned by f is returned by [Collector.Wait]. Values emitted before the error are retained.
+//
+// Panics if f is nil.
+func (c *Collector[T]) Go(f func(emit func(T)) error) {
+	if f == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "f"))
+	}
+
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+
+		batch := make([]T, 0, c.batchSize)
+
+		err := f(func(value T) {
+			batch = append(batch, value)
+			if len(batch) == c.batchSize {
+				c.flush(batch)
+				batch = batch[:0]
+			}
+		})
+
+		c.flush(batch)
+
+		if err != nil {
+			c.lock.Lock()
+			c.errs = append(c.errs, err)
+			c.lock.Unlock()
+		}
+	}()
+}
+
+// Wait blocks until all goroutines started by Go have returned and their values have been added
+// to the destination collection.
+//
+// Returns the errors returned by the goroutines joined with [errors.Join], or nil if there were none.
+// The errors are cleared, so the collector may be reused for a further group of goroutines.
+func (c *Collector[T]) Wait() error {
+	c.wg.Wait()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	err := errors.Join(c.errs...)
+	c.errs = nil
+	return err
+}
+
+// flush adds a batch of values to the destination collection.
+func (c *Collector[T]) flush(batch []T) {
+	if len(batch) == 0 {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.dest.AddRange(batch)
+}
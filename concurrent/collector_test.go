@@ -0,0 +1,98 @@
+package concurrent_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/concurrent"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+
+	t.Run("Collects from all goroutines", func(t *testing.T) {
+		const workers, perWorker = 8, 1000
+
+		results := hashset.New[int]()
+		c := concurrent.NewCollector[int](results, concurrent.WithBatchSize[int](10))
+
+		for w := 0; w < workers; w++ {
+			w := w
+			c.Go(func(emit func(int)) error {
+				for i := 0; i < perWorker; i++ {
+					emit(w*perWorker + i)
+				}
+				return nil
+			})
+		}
+
+		require.NoError(t, c.Wait())
+		require.Equal(t, workers*perWorker, results.Count())
+	})
+
+	t.Run("Values from one goroutine keep their order", func(t *testing.T) {
+		results := dlist.New[int]()
+		c := concurrent.NewCollector[int](results, concurrent.WithBatchSize[int](3))
+
+		c.Go(func(emit func(int)) error {
+			for i := 1; i <= 7; i++ {
+				emit(i)
+			}
+			return nil
+		})
+
+		require.NoError(t, c.Wait())
+		require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, results.ToSlice())
+	})
+
+	t.Run("Full batches are added before the goroutine returns", func(t *testing.T) {
+		results := queue.New(queue.WithThreadSafe[int]())
+		c := concurrent.NewCollector[int](results, concurrent.WithBatchSize[int](4))
+		emitted, release := make(chan struct{}), make(chan struct{})
+
+		c.Go(func(emit func(int)) error {
+			for i := 0; i < 5; i++ {
+				emit(i)
+			}
+			close(emitted)
+			<-release
+			return nil
+		})
+
+		<-emitted
+		require.Equal(t, 4, results.Count())
+		close(release)
+		require.NoError(t, c.Wait())
+		require.Equal(t, 5, results.Count())
+	})
+
+	t.Run("Errors are joined and cleared", func(t *testing.T) {
+		results := queue.New[int]()
+		c := concurrent.NewCollector[int](results)
+		err1, err2 := errors.New("one"), errors.New("two")
+
+		c.Go(func(emit func(int)) error { emit(1); return err1 })
+		c.Go(func(emit func(int)) error { emit(2); return err2 })
+		c.Go(func(emit func(int)) error { return nil })
+
+		err := c.Wait()
+		require.True(t, errors.Is(err, err1))
+		require.True(t, errors.Is(err, err2))
+		require.Equal(t, 2, results.Count())
+
+		c.Go(func(emit func(int)) error { emit(3); return nil })
+		require.NoError(t, c.Wait())
+		require.Equal(t, 3, results.Count())
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dest"), func() { concurrent.NewCollector[int](nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"), func() { concurrent.WithBatchSize[int](0) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "f"), func() { concurrent.NewCollector[int](queue.New[int]()).Go(nil) })
+	})
+}
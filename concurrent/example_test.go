@@ -0,0 +1,31 @@
+package concurrent_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/concurrent"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+)
+
+func ExampleCollector() {
+	squares := orderedset.New[int]()
+	c := concurrent.NewCollector[int](squares)
+
+	for w := 0; w < 4; w++ {
+		w := w
+		c.Go(func(emit func(int)) error {
+			for i := w * 3; i < (w+1)*3; i++ {
+				emit(i * i)
+			}
+			return nil
+		})
+	}
+
+	if err := c.Wait(); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(squares.ToSlice())
+	// Output:
+	// [0 1 4 9 16 25 36 49 64 81 100 121]
+}
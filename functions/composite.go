@@ -0,0 +1,62 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+const (
+	fnvOffset uint64 = 0xcbf29ce484222325
+	fnvPrime  uint64 = 0x00000100000001b3
+)
+
+// HashSlice returns a HashFunc for slices of T, built from a HashFunc for the elements,
+// so that slices may be stored in a HashSet. The hash depends on the order of the elements,
+// so should be paired with a comparer such as that returned by [CompareSlices].
+//
+//	set := hashset.New(
+//		hashset.WithHasher(functions.HashSlice(func(v int) uintptr { return hashset.HashQword(uint64(v)) })),
+//		hashset.WithComparer(functions.CompareSlices(func(a, b int) int { return a - b })),
+//	)
+//
+// Panics if hasher is nil.
+func HashSlice[T any](hasher HashFunc[T]) HashFunc[[]T] {
+
+	if hasher == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "hasher"))
+	}
+
+	return func(values []T) uintptr {
+		hash := fnvOffset
+
+		for _, v := range values {
+			hash ^= uint64(hasher(v))
+			hash *= fnvPrime
+		}
+
+		return uintptr(hash)
+	}
+}
+
+// CompareSlices returns a ComparerFunc for slices of T, built from a ComparerFunc for the elements.
+// Slices are compared element by element, and the first unequal pair decides the result.
+// If one slice is a prefix of the other, the shorter slice is the lesser.
+//
+// Panics if compare is nil.
+func CompareSlices[T any](compare ComparerFunc[T]) ComparerFunc[[]T] {
+
+	if compare == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "compare"))
+	}
+
+	return func(a, b []T) int {
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if c := compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+
+		return len(a) - len(b)
+	}
+}
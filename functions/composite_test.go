@@ -0,0 +1,33 @@
+package functions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashSlice(t *testing.T) {
+	hash := HashSlice(func(v int) uintptr { return uintptr(v) })
+
+	require.Equal(t, hash([]int{1, 2, 3}), hash([]int{1, 2, 3}))
+	require.NotEqual(t, hash([]int{1, 2, 3}), hash([]int{3, 2, 1}))
+	require.NotEqual(t, hash([]int{}), hash([]int{0}))
+	require.Equal(t, hash(nil), hash([]int{}))
+
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "hasher"), func() { HashSlice[int](nil) })
+}
+
+func TestCompareSlices(t *testing.T) {
+	compare := CompareSlices(func(a, b int) int { return a - b })
+
+	require.Zero(t, compare([]int{1, 2, 3}, []int{1, 2, 3}))
+	require.Less(t, compare([]int{1, 2, 3}, []int{1, 3}), 0)
+	require.Greater(t, compare([]int{2}, []int{1, 9, 9}), 0)
+	require.Less(t, compare([]int{1, 2}, []int{1, 2, 3}), 0)
+	require.Greater(t, compare([]int{1, 2, 3}, []int{1, 2}), 0)
+	require.Zero(t, compare(nil, []int{}))
+
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "compare"), func() { CompareSlices[int](nil) })
+}
@@ -0,0 +1,13 @@
+package functions
+
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// AbsDifference is a DistanceFunc for any numeric type, returning |a - b|.
+// The difference is taken in float64, so does not overflow or wrap for unsigned types.
+func AbsDifference[T constraints.Integer | constraints.Float](a, b T) float64 {
+	return math.Abs(float64(a) - float64(b))
+}
@@ -0,0 +1,14 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsDifference(t *testing.T) {
+	require.Equal(t, 3.0, AbsDifference(2, 5))
+	require.Equal(t, 3.0, AbsDifference(5, 2))
+	require.Equal(t, 255.0, AbsDifference(uint8(0), uint8(255)))
+	require.Equal(t, 0.5, AbsDifference(-0.25, 0.25))
+}
@@ -0,0 +1,80 @@
+package functions
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"golang.org/x/exp/constraints"
+)
+
+// NaNOrder selects where a comparer returned by [TotalOrderFloat] places NaN.
+type NaNOrder int
+
+const (
+	// NaNFirst orders NaN before every other value, as [Compare] and the default float comparers do.
+	NaNFirst NaNOrder = iota
+
+	// NaNLast orders NaN after every other value, including +Inf.
+	NaNLast
+)
+
+// TotalOrderFloat returns a ComparerFunc for floats that orders every value, e.g.
+//
+//	set := orderedset.NewFunc(functions.TotalOrderFloat[float64](functions.NaNLast))
+//
+// The comparers collections use for floats by default order NaN first and consider
+// -0 and +0 equal, so a set holds only one of them. A comparer returned by TotalOrderFloat
+// places NaN as given by nanOrder and orders -0 before +0, so that, as in IEEE 754 totalOrder,
+// no two distinct values compare equal. All NaNs are considered equal to each other, whatever
+// their sign or payload.
+//
+// Panics if nanOrder is not NaNFirst or NaNLast.
+func TotalOrderFloat[T constraints.Float](nanOrder NaNOrder) ComparerFunc[T] {
+	var nanSign int
+
+	switch nanOrder {
+	case NaNFirst:
+		nanSign = -1
+	case NaNLast:
+		nanSign = 1
+	default:
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "nanOrder"))
+	}
+
+	return func(a, b T) int {
+		aNaN := isNaN(a)
+		bNaN := isNaN(b)
+
+		switch {
+		case aNaN && bNaN:
+			return 0
+		case aNaN:
+			return nanSign
+		case bNaN:
+			return -nanSign
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+
+		// Equal, but may be zeros of different sign.
+		aNeg := math.Signbit(float64(a))
+		bNeg := math.Signbit(float64(b))
+
+		switch {
+		case aNeg == bNeg:
+			return 0
+		case aNeg:
+			return -1
+		default:
+			return 1
+		}
+	}
+}
+
+// IsFinite returns true if v is neither NaN nor infinite.
+func IsFinite[T constraints.Float](v T) bool {
+	return !isNaN(v) && !math.IsInf(float64(v), 0)
+}
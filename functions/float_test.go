@@ -0,0 +1,60 @@
+package functions
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTotalOrderFloat(t *testing.T) {
+
+	nan := math.NaN()
+	negZero := math.Copysign(0, -1)
+	inf := math.Inf(1)
+
+	sortWith := func(compare ComparerFunc[float64]) []float64 {
+		values := []float64{1, nan, inf, 0, -inf, negZero, -1, nan}
+		sort.Slice(values, func(i, j int) bool { return compare(values[i], values[j]) < 0 })
+		return values
+	}
+
+	t.Run("NaN last", func(t *testing.T) {
+		compare := TotalOrderFloat[float64](NaNLast)
+		values := sortWith(compare)
+
+		require.Equal(t, []float64{-inf, -1, 0, 0, 1, inf}, values[:6])
+		require.True(t, math.Signbit(values[2]))
+		require.False(t, math.Signbit(values[3]))
+		require.True(t, math.IsNaN(values[6]) && math.IsNaN(values[7]))
+		require.Less(t, compare(negZero, 0), 0)
+		require.Greater(t, compare(0, negZero), 0)
+		require.Zero(t, compare(nan, math.Copysign(nan, -1)))
+	})
+
+	t.Run("NaN first", func(t *testing.T) {
+		compare := TotalOrderFloat[float32](NaNFirst)
+		nan32 := float32(nan)
+
+		require.Less(t, compare(nan32, float32(-inf)), 0)
+		require.Greater(t, compare(float32(-inf), nan32), 0)
+		require.Zero(t, compare(nan32, nan32))
+		require.Less(t, compare(1.5, 2.5), 0)
+		require.Zero(t, compare(2.5, 2.5))
+	})
+
+	t.Run("Invalid order panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "nanOrder"), func() { TotalOrderFloat[float64](NaNOrder(2)) })
+	})
+}
+
+func TestIsFinite(t *testing.T) {
+	require.True(t, IsFinite(1.5))
+	require.True(t, IsFinite(float32(0)))
+	require.False(t, IsFinite(math.NaN()))
+	require.False(t, IsFinite(math.Inf(1)))
+	require.False(t, IsFinite(float32(math.Inf(-1))))
+}
@@ -1,6 +1,8 @@
 // This package contains signatures for functions used by collections and their methods.
 package functions
 
+import "time"
+
 // ComparerFunc is the signature for a function that compares two values.
 //
 // This function allows the developer to supply a custom compare function to a collection for a type that is not one of the supported types.
@@ -26,6 +28,27 @@ type PredicateFunc[T any] func(T) bool
 // package so can be used to construct hashes for struct types.
 type HashFunc[T any] func(T) uintptr
 
+// DistanceFunc is the signature for a function that measures how far apart two values are,
+// used to choose the nearer of two candidates, e.g. by ClosestTo. The result must not be negative.
+//
+// For numeric types [AbsDifference] may be used. For times it might look like this
+//
+//	func timeDistance(a, b time.Time) float64 {
+//		return math.Abs(float64(a.Sub(b)))
+//	}
+type DistanceFunc[T any] func(a, b T) float64
+
+// ValidatorFunc is the signature for a function that checks a value before it is inserted into a collection.
+// It returns nil if the value is valid, else an error describing why it is not, e.g.
+//
+//	func validatePort(port int) error {
+//		if port < 1 || port > 65535 {
+//			return fmt.Errorf("port %d out of range", port)
+//		}
+//		return nil
+//	}
+type ValidatorFunc[T any] func(T) error
+
 // Function signature for a function to deep copy a collection element.
 //
 // This function should return a new instance of type T copied from the original.
@@ -36,3 +59,29 @@ type HashFunc[T any] func(T) uintptr
 // deep-copy elements, supply an implementation of this function to the
 // collection's constructor.
 type DeepCopyFunc[T any] func(T) T
+
+// Clock is the source of the current time for collections with time-dependent behaviour.
+//
+// Such collections accept a Clock via a WithClock constructor option and use [SystemClock]
+// by default. Supplying a different implementation allows unit tests to control the passage
+// of time deterministically, e.g.
+//
+//	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	clock := functions.ClockFunc(func() time.Time { return now })
+//	// ... construct collection with clock, then advance time
+//	now = now.Add(time.Minute)
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to allow the use of an ordinary function as a [Clock].
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the [Clock] that returns the current time of the system.
+var SystemClock Clock = ClockFunc(time.Now)
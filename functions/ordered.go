@@ -0,0 +1,33 @@
+package functions
+
+import "golang.org/x/exp/constraints"
+
+// Compare is a ComparerFunc for any ordered type, with the semantics of cmp.Compare
+// in the Go 1.21 standard library. A NaN is considered less than any other float,
+// and equal to another NaN.
+//
+// It may be given to the WithComparer option or NewFunc constructor of any collection,
+// and is the comparer NewOrdered uses for types defined on a built-in type.
+func Compare[T constraints.Ordered](a, b T) int {
+	aNaN := isNaN(a)
+	bNaN := isNaN(b)
+
+	if aNaN && bNaN {
+		return 0
+	}
+
+	if aNaN || a < b {
+		return -1
+	}
+
+	if bNaN || a > b {
+		return 1
+	}
+
+	return 0
+}
+
+// isNaN reports whether x is a NaN. Only a float can be unequal to itself.
+func isNaN[T constraints.Ordered](x T) bool {
+	return x != x
+}
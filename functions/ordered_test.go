@@ -0,0 +1,27 @@
+package functions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	require.Less(t, Compare(1, 2), 0)
+	require.Greater(t, Compare(2, 1), 0)
+	require.Zero(t, Compare(2, 2))
+
+	// No overflow, unlike subtraction.
+	require.Less(t, Compare(math.MinInt64, 1), 0)
+	require.Greater(t, Compare(uint8(255), uint8(0)), 0)
+
+	require.Less(t, Compare("a", "b"), 0)
+	require.Zero(t, Compare("a", "a"))
+
+	nan := math.NaN()
+	require.Less(t, Compare(nan, math.Inf(-1)), 0)
+	require.Greater(t, Compare(math.Inf(-1), nan), 0)
+	require.Zero(t, Compare(nan, nan))
+	require.Less(t, Compare(1.5, 2.5), 0)
+}
@@ -0,0 +1,23 @@
+package local
+
+import (
+	"reflect"
+	"sync"
+)
+
+// singletons holds the value created by Singleton for each type.
+var singletons sync.Map
+
+// Singleton returns the value of type S created by the first call for that type,
+// calling create to make it. Generic types cannot have package level variables,
+// so this provides one instance of e.g. an empty collection per element type.
+func Singleton[S any](create func() S) S {
+	key := reflect.TypeOf((*S)(nil)).Elem()
+
+	if s, ok := singletons.Load(key); ok {
+		return s.(S)
+	}
+
+	s, _ := singletons.LoadOrStore(key, create())
+	return s.(S)
+}
@@ -1,18 +1,48 @@
 package messages
 
 const (
-	COLLECTION_MODIFIED      = "Collection has been modified"
-	COLLECTION_EMPTY         = "Cannot perform operation on empty collection"
-	NEGATIVE_CAPACITY        = "Cannot create collection with negative capacity"
-	FOREIGN_NODE             = "Node does not belong to this list"
-	NIL_NODE                 = "Cannot perform operation on nil node"
-	SET_POINTER_MODIFICATION = "Cannot modify set elements through pointer"
-	COMP_FN_NIL              = "Comparer function cannot be nil"
-	HASH_BUCKET_SIZE_INVALID = "Hash bucket size cannot be less than 1"
-	COMPARER_INVALID_INT_FMT = "Unsupported integer byte size %d"
-	COMPARER_INVALID_KEY_FMT = "Unsupported key type %T of kind %v. Supply instance of CompararFunc[T]"
-	ARG_NIL_FMT              = "Argument %s cannot be nil"
-	ARG_OUT_OF_RANGE_FMT     = "Argument %s out of range"
-	SLICE_TOO_SMALL          = "Slice is too small to receive all elements"
-	AGG_SLICE_EMPTY          = "Cannot compute aggregate of empty slice"
+	COLLECTION_MODIFIED       = "Collection has been modified"
+	COLLECTION_EMPTY          = "Cannot perform operation on empty collection"
+	NEGATIVE_CAPACITY         = "Cannot create collection with negative capacity"
+	FOREIGN_NODE              = "Node does not belong to this list"
+	NIL_NODE                  = "Cannot perform operation on nil node"
+	SET_POINTER_MODIFICATION  = "Cannot modify set elements through pointer"
+	COMP_FN_NIL               = "Comparer function cannot be nil"
+	HASH_BUCKET_SIZE_INVALID  = "Hash bucket size cannot be less than 1"
+	COMPARER_INVALID_INT_FMT  = "Unsupported integer byte size %d"
+	COMPARER_INVALID_KEY_FMT  = "Unsupported key type %T of kind %v. Supply instance of CompararFunc[T]"
+	ARG_NIL_FMT               = "Argument %s cannot be nil"
+	ARG_OUT_OF_RANGE_FMT      = "Argument %s out of range"
+	SLICE_TOO_SMALL           = "Slice is too small to receive all elements"
+	AGG_SLICE_EMPTY           = "Cannot compute aggregate of empty slice"
+	COLLECTION_CLOSED         = "Collection has been closed"
+	OP_KIND_INVALID_FMT       = "Invalid operation kind %d"
+	SNAPSHOT_TYPE_MISMATCH    = "Snapshots were taken from different types of set"
+	NAME_EMPTY                = "Name cannot be empty"
+	CONCURRENT_ACCESS         = "Concurrent access to collection that is not thread-safe"
+	INVARIANT_VIOLATED_FMT    = "Collection invariant violated: %s"
+	POWER_SET_TOO_LARGE_FMT   = "Set of %d values exceeds the power set limit of %d"
+	WEIGHT_NEGATIVE           = "Weigher returned a negative weight"
+	VALUE_OUTSIDE_VIEW        = "Value is outside the range of the view"
+	ZERO_VALUE_COMPARER_FMT   = "No default comparer for type %T. Construct the collection with New and supply a ComparerFunc"
+	ZERO_VALUE_UNUSABLE_FMT   = "Zero value of %s cannot be used. Construct it with New"
+	REMOVE_FROM_WINDOW        = "Cannot remove values from a sliding window"
+	HEAP_POINTER_MODIFICATION = "Cannot modify priority queue elements through pointer"
+	COLLECTION_READ_ONLY      = "Cannot modify a read-only collection"
+	DECODE_INVALID_FMT        = "Encoded %s is invalid"
+	DECODE_LEVELS_FMT         = "Cannot decode %d priority levels into a queue with %d levels"
+	LOADER_PANICKED           = "Loader panicked"
+	BATCH_LOAD_MISMATCH_FMT   = "Batch loader returned %d values for %d keys"
+	BINDING_DUPLICATE_FMT     = "A collection of %v named %q is already provided"
+	BINDING_NOT_FOUND_FMT     = "No collection of %v named %q is provided"
+	STABLE_IDS_DISABLED       = "Collection was not created with stable IDs"
+	VALUE_NOT_FINITE          = "Cannot add NaN or infinite value to collection"
+	VALUE_INVALID             = "Value is not valid for collection"
+	VALUE_DUPLICATE           = "Value is already in collection"
+	COLLECTION_FULL           = "Collection is full"
+	SCHEMA_MISSING            = "Serialized data has no schema header"
+	SCHEMA_MISMATCH           = "Serialized data does not match the schema of the collection"
+	SCHEMA_MISMATCH_FMT       = "Serialized %s is %q, expected %q"
+	SNAPSHOT_POINTER          = "Cannot modify values of a snapshot through pointer"
+	VALUE_SKIPPED             = "Value skipped by pipeline stage"
 )
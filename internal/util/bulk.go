@@ -0,0 +1,40 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// BulkAdd receives batches of values from src and passes each to addRange,
+// until src is closed or ctx is done.
+//
+// Only one batch is received at a time, so a producer sending to src blocks
+// once the channel's buffer is full until the previous batch has been added.
+//
+// Returns nil when src is closed, else the error of ctx.
+func BulkAdd[T any](ctx context.Context, src <-chan []T, addRange func([]T)) error {
+	if src == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "src"))
+	}
+
+	for {
+		// Favour cancellation over a batch that is also ready.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case batch, ok := <-src:
+			if !ok {
+				return nil
+			}
+
+			addRange(batch)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkAdd(t *testing.T) {
+
+	t.Run("Adds batches until source closed", func(t *testing.T) {
+		src := make(chan []int, 2)
+		added := make([]int, 0)
+
+		go func() {
+			for i := 0; i < 10; i++ {
+				src <- []int{i * 2, i*2 + 1}
+			}
+			close(src)
+		}()
+
+		err := BulkAdd(context.Background(), src, func(batch []int) { added = append(added, batch...) })
+
+		require.NoError(t, err)
+		require.Len(t, added, 20)
+
+		for i, v := range added {
+			require.Equal(t, i, v)
+		}
+	})
+
+	t.Run("Stops when context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		src := make(chan []int)
+		batches := 0
+
+		go func() {
+			src <- []int{1}
+			src <- []int{2}
+			cancel()
+		}()
+
+		err := BulkAdd(ctx, src, func([]int) { batches++ })
+
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 2, batches)
+	})
+
+	t.Run("Cancellation is favoured over ready batch", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		src := make(chan []int, 1)
+		src <- []int{1}
+
+		err := BulkAdd(ctx, src, func([]int) { t.Fatal("batch added after cancellation") })
+
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Producer blocks until batch received", func(t *testing.T) {
+		src := make(chan []int, 1)
+		release := make(chan struct{})
+		sent := make(chan int, 3)
+
+		go func() {
+			for i := 0; i < 3; i++ {
+				src <- []int{i}
+				sent <- i
+			}
+			close(src)
+		}()
+
+		done := make(chan error)
+
+		go func() {
+			done <- BulkAdd(context.Background(), src, func([]int) { <-release })
+		}()
+
+		// First batch is being added and the second fills the buffer, so the third cannot be sent.
+		require.Equal(t, 0, <-sent)
+		require.Equal(t, 1, <-sent)
+		require.Len(t, sent, 0)
+
+		close(release)
+		require.NoError(t, <-done)
+		require.Equal(t, 2, <-sent)
+	})
+
+	t.Run("Nil source panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "src"), func() {
+			_ = BulkAdd[int](context.Background(), nil, func([]int) {})
+		})
+	})
+}
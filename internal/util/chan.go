@@ -0,0 +1,77 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// ToChan returns an unbuffered channel to which a new goroutine sends each value returned by take,
+// until take returns false or ctx is done, then closes the channel.
+//
+// A value is taken before it is sent, so if ctx is done while a value is waiting
+// to be received, that value is discarded.
+func ToChan[T any](ctx context.Context, take func() (T, bool)) <-chan T {
+	ch := make(chan T)
+
+	go func() {
+		defer close(ch)
+
+		for ctx.Err() == nil {
+			value, ok := take()
+
+			if !ok {
+				return
+			}
+
+			select {
+			case ch <- value:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// FromChan passes each value received from src to add in a new goroutine,
+// until src is closed, ctx is done or add returns false.
+//
+// The returned channel receives nil, or the error of ctx if it was done first, then is closed.
+//
+// Panics if src is nil.
+func FromChan[T any](ctx context.Context, src <-chan T, add func(T) bool) <-chan error {
+	if src == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "src"))
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		defer close(done)
+
+		for {
+			// Favour cancellation over a value that is also ready.
+			if err := ctx.Err(); err != nil {
+				done <- err
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				done <- ctx.Err()
+				return
+
+			case value, ok := <-src:
+				if !ok || !add(value) {
+					done <- nil
+					return
+				}
+			}
+		}
+	}()
+
+	return done
+}
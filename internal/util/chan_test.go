@@ -0,0 +1,89 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToChan(t *testing.T) {
+
+	source := func(values ...int) func() (int, bool) {
+		return func() (int, bool) {
+			if len(values) == 0 {
+				return 0, false
+			}
+			v := values[0]
+			values = values[1:]
+			return v, true
+		}
+	}
+
+	t.Run("Sends values until take fails", func(t *testing.T) {
+		received := make([]int, 0)
+
+		for v := range ToChan(context.Background(), source(1, 2, 3)) {
+			received = append(received, v)
+		}
+
+		require.Equal(t, []int{1, 2, 3}, received)
+	})
+
+	t.Run("Closes when context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		taken := 0
+		ch := ToChan(ctx, func() (int, bool) { taken++; return taken, true })
+
+		require.Equal(t, 1, <-ch)
+		cancel()
+
+		for range ch {
+		}
+
+		// At most the value waiting to be sent when cancelled is discarded
+		require.LessOrEqual(t, taken, 3)
+	})
+}
+
+func TestFromChan(t *testing.T) {
+
+	t.Run("Adds values until source closed", func(t *testing.T) {
+		src := make(chan int, 3)
+		added := make([]int, 0)
+		src <- 1
+		src <- 2
+		close(src)
+
+		require.NoError(t, <-FromChan(context.Background(), src, func(v int) bool { added = append(added, v); return true }))
+		require.Equal(t, []int{1, 2}, added)
+	})
+
+	t.Run("Stops when add fails", func(t *testing.T) {
+		src := make(chan int, 3)
+		src <- 1
+		src <- 2
+		src <- 3
+		count := 0
+
+		require.NoError(t, <-FromChan(context.Background(), src, func(v int) bool { count++; return v < 2 }))
+		require.Equal(t, 2, count)
+		require.Equal(t, 3, <-src)
+	})
+
+	t.Run("Stops when context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := FromChan(ctx, make(chan int), func(int) bool { return true })
+		cancel()
+
+		require.ErrorIs(t, <-done, context.Canceled)
+		_, open := <-done
+		require.False(t, open)
+	})
+
+	t.Run("Nil source panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "src"), func() { FromChan[int](context.Background(), nil, nil) })
+	})
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"fmt"
 	"runtime"
 	"sync"
 	"unsafe"
@@ -214,6 +215,75 @@ func lastIndexOfConcurrent[T any](data []T, value T, compare functions.ComparerF
 	return <-resultsChan
 }
 
+// PageBounds validates pagination arguments and returns the start (inclusive)
+// and end (exclusive) indexes of the requested page within a collection of count elements.
+func PageBounds(count, pageIndex, pageSize int) (start, end int) {
+	if pageIndex < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "pageIndex"))
+	}
+
+	if pageSize < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "pageSize"))
+	}
+
+	if pageIndex > count/pageSize {
+		return count, count
+	}
+
+	start = pageIndex * pageSize
+	end = start + pageSize
+
+	if end > count {
+		end = count
+	}
+
+	return
+}
+
+// Thin returns every keepEvery'th value of the slice, starting with the first.
+func Thin[T any](values []T, keepEvery int) []T {
+	if keepEvery < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "keepEvery"))
+	}
+
+	thinned := make([]T, 0, (len(values)+keepEvery-1)/keepEvery)
+
+	for i := 0; i < len(values); i += keepEvery {
+		thinned = append(thinned, values[i])
+	}
+
+	return thinned
+}
+
+// Downsample divides the slice into n consecutive buckets whose sizes differ by at most one,
+// and returns the result of applying reducer to each bucket. If there are no more than n values,
+// each value is a bucket of its own.
+//
+// Each bucket passed to reducer has its capacity limited to its length,
+// so appending to it cannot overwrite the following bucket.
+func Downsample[T any](values []T, n int, reducer func([]T) T) []T {
+	if n < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	if reducer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "reducer"))
+	}
+
+	if len(values) < n {
+		n = len(values)
+	}
+
+	reduced := make([]T, n)
+
+	for i := 0; i < n; i++ {
+		start, end := i*len(values)/n, (i+1)*len(values)/n
+		reduced[i] = reducer(values[start:end:end])
+	}
+
+	return reduced
+}
+
 func Iif[T any](pred bool, trueVal T, falseVal T) T {
 	// Cannot use function calls as args to this function,
 	// because both calls are evaluated first
@@ -229,6 +299,36 @@ func DefaultDeepCopy[T any](value T) T {
 	return value
 }
 
+// ImportValues returns the values of collection for import by a collection with the given [collections.CopyPolicy],
+// deep copied by collection only if the policy is Deep.
+func ImportValues[T any](collection collections.Collection[T], policy collections.CopyPolicy) []T {
+	if policy == collections.Deep {
+		return collection.ToSliceDeep()
+	}
+
+	return collection.ToSlice()
+}
+
+// RetainAll removes from collection, by calling retainWhere, the values not contained in other,
+// for collections implementing [collections.Retainer]. Nothing is removed if other is collection,
+// as it cannot be read while collection is being modified.
+//
+// other is cloned before retainWhere takes the lock of collection, so that the lock of other is not
+// taken while that of collection is held, which would deadlock with a concurrent other.RetainAll(collection).
+//
+// Panics if other is nil.
+func RetainAll[T any](collection, other collections.Collection[T], retainWhere func(functions.PredicateFunc[T]) int) int {
+	if other == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "other"))
+	}
+
+	if other == collection {
+		return 0
+	}
+
+	return retainWhere(other.Clone().Contains)
+}
+
 func DeepCopy[T any](value T, f functions.DeepCopyFunc[T]) T {
 	if f == nil {
 		return value
@@ -356,7 +456,83 @@ func GetVersion[T any](c collections.Collection[T]) int {
 	return *(*int)((*eface)(unsafe.Pointer(&c)).val)
 }
 
+// versioned is implemented by collections that do not hold their version in their first member,
+// such as the copy-on-write collections.
+type versioned interface {
+	currentVersion() int
+}
+
+// CurrentVersion returns the version of a collection, under its read lock if it is thread-safe,
+// so that it may be compared with a version read earlier to determine whether the collection
+// has since been modified.
+func CurrentVersion[T any](c collections.Collection[T]) int {
+	if v, ok := c.(versioned); ok {
+		return v.currentVersion()
+	}
+
+	if lock := GetLock(c); lock != nil {
+		lock.RLock()
+		defer lock.RUnlock()
+	}
+
+	return GetVersion(c)
+}
+
 func GetLock[T any](c collections.Collection[T]) *sync.RWMutex {
 	// Expects lock to be the second member of the version struct, following version
 	return *(**sync.RWMutex)(unsafe.Add((*eface)(unsafe.Pointer(&c)).val, intSizeBytes))
 }
+
+// LockPair takes the read lock of src and the write lock of dst, either of which may be nil, in the order
+// of their addresses, so that concurrent operations that read one collection while writing another
+// cannot deadlock when called on the same two collections in opposite directions.
+// Release the locks with UnlockPair.
+func LockPair(src, dst *sync.RWMutex) {
+	if uintptr(unsafe.Pointer(src)) < uintptr(unsafe.Pointer(dst)) {
+		rLock(src)
+		lock(dst)
+	} else {
+		lock(dst)
+		rLock(src)
+	}
+}
+
+// UnlockPair releases the locks taken by LockPair.
+func UnlockPair(src, dst *sync.RWMutex) {
+	if dst != nil {
+		dst.Unlock()
+	}
+
+	if src != nil {
+		src.RUnlock()
+	}
+}
+
+func lock(l *sync.RWMutex) {
+	if l != nil {
+		l.Lock()
+	}
+}
+
+func rLock(l *sync.RWMutex) {
+	if l != nil {
+		l.RLock()
+	}
+}
+
+// ValidateIndex panics if index is not in the range 0 <= index < count.
+func ValidateIndex(index, count int) {
+	if index < 0 || index >= count {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "index"))
+	}
+}
+
+// Closest returns whichever of below and above is nearer to value by the given distance function,
+// preferring below if they are equally near.
+func Closest[T any](value, below, above T, distance functions.DistanceFunc[T]) T {
+	if distance(value, above) < distance(value, below) {
+		return above
+	}
+
+	return below
+}
@@ -3,7 +3,10 @@ package util
 import (
 	"fmt"
 	"math/rand"
+	"sync"
 	"testing"
+	"time"
+	"unsafe"
 
 	"github.com/stretchr/testify/require"
 )
@@ -49,6 +52,31 @@ func cmp(v1, v2 int) int {
 	return v1 - v2
 }
 
+func TestPageBounds(t *testing.T) {
+	tests := []struct {
+		count, pageIndex, pageSize int
+		start, end                 int
+	}{
+		{0, 0, 4, 0, 0},
+		{10, 0, 4, 0, 4},
+		{10, 1, 4, 4, 8},
+		{10, 2, 4, 8, 10},
+		{10, 3, 4, 10, 10},
+		{8, 2, 4, 8, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Count %d, page %d of size %d", tt.count, tt.pageIndex, tt.pageSize), func(t *testing.T) {
+			start, end := PageBounds(tt.count, tt.pageIndex, tt.pageSize)
+			require.Equal(t, tt.start, start)
+			require.Equal(t, tt.end, end)
+		})
+	}
+
+	require.Panics(t, func() { PageBounds(10, -1, 4) })
+	require.Panics(t, func() { PageBounds(10, 0, 0) })
+}
+
 func TestMinMax(t *testing.T) {
 	seed := int64(2163)
 	slc, expectedMin, expectedMax := CreateMinMaxTestData(DefaultCapacity, &seed)
@@ -278,3 +306,85 @@ func BenchmarkIndexOf(b *testing.B) {
 
 	}
 }
+
+func TestThin(t *testing.T) {
+	values := []int{0, 1, 2, 3, 4, 5, 6}
+
+	require.Equal(t, values, Thin(values, 1))
+	require.Equal(t, []int{0, 3, 6}, Thin(values, 3))
+	require.Equal(t, []int{0}, Thin(values, 10))
+	require.Empty(t, Thin([]int{}, 2))
+	require.Panics(t, func() { Thin(values, 0) })
+}
+
+func TestDownsample(t *testing.T) {
+	sum := func(bucket []int) int {
+		total := 0
+		for _, v := range bucket {
+			total += v
+		}
+		return total
+	}
+
+	values := []int{1, 2, 3, 4, 5, 6, 7}
+
+	require.Equal(t, []int{28}, Downsample(values, 1, sum))
+	require.Equal(t, []int{3, 7, 18}, Downsample(values, 3, sum))
+	require.Equal(t, values, Downsample(values, 10, sum))
+	require.Empty(t, Downsample([]int{}, 3, sum))
+
+	t.Run("Buckets cannot overwrite each other", func(t *testing.T) {
+		Downsample(values, 2, func(bucket []int) int {
+			_ = append(bucket, 0)
+			return 0
+		})
+		require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, values)
+	})
+
+	require.Panics(t, func() { Downsample(values, 0, sum) })
+	require.Panics(t, func() { Downsample[int](values, 1, nil) })
+}
+
+func TestLockPair(t *testing.T) {
+
+	a, b := &sync.RWMutex{}, &sync.RWMutex{}
+	lower, higher := a, b
+
+	if uintptr(unsafe.Pointer(b)) < uintptr(unsafe.Pointer(a)) {
+		lower, higher = b, a
+	}
+
+	for _, pair := range [][2]*sync.RWMutex{{lower, higher}, {higher, lower}} {
+		src, dst := pair[0], pair[1]
+
+		// Whichever role it has, the lock at the lower address is taken first,
+		// so the other is not held while waiting for it.
+		lower.Lock()
+		done := make(chan struct{})
+
+		go func() {
+			LockPair(src, dst)
+			close(done)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		require.True(t, higher.TryLock())
+		higher.Unlock()
+		lower.Unlock()
+		<-done
+
+		require.False(t, dst.TryRLock(), "dst should be write locked")
+		require.True(t, src.TryRLock(), "src should be read locked")
+		src.RUnlock()
+		UnlockPair(src, dst)
+		require.True(t, a.TryLock())
+		require.True(t, b.TryLock())
+		a.Unlock()
+		b.Unlock()
+	}
+
+	LockPair(nil, a)
+	UnlockPair(nil, a)
+	LockPair(a, nil)
+	UnlockPair(a, nil)
+}
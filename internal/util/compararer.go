@@ -19,6 +19,21 @@ type ordered interface {
 		~string
 }
 
+// GetZeroValueComparer is as GetDefaultComparer, for completing the construction
+// of the zero value of a collection, which cannot have been given a comparer.
+//
+// Panics with a message directing the user to New if T is not a supported type.
+func GetZeroValueComparer[T any]() functions.ComparerFunc[T] {
+	defer func() {
+		if recover() != nil {
+			var key T
+			panic(fmt.Sprintf(messages.ZERO_VALUE_COMPARER_FMT, key))
+		}
+	}()
+
+	return GetDefaultComparer[T]()
+}
+
 // GetDefaultComparer returns a function to compare two values of
 // types supported by this module.
 //
@@ -92,6 +107,49 @@ func GetDefaultComparer[T any]() functions.ComparerFunc[T] {
 	}
 }
 
+// GetOrderedComparer is as GetDefaultComparer for ordered types, selecting the comparer
+// with a type switch rather than reflection. Types defined on a built-in type, such as
+// type Celsius float64, are not matched by the switch and get functions.Compare.
+func GetOrderedComparer[T constraints.Ordered]() functions.ComparerFunc[T] {
+	var key T
+	var compare any
+
+	switch any(key).(type) {
+	case int:
+		compare = xxCompareInt
+	case int8:
+		compare = xxCompareSignedByte
+	case int16:
+		compare = xxCompareSignedWord
+	case int32:
+		compare = xxCompareSignedDword
+	case int64:
+		compare = xxCompareSignedQword
+	case uint:
+		compare = xxCompareUint
+	case uint8:
+		compare = xxCompareUnsignedByte
+	case uint16:
+		compare = xxCompareUnsignedWord
+	case uint32:
+		compare = xxCompareUnsignedDword
+	case uint64:
+		compare = xxCompareUnsignedQword
+	case uintptr:
+		compare = xxCompareUintptr
+	case float32:
+		compare = xxCompareFloat32
+	case float64:
+		compare = xxCompareFloat64
+	case string:
+		compare = xxCompareString
+	default:
+		return functions.Compare[T]
+	}
+
+	return compare.(func(T, T) int)
+}
+
 var xxCompareTime = func(t1, t2 time.Time) int {
 	d := t1.Sub(t2)
 	if d < 0 {
@@ -134,6 +192,18 @@ var xxCompareUnsignedQword = func(v1, v2 uint64) int {
 	return xxCompare(v1, v2)
 }
 
+var xxCompareInt = func(v1, v2 int) int {
+	return xxCompareSignedInt(v1, v2)
+}
+
+var xxCompareUint = func(v1, v2 uint) int {
+	return xxCompare(v1, v2)
+}
+
+var xxCompareUintptr = func(v1, v2 uintptr) int {
+	return xxCompare(v1, v2)
+}
+
 var xxCompareSignedByte = func(v1, v2 int8) int {
 	return xxCompareSignedInt(v1, v2)
 }
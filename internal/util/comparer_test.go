@@ -147,3 +147,26 @@ func TestComparePointerToStruct(t *testing.T) {
 	require.Equal(t, 0, f(aPtr, a2Ptr))
 	require.NotEqual(t, 0, f(aPtr, bPtr))
 }
+
+func TestOrderedComparer(t *testing.T) {
+
+	t.Run("Built-in types", func(t *testing.T) {
+		require.Less(t, GetOrderedComparer[int]()(-1, 0), 0)
+		require.Greater(t, GetOrderedComparer[uint]()(1, 0), 0)
+		require.Greater(t, GetOrderedComparer[uint8]()(255, 0), 0)
+		require.Less(t, GetOrderedComparer[int16]()(-300, 300), 0)
+		require.Greater(t, GetOrderedComparer[uintptr]()(2, 1), 0)
+		require.Zero(t, GetOrderedComparer[float32]()(1.5, 1.5))
+		require.Less(t, GetOrderedComparer[float64]()(1.5, 2.5), 0)
+		require.Less(t, GetOrderedComparer[string]()("a", "b"), 0)
+	})
+
+	t.Run("Defined types", func(t *testing.T) {
+		type celsius float64
+		type name string
+
+		require.Less(t, GetOrderedComparer[celsius]()(-1.5, 0), 0)
+		require.Greater(t, GetOrderedComparer[name]()("b", "a"), 0)
+		require.Zero(t, GetOrderedComparer[name]()("a", "a"))
+	})
+}
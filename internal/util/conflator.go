@@ -0,0 +1,98 @@
+package util
+
+// Conflator locates the value queued under the same key as a new value, so that a FIFO queue
+// can replace it in place rather than enqueuing another.
+//
+// The position of each key is recorded as a sequence number as values are appended to the tail,
+// so values appended and removed from the head are located in O(1). If the queue has been
+// rearranged in some other way (e.g. a value removed from the middle), the recorded position
+// is found to be stale, and the queue is searched instead.
+//
+// It is not thread-safe, and should be accessed under the owning collection's lock.
+type Conflator[T any] interface {
+	// Find returns the logical index of the queued value with the same key as value, or -1 if there is none.
+	// count is the number of values in the queue, and at returns the value at a logical index.
+	Find(value T, count int, at func(int) T) int
+
+	// Appended records that value has been appended to the tail of the queue,
+	// which now holds count values.
+	Appended(value T, count int, at func(int) T)
+
+	// Removed records that value is about to be removed from the head of the queue,
+	// which currently holds count values.
+	Removed(value T, count int)
+
+	// Reset forgets all recorded positions, then records the positions of the count
+	// values now in the queue, where at returns the value at a logical index.
+	Reset(count int, at func(int) T)
+}
+
+type conflator[T any, K comparable] struct {
+	key      func(T) K
+	index    map[K]int
+	appended int
+}
+
+// NewConflator returns a Conflator that identifies values by the key returned by keyFn.
+func NewConflator[T any, K comparable](keyFn func(T) K) Conflator[T] {
+	return &conflator[T, K]{
+		key:   keyFn,
+		index: make(map[K]int),
+	}
+}
+
+func (c *conflator[T, K]) Find(value T, count int, at func(int) T) int {
+	key := c.key(value)
+	seq, ok := c.index[key]
+
+	if !ok {
+		return -1
+	}
+
+	first := c.appended - count
+
+	if i := seq - first; i >= 0 && i < count && c.key(at(i)) == key {
+		return i
+	}
+
+	for i := 0; i < count; i++ {
+		if c.key(at(i)) == key {
+			c.index[key] = first + i
+			return i
+		}
+	}
+
+	delete(c.index, key)
+	return -1
+}
+
+func (c *conflator[T, K]) Appended(value T, count int, at func(int) T) {
+	c.index[c.key(value)] = c.appended
+	c.appended++
+
+	// Positions left behind by values removed other than from the head are not deleted,
+	// so rebuild the index once they outnumber the queued values.
+	if len(c.index) > 2*count+DefaultCapacity {
+		c.Reset(count, at)
+	}
+}
+
+func (c *conflator[T, K]) Removed(value T, count int) {
+	key := c.key(value)
+
+	if seq, ok := c.index[key]; ok && seq == c.appended-count {
+		delete(c.index, key)
+	}
+}
+
+func (c *conflator[T, K]) Reset(count int, at func(int) T) {
+	for key := range c.index {
+		delete(c.index, key)
+	}
+
+	c.appended = count
+
+	for i := 0; i < count; i++ {
+		c.index[c.key(at(i))] = i
+	}
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConflator(t *testing.T) {
+
+	queue := []int{}
+	at := func(i int) int { return queue[i] }
+	c := NewConflator(func(v int) int { return v % 10 }).(*conflator[int, int])
+
+	push := func(v int) {
+		if i := c.Find(v, len(queue), at); i != -1 {
+			queue[i] = v
+			return
+		}
+		queue = append(queue, v)
+		c.Appended(v, len(queue), at)
+	}
+
+	t.Run("Finds values appended and removed from head", func(t *testing.T) {
+		push(1)
+		push(2)
+		push(11)
+		require.Equal(t, []int{11, 2}, queue)
+
+		c.Removed(queue[0], len(queue))
+		queue = queue[1:]
+		push(21)
+		push(12)
+		require.Equal(t, []int{12, 21}, queue)
+	})
+
+	t.Run("Finds values after rearrangement", func(t *testing.T) {
+		queue[0], queue[1] = queue[1], queue[0]
+		push(32)
+		push(41)
+		require.Equal(t, []int{41, 32}, queue)
+	})
+
+	t.Run("Stale positions are bounded", func(t *testing.T) {
+		queue := []int{1, 2}
+		at := func(i int) int { return queue[i] }
+		c := NewConflator(func(v int) int { return v }).(*conflator[int, int])
+		c.Reset(len(queue), at)
+
+		for i := 10; i < 1000; i++ {
+			queue = append(queue, i)
+			c.Appended(i, len(queue), at)
+			// Remove from the tail, which is not recorded.
+			queue = queue[:len(queue)-1]
+			require.True(t, len(c.index) <= 2*(len(queue)+1)+DefaultCapacity)
+		}
+
+		require.Equal(t, 1, c.Find(2, len(queue), at))
+		require.Equal(t, -1, c.Find(500, len(queue), at))
+	})
+}
@@ -0,0 +1,432 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// CowCollection is the core of the copy-on-write collections.
+//
+// Writers apply each modification to a private working collection of type C while holding a mutex,
+// then atomically publish an immutable snapshot of its values. Readers load the latest snapshot,
+// so they never take a lock nor wait for a writer. Publishing a snapshot copies the values, so each
+// write is O(n), which suits collections that are read far more often than they are written.
+//
+// All elements yielded by iterators and Find belong to a snapshot, so are never invalidated
+// and their ValuePtr method panics. Values may be modified in place with ForEach, which is a write.
+type CowCollection[T any, C collections.Collection[T]] struct {
+	state   atomic.Pointer[cowState[T]]
+	writer  sync.Mutex
+	work    C
+	typ     collections.CollectionType
+	compare functions.ComparerFunc[T]
+	copy    functions.DeepCopyFunc[T]
+	derive  func([]T) collections.Collection[T]
+	index   func(C) func(T) (T, bool)
+
+	local.InternalImpl
+}
+
+// cowState is an immutable snapshot published by a CowCollection.
+type cowState[T any] struct {
+	values    []T
+	lookup    func(T) (T, bool)
+	recording bool
+	version   int
+}
+
+// NewCowCollection returns a copy-on-write collection whose writes are applied to work,
+// which must not be thread-safe nor be used elsewhere, and whose values are compared with compare
+// and deep copied with deepCopy. derive returns a new copy-on-write collection of the same type
+// holding the given values, for Map, Select and the like. If index is not nil, it is called after
+// each write to return a function that finds the stored value equal to a given value, which Contains
+// and Get use in place of a linear search of the snapshot. It must not refer to the working collection.
+func NewCowCollection[T any, C collections.Collection[T]](
+	work C,
+	compare functions.ComparerFunc[T],
+	deepCopy functions.DeepCopyFunc[T],
+	derive func([]T) collections.Collection[T],
+	index func(C) func(T) (T, bool),
+) *CowCollection[T, C] {
+	c := &CowCollection[T, C]{
+		work:    work,
+		typ:     work.Type(),
+		compare: compare,
+		copy:    deepCopy,
+		derive:  derive,
+		index:   index,
+	}
+
+	c.publish()
+	return c
+}
+
+// Update calls f with the working collection, and publishes the result as a single modification,
+// so that several changes may be made for the cost of one copy, and readers observe all or none of them.
+//
+// Other writers wait until f returns, so f must not modify this collection other than through
+// the working collection, nor retain the working collection or elements obtained from it.
+func (c *CowCollection[T, C]) Update(f func(C)) {
+	c.writer.Lock()
+	defer c.writer.Unlock()
+	defer c.publish()
+
+	f(c.work)
+}
+
+func (c *CowCollection[T, C]) publish() {
+	values := c.work.ToSlice()
+
+	s := &cowState[T]{
+		values:    values[:len(values):len(values)],
+		recording: c.work.IsRecording(),
+		version:   GetVersion[T](c.work),
+	}
+
+	if c.index != nil {
+		s.lookup = c.index(c.work)
+	}
+
+	c.state.Store(s)
+}
+
+func (c *CowCollection[T, C]) load() *cowState[T] {
+	return c.state.Load()
+}
+
+// currentVersion returns the version of the working collection when the latest snapshot was published.
+func (c *CowCollection[T, C]) currentVersion() int {
+	return c.load().version
+}
+
+// Add adds a value to the working collection and publishes the result.
+func (c *CowCollection[T, C]) Add(value T) bool {
+	var added bool
+	c.Update(func(w C) { added = w.Add(value) })
+	return added
+}
+
+// AddRange adds the values to the working collection and publishes the result.
+func (c *CowCollection[T, C]) AddRange(values []T) {
+	c.Update(func(w C) { w.AddRange(values) })
+}
+
+// AddCollection adds the values of the given collection to the working collection and publishes the result.
+func (c *CowCollection[T, C]) AddCollection(collection collections.Collection[T]) {
+	c.Update(func(w C) { w.AddCollection(collection) })
+}
+
+// ReplaceAll replaces the values of the working collection with those of the given collection and publishes the result.
+func (c *CowCollection[T, C]) ReplaceAll(collection collections.Collection[T]) {
+	c.Update(func(w C) { w.ReplaceAll(collection) })
+}
+
+// Clear removes all values and publishes the empty collection.
+func (c *CowCollection[T, C]) Clear() {
+	c.Update(func(w C) { w.Clear() })
+}
+
+// Remove removes the first occurrence of the value from the working collection and publishes the result.
+func (c *CowCollection[T, C]) Remove(value T) bool {
+	var removed bool
+	c.Update(func(w C) { removed = w.Remove(value) })
+	return removed
+}
+
+// ApplyOps applies the operations to the working collection and publishes the result.
+func (c *CowCollection[T, C]) ApplyOps(operations []ops.Op[T]) {
+	c.Update(func(w C) { w.ApplyOps(operations) })
+}
+
+// StartRecording begins capturing mutations of the working collection as ops.
+func (c *CowCollection[T, C]) StartRecording() {
+	c.Update(func(w C) { w.StartRecording() })
+}
+
+// StopRecording ends recording and returns the ops recorded since StartRecording.
+func (c *CowCollection[T, C]) StopRecording() []ops.Op[T] {
+	var recorded []ops.Op[T]
+	c.Update(func(w C) { recorded = w.StopRecording() })
+	return recorded
+}
+
+// IsRecording returns true if the collection is recording mutations.
+func (c *CowCollection[T, C]) IsRecording() bool {
+	return c.load().recording
+}
+
+// ForEach applies f to each element of the working collection, so that values may be
+// modified via the ValuePtr method of the elements, and publishes the result.
+func (c *CowCollection[T, C]) ForEach(f func(collections.Element[T])) {
+	c.Update(func(w C) { w.ForEach(f) })
+}
+
+// Contains returns true if the value is present in the latest snapshot.
+func (c *CowCollection[T, C]) Contains(value T) bool {
+	_, ok := c.find(value)
+	return ok
+}
+
+// Get returns an element of the value of the latest snapshot equal to the given value, or nil if it is not found.
+func (c *CowCollection[T, C]) Get(value T) collections.Element[T] {
+	if v, ok := c.find(value); ok {
+		return &SnapshotElement[T]{value: v}
+	}
+
+	return nil
+}
+
+// Count returns the number of values in the latest snapshot.
+func (c *CowCollection[T, C]) Count() int {
+	return len(c.load().values)
+}
+
+// IsEmpty returns true if the latest snapshot has no values.
+func (c *CowCollection[T, C]) IsEmpty() bool {
+	return len(c.load().values) == 0
+}
+
+// ToSlice returns a copy of the values of the latest snapshot.
+func (c *CowCollection[T, C]) ToSlice() []T {
+	values := c.load().values
+	return append(make([]T, 0, len(values)), values...)
+}
+
+// ToSliceDeep returns a deep copy of the values of the latest snapshot.
+func (c *CowCollection[T, C]) ToSliceDeep() []T {
+	return c.copyWhere(DefaultPredicate[T])
+}
+
+// ToImmutableSlice returns the values of the latest snapshot, which is shared and must not be modified.
+func (c *CowCollection[T, C]) ToImmutableSlice() []T {
+	return c.load().values
+}
+
+// Type returns the type of the working collection.
+func (c *CowCollection[T, C]) Type() collections.CollectionType {
+	return c.typ
+}
+
+// Any returns true for the first value of the latest snapshot for which the predicate returns true.
+func (c *CowCollection[T, C]) Any(predicate functions.PredicateFunc[T]) bool {
+	for _, v := range c.load().values {
+		if predicate(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All returns true if the predicate returns true for all values of the latest snapshot.
+func (c *CowCollection[T, C]) All(predicate functions.PredicateFunc[T]) bool {
+	for _, v := range c.load().values {
+		if !predicate(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Find returns an element of the first value of the latest snapshot for which the predicate returns true;
+// else nil.
+func (c *CowCollection[T, C]) Find(predicate functions.PredicateFunc[T]) collections.Element[T] {
+	for _, v := range c.load().values {
+		if predicate(v) {
+			return &SnapshotElement[T]{value: v}
+		}
+	}
+
+	return nil
+}
+
+// FindAll returns elements of all values of the latest snapshot for which the predicate returns true.
+func (c *CowCollection[T, C]) FindAll(predicate functions.PredicateFunc[T]) []collections.Element[T] {
+	found := []collections.Element[T]{}
+
+	for _, v := range c.load().values {
+		if predicate(v) {
+			found = append(found, &SnapshotElement[T]{value: v})
+		}
+	}
+
+	return found
+}
+
+// TryForEach calls f for each value of the latest snapshot, stopping at the first error
+// returned by f, which is returned. Returns nil if f returned no error.
+func (c *CowCollection[T, C]) TryForEach(f func(T) error) error {
+	for _, v := range c.load().values {
+		if err := f(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value of the latest snapshot, continuing after any error
+// returned by f. Returns all errors returned by f joined with errors.Join, or nil if there were none.
+func (c *CowCollection[T, C]) TryForEachAll(f func(T) error) error {
+	var errs []error
+
+	for _, v := range c.load().values {
+		if err := f(v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Min returns the minimum value of the latest snapshot.
+//
+// Panics if the collection is empty.
+func (c *CowCollection[T, C]) Min() T {
+	values := c.load().values
+
+	if len(values) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return Min(values, c.compare, false)
+}
+
+// Max returns the maximum value of the latest snapshot.
+//
+// Panics if the collection is empty.
+func (c *CowCollection[T, C]) Max() T {
+	values := c.load().values
+
+	if len(values) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return Max(values, c.compare, false)
+}
+
+// Map returns a new copy-on-write collection of the results of f applied to each value of the latest snapshot.
+func (c *CowCollection[T, C]) Map(f func(T) T) collections.Collection[T] {
+	values := c.load().values
+	mapped := make([]T, len(values))
+
+	for i, v := range values {
+		mapped[i] = f(v)
+	}
+
+	return c.derive(mapped)
+}
+
+// Select returns a new copy-on-write collection of the values of the latest snapshot for which the predicate is true.
+func (c *CowCollection[T, C]) Select(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+	var selected []T
+
+	for _, v := range c.load().values {
+		if predicate(v) {
+			selected = append(selected, v)
+		}
+	}
+
+	return c.derive(selected)
+}
+
+// SelectDeep returns a new copy-on-write collection of deep copies of the values of the latest snapshot
+// for which the predicate is true.
+func (c *CowCollection[T, C]) SelectDeep(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+	return c.derive(c.copyWhere(predicate))
+}
+
+// Clone returns a new copy-on-write collection of the values of the latest snapshot.
+func (c *CowCollection[T, C]) Clone() collections.Collection[T] {
+	return c.derive(c.load().values)
+}
+
+// CloneDeep returns a new copy-on-write collection of deep copies of the values of the latest snapshot.
+func (c *CowCollection[T, C]) CloneDeep() collections.Collection[T] {
+	return c.derive(c.copyWhere(DefaultPredicate[T]))
+}
+
+// CopyTo adds deep copies of the values of the latest snapshot for which the predicate is true to dest.
+func (c *CowCollection[T, C]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+	dest.AddRange(c.copyWhere(predicate))
+}
+
+// Iterator returns an iterator over the latest snapshot.
+func (c *CowCollection[T, C]) Iterator() collections.Iterator[T] {
+	return NewSnapshotIterator(c.load().values)
+}
+
+// TakeWhile returns an iterator over the values of the latest snapshot for which the predicate returns true.
+func (c *CowCollection[T, C]) TakeWhile(predicate functions.PredicateFunc[T]) collections.Iterator[T] {
+	var selected []T
+
+	for _, v := range c.load().values {
+		if predicate(v) {
+			selected = append(selected, v)
+		}
+	}
+
+	return NewSnapshotIterator(selected)
+}
+
+// SampleIterator returns an iterator that yields n values of the latest snapshot chosen at random.
+//
+// Panics if n is negative.
+func (c *CowCollection[T, C]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+	return NewSliceSampleIterator(c.load().values, n, r)
+}
+
+// SnapshotIterator returns an iterator over the latest snapshot, which is the same as Iterator.
+func (c *CowCollection[T, C]) SnapshotIterator() collections.Iterator[T] {
+	return NewSnapshotIterator(c.load().values)
+}
+
+// String returns a string representation of the latest snapshot.
+func (c *CowCollection[T, C]) String() string {
+	values := c.load().values
+	strs := make([]string, len(values))
+
+	for i, v := range values {
+		strs[i] = fmt.Sprint(v)
+	}
+
+	return c.typ.String() + "\n" + strings.Join(strs, ", ")
+}
+
+func (c *CowCollection[T, C]) find(value T) (T, bool) {
+	s := c.load()
+
+	if s.lookup != nil {
+		return s.lookup(value)
+	}
+
+	if i := IndexOf(s.values, value, c.compare, false); i >= 0 {
+		return s.values[i], true
+	}
+
+	var zero T
+	return zero, false
+}
+
+func (c *CowCollection[T, C]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+	values := []T{}
+
+	for _, v := range c.load().values {
+		if predicate(v) {
+			values = append(values, DeepCopy(v, c.copy))
+		}
+	}
+
+	return values
+}
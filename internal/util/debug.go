@@ -0,0 +1,15 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// AssertInvariant panics if condition is false, describing the violated invariant.
+// Used by the invariant checks that run after each modification when [Debug] is true.
+func AssertInvariant(condition bool, description string) {
+	if !condition {
+		panic(fmt.Sprintf(messages.INVARIANT_VIOLATED_FMT, description))
+	}
+}
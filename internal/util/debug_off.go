@@ -0,0 +1,24 @@
+//go:build !collections_debug
+
+package util
+
+// Debug is true when built with the collections_debug tag, enabling assertions
+// that verify the invariants of collections after each modification, and detect
+// unsynchronized concurrent access to collections that are not thread-safe.
+//
+// Calls guarded by Debug are removed by the compiler from other builds.
+const Debug = false
+
+// AccessGuard detects concurrent access to a collection that is not thread-safe.
+// It has no size and does nothing unless built with the collections_debug tag.
+type AccessGuard struct{}
+
+// Write does nothing unless built with the collections_debug tag.
+func (*AccessGuard) Write(bool, func()) func() {
+	return func() {}
+}
+
+// Read does nothing unless built with the collections_debug tag.
+func (*AccessGuard) Read(bool) func() {
+	return func() {}
+}
@@ -0,0 +1,63 @@
+//go:build collections_debug
+
+package util
+
+import (
+	"sync/atomic"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Debug is true when built with the collections_debug tag, enabling assertions
+// that verify the invariants of collections after each modification, and detect
+// unsynchronized concurrent access to collections that are not thread-safe.
+const Debug = true
+
+// AccessGuard detects concurrent access to a collection that is not thread-safe,
+// by marking the collection as owned by a writer, or by one or more readers,
+// for the duration of each operation. An operation that finds the collection owned by
+// a conflicting operation panics.
+//
+// Only the operations overlapping in time are detected, so a race may not surface on every run.
+type AccessGuard struct {
+	// Number of readers, or -1 if owned by a writer
+	state atomic.Int32
+}
+
+// Write marks the collection as owned by a writer and returns a function that verifies the invariants
+// of the collection and releases ownership, to be deferred. If locked is true, the collection's
+// own lock is held, so only the invariants are verified.
+func (g *AccessGuard) Write(locked bool, verifyInvariants func()) func() {
+	if locked {
+		return verifyInvariants
+	}
+
+	if !g.state.CompareAndSwap(0, -1) {
+		panic(messages.CONCURRENT_ACCESS)
+	}
+
+	return func() {
+		defer g.state.Store(0)
+		verifyInvariants()
+	}
+}
+
+// Read marks the collection as owned by a reader and returns a function that releases
+// ownership, to be deferred. If locked is true, the collection's own lock is held, so nothing is marked.
+func (g *AccessGuard) Read(locked bool) func() {
+	if locked {
+		return func() {}
+	}
+
+	for {
+		readers := g.state.Load()
+
+		if readers < 0 {
+			panic(messages.CONCURRENT_ACCESS)
+		}
+
+		if g.state.CompareAndSwap(readers, readers+1) {
+			return func() { g.state.Add(-1) }
+		}
+	}
+}
@@ -0,0 +1,57 @@
+//go:build collections_debug
+
+package util
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessGuard(t *testing.T) {
+
+	noop := func() {}
+
+	t.Run("Overlapping writes panic", func(t *testing.T) {
+		var g AccessGuard
+		release := g.Write(false, noop)
+
+		require.PanicsWithValue(t, messages.CONCURRENT_ACCESS, func() { g.Write(false, noop) })
+		require.PanicsWithValue(t, messages.CONCURRENT_ACCESS, func() { g.Read(false) })
+
+		release()
+		g.Write(false, noop)()
+	})
+
+	t.Run("Overlapping reads are permitted", func(t *testing.T) {
+		var g AccessGuard
+		release1 := g.Read(false)
+		release2 := g.Read(false)
+
+		require.PanicsWithValue(t, messages.CONCURRENT_ACCESS, func() { g.Write(false, noop) })
+
+		release1()
+		release2()
+		g.Write(false, noop)()
+	})
+
+	t.Run("Locked access is not marked", func(t *testing.T) {
+		var g AccessGuard
+		verified := false
+		release := g.Write(true, func() { verified = true })
+
+		g.Write(true, noop)()
+		g.Read(true)()
+		release()
+		require.True(t, verified)
+	})
+
+	t.Run("Invariants are verified on release", func(t *testing.T) {
+		var g AccessGuard
+		release := g.Write(false, func() { AssertInvariant(false, "broken") })
+
+		require.PanicsWithValue(t, "Collection invariant violated: broken", release)
+		g.Write(false, noop)()
+	})
+}
@@ -0,0 +1,72 @@
+package util
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// GobEncode encodes value with encoding/gob after a header holding the schema of the collection,
+// for collections implementing gob.GobEncoder.
+func GobEncode(s schema.Schema, value any) ([]byte, error) {
+	header, err := schema.Append(nil, s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(header)
+
+	if err := gob.NewEncoder(buf).Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes data written by GobEncode into the value pointed to by ptr,
+// for collections implementing gob.GobDecoder.
+//
+// If verify is true, returns an error if the data has no header, or its schema does not match expected.
+// Otherwise the header is skipped, and data written without a header is also decoded.
+func GobDecode(data []byte, ptr any, expected schema.Schema, verify bool) error {
+	actual, payload, err := schema.Read(data)
+
+	if err := checkSchema(actual, err, expected, verify); err != nil {
+		return err
+	}
+
+	return gob.NewDecoder(bytes.NewReader(payload)).Decode(ptr)
+}
+
+// ReadSchema reads the schema header at the start of r, and returns a reader of the data that follows it.
+//
+// If verify is true, returns an error if r has no header, or its schema does not match expected.
+// Otherwise the header is skipped, and data written without a header is returned unchanged.
+func ReadSchema(r io.Reader, expected schema.Schema, verify bool) (io.Reader, error) {
+	actual, payload, err := schema.ReadFrom(r)
+
+	if err := checkSchema(actual, err, expected, verify); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// checkSchema returns the error from reading a schema header, unless it was only missing and verify is false,
+// or if verify is true, the error from verifying the schema read against expected.
+func checkSchema(actual schema.Schema, err error, expected schema.Schema, verify bool) error {
+	switch {
+	case errors.Is(err, schema.ErrMissing) && !verify:
+		return nil
+	case err != nil:
+		return err
+	case verify:
+		return actual.Verify(expected)
+	default:
+		return nil
+	}
+}
@@ -31,6 +31,26 @@ func GosortDescending[T any](values []T, length int, compare functions.ComparerF
 	sort.Sort(descendingSortable[T]{sortable[T]{values[:length], compare}})
 }
 
+// GosortStable sorts values (in-place) with respect to the given ComparerFunc using Go's built in stable sort,
+// so that values which compare equal keep their relative order.
+func GosortStable[T any](values []T, length int, compare functions.ComparerFunc[T]) {
+	sort.Stable(ascendingSortable[T]{sortable[T]{values[:length], compare}})
+}
+
+// GosortStableDescending sorts values (in-place) in descending order with respect to the given ComparerFunc
+// using Go's built in stable sort, so that values which compare equal keep their relative order.
+func GosortStableDescending[T any](values []T, length int, compare functions.ComparerFunc[T]) {
+	sort.Stable(descendingSortable[T]{sortable[T]{values[:length], compare}})
+}
+
+// CompareSort returns a SortFunc that sorts values with f with respect to compare,
+// in place of the ComparerFunc passed to it.
+func CompareSort[T any](f SortFunc[T], compare functions.ComparerFunc[T]) SortFunc[T] {
+	return func(values []T, length int, _ functions.ComparerFunc[T]) {
+		f(values, length, compare)
+	}
+}
+
 func (s sortable[T]) Len() int {
 	return len(s.values)
 }
@@ -46,3 +66,46 @@ func (s ascendingSortable[T]) Less(i, j int) bool {
 func (s descendingSortable[T]) Less(i, j int) bool {
 	return s.compare(s.values[i], s.values[j]) > 0
 }
+
+// keyed sorts values by keys precomputed at the same indexes, moving both together.
+type keyed[T, K any] struct {
+	values     []T
+	keys       []K
+	compare    functions.ComparerFunc[K]
+	descending bool
+}
+
+// SortByKey sorts values (in-place) by the keys computed for them by key, with respect to the given ComparerFunc for keys.
+// Each key is computed once before sorting, rather than once per comparison, so where comparing
+// values is expensive but a cheaply compared key can be derived from each, far fewer comparisons of values are made.
+func SortByKey[T, K any](values []T, key func(T) K, compare functions.ComparerFunc[K], descending bool) {
+	keys := make([]K, len(values))
+
+	for i, v := range values {
+		keys[i] = key(v)
+	}
+
+	sort.Sort(keyed[T, K]{values, keys, compare, descending})
+}
+
+// KeySort returns a SortFunc that sorts values by key as per SortByKey,
+// in place of the ComparerFunc passed to it.
+func KeySort[T, K any](key func(T) K, compare functions.ComparerFunc[K], descending bool) SortFunc[T] {
+	return func(values []T, length int, _ functions.ComparerFunc[T]) {
+		SortByKey(values[:length], key, compare, descending)
+	}
+}
+
+func (s keyed[T, K]) Len() int {
+	return len(s.values)
+}
+
+func (s keyed[T, K]) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+func (s keyed[T, K]) Less(i, j int) bool {
+	c := s.compare(s.keys[i], s.keys[j])
+	return Iif(s.descending, c > 0, c < 0)
+}
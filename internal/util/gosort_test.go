@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"testing"
 
@@ -119,3 +120,62 @@ func BenchmarkGosort(b *testing.B) {
 		})
 	}
 }
+
+func TestSortByKey(t *testing.T) {
+
+	type record struct {
+		name string
+		age  int
+	}
+
+	values := []record{{"c", 30}, {"a", 10}, {"d", 40}, {"b", 20}}
+	calls := 0
+	key := func(r record) int { calls++; return r.age }
+
+	SortByKey(values, key, GetOrderedComparer[int](), false)
+	require.Equal(t, []record{{"a", 10}, {"b", 20}, {"c", 30}, {"d", 40}}, values)
+	require.Equal(t, len(values), calls)
+
+	SortByKey(values, key, GetOrderedComparer[int](), true)
+	require.Equal(t, []record{{"d", 40}, {"c", 30}, {"b", 20}, {"a", 10}}, values)
+
+	// The comparer passed to the SortFunc is not used
+	input := []int{3, 1, 2, 0}
+	KeySort(func(v int) int { return -v }, GetOrderedComparer[int](), false)(input, 3, nil)
+	require.Equal(t, []int{3, 2, 1, 0}, input)
+}
+
+func TestGosortNaN(t *testing.T) {
+
+	nan := math.NaN()
+	values := []float64{2, nan, math.Inf(-1), 1, nan}
+
+	Gosort(values, len(values), GetDefaultComparer[float64]())
+
+	require.True(t, math.IsNaN(values[0]) && math.IsNaN(values[1]))
+	require.Equal(t, []float64{math.Inf(-1), 1, 2}, values[2:])
+}
+
+func TestGosortStable(t *testing.T) {
+
+	type pair struct{ key, seq int }
+	byKey := func(a, b pair) int { return a.key - b.key }
+
+	input := make([]pair, 1000)
+	for i := range input {
+		input[i] = pair{i % 7, i}
+	}
+
+	descending := make([]pair, len(input))
+	copy(descending, input)
+
+	GosortStable(input, len(input), byKey)
+	require.True(t, sort.SliceIsSorted(input, func(i, j int) bool {
+		return input[i].key < input[j].key || (input[i].key == input[j].key && input[i].seq < input[j].seq)
+	}))
+
+	CompareSort(GosortStableDescending[pair], byKey)(descending, len(descending), nil)
+	require.True(t, sort.SliceIsSorted(descending, func(i, j int) bool {
+		return descending[i].key > descending[j].key || (descending[i].key == descending[j].key && descending[i].seq < descending[j].seq)
+	}))
+}
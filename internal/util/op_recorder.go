@@ -0,0 +1,161 @@
+package util
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/fireflycons/generic_collections/registry"
+)
+
+// OpRecorder captures the mutations of a collection as ops while recording is active.
+// The zero value is not recording. It is not thread-safe, and should be accessed
+// under the owning collection's lock.
+//
+// If counting is enabled, it also counts the mutations whether or not recording is active.
+type OpRecorder[T any] struct {
+	log       []ops.Op[T]
+	copy      functions.DeepCopyFunc[T]
+	recording bool
+	counter   *opCounter
+
+	// If trailingReset, the log ends with the ops of a Reset, beginning with the Clear at resetAt.
+	trailingReset bool
+	resetAt       int
+}
+
+// opCounter counts mutations. The counts may be read while the owning collection is being modified.
+type opCounter struct {
+	adds    atomic.Uint64
+	removes atomic.Uint64
+	clears  atomic.Uint64
+	resets  atomic.Uint64
+}
+
+// EnableCounting begins counting mutations, which continues for the life of the recorder.
+func (r *OpRecorder[T]) EnableCounting() {
+	if r.counter == nil {
+		r.counter = &opCounter{}
+	}
+}
+
+// Counts returns the mutations counted since EnableCounting was called.
+// It may be called without holding the owning collection's lock.
+func (r *OpRecorder[T]) Counts() registry.OpCounts {
+	if r.counter == nil {
+		return registry.OpCounts{}
+	}
+
+	return registry.OpCounts{
+		Adds:    r.counter.adds.Load(),
+		Removes: r.counter.removes.Load(),
+		Clears:  r.counter.clears.Load(),
+		Resets:  r.counter.resets.Load(),
+	}
+}
+
+// Start begins recording, discarding any previously recorded ops.
+// Recorded values are deep-copied with the given function.
+func (r *OpRecorder[T]) Start(copier functions.DeepCopyFunc[T]) {
+	r.log = nil
+	r.copy = copier
+	r.recording = true
+	r.trailingReset = false
+}
+
+// Stop ends recording and returns the recorded ops.
+func (r *OpRecorder[T]) Stop() []ops.Op[T] {
+	log := r.log
+	r.log = nil
+	r.recording = false
+	r.trailingReset = false
+	return log
+}
+
+// IsRecording returns true if recording is active.
+func (r *OpRecorder[T]) IsRecording() bool {
+	return r.recording
+}
+
+// Add records the addition of a value.
+func (r *OpRecorder[T]) Add(value T) {
+	if r.counter != nil {
+		r.counter.adds.Add(1)
+	}
+
+	if r.recording {
+		r.log = append(r.log, ops.Add(DeepCopy(value, r.copy)))
+		r.trailingReset = false
+	}
+}
+
+// Remove records the removal of a value.
+func (r *OpRecorder[T]) Remove(value T) {
+	if r.counter != nil {
+		r.counter.removes.Add(1)
+	}
+
+	if r.recording {
+		r.log = append(r.log, ops.Remove(DeepCopy(value, r.copy)))
+		r.trailingReset = false
+	}
+}
+
+// Clear records the removal of all values.
+func (r *OpRecorder[T]) Clear() {
+	if r.counter != nil {
+		r.counter.clears.Add(1)
+	}
+
+	if r.recording {
+		r.log = append(r.log, ops.Clear[T]())
+		r.trailingReset = false
+	}
+}
+
+// Reset records the entire content of the collection as a Clear followed by an Add of each value.
+// Used where a mutation cannot be expressed as a single Add or Remove.
+//
+// A Reset that directly follows another replaces it in the log, as it supersedes it,
+// so that repeated in-place mutations such as the swaps of a sort do not grow the log
+// by the size of the collection each time.
+//
+// The values function is only called if recording is active.
+func (r *OpRecorder[T]) Reset(values func() []T) {
+	if r.counter != nil {
+		r.counter.resets.Add(1)
+	}
+
+	if r.recording {
+		if r.trailingReset {
+			r.log = r.log[:r.resetAt]
+		}
+
+		r.trailingReset = true
+		r.resetAt = len(r.log)
+		r.log = append(r.log, ops.Clear[T]())
+		for _, v := range values() {
+			r.log = append(r.log, ops.Add(DeepCopy(v, r.copy)))
+		}
+	}
+}
+
+// ApplyOps applies each op by calling the corresponding function.
+//
+// Panics if an op has an invalid kind.
+func ApplyOps[T any](operations []ops.Op[T], add func(T), remove func(T), clear func()) {
+	for _, op := range operations {
+		switch op.Kind {
+		case ops.KindAdd:
+			add(op.Value)
+		case ops.KindRemove:
+			remove(op.Value)
+		case ops.KindClear:
+			clear()
+		default:
+			panic(fmt.Sprintf(messages.OP_KIND_INVALID_FMT, op.Kind))
+		}
+	}
+}
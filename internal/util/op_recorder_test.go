@@ -0,0 +1,49 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpRecorderReset(t *testing.T) {
+
+	values := func(v ...int) func() []int {
+		return func() []int { return v }
+	}
+
+	t.Run("Consecutive resets are coalesced", func(t *testing.T) {
+		var r OpRecorder[int]
+		r.Start(nil)
+		r.Add(1)
+		r.Reset(values(1, 2))
+		r.Reset(values(2, 1))
+		r.Reset(values(3))
+
+		require.Equal(t, []ops.Op[int]{ops.Add(1), ops.Clear[int](), ops.Add(3)}, r.Stop())
+	})
+
+	t.Run("Resets separated by other ops are kept", func(t *testing.T) {
+		var r OpRecorder[int]
+		r.Start(nil)
+		r.Reset(values(1))
+		r.Remove(1)
+		r.Reset(values(2))
+
+		require.Equal(t, []ops.Op[int]{ops.Clear[int](), ops.Add(1), ops.Remove(1), ops.Clear[int](), ops.Add(2)}, r.Stop())
+	})
+
+	t.Run("Reset is not coalesced with one from a previous recording", func(t *testing.T) {
+		var r OpRecorder[int]
+		r.Start(nil)
+		r.Reset(values(1))
+		r.Stop()
+
+		r.Start(nil)
+		r.Add(2)
+		r.Reset(values(3))
+
+		require.Equal(t, []ops.Op[int]{ops.Add(2), ops.Clear[int](), ops.Add(3)}, r.Stop())
+	})
+}
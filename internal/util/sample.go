@@ -0,0 +1,132 @@
+package util
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// SampleIterator yields a number of elements of a collection chosen at random, without replacement.
+//
+// The sample is drawn by reservoir sampling when Start is called, in a single pass over
+// the collection's forward iterator, so only the chosen elements are held.
+type SampleIterator[T any] struct {
+	IteratorBase[T]
+	collection collections.Collection[T]
+	iterator   func() collections.Iterator[T]
+	size       int
+	rand       *rand.Rand
+	sample     []collections.Element[T]
+	index      int
+
+	local.InternalImpl
+}
+
+// NewSampleIterator returns an iterator that yields n elements of collection chosen at random,
+// or all elements if there are no more than n, in random order. If r is nil, the default source
+// of the math/rand package is used.
+//
+// Panics if n is negative.
+func NewSampleIterator[T any](collection collections.Collection[T], n int, r *rand.Rand) *SampleIterator[T] {
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	return &SampleIterator[T]{
+		IteratorBase: IteratorBase[T]{
+			Version:    GetVersion(collection),
+			NilElement: nil,
+		},
+		collection: collection,
+		iterator:   collection.Iterator,
+		size:       n,
+		rand:       r,
+	}
+}
+
+// NewSliceSampleIterator returns an iterator that yields n of the given values chosen at random,
+// as [NewSampleIterator], for collections whose values are held in an immutable slice.
+//
+// Panics if n is negative.
+func NewSliceSampleIterator[T any](values []T, n int, r *rand.Rand) *SampleIterator[T] {
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	return &SampleIterator[T]{
+		iterator: func() collections.Iterator[T] { return NewSnapshotIterator(values) },
+		size:     n,
+		rand:     r,
+	}
+}
+
+// Start draws a new sample and returns its first element,
+// which will be nil if the collection is empty or n is zero.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *SampleIterator[T]) Start() collections.Element[T] {
+	i.validateIterator()
+
+	i.sample = i.sample[:0]
+	i.index = 0
+
+	if i.size == 0 {
+		return i.NilElement
+	}
+
+	iter := i.iterator()
+	seen := 0
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if seen < i.size {
+			i.sample = append(i.sample, e)
+		} else if j := i.intn(seen + 1); j < i.size {
+			i.sample[j] = e
+		}
+
+		seen++
+	}
+
+	// The reservoir is filled in collection order, so shuffle it.
+	for j := len(i.sample) - 1; j > 0; j-- {
+		k := i.intn(j + 1)
+		i.sample[j], i.sample[k] = i.sample[k], i.sample[j]
+	}
+
+	return i.current()
+}
+
+// Next returns the next element of the sample,
+// which will be nil if the end has been reached.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *SampleIterator[T]) Next() collections.Element[T] {
+	i.validateIterator()
+	i.index++
+	return i.current()
+}
+
+func (i *SampleIterator[T]) current() collections.Element[T] {
+	if i.index >= len(i.sample) {
+		return i.NilElement
+	}
+
+	return i.sample[i.index]
+}
+
+func (i *SampleIterator[T]) intn(n int) int {
+	if i.rand == nil {
+		return rand.Intn(n)
+	}
+
+	return i.rand.Intn(n)
+}
+
+func (i *SampleIterator[T]) validateIterator() {
+	if i.collection != nil && i.Version != GetVersion(i.collection) {
+		panic(messages.COLLECTION_MODIFIED)
+	}
+}
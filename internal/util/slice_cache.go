@@ -0,0 +1,45 @@
+package util
+
+// SliceCache holds a slice of the values of a collection, computed at a given version
+// of the collection. The zero value is empty.
+//
+// It is not thread-safe. A thread-safe collection may Load the cached slice under its read lock,
+// so that concurrent readers do not block one another, but must hold its write lock to Get or Invalidate it.
+type SliceCache[T any] struct {
+	values  []T
+	version int
+	valid   bool
+}
+
+// Load returns the cached slice and true if it was computed at the given version; else nil and false,
+// in which case the caller should take the write lock and call Get.
+func (c *SliceCache[T]) Load(version int) ([]T, bool) {
+	if !c.valid || c.version != version {
+		return nil, false
+	}
+
+	return c.values, true
+}
+
+// Get returns the cached slice if it was computed at the given version, else calls compute,
+// caches the result against version and returns it.
+//
+// The capacity of the returned slice is limited to its length, so that appending to it
+// allocates a new backing array rather than overwriting the cache.
+func (c *SliceCache[T]) Get(version int, compute func() []T) []T {
+	if !c.valid || c.version != version {
+		values := compute()
+		c.values = values[:len(values):len(values)]
+		c.version = version
+		c.valid = true
+	}
+
+	return c.values
+}
+
+// Invalidate discards the cached slice, so that the next call to Get recomputes it.
+// For modifications that do not change the version of the collection.
+func (c *SliceCache[T]) Invalidate() {
+	c.values = nil
+	c.valid = false
+}
@@ -0,0 +1,81 @@
+package util
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// SnapshotIterator yields the values of a slice taken from a collection, so that
+// the collection may be modified while iterating without invalidating the iterator.
+//
+// The slice is expected to be the collection's immutable slice, which is shared with other
+// callers and only recomputed after a modification, so taking a snapshot of a collection
+// that has not been modified since the last snapshot costs nothing.
+type SnapshotIterator[T any] struct {
+	values []T
+	index  int
+
+	local.InternalImpl
+}
+
+// SnapshotElement is an element yielded by a SnapshotIterator. It does not belong to
+// the collection, so remains valid whatever modifications are made to the collection.
+type SnapshotElement[T any] struct {
+	value T
+
+	local.InternalImpl
+}
+
+// NewSnapshotIterator returns an iterator that yields the given values in order.
+func NewSnapshotIterator[T any](values []T) *SnapshotIterator[T] {
+	return &SnapshotIterator[T]{
+		values: values,
+	}
+}
+
+// Start begins iteration at the first value of the snapshot and returns its element,
+// which will be nil if the snapshot is empty.
+func (i *SnapshotIterator[T]) Start() collections.Element[T] {
+	i.index = 0
+	return i.current()
+}
+
+// Next returns the element of the next value of the snapshot,
+// which will be nil if the end has been reached.
+func (i *SnapshotIterator[T]) Next() collections.Element[T] {
+	if i.index < len(i.values) {
+		i.index++
+	}
+
+	return i.current()
+}
+
+func (i *SnapshotIterator[T]) current() collections.Element[T] {
+	if i.index >= len(i.values) {
+		return nil
+	}
+
+	return &SnapshotElement[T]{value: i.values[i.index]}
+}
+
+// Value returns the value at the time the snapshot was taken.
+func (e *SnapshotElement[T]) Value() T {
+	return e.value
+}
+
+// ValuePtr panics, as the snapshot is shared and modifying
+// it would not modify the collection from which it was taken.
+func (e *SnapshotElement[T]) ValuePtr() *T {
+	panic(messages.SNAPSHOT_POINTER)
+}
+
+// IsValid returns true, as the snapshot is never modified.
+func (e *SnapshotElement[T]) IsValid() bool {
+	return true
+}
+
+// Refresh returns true, as the snapshot is never modified.
+func (e *SnapshotElement[T]) Refresh() bool {
+	return true
+}
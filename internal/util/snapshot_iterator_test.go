@@ -0,0 +1,28 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotIterator(t *testing.T) {
+
+	values := []int{1, 2, 3}
+	iter := NewSnapshotIterator(values)
+	var got []int
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		got = append(got, e.Value())
+		require.True(t, e.IsValid())
+		require.True(t, e.Refresh())
+		require.PanicsWithValue(t, messages.SNAPSHOT_POINTER, func() { e.ValuePtr() })
+	}
+
+	require.Equal(t, values, got)
+	require.Nil(t, iter.Next(), "Next should remain at the end")
+	require.Equal(t, 1, iter.Start().Value(), "Start should restart the snapshot")
+
+	require.Nil(t, NewSnapshotIterator[int](nil).Start())
+}
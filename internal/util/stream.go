@@ -0,0 +1,145 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// EncodeStream writes count followed by each value yielded by walk to w,
+// using enc to encode the values. The count is written as a uvarint.
+//
+// walk must call the given function for each value in turn, stopping if it returns false.
+func EncodeStream[T any](w io.Writer, count int, walk func(func(T) bool), enc func(io.Writer, T) error) error {
+	var header [binary.MaxVarintLen64]byte
+
+	if _, err := w.Write(header[:binary.PutUvarint(header[:], uint64(count))]); err != nil {
+		return err
+	}
+
+	var err error
+
+	walk(func(value T) bool {
+		err = enc(w, value)
+		return err == nil
+	})
+
+	return err
+}
+
+// DecodeStream reads a stream written by EncodeStream from r, using dec to decode
+// the values and passing each to add.
+//
+// Returns io.ErrUnexpectedEOF if the stream ends before all values have been read.
+func DecodeStream[T any](r io.Reader, dec func(io.Reader) (T, error), add func(T)) error {
+	count, err := binary.ReadUvarint(byteReader{r})
+
+	if err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+
+		return err
+	}
+
+	for ; count > 0; count-- {
+		value, err := dec(r)
+
+		if err != nil {
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+
+			return err
+		}
+
+		add(value)
+	}
+
+	return nil
+}
+
+// EncodeCheckpoint returns a header holding the schema s, followed by position and a stream
+// of count values written by EncodeStream. The position is written as a uvarint.
+func EncodeCheckpoint[T any](s schema.Schema, position, count int, walk func(func(T) bool), enc func(io.Writer, T) error) ([]byte, error) {
+	var header [binary.MaxVarintLen64]byte
+
+	prefix, err := schema.Append(nil, s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := bytes.NewBuffer(prefix)
+	state.Write(header[:binary.PutUvarint(header[:], uint64(position))])
+
+	if err := EncodeStream(state, count, walk, enc); err != nil {
+		return nil, err
+	}
+
+	return state.Bytes(), nil
+}
+
+// DecodeCheckpoint reads the position and values of a checkpoint written by EncodeCheckpoint,
+// using dec to decode the values.
+//
+// If verify is true, returns an error if the checkpoint has no header, or its schema does not match expected.
+// Otherwise the header is skipped, and checkpoints written without a header are also decoded.
+// Returns io.ErrUnexpectedEOF if the checkpoint ends before all values have been read.
+func DecodeCheckpoint[T any](state []byte, expected schema.Schema, verify bool, dec func(io.Reader) (T, error)) (int, []T, error) {
+	actual, state, err := schema.Read(state)
+
+	if err := checkSchema(actual, err, expected, verify); err != nil {
+		return 0, nil, err
+	}
+
+	r := bytes.NewReader(state)
+	position, err := binary.ReadUvarint(r)
+
+	if err != nil {
+		if err == io.EOF {
+			return 0, nil, io.ErrUnexpectedEOF
+		}
+
+		return 0, nil, err
+	}
+
+	if position > math.MaxInt {
+		return 0, nil, fmt.Errorf(messages.DECODE_INVALID_FMT, "checkpoint")
+	}
+
+	values := []T{}
+
+	if err = DecodeStream(r, dec, func(value T) {
+		values = append(values, value)
+	}); err != nil {
+		return 0, nil, err
+	}
+
+	return int(position), values, nil
+}
+
+// byteReader reads the stream header a byte at a time, so that no more
+// than the header is consumed from the underlying reader.
+type byteReader struct {
+	io.Reader
+}
+
+func (b byteReader) ReadByte() (byte, error) {
+	if br, ok := b.Reader.(io.ByteReader); ok {
+		return br.ReadByte()
+	}
+
+	var buf [1]byte
+
+	if _, err := io.ReadFull(b.Reader, buf[:]); err != nil {
+		return 0, err
+	}
+
+	return buf[0], nil
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/local"
 	"github.com/fireflycons/generic_collections/internal/messages"
 )
@@ -17,14 +18,18 @@ type ElementType[T any] struct {
 	Collection collections.Collection[T]
 	Version    int
 	ValueP     *T
+	value      T
+	compare    functions.ComparerFunc[T]
 	local.InternalImpl
 }
 
-func NewElementType[T any](collection collections.Collection[T], val *T) *ElementType[T] {
+func NewElementType[T any](collection collections.Collection[T], val *T, compare functions.ComparerFunc[T]) *ElementType[T] {
 	return &ElementType[T]{
 		Collection: collection,
 		Version:    GetVersion[T](collection),
 		ValueP:     val,
+		value:      *val,
+		compare:    compare,
 	}
 }
 
@@ -37,11 +42,53 @@ func (e *ElementType[T]) Value() T {
 
 func (e *ElementType[T]) ValuePtr() *T {
 	collectionType := e.Collection.Type()
-	if collectionType == collections.COLLECTION_HASHSET || collectionType == collections.COLLECTION_ORDEREDSET {
+	if collectionType == collections.COLLECTION_HASHSET || collectionType == collections.COLLECTION_ORDEREDSET ||
+		collectionType == collections.COLLECTION_SKIPLISTSET || collectionType == collections.COLLECTION_LINKEDHASHSET {
 		panic(messages.SET_POINTER_MODIFICATION)
 	}
+	if collectionType == collections.COLLECTION_PRIORITYQUEUE {
+		panic(messages.HEAP_POINTER_MODIFICATION)
+	}
 	if e.Version != GetVersion[T](e.Collection) {
 		panic(messages.COLLECTION_MODIFIED)
 	}
 	return e.ValueP
 }
+
+// Implemented by sets, which can locate a value directly.
+type getter[T any] interface {
+	Get(T) collections.Element[T]
+}
+
+func (e *ElementType[T]) IsValid() bool {
+	if e.Version != GetVersion[T](e.Collection) {
+		return false
+	}
+
+	// Track changes made via ValuePtr, so that Refresh locates the current value.
+	e.value = *e.ValueP
+	return true
+}
+
+func (e *ElementType[T]) Refresh() bool {
+	if e.IsValid() {
+		return true
+	}
+
+	var found collections.Element[T]
+
+	if set, ok := e.Collection.(getter[T]); ok {
+		found = set.Get(e.value)
+	} else {
+		found = e.Collection.Find(func(v T) bool { return e.compare(v, e.value) == 0 })
+	}
+
+	if found == nil {
+		return false
+	}
+
+	located := found.(*ElementType[T])
+	e.ValueP = located.ValueP
+	e.Version = located.Version
+	return true
+}
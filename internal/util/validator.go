@@ -0,0 +1,84 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+)
+
+// Validator checks values before they are inserted into a collection.
+// A nil *Validator accepts all values, so collections without a validator pay only a nil check.
+type Validator[T any] struct {
+	validate functions.ValidatorFunc[T]
+	policy   collections.ValidationPolicy
+}
+
+// NewValidator returns a Validator that checks values with validate,
+// dealing with invalid values according to policy.
+func NewValidator[T any](validate functions.ValidatorFunc[T], policy collections.ValidationPolicy) *Validator[T] {
+	return &Validator[T]{
+		validate: validate,
+		policy:   policy,
+	}
+}
+
+// Accept returns true if value is valid, or false if it is not and invalid values are rejected.
+//
+// Panics if value is not valid and the policy is to panic.
+func (v *Validator[T]) Accept(value T) bool {
+	err := v.Check(value)
+
+	if err == nil {
+		return true
+	}
+
+	if v.policy == collections.RejectInvalid {
+		return false
+	}
+
+	panic(err)
+}
+
+// Check returns nil if value is valid; else an error wrapping both [collections.ErrInvalidValue]
+// and the error of the validator, whatever the policy.
+func (v *Validator[T]) Check(value T) error {
+	if v == nil {
+		return nil
+	}
+
+	if err := v.validate(value); err != nil {
+		return fmt.Errorf("%w: %w", collections.ErrInvalidValue, err)
+	}
+
+	return nil
+}
+
+// Filter returns the valid values. The given slice is returned if all are valid,
+// else a new slice holding only the valid values.
+//
+// Panics if any value is not valid and the policy is to panic, having checked no
+// more values than necessary, so that the caller may validate before inserting any.
+func (v *Validator[T]) Filter(values []T) []T {
+	if v == nil {
+		return values
+	}
+
+	for i, value := range values {
+		if v.Accept(value) {
+			continue
+		}
+
+		valid := append(make([]T, 0, len(values)-1), values[:i]...)
+
+		for _, value := range values[i+1:] {
+			if v.Accept(value) {
+				valid = append(valid, value)
+			}
+		}
+
+		return valid
+	}
+
+	return values
+}
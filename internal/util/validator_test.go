@@ -0,0 +1,54 @@
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator(t *testing.T) {
+
+	errNegative := errors.New("negative")
+	validate := func(v int) error {
+		if v < 0 {
+			return errNegative
+		}
+
+		return nil
+	}
+
+	var none *Validator[int]
+	require.True(t, none.Accept(-1))
+	values := []int{1, -2, 3}
+	require.Equal(t, values, none.Filter(values))
+
+	reject := NewValidator(validate, collections.RejectInvalid)
+	require.True(t, reject.Accept(1))
+	require.False(t, reject.Accept(-1))
+	require.Equal(t, []int{1, 3}, reject.Filter(values))
+	require.Equal(t, []int{1, 3}, reject.Filter([]int{-1, 1, 3}))
+	require.Equal(t, []int{1, 3}, reject.Filter([]int{1, 3, -1}))
+
+	valid := []int{1, 2}
+	require.Same(t, &valid[0], &reject.Filter(valid)[0], "Filter should return the given slice if all values are valid")
+
+	panics := NewValidator(validate, collections.PanicOnInvalid)
+	require.True(t, panics.Accept(1))
+
+	require.NoError(t, none.Check(-1))
+	require.NoError(t, panics.Check(1))
+	err := panics.Check(-1)
+	require.ErrorIs(t, err, collections.ErrInvalidValue, "Check should not panic whatever the policy")
+	require.ErrorIs(t, err, errNegative)
+
+	defer func() {
+		err, ok := recover().(error)
+		require.True(t, ok)
+		require.ErrorIs(t, err, collections.ErrInvalidValue)
+		require.ErrorIs(t, err, errNegative)
+	}()
+
+	panics.Filter(values)
+}
@@ -0,0 +1,159 @@
+package util
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/fireflycons/generic_collections/functions"
+)
+
+// WaitStats reports how the waits of a Waiter were satisfied.
+type WaitStats struct {
+	// Waits is the number of calls to Wait.
+	Waits int
+
+	// Spun is the number of waits satisfied whilst spinning, without parking the goroutine.
+	Spun int
+
+	// Parked is the number of waits that parked the goroutine.
+	Parked int
+
+	// Cancelled is the number of waits ended by their context rather than a Signal.
+	Cancelled int
+
+	// Total is the total time spent waiting.
+	Total time.Duration
+
+	// Max is the longest single wait.
+	Max time.Duration
+}
+
+// Waiter is a condition variable for blocking collections that accepts a context, and that
+// optionally spins for a bounded number of iterations before parking the waiting goroutine.
+// When the condition is likely to change soon, as in a busy low-latency pipeline, spinning
+// avoids the cost of parking and waking the goroutine and so reduces tail latency.
+//
+// As with sync.Cond, Wait, Signal and Broadcast must be called with the owning collection's lock held,
+// and the caller should re-check its condition in a loop as Wait returns.
+//
+// Signal wakes only the longest waiting goroutine, so a collection that frees one slot
+// wakes one waiter for it rather than every waiter contending for the lock to find it taken.
+type Waiter struct {
+	spins   int
+	clock   functions.Clock
+	waiting []chan struct{}
+	stats   WaitStats
+}
+
+// NewWaiter returns a Waiter that spins up to spins times before parking,
+// timing its waits with clock.
+func NewWaiter(spins int, clock functions.Clock) *Waiter {
+	return &Waiter{
+		spins: spins,
+		clock: clock,
+	}
+}
+
+// Wait unlocks lock and waits for a call to Signal or for ctx to be done,
+// then locks lock again before returning.
+//
+// Returns the error of ctx if it was done before the wait was signalled.
+func (w *Waiter) Wait(ctx context.Context, lock sync.Locker) error {
+	wake := make(chan struct{})
+	w.waiting = append(w.waiting, wake)
+	start := w.clock.Now()
+	spun := false
+
+	lock.Unlock()
+
+	for i := 0; i < w.spins && !spun; i++ {
+		select {
+		case <-wake:
+			spun = true
+		default:
+			runtime.Gosched()
+		}
+	}
+
+	var err error
+
+	if !spun {
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+
+	lock.Lock()
+
+	if err != nil {
+		select {
+		case <-wake:
+			// Signalled as ctx was done. The wait is treated as signalled,
+			// else the signal would be lost to any other waiter.
+			err = nil
+		default:
+			w.remove(wake)
+		}
+	}
+
+	elapsed := w.clock.Now().Sub(start)
+	w.stats.Waits++
+	w.stats.Total += elapsed
+
+	if elapsed > w.stats.Max {
+		w.stats.Max = elapsed
+	}
+
+	switch {
+	case spun:
+		w.stats.Spun++
+	case err != nil:
+		w.stats.Parked++
+		w.stats.Cancelled++
+	default:
+		w.stats.Parked++
+	}
+
+	return err
+}
+
+// Signal wakes the goroutine that has waited longest in Wait, if any.
+func (w *Waiter) Signal() {
+	if len(w.waiting) == 0 {
+		return
+	}
+
+	close(w.waiting[0])
+	w.waiting[0] = nil
+	w.waiting = w.waiting[1:]
+}
+
+// Broadcast wakes all goroutines waiting in Wait. Every waiter then contends for the lock,
+// so this is for changes that may satisfy many waiters, such as closing the collection.
+func (w *Waiter) Broadcast() {
+	for i, wake := range w.waiting {
+		close(wake)
+		w.waiting[i] = nil
+	}
+
+	w.waiting = w.waiting[:0]
+}
+
+// Stats returns the statistics of the waits so far.
+func (w *Waiter) Stats() WaitStats {
+	return w.stats
+}
+
+// remove removes a cancelled wait from those waiting.
+func (w *Waiter) remove(wake chan struct{}) {
+	for i, c := range w.waiting {
+		if c == wake {
+			w.waiting = append(w.waiting[:i], w.waiting[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package util
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaiter(t *testing.T) {
+
+	waitFor := func(w *Waiter, lock *sync.Mutex, ready *bool) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		for !*ready {
+			require.NoError(t, w.Wait(context.Background(), lock))
+		}
+	}
+
+	t.Run("Parks until signalled", func(t *testing.T) {
+		var lock sync.Mutex
+		w := NewWaiter(0, functions.SystemClock)
+		ready := false
+		done := make(chan struct{})
+
+		go func() {
+			waitFor(w, &lock, &ready)
+			close(done)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		lock.Lock()
+		ready = true
+		w.Signal()
+		lock.Unlock()
+		<-done
+
+		lock.Lock()
+		defer lock.Unlock()
+		stats := w.Stats()
+		require.Equal(t, stats.Waits, stats.Parked)
+		require.Zero(t, stats.Spun)
+		require.Greater(t, stats.Max, time.Duration(0))
+	})
+
+	t.Run("Satisfied by spinning", func(t *testing.T) {
+		var lock sync.Mutex
+		w := NewWaiter(1<<30, functions.SystemClock)
+		ready := false
+		done := make(chan struct{})
+
+		go func() {
+			waitFor(w, &lock, &ready)
+			close(done)
+		}()
+
+		time.Sleep(time.Millisecond)
+		lock.Lock()
+		ready = true
+		w.Signal()
+		lock.Unlock()
+		<-done
+
+		lock.Lock()
+		defer lock.Unlock()
+		stats := w.Stats()
+		require.Equal(t, stats.Waits, stats.Spun)
+		require.Zero(t, stats.Parked)
+	})
+
+	t.Run("Cancelled by context", func(t *testing.T) {
+		var lock sync.Mutex
+		w := NewWaiter(10, functions.SystemClock)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+
+		lock.Lock()
+		defer lock.Unlock()
+		require.ErrorIs(t, w.Wait(ctx, &lock), context.DeadlineExceeded)
+
+		stats := w.Stats()
+		require.Equal(t, 1, stats.Waits)
+		require.Equal(t, 1, stats.Parked)
+		require.Equal(t, 1, stats.Cancelled)
+		require.Empty(t, w.waiting)
+	})
+
+	t.Run("Signal wakes a single waiter and Broadcast wakes the rest", func(t *testing.T) {
+		var lock sync.Mutex
+		w := NewWaiter(0, functions.SystemClock)
+		woken := 0
+		var wg sync.WaitGroup
+
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				lock.Lock()
+				defer lock.Unlock()
+				require.NoError(t, w.Wait(context.Background(), &lock))
+				woken++
+			}()
+		}
+
+		countWoken := func() int {
+			lock.Lock()
+			defer lock.Unlock()
+			return woken
+		}
+
+		require.Eventually(t, func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(w.waiting) == 3
+		}, time.Second, time.Millisecond)
+
+		lock.Lock()
+		w.Signal()
+		lock.Unlock()
+
+		require.Eventually(t, func() bool { return countWoken() == 1 }, time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		require.Equal(t, 1, countWoken())
+
+		lock.Lock()
+		w.Broadcast()
+		lock.Unlock()
+
+		wg.Wait()
+		require.Equal(t, 3, woken)
+		require.Empty(t, w.waiting)
+	})
+}
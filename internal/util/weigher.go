@@ -0,0 +1,64 @@
+package util
+
+import "github.com/fireflycons/generic_collections/internal/messages"
+
+// Weigher tracks the total weight of the values in a collection against a maximum,
+// so that a collection may be bounded by e.g. the size in bytes of its values rather than their number.
+//
+// It is not thread-safe, and should be accessed under the owning collection's lock.
+type Weigher[T any] struct {
+	weigh func(T) int
+	max   int
+	total int
+}
+
+// NewWeigher returns a Weigher that weighs values with weigh, against a maximum total of maxWeight.
+func NewWeigher[T any](weigh func(T) int, maxWeight int) *Weigher[T] {
+	return &Weigher[T]{
+		weigh: weigh,
+		max:   maxWeight,
+	}
+}
+
+// Weigh returns the weight of value.
+//
+// Panics if the weight is negative.
+func (w *Weigher[T]) Weigh(value T) int {
+	weight := w.weigh(value)
+
+	if weight < 0 {
+		panic(messages.WEIGHT_NEGATIVE)
+	}
+
+	return weight
+}
+
+// Fits returns true if changing the total weight by delta would not exceed the maximum.
+func (w *Weigher[T]) Fits(delta int) bool {
+	return w.total+delta <= w.max
+}
+
+// Adjust changes the total weight by delta, as values are added or removed.
+func (w *Weigher[T]) Adjust(delta int) {
+	w.total += delta
+}
+
+// Reset recalculates the total weight from the count values now in the collection,
+// where at returns the value at a logical index.
+func (w *Weigher[T]) Reset(count int, at func(int) T) {
+	w.total = 0
+
+	for i := 0; i < count; i++ {
+		w.total += w.Weigh(at(i))
+	}
+}
+
+// Total returns the total weight of the values in the collection.
+func (w *Weigher[T]) Total() int {
+	return w.total
+}
+
+// Max returns the maximum total weight.
+func (w *Weigher[T]) Max() int {
+	return w.max
+}
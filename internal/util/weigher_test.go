@@ -0,0 +1,32 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeigher(t *testing.T) {
+
+	values := []string{"a", "bb", "ccc"}
+	w := NewWeigher(func(s string) int { return len(s) }, 5)
+
+	require.Equal(t, 3, w.Weigh("abc"))
+	require.True(t, w.Fits(5))
+	require.False(t, w.Fits(6))
+
+	w.Reset(len(values), func(i int) string { return values[i] })
+	require.Equal(t, 6, w.Total())
+	require.True(t, w.Fits(-1))
+
+	w.Adjust(-3)
+	require.Equal(t, 3, w.Total())
+	require.Equal(t, 5, w.Max())
+
+	w.Reset(0, nil)
+	require.Equal(t, 0, w.Total())
+
+	negative := NewWeigher(func(int) int { return -1 }, 1)
+	require.PanicsWithValue(t, messages.WEIGHT_NEGATIVE, func() { negative.Weigh(1) })
+}
@@ -0,0 +1,749 @@
+/*
+Package arraylist implements a list backed by a slice.
+
+Values are stored contiguously, so access by index is O(1), at the cost of
+insertion and removal anywhere but the end of the list being O(n).
+*/
+package arraylist
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/lists"
+	"github.com/fireflycons/generic_collections/registry"
+	"golang.org/x/exp/constraints"
+)
+
+// Assert ArrayList implements required interfaces.
+var _ lists.List[int] = (*ArrayList[int])(nil)
+var _ collections.ReverseIterable[int] = (*ArrayList[int])(nil)
+var _ collections.Pageable[int] = (*ArrayList[int])(nil)
+
+// ArrayListOptionFunc is the signature of a function
+// for providing options to the ArrayList constructor.
+type ArrayListOptionFunc[T any] func(*ArrayList[T])
+
+// ArrayList represents a list of elements of type T stored in a slice.
+//
+// The zero value is an empty list with default options, ready to use. It is not thread-safe.
+type ArrayList[T any] struct {
+	version         int
+	lock            *sync.RWMutex
+	initialCapacity int
+	compare         functions.ComparerFunc[T]
+	copy            functions.DeepCopyFunc[T]
+	copyPolicy      collections.CopyPolicy
+	buffer          []T
+	concurrent      bool
+	recorder        util.OpRecorder[T]
+	guard           util.AccessGuard
+	immutable       util.SliceCache[T]
+	validator       *util.Validator[T]
+	verifySchema    bool
+	name            string
+	registration    *registry.Registration
+	local.InternalImpl
+}
+
+// New constructs an array list with initial capacity for 16 elements.
+func New[T any](options ...ArrayListOptionFunc[T]) *ArrayList[T] {
+	l := &ArrayList[T]{
+		initialCapacity: util.DefaultCapacity,
+	}
+
+	for _, o := range options {
+		o(l)
+	}
+
+	l.buffer = make([]T, 0, l.initialCapacity)
+
+	if l.copy == nil || l.copyPolicy == collections.Shallow {
+		l.copy = util.DefaultDeepCopy[T]
+	}
+
+	if l.compare == nil {
+		l.compare = util.GetDefaultComparer[T]()
+	}
+
+	l.register()
+
+	return l
+}
+
+// lazyInit completes the construction of a zero value ArrayList on its first modification,
+// as New would have done.
+func (l *ArrayList[T]) lazyInit() {
+	if l.compare != nil {
+		return
+	}
+
+	l.compare = util.GetZeroValueComparer[T]()
+
+	if l.copy == nil {
+		l.copy = util.DefaultDeepCopy[T]
+	}
+
+	if l.buffer == nil {
+		l.initialCapacity = util.DefaultCapacity
+		l.buffer = make([]T, 0, l.initialCapacity)
+	}
+}
+
+// Of constructs a new array list containing the given values, e.g. arraylist.Of(1, 2, 3).
+//
+// The values are added in order.
+func Of[T any](values ...T) *ArrayList[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	l := arraylist.OfWith([]arraylist.ArrayListOptionFunc[int]{arraylist.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []ArrayListOptionFunc[T], values ...T) *ArrayList[T] {
+	l := New(options...)
+	l.AddRange(values)
+	return l
+}
+
+// NewFunc is as [New], using compare to find values, e.g. in Contains and Sort.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...ArrayListOptionFunc[T]) *ArrayList[T] {
+	return New(append([]ArrayListOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...ArrayListOptionFunc[T]) *ArrayList[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[T any]() ArrayListOptionFunc[T] {
+	return func(l *ArrayList[T]) {
+		l.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function to enable concurrency feature.
+func WithConcurrent[T any]() ArrayListOptionFunc[T] {
+	return func(l *ArrayList[T]) {
+		l.concurrent = true
+	}
+}
+
+// Option function for New to set the initial capacity of the list
+// to something other than the default 16 values.
+func WithCapacity[T any](capacity int) ArrayListOptionFunc[T] {
+	if capacity < 0 {
+		panic(messages.NEGATIVE_CAPACITY)
+	}
+	return func(l *ArrayList[T]) {
+		l.initialCapacity = capacity
+	}
+}
+
+// Option function for New to provide a comparer function for values of type T.
+// Required if the element type is not numeric, bool, pointer or string.
+func WithComparer[T any](comparer functions.ComparerFunc[T]) ArrayListOptionFunc[T] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(l *ArrayList[T]) {
+		l.compare = comparer
+	}
+}
+
+// Option func to provide a deep copy implementation for collection elements.
+func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) ArrayListOptionFunc[T] {
+	// Can be nil
+	return func(l *ArrayList[T]) {
+		l.copy = copier
+	}
+}
+
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) ArrayListOptionFunc[T] {
+	return func(l *ArrayList[T]) {
+		l.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+func WithOptions[T any](options collections.CommonOptions[T]) ArrayListOptionFunc[T] {
+	opts := make([]ArrayListOptionFunc[T], 0, 6)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Concurrent {
+		opts = append(opts, WithConcurrent[T]())
+	}
+
+	if options.Capacity != 0 {
+		opts = append(opts, WithCapacity[T](options.Capacity))
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(l *ArrayList[T]) {
+		for _, o := range opts {
+			o(l)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection,
+// ReplaceAll, AddItemFirst, AddItemLast, InsertAt and Set with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) ArrayListOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(l *ArrayList[T]) {
+		l.validator = util.NewValidator(validator, policy)
+	}
+}
+
+// AddItemFirst adds the given value at the head of the list. O(n).
+func (l *ArrayList[T]) AddItemFirst(value T) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if l.validator.Accept(value) {
+		l.insertAt(0, value)
+	}
+}
+
+// AddItemLast adds the given value at the end of the list. O(1) amortized.
+func (l *ArrayList[T]) AddItemLast(value T) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if l.validator.Accept(value) {
+		l.append(value)
+	}
+}
+
+// Add adds a value to the end of the list.
+//
+// Returns true unless the value was rejected by the validator.
+func (l *ArrayList[T]) Add(value T) bool {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if !l.validator.Accept(value) {
+		return false
+	}
+
+	l.append(value)
+	return true
+}
+
+// Count returns the number of values in the list.
+func (l *ArrayList[T]) Count() int {
+
+	return len(l.buffer)
+}
+
+// IsEmpty returns true if the collection has no elements.
+func (l *ArrayList[T]) IsEmpty() bool {
+	return len(l.buffer) == 0
+}
+
+// AddRange adds a slice of values to the end of the list.
+func (l *ArrayList[T]) AddRange(values []T) {
+
+	if len(values) == 0 {
+		return
+	}
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if values = l.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
+	for _, v := range values {
+		l.recorder.Add(v)
+	}
+
+	l.buffer = append(l.buffer, values...)
+	l.version++
+}
+
+// AddCollection adds the values of the given collection to the end of this list.
+// Values are added in the order defined by the other collection.
+func (l *ArrayList[T]) AddCollection(collection collections.Collection[T]) {
+
+	l.AddRange(util.ImportValues(collection, l.copyPolicy))
+}
+
+// Clear removes all values from the list. The capacity of the list is retained.
+func (l *ArrayList[T]) Clear() {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+	l.clear()
+}
+
+// ReplaceAll replaces the content of the list with the values of the given collection.
+// Values are added in the order defined by the other collection.
+func (l *ArrayList[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	l.replaceAll(util.ImportValues(collection, l.copyPolicy))
+}
+
+// replaceAll replaces the values of the list with the given values under a single lock.
+func (l *ArrayList[T]) replaceAll(values []T) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	values = l.validator.Filter(values)
+	l.clear()
+	l.buffer = append(l.buffer, values...)
+	l.recordReset()
+}
+
+// Contains returns true if the given value is in the list; else false. Up to O(n).
+func (l *ArrayList[T]) Contains(value T) bool {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.indexOf(value) != -1
+}
+
+// Remove is an alias for [ArrayList.RemoveItem].
+func (l *ArrayList[T]) Remove(value T) bool {
+
+	return l.RemoveItem(value)
+}
+
+// RemoveItem searches the list for the first occurrence of value
+// and removes it, moving the values after it down. Up to O(n).
+//
+// Returns true if a value was removed; else false.
+func (l *ArrayList[T]) RemoveItem(value T) bool {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	index := l.indexOf(value)
+
+	if index == -1 {
+		return false
+	}
+
+	l.removeAt(index)
+	l.recorder.Remove(value)
+	return true
+}
+
+// RemoveFirst removes the value at the head of the list and returns it. O(n).
+//
+// Panics if list is empty.
+func (l *ArrayList[T]) RemoveFirst() T {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if len(l.buffer) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	item := l.removeAt(0)
+	l.recorder.Remove(item)
+	return item
+}
+
+// RemoveLast removes the value at the end of the list and returns it. O(1).
+//
+// Panics if list is empty.
+func (l *ArrayList[T]) RemoveLast() T {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if len(l.buffer) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return l.removeLast()
+}
+
+// TryRemoveFirst removes the value at the head of the list and returns it and true,
+// or the zero value of T and false if the list is empty.
+func (l *ArrayList[T]) TryRemoveFirst() (T, bool) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if len(l.buffer) == 0 {
+		var v T
+		return v, false
+	}
+
+	item := l.removeAt(0)
+	l.recorder.Remove(item)
+	return item, true
+}
+
+// TryRemoveLast removes the value at the end of the list and returns it and true,
+// or the zero value of T and false if the list is empty.
+func (l *ArrayList[T]) TryRemoveLast() (T, bool) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if len(l.buffer) == 0 {
+		var v T
+		return v, false
+	}
+
+	return l.removeLast(), true
+}
+
+// TrimExcess resizes the backing store's capacity
+// to match the number of elements in the list.
+func (l *ArrayList[T]) TrimExcess() {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	slc := make([]T, len(l.buffer))
+	copy(slc, l.buffer)
+	l.buffer = slc
+}
+
+// Page returns a copy of the values on the given zero-based page of the list,
+// counting from the head of the list. O(pageSize).
+//
+// Panics if pageIndex is negative or pageSize is less than 1.
+func (l *ArrayList[T]) Page(pageIndex, pageSize int) []T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	start, end := util.PageBounds(len(l.buffer), pageIndex, pageSize)
+	page := make([]T, end-start)
+	copy(page, l.buffer[start:end])
+	return page
+}
+
+// ToSlice returns a copy of the list content as a slice.
+func (l *ArrayList[T]) ToSlice() []T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.toSlice(false)
+}
+
+// ToImmutableSlice returns the content of the list as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the list has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified. Values modified in place, e.g. via [collections.Element.ValuePtr],
+// are not reflected until the list is otherwise modified.
+func (l *ArrayList[T]) ToImmutableSlice() []T {
+
+	if l.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		l.lock.RLock()
+		values, ok := l.immutable.Load(l.version)
+		l.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	return l.immutable.Get(l.version, func() []T { return l.toSlice(false) })
+}
+
+// ToSliceDeep returns the content of the collection as a slice using the provided [functions.DeepCopyFunc] if any.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (l *ArrayList[T]) ToSliceDeep() []T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.toSlice(true)
+}
+
+func (l *ArrayList[T]) toSlice(deepCopy bool) []T {
+	slc := make([]T, len(l.buffer))
+
+	if deepCopy {
+		util.DeepCopySlice(slc, l.buffer, l.copy)
+	} else {
+		copy(slc, l.buffer)
+	}
+
+	return slc
+}
+
+// Type returns the type of this collection.
+func (*ArrayList[T]) Type() collections.CollectionType {
+	return collections.COLLECTION_ARRAYLIST
+}
+
+// String returns a string representation of container.
+func (l *ArrayList[T]) String() string {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	var values []string
+	for _, value := range l.buffer {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+
+	return "ArrayList\n" + strings.Join(values, ", ")
+}
+
+func (l *ArrayList[T]) append(value T) {
+	l.buffer = append(l.buffer, value)
+	l.version++
+	l.recorder.Add(value)
+}
+
+// insertAt inserts value at index, moving the values from index onwards up.
+func (l *ArrayList[T]) insertAt(index int, value T) {
+	if index == len(l.buffer) {
+		l.append(value)
+		return
+	}
+
+	var empty T
+	l.buffer = append(l.buffer, empty)
+	copy(l.buffer[index+1:], l.buffer[index:])
+	l.buffer[index] = value
+	l.version++
+	l.recordReset()
+}
+
+// removeAt removes the value at index, moving the values after it down.
+// The caller records the removal.
+func (l *ArrayList[T]) removeAt(index int) T {
+	value := l.buffer[index]
+	last := len(l.buffer) - 1
+
+	copy(l.buffer[index:], l.buffer[index+1:])
+
+	// Release the reference held by the vacated slot
+	var empty T
+	l.buffer[last] = empty
+	l.buffer = l.buffer[:last]
+	l.version++
+	return value
+}
+
+func (l *ArrayList[T]) removeLast() T {
+	value := l.removeAt(len(l.buffer) - 1)
+	l.recordReset()
+	return value
+}
+
+func (l *ArrayList[T]) indexOf(value T) int {
+	return util.IndexOf(l.buffer, value, l.compare, l.concurrent)
+}
+
+func (l *ArrayList[T]) clear() {
+	var empty T
+	for i := range l.buffer {
+		l.buffer[i] = empty
+	}
+
+	l.buffer = l.buffer[:0]
+	l.version++
+	l.recorder.Clear()
+}
+
+func (l *ArrayList[T]) makeEmptyCopy(capacity int) *ArrayList[T] {
+	other := &ArrayList[T]{
+		initialCapacity: l.initialCapacity,
+		compare:         l.compare,
+		copy:            l.copy,
+		copyPolicy:      l.copyPolicy,
+		concurrent:      l.concurrent,
+		buffer:          make([]T, 0, capacity),
+	}
+
+	if l.lock != nil {
+		other.lock = &sync.RWMutex{}
+	}
+
+	// Completes construction of a copy of a zero value list
+	other.lazyInit()
+	return other
+}
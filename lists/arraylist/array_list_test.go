@@ -0,0 +1,339 @@
+package arraylist
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/stretchr/testify/require"
+)
+
+var indexOutOfRange = fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "index")
+
+func TestConstructor(t *testing.T) {
+
+	t.Run("Default capacity", func(t *testing.T) {
+		l := New[int]()
+
+		require.Equal(t, 16, cap(l.buffer))
+		require.Nil(t, l.lock)
+	})
+
+	t.Run("With common options", func(t *testing.T) {
+		magic := 42
+		opts := collections.CommonOptions[int]{
+			ThreadSafe: true,
+			Capacity:   100,
+			Comparer:   func(v1, v2 int) int { return magic },
+		}
+		l := New(WithOptions(opts))
+
+		require.NotNil(t, l.lock)
+		require.Equal(t, 100, cap(l.buffer))
+		require.Equal(t, magic, l.compare(1, 0))
+	})
+
+	t.Run("With nil comparer panics", func(t *testing.T) {
+		var comp func(v1, v2 int) int
+		require.Panics(t, func() { New(WithComparer(comp)) })
+	})
+
+	t.Run("With negative capacity panics", func(t *testing.T) {
+		require.PanicsWithValue(t, messages.NEGATIVE_CAPACITY, func() { New(WithCapacity[int](-1)) })
+	})
+}
+
+func TestIndexable(t *testing.T) {
+
+	t.Run("Get and Set", func(t *testing.T) {
+		l := Of(1, 2, 3)
+
+		require.Equal(t, 2, l.Get(1))
+		l.Set(1, 20)
+		require.Equal(t, []int{1, 20, 3}, l.ToSlice())
+		require.PanicsWithValue(t, indexOutOfRange, func() { l.Get(3) })
+		require.PanicsWithValue(t, indexOutOfRange, func() { l.Set(-1, 0) })
+	})
+
+	t.Run("InsertAt", func(t *testing.T) {
+		l := Of(2, 4)
+
+		l.InsertAt(0, 1)
+		l.InsertAt(2, 3)
+		l.InsertAt(4, 5)
+		require.Equal(t, []int{1, 2, 3, 4, 5}, l.ToSlice())
+		require.PanicsWithValue(t, indexOutOfRange, func() { l.InsertAt(6, 0) })
+	})
+
+	t.Run("RemoveAt", func(t *testing.T) {
+		l := Of(1, 2, 3, 4, 5)
+
+		require.Equal(t, 1, l.RemoveAt(0))
+		require.Equal(t, 4, l.RemoveAt(2))
+		require.Equal(t, 5, l.RemoveAt(2))
+		require.Equal(t, []int{2, 3}, l.ToSlice())
+		require.PanicsWithValue(t, indexOutOfRange, func() { l.RemoveAt(2) })
+	})
+
+	t.Run("IndexOf and LastIndexOf", func(t *testing.T) {
+		l := Of(1, 2, 3, 2, 1)
+
+		require.Equal(t, 1, l.IndexOf(2))
+		require.Equal(t, 3, l.LastIndexOf(2))
+		require.Equal(t, -1, l.IndexOf(9))
+		require.Equal(t, -1, l.LastIndexOf(9))
+	})
+
+	t.Run("Swap and CompareAt", func(t *testing.T) {
+		l := Of(1, 2, 3)
+
+		l.Swap(0, 2)
+		require.Equal(t, []int{3, 2, 1}, l.ToSlice())
+		require.Positive(t, l.CompareAt(0, 1))
+		require.Zero(t, l.CompareAt(1, 1))
+	})
+}
+
+func TestListMethods(t *testing.T) {
+
+	l := New[int]()
+	l.AddItemLast(2)
+	l.AddItemFirst(1)
+	l.AddRange([]int{3, 4, 3})
+
+	require.Equal(t, []int{1, 2, 3, 4, 3}, l.ToSlice())
+	require.True(t, l.Remove(3))
+	require.False(t, l.Remove(9))
+	require.Equal(t, []int{1, 2, 4, 3}, l.ToSlice())
+	require.Equal(t, 1, l.RemoveFirst())
+	require.Equal(t, 3, l.RemoveLast())
+	require.Equal(t, []int{2, 4}, l.ToSlice())
+
+	l.Clear()
+	require.True(t, l.IsEmpty())
+	require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { l.RemoveFirst() })
+	require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { l.RemoveLast() })
+
+	_, ok := l.TryRemoveFirst()
+	require.False(t, ok)
+	_, ok = l.TryRemoveLast()
+	require.False(t, ok)
+}
+
+func TestSort(t *testing.T) {
+
+	l := Of(3, 1, 4, 1, 5, 9, 2, 6)
+
+	sorted := l.Sorted()
+	require.Equal(t, []int{1, 1, 2, 3, 4, 5, 6, 9}, sorted.ToSlice())
+	require.Equal(t, []int{3, 1, 4, 1, 5, 9, 2, 6}, l.ToSlice())
+
+	l.SortDescending()
+	require.Equal(t, []int{9, 6, 5, 4, 3, 2, 1, 1}, l.ToSlice())
+}
+func TestSortByKey(t *testing.T) {
+
+	values := []string{"ccc", "a", "dddd", "bb"}
+	calls := 0
+	length := func(v string) int { calls++; return len(v) }
+
+	c := New[string]()
+	c.AddRange(values)
+	version := c.version
+
+	SortByKey(c, length)
+	require.Equal(t, []string{"a", "bb", "ccc", "dddd"}, c.ToSlice())
+	require.Equal(t, len(values), calls)
+	require.Greater(t, c.version, version)
+
+	SortByKeyDescending(c, length)
+	require.Equal(t, []string{"dddd", "ccc", "bb", "a"}, c.ToSlice())
+}
+
+func TestSortBy(t *testing.T) {
+
+	// Ordered differently by length than by the default comparer
+	byLength := func(a, b string) int { return len(a) - len(b) }
+
+	c := New[string]()
+	c.AddRange([]string{"zz", "a", "bbb", "yyyy"})
+	version := c.version
+
+	c.SortBy(byLength)
+	require.Equal(t, []string{"a", "zz", "bbb", "yyyy"}, c.ToSlice())
+	require.Greater(t, c.version, version)
+
+	c.SortBy(func(a, b string) int { return byLength(b, a) })
+	require.Equal(t, []string{"yyyy", "bbb", "zz", "a"}, c.ToSlice())
+
+	// The comparer of the collection is unchanged
+	c.Sort()
+	require.Equal(t, []string{"a", "bbb", "yyyy", "zz"}, c.ToSlice())
+
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"), func() { c.SortBy(nil) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"), func() { c.SortStableBy(nil) })
+}
+
+func TestSortStable(t *testing.T) {
+
+	type record struct {
+		key int
+		seq int
+	}
+
+	// Enough records with equal keys that an unstable sort would reorder them
+	records := make([]record, 200)
+	for i := range records {
+		records[i] = record{key: (i * 7) % 5, seq: i}
+	}
+
+	byKey := func(a, b record) int { return a.key - b.key }
+	byKeyDescending := func(a, b record) int { return b.key - a.key }
+
+	stableSorted := func(compare func(a, b record) int) []record {
+		expected := append([]record{}, records...)
+		sort.SliceStable(expected, func(i, j int) bool { return compare(expected[i], expected[j]) < 0 })
+		return expected
+	}
+
+	t.Run("SortStable", func(t *testing.T) {
+		c := NewFunc(byKey)
+		c.AddRange(records)
+		c.SortStable()
+		require.Equal(t, stableSorted(byKey), c.ToSlice())
+	})
+
+	t.Run("SortStableBy", func(t *testing.T) {
+		c := NewFunc(byKey)
+		c.AddRange(records)
+		c.SortStableBy(byKeyDescending)
+		require.Equal(t, stableSorted(byKeyDescending), c.ToSlice())
+	})
+}
+
+func TestPage(t *testing.T) {
+
+	l := New[int]()
+
+	for i := 0; i < 10; i++ {
+		l.Add(i)
+	}
+
+	require.Equal(t, []int{0, 1, 2}, l.Page(0, 3))
+	require.Equal(t, []int{9}, l.Page(3, 3))
+	require.Empty(t, l.Page(4, 3))
+}
+
+func TestIterator(t *testing.T) {
+
+	l := Of(3, 1, 2)
+
+	collect := func(iter collections.Iterator[int]) []int {
+		var values []int
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+		}
+		return values
+	}
+
+	require.Equal(t, []int{3, 1, 2}, collect(l.Iterator()))
+	require.Equal(t, []int{2, 1, 3}, collect(l.ReverseIterator()))
+	require.Equal(t, []int{2}, collect(l.TakeWhile(func(v int) bool { return v%2 == 0 })))
+	require.Nil(t, New[int]().ReverseIterator().Start())
+
+	// Elements are writable
+	iter := l.Iterator()
+	*iter.Start().ValuePtr() = 30
+	require.Equal(t, 30, l.Get(0))
+
+	iter = l.Iterator()
+	l.Add(4)
+	require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Start() })
+}
+
+func TestEnumerable(t *testing.T) {
+
+	l := Of(3, 1, 4, 5, 9, 2, 6)
+
+	require.Equal(t, 1, l.Min())
+	require.Equal(t, 9, l.Max())
+	require.Equal(t, 4, l.Find(func(v int) bool { return v%2 == 0 }).Value())
+	require.Len(t, l.FindAll(func(v int) bool { return v%2 == 0 }), 3)
+	require.Equal(t, []int{4, 2, 6}, l.Select(func(v int) bool { return v%2 == 0 }).ToSlice())
+	require.Equal(t, []int{6, 2, 8, 10, 18, 4, 12}, l.Map(func(v int) int { return v * 2 }).ToSlice())
+	require.True(t, l.Any(func(v int) bool { return v == 9 }))
+	require.False(t, l.All(func(v int) bool { return v < 9 }))
+	require.Equal(t, "ArrayList\n3, 1, 4, 5, 9, 2, 6", l.String())
+}
+
+func TestApplyOpsAndRecording(t *testing.T) {
+
+	l := Of(1, 2, 1)
+	l.ApplyOps([]ops.Op[int]{ops.Remove(1), ops.Add(3)})
+	require.Equal(t, []int{2, 1, 3}, l.ToSlice())
+
+	l.StartRecording()
+	l.Add(4)
+	l.RemoveAt(0)
+	l.Set(0, 10)
+	require.Equal(t, []ops.Op[int]{
+		ops.Add(4),
+		ops.Remove(2),
+		ops.Clear[int](),
+		ops.Add(10),
+		ops.Add(3),
+		ops.Add(4),
+	}, l.StopRecording())
+}
+
+func TestGob(t *testing.T) {
+
+	l := Of(3, 1, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(l))
+
+	decoded := New[int]()
+	require.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	require.Equal(t, []int{3, 1, 2}, decoded.ToSlice())
+}
+
+func TestZeroValue(t *testing.T) {
+
+	var l ArrayList[int]
+
+	require.True(t, l.IsEmpty())
+	require.False(t, l.Contains(1))
+	require.Equal(t, -1, l.IndexOf(1))
+	require.Empty(t, l.ToSlice())
+	require.Nil(t, l.Iterator().Start())
+	require.Empty(t, l.Select(func(int) bool { return true }).ToSlice())
+
+	l.InsertAt(0, 2)
+	l.AddItemFirst(1)
+	require.Equal(t, []int{1, 2}, l.ToSlice())
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+	})
+}
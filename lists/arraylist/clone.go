@@ -0,0 +1,20 @@
+package arraylist
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Clone returns a new ArrayList holding the values of this list, copied by value.
+// The result is that of Select with a predicate that is always true.
+func (l *ArrayList[T]) Clone() collections.Collection[T] {
+
+	return l.Select(util.DefaultPredicate[T])
+}
+
+// CloneDeep returns a new ArrayList holding the values of this list, copied using the provided
+// [functions.DeepCopyFunc] if any. The result is that of SelectDeep with a predicate that is always true.
+func (l *ArrayList[T]) CloneDeep() collections.Collection[T] {
+
+	return l.SelectDeep(util.DefaultPredicate[T])
+}
@@ -0,0 +1,315 @@
+package arraylist
+
+import (
+	"errors"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Enumerable[int] = (*ArrayList[int])(nil)
+
+// Any returns true for the first element found where the predicate function returns true.
+// It returns false if no element matches the predicate.
+func (l *ArrayList[T]) Any(predicate functions.PredicateFunc[T]) bool {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	for _, v := range l.buffer {
+		if predicate(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All applies the predicate function to every element in the collection,
+// and returns true if all elements match the predicate.
+func (l *ArrayList[T]) All(predicate functions.PredicateFunc[T]) bool {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	for _, v := range l.buffer {
+		if !predicate(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ForEach applies function f to all elements in the collection.
+func (l *ArrayList[T]) ForEach(f func(collections.Element[T])) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	for i := range l.buffer {
+		f(util.NewElementType[T](l, &l.buffer[i], l.compare))
+	}
+}
+
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (l *ArrayList[T]) TryForEach(f func(T) error) error {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	for _, v := range l.buffer {
+		if err := f(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (l *ArrayList[T]) TryForEachAll(f func(T) error) error {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	var errs []error
+
+	for _, v := range l.buffer {
+		if err := f(v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Map applies function f to all elements in the collection
+// and returns a new ArrayList containing the result of f.
+func (l *ArrayList[T]) Map(f func(T) T) collections.Collection[T] {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	l1 := l.makeEmptyCopy(len(l.buffer))
+
+	for _, v := range l.buffer {
+		l1.buffer = append(l1.buffer, f(v))
+	}
+
+	return l1
+}
+
+// Select returns a new ArrayList containing only the items for which predicate is true.
+func (l *ArrayList[T]) Select(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.doSelect(predicate, false)
+}
+
+// SelectDeep returns a new ArrayList containing only the items for which predicate is true
+//
+// Elements are deep copied to the new collection using the provided [functions.DeepCopyFunc] if any.
+func (l *ArrayList[T]) SelectDeep(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.doSelect(predicate, true)
+}
+
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Values are copied from the head of the list.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (l *ArrayList[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(l.copyWhere(predicate))
+}
+
+// Find finds the first occurrence of an element matching the predicate.
+//
+// The function returns nil if no match.
+func (l *ArrayList[T]) Find(predicate functions.PredicateFunc[T]) collections.Element[T] {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	result := l.find(predicate, false)
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result[0]
+}
+
+// FindAll finds all occurrences of an element matching the predicate.
+//
+// The function returns an empty slice if none match.
+func (l *ArrayList[T]) FindAll(predicate functions.PredicateFunc[T]) []collections.Element[T] {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.find(predicate, true)
+}
+
+// Min returns the minimum value in the collection according to the Comparer function.
+func (l *ArrayList[T]) Min() T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return util.Min(l.buffer, l.compare, l.concurrent)
+}
+
+// Max returns the maximum value in the collection according to the Comparer function.
+func (l *ArrayList[T]) Max() T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return util.Max(l.buffer, l.compare, l.concurrent)
+}
+
+func (l *ArrayList[T]) find(predicate functions.PredicateFunc[T], all bool) []collections.Element[T] {
+
+	result := make([]collections.Element[T], 0, util.DefaultCapacity)
+
+	for i := range l.buffer {
+		if predicate(l.buffer[i]) {
+			result = append(result, util.NewElementType[T](l, &l.buffer[i], l.compare))
+
+			if !all {
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func (l *ArrayList[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
+
+	l1 := l.makeEmptyCopy(util.DefaultCapacity)
+
+	for _, v := range l.buffer {
+		if predicate(v) {
+			if deepCopy {
+				l1.buffer = append(l1.buffer, util.DeepCopy(v, l.copy))
+			} else {
+				l1.buffer = append(l1.buffer, v)
+			}
+		}
+	}
+
+	return l1
+}
+
+func (l *ArrayList[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+
+	for _, v := range l.buffer {
+		if predicate(v) {
+			values = append(values, util.DeepCopy(v, l.copy))
+		}
+	}
+
+	return values
+}
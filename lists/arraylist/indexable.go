@@ -0,0 +1,186 @@
+package arraylist
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert Indexable implementation
+var _ collections.Indexable[int] = (*ArrayList[int])(nil)
+
+// Get returns the value at the given index, where index 0 is the head of the list. O(1).
+//
+// Panics if index is out of range.
+func (l *ArrayList[T]) Get(index int) T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	util.ValidateIndex(index, len(l.buffer))
+	return l.buffer[index]
+}
+
+// At is an alias for [ArrayList.Get].
+func (l *ArrayList[T]) At(index int) T {
+
+	return l.Get(index)
+}
+
+// Set replaces the value at the given index, where index 0 is the head of the list. O(1).
+//
+// Panics if index is out of range.
+func (l *ArrayList[T]) Set(index int, value T) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	util.ValidateIndex(index, len(l.buffer))
+
+	if !l.validator.Accept(value) {
+		return
+	}
+
+	l.buffer[index] = value
+	l.version++
+	l.recordReset()
+}
+
+// InsertAt inserts a value at the given index, moving the value at that index and those after it up. O(n).
+// An index equal to Count appends the value.
+//
+// Panics if index is out of range.
+func (l *ArrayList[T]) InsertAt(index int, value T) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	util.ValidateIndex(index, len(l.buffer)+1)
+
+	if l.validator.Accept(value) {
+		l.insertAt(index, value)
+	}
+}
+
+// RemoveAt removes and returns the value at the given index, moving the values after it down. O(n).
+//
+// Panics if index is out of range.
+func (l *ArrayList[T]) RemoveAt(index int) T {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	util.ValidateIndex(index, len(l.buffer))
+
+	// Removing the first occurrence of a value is replayed correctly by a Remove op.
+	if l.indexOf(l.buffer[index]) == index {
+		value := l.removeAt(index)
+		l.recorder.Remove(value)
+		return value
+	}
+
+	value := l.removeAt(index)
+	l.recordReset()
+	return value
+}
+
+// IndexOf returns the index of the first occurrence of the given value, or -1 if the value is not in the list.
+func (l *ArrayList[T]) IndexOf(value T) int {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.indexOf(value)
+}
+
+// LastIndexOf returns the index of the last occurrence of the given value, or -1 if the value is not in the list.
+func (l *ArrayList[T]) LastIndexOf(value T) int {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return util.LastIndexOf(l.buffer, value, l.compare, l.concurrent)
+}
+
+// Swap exchanges the values at the given indexes.
+//
+// Panics if either index is out of range.
+func (l *ArrayList[T]) Swap(i, j int) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	util.ValidateIndex(i, len(l.buffer))
+	util.ValidateIndex(j, len(l.buffer))
+	l.buffer[i], l.buffer[j] = l.buffer[j], l.buffer[i]
+	l.version++
+	l.recordReset()
+}
+
+// CompareAt compares the values at the given indexes using the collection's comparer.
+//
+// Panics if either index is out of range.
+func (l *ArrayList[T]) CompareAt(i, j int) int {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	util.ValidateIndex(i, len(l.buffer))
+	util.ValidateIndex(j, len(l.buffer))
+	return l.compare(l.buffer[i], l.buffer[j])
+}
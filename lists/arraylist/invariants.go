@@ -0,0 +1,10 @@
+package arraylist
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the list is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (l *ArrayList[T]) verifyInvariants() {
+	util.AssertInvariant(l.buffer != nil || l.compare == nil, "list buffer is nil")
+	util.AssertInvariant(len(l.buffer) <= cap(l.buffer), "list count exceeds capacity")
+}
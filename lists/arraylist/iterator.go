@@ -0,0 +1,155 @@
+package arraylist
+
+import (
+	"math/rand"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+type direction int
+
+const (
+	forward, reverse direction = 1, -1
+)
+
+// Assert interface implementation.
+var _ collections.Iterable[int] = (*ArrayList[int])(nil)
+
+// ArrayListIterator implements an iterator over the elements in the list.
+type ArrayListIterator[T any] struct {
+	util.IteratorBase[T]
+	list      *ArrayList[T]
+	index     int
+	predicate functions.PredicateFunc[T]
+	direction direction
+
+	local.InternalImpl
+}
+
+func newIterator[T any](list *ArrayList[T], predicate functions.PredicateFunc[T], direction direction) collections.Iterator[T] {
+	return &ArrayListIterator[T]{
+		list:      list,
+		predicate: predicate,
+		direction: direction,
+		IteratorBase: util.IteratorBase[T]{
+			Version:    list.version,
+			NilElement: nil,
+		},
+	}
+}
+
+// Iterator returns an iterator that walks the list from first to last element
+//
+//	iter := l.Iterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (l *ArrayList[T]) Iterator() collections.Iterator[T] {
+
+	return newIterator(l, util.DefaultPredicate[T], forward)
+}
+
+// ReverseIterator returns an iterator that walks the list from last to first element
+//
+//	iter := l.ReverseIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (l *ArrayList[T]) ReverseIterator() collections.Iterator[T] {
+
+	return newIterator(l, util.DefaultPredicate[T], reverse)
+}
+
+// TakeWhile returns a forward iterater that walks the collection returning only
+// those elements for which predicate returns true.
+//
+//	l := arraylist.New[int]()
+//	// add values
+//	iter := l.TakeWhile(func (val int) bool { return val % 2 == 0 })
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (l *ArrayList[T]) TakeWhile(predicate functions.PredicateFunc[T]) collections.Iterator[T] {
+
+	return newIterator(l, predicate, forward)
+}
+
+// SampleIterator returns an iterator that yields n values chosen at random from the list,
+// without replacement and in random order. If the list holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the list.
+// If r is nil, the default source of the math/rand package is used.
+//
+//	iter := l.SampleIterator(100, nil)
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// audit e.Value()
+//	}
+//
+// Panics if n is negative.
+func (l *ArrayList[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](l, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the list at the time of the call,
+// in the same order as ToSlice, so that the list may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the list's immutable slice, so it is only copied if the list has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the list, and their ValuePtr method panics.
+//
+//	iter := l.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		l.Remove(e.Value())
+//	}
+func (l *ArrayList[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(l.ToImmutableSlice())
+}
+
+// Start begins an iteration across the list returning the fisrt element,
+// which will be nil if the collection is empty.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *ArrayListIterator[T]) Start() collections.Element[T] {
+	i.validateIterator()
+
+	i.index = util.Iif(i.direction == forward, -1, len(i.list.buffer))
+	return i.Next()
+}
+
+// Next returns the next element in the list,
+// which will be nil if the end has been reached.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *ArrayListIterator[T]) Next() collections.Element[T] {
+	i.validateIterator()
+
+	for {
+		i.index += int(i.direction)
+
+		if i.index < 0 || i.index >= len(i.list.buffer) {
+			return i.NilElement
+		}
+
+		valPtr := &i.list.buffer[i.index]
+
+		if i.predicate(*valPtr) {
+			return util.NewElementType[T](i.list, valPtr, i.list.compare)
+		}
+	}
+}
+
+func (i *ArrayListIterator[T]) validateIterator() {
+	if i.Version != i.list.version {
+		panic(messages.COLLECTION_MODIFIED)
+	}
+}
@@ -0,0 +1,103 @@
+package arraylist
+
+import (
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// ApplyOps applies the given operations to the list in order.
+// Add appends the value, Remove removes the first occurrence of the value
+// searching from the head, and Clear empties the list.
+//
+// Panics if an op has an invalid kind.
+func (l *ArrayList[T]) ApplyOps(operations []ops.Op[T]) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	util.ApplyOps(
+		operations,
+		func(value T) {
+			if l.validator.Accept(value) {
+				l.append(value)
+			}
+		},
+		func(value T) {
+			if index := l.indexOf(value); index >= 0 {
+				l.removeAt(index)
+				l.recorder.Remove(value)
+			}
+		},
+		l.clear,
+	)
+
+	l.version++
+}
+
+// StartRecording begins capturing mutations of the list as ops,
+// discarding any ops previously recorded.
+//
+// Values appended to the list are recorded as Add, and values removed from the head
+// or by value are recorded as Remove. Other insertions and removals, Set, Swap and sorting
+// are recorded as a Clear followed by an Add of each value.
+func (l *ArrayList[T]) StartRecording() {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.recorder.Start(l.copy)
+}
+
+// StopRecording ends recording and returns the ops recorded since StartRecording.
+func (l *ArrayList[T]) StopRecording() []ops.Op[T] {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	return l.recorder.Stop()
+}
+
+// IsRecording returns true if the list is recording mutations.
+func (l *ArrayList[T]) IsRecording() bool {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.recorder.IsRecording()
+}
+
+// recordReset records the entire content of the list, for mutations
+// that cannot be expressed as a single Add or Remove.
+func (l *ArrayList[T]) recordReset() {
+	l.recorder.Reset(func() []T { return l.toSlice(false) })
+}
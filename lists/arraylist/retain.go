@@ -0,0 +1,61 @@
+package arraylist
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*ArrayList[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single pass,
+// moving the remaining values down in their order, and returns the number of values removed. O(n).
+func (l *ArrayList[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	kept := 0
+
+	for _, v := range l.buffer {
+		if predicate(v) {
+			l.buffer[kept] = v
+			kept++
+		}
+	}
+
+	removed := len(l.buffer) - kept
+
+	if removed == 0 {
+		return 0
+	}
+
+	// Release the references held by the vacated slots
+	var empty T
+	for i := kept; i < len(l.buffer); i++ {
+		l.buffer[i] = empty
+	}
+
+	l.buffer = l.buffer[:kept]
+	l.version++
+	l.recordReset()
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (l *ArrayList[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](l, other, l.RetainWhere)
+}
@@ -0,0 +1,142 @@
+package arraylist
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"golang.org/x/exp/constraints"
+)
+
+// Assert interface implementation.
+var _ collections.Sortable[int] = (*ArrayList[int])(nil)
+
+// Sort performs an in-place sort of this collection.
+//
+// The item with the smallest value will be placed at the head of the list.
+func (l *ArrayList[T]) Sort() {
+
+	l.sortInPlace(util.Gosort[T])
+}
+
+// Sorted returns a sorted copy of this list as a new list using the provided [functions.DeepCopyFunc] if any.
+func (l *ArrayList[T]) Sorted() collections.Collection[T] {
+
+	return l.sortedCopy(util.Gosort[T])
+}
+
+// SortDescending performs an in-place sort of this collection.
+//
+// The item with the largest value will be placed at the head of the list.
+func (l *ArrayList[T]) SortDescending() {
+
+	l.sortInPlace(util.GosortDescending[T])
+}
+
+// SortedDescending returns a descending order sorted copy of this list as a new list using the provided [functions.DeepCopyFunc] if any.
+func (l *ArrayList[T]) SortedDescending() collections.Collection[T] {
+
+	return l.sortedCopy(util.GosortDescending[T])
+}
+
+// SortBy performs an in-place sort of this collection with respect to the given comparer,
+// rather than the comparer of the list, which is unchanged.
+//
+// The item that comparer orders first will be placed at the head of the list.
+//
+// Panics if comparer is nil.
+func (l *ArrayList[T]) SortBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	l.sortInPlace(util.CompareSort(util.Gosort[T], comparer))
+}
+
+// SortStable performs an in-place sort of this collection as per Sort,
+// except that values which compare equal keep their relative order.
+func (l *ArrayList[T]) SortStable() {
+
+	l.sortInPlace(util.GosortStable[T])
+}
+
+// SortStableBy performs an in-place sort of this collection as per [ArrayList.SortBy],
+// except that values which compare equal keep their relative order.
+//
+// Panics if comparer is nil.
+func (l *ArrayList[T]) SortStableBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	l.sortInPlace(util.CompareSort(util.GosortStable[T], comparer))
+}
+
+func (l *ArrayList[T]) sortInPlace(f util.SortFunc[T]) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if len(l.buffer) < 2 {
+		return
+	}
+
+	f(l.buffer, len(l.buffer), l.compare)
+	l.version++
+	l.recordReset()
+}
+
+func (l *ArrayList[T]) sortedCopy(f util.SortFunc[T]) collections.Collection[T] {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	l1 := l.makeEmptyCopy(len(l.buffer))
+	l1.buffer = l1.buffer[:len(l.buffer)]
+	util.DeepCopySlice(l1.buffer, l.buffer, l.copy)
+
+	if len(l1.buffer) > 1 {
+		f(l1.buffer, len(l1.buffer), l1.compare)
+	}
+
+	return l1
+}
+
+// SortByKey performs an in-place sort of the list by the keys computed for its values by key.
+// The value with the smallest key will be placed at the head of the list.
+//
+// Each key is computed once before sorting, so where comparing values is expensive, e.g. comparing strings
+// with collation, sorting by a cheaply compared key derived from each value is much faster than Sort.
+func SortByKey[T any, K constraints.Ordered](l *ArrayList[T], key func(T) K) {
+
+	sortByKey(l, key, false)
+}
+
+// SortByKeyDescending is as [SortByKey], placing the value with the largest key at the head of the list.
+func SortByKeyDescending[T any, K constraints.Ordered](l *ArrayList[T], key func(T) K) {
+
+	sortByKey(l, key, true)
+}
+
+func sortByKey[T any, K constraints.Ordered](l *ArrayList[T], key func(T) K, descending bool) {
+
+	l.sortInPlace(util.KeySort(key, util.GetOrderedComparer[K](), descending))
+}
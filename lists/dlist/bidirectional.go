@@ -0,0 +1,124 @@
+package dlist
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.BidirectionalIterable[int] = (*DList[int])(nil)
+
+// DListBidirectionalIterator implements an iterator that can move in either direction along the list.
+type DListBidirectionalIterator[T any] struct {
+	util.IteratorBase[T]
+	list    *DList[T]
+	current *DListNode[T]
+	pastEnd bool
+
+	local.InternalImpl
+}
+
+// BidirectionalIterator returns an iterator that can move forwards and backwards along the list,
+// for instance to expand outwards from a given value.
+//
+//	iter := ll.BidirectionalIterator()
+//	iter.Seek(value)
+//
+//	for e := iter.Prev(); e != nil; e = iter.Prev() {
+//		// do something with the values preceding value
+//	}
+func (l *DList[T]) BidirectionalIterator() collections.BidirectionalIterator[T] {
+
+	return &DListBidirectionalIterator[T]{
+		list: l,
+		IteratorBase: util.IteratorBase[T]{
+			Version:    l.version,
+			NilElement: nil,
+		},
+	}
+}
+
+// Start begins an iteration across the DList returning the first element,
+// which will be nil if the collection is empty.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *DListBidirectionalIterator[T]) Start() collections.Element[T] {
+	i.validateIterator()
+
+	return i.moveTo(i.list.head, false)
+}
+
+// End begins an iteration across the DList returning the last element,
+// which will be nil if the collection is empty.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *DListBidirectionalIterator[T]) End() collections.Element[T] {
+	i.validateIterator()
+
+	return i.moveTo(i.list.tail, true)
+}
+
+// Next returns the next element in the list,
+// which will be nil if the end has been reached.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *DListBidirectionalIterator[T]) Next() collections.Element[T] {
+	i.validateIterator()
+
+	switch {
+	case i.current != nil:
+		return i.moveTo(i.current.next, true)
+	case i.pastEnd:
+		return i.NilElement
+	default:
+		return i.moveTo(i.list.head, true)
+	}
+}
+
+// Prev returns the previous element in the list,
+// which will be nil if the start has been reached.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *DListBidirectionalIterator[T]) Prev() collections.Element[T] {
+	i.validateIterator()
+
+	switch {
+	case i.current != nil:
+		return i.moveTo(i.current.prev, false)
+	case i.pastEnd:
+		return i.moveTo(i.list.tail, false)
+	default:
+		return i.NilElement
+	}
+}
+
+// Seek positions the iterator at the first element in the list that is equal to value
+// and returns it, or returns nil and positions the iterator beyond the end if there is none.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *DListBidirectionalIterator[T]) Seek(value T) collections.Element[T] {
+	i.validateIterator()
+
+	return i.moveTo(i.list.findNode(value, forward), true)
+}
+
+// moveTo positions the iterator at node. If node is nil, the iterator
+// is positioned beyond the end if pastEnd is set, else before the start.
+func (i *DListBidirectionalIterator[T]) moveTo(node *DListNode[T], pastEnd bool) collections.Element[T] {
+	i.current = node
+
+	if node == nil {
+		i.pastEnd = pastEnd
+		return i.NilElement
+	}
+
+	return util.NewElementType[T](i.list, &node.item, i.list.compare)
+}
+
+func (i *DListBidirectionalIterator[T]) validateIterator() {
+	if i.Version != i.list.version {
+		panic(messages.COLLECTION_MODIFIED)
+	}
+}
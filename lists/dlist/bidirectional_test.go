@@ -0,0 +1,100 @@
+package dlist
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBidirectionalIterator(t *testing.T) {
+
+	t.Run("Forwards and backwards", func(t *testing.T) {
+		l := Of(1, 2, 3, 4)
+		iter := l.BidirectionalIterator()
+
+		forwards := make([]int, 0, l.Count())
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			forwards = append(forwards, e.Value())
+		}
+
+		backwards := make([]int, 0, l.Count())
+		for e := iter.Prev(); e != nil; e = iter.Prev() {
+			backwards = append(backwards, e.Value())
+		}
+
+		require.Equal(t, []int{1, 2, 3, 4}, forwards)
+		require.Equal(t, []int{4, 3, 2, 1}, backwards)
+
+		require.Equal(t, 1, iter.Next().Value())
+	})
+
+	t.Run("Change direction", func(t *testing.T) {
+		l := Of(1, 2, 3, 4)
+		iter := l.BidirectionalIterator()
+
+		require.Equal(t, 4, iter.End().Value())
+		require.Equal(t, 3, iter.Prev().Value())
+		require.Equal(t, 4, iter.Next().Value())
+		require.Nil(t, iter.Next())
+		require.Nil(t, iter.Next())
+		require.Equal(t, 4, iter.Prev().Value())
+	})
+
+	t.Run("New iterator is before start", func(t *testing.T) {
+		iter := Of(1, 2).BidirectionalIterator()
+		require.Nil(t, iter.Prev())
+		require.Equal(t, 1, iter.Next().Value())
+	})
+
+	t.Run("Seek expands from value", func(t *testing.T) {
+		l := Of(5, 3, 8, 1)
+		iter := l.BidirectionalIterator()
+
+		require.Equal(t, 3, iter.Seek(3).Value())
+		require.Equal(t, 5, iter.Prev().Value())
+		require.Nil(t, iter.Prev())
+
+		require.Equal(t, 8, iter.Seek(8).Value())
+		require.Equal(t, 1, iter.Next().Value())
+	})
+
+	t.Run("Seek missing value", func(t *testing.T) {
+		iter := Of(1, 2, 3).BidirectionalIterator()
+
+		require.Nil(t, iter.Seek(10))
+		require.Equal(t, 3, iter.Prev().Value())
+	})
+
+	t.Run("Empty list", func(t *testing.T) {
+		iter := New[int]().BidirectionalIterator()
+
+		require.Nil(t, iter.Start())
+		require.Nil(t, iter.End())
+		require.Nil(t, iter.Next())
+		require.Nil(t, iter.Prev())
+	})
+
+	t.Run("ValuePtr modifies list", func(t *testing.T) {
+		l := Of(1, 2, 3)
+		*l.BidirectionalIterator().End().ValuePtr() = 30
+		require.Equal(t, []int{1, 2, 30}, l.ToSlice())
+	})
+
+	t.Run("Panics if list modified", func(t *testing.T) {
+		l := Of(1, 2, 3)
+		iter := l.BidirectionalIterator()
+		iter.Start()
+		l.Add(4)
+
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Prev() })
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Seek(1) })
+	})
+
+	t.Run("Implements BidirectionalIterable", func(t *testing.T) {
+		var c any = New[int]()
+		_, ok := c.(collections.BidirectionalIterable[int])
+		require.True(t, ok)
+	})
+}
@@ -0,0 +1,106 @@
+package dlist
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// CloneInto replaces the content of dst with a deep copy of this list's values,
+// using the provided [functions.DeepCopyFunc] if any.
+//
+// The existing nodes of dst are reused to hold the copied values, so periodically cloning into
+// the same destination only allocates when this list is longer than dst. Surplus nodes of dst
+// are detached and invalidated. The comparer, deep copy function and copy policy of this list are copied to dst;
+// its thread safety is not changed. If this list was created with [WithStableIDs], so is dst,
+// and each node of dst has the ID of the node it was copied from.
+//
+// Panics if dst is nil.
+func (l *DList[T]) CloneInto(dst *DList[T]) {
+
+	if dst == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dst"))
+	}
+
+	if dst == l {
+		return
+	}
+
+	// Locked in a consistent order, so that a concurrent dst.CloneInto(l) cannot deadlock
+	util.LockPair(l.lock, dst.lock)
+	defer util.UnlockPair(l.lock, dst.lock)
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	if util.Debug {
+		defer dst.guard.Write(dst.lock != nil, dst.verifyInvariants)()
+	}
+
+	dst.lazyInit()
+
+	var prev *DListNode[T]
+	target := dst.head
+
+	for node := l.head; node != nil; node = node.next {
+		if target == nil {
+			target = &DListNode[T]{list: dst}
+			target.prev = prev
+
+			if prev == nil {
+				dst.head = target
+			} else {
+				prev.next = target
+			}
+		}
+
+		target.item = util.DeepCopy(node.item, l.copy)
+		target.id = node.id
+		prev = target
+		target = target.next
+	}
+
+	// Detach surplus nodes
+	var empty T
+	for target != nil {
+		next := target.next
+		target.invalidate()
+		target.item = empty
+		target.id = 0
+		target = next
+	}
+
+	if prev == nil {
+		dst.head = nil
+	} else {
+		prev.next = nil
+	}
+
+	dst.tail = prev
+	dst.count = l.count
+	dst.compare = l.compare
+	dst.copy = l.copy
+	dst.copyPolicy = l.copyPolicy
+	dst.stableIDs = l.stableIDs
+	dst.nextID = l.nextID
+	dst.reindexIDs()
+	dst.version++
+	dst.recordReset()
+}
+
+// Clone returns a new DList holding the values of this list, copied by value.
+// The result is that of Select with a predicate that is always true.
+func (l *DList[T]) Clone() collections.Collection[T] {
+
+	return l.Select(util.DefaultPredicate[T])
+}
+
+// CloneDeep returns a new DList holding the values of this list, copied using the provided
+// [functions.DeepCopyFunc] if any. The result is that of SelectDeep with a predicate that is always true.
+func (l *DList[T]) CloneDeep() collections.Collection[T] {
+
+	return l.SelectDeep(util.DefaultPredicate[T])
+}
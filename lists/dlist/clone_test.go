@@ -0,0 +1,75 @@
+package dlist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneInto(t *testing.T) {
+
+	t.Run("Clone into shorter list allocates new nodes", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2, 3, 4})
+		dst := New[int]()
+		dst.Add(9)
+		first := dst.First()
+		ll.CloneInto(dst)
+
+		require.Equal(t, []int{1, 2, 3, 4}, dst.ToSlice())
+		require.Equal(t, 4, dst.Count())
+		require.Same(t, first, dst.First())
+		require.Equal(t, 4, dst.Last().Value())
+		dst.Add(5)
+		require.Equal(t, []int{1, 2, 3, 4, 5}, dst.ToSlice())
+		require.Equal(t, []int{1, 2, 3, 4}, ll.ToSlice())
+	})
+
+	t.Run("Clone into longer list detaches surplus nodes", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2})
+		dst := New[int]()
+		dst.AddRange([]int{9, 8, 7, 6})
+		last := dst.Last()
+		ll.CloneInto(dst)
+
+		require.Equal(t, []int{1, 2}, dst.ToSlice())
+		require.Equal(t, 2, dst.Last().Value())
+		require.Nil(t, last.List())
+		dst.Add(3)
+		require.Equal(t, []int{1, 2, 3}, dst.ToSlice())
+	})
+
+	t.Run("Clone of empty list empties destination", func(t *testing.T) {
+		ll := New[int]()
+		dst := New[int]()
+		dst.AddRange([]int{9, 8})
+		ll.CloneInto(dst)
+
+		require.True(t, dst.IsEmpty())
+		require.Nil(t, dst.First())
+		require.Nil(t, dst.Last())
+	})
+
+	t.Run("Destination nodes are reused", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2, 3, 4})
+		dst := New[int]()
+		ll.CloneInto(dst)
+
+		if util.Debug {
+			t.Skip("assertions allocate in debug builds")
+		}
+
+		allocs := testing.AllocsPerRun(10, func() { ll.CloneInto(dst) })
+		require.Zero(t, allocs)
+	})
+
+	t.Run("Nil destination panics", func(t *testing.T) {
+		ll := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dst"), func() { ll.CloneInto(nil) })
+	})
+}
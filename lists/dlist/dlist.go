@@ -14,25 +14,41 @@ import (
 	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/fireflycons/generic_collections/lists"
+	"github.com/fireflycons/generic_collections/registry"
+	"golang.org/x/exp/constraints"
 )
 
 // Assert DList implements required interfaces.
 var _ lists.List[int] = (*DList[int])(nil)
 var _ collections.ReverseIterable[int] = (*DList[int])(nil)
+var _ collections.Pageable[int] = (*DList[int])(nil)
 
 // DListOptionFunc is the signature of a function
 // for providing options to the DList constructor.
 type DListOptionFunc[T any] func(*DList[T])
 
 // DList represents a doubly linked list of elements of type T.
+//
+// The zero value is an empty list with default options, ready to use. It is not thread-safe.
 type DList[T any] struct {
-	version int
-	lock    *sync.RWMutex
-	head    *DListNode[T]
-	tail    *DListNode[T]
-	count   int
-	compare functions.ComparerFunc[T]
-	copy    functions.DeepCopyFunc[T]
+	version      int
+	lock         *sync.RWMutex
+	head         *DListNode[T]
+	tail         *DListNode[T]
+	count        int
+	compare      functions.ComparerFunc[T]
+	copy         functions.DeepCopyFunc[T]
+	copyPolicy   collections.CopyPolicy
+	recorder     util.OpRecorder[T]
+	guard        util.AccessGuard
+	immutable    util.SliceCache[T]
+	validator    *util.Validator[T]
+	verifySchema bool
+	stableIDs    bool
+	nextID       uint64
+	ids          map[uint64]*DListNode[T]
+	name         string
+	registration *registry.Registration
 	local.InternalImpl
 }
 
@@ -44,7 +60,7 @@ func New[T any](options ...DListOptionFunc[T]) *DList[T] {
 		o(ll)
 	}
 
-	if ll.copy == nil {
+	if ll.copy == nil || ll.copyPolicy == collections.Shallow {
 		ll.copy = util.DefaultDeepCopy[T]
 	}
 
@@ -52,9 +68,55 @@ func New[T any](options ...DListOptionFunc[T]) *DList[T] {
 		ll.compare = util.GetDefaultComparer[T]()
 	}
 
+	ll.register()
+
 	return ll
 }
 
+// lazyInit completes the construction of a zero value DList on its first modification,
+// as New would have done.
+func (l *DList[T]) lazyInit() {
+	if l.compare != nil {
+		return
+	}
+
+	l.compare = util.GetZeroValueComparer[T]()
+
+	if l.copy == nil {
+		l.copy = util.DefaultDeepCopy[T]
+	}
+}
+
+// Of constructs a new linked list containing the given values, e.g. dlist.Of(1, 2, 3).
+//
+// The values are added in order.
+func Of[T any](values ...T) *DList[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	l := dlist.OfWith([]dlist.DListOptionFunc[int]{dlist.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []DListOptionFunc[T], values ...T) *DList[T] {
+	l := New(options...)
+	l.AddRange(values)
+	return l
+}
+
+// NewFunc is as [New], using compare to find values, e.g. in Contains and Sort.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...DListOptionFunc[T]) *DList[T] {
+	return New(append([]DListOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...DListOptionFunc[T]) *DList[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
 // Option function for New to make the collection thread-safe. Adds overhead.
 func WithThreadSafe[T any]() DListOptionFunc[T] {
 	return func(ll *DList[T]) {
@@ -81,6 +143,61 @@ func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) DListOptionFunc[T] {
 	}
 }
 
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) DListOptionFunc[T] {
+	return func(ll *DList[T]) {
+		ll.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+//
+// Concurrent and Capacity are ignored by this collection.
+func WithOptions[T any](options collections.CommonOptions[T]) DListOptionFunc[T] {
+	opts := make([]DListOptionFunc[T], 0, 4)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(l *DList[T]) {
+		for _, o := range opts {
+			o(l)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection,
+// ReplaceAll, AddItemFirst, AddItemLast, AddItemAfter, AddItemBefore and AddWithID with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) DListOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(l *DList[T]) {
+		l.validator = util.NewValidator(validator, policy)
+	}
+}
+
 // AddItemFirst adds the given value at the head of the list and returns the newly inserted node.
 func (l *DList[T]) AddItemFirst(value T) {
 
@@ -89,6 +206,16 @@ func (l *DList[T]) AddItemFirst(value T) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if !l.validator.Accept(value) {
+		return
+	}
+
 	newNode := &DListNode[T]{
 		list: l,
 		item: value,
@@ -106,6 +233,16 @@ func (l *DList[T]) AddItemLast(value T) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if !l.validator.Accept(value) {
+		return
+	}
+
 	newNode := &DListNode[T]{
 		list: l,
 		item: value,
@@ -117,10 +254,30 @@ func (l *DList[T]) AddItemLast(value T) {
 
 // Add adds a value to the end of the list.
 //
-// Always returns true.
+// Returns true unless the value was rejected by the validator.
 func (l *DList[T]) Add(value T) bool {
 
-	l.AddItemLast(value)
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if !l.validator.Accept(value) {
+		return false
+	}
+
+	l.appendNode(&DListNode[T]{
+		list: l,
+		item: value,
+	})
+
+	l.version++
 	return true
 }
 
@@ -147,6 +304,16 @@ func (l *DList[T]) AddRange(values []T) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if values = l.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
 	for _, v := range values {
 		l.appendNode(&DListNode[T]{
 			list: l,
@@ -161,7 +328,7 @@ func (l *DList[T]) AddRange(values []T) {
 // Values are added in the order defined by the other collection.
 func (l *DList[T]) AddCollection(collection collections.Collection[T]) {
 
-	l.AddRange(collection.ToSliceDeep())
+	l.AddRange(util.ImportValues(collection, l.copyPolicy))
 }
 
 // First returns the node at the head of the list.
@@ -188,7 +355,18 @@ func (l *DList[T]) AddItemAfter(node *DListNode[T], value T) { //*DListNode[T] {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNode(node)
+
+	if !l.validator.Accept(value) {
+		return
+	}
+
 	newNode := &DListNode[T]{
 		list: l,
 		item: value,
@@ -215,6 +393,12 @@ func (l *DList[T]) AddNodeAfter(node, newNode *DListNode[T]) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNode(node)
 	l.validateNewNode(newNode)
 
@@ -228,7 +412,8 @@ func (l *DList[T]) AddNodeAfter(node, newNode *DListNode[T]) {
 	l.version++
 }
 
-// AddItemBefore inserts a value before the given node in the list and returns the newly inserted node.
+// AddItemBefore inserts a value before the given node in the list and returns the newly inserted node,
+// or nil if the value was rejected by the validator.
 //
 // Panics if the node argument is nil or belongs to another list.
 func (l *DList[T]) AddItemBefore(node *DListNode[T], value T) *DListNode[T] {
@@ -238,7 +423,18 @@ func (l *DList[T]) AddItemBefore(node *DListNode[T], value T) *DListNode[T] {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNode(node)
+
+	if !l.validator.Accept(value) {
+		return nil
+	}
+
 	result := &DListNode[T]{
 		list: l,
 		item: value,
@@ -264,6 +460,12 @@ func (l *DList[T]) AddNodeBefore(node, newNode *DListNode[T]) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNode(node)
 	l.validateNewNode(newNode)
 
@@ -285,6 +487,12 @@ func (l *DList[T]) AddNodeFirst(node *DListNode[T]) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNewNode(node)
 
 	if l.head == nil {
@@ -320,6 +528,12 @@ func (l *DList[T]) AddNodeLast(node *DListNode[T]) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNewNode(node)
 
 	l.appendNode(node)
@@ -338,6 +552,50 @@ func (l *DList[T]) Clear() {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.clear()
+}
+
+// ReplaceAll replaces the content of the list with the values of the given collection.
+// Values are added in the order defined by the other collection.
+//
+// All existing nodes are detached and invalidated.
+func (l *DList[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	l.replaceAll(util.ImportValues(collection, l.copyPolicy))
+}
+
+// replaceAll replaces the values of the list with the given values under a single lock.
+func (l *DList[T]) replaceAll(values []T) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	values = l.validator.Filter(values)
+	l.clear()
+
+	for _, v := range values {
+		l.appendNode(&DListNode[T]{
+			list: l,
+			item: v,
+		})
+	}
+}
+
+func (l *DList[T]) clear() {
 	var empty T
 	current := l.head
 	for current != nil {
@@ -345,11 +603,15 @@ func (l *DList[T]) Clear() {
 		current = current.next
 		temp.invalidate()
 		temp.item = empty
+		temp.id = 0
 	}
 
 	l.head = nil
+	l.tail = nil
 	l.count = 0
+	l.ids = nil
 	l.version++
+	l.recorder.Clear()
 }
 
 // Contains returns true if the given value is in the list; else false. Up to O(n).
@@ -360,6 +622,10 @@ func (l *DList[T]) Contains(value T) bool {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	if l.head == nil {
 		return false
 	}
@@ -390,9 +656,16 @@ func (l *DList[T]) RemoveItem(value T) bool {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	node := l.findNode(value, forward)
 	if node != nil {
 		l.removeNode(node)
+		l.recorder.Remove(value)
 
 		var empty T
 		node.item = empty
@@ -412,8 +685,15 @@ func (l *DList[T]) RemoveNode(node *DListNode[T]) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNode(node)
 	l.removeNode(node)
+	l.recordReset()
 }
 
 // RemoveFirst removes the node at the head of the list and returns the value that was stored
@@ -426,12 +706,19 @@ func (l *DList[T]) RemoveFirst() T {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	if l.head == nil {
 		panic(messages.COLLECTION_EMPTY)
 	}
 
 	item := l.head.item
 	l.removeNode(l.head)
+	l.recorder.Remove(item)
 	return item
 }
 
@@ -445,12 +732,19 @@ func (l *DList[T]) RemoveLast() T {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	if l.head == nil {
 		panic(messages.COLLECTION_EMPTY)
 	}
 
 	item := l.tail.item
 	l.removeNode(l.tail)
+	l.recordReset()
 	return item
 }
 
@@ -463,6 +757,12 @@ func (l *DList[T]) TryRemoveFirst() (T, bool) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	if l.head == nil {
 		var v T
 		return v, false
@@ -470,6 +770,7 @@ func (l *DList[T]) TryRemoveFirst() (T, bool) {
 
 	item := l.head.item
 	l.removeNode(l.head)
+	l.recorder.Remove(item)
 	return item, true
 }
 
@@ -482,6 +783,12 @@ func (l *DList[T]) TryRemoveLast() (T, bool) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	if l.head == nil {
 		var v T
 		return v, false
@@ -489,9 +796,45 @@ func (l *DList[T]) TryRemoveLast() (T, bool) {
 
 	item := l.tail.item
 	l.removeNode(l.tail)
+	l.recordReset()
 	return item, true
 }
 
+// Page returns a copy of the values on the given zero-based page of the list,
+// counting from the head of the list.
+//
+// Panics if pageIndex is negative or pageSize is less than 1.
+func (l *DList[T]) Page(pageIndex, pageSize int) []T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	start, end := util.PageBounds(l.count, pageIndex, pageSize)
+	page := make([]T, end-start)
+
+	if len(page) == 0 {
+		return page
+	}
+
+	node := l.head
+	for i := 0; i < start; i++ {
+		node = node.next
+	}
+
+	for i := range page {
+		page[i] = node.item
+		node = node.next
+	}
+
+	return page
+}
+
 // ToSlice returns a copy of the list content as a slice.
 func (l *DList[T]) ToSlice() []T {
 
@@ -500,9 +843,47 @@ func (l *DList[T]) ToSlice() []T {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	return l.toSlice(false)
 }
 
+// ToImmutableSlice returns the content of the list as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the list has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified. Values modified in place, e.g. via [collections.Element.ValuePtr],
+// are not reflected until the list is otherwise modified.
+func (l *DList[T]) ToImmutableSlice() []T {
+
+	if l.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		l.lock.RLock()
+		values, ok := l.immutable.Load(l.version)
+		l.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	return l.immutable.Get(l.version, func() []T { return l.toSlice(false) })
+}
+
 // ToSliceDeep returns the content of the collection as a slice using the provided [functions.DeepCopyFunc] if any.
 //
 // Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
@@ -513,6 +894,12 @@ func (l *DList[T]) ToSliceDeep() []T {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	return l.toSlice(true)
 }
 
@@ -544,6 +931,10 @@ func (l *DList[T]) String() string {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	var values []string
 	for _, value := range l.toSlice(false) {
 		values = append(values, fmt.Sprintf("%v", value))
@@ -568,6 +959,8 @@ func (ll *DList[T]) appendNode(newNode *DListNode[T]) {
 	}
 
 	ll.count++
+	ll.assignID(newNode)
+	ll.recorder.Add(newNode.item)
 }
 
 func (ll *DList[T]) prependNode(newNode *DListNode[T]) {
@@ -585,6 +978,8 @@ func (ll *DList[T]) prependNode(newNode *DListNode[T]) {
 	}
 
 	ll.count++
+	ll.assignID(newNode)
+	ll.recordReset()
 }
 
 func (ll *DList[T]) insertNodeBefore(nextNode, newNode *DListNode[T]) {
@@ -602,6 +997,8 @@ func (ll *DList[T]) insertNodeBefore(nextNode, newNode *DListNode[T]) {
 	}
 
 	ll.count++
+	ll.assignID(newNode)
+	ll.recordReset()
 }
 
 func (ll *DList[T]) validateNode(node *DListNode[T]) {
@@ -640,6 +1037,7 @@ func (ll *DList[T]) removeNode(node *DListNode[T]) {
 		node.prev.next = node.next
 	}
 
+	ll.releaseID(node)
 	node.invalidate()
 	if ll.count == 1 {
 		ll.head = nil
@@ -653,8 +1051,10 @@ func (ll *DList[T]) removeNode(node *DListNode[T]) {
 // Make a new empty list with the same attributes as this.
 func (ll *DList[T]) makeCopy() *DList[T] {
 	ll1 := &DList[T]{
-		copy:    ll.copy,
-		compare: ll.compare,
+		copy:       ll.copy,
+		copyPolicy: ll.copyPolicy,
+		compare:    ll.compare,
+		stableIDs:  ll.stableIDs,
 	}
 
 	if ll.lock != nil {
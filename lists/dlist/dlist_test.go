@@ -6,7 +6,10 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
 	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +23,20 @@ func TestLLConstructor(t *testing.T) {
 		require.Empty(t, linkedList.count)
 	})
 
+	t.Run("Construct with common options", func(t *testing.T) {
+		magic := 42
+		opts := collections.CommonOptions[int]{
+			ThreadSafe: true,
+			Capacity:   100,
+			Comparer:   func(v1, v2 int) int { return magic },
+		}
+		linkedList := New(WithOptions(opts))
+
+		require.NotNil(t, linkedList.lock)
+		require.Equal(t, magic, linkedList.compare(1, 0))
+		require.NotNil(t, linkedList.copy)
+	})
+
 	t.Run("Construct with nil comparer panics", func(t *testing.T) {
 		var comp functions.ComparerFunc[int]
 
@@ -478,3 +495,86 @@ func verifyRemovedNode4[T any](t *testing.T, linkedList *DList[T], linkedListVal
 func initialItems_Tests[T any](t *testing.T, collection *DList[T], expectedItems []T) {
 	verifyLLState(t, collection, expectedItems)
 }
+
+func TestOf(t *testing.T) {
+
+	l := Of(1, 2, 3)
+	require.Equal(t, []int{1, 2, 3}, l.ToSlice())
+
+	l = OfWith([]DListOptionFunc[int]{WithThreadSafe[int]()}, 4)
+	require.NotNil(t, l.lock)
+	require.Equal(t, []int{4}, l.ToSlice())
+}
+
+func TestToImmutableSlice(t *testing.T) {
+
+	t.Run("Repeated calls return the same backing array", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc1)
+		require.Same(t, &slc1[0], &slc2[0])
+		require.Equal(t, len(slc1), cap(slc1))
+	})
+
+	t.Run("Recomputed after modification", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		col.Remove(2)
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc2)
+		require.Len(t, slc1, 3)
+		require.Len(t, slc2, 2)
+
+		col.Clear()
+		require.Empty(t, col.ToImmutableSlice())
+	})
+
+	t.Run("Empty collection", func(t *testing.T) {
+		require.Empty(t, New[int]().ToImmutableSlice())
+	})
+}
+
+func TestZeroValue(t *testing.T) {
+
+	t.Run("Usable without New", func(t *testing.T) {
+		var l DList[int]
+
+		require.True(t, l.IsEmpty())
+		require.False(t, l.Contains(1))
+		require.Nil(t, l.Find(func(int) bool { return true }))
+
+		l.AddRange([]int{3, 1, 2})
+		l.Sort()
+
+		require.Equal(t, []int{1, 2, 3}, l.ToSlice())
+		require.True(t, l.Contains(2))
+		require.True(t, l.Remove(2))
+	})
+
+	t.Run("Panics on first modification if no default comparer", func(t *testing.T) {
+		type point struct{ x, y int }
+		var l DList[point]
+
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ZERO_VALUE_COMPARER_FMT, point{}), func() { l.Add(point{}) })
+	})
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package dlist_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/lists/dlist"
+)
+
+func Example() {
+	l := dlist.New[string]()
+	l.AddItemLast("b")
+	l.AddItemFirst("a")
+	l.AddItemLast("c")
+
+	fmt.Println(l.ToSlice())
+	fmt.Println(l.RemoveFirst(), l.RemoveLast(), l.ToSlice())
+	// Output:
+	// [a b c]
+	// a c [b]
+}
+
+// Nodes may be used to edit the list at any position.
+func Example_nodes() {
+	l := dlist.Of(1, 2, 4, 5)
+
+	// Insert 3 before the node holding 4.
+	for node := l.First(); node != nil; node = node.Next() {
+		if node.Value() == 4 {
+			l.AddItemBefore(node, 3)
+			break
+		}
+	}
+
+	// Replace the last value and remove the first.
+	l.Last().SetValue(50)
+	l.RemoveNode(l.First())
+
+	fmt.Println(l.ToSlice())
+	// Output:
+	// [2 3 4 50]
+}
+
+func ExampleDList_AddSliceAfter() {
+	l := dlist.Of(1, 5)
+	last := l.AddSliceAfter(l.First(), []int{2, 3})
+	l.AddItemAfter(last, 4)
+
+	fmt.Println(l.ToSlice())
+	// Output:
+	// [1 2 3 4 5]
+}
+
+func ExampleDList_ReverseIterator() {
+	l := dlist.Of("a", "b", "c")
+	iter := l.ReverseIterator()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Print(e.Value(), " ")
+	}
+	fmt.Println()
+	// Output:
+	// c b a
+}
+
+func ExampleDList_Sort() {
+	l := dlist.Of(3, 1, 2)
+	l.Sort()
+	fmt.Println(l.ToSlice())
+
+	l.SortDescending()
+	fmt.Println(l.ToSlice())
+	// Output:
+	// [1 2 3]
+	// [3 2 1]
+}
+
+func ExampleDList_ExtractWhere() {
+	l := dlist.Of(1, 2, 3, 4, 5)
+	odd := l.ExtractWhere(func(v int) bool { return v%2 == 1 })
+
+	fmt.Println(l.ToSlice(), odd.ToSlice())
+	// Output:
+	// [2 4] [1 3 5]
+}
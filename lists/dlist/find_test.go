@@ -159,3 +159,10 @@ func TestFindAll(t *testing.T) {
 		require.Equal(t, expected, tempItems)
 	})
 }
+
+func TestFindBeyondHead(t *testing.T) {
+	l := Of(1, 2, 3)
+	e := l.Find(func(v int) bool { return v == 3 })
+	require.NotNil(t, e)
+	require.Equal(t, 3, e.Value())
+}
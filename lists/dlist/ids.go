@@ -0,0 +1,136 @@
+package dlist
+
+import (
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Option function for New to assign each node inserted into the list an ID that is unique within the list,
+// by which it may be retrieved or removed in O(1) with [DList.GetByID] and [DList.RemoveByID].
+//
+// A node keeps its ID while it remains in the list, including when the list is sorted. IDs are never reused,
+// so an external system may hold the ID of a node as a durable reference to it, where holding the node itself
+// would be unsafe. A node moved to another list, e.g. by ExtractWhere, is given an ID by that list.
+// Adds the overhead of an index from ID to node.
+func WithStableIDs[T any]() DListOptionFunc[T] {
+	return func(l *DList[T]) {
+		l.stableIDs = true
+	}
+}
+
+// AddWithID adds a value to the end of the list and returns the ID of the new node,
+// or zero, which is never an ID, if the value was rejected by the validator.
+//
+// Panics if the list was not created with [WithStableIDs].
+func (l *DList[T]) AddWithID(value T) uint64 {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.requireStableIDs()
+	l.lazyInit()
+
+	if !l.validator.Accept(value) {
+		return 0
+	}
+
+	return l.addItemLast(value).id
+}
+
+// GetByID returns the node with the given ID, or nil if there is no node with that ID in the list. O(1).
+//
+// Panics if the list was not created with [WithStableIDs].
+func (l *DList[T]) GetByID(id uint64) *DListNode[T] {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	l.requireStableIDs()
+
+	return l.ids[id]
+}
+
+// RemoveByID removes the node with the given ID from the list. O(1).
+//
+// Returns true if the node was present and was removed; else false.
+//
+// Panics if the list was not created with [WithStableIDs].
+func (l *DList[T]) RemoveByID(id uint64) bool {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.requireStableIDs()
+	l.lazyInit()
+
+	node, ok := l.ids[id]
+
+	if !ok {
+		return false
+	}
+
+	l.removeNode(node)
+	l.recordReset()
+	return true
+}
+
+func (l *DList[T]) requireStableIDs() {
+	if !l.stableIDs {
+		panic(messages.STABLE_IDS_DISABLED)
+	}
+}
+
+// assignID gives a node inserted into the list the next ID.
+func (l *DList[T]) assignID(n *DListNode[T]) {
+	if !l.stableIDs {
+		return
+	}
+
+	l.nextID++
+	n.id = l.nextID
+	l.indexID(n)
+}
+
+func (l *DList[T]) indexID(n *DListNode[T]) {
+	if l.ids == nil {
+		l.ids = make(map[uint64]*DListNode[T])
+	}
+
+	l.ids[n.id] = n
+}
+
+func (l *DList[T]) releaseID(n *DListNode[T]) {
+	delete(l.ids, n.id)
+	n.id = 0
+}
+
+// reindexIDs rebuilds the index from the IDs held by the nodes.
+func (l *DList[T]) reindexIDs() {
+	l.ids = nil
+
+	if !l.stableIDs {
+		return
+	}
+
+	for n := l.head; n != nil; n = n.next {
+		l.indexID(n)
+	}
+}
@@ -0,0 +1,105 @@
+package dlist
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStableIDs(t *testing.T) {
+
+	newList := func(values ...int) *DList[int] {
+		return OfWith([]DListOptionFunc[int]{WithStableIDs[int]()}, values...)
+	}
+
+	t.Run("IDs survive sorting", func(t *testing.T) {
+		linkedList := newList()
+		ids := make(map[int]uint64)
+
+		for _, v := range []int{5, 3, 9, 1, 7} {
+			ids[v] = linkedList.AddWithID(v)
+		}
+
+		linkedList.Sort()
+		initialItems_Tests(t, linkedList, []int{1, 3, 5, 7, 9})
+
+		for v, id := range ids {
+			node := linkedList.GetByID(id)
+			require.Equal(t, v, node.Value())
+			require.Equal(t, id, node.ID())
+		}
+	})
+
+	t.Run("Every insertion is given an ID", func(t *testing.T) {
+		linkedList := newList(2)
+		linkedList.AddItemFirst(1)
+		linkedList.AddItemBefore(linkedList.Last(), 10)
+		linkedList.AddSliceAfter(linkedList.Last(), []int{3, 4})
+		linkedList.AddNodeLast(NewNode(5))
+
+		seen := make(map[uint64]bool)
+
+		for n := linkedList.First(); n != nil; n = n.Next() {
+			require.NotZero(t, n.ID())
+			require.False(t, seen[n.ID()])
+			require.Same(t, n, linkedList.GetByID(n.ID()))
+			seen[n.ID()] = true
+		}
+	})
+
+	t.Run("RemoveByID", func(t *testing.T) {
+		linkedList := newList(1, 2, 3)
+		id := linkedList.First().Next().ID()
+		node := linkedList.GetByID(id)
+
+		require.True(t, linkedList.RemoveByID(id))
+		require.False(t, linkedList.RemoveByID(id))
+		require.Nil(t, linkedList.GetByID(id))
+		require.Zero(t, node.ID())
+		initialItems_Tests(t, linkedList, []int{1, 3})
+
+		// IDs are not reused
+		require.Greater(t, linkedList.AddWithID(2), id+1)
+	})
+
+	t.Run("Extracted nodes are given IDs by the new list", func(t *testing.T) {
+		linkedList := newList(1, 2, 3, 4)
+		even := linkedList.ExtractWhere(func(v int) bool { return v%2 == 0 })
+
+		for n := even.First(); n != nil; n = n.Next() {
+			require.Same(t, n, even.GetByID(n.ID()))
+		}
+
+		for n := linkedList.First(); n != nil; n = n.Next() {
+			require.Same(t, n, linkedList.GetByID(n.ID()))
+		}
+
+		require.Len(t, linkedList.ids, 2)
+	})
+
+	t.Run("CloneInto copies IDs", func(t *testing.T) {
+		linkedList := newList(1, 2, 3)
+		dst := New[int]()
+		linkedList.CloneInto(dst)
+
+		for n := linkedList.First(); n != nil; n = n.Next() {
+			require.Equal(t, n.Value(), dst.GetByID(n.ID()).Value())
+		}
+	})
+
+	t.Run("Clear releases IDs", func(t *testing.T) {
+		linkedList := newList(1, 2, 3)
+		id := linkedList.First().ID()
+		linkedList.Clear()
+		require.Nil(t, linkedList.GetByID(id))
+	})
+
+	t.Run("Without option panics", func(t *testing.T) {
+		linkedList := Of(1)
+		require.Zero(t, linkedList.First().ID())
+		require.PanicsWithValue(t, messages.STABLE_IDS_DISABLED, func() { linkedList.AddWithID(2) })
+		require.PanicsWithValue(t, messages.STABLE_IDS_DISABLED, func() { linkedList.GetByID(1) })
+		require.PanicsWithValue(t, messages.STABLE_IDS_DISABLED, func() { linkedList.RemoveByID(1) })
+	})
+}
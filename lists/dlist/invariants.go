@@ -0,0 +1,25 @@
+package dlist
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the list is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (l *DList[T]) verifyInvariants() {
+	util.AssertInvariant((l.head == nil) == (l.count == 0), "list head does not match count")
+	util.AssertInvariant((l.tail == nil) == (l.count == 0), "list tail does not match count")
+
+	var last *DListNode[T]
+	count := 0
+
+	for n := l.head; n != nil && count <= l.count; n = n.Next() {
+		util.AssertInvariant(n.list == l, "list node belongs to another list")
+		util.AssertInvariant(n.Previous() == last, "list node is not linked to previous node")
+		util.AssertInvariant(!l.stableIDs || l.ids[n.id] == n, "list ID index does not match nodes")
+		last = n
+		count++
+	}
+
+	util.AssertInvariant(count == l.count, "list count does not match nodes")
+	util.AssertInvariant(last == l.tail, "list tail is not last node")
+	util.AssertInvariant(len(l.ids) == util.Iif(l.stableIDs, l.count, 0), "list ID index does not match count")
+}
@@ -1,6 +1,8 @@
 package dlist
 
 import (
+	"math/rand"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/local"
@@ -97,6 +99,40 @@ func (l *DList[T]) TakeWhile(predicate functions.PredicateFunc[T]) collections.I
 	return newForwardIterator(l, predicate)
 }
 
+// SampleIterator returns an iterator that yields n values chosen at random from the list,
+// without replacement and in random order. If the list holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the list.
+// If r is nil, the default source of the math/rand package is used.
+//
+//	iter := l.SampleIterator(100, nil)
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// audit e.Value()
+//	}
+//
+// Panics if n is negative.
+func (l *DList[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](l, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the list at the time of the call,
+// in the same order as ToSlice, so that the list may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the list's immutable slice, so it is only copied if the list has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the list, and their ValuePtr method panics.
+//
+//	iter := l.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		l.Remove(e.Value())
+//	}
+func (l *DList[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(l.ToImmutableSlice())
+}
+
 // Start begins an iteration across the DList returning the fisrt element,
 // which will be nil if the collection is empty.
 //
@@ -112,7 +148,7 @@ func (i *DListIterator[T]) Start() collections.Element[T] {
 		return i.Next()
 	}
 
-	return util.NewElementType[T](i.list, &i.current.item)
+	return util.NewElementType[T](i.list, &i.current.item, i.list.compare)
 }
 
 // Next returns the next element in the list,
@@ -134,7 +170,7 @@ func (i *DListIterator[T]) Next() collections.Element[T] {
 		}
 
 		if i.predicate(i.current.item) {
-			return util.NewElementType[T](i.list, &i.current.item)
+			return util.NewElementType[T](i.list, &i.current.item, i.list.compare)
 		}
 	}
 }
@@ -6,6 +6,7 @@ type DListNode[T any] struct {
 	next *DListNode[T]
 	list *DList[T]
 	item T
+	id   uint64
 }
 
 // NewNode initializes a new node with given value.
@@ -20,6 +21,11 @@ func (n *DListNode[T]) List() *DList[T] {
 	return n.list
 }
 
+// ID returns the ID of this node within its list, if the list was created with [WithStableIDs]; else 0.
+func (n *DListNode[T]) ID() uint64 {
+	return n.id
+}
+
 // Next returns the next node in the chain.
 // Will be nil if this is the last node.
 func (n *DListNode[T]) Next() *DListNode[T] {
@@ -0,0 +1,106 @@
+package dlist
+
+import (
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// ApplyOps applies the given operations to the list in order.
+// Add appends the value, Remove removes the first occurrence of the value
+// searching from the head, and Clear empties the list.
+//
+// Panics if an op has an invalid kind.
+func (l *DList[T]) ApplyOps(operations []ops.Op[T]) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	util.ApplyOps(
+		operations,
+		func(value T) {
+			if l.validator.Accept(value) {
+				l.appendNode(&DListNode[T]{
+					list: l,
+					item: value,
+				})
+			}
+		},
+		func(value T) {
+			if node := l.findNode(value, forward); node != nil {
+				l.removeNode(node)
+				l.recorder.Remove(value)
+			}
+		},
+		l.clear,
+	)
+
+	l.version++
+}
+
+// StartRecording begins capturing mutations of the list as ops,
+// discarding any ops previously recorded.
+//
+// Values appended to the list are recorded as Add, and values removed from the head
+// or by value are recorded as Remove. Other insertions and removals, and sorting,
+// are recorded as a Clear followed by an Add of each value.
+func (l *DList[T]) StartRecording() {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.recorder.Start(l.copy)
+}
+
+// StopRecording ends recording and returns the ops recorded since StartRecording.
+func (l *DList[T]) StopRecording() []ops.Op[T] {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	return l.recorder.Stop()
+}
+
+// IsRecording returns true if the list is recording mutations.
+func (l *DList[T]) IsRecording() bool {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.recorder.IsRecording()
+}
+
+// recordReset records the entire content of the list, for mutations
+// that cannot be expressed as a single Add or Remove.
+func (l *DList[T]) recordReset() {
+	l.recorder.Reset(func() []T { return l.toSlice(false) })
+}
@@ -0,0 +1,46 @@
+package dlist
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage(t *testing.T) {
+
+	arraySize := 10
+	seed := int64(21543)
+	items := util.CreateSingleIntListData(arraySize, &seed)
+
+	t.Run("Page of empty list is empty", func(t *testing.T) {
+		linkedList := New[int]()
+		require.Equal(t, []int{}, linkedList.Page(0, 4))
+	})
+
+	t.Run("Pages are returned in list order", func(t *testing.T) {
+		linkedList := New[int]()
+		linkedList.AddRange(items)
+		require.Equal(t, items[0:4], linkedList.Page(0, 4))
+		require.Equal(t, items[4:8], linkedList.Page(1, 4))
+	})
+
+	t.Run("Final page is partial", func(t *testing.T) {
+		linkedList := New[int]()
+		linkedList.AddRange(items)
+		require.Equal(t, items[8:], linkedList.Page(2, 4))
+	})
+
+	t.Run("Page beyond end is empty", func(t *testing.T) {
+		linkedList := New[int]()
+		linkedList.AddRange(items)
+		require.Equal(t, []int{}, linkedList.Page(3, 4))
+		require.Equal(t, []int{}, linkedList.Page(100, 4))
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		linkedList := New[int]()
+		require.Panics(t, func() { linkedList.Page(-1, 4) })
+		require.Panics(t, func() { linkedList.Page(0, 0) })
+	})
+}
@@ -0,0 +1,91 @@
+package dlist
+
+import (
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// PartitionInPlace splits the list in two. Nodes whose values match the predicate remain in this list,
+// and the remaining nodes are moved to a new list which is returned.
+//
+// Nodes are relinked rather than copied, so both lists retain the original relative order
+// of their values and references to existing nodes remain valid.
+func (l *DList[T]) PartitionInPlace(predicate functions.PredicateFunc[T]) *DList[T] {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	return l.detachWhere(func(value T) bool { return !predicate(value) })
+}
+
+// ExtractWhere removes all nodes whose values match the predicate from the list in a single pass,
+// and returns them as a new list in their original relative order.
+//
+// Nodes are relinked rather than copied, so references to existing nodes remain valid.
+func (l *DList[T]) ExtractWhere(predicate functions.PredicateFunc[T]) *DList[T] {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	return l.detachWhere(predicate)
+}
+
+// detachWhere moves nodes whose values match the predicate to a new list.
+func (l *DList[T]) detachWhere(predicate functions.PredicateFunc[T]) *DList[T] {
+
+	detached := l.makeCopy()
+
+	var head, tail *DListNode[T]
+	count := 0
+
+	for node := l.head; node != nil; {
+		next := node.next
+
+		if predicate(node.item) {
+			// The node is given an ID by the new list
+			l.releaseID(node)
+			detached.appendNode(node)
+		} else {
+			node.prev = tail
+			node.next = nil
+
+			if tail == nil {
+				head = node
+			} else {
+				tail.next = node
+			}
+
+			tail = node
+			count++
+		}
+
+		node = next
+	}
+
+	if detached.count == 0 {
+		return detached
+	}
+
+	l.head = head
+	l.tail = tail
+	l.count = count
+	l.version++
+	l.recordReset()
+	return detached
+}
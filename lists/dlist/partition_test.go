@@ -0,0 +1,90 @@
+package dlist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionInPlace(t *testing.T) {
+
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Non-matching nodes are moved to new list", func(t *testing.T) {
+		ll := New[int](WithThreadSafe[int]())
+		ll.AddRange([]int{1, 2, 3, 4, 5, 6})
+		first := ll.First()
+		rest := ll.PartitionInPlace(isEven)
+
+		require.Equal(t, []int{2, 4, 6}, ll.ToSlice())
+		require.Equal(t, []int{1, 3, 5}, rest.ToSlice())
+		require.Equal(t, 3, ll.Count())
+		require.Equal(t, 3, rest.Count())
+		require.Same(t, first, rest.First())
+		require.Same(t, rest, first.List())
+		require.NotNil(t, rest.lock)
+	})
+
+	t.Run("Lists remain usable after partition", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2, 3, 4})
+		rest := ll.PartitionInPlace(isEven)
+
+		ll.Add(8)
+		rest.Add(7)
+		require.Equal(t, []int{2, 4, 8}, ll.ToSlice())
+		require.Equal(t, []int{1, 3, 7}, rest.ToSlice())
+		require.Equal(t, 8, ll.Last().Value())
+		require.Equal(t, 7, rest.Last().Value())
+	})
+
+	t.Run("All matching returns empty list", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{2, 4})
+		require.True(t, ll.PartitionInPlace(isEven).IsEmpty())
+		require.Equal(t, []int{2, 4}, ll.ToSlice())
+	})
+}
+
+func TestExtractWhere(t *testing.T) {
+
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Matching nodes are extracted in order", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2, 3, 4, 5, 6})
+		extracted := ll.ExtractWhere(isEven)
+
+		require.Equal(t, []int{2, 4, 6}, extracted.ToSlice())
+		require.Equal(t, []int{1, 3, 5}, ll.ToSlice())
+		require.Equal(t, 5, ll.Last().Value())
+	})
+
+	t.Run("All values extracted leaves empty list", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{2, 4})
+		require.Equal(t, []int{2, 4}, ll.ExtractWhere(isEven).ToSlice())
+		require.True(t, ll.IsEmpty())
+		require.Nil(t, ll.First())
+		require.Nil(t, ll.Last())
+	})
+
+	t.Run("Modification invalidates iterator", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2, 3})
+		iter := ll.Iterator()
+		iter.Start()
+		ll.ExtractWhere(isEven)
+		require.Panics(t, func() { iter.Next() })
+	})
+
+	t.Run("Replaying recorded ops reproduces list", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2, 3, 4})
+		ll.StartRecording()
+		ll.ExtractWhere(isEven)
+		ll1 := New[int]()
+		ll1.ApplyOps(ll.StopRecording())
+		require.Equal(t, ll.ToSlice(), ll1.ToSlice())
+	})
+}
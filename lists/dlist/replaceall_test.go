@@ -0,0 +1,46 @@
+package dlist
+
+import (
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/lists/slist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceAll(t *testing.T) {
+
+	arraySize := 16
+	seed := int64(21543)
+	headItems, tailItems, _, _ := util.CreateIntListData(arraySize, &seed)
+
+	t.Run("Content is replaced and old nodes invalidated", func(t *testing.T) {
+		linkedList := New[int]()
+		linkedList.AddRange(headItems)
+		first := linkedList.First()
+		other := slist.New[int]()
+		other.AddRange(tailItems)
+		linkedList.ReplaceAll(other)
+		require.Nil(t, first.List())
+		require.Equal(t, tailItems, linkedList.ToSlice())
+		require.Equal(t, tailItems[0], linkedList.First().Value())
+		require.Equal(t, tailItems[arraySize-1], linkedList.Last().Value())
+	})
+
+	t.Run("Replace with empty collection", func(t *testing.T) {
+		linkedList := New[int]()
+		linkedList.AddRange(headItems)
+		linkedList.ReplaceAll(slist.New[int]())
+		require.True(t, linkedList.IsEmpty())
+		require.Nil(t, linkedList.First())
+		require.Nil(t, linkedList.Last())
+	})
+
+	t.Run("Replace empty list", func(t *testing.T) {
+		linkedList := New[int]()
+		other := slist.New[int]()
+		other.AddRange(tailItems)
+		linkedList.ReplaceAll(other)
+		require.Equal(t, tailItems, linkedList.ToSlice())
+	})
+}
@@ -0,0 +1,54 @@
+package dlist
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// Thin returns a new list with the same properties as this one, containing every
+// keepEvery'th value counting from the head, starting with the head itself.
+// This is a cheap way to reduce a high-rate series of samples to one that can be plotted.
+//
+// Panics if keepEvery is less than 1.
+func (l *DList[T]) Thin(keepEvery int) *DList[T] {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.resampled(util.Thin(l.toSlice(false), keepEvery))
+}
+
+// Downsample returns a new list with the same properties as this one, containing n values.
+// The values of this list are divided from head to tail into n consecutive buckets whose sizes
+// differ by at most one, and each bucket is aggregated into a single value by reducer,
+// e.g. to take the mean or maximum of each bucket.
+//
+// If the list holds no more than n values, each value is a bucket of its own.
+//
+// Panics if n is less than 1 or reducer is nil.
+func (l *DList[T]) Downsample(n int, reducer func([]T) T) *DList[T] {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return l.resampled(util.Downsample(l.toSlice(false), n, reducer))
+}
+
+func (l *DList[T]) resampled(values []T) *DList[T] {
+	l1 := l.makeCopy()
+
+	for _, v := range values {
+		l1.addItemLast(v)
+	}
+
+	return l1
+}
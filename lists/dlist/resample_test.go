@@ -0,0 +1,63 @@
+package dlist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThin(t *testing.T) {
+
+	t.Run("Keeps every k-th value from the head", func(t *testing.T) {
+		linkedList := Of(0, 1, 2, 3, 4, 5, 6)
+		thinned := linkedList.Thin(3)
+
+		initialItems_Tests(t, thinned, []int{0, 3, 6})
+		initialItems_Tests(t, linkedList, []int{0, 1, 2, 3, 4, 5, 6})
+	})
+
+	t.Run("Result has the same properties", func(t *testing.T) {
+		linkedList := New(WithThreadSafe[int]())
+		linkedList.AddRange([]int{1, 2, 3})
+
+		require.NotNil(t, linkedList.Thin(2).lock)
+	})
+
+	t.Run("Empty list", func(t *testing.T) {
+		initialItems_Tests(t, New[int]().Thin(2), []int{})
+	})
+
+	t.Run("Invalid keepEvery panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "keepEvery"), func() { Of(1).Thin(0) })
+	})
+}
+
+func TestDownsample(t *testing.T) {
+
+	maximum := func(bucket []int) int {
+		m := bucket[0]
+		for _, v := range bucket[1:] {
+			if v > m {
+				m = v
+			}
+		}
+		return m
+	}
+
+	t.Run("Reduces buckets from the head", func(t *testing.T) {
+		linkedList := Of(5, 1, 2, 8, 3, 4, 1)
+		initialItems_Tests(t, linkedList.Downsample(3, maximum), []int{5, 8, 4})
+	})
+
+	t.Run("Fewer values than buckets", func(t *testing.T) {
+		linkedList := Of(1, 2)
+		initialItems_Tests(t, linkedList.Downsample(5, maximum), []int{1, 2})
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { Of(1).Downsample(0, maximum) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "reducer"), func() { Of(1).Downsample(1, nil) })
+	})
+}
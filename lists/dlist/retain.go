@@ -0,0 +1,78 @@
+package dlist
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*DList[int])(nil)
+
+// RetainWhere removes the nodes whose values the predicate returns false for in a single pass,
+// and returns the number of nodes removed. O(n).
+//
+// The remaining nodes are relinked in their order, so references to them remain valid.
+func (l *DList[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	var head, tail *DListNode[T]
+	count := 0
+
+	for node := l.head; node != nil; {
+		next := node.next
+
+		if predicate(node.item) {
+			node.prev = tail
+			node.next = nil
+
+			if tail == nil {
+				head = node
+			} else {
+				tail.next = node
+			}
+
+			tail = node
+			count++
+		} else {
+			var empty T
+			l.releaseID(node)
+			node.invalidate()
+			node.item = empty
+		}
+
+		node = next
+	}
+
+	removed := l.count - count
+
+	if removed == 0 {
+		return 0
+	}
+
+	l.head = head
+	l.tail = tail
+	l.count = count
+	l.version++
+	l.recordReset()
+	return removed
+}
+
+// RetainAll removes the nodes whose values other does not contain in a single pass,
+// and returns the number of nodes removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (l *DList[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](l, other, l.RetainWhere)
+}
@@ -1,7 +1,13 @@
 package dlist
 
 import (
+	"fmt"
+
 	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"golang.org/x/exp/constraints"
 )
 
 // DList has its own implementation of sort.
@@ -11,6 +17,7 @@ import (
 // over 16K elements.
 
 // Sort performs an in-place sort of this collection with a time complexity of O(n*log n).
+// The sort is stable, so values which compare equal keep their relative order.
 func (l *DList[T]) Sort() {
 
 	if l.head == nil || l.count < 2 {
@@ -22,8 +29,15 @@ func (l *DList[T]) Sort() {
 		defer l.lock.Unlock()
 	}
 
-	l.mergeSort(forward)
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.mergeSort(l.compare, forward)
 	l.version++
+	l.recordReset()
 }
 
 // Sorted returns a sorted copy of this DList as a new DList using the provided [functions.DeepCopyFunc] if any.
@@ -34,6 +48,10 @@ func (l *DList[T]) Sorted() collections.Collection[T] {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	ll1 := l.makeCopy()
 
 	switch {
@@ -47,7 +65,7 @@ func (l *DList[T]) Sorted() collections.Collection[T] {
 	default:
 
 		ll1.AddRange(l.toSlice(true))
-		ll1.mergeSort(forward)
+		ll1.mergeSort(ll1.compare, forward)
 		return ll1
 	}
 }
@@ -64,8 +82,15 @@ func (l *DList[T]) SortDescending() {
 		defer l.lock.Unlock()
 	}
 
-	l.mergeSort(reverse)
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.mergeSort(l.compare, reverse)
 	l.version++
+	l.recordReset()
 }
 
 // Sorted returns a descending order sorted copy of this DList as a new DList using the provided [functions.DeepCopyFunc] if any.
@@ -76,6 +101,10 @@ func (l *DList[T]) SortedDescending() collections.Collection[T] {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	ll1 := l.makeCopy()
 
 	switch {
@@ -89,15 +118,63 @@ func (l *DList[T]) SortedDescending() collections.Collection[T] {
 	default:
 
 		ll1.AddRange(l.toSlice(true))
-		ll1.mergeSort(reverse)
+		ll1.mergeSort(ll1.compare, reverse)
 		return ll1
 	}
 }
 
-func (ll *DList[T]) mergeSort(dir direction) {
+// SortBy performs an in-place stable sort of this collection with respect to the given comparer,
+// rather than the comparer of the list, which is unchanged.
+//
+// The item that comparer orders first will be placed at the head of the list.
+//
+// Panics if comparer is nil.
+func (l *DList[T]) SortBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	if l.head == nil || l.count < 2 {
+		return
+	}
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.mergeSort(comparer, forward)
+	l.version++
+	l.recordReset()
+}
+
+// SortStable performs an in-place sort of this collection. It is equivalent to Sort,
+// which is stable, and is provided for parity with other sortable collections.
+func (l *DList[T]) SortStable() {
+
+	l.Sort()
+}
+
+// SortStableBy performs an in-place sort of this collection. It is equivalent to [DList.SortBy],
+// which is stable, and is provided for parity with other sortable collections.
+//
+// Panics if comparer is nil.
+func (l *DList[T]) SortStableBy(comparer functions.ComparerFunc[T]) {
+
+	l.SortBy(comparer)
+}
+
+func (ll *DList[T]) mergeSort(compare functions.ComparerFunc[T], dir direction) {
 
 	var n *DListNode[T]
-	ll.head = ll.mergeSortRecursive(ll.head, dir)
+	ll.head = ll.mergeSortRecursive(ll.head, compare, dir)
 
 	for n = ll.head; n.next != nil; n = n.next {
 	}
@@ -105,7 +182,7 @@ func (ll *DList[T]) mergeSort(dir direction) {
 	ll.tail = n
 }
 
-func (ll *DList[T]) mergeSortRecursive(node *DListNode[T], dir direction) *DListNode[T] {
+func (ll *DList[T]) mergeSortRecursive(node *DListNode[T], compare functions.ComparerFunc[T], dir direction) *DListNode[T] {
 	if node == nil || node.next == nil {
 		return node
 	}
@@ -113,11 +190,11 @@ func (ll *DList[T]) mergeSortRecursive(node *DListNode[T], dir direction) *DList
 	second := split(node)
 
 	// Recur for left and right halves
-	node = ll.mergeSortRecursive(node, dir)
-	second = ll.mergeSortRecursive(second, dir)
+	node = ll.mergeSortRecursive(node, compare, dir)
+	second = ll.mergeSortRecursive(second, compare, dir)
 
 	// Merge the two sorted halves
-	return ll.merge(node, second, dir)
+	return ll.merge(node, second, compare, dir)
 }
 
 func split[T any](head *DListNode[T]) *DListNode[T] {
@@ -134,7 +211,7 @@ func split[T any](head *DListNode[T]) *DListNode[T] {
 	return temp
 }
 
-func (ll *DList[T]) merge(first, second *DListNode[T], dir direction) *DListNode[T] {
+func (ll *DList[T]) merge(first, second *DListNode[T], compare functions.ComparerFunc[T], dir direction) *DListNode[T] {
 	// If first linked list is empty
 	if first == nil {
 		return second
@@ -148,23 +225,84 @@ func (ll *DList[T]) merge(first, second *DListNode[T], dir direction) *DListNode
 	var x bool
 
 	if dir == forward {
-		// Pick the smaller value
-		x = ll.compare(first.item, second.item) < 0
+		// Pick the smaller value, or the first of equal values so that the sort is stable
+		x = compare(first.item, second.item) <= 0
 	} else {
-		// Pick the larger value
-		x = ll.compare(first.item, second.item) > 0
+		// Pick the larger value, or the first of equal values so that the sort is stable
+		x = compare(first.item, second.item) >= 0
 	}
 
 	if x {
-		first.next = ll.merge(first.next, second, dir)
+		first.next = ll.merge(first.next, second, compare, dir)
 		first.next.prev = first
 		first.prev = nil
 		return first
 	}
 
-	second.next = ll.merge(first, second.next, dir)
+	second.next = ll.merge(first, second.next, compare, dir)
 	second.next.prev = second
 	second.prev = nil
 	return second
 
 }
+
+// SortByKey performs an in-place sort of the list by the keys computed for its values by key.
+// The value with the smallest key will be placed at the head of the list.
+//
+// Each key is computed once before sorting, so where comparing values is expensive, e.g. comparing strings
+// with collation, sorting by a cheaply compared key derived from each value is much faster than Sort.
+func SortByKey[T any, K constraints.Ordered](l *DList[T], key func(T) K) {
+
+	sortByKey(l, key, false)
+}
+
+// SortByKeyDescending is as [SortByKey], placing the value with the largest key at the head of the list.
+func SortByKeyDescending[T any, K constraints.Ordered](l *DList[T], key func(T) K) {
+
+	sortByKey(l, key, true)
+}
+
+func sortByKey[T any, K constraints.Ordered](l *DList[T], key func(T) K, descending bool) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if l.count < 2 {
+		return
+	}
+
+	// Sort the nodes rather than the values, so that references to nodes remain valid
+	nodes := make([]*DListNode[T], 0, l.count)
+
+	for n := l.head; n != nil; n = n.next {
+		nodes = append(nodes, n)
+	}
+
+	util.SortByKey(nodes, func(n *DListNode[T]) K { return key(n.item) }, util.GetOrderedComparer[K](), descending)
+
+	var prev *DListNode[T]
+
+	for _, n := range nodes {
+		n.prev = prev
+
+		if prev != nil {
+			prev.next = n
+		}
+
+		prev = n
+	}
+
+	prev.next = nil
+	l.head = nodes[0]
+	l.tail = prev
+	l.version++
+	l.recordReset()
+}
@@ -0,0 +1,117 @@
+package lists
+
+import (
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Assert emptyList implements required interfaces.
+var _ List[int] = (*emptyList[int])(nil)
+
+// emptyList is an immutable list with no values.
+type emptyList[T any] struct {
+	// version and lock are never set, but are laid out as in every other
+	// collection as they are read by the internal element and lock helpers.
+	version int
+	lock    *sync.RWMutex
+
+	collections.Collection[T]
+}
+
+// Empty returns an immutable list with no values, that may be returned instead of nil
+// by functions returning a List, so that callers need not check for nil.
+//
+// Methods that read the list behave as those of any other empty list, and methods that would
+// modify it panic, as described for [collections.Empty].
+// Map, Select, Sorted, Clone and the like return the empty list itself.
+//
+// There is one empty list for each type of value, so the result may be compared with ==.
+func Empty[T any]() List[T] {
+	return local.Singleton(func() *emptyList[T] {
+		return &emptyList[T]{Collection: collections.Empty[T]()}
+	})
+}
+
+// AddItemFirst panics as the list is read-only.
+func (*emptyList[T]) AddItemFirst(T) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// AddItemLast panics as the list is read-only.
+func (*emptyList[T]) AddItemLast(T) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// RemoveItem panics as the list is read-only.
+func (*emptyList[T]) RemoveItem(T) bool {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// RemoveFirst panics as the list is read-only.
+func (*emptyList[T]) RemoveFirst() T {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// RemoveLast panics as the list is read-only.
+func (*emptyList[T]) RemoveLast() T {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// TryRemoveFirst panics as the list is read-only.
+func (*emptyList[T]) TryRemoveFirst() (T, bool) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// TryRemoveLast panics as the list is read-only.
+func (*emptyList[T]) TryRemoveLast() (T, bool) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Sort panics as the list is read-only.
+func (*emptyList[T]) Sort() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// SortDescending panics as the list is read-only.
+func (*emptyList[T]) SortDescending() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Sorted returns this list.
+func (l *emptyList[T]) Sorted() collections.Collection[T] {
+	return l
+}
+
+// SortedDescending returns this list.
+func (l *emptyList[T]) SortedDescending() collections.Collection[T] {
+	return l
+}
+
+// Map returns this list.
+func (l *emptyList[T]) Map(func(T) T) collections.Collection[T] {
+	return l
+}
+
+// Select returns this list.
+func (l *emptyList[T]) Select(functions.PredicateFunc[T]) collections.Collection[T] {
+	return l
+}
+
+// SelectDeep returns this list.
+func (l *emptyList[T]) SelectDeep(functions.PredicateFunc[T]) collections.Collection[T] {
+	return l
+}
+
+// Clone returns this list.
+func (l *emptyList[T]) Clone() collections.Collection[T] {
+	return l
+}
+
+// CloneDeep returns this list.
+func (l *emptyList[T]) CloneDeep() collections.Collection[T] {
+	return l
+}
@@ -0,0 +1,32 @@
+package slist
+
+import (
+	"context"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.BulkLoader[int] = (*SList[int])(nil)
+
+// BulkAdd adds each batch of values received from src to the list, until src is closed or ctx is done.
+// Values are appended in the order they are received.
+//
+// Batches are received and added one at a time, so the number of values in flight is bounded
+// by the capacity of src, and a producer blocks while the list catches up. Each batch is added
+// under a single acquisition of the lock of a thread-safe list, so other goroutines may use it
+// between batches.
+//
+//	src := make(chan []Row, 4) // at most 4 batches waiting
+//	go readBatches(file, src)
+//	err := l.BulkAdd(ctx, src)
+//
+// Returns nil once src is closed, else the error of ctx.
+// The values of batches added before ctx is done remain in the list.
+//
+// Panics if src is nil.
+func (l *SList[T]) BulkAdd(ctx context.Context, src <-chan []T) error {
+
+	return util.BulkAdd(ctx, src, l.AddRange)
+}
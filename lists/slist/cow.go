@@ -0,0 +1,137 @@
+package slist
+
+import (
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/lists"
+)
+
+// Assert CopyOnWrite implements required interfaces.
+var _ lists.List[int] = (*CopyOnWrite[int])(nil)
+
+// CopyOnWrite is a thread-safe singly linked list for lists that are read far more often than they are written,
+// such as lists of subscribers or configuration.
+//
+// Each write is applied to a private working list, after which an immutable snapshot of its values
+// is published atomically. Reads use the latest snapshot without taking a lock, so they never wait
+// for a writer, however long the write. Publishing a snapshot copies the values, so each write is O(n).
+// Use [CopyOnWrite.Update] to make several modifications for the cost of one.
+//
+// Elements yielded by iterators and Find belong to a snapshot, so are never invalidated by modification,
+// and their ValuePtr method panics. ForEach is a write, so may modify values via ValuePtr.
+type CopyOnWrite[T any] struct {
+	// version and lock are never set, but are laid out as in every other
+	// collection as they are read by the internal element and lock helpers.
+	version int
+	lock    *sync.RWMutex
+
+	*util.CowCollection[T, *SList[T]]
+}
+
+// NewCopyOnWrite constructs a copy-on-write list, whose working list is constructed with the given options.
+// WithThreadSafe is implied.
+func NewCopyOnWrite[T any](options ...SListOptionFunc[T]) *CopyOnWrite[T] {
+	return newCopyOnWrite(New(append(options[:len(options):len(options)], WithThreadSafe[T]())...))
+}
+
+func newCopyOnWrite[T any](work *SList[T]) *CopyOnWrite[T] {
+	compare, deepCopy, copyPolicy := work.compare, work.copy, work.copyPolicy
+
+	derive := func(values []T) collections.Collection[T] {
+		l := New(WithComparer(compare), WithDeepCopy(deepCopy), WithCopyPolicy[T](copyPolicy), WithThreadSafe[T]())
+		l.AddRange(values)
+		return newCopyOnWrite(l)
+	}
+
+	return &CopyOnWrite[T]{
+		CowCollection: util.NewCowCollection(work, compare, deepCopy, derive, nil),
+	}
+}
+
+// AddItemFirst adds the given value at the head of the list.
+func (l *CopyOnWrite[T]) AddItemFirst(value T) {
+	l.Update(func(w *SList[T]) { w.AddItemFirst(value) })
+}
+
+// AddItemLast adds the given value at the end of the list.
+func (l *CopyOnWrite[T]) AddItemLast(value T) {
+	l.Update(func(w *SList[T]) { w.AddItemLast(value) })
+}
+
+// RemoveItem removes the first occurrence of the value from the list.
+//
+// Returns true if a value was removed; else false.
+func (l *CopyOnWrite[T]) RemoveItem(value T) bool {
+	var removed bool
+	l.Update(func(w *SList[T]) { removed = w.RemoveItem(value) })
+	return removed
+}
+
+// RemoveFirst removes the value at the head of the list and returns it.
+//
+// Panics if the list is empty.
+func (l *CopyOnWrite[T]) RemoveFirst() T {
+	var value T
+	l.Update(func(w *SList[T]) { value = w.RemoveFirst() })
+	return value
+}
+
+// RemoveLast removes the value at the end of the list and returns it.
+//
+// Panics if the list is empty.
+func (l *CopyOnWrite[T]) RemoveLast() T {
+	var value T
+	l.Update(func(w *SList[T]) { value = w.RemoveLast() })
+	return value
+}
+
+// TryRemoveFirst removes the value at the head of the list and returns it and true,
+// or the zero value of T and false if the list is empty.
+func (l *CopyOnWrite[T]) TryRemoveFirst() (T, bool) {
+	var value T
+	var ok bool
+	l.Update(func(w *SList[T]) { value, ok = w.TryRemoveFirst() })
+	return value, ok
+}
+
+// TryRemoveLast removes the value at the end of the list and returns it and true,
+// or the zero value of T and false if the list is empty.
+func (l *CopyOnWrite[T]) TryRemoveLast() (T, bool) {
+	var value T
+	var ok bool
+	l.Update(func(w *SList[T]) { value, ok = w.TryRemoveLast() })
+	return value, ok
+}
+
+// Sort sorts the list in ascending order.
+func (l *CopyOnWrite[T]) Sort() {
+	l.Update(func(w *SList[T]) { w.Sort() })
+}
+
+// SortDescending sorts the list in descending order.
+func (l *CopyOnWrite[T]) SortDescending() {
+	l.Update(func(w *SList[T]) { w.SortDescending() })
+}
+
+// Sorted returns a sorted copy of the latest snapshot as a new copy-on-write list,
+// using the provided [functions.DeepCopyFunc] if any.
+func (l *CopyOnWrite[T]) Sorted() collections.Collection[T] {
+	sorted := l.CloneDeep().(*CopyOnWrite[T])
+	sorted.Sort()
+	return sorted
+}
+
+// SortedDescending returns a descending sorted copy of the latest snapshot as a new copy-on-write list,
+// using the provided [functions.DeepCopyFunc] if any.
+func (l *CopyOnWrite[T]) SortedDescending() collections.Collection[T] {
+	sorted := l.CloneDeep().(*CopyOnWrite[T])
+	sorted.SortDescending()
+	return sorted
+}
+
+// Dispose removes the working list from the [registry] if it was created with [WithName].
+func (l *CopyOnWrite[T]) Dispose() {
+	l.Update(func(w *SList[T]) { w.Dispose() })
+}
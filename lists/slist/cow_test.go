@@ -0,0 +1,110 @@
+package slist
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyOnWriteConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return NewCopyOnWrite(WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return NewCopyOnWrite(WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+		SnapshotElements: true,
+	})
+}
+
+func TestCopyOnWrite(t *testing.T) {
+
+	t.Run("List operations", func(t *testing.T) {
+		l := NewCopyOnWrite[int]()
+		l.AddItemLast(2)
+		l.AddItemFirst(1)
+		l.AddItemLast(3)
+		require.Equal(t, []int{1, 2, 3}, l.ToSlice())
+		require.Equal(t, 1, l.RemoveFirst())
+		require.Equal(t, 3, l.RemoveLast())
+		require.Equal(t, []int{2}, l.ToSlice())
+	})
+
+	t.Run("Update publishes once", func(t *testing.T) {
+		l := NewCopyOnWrite[int]()
+		it := l.Iterator()
+
+		l.Update(func(w *SList[int]) {
+			w.AddRange([]int{3, 1, 2})
+			w.Sort()
+			require.Equal(t, 0, l.Count(), "reads should not see an update in progress")
+		})
+
+		require.Equal(t, []int{1, 2, 3}, l.ToSlice())
+		require.Nil(t, it.Start(), "iterator should read the snapshot at its creation")
+	})
+
+	t.Run("Elements of a snapshot are read only", func(t *testing.T) {
+		l := NewCopyOnWrite[int]()
+		l.Add(1)
+		e := l.Find(func(int) bool { return true })
+		require.PanicsWithValue(t, messages.SNAPSHOT_POINTER, func() { e.ValuePtr() })
+
+		l.ForEach(func(e collections.Element[int]) { *e.ValuePtr() = 2 })
+		require.Equal(t, []int{2}, l.ToSlice())
+	})
+
+	t.Run("Sorted is a copy-on-write list", func(t *testing.T) {
+		l := NewCopyOnWrite[int]()
+		l.AddRange([]int{3, 1, 2})
+		sorted := l.Sorted()
+		require.IsType(t, l, sorted)
+		require.Equal(t, []int{1, 2, 3}, sorted.ToSlice())
+		require.Equal(t, []int{3, 1, 2}, l.ToSlice())
+	})
+
+	t.Run("Reads are concurrent with writes", func(t *testing.T) {
+		l := NewCopyOnWrite[int]()
+		var wg sync.WaitGroup
+
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					l.Add(i)
+				}
+			}()
+		}
+
+		for r := 0; r < 4; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					count := 0
+					for it := l.Iterator(); it.Next() != nil; {
+						count++
+					}
+					require.LessOrEqual(t, count, 400)
+					l.Contains(i)
+				}
+			}()
+		}
+
+		wg.Wait()
+		require.Equal(t, 400, l.Count())
+	})
+}
@@ -0,0 +1,69 @@
+package slist
+
+import (
+	"io"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// Encode writes the values of the list to w from head to tail, using enc to encode each value.
+//
+// Values are written as the list is traversed, so no intermediate copy of the list is made.
+// The stream begins with a header describing the [schema] of the list, but not its options, followed
+// by the number of values, allowing [SList.Decode] to read exactly the values written even if further
+// data follows in the same stream.
+//
+// Returns the first error returned by w or enc.
+func (l *SList[T]) Encode(w io.Writer, enc func(io.Writer, T) error) error {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	if err := schema.Write(w, schema.Of[T](collections.COLLECTION_SLIST, nil)); err != nil {
+		return err
+	}
+
+	return util.EncodeStream(w, l.count, func(yield func(T) bool) {
+		for node := l.head; node != nil && yield(node.item); node = node.next {
+		}
+	}, enc)
+}
+
+// Decode reads values written by [SList.Encode] from r, using dec to decode each value,
+// and appends them to the end of the list. Values are appended as they are read.
+//
+// If the list was created [WithSchemaValidation], returns an error if the stream has no header or
+// was written by another type of collection or value. Returns the first error returned by dec,
+// or io.ErrUnexpectedEOF if the stream is truncated.
+// Values read before an error remain in the list.
+func (l *SList[T]) Decode(r io.Reader, dec func(io.Reader) (T, error)) error {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	r, err := util.ReadSchema(r, schema.Of[T](collections.COLLECTION_SLIST, nil), l.verifySchema)
+
+	if err != nil {
+		return err
+	}
+
+	return util.DecodeStream(r, dec, func(value T) {
+		l.addItemLast(value)
+	})
+}
@@ -0,0 +1,123 @@
+package slist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeInt(w io.Writer, v int) error {
+	return binary.Write(w, binary.LittleEndian, int64(v))
+}
+
+func decodeInt(r io.Reader) (int, error) {
+	var v int64
+	err := binary.Read(r, binary.LittleEndian, &v)
+	return int(v), err
+}
+
+func TestEncodeDecode(t *testing.T) {
+
+	seed := int64(2163)
+
+	t.Run("Round trip preserves order", func(t *testing.T) {
+		values := util.CreateSingleIntListData(1000, &seed)
+		ll := New[int]()
+		ll.AddRange(values)
+
+		var buf bytes.Buffer
+		require.NoError(t, ll.Encode(&buf, encodeInt))
+		buf.WriteString("trailer")
+
+		ll1 := New[int]()
+		ll1.Add(-1)
+		require.NoError(t, ll1.Decode(&buf, decodeInt))
+		require.Equal(t, append([]int{-1}, values...), ll1.ToSlice())
+		require.Equal(t, "trailer", buf.String())
+	})
+
+	t.Run("Empty list", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, New[int]().Encode(&buf, encodeInt))
+
+		ll := New[int]()
+		require.NoError(t, ll.Decode(&buf, decodeInt))
+		require.True(t, ll.IsEmpty())
+	})
+
+	t.Run("Encoder error stops encoding", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2, 3})
+		errStop := errors.New("stop")
+		calls := 0
+
+		err := ll.Encode(io.Discard, func(w io.Writer, v int) error {
+			calls++
+			if v == 2 {
+				return errStop
+			}
+			return nil
+		})
+
+		require.ErrorIs(t, err, errStop)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("Truncated stream", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2, 3})
+
+		var buf bytes.Buffer
+		require.NoError(t, ll.Encode(&buf, encodeInt))
+		buf.Truncate(buf.Len() - 8)
+
+		ll1 := New[int]()
+		require.ErrorIs(t, ll1.Decode(&buf, decodeInt), io.ErrUnexpectedEOF)
+		require.Equal(t, []int{1, 2}, ll1.ToSlice())
+		require.ErrorIs(t, ll1.Decode(&bytes.Buffer{}, decodeInt), io.ErrUnexpectedEOF)
+	})
+
+	t.Run("Schema validation", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{1, 2, 3})
+
+		var buf bytes.Buffer
+		require.NoError(t, ll.Encode(&buf, encodeInt))
+
+		ll1 := New(WithSchemaValidation[int]())
+		require.NoError(t, ll1.Decode(&buf, decodeInt))
+		require.Equal(t, []int{1, 2, 3}, ll1.ToSlice())
+
+		buf.Reset()
+		require.NoError(t, schema.Write(&buf, schema.Of[int](collections.COLLECTION_ARRAYLIST, nil)))
+		require.NoError(t, util.EncodeStream(&buf, 1, func(yield func(int) bool) { yield(1) }, encodeInt))
+
+		var mismatch *schema.MismatchError
+		require.ErrorAs(t, New(WithSchemaValidation[int]()).Decode(&buf, decodeInt), &mismatch)
+		require.Equal(t, "collection", mismatch.Field)
+	})
+
+	t.Run("Stream without a schema header", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, util.EncodeStream(&buf, 2, func(yield func(int) bool) { _ = yield(1) && yield(2) }, encodeInt))
+		buf.WriteString("trailer")
+		data := buf.Bytes()
+
+		ll := New[int]()
+		require.NoError(t, ll.Decode(bytes.NewReader(data), decodeInt))
+		require.Equal(t, []int{1, 2}, ll.ToSlice())
+
+		require.ErrorIs(t, New(WithSchemaValidation[int]()).Decode(bytes.NewReader(data), decodeInt), schema.ErrMissing)
+
+		ll = New[int]()
+		require.NoError(t, ll.Decode(bytes.NewReader([]byte{0}), decodeInt))
+		require.True(t, ll.IsEmpty())
+	})
+}
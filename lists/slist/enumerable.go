@@ -1,6 +1,8 @@
 package slist
 
 import (
+	"errors"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/messages"
@@ -19,6 +21,12 @@ func (l *SList[T]) Any(predicate functions.PredicateFunc[T]) bool {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	iter := newForwardIterator[T](l, predicate)
 
 	return iter.Start() != nil
@@ -33,6 +41,12 @@ func (l *SList[T]) All(predicate functions.PredicateFunc[T]) bool {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	iter := newForwardIterator[T](l, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -52,6 +66,12 @@ func (l *SList[T]) ForEach(f func(collections.Element[T])) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	iter := newForwardIterator[T](l, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -59,6 +79,59 @@ func (l *SList[T]) ForEach(f func(collections.Element[T])) {
 	}
 }
 
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (l *SList[T]) TryForEach(f func(T) error) error {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](l, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (l *SList[T]) TryForEachAll(f func(T) error) error {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	var errs []error
+	iter := newForwardIterator[T](l, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Map applies function f to all elements in the collection
 // and returns a new SList containing the result of f.
 func (l *SList[T]) Map(f func(T) T) collections.Collection[T] {
@@ -68,6 +141,12 @@ func (l *SList[T]) Map(f func(T) T) collections.Collection[T] {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	iter := newForwardIterator[T](l, util.DefaultPredicate[T])
 
 	ll1 := New[T](WithComparer[T](l.compare))
@@ -87,6 +166,12 @@ func (l *SList[T]) Select(predicate functions.PredicateFunc[T]) collections.Coll
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	return l.doSelect(predicate, false)
 }
 
@@ -100,9 +185,21 @@ func (l *SList[T]) SelectDeep(predicate functions.PredicateFunc[T]) collections.
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	return l.doSelect(predicate, true)
 }
 
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (l *SList[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(l.copyWhere(predicate))
+}
+
 // Find finds the first occurrence of an element matching the predicate.
 //
 // The function returns nil if no match.
@@ -113,6 +210,10 @@ func (l *SList[T]) Find(predicate functions.PredicateFunc[T]) collections.Elemen
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	result := l.doFind(predicate, false)
 
 	if len(result) == 0 {
@@ -136,6 +237,10 @@ func (l *SList[T]) FindLast(predicate functions.PredicateFunc[T]) collections.El
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	result := l.doFind(predicate, true)
 
 	length := len(result)
@@ -157,6 +262,10 @@ func (l *SList[T]) FindAll(predicate functions.PredicateFunc[T]) []collections.E
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	result := l.doFind(predicate, true)
 
 	return result
@@ -170,6 +279,10 @@ func (l *SList[T]) Min() T {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	if l.head == nil {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -193,6 +306,10 @@ func (l *SList[T]) Max() T {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	if l.head == nil {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -238,20 +355,19 @@ func (l *SList[T]) doFind(predicate functions.PredicateFunc[T], all bool) []coll
 
 	for node := l.head; node != nil; node = node.next {
 		if predicate(node.item) {
-			result = append(result, util.NewElementType[T](l, &node.item))
-		}
+			result = append(result, util.NewElementType[T](l, &node.item, l.compare))
 
-		if !all {
-			break
+			if !all {
+				break
+			}
 		}
-
 	}
 
 	return result
 }
 
 func (l *SList[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
-	sl1 := New[T](WithComparer[T](l.compare))
+	sl1 := New[T](WithComparer[T](l.compare), WithDeepCopy(l.copy), WithCopyPolicy[T](l.copyPolicy))
 	iter := newForwardIterator[T](l, predicate)
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -264,3 +380,24 @@ func (l *SList[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool)
 
 	return sl1
 }
+
+func (l *SList[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+	iter := newForwardIterator[T](l, predicate)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, util.DeepCopy(e.Value(), l.copy))
+	}
+
+	return values
+}
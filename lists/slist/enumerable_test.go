@@ -1,6 +1,8 @@
 package slist
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/fireflycons/generic_collections/collections"
@@ -107,6 +109,37 @@ func TestEnumerable(t *testing.T) {
 		// Output won't be in the same order as input slice
 		require.ElementsMatch(t, expected, s1.ToSlice())
 	})
+
+	t.Run("CopyTo copies all values <= 6 to destination", func(t *testing.T) {
+		sl = New[int]()
+		expected := []int{1, 2, 4, 6}
+
+		sl.AddRange(evens)
+		dest := New[int]()
+		dest.Add(1)
+		sl.CopyTo(dest, func(i int) bool { return i <= 6 })
+
+		require.ElementsMatch(t, expected, dest.ToSlice())
+		require.Equal(t, len(evens), sl.Count())
+	})
+
+	t.Run("CopyTo deep copies values", func(t *testing.T) {
+		values := []int{1, 2, 3}
+		src := New(WithDeepCopy(deepCopyIntPtr))
+
+		for i := range values {
+			src.Add(&values[i])
+		}
+
+		dest := New[*int]()
+		src.CopyTo(dest, func(p *int) bool { return *p >= 2 })
+		require.Equal(t, 2, dest.Count())
+
+		dest.ForEach(func(e collections.Element[*int]) {
+			require.NotSame(t, &values[1], e.Value())
+			require.NotSame(t, &values[2], e.Value())
+		})
+	})
 }
 
 func TestMinMax(t *testing.T) {
@@ -127,3 +160,46 @@ func TestMinMax(t *testing.T) {
 		require.Equal(t, max, sl.Max())
 	})
 }
+
+func deepCopyIntPtr(p *int) *int {
+	v := *p
+	return &v
+}
+
+func TestTryForEach(t *testing.T) {
+
+	errOdd := errors.New("odd")
+	failOdd := func(v int) error {
+		if v%2 != 0 {
+			return fmt.Errorf("%d: %w", v, errOdd)
+		}
+		return nil
+	}
+
+	t.Run("TryForEach stops at first error", func(t *testing.T) {
+		c := Of(2, 4, 5, 6, 7)
+		visited := 0
+		err := c.TryForEach(func(v int) error {
+			visited++
+			return failOdd(v)
+		})
+
+		require.True(t, errors.Is(err, errOdd))
+		require.Less(t, visited, 5)
+		require.NoError(t, Of(2, 4).TryForEach(failOdd))
+	})
+
+	t.Run("TryForEachAll collects all errors", func(t *testing.T) {
+		c := Of(2, 4, 5, 6, 7)
+		visited := 0
+		err := c.TryForEachAll(func(v int) error {
+			visited++
+			return failOdd(v)
+		})
+
+		require.True(t, errors.Is(err, errOdd))
+		require.Equal(t, 5, visited)
+		require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+		require.NoError(t, Of(2, 4).TryForEachAll(failOdd))
+	})
+}
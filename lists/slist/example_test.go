@@ -0,0 +1,68 @@
+package slist_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/lists/slist"
+)
+
+func Example() {
+	l := slist.New[string]()
+	l.AddItemLast("b")
+	l.AddItemFirst("a")
+	l.AddItemLast("c")
+
+	fmt.Println(l.ToSlice())
+	fmt.Println(l.RemoveFirst(), l.ToSlice())
+	// Output:
+	// [a b c]
+	// a [b c]
+}
+
+// Nodes may be used to edit the list at any position.
+func Example_nodes() {
+	l := slist.Of(1, 2, 4)
+
+	node := l.First().Next()
+	node = l.AddItemAfter(node, 3)
+	node.SetValue(30)
+	l.RemoveNode(l.First())
+
+	fmt.Println(l.ToSlice())
+	// Output:
+	// [2 30 4]
+}
+
+func ExampleSList_Iterator() {
+	l := slist.Of("a", "b", "c")
+	iter := l.Iterator()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Print(e.Value(), " ")
+	}
+	fmt.Println()
+	// Output:
+	// a b c
+}
+
+func ExampleSList_ForEach() {
+	l := slist.Of(1, 2, 3)
+
+	// Values may be modified in place via the element.
+	l.ForEach(func(e collections.Element[int]) {
+		*e.ValuePtr() *= 10
+	})
+
+	fmt.Println(l.ToSlice())
+	// Output:
+	// [10 20 30]
+}
+
+func ExampleSList_Thin() {
+	l := slist.Of(0, 1, 2, 3, 4, 5, 6)
+
+	fmt.Println(l.Thin(3).ToSlice())
+	// Output:
+	// [0 3 6]
+}
@@ -0,0 +1,40 @@
+package slist
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// GobEncode implements gob.GobEncoder, encoding the values of the list from head to tail,
+// so that the list may be persisted or sent over RPC with encoding/gob.
+// The values must themselves be encodable by encoding/gob. The values follow a header describing the [schema] of the list, but its options are not encoded.
+func (l *SList[T]) GobEncode() ([]byte, error) {
+
+	return util.GobEncode(schema.Of[T](collections.COLLECTION_SLIST, nil), l.ToSlice())
+}
+
+// GobDecode implements gob.GobDecoder, replacing the values of the list with those encoded by GobEncode.
+//
+// The options of the list are retained, so decode into a list constructed with the options
+// of that encoded, or into a zero value for the default options.
+func (l *SList[T]) GobDecode(data []byte) error {
+
+	var values []T
+
+	if err := util.GobDecode(data, &values, schema.Of[T](collections.COLLECTION_SLIST, nil), l.verifySchema); err != nil {
+		return err
+	}
+
+	l.replaceAll(values)
+	return nil
+}
+
+// Option function for New to verify the schema header of data decoded by GobDecode and Decode,
+// which then returns an error wrapping [schema.ErrMismatch] if the data was encoded
+// from another type of collection or value, or [schema.ErrMissing] if it has no header.
+func WithSchemaValidation[T any]() SListOptionFunc[T] {
+	return func(l *SList[T]) {
+		l.verifySchema = true
+	}
+}
@@ -0,0 +1,22 @@
+package slist
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the list is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (l *SList[T]) verifyInvariants() {
+	util.AssertInvariant((l.head == nil) == (l.count == 0), "list head does not match count")
+	util.AssertInvariant((l.tail == nil) == (l.count == 0), "list tail does not match count")
+
+	var last *SListNode[T]
+	count := 0
+
+	for n := l.head; n != nil && count <= l.count; n = n.Next() {
+		util.AssertInvariant(n.list == l, "list node belongs to another list")
+		last = n
+		count++
+	}
+
+	util.AssertInvariant(count == l.count, "list count does not match nodes")
+	util.AssertInvariant(last == l.tail, "list tail is not last node")
+}
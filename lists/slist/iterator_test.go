@@ -5,6 +5,7 @@ import (
 
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/stretchr/testify/require"
+	"math/rand"
 )
 
 func TestValidateIteratorNotNil(t *testing.T) {
@@ -146,3 +147,30 @@ func TestWhere(t *testing.T) {
 
 	})
 }
+
+func TestSampleIterator(t *testing.T) {
+	col := Of(1, 2, 3, 4, 5, 6, 7, 8)
+	iter := col.SampleIterator(3, rand.New(rand.NewSource(1)))
+	seen := make(map[int]bool)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		require.Contains(t, col.ToSlice(), e.Value())
+		require.False(t, seen[e.Value()])
+		seen[e.Value()] = true
+	}
+
+	require.Len(t, seen, 3)
+}
+
+func TestElementRefresh(t *testing.T) {
+	l := Of(1, 2, 3)
+	e := l.Iterator().Start()
+	l.Add(4)
+
+	require.False(t, e.IsValid())
+	require.True(t, e.Refresh())
+	require.Equal(t, 1, e.Value())
+
+	l.Remove(1)
+	require.False(t, e.Refresh())
+}
@@ -0,0 +1,92 @@
+package slist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOps(t *testing.T) {
+
+	t.Run("Script is applied in order", func(t *testing.T) {
+		ll := New[int]()
+		ll.Add(10)
+		ll.ApplyOps([]ops.Op[int]{
+			ops.Clear[int](),
+			ops.Add(1),
+			ops.Add(2),
+			ops.Add(1),
+			ops.Remove(1),
+			ops.Remove(5),
+		})
+
+		require.Equal(t, []int{2, 1}, ll.ToSlice())
+	})
+
+	t.Run("Invalid op kind panics", func(t *testing.T) {
+		ll := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.OP_KIND_INVALID_FMT, 9), func() {
+			ll.ApplyOps([]ops.Op[int]{ops.Add(1), {Kind: 9}})
+		})
+		require.Equal(t, []int{1}, ll.ToSlice())
+	})
+}
+
+func TestRecording(t *testing.T) {
+
+	t.Run("Replaying recorded ops reproduces list", func(t *testing.T) {
+		ll := New[int]()
+		ll.AddRange([]int{9, 8})
+		ll.StartRecording()
+		require.True(t, ll.IsRecording())
+
+		ll.Add(1)
+		ll.AddRange([]int{2, 3, 2})
+		ll.AddItemFirst(4)
+		ll.AddItemAfter(ll.First(), 5)
+		ll.Remove(2)
+		ll.RemoveFirst()
+		ll.RemoveLast()
+		ll.RemoveNode(ll.First().Next())
+		ll.Sort()
+		ll.ReplaceAll(New[int]())
+		ll.AddRange([]int{7, 7, 3})
+		ll.SortDescending()
+		ll.RemoveFirst()
+
+		recorded := ll.StopRecording()
+		require.False(t, ll.IsRecording())
+
+		// Replay onto a list in the state that recording started from
+		replica := New[int]()
+		replica.AddRange([]int{9, 8})
+		replica.ApplyOps(recorded)
+		require.Equal(t, ll.ToSlice(), replica.ToSlice())
+	})
+
+	t.Run("Appends and removals from head are recorded compactly", func(t *testing.T) {
+		ll := New[int]()
+		ll.StartRecording()
+		ll.Add(1)
+		ll.Add(2)
+		ll.RemoveFirst()
+		ll.Clear()
+
+		require.Equal(t, []ops.Op[int]{ops.Add(1), ops.Add(2), ops.Remove(1), ops.Clear[int]()}, ll.StopRecording())
+	})
+
+	t.Run("Mutations are not recorded when not recording", func(t *testing.T) {
+		ll := New[int]()
+		ll.Add(1)
+		ll.StartRecording()
+		ll.Add(2)
+		ll.StopRecording()
+		ll.Add(3)
+		ll.StartRecording()
+
+		require.Empty(t, ll.StopRecording())
+	})
+}
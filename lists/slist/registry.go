@@ -0,0 +1,67 @@
+package slist
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+)
+
+// Option function for New to give the list a name, under which it is registered with the
+// [registry] so that its size, version and operation counts are included in [registry.Snapshot].
+// The list remains registered until Dispose is called.
+//
+// Panics if name is empty.
+func WithName[T any](name string) SListOptionFunc[T] {
+	if name == "" {
+		panic(messages.NAME_EMPTY)
+	}
+	return func(l *SList[T]) {
+		l.name = name
+	}
+}
+
+// Dispose removes the list from the [registry] if it was created with [WithName].
+// The list remains usable, but no longer appears in [registry.Snapshot].
+// Calling Dispose more than once has no effect.
+func (l *SList[T]) Dispose() {
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.registration.Deregister()
+	l.registration = nil
+}
+
+// register registers the list with the registry if it has been given a name.
+func (l *SList[T]) register() {
+	if l.name == "" {
+		return
+	}
+
+	l.recorder.EnableCounting()
+	l.registration = registry.Register(l.name, l.stats)
+}
+
+func (l *SList[T]) stats() registry.Stats {
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	return registry.Stats{
+		Type:    collections.COLLECTION_SLIST,
+		Count:   l.count,
+		Version: l.version,
+		Ops:     l.recorder.Counts(),
+	}
+}
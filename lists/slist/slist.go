@@ -14,23 +14,37 @@ import (
 	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/fireflycons/generic_collections/lists"
+	"github.com/fireflycons/generic_collections/registry"
+	"golang.org/x/exp/constraints"
 )
 
 // Assert SList implements required interfaces.
 var _ lists.List[int] = (*SList[int])(nil)
+var _ collections.Pageable[int] = (*SList[int])(nil)
 
 // SListOptionFunc is the signature of a function
 // for providing options to the SList constructor.
 type SListOptionFunc[T any] func(*SList[T])
 
+// SList represents a singly linked list of elements of type T.
+//
+// The zero value is an empty list with default options, ready to use. It is not thread-safe.
 type SList[T any] struct {
-	version int
-	lock    *sync.RWMutex
-	head    *SListNode[T]
-	tail    *SListNode[T]
-	count   int
-	compare functions.ComparerFunc[T]
-	copy    functions.DeepCopyFunc[T]
+	version      int
+	lock         *sync.RWMutex
+	head         *SListNode[T]
+	tail         *SListNode[T]
+	count        int
+	compare      functions.ComparerFunc[T]
+	copy         functions.DeepCopyFunc[T]
+	copyPolicy   collections.CopyPolicy
+	recorder     util.OpRecorder[T]
+	guard        util.AccessGuard
+	immutable    util.SliceCache[T]
+	validator    *util.Validator[T]
+	verifySchema bool
+	name         string
+	registration *registry.Registration
 	local.InternalImpl
 }
 
@@ -42,7 +56,7 @@ func New[T any](options ...SListOptionFunc[T]) *SList[T] {
 		o(sl)
 	}
 
-	if sl.copy == nil {
+	if sl.copy == nil || sl.copyPolicy == collections.Shallow {
 		sl.copy = util.DefaultDeepCopy[T]
 	}
 
@@ -50,9 +64,55 @@ func New[T any](options ...SListOptionFunc[T]) *SList[T] {
 		sl.compare = util.GetDefaultComparer[T]()
 	}
 
+	sl.register()
+
 	return sl
 }
 
+// lazyInit completes the construction of a zero value SList on its first modification,
+// as New would have done.
+func (l *SList[T]) lazyInit() {
+	if l.compare != nil {
+		return
+	}
+
+	l.compare = util.GetZeroValueComparer[T]()
+
+	if l.copy == nil {
+		l.copy = util.DefaultDeepCopy[T]
+	}
+}
+
+// Of constructs a new linked list containing the given values, e.g. slist.Of(1, 2, 3).
+//
+// The values are added in order.
+func Of[T any](values ...T) *SList[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	l := slist.OfWith([]slist.SListOptionFunc[int]{slist.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []SListOptionFunc[T], values ...T) *SList[T] {
+	l := New(options...)
+	l.AddRange(values)
+	return l
+}
+
+// NewFunc is as [New], using compare to find values, e.g. in Contains and Sort.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...SListOptionFunc[T]) *SList[T] {
+	return New(append([]SListOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...SListOptionFunc[T]) *SList[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
 // Option function to make the collection thread-safe. Adds overhead.
 func WithThreadSafe[T any]() SListOptionFunc[T] {
 	return func(sl *SList[T]) {
@@ -79,6 +139,61 @@ func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) SListOptionFunc[T] {
 	}
 }
 
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) SListOptionFunc[T] {
+	return func(sl *SList[T]) {
+		sl.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+//
+// Concurrent and Capacity are ignored by this collection.
+func WithOptions[T any](options collections.CommonOptions[T]) SListOptionFunc[T] {
+	opts := make([]SListOptionFunc[T], 0, 4)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(l *SList[T]) {
+		for _, o := range opts {
+			o(l)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection,
+// ReplaceAll, AddItemFirst, AddItemLast and AddItemAfter with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) SListOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(l *SList[T]) {
+		l.validator = util.NewValidator(validator, policy)
+	}
+}
+
 // AddItemFirst adds the given value at the head of the list.
 func (l *SList[T]) AddItemFirst(value T) {
 
@@ -87,6 +202,16 @@ func (l *SList[T]) AddItemFirst(value T) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if !l.validator.Accept(value) {
+		return
+	}
+
 	newNode := &SListNode[T]{
 		list: l,
 		item: value,
@@ -104,6 +229,16 @@ func (l *SList[T]) AddItemLast(value T) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if !l.validator.Accept(value) {
+		return
+	}
+
 	newNode := &SListNode[T]{
 		list: l,
 		item: value,
@@ -115,10 +250,30 @@ func (l *SList[T]) AddItemLast(value T) {
 
 // Add adds a value to the end of the list.
 //
-// Always returns true.
+// Returns true unless the value was rejected by the validator.
 func (l *SList[T]) Add(value T) bool {
 
-	l.AddItemLast(value)
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if !l.validator.Accept(value) {
+		return false
+	}
+
+	l.appendNode(&SListNode[T]{
+		list: l,
+		item: value,
+	})
+
+	l.version++
 	return true
 }
 
@@ -145,6 +300,16 @@ func (l *SList[T]) AddRange(values []T) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if values = l.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
 	for _, v := range values {
 		l.appendNode(&SListNode[T]{
 			list: l,
@@ -159,7 +324,7 @@ func (l *SList[T]) AddRange(values []T) {
 // Values are added in the order defined by the other collection.
 func (l *SList[T]) AddCollection(collection collections.Collection[T]) {
 
-	l.AddRange(collection.ToSliceDeep())
+	l.AddRange(util.ImportValues(collection, l.copyPolicy))
 }
 
 // First returns the node at the head of the list.
@@ -176,7 +341,8 @@ func (l *SList[T]) Last() *SListNode[T] {
 	return l.tail
 }
 
-// AddItemAfter inserts a value after the given node in the list and returns the newly inserted node.
+// AddItemAfter inserts a value after the given node in the list and returns the newly inserted node,
+// or nil if the value was rejected by the validator.
 //
 // Panics if the node argument is nil or belongs to another list.
 func (l *SList[T]) AddItemAfter(node *SListNode[T], value T) *SListNode[T] {
@@ -186,7 +352,18 @@ func (l *SList[T]) AddItemAfter(node *SListNode[T], value T) *SListNode[T] {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNode(node)
+
+	if !l.validator.Accept(value) {
+		return nil
+	}
+
 	newNode := &SListNode[T]{
 		list: l,
 		item: value,
@@ -213,6 +390,12 @@ func (l *SList[T]) AddNodeAfter(node, newNode *SListNode[T]) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNode(node)
 	l.validateNewNode(newNode)
 
@@ -236,6 +419,12 @@ func (l *SList[T]) AddNodeFirst(node *SListNode[T]) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNewNode(node)
 	l.prependNode(node)
 	l.version++
@@ -251,6 +440,12 @@ func (l *SList[T]) AddNodeLast(node *SListNode[T]) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNewNode(node)
 
 	l.appendNode(node)
@@ -269,6 +464,50 @@ func (l *SList[T]) Clear() {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.clear()
+}
+
+// ReplaceAll replaces the content of the list with the values of the given collection.
+// Values are added in the order defined by the other collection.
+//
+// All existing nodes are detached and invalidated.
+func (l *SList[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	l.replaceAll(util.ImportValues(collection, l.copyPolicy))
+}
+
+// replaceAll replaces the values of the list with the given values under a single lock.
+func (l *SList[T]) replaceAll(values []T) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	values = l.validator.Filter(values)
+	l.clear()
+
+	for _, v := range values {
+		l.appendNode(&SListNode[T]{
+			list: l,
+			item: v,
+		})
+	}
+}
+
+func (l *SList[T]) clear() {
 	var empty T
 	current := l.head
 	for current != nil {
@@ -279,8 +518,10 @@ func (l *SList[T]) Clear() {
 	}
 
 	l.head = nil
+	l.tail = nil
 	l.count = 0
 	l.version++
+	l.recorder.Clear()
 }
 
 // Contains returns true if the given value is in the list; else false. Up to O(n).
@@ -291,6 +532,10 @@ func (l *SList[T]) Contains(value T) bool {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	if l.head == nil {
 		return false
 	}
@@ -321,9 +566,16 @@ func (l *SList[T]) RemoveItem(value T) bool {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	node := l.findNode(value)
 	if node != nil {
 		l.removeNode(node)
+		l.recorder.Remove(value)
 
 		var empty T
 		node.item = empty
@@ -343,8 +595,15 @@ func (l *SList[T]) RemoveNode(node *SListNode[T]) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	l.validateNode(node)
 	l.removeNode(node)
+	l.recordReset()
 }
 
 // RemoveFirst removes the node at the head of the list and returns the value that was stored
@@ -357,12 +616,19 @@ func (l *SList[T]) RemoveFirst() T {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	if l.head == nil {
 		panic(messages.COLLECTION_EMPTY)
 	}
 
 	item := l.head.item
 	l.removeNode(l.head)
+	l.recorder.Remove(item)
 	return item
 }
 
@@ -376,12 +642,19 @@ func (l *SList[T]) RemoveLast() T {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	if l.head == nil {
 		panic(messages.COLLECTION_EMPTY)
 	}
 
 	item := l.tail.item
 	l.removeNode(l.tail)
+	l.recordReset()
 	return item
 }
 
@@ -394,6 +667,12 @@ func (l *SList[T]) TryRemoveFirst() (T, bool) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	if l.head == nil {
 		var v T
 		return v, false
@@ -401,6 +680,7 @@ func (l *SList[T]) TryRemoveFirst() (T, bool) {
 
 	item := l.head.item
 	l.removeNode(l.head)
+	l.recorder.Remove(item)
 	return item, true
 }
 
@@ -413,6 +693,12 @@ func (l *SList[T]) TryRemoveLast() (T, bool) {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	if l.head == nil {
 		var v T
 		return v, false
@@ -420,9 +706,45 @@ func (l *SList[T]) TryRemoveLast() (T, bool) {
 
 	item := l.tail.item
 	l.removeNode(l.tail)
+	l.recordReset()
 	return item, true
 }
 
+// Page returns a copy of the values on the given zero-based page of the list,
+// counting from the head of the list.
+//
+// Panics if pageIndex is negative or pageSize is less than 1.
+func (l *SList[T]) Page(pageIndex, pageSize int) []T {
+
+	if l.lock != nil {
+		l.lock.RLock()
+		defer l.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
+	start, end := util.PageBounds(l.count, pageIndex, pageSize)
+	page := make([]T, end-start)
+
+	if len(page) == 0 {
+		return page
+	}
+
+	node := l.head
+	for i := 0; i < start; i++ {
+		node = node.next
+	}
+
+	for i := range page {
+		page[i] = node.item
+		node = node.next
+	}
+
+	return page
+}
+
 // ToSlice returns a copy of the list content as a slice.
 func (l *SList[T]) ToSlice() []T {
 
@@ -431,9 +753,47 @@ func (l *SList[T]) ToSlice() []T {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	return l.toSlice(false)
 }
 
+// ToImmutableSlice returns the content of the list as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the list has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified. Values modified in place, e.g. via [collections.Element.ValuePtr],
+// are not reflected until the list is otherwise modified.
+func (l *SList[T]) ToImmutableSlice() []T {
+
+	if l.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		l.lock.RLock()
+		values, ok := l.immutable.Load(l.version)
+		l.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	return l.immutable.Get(l.version, func() []T { return l.toSlice(false) })
+}
+
 // ToSliceDeep returns the content of the collection as a slice using the provided [functions.DeepCopyFunc] if any.
 //
 // Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
@@ -444,6 +804,12 @@ func (l *SList[T]) ToSliceDeep() []T {
 		defer l.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
 	return l.toSlice(true)
 }
 
@@ -475,6 +841,10 @@ func (l *SList[T]) String() string {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	var values []string
 	for _, value := range l.toSlice(false) {
 		values = append(values, fmt.Sprintf("%v", value))
@@ -496,6 +866,7 @@ func (l *SList[T]) appendNode(newNode *SListNode[T]) {
 	}
 
 	l.count++
+	l.recorder.Add(newNode.item)
 }
 
 func (l *SList[T]) prependNode(newNode *SListNode[T]) {
@@ -510,6 +881,7 @@ func (l *SList[T]) prependNode(newNode *SListNode[T]) {
 
 	newNode.list = l
 	l.count++
+	l.recordReset()
 }
 
 func (l *SList[T]) insertNodeAfter(node, newNode *SListNode[T]) {
@@ -518,6 +890,7 @@ func (l *SList[T]) insertNodeAfter(node, newNode *SListNode[T]) {
 	node.next = newNode
 	newNode.list = l
 	l.count++
+	l.recordReset()
 }
 
 func (l *SList[T]) validateNode(node *SListNode[T]) {
@@ -591,8 +964,9 @@ func (l *SList[T]) addItemLast(value T) *SListNode[T] {
 // Make a new empty list with the same attributes as this.
 func (l *SList[T]) makeCopy() *SList[T] {
 	ll1 := &SList[T]{
-		copy:    l.copy,
-		compare: l.compare,
+		copy:       l.copy,
+		copyPolicy: l.copyPolicy,
+		compare:    l.compare,
 	}
 
 	if l.lock != nil {
@@ -1,6 +1,14 @@
 package slist
 
-import "github.com/fireflycons/generic_collections/collections"
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"golang.org/x/exp/constraints"
+)
 
 type direction bool
 
@@ -9,6 +17,7 @@ const (
 )
 
 // Sort performs an in-place sort of this collection with a time complexity of O(n*log n).
+// The sort is stable, so values which compare equal keep their relative order.
 func (l *SList[T]) Sort() {
 
 	if l.head == nil || l.count < 2 {
@@ -20,8 +29,15 @@ func (l *SList[T]) Sort() {
 		defer l.lock.Unlock()
 	}
 
-	l.mergeSort(forward)
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.mergeSort(l.compare, forward)
 	l.version++
+	l.recordReset()
 }
 
 // Sorted returns a sorted copy of this SList as a new SList using the provided [functions.DeepCopyFunc] if any.
@@ -32,6 +48,10 @@ func (l *SList[T]) Sorted() collections.Collection[T] {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	ll1 := l.makeCopy()
 
 	switch {
@@ -45,7 +65,7 @@ func (l *SList[T]) Sorted() collections.Collection[T] {
 	default:
 
 		ll1.AddRange(l.toSlice(true))
-		ll1.mergeSort(forward)
+		ll1.mergeSort(ll1.compare, forward)
 		return ll1
 	}
 }
@@ -62,8 +82,15 @@ func (l *SList[T]) SortDescending() {
 		defer l.lock.Unlock()
 	}
 
-	l.mergeSort(reverse)
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.mergeSort(l.compare, reverse)
 	l.version++
+	l.recordReset()
 }
 
 // Sorted returns a descending order sorted copy of this SList as a new SList using the provided [functions.DeepCopyFunc] if any.
@@ -74,6 +101,10 @@ func (l *SList[T]) SortedDescending() collections.Collection[T] {
 		defer l.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer l.guard.Read(l.lock != nil)()
+	}
+
 	ll1 := l.makeCopy()
 
 	switch {
@@ -87,15 +118,63 @@ func (l *SList[T]) SortedDescending() collections.Collection[T] {
 	default:
 
 		ll1.AddRange(l.toSlice(true))
-		ll1.mergeSort(reverse)
+		ll1.mergeSort(ll1.compare, reverse)
 		return ll1
 	}
 }
 
-func (l *SList[T]) mergeSort(dir direction) {
+// SortBy performs an in-place stable sort of this collection with respect to the given comparer,
+// rather than the comparer of the list, which is unchanged.
+//
+// The item that comparer orders first will be placed at the head of the list.
+//
+// Panics if comparer is nil.
+func (l *SList[T]) SortBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	if l.head == nil || l.count < 2 {
+		return
+	}
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.mergeSort(comparer, forward)
+	l.version++
+	l.recordReset()
+}
+
+// SortStable performs an in-place sort of this collection. It is equivalent to Sort,
+// which is stable, and is provided for parity with other sortable collections.
+func (l *SList[T]) SortStable() {
+
+	l.Sort()
+}
+
+// SortStableBy performs an in-place sort of this collection. It is equivalent to [SList.SortBy],
+// which is stable, and is provided for parity with other sortable collections.
+//
+// Panics if comparer is nil.
+func (l *SList[T]) SortStableBy(comparer functions.ComparerFunc[T]) {
+
+	l.SortBy(comparer)
+}
+
+func (l *SList[T]) mergeSort(compare functions.ComparerFunc[T], dir direction) {
 
 	var n *SListNode[T]
-	l.head = l.mergeSortRecursive(l.head, dir)
+	l.head = l.mergeSortRecursive(l.head, compare, dir)
 
 	for n = l.head; n.next != nil; n = n.next {
 	}
@@ -103,7 +182,7 @@ func (l *SList[T]) mergeSort(dir direction) {
 	l.tail = n
 }
 
-func (l *SList[T]) mergeSortRecursive(node *SListNode[T], dir direction) *SListNode[T] {
+func (l *SList[T]) mergeSortRecursive(node *SListNode[T], compare functions.ComparerFunc[T], dir direction) *SListNode[T] {
 	if node == nil || node.next == nil {
 		return node
 	}
@@ -111,11 +190,11 @@ func (l *SList[T]) mergeSortRecursive(node *SListNode[T], dir direction) *SListN
 	second := split(node)
 
 	// Recur for left and right halves
-	node = l.mergeSortRecursive(node, dir)
-	second = l.mergeSortRecursive(second, dir)
+	node = l.mergeSortRecursive(node, compare, dir)
+	second = l.mergeSortRecursive(second, compare, dir)
 
 	// Merge the two sorted halves
-	return l.merge(node, second, dir)
+	return l.merge(node, second, compare, dir)
 }
 
 func split[T any](head *SListNode[T]) *SListNode[T] {
@@ -132,7 +211,7 @@ func split[T any](head *SListNode[T]) *SListNode[T] {
 	return temp
 }
 
-func (l *SList[T]) merge(first, second *SListNode[T], dir direction) *SListNode[T] {
+func (l *SList[T]) merge(first, second *SListNode[T], compare functions.ComparerFunc[T], dir direction) *SListNode[T] {
 	// If first linked list is empty
 	if first == nil {
 		return second
@@ -146,18 +225,71 @@ func (l *SList[T]) merge(first, second *SListNode[T], dir direction) *SListNode[
 	var x bool
 
 	if dir == forward {
-		// Pick the smaller value
-		x = l.compare(first.item, second.item) < 0
+		// Pick the smaller value, or the first of equal values so that the sort is stable
+		x = compare(first.item, second.item) <= 0
 	} else {
-		// Pick the larger value
-		x = l.compare(first.item, second.item) > 0
+		// Pick the larger value, or the first of equal values so that the sort is stable
+		x = compare(first.item, second.item) >= 0
 	}
 
 	if x {
-		first.next = l.merge(first.next, second, dir)
+		first.next = l.merge(first.next, second, compare, dir)
 		return first
 	}
 
-	second.next = l.merge(first, second.next, dir)
+	second.next = l.merge(first, second.next, compare, dir)
 	return second
 }
+
+// SortByKey performs an in-place sort of the list by the keys computed for its values by key.
+// The value with the smallest key will be placed at the head of the list.
+//
+// Each key is computed once before sorting, so where comparing values is expensive, e.g. comparing strings
+// with collation, sorting by a cheaply compared key derived from each value is much faster than Sort.
+func SortByKey[T any, K constraints.Ordered](l *SList[T], key func(T) K) {
+
+	sortByKey(l, key, false)
+}
+
+// SortByKeyDescending is as [SortByKey], placing the value with the largest key at the head of the list.
+func SortByKeyDescending[T any, K constraints.Ordered](l *SList[T], key func(T) K) {
+
+	sortByKey(l, key, true)
+}
+
+func sortByKey[T any, K constraints.Ordered](l *SList[T], key func(T) K, descending bool) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if l.count < 2 {
+		return
+	}
+
+	// Sort the nodes rather than the values, so that references to nodes remain valid
+	nodes := make([]*SListNode[T], 0, l.count)
+
+	for n := l.head; n != nil; n = n.next {
+		nodes = append(nodes, n)
+	}
+
+	util.SortByKey(nodes, func(n *SListNode[T]) K { return key(n.item) }, util.GetOrderedComparer[K](), descending)
+
+	for i := 1; i < len(nodes); i++ {
+		nodes[i-1].next = nodes[i]
+	}
+
+	l.head = nodes[0]
+	l.tail = nodes[len(nodes)-1]
+	l.tail.next = nil
+	l.version++
+	l.recordReset()
+}
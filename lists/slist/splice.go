@@ -0,0 +1,128 @@
+package slist
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// AddSliceAfter inserts the given values after the given node, in slice order.
+//
+// The values are linked together before being spliced into the list in a single
+// operation, so the version is incremented once regardless of the number of values.
+// Returns the last node inserted, or node if values is empty, so that further values
+// may be added after them.
+//
+// Panics if the node argument is nil or belongs to another list.
+func (l *SList[T]) AddSliceAfter(node *SListNode[T], values []T) *SListNode[T] {
+
+	first, last, count := l.chainSlice(values)
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.validateNode(node)
+	return l.spliceAfter(node, first, last, count)
+}
+
+// AddIteratorAfter inserts the values yielded by the given iterator after the given node,
+// in iteration order, as per [SList.AddSliceAfter].
+//
+// The iterator is consumed before the list is modified, so it may iterate this list.
+//
+// Panics if iter is nil, or the node argument is nil or belongs to another list.
+func (l *SList[T]) AddIteratorAfter(node *SListNode[T], iter collections.Iterator[T]) *SListNode[T] {
+
+	if iter == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "iter"))
+	}
+
+	var first, last *SListNode[T]
+	count := 0
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		last = l.chainNode(last, e.Value())
+		if first == nil {
+			first = last
+		}
+		count++
+	}
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	l.validateNode(node)
+	return l.spliceAfter(node, first, last, count)
+}
+
+func (l *SList[T]) chainSlice(values []T) (first, last *SListNode[T], count int) {
+	for _, v := range values {
+		last = l.chainNode(last, v)
+		if first == nil {
+			first = last
+		}
+	}
+
+	return first, last, len(values)
+}
+
+// chainNode creates a node for value linked after last, which may be nil.
+func (l *SList[T]) chainNode(last *SListNode[T], value T) *SListNode[T] {
+	n := &SListNode[T]{
+		list: l,
+		item: value,
+	}
+
+	if last != nil {
+		last.next = n
+	}
+
+	return n
+}
+
+// spliceAfter links the chain of count nodes from first to last into the list after node.
+func (l *SList[T]) spliceAfter(node, first, last *SListNode[T], count int) *SListNode[T] {
+
+	if count == 0 {
+		return node
+	}
+
+	next := node.next
+	node.next = first
+	last.next = next
+
+	if next == nil {
+		l.tail = last
+	}
+
+	l.count += count
+	l.version++
+
+	if next == nil {
+		for n := first; n != nil; n = n.next {
+			l.recorder.Add(n.item)
+		}
+	} else {
+		l.recordReset()
+	}
+
+	return last
+}
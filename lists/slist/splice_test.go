@@ -0,0 +1,89 @@
+package slist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSliceAfter(t *testing.T) {
+
+	t.Run("Node is the head", func(t *testing.T) {
+		linkedList := Of(1, 5)
+		version := linkedList.version
+		last := linkedList.AddSliceAfter(linkedList.First(), []int{2, 3, 4})
+
+		require.Equal(t, 4, last.Value())
+		require.Equal(t, version+1, linkedList.version)
+		initialItems_Tests(t, linkedList, []int{1, 2, 3, 4, 5})
+	})
+
+	t.Run("Node is the tail", func(t *testing.T) {
+		linkedList := Of(1)
+		last := linkedList.AddSliceAfter(linkedList.Last(), []int{2, 3})
+
+		require.Same(t, linkedList.Last(), last)
+		initialItems_Tests(t, linkedList, []int{1, 2, 3})
+		linkedList.AddItemLast(4)
+		initialItems_Tests(t, linkedList, []int{1, 2, 3, 4})
+	})
+
+	t.Run("Chained insertion", func(t *testing.T) {
+		linkedList := Of(1, 6)
+		node := linkedList.AddSliceAfter(linkedList.First(), []int{2, 3})
+		linkedList.AddSliceAfter(node, []int{4, 5})
+		initialItems_Tests(t, linkedList, []int{1, 2, 3, 4, 5, 6})
+	})
+
+	t.Run("Empty slice", func(t *testing.T) {
+		linkedList := Of(1)
+		version := linkedList.version
+		require.Same(t, linkedList.First(), linkedList.AddSliceAfter(linkedList.First(), nil))
+		require.Equal(t, version, linkedList.version)
+	})
+
+	t.Run("Insertion is recorded", func(t *testing.T) {
+		linkedList := Of(1, 4)
+		linkedList.StartRecording()
+		linkedList.AddSliceAfter(linkedList.Last(), []int{5, 6})
+		linkedList.AddSliceAfter(linkedList.First(), []int{2, 3})
+		recorded := linkedList.StopRecording()
+
+		require.Equal(t, []ops.Op[int]{ops.Add(5), ops.Add(6)}, recorded[:2])
+		replica := Of(1, 4)
+		replica.ApplyOps(recorded)
+		require.Equal(t, linkedList.ToSlice(), replica.ToSlice())
+	})
+
+	t.Run("Foreign node panics", func(t *testing.T) {
+		linkedList := Of(1)
+		require.PanicsWithValue(t, messages.FOREIGN_NODE, func() { linkedList.AddSliceAfter(Of(2).First(), []int{3}) })
+		require.PanicsWithValue(t, messages.NIL_NODE, func() { linkedList.AddSliceAfter(nil, []int{3}) })
+		require.Equal(t, 1, linkedList.Count())
+	})
+}
+
+func TestAddIteratorAfter(t *testing.T) {
+
+	t.Run("Values of another collection", func(t *testing.T) {
+		linkedList := Of(1, 5)
+		last := linkedList.AddIteratorAfter(linkedList.First(), Of(2, 3, 4).Iterator())
+
+		require.Equal(t, 4, last.Value())
+		initialItems_Tests(t, linkedList, []int{1, 2, 3, 4, 5})
+	})
+
+	t.Run("Values of this list", func(t *testing.T) {
+		linkedList := Of(1, 2)
+		linkedList.AddIteratorAfter(linkedList.First(), linkedList.Iterator())
+		initialItems_Tests(t, linkedList, []int{1, 1, 2, 2})
+	})
+
+	t.Run("Nil iterator panics", func(t *testing.T) {
+		linkedList := Of(1)
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "iter"), func() { linkedList.AddIteratorAfter(linkedList.First(), nil) })
+	})
+}
@@ -0,0 +1,39 @@
+package slist
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*SList[int])(nil)
+
+// TryAdd adds a value to the end of the list.
+//
+// Returns true and nil unless the value was rejected by the validator, in which case false and an error
+// wrapping [collections.ErrInvalidValue] and the error of the validator, whatever the validation policy.
+func (l *SList[T]) TryAdd(value T) (bool, error) {
+
+	if l.lock != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer l.guard.Write(l.lock != nil, l.verifyInvariants)()
+	}
+
+	l.lazyInit()
+
+	if err := l.validator.Check(value); err != nil {
+		return false, err
+	}
+
+	l.appendNode(&SListNode[T]{
+		list: l,
+		item: value,
+	})
+
+	l.version++
+	return true, nil
+}
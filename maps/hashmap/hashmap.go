@@ -0,0 +1,433 @@
+/*
+Package hashmap provides an unordered map whose keys are located by hashing.
+
+The entries are held in a [hashset.HashSet] hashed and compared by key, so Put, Get and Remove
+are O(1) on average. Unlike the built-in map, keys may be of any type, including types that are
+not comparable such as slices and structs containing them, given a hash function and comparer
+for the key type via WithHasher and WithComparer.
+*/
+package hashmap
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/maps"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+)
+
+// Assert HashMap implements required interfaces.
+var _ maps.Map[int, int] = (*HashMap[int, int])(nil)
+
+// HashMapOptionFunc is the signature of a function
+// for providing options to the HashMap constructor.
+type HashMapOptionFunc[K any, V any] func(*HashMap[K, V])
+
+// HashMap stores key/value pairs with unique keys in no defined order.
+type HashMap[K any, V any] struct {
+	lock    *sync.RWMutex
+	hasher  functions.HashFunc[K]
+	compare functions.ComparerFunc[K]
+	copy    functions.DeepCopyFunc[V]
+	entries *hashset.HashSet[maps.Entry[K, V]]
+
+	local.InternalImpl
+}
+
+// New constructs a new, empty HashMap.
+//
+// Panics if no hasher is given and there is no default hash function for the key type.
+func New[K any, V any](options ...HashMapOptionFunc[K, V]) *HashMap[K, V] {
+	m := &HashMap[K, V]{}
+
+	for _, o := range options {
+		o(m)
+	}
+
+	if m.hasher == nil {
+		m.hasher = hashset.DefaultHasher[K]()
+	}
+
+	if m.compare == nil {
+		m.compare = util.GetDefaultComparer[K]()
+	}
+
+	if m.copy == nil {
+		m.copy = util.DefaultDeepCopy[V]
+	}
+
+	m.entries = m.newEntrySet()
+	return m
+}
+
+// NewFunc is as [New], using hasher and compare to locate the keys.
+func NewFunc[K any, V any](hasher functions.HashFunc[K], compare functions.ComparerFunc[K], options ...HashMapOptionFunc[K, V]) *HashMap[K, V] {
+	return New(append([]HashMapOptionFunc[K, V]{WithHasher[K, V](hasher), WithComparer[K, V](compare)}, options...)...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[K any, V any]() HashMapOptionFunc[K, V] {
+	return func(m *HashMap[K, V]) {
+		m.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function for New to provide a hash function for keys of type K.
+// Required if the key type is not numeric, bool, pointer, string or time.Time.
+//
+// Keys that are equal according to the comparer must have the same hash.
+func WithHasher[K any, V any](hasher functions.HashFunc[K]) HashMapOptionFunc[K, V] {
+	if hasher == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "hasher"))
+	}
+	return func(m *HashMap[K, V]) {
+		m.hasher = hasher
+	}
+}
+
+// Option function for New to provide a comparer function for keys of type K.
+// Required if the key type is not numeric, bool, pointer or string.
+func WithComparer[K any, V any](comparer functions.ComparerFunc[K]) HashMapOptionFunc[K, V] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(m *HashMap[K, V]) {
+		m.compare = comparer
+	}
+}
+
+// Option func to provide a deep copy implementation for values,
+// used by ToSliceDeep and SelectDeep. Keys are copied by value.
+func WithDeepCopy[K any, V any](copier functions.DeepCopyFunc[V]) HashMapOptionFunc[K, V] {
+	// Can be nil
+	return func(m *HashMap[K, V]) {
+		m.copy = copier
+	}
+}
+
+// Put associates the value with the key, replacing any value already associated with it. O(1).
+//
+// Returns true if the key was added; false if its value was replaced.
+func (m *HashMap[K, V]) Put(key K, value V) bool {
+
+	if m.lock != nil {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+	}
+
+	entry := maps.Entry[K, V]{Key: key, Value: value}
+
+	if m.entries.Add(entry) {
+		return true
+	}
+
+	// Values in a set cannot be modified in place, so the entry is replaced.
+	m.entries.Remove(entry)
+	m.entries.Add(entry)
+	return false
+}
+
+// Get returns the value associated with the key and true if the key is present;
+// else zero value of V and false. O(1).
+func (m *HashMap[K, V]) Get(key K) (V, bool) {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	if e := m.entries.Get(maps.Entry[K, V]{Key: key}); e != nil {
+		return e.Value().Value, true
+	}
+
+	var empty V
+	return empty, false
+}
+
+// Remove removes the key and its value. O(1).
+//
+// Returns true if the key was present and was removed; else false.
+func (m *HashMap[K, V]) Remove(key K) bool {
+
+	if m.lock != nil {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+	}
+
+	return m.entries.Remove(maps.Entry[K, V]{Key: key})
+}
+
+// ContainsKey returns true if the key is present. O(1).
+func (m *HashMap[K, V]) ContainsKey(key K) bool {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	return m.entries.Contains(maps.Entry[K, V]{Key: key})
+}
+
+// Count returns the number of keys in the map.
+func (m *HashMap[K, V]) Count() int {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	return m.entries.Count()
+}
+
+// IsEmpty returns true if the map has no keys.
+func (m *HashMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// Clear removes all keys from the map.
+func (m *HashMap[K, V]) Clear() {
+
+	if m.lock != nil {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+	}
+
+	m.entries.Clear()
+}
+
+// Keys returns the keys of the map in no defined order.
+func (m *HashMap[K, V]) Keys() []K {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	keys := make([]K, 0, m.entries.Count())
+
+	for _, e := range m.entries.ToSlice() {
+		keys = append(keys, e.Key)
+	}
+
+	return keys
+}
+
+// Values returns the values of the map in no defined order,
+// which may differ from that of a previous call to Keys.
+func (m *HashMap[K, V]) Values() []V {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	values := make([]V, 0, m.entries.Count())
+
+	for _, e := range m.entries.ToSlice() {
+		values = append(values, e.Value)
+	}
+
+	return values
+}
+
+// Entries returns the key/value pairs of the map in no defined order.
+func (m *HashMap[K, V]) Entries() []maps.Entry[K, V] {
+	return m.ToSlice()
+}
+
+// ToSlice returns the key/value pairs of the map as a slice, in no defined order.
+func (m *HashMap[K, V]) ToSlice() []maps.Entry[K, V] {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	return m.entries.ToSlice()
+}
+
+// ToSliceDeep returns the key/value pairs of the map as a slice, in no defined order.
+//
+// If a DeepCopyFunc[V] was provided to the constructor it will be used to copy the values,
+// else a by-value copy is made, i.e. works the same as ToSlice.
+func (m *HashMap[K, V]) ToSliceDeep() []maps.Entry[K, V] {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	return m.entries.ToSliceDeep()
+}
+
+// ForEach calls f for each key/value pair in no defined order.
+func (m *HashMap[K, V]) ForEach(f func(K, V)) {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	for _, e := range m.entries.ToSlice() {
+		f(e.Key, e.Value)
+	}
+}
+
+// Iterator returns an iterator that walks the entries of the map in no defined order.
+//
+//	iter := m.Iterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value().Key and e.Value().Value
+//	}
+//
+// Entries may not be modified through the iterator.
+func (m *HashMap[K, V]) Iterator() collections.Iterator[maps.Entry[K, V]] {
+
+	return m.entries.Iterator()
+}
+
+// TakeWhile returns an iterator that walks the entries of the map in no defined order,
+// returning only those for which predicate returns true.
+func (m *HashMap[K, V]) TakeWhile(predicate func(K, V) bool) collections.Iterator[maps.Entry[K, V]] {
+
+	return m.entries.TakeWhile(func(e maps.Entry[K, V]) bool {
+		return predicate(e.Key, e.Value)
+	})
+}
+
+// Select returns a new map with the same options containing only the key/value pairs
+// for which predicate is true.
+func (m *HashMap[K, V]) Select(predicate func(K, V) bool) *HashMap[K, V] {
+	return m.doSelect(predicate, false)
+}
+
+// SelectDeep returns a new map with the same options containing only the key/value pairs
+// for which predicate is true.
+//
+// If a DeepCopyFunc[V] was provided to the constructor it will be used to copy the values,
+// else a by-value copy is made, i.e. works the same as Select.
+func (m *HashMap[K, V]) SelectDeep(predicate func(K, V) bool) *HashMap[K, V] {
+	return m.doSelect(predicate, true)
+}
+
+// KeySet returns the keys of the map as a new HashSet hashed and compared as this map's keys.
+func (m *HashMap[K, V]) KeySet() *hashset.HashSet[K] {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	keys := hashset.New(hashset.WithHasher(m.hasher), hashset.WithComparer(m.compare), hashset.WithCapacity[K](m.entries.Count()))
+
+	for _, e := range m.entries.ToSlice() {
+		keys.Add(e.Key)
+	}
+
+	return keys
+}
+
+// Union returns a new map with the same options as this map containing the key/value pairs of both maps.
+// Where a key is present in both, the value of this map is taken.
+func (m *HashMap[K, V]) Union(other *HashMap[K, V]) *HashMap[K, V] {
+	union := m.Select(func(K, V) bool { return true })
+
+	for _, e := range other.Entries() {
+		union.entries.Add(e)
+	}
+
+	return union
+}
+
+// Intersection returns a new map with the same options as this map containing
+// the key/value pairs of this map whose keys are also present in the other map.
+func (m *HashMap[K, V]) Intersection(other *HashMap[K, V]) *HashMap[K, V] {
+	keys := m.otherKeys(other)
+	return m.Select(func(k K, _ V) bool { return keys.Contains(maps.Entry[K, V]{Key: k}) })
+}
+
+// Difference returns a new map with the same options as this map containing
+// the key/value pairs of this map whose keys are not present in the other map.
+func (m *HashMap[K, V]) Difference(other *HashMap[K, V]) *HashMap[K, V] {
+	keys := m.otherKeys(other)
+	return m.Select(func(k K, _ V) bool { return !keys.Contains(maps.Entry[K, V]{Key: k}) })
+}
+
+// String returns a string representation of container.
+func (m *HashMap[K, V]) String() string {
+
+	var entries []string
+
+	for _, e := range m.Entries() {
+		entries = append(entries, fmt.Sprintf("%v: %v", e.Key, e.Value))
+	}
+
+	return "HashMap\n" + strings.Join(entries, ", ")
+}
+
+func (m *HashMap[K, V]) doSelect(predicate func(K, V) bool, deepCopy bool) *HashMap[K, V] {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	result := m.makeEmptyCopy()
+	entryPredicate := func(e maps.Entry[K, V]) bool { return predicate(e.Key, e.Value) }
+	var selected []maps.Entry[K, V]
+
+	if deepCopy {
+		selected = m.entries.SelectDeep(entryPredicate).ToSlice()
+	} else {
+		selected = m.entries.Select(entryPredicate).ToSlice()
+	}
+
+	result.entries.AddRange(selected)
+	return result
+}
+
+// otherKeys returns the entries of the other map in a set hashed and compared by this map,
+// taken before this map is locked so that the locks of the two maps are never held together.
+func (m *HashMap[K, V]) otherKeys(other *HashMap[K, V]) *hashset.HashSet[maps.Entry[K, V]] {
+	keys := m.newEntrySet()
+	keys.AddRange(other.Entries())
+	return keys
+}
+
+func (m *HashMap[K, V]) makeEmptyCopy() *HashMap[K, V] {
+	result := &HashMap[K, V]{
+		hasher:  m.hasher,
+		compare: m.compare,
+		copy:    m.copy,
+	}
+
+	if m.lock != nil {
+		result.lock = &sync.RWMutex{}
+	}
+
+	result.entries = result.newEntrySet()
+	return result
+}
+
+func (m *HashMap[K, V]) newEntrySet() *hashset.HashSet[maps.Entry[K, V]] {
+	hasher, compare, copy := m.hasher, m.compare, m.copy
+
+	return hashset.New(
+		hashset.WithHasher(func(e maps.Entry[K, V]) uintptr {
+			return hasher(e.Key)
+		}),
+		hashset.WithComparer(func(a, b maps.Entry[K, V]) int {
+			return compare(a.Key, b.Key)
+		}),
+		hashset.WithDeepCopy(func(e maps.Entry[K, V]) maps.Entry[K, V] {
+			return maps.Entry[K, V]{Key: e.Key, Value: copy(e.Value)}
+		}),
+	)
+}
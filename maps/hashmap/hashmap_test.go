@@ -0,0 +1,153 @@
+package hashmap
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/maps"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/stretchr/testify/require"
+)
+
+func sortedKeys[V any](m *HashMap[int, V]) []int {
+	keys := m.Keys()
+	sort.Ints(keys)
+	return keys
+}
+
+func TestPutGetRemove(t *testing.T) {
+	m := New[string, int]()
+
+	require.True(t, m.Put("b", 2))
+	require.True(t, m.Put("a", 1))
+	require.True(t, m.Put("c", 3))
+	require.False(t, m.Put("b", 20))
+	require.Equal(t, 3, m.Count())
+
+	v, ok := m.Get("b")
+	require.True(t, ok)
+	require.Equal(t, 20, v)
+
+	_, ok = m.Get("d")
+	require.False(t, ok)
+
+	require.True(t, m.ContainsKey("a"))
+	require.True(t, m.Remove("a"))
+	require.False(t, m.Remove("a"))
+	require.False(t, m.ContainsKey("a"))
+	require.Equal(t, 2, m.Count())
+
+	m.Clear()
+	require.True(t, m.IsEmpty())
+}
+
+func TestNonComparableKeys(t *testing.T) {
+	m := NewFunc[[]int, string](
+		functions.HashSlice(func(v int) uintptr { return hashset.HashQword(uint64(v)) }),
+		functions.CompareSlices(func(a, b int) int { return a - b }),
+	)
+
+	m.Put([]int{1, 2}, "one-two")
+	m.Put([]int{2, 1}, "two-one")
+
+	v, ok := m.Get([]int{1, 2})
+	require.True(t, ok)
+	require.Equal(t, "one-two", v)
+	require.Equal(t, 2, m.Count())
+}
+
+func TestKeysValuesEntries(t *testing.T) {
+	m := New[int, int]()
+
+	for i := 0; i < 10; i++ {
+		m.Put(i, i*10)
+	}
+
+	keys, values := m.Keys(), m.Values()
+	sort.Ints(keys)
+	sort.Ints(values)
+
+	for i := range keys {
+		require.Equal(t, keys[i]*10, values[i])
+	}
+
+	for _, e := range m.Entries() {
+		require.Equal(t, maps.Entry[int, int]{Key: e.Key, Value: e.Key * 10}, e)
+	}
+
+	count := 0
+	iter := m.TakeWhile(func(k, _ int) bool { return k%2 == 0 })
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		require.Zero(t, e.Value().Key%2)
+		count++
+	}
+
+	require.Equal(t, 5, count)
+}
+
+func TestDeepCopy(t *testing.T) {
+	m := New(WithDeepCopy[string](func(v *int) *int {
+		c := *v
+		return &c
+	}))
+
+	one := 1
+	m.Put("one", &one)
+
+	deep := m.ToSliceDeep()
+	require.NotSame(t, &one, deep[0].Value)
+	require.Equal(t, 1, *deep[0].Value)
+	require.Same(t, &one, m.ToSlice()[0].Value)
+
+	selected := m.SelectDeep(func(string, *int) bool { return true })
+	v, _ := selected.Get("one")
+	require.NotSame(t, &one, v)
+}
+
+func TestSetOperations(t *testing.T) {
+	a := New[int, string]()
+	b := New[int, string]()
+
+	for _, k := range []int{1, 2, 3} {
+		a.Put(k, "a")
+	}
+
+	for _, k := range []int{3, 4} {
+		b.Put(k, "b")
+	}
+
+	union := a.Union(b)
+	require.Equal(t, []int{1, 2, 3, 4}, sortedKeys(union))
+	v, _ := union.Get(3)
+	require.Equal(t, "a", v)
+
+	require.Equal(t, []int{3}, sortedKeys(a.Intersection(b)))
+	require.Equal(t, []int{1, 2}, sortedKeys(a.Difference(b)))
+	require.Equal(t, []int{1, 2}, sortedKeys(a.Select(func(k int, _ string) bool { return k < 3 })))
+
+	keys := a.KeySet()
+	require.Equal(t, 3, keys.Count())
+	require.True(t, keys.Contains(2))
+}
+
+func TestThreadSafe(t *testing.T) {
+	m := New(WithThreadSafe[int, int]())
+	var wg sync.WaitGroup
+
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Put(g*100+i, i)
+				m.Get(i)
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	require.Equal(t, 400, m.Count())
+}
@@ -0,0 +1,60 @@
+/*
+Package maps defines the interface for map collections. Maps associate each of a set of unique keys with a value.
+Sub-packages contain implementations.
+*/
+package maps
+
+import (
+	"github.com/fireflycons/generic_collections/internal/local"
+)
+
+// Entry is a key/value pair held in a map.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// Map is the abstract interface for collections of key/value pairs with unique keys.
+//
+// Implemented by OrderedMap[K, V], HashMap[K, V].
+type Map[K any, V any] interface {
+	// Put associates the value with the key, replacing any value already associated with it.
+	//
+	// Returns true if the key was added; false if its value was replaced.
+	Put(key K, value V) bool
+
+	// Get returns the value associated with the key and true if the key is present;
+	// else zero value of V and false.
+	Get(key K) (V, bool)
+
+	// Remove removes the key and its value.
+	//
+	// Returns true if the key was present and was removed; else false.
+	Remove(key K) bool
+
+	// ContainsKey returns true if the key is present.
+	ContainsKey(key K) bool
+
+	// Count returns the number of keys in the map.
+	Count() int
+
+	// IsEmpty returns true if the map has no keys.
+	IsEmpty() bool
+
+	// Clear removes all keys from the map.
+	Clear()
+
+	// Keys returns the keys of the map.
+	Keys() []K
+
+	// Values returns the values of the map, in the same order as Keys
+	// if the map defines the order of its keys.
+	Values() []V
+
+	// Entries returns the key/value pairs of the map, in the same order as Keys
+	// if the map defines the order of its keys.
+	Entries() []Entry[K, V]
+
+	// Prevent external implementations of this interface
+	local.InternalInter
+}
@@ -0,0 +1,313 @@
+/*
+Package orderedmap provides a map whose keys are kept in order, also known as a sorted dictionary.
+
+The entries are held in the red-black tree of an [orderedset.OrderedSet] ordered by key, so Put, Get
+and Remove are O(log n), iteration is in ascending order of key, and the nearest keys to any key
+may be found with Floor and Ceiling.
+*/
+package orderedmap
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/maps"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"golang.org/x/exp/constraints"
+)
+
+// Assert OrderedMap implements required interfaces.
+var _ maps.Map[int, int] = (*OrderedMap[int, int])(nil)
+
+// OrderedMapOptionFunc is the signature of a function
+// for providing options to the OrderedMap constructor.
+type OrderedMapOptionFunc[K any, V any] func(*OrderedMap[K, V])
+
+// OrderedMap stores key/value pairs with unique keys in ascending order of key.
+type OrderedMap[K any, V any] struct {
+	lock    *sync.RWMutex
+	compare functions.ComparerFunc[K]
+	entries *orderedset.OrderedSet[maps.Entry[K, V]]
+
+	local.InternalImpl
+}
+
+// New constructs a new, empty OrderedMap.
+func New[K any, V any](options ...OrderedMapOptionFunc[K, V]) *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{}
+
+	for _, o := range options {
+		o(m)
+	}
+
+	if m.compare == nil {
+		m.compare = util.GetDefaultComparer[K]()
+	}
+
+	compare := m.compare
+	m.entries = orderedset.New(orderedset.WithComparer(func(a, b maps.Entry[K, V]) int {
+		return compare(a.Key, b.Key)
+	}))
+
+	return m
+}
+
+// NewFunc is as [New], using compare to order the keys.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[K any, V any](compare functions.ComparerFunc[K], options ...OrderedMapOptionFunc[K, V]) *OrderedMap[K, V] {
+	return New(append([]OrderedMapOptionFunc[K, V]{WithComparer[K, V](compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered key types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[K constraints.Ordered, V any](options ...OrderedMapOptionFunc[K, V]) *OrderedMap[K, V] {
+	return NewFunc(util.GetOrderedComparer[K](), options...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[K any, V any]() OrderedMapOptionFunc[K, V] {
+	return func(m *OrderedMap[K, V]) {
+		m.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function for New to provide a comparer function for keys of type K.
+// Required if the key type is not numeric, bool, pointer or string.
+func WithComparer[K any, V any](comparer functions.ComparerFunc[K]) OrderedMapOptionFunc[K, V] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(m *OrderedMap[K, V]) {
+		m.compare = comparer
+	}
+}
+
+// Put associates the value with the key, replacing any value already associated with it. O(log n).
+//
+// Returns true if the key was added; false if its value was replaced.
+func (m *OrderedMap[K, V]) Put(key K, value V) bool {
+
+	if m.lock != nil {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+	}
+
+	entry := maps.Entry[K, V]{Key: key, Value: value}
+
+	if m.entries.Add(entry) {
+		return true
+	}
+
+	// Keys are immutable within the tree, so the entry is replaced.
+	m.entries.Remove(entry)
+	m.entries.Add(entry)
+	return false
+}
+
+// Get returns the value associated with the key and true if the key is present;
+// else zero value of V and false. O(log n).
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	if e := m.entries.Get(maps.Entry[K, V]{Key: key}); e != nil {
+		return e.Value().Value, true
+	}
+
+	var empty V
+	return empty, false
+}
+
+// Remove removes the key and its value. O(log n).
+//
+// Returns true if the key was present and was removed; else false.
+func (m *OrderedMap[K, V]) Remove(key K) bool {
+
+	if m.lock != nil {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+	}
+
+	return m.entries.Remove(maps.Entry[K, V]{Key: key})
+}
+
+// ContainsKey returns true if the key is present. O(log n).
+func (m *OrderedMap[K, V]) ContainsKey(key K) bool {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	return m.entries.Contains(maps.Entry[K, V]{Key: key})
+}
+
+// Count returns the number of keys in the map.
+func (m *OrderedMap[K, V]) Count() int {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	return m.entries.Count()
+}
+
+// IsEmpty returns true if the map has no keys.
+func (m *OrderedMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// Clear removes all keys from the map.
+func (m *OrderedMap[K, V]) Clear() {
+
+	if m.lock != nil {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+	}
+
+	m.entries.Clear()
+}
+
+// Keys returns the keys of the map in ascending order.
+func (m *OrderedMap[K, V]) Keys() []K {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	keys := make([]K, 0, m.entries.Count())
+	m.entries.TreeWalk(func(e maps.Entry[K, V]) bool {
+		keys = append(keys, e.Key)
+		return true
+	})
+
+	return keys
+}
+
+// Values returns the values of the map in ascending order of key.
+func (m *OrderedMap[K, V]) Values() []V {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	values := make([]V, 0, m.entries.Count())
+	m.entries.TreeWalk(func(e maps.Entry[K, V]) bool {
+		values = append(values, e.Value)
+		return true
+	})
+
+	return values
+}
+
+// Entries returns the key/value pairs of the map in ascending order of key.
+func (m *OrderedMap[K, V]) Entries() []maps.Entry[K, V] {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	return m.entries.ToSlice()
+}
+
+// Floor returns the greatest key less than or equal to the given key, with its value and true;
+// else zero values and false if there is no such key. O(log n).
+func (m *OrderedMap[K, V]) Floor(key K) (K, V, bool) {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	iter := m.entries.BidirectionalIterator()
+	e := iter.Seek(maps.Entry[K, V]{Key: key})
+
+	if e == nil || m.compare(e.Value().Key, key) != 0 {
+		e = iter.Prev()
+	}
+
+	return unpack(e)
+}
+
+// Ceiling returns the least key greater than or equal to the given key, with its value and true;
+// else zero values and false if there is no such key. O(log n).
+func (m *OrderedMap[K, V]) Ceiling(key K) (K, V, bool) {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	return unpack(m.entries.BidirectionalIterator().Seek(maps.Entry[K, V]{Key: key}))
+}
+
+// ForEach calls f for each key/value pair in ascending order of key.
+func (m *OrderedMap[K, V]) ForEach(f func(K, V)) {
+
+	if m.lock != nil {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+	}
+
+	m.entries.TreeWalk(func(e maps.Entry[K, V]) bool {
+		f(e.Key, e.Value)
+		return true
+	})
+}
+
+// Iterator returns an iterator that walks the entries of the map in ascending order of key.
+//
+//	iter := m.Iterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value().Key and e.Value().Value
+//	}
+//
+// Entries may not be modified through the iterator.
+func (m *OrderedMap[K, V]) Iterator() collections.Iterator[maps.Entry[K, V]] {
+
+	return m.entries.Iterator()
+}
+
+// ReverseIterator returns an iterator that walks the entries of the map in descending order of key.
+func (m *OrderedMap[K, V]) ReverseIterator() collections.Iterator[maps.Entry[K, V]] {
+
+	return m.entries.ReverseIterator()
+}
+
+// String returns a string representation of container.
+func (m *OrderedMap[K, V]) String() string {
+
+	var entries []string
+
+	for _, e := range m.Entries() {
+		entries = append(entries, fmt.Sprintf("%v: %v", e.Key, e.Value))
+	}
+
+	return "OrderedMap\n" + strings.Join(entries, ", ")
+}
+
+func unpack[K any, V any](e collections.Element[maps.Entry[K, V]]) (K, V, bool) {
+	if e == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+
+	entry := e.Value()
+	return entry.Key, entry.Value, true
+}
@@ -0,0 +1,135 @@
+package orderedmap
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutGetRemove(t *testing.T) {
+	m := New[string, int]()
+
+	require.True(t, m.Put("b", 2))
+	require.True(t, m.Put("a", 1))
+	require.True(t, m.Put("c", 3))
+	require.False(t, m.Put("b", 20))
+	require.Equal(t, 3, m.Count())
+
+	v, ok := m.Get("b")
+	require.True(t, ok)
+	require.Equal(t, 20, v)
+
+	_, ok = m.Get("d")
+	require.False(t, ok)
+
+	require.True(t, m.ContainsKey("a"))
+	require.True(t, m.Remove("a"))
+	require.False(t, m.Remove("a"))
+	require.False(t, m.ContainsKey("a"))
+	require.Equal(t, 2, m.Count())
+
+	m.Clear()
+	require.True(t, m.IsEmpty())
+}
+
+func TestOrder(t *testing.T) {
+	m := NewOrdered[int, string]()
+
+	for _, k := range []int{5, 1, 4, 2, 3} {
+		m.Put(k, strings.Repeat("x", k))
+	}
+
+	require.Equal(t, []int{1, 2, 3, 4, 5}, m.Keys())
+	require.Equal(t, []string{"x", "xx", "xxx", "xxxx", "xxxxx"}, m.Values())
+	require.Equal(t, maps.Entry[int, string]{Key: 1, Value: "x"}, m.Entries()[0])
+
+	keys := []int{}
+	iter := m.Iterator()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		keys = append(keys, e.Value().Key)
+	}
+
+	require.Equal(t, []int{1, 2, 3, 4, 5}, keys)
+
+	keys = keys[:0]
+	iter = m.ReverseIterator()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		keys = append(keys, e.Value().Key)
+	}
+
+	require.Equal(t, []int{5, 4, 3, 2, 1}, keys)
+
+	keys = keys[:0]
+	m.ForEach(func(k int, _ string) { keys = append(keys, k) })
+	require.Equal(t, []int{1, 2, 3, 4, 5}, keys)
+}
+
+func TestFloorCeiling(t *testing.T) {
+	m := NewOrdered[int, int]()
+
+	for _, k := range []int{10, 20, 30} {
+		m.Put(k, k*10)
+	}
+
+	tests := []struct {
+		key                  int
+		floor, ceiling       int
+		hasFloor, hasCeiling bool
+	}{
+		{5, 0, 10, false, true},
+		{10, 10, 10, true, true},
+		{15, 10, 20, true, true},
+		{30, 30, 30, true, true},
+		{35, 30, 0, true, false},
+	}
+
+	for _, tt := range tests {
+		k, v, ok := m.Floor(tt.key)
+		require.Equal(t, tt.hasFloor, ok, "Floor(%d)", tt.key)
+		require.Equal(t, tt.floor, k, "Floor(%d)", tt.key)
+		require.Equal(t, tt.floor*10, v, "Floor(%d)", tt.key)
+
+		k, v, ok = m.Ceiling(tt.key)
+		require.Equal(t, tt.hasCeiling, ok, "Ceiling(%d)", tt.key)
+		require.Equal(t, tt.ceiling, k, "Ceiling(%d)", tt.key)
+		require.Equal(t, tt.ceiling*10, v, "Ceiling(%d)", tt.key)
+	}
+
+	_, _, ok := New[int, int]().Floor(1)
+	require.False(t, ok)
+}
+
+func TestCustomComparer(t *testing.T) {
+	m := NewFunc[string, int](func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+
+	m.Put("Apple", 1)
+	require.False(t, m.Put("APPLE", 2))
+
+	v, _ := m.Get("apple")
+	require.Equal(t, 2, v)
+	require.Equal(t, []string{"APPLE"}, m.Keys())
+}
+
+func TestThreadSafety(t *testing.T) {
+	m := New(WithThreadSafe[int, int]())
+	wg := sync.WaitGroup{}
+
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Put(g*100+i, i)
+				m.Get(i)
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	require.Equal(t, 800, m.Count())
+}
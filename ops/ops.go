@@ -0,0 +1,81 @@
+/*
+Package ops defines a compact representation of the operations that mutate a collection.
+
+A sequence of ops may be applied to any collection with ApplyOps, and a collection
+may record its own mutations as ops between calls to StartRecording and StopRecording.
+Together these allow the state of a collection to be replicated elsewhere, e.g. in another
+process, by shipping the recorded ops, and allow test fixtures to be expressed as op scripts
+
+	script := []ops.Op[int]{
+		ops.Add(1),
+		ops.Add(2),
+		ops.Remove(1),
+	}
+
+	q := queue.New[int]()
+	q.ApplyOps(script)
+*/
+package ops
+
+import "fmt"
+
+// Kind identifies the operation represented by an Op.
+type Kind uint8
+
+const (
+	// KindAdd adds the op's value to the collection, as per Collection.Add.
+	KindAdd Kind = iota
+
+	// KindRemove removes the first occurrence of the op's value from the collection, as per Collection.Remove.
+	KindRemove
+
+	// KindClear removes all values from the collection, as per Collection.Clear.
+	KindClear
+)
+
+// Op is a single operation on a collection of T.
+type Op[T any] struct {
+	// Kind is the operation to perform.
+	Kind Kind
+
+	// Value is the operand. Unused for KindClear.
+	Value T
+}
+
+// Add returns an op that adds the given value.
+func Add[T any](value T) Op[T] {
+	return Op[T]{Kind: KindAdd, Value: value}
+}
+
+// Remove returns an op that removes the given value.
+func Remove[T any](value T) Op[T] {
+	return Op[T]{Kind: KindRemove, Value: value}
+}
+
+// Clear returns an op that removes all values.
+func Clear[T any]() Op[T] {
+	return Op[T]{Kind: KindClear}
+}
+
+// String returns a string representation of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindAdd:
+		return "Add"
+	case KindRemove:
+		return "Remove"
+	case KindClear:
+		return "Clear"
+	default:
+		return fmt.Sprintf("Kind(%d)", uint8(k))
+	}
+}
+
+// String returns a string representation of the op, e.g. "Add 1".
+func (o Op[T]) String() string {
+	if o.Kind == KindClear {
+		return o.Kind.String()
+	}
+
+	return fmt.Sprintf("%v %v", o.Kind, o.Value)
+}
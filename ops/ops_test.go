@@ -0,0 +1,23 @@
+package ops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOp(t *testing.T) {
+
+	t.Run("Constructors set kind and value", func(t *testing.T) {
+		require.Equal(t, Op[int]{Kind: KindAdd, Value: 1}, Add(1))
+		require.Equal(t, Op[int]{Kind: KindRemove, Value: 2}, Remove(2))
+		require.Equal(t, Op[int]{Kind: KindClear}, Clear[int]())
+	})
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, "Add 1", Add(1).String())
+		require.Equal(t, "Remove a", Remove("a").String())
+		require.Equal(t, "Clear", Clear[int]().String())
+		require.Equal(t, "Kind(9) 0", Op[int]{Kind: 9}.String())
+	})
+}
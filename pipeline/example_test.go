@@ -0,0 +1,34 @@
+package pipeline_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/pipeline"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+)
+
+func Example() {
+	numbers := queue.Of(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	results := orderedset.New[string]()
+
+	odd := pipeline.Then(pipeline.FromCollection[int](numbers), func(_ context.Context, v int) (int, error) {
+		if v%2 == 0 {
+			return 0, pipeline.ErrSkip
+		}
+		return v * v, nil
+	}, pipeline.WithWorkers(4))
+
+	labelled := pipeline.Then(odd, func(_ context.Context, v int) (string, error) {
+		return fmt.Sprintf("%03d", v), nil
+	})
+
+	if err := pipeline.Into[string](context.Background(), labelled, results); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(results.ToSlice())
+	// Output:
+	// [001 009 025 049 081]
+}
@@ -0,0 +1,327 @@
+/*
+Package pipeline moves the values of one collection into another through a series of concurrent stages.
+
+A pipeline begins with a source that reads the values of a collection or iterator,
+is extended with [Then] by stages that transform each value, and is run by [Into],
+which adds the results to a destination collection.
+
+	words := pipeline.FromCollection[string](lines, pipeline.WithChunkSize(256))
+	parsed := pipeline.Then(words, parse, pipeline.WithWorkers(8))
+	enriched := pipeline.Then(parsed, lookup, pipeline.WithWorkers(32), pipeline.WithBuffer(16))
+
+	err := pipeline.Into(ctx, enriched, results)
+
+Values pass between stages in chunks, so the cost of synchronisation is paid once per chunk
+rather than once per value. Each stage buffers a bounded number of chunks, so a fast stage
+blocks once its buffer is full until the stages after it catch up, and the number of values
+in flight is bounded however large the source.
+
+The first error returned by any stage, or the error of the context if it is done first,
+stops the whole pipeline and is returned by Into. Values already added to the destination remain.
+*/
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// DefaultChunkSize is the number of values a source reads into each chunk,
+// unless set otherwise with [WithChunkSize].
+const DefaultChunkSize = 64
+
+// DefaultBuffer is the number of chunks a source or stage buffers for the next stage,
+// unless set otherwise with [WithBuffer].
+const DefaultBuffer = 4
+
+// ErrSkip may be returned by a [TransformFunc] to drop the value from the pipeline
+// without stopping it, so that a stage may filter as well as transform.
+var ErrSkip = errors.New(messages.VALUE_SKIPPED)
+
+// TransformFunc is the signature of a function that transforms a value in a pipeline stage.
+// It should return promptly once ctx is done.
+type TransformFunc[In any, Out any] func(ctx context.Context, value In) (Out, error)
+
+// StageOptionFunc is the signature of a function
+// for providing options to sources and stages.
+type StageOptionFunc func(*stage)
+
+// stage holds the options of a source or stage.
+type stage struct {
+	chunkSize int
+	buffer    int
+	workers   int
+}
+
+// Pipe is a source of chunks of values, being either the source of a pipeline
+// or a stage that transforms the chunks of the pipe before it.
+//
+// A pipe does nothing until it is run by [Into]. It may be run more than once,
+// each run reading its source from the start.
+type Pipe[T any] struct {
+	start func(g *group) <-chan []T
+}
+
+// group runs the goroutines of a pipeline, cancelling them all on the first error.
+type group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+// Option function for a source to set the number of values read into each chunk.
+// Has no effect on stages, whose chunks are those received from the pipe before them.
+//
+// Panics if size is less than 1.
+func WithChunkSize(size int) StageOptionFunc {
+	if size < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"))
+	}
+	return func(s *stage) {
+		s.chunkSize = size
+	}
+}
+
+// Option function for a source or stage to set the number of chunks buffered for the next stage.
+// A buffer of 0 hands each chunk directly to the next stage.
+//
+// Panics if size is negative.
+func WithBuffer(size int) StageOptionFunc {
+	if size < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"))
+	}
+	return func(s *stage) {
+		s.buffer = size
+	}
+}
+
+// Option function for a stage to set the number of goroutines that transform chunks concurrently.
+// With more than one worker, chunks may leave the stage in a different order to that in which they arrived,
+// though the order of the values within each chunk is preserved. Has no effect on sources.
+//
+// Panics if workers is less than 1.
+func WithWorkers(workers int) StageOptionFunc {
+	if workers < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "workers"))
+	}
+	return func(s *stage) {
+		s.workers = workers
+	}
+}
+
+// FromIterator returns a pipe whose source is the values yielded by an iterator, read in chunks by a single goroutine.
+// newIterator is called at the start of each run for the iterator of that run, so that runs of the pipe,
+// including concurrent runs, do not share an iterator.
+// As with any iterator, the collection being iterated must not be modified while the pipeline runs.
+//
+// Panics if newIterator is nil.
+func FromIterator[T any](newIterator func() collections.Iterator[T], options ...StageOptionFunc) *Pipe[T] {
+	if newIterator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "newIterator"))
+	}
+
+	s := newStage(options)
+
+	return &Pipe[T]{
+		start: func(g *group) <-chan []T {
+			out := make(chan []T, s.buffer)
+			iter := newIterator()
+
+			g.run(func() error {
+				defer close(out)
+
+				chunk := make([]T, 0, s.chunkSize)
+
+				for e := iter.Start(); e != nil; e = iter.Next() {
+					chunk = append(chunk, e.Value())
+
+					if len(chunk) == s.chunkSize {
+						if err := send(g.ctx, out, chunk); err != nil {
+							return err
+						}
+
+						chunk = make([]T, 0, s.chunkSize)
+					}
+				}
+
+				if len(chunk) > 0 {
+					return send(g.ctx, out, chunk)
+				}
+
+				return nil
+			})
+
+			return out
+		},
+	}
+}
+
+// FromCollection returns a pipe whose source is the values of the collection,
+// in the order of its forward iterator, as [FromIterator].
+//
+// Panics if collection is nil.
+func FromCollection[T any](collection collections.Collection[T], options ...StageOptionFunc) *Pipe[T] {
+	if collection == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "collection"))
+	}
+
+	return FromIterator(collection.Iterator, options...)
+}
+
+// Then returns a pipe that transforms each value of p with transform.
+// Chunks are transformed by the number of goroutines given by [WithWorkers], by default one.
+//
+// If transform returns [ErrSkip] the value is dropped. Any other error stops the pipeline.
+//
+// Panics if p or transform is nil.
+func Then[In any, Out any](p *Pipe[In], transform TransformFunc[In, Out], options ...StageOptionFunc) *Pipe[Out] {
+	if p == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "p"))
+	}
+
+	if transform == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "transform"))
+	}
+
+	s := newStage(options)
+
+	return &Pipe[Out]{
+		start: func(g *group) <-chan []Out {
+			in := p.start(g)
+			out := make(chan []Out, s.buffer)
+			workers := sync.WaitGroup{}
+			workers.Add(s.workers)
+
+			for i := 0; i < s.workers; i++ {
+				g.run(func() error {
+					defer workers.Done()
+
+					for chunk := range in {
+						results := make([]Out, 0, len(chunk))
+
+						for _, v := range chunk {
+							r, err := transform(g.ctx, v)
+
+							if errors.Is(err, ErrSkip) {
+								continue
+							}
+
+							if err != nil {
+								return err
+							}
+
+							results = append(results, r)
+						}
+
+						if len(results) == 0 {
+							continue
+						}
+
+						if err := send(g.ctx, out, results); err != nil {
+							return err
+						}
+					}
+
+					return g.ctx.Err()
+				})
+			}
+
+			go func() {
+				workers.Wait()
+				close(out)
+			}()
+
+			return out
+		},
+	}
+}
+
+// Into runs the pipeline ending with p, adding each chunk of its results to dest with AddRange
+// until the source is exhausted, a stage returns an error, or ctx is done.
+// Values are inserted according to the rules of dest.
+//
+// dest is modified by a single goroutine, so need not be thread-safe, but should not be
+// accessed elsewhere until Into returns unless it is.
+//
+// Returns nil if all values reached dest, else the first error returned by a stage or the error of ctx.
+//
+// Panics if p or dest is nil.
+func Into[T any](ctx context.Context, p *Pipe[T], dest collections.Collection[T]) error {
+	if p == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "p"))
+	}
+
+	if dest == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dest"))
+	}
+
+	g := newGroup(ctx)
+	in := p.start(g)
+
+	g.run(func() error {
+		return util.BulkAdd(g.ctx, in, dest.AddRange)
+	})
+
+	return g.wait()
+}
+
+func newStage(options []StageOptionFunc) *stage {
+	s := &stage{
+		chunkSize: DefaultChunkSize,
+		buffer:    DefaultBuffer,
+		workers:   1,
+	}
+
+	for _, o := range options {
+		o(s)
+	}
+
+	return s
+}
+
+// send sends a chunk to the next stage, unless ctx is done first.
+func send[T any](ctx context.Context, out chan<- []T, chunk []T) error {
+	select {
+	case out <- chunk:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newGroup(ctx context.Context) *group {
+	g := &group{}
+	g.ctx, g.cancel = context.WithCancel(ctx)
+	return g
+}
+
+// run calls f in a new goroutine, cancelling the group if it returns an error.
+func (g *group) run(f func() error) {
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+
+		if err := f(); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				g.cancel()
+			})
+		}
+	}()
+}
+
+// wait waits for all goroutines of the group, returning the first error.
+func (g *group) wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
@@ -0,0 +1,198 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists/slist"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/stretchr/testify/require"
+)
+
+func rangeOf(n int) *slist.SList[int] {
+	l := slist.New[int]()
+	for i := 0; i < n; i++ {
+		l.Add(i)
+	}
+	return l
+}
+
+func double(_ context.Context, v int) (int, error) {
+	return v * 2, nil
+}
+
+func TestOptions(t *testing.T) {
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"), func() { WithChunkSize(0) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"), func() { WithBuffer(-1) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "workers"), func() { WithWorkers(0) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "newIterator"), func() { FromIterator[int](nil) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "collection"), func() { FromCollection[int](nil) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "transform"), func() { Then[int, int](FromCollection[int](rangeOf(1)), nil) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dest"), func() { Into[int](context.Background(), FromCollection[int](rangeOf(1)), nil) })
+}
+
+func TestSingleStage(t *testing.T) {
+
+	t.Run("Single worker preserves order", func(t *testing.T) {
+		dest := queue.New[int]()
+		p := Then(FromCollection[int](rangeOf(1000), WithChunkSize(7)), double)
+
+		require.NoError(t, Into[int](context.Background(), p, dest))
+		require.Equal(t, 1000, dest.Count())
+
+		for i := 0; i < 1000; i++ {
+			require.Equal(t, i*2, dest.Dequeue())
+		}
+	})
+
+	t.Run("Source alone copies collection", func(t *testing.T) {
+		dest := slist.New[int]()
+		require.NoError(t, Into[int](context.Background(), FromCollection[int](rangeOf(10)), dest))
+		require.Equal(t, rangeOf(10).ToSlice(), dest.ToSlice())
+	})
+
+	t.Run("Empty source", func(t *testing.T) {
+		dest := slist.New[int]()
+		require.NoError(t, Into[int](context.Background(), Then(FromCollection[int](rangeOf(0)), double), dest))
+		require.True(t, dest.IsEmpty())
+	})
+
+	t.Run("Skipped values are dropped", func(t *testing.T) {
+		dest := slist.New[int]()
+		evens := Then(FromCollection[int](rangeOf(10)), func(_ context.Context, v int) (int, error) {
+			if v%2 != 0 {
+				return 0, ErrSkip
+			}
+			return v, nil
+		})
+
+		require.NoError(t, Into[int](context.Background(), evens, dest))
+		require.Equal(t, []int{0, 2, 4, 6, 8}, dest.ToSlice())
+	})
+
+	t.Run("Pipe may be run again", func(t *testing.T) {
+		p := Then(FromCollection[int](rangeOf(5)), double)
+
+		for i := 0; i < 2; i++ {
+			dest := slist.New[int]()
+			require.NoError(t, Into[int](context.Background(), p, dest))
+			require.Equal(t, []int{0, 2, 4, 6, 8}, dest.ToSlice())
+		}
+	})
+
+	t.Run("Concurrent runs each read their own iterator", func(t *testing.T) {
+		source := rangeOf(1000)
+		var iterators int32
+		p := FromIterator(func() collections.Iterator[int] {
+			atomic.AddInt32(&iterators, 1)
+			return source.Iterator()
+		}, WithChunkSize(1))
+
+		dests := make([]*slist.SList[int], 4)
+		errs := make(chan error, len(dests))
+
+		for i := range dests {
+			dests[i] = slist.New[int]()
+			go func(dest *slist.SList[int]) {
+				errs <- Into[int](context.Background(), p, dest)
+			}(dests[i])
+		}
+
+		for range dests {
+			require.NoError(t, <-errs)
+		}
+
+		require.Equal(t, int32(len(dests)), atomic.LoadInt32(&iterators))
+
+		for _, dest := range dests {
+			require.Equal(t, source.ToSlice(), dest.ToSlice())
+		}
+	})
+}
+
+func TestMultiStage(t *testing.T) {
+
+	t.Run("Stages of different types with many workers", func(t *testing.T) {
+		dest := hashset.New[string]()
+		doubled := Then(FromCollection[int](rangeOf(10000), WithChunkSize(16)), double, WithWorkers(8))
+		strs := Then(doubled, func(_ context.Context, v int) (string, error) {
+			return strconv.Itoa(v), nil
+		}, WithWorkers(4), WithBuffer(0))
+
+		require.NoError(t, Into[string](context.Background(), strs, dest))
+		require.Equal(t, 10000, dest.Count())
+
+		values := dest.ToSlice()
+		sort.Slice(values, func(i, j int) bool {
+			a, _ := strconv.Atoi(values[i])
+			b, _ := strconv.Atoi(values[j])
+			return a < b
+		})
+		require.Equal(t, "19998", values[len(values)-1])
+	})
+
+	t.Run("Workers run concurrently", func(t *testing.T) {
+		var running, maxRunning int32
+		slow := func(_ context.Context, v int) (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return v, nil
+		}
+
+		dest := slist.New[int]()
+		p := Then(FromCollection[int](rangeOf(16), WithChunkSize(1)), slow, WithWorkers(4))
+		require.NoError(t, Into[int](context.Background(), p, dest))
+		require.Equal(t, 16, dest.Count())
+		require.Greater(t, atomic.LoadInt32(&maxRunning), int32(1))
+	})
+}
+
+func TestErrors(t *testing.T) {
+
+	t.Run("Stage error stops pipeline", func(t *testing.T) {
+		failure := errors.New("bad value")
+		var seen int32
+
+		p := Then(FromCollection[int](rangeOf(100000), WithChunkSize(10)), func(_ context.Context, v int) (int, error) {
+			atomic.AddInt32(&seen, 1)
+			if v == 50 {
+				return 0, failure
+			}
+			return v, nil
+		}, WithWorkers(2))
+
+		dest := slist.New[int]()
+		require.ErrorIs(t, Into[int](context.Background(), Then(p, double), dest), failure)
+		require.Less(t, int(atomic.LoadInt32(&seen)), 100000)
+	})
+
+	t.Run("Cancelled context stops pipeline", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		p := Then(FromCollection[int](rangeOf(100000), WithChunkSize(1)), func(ctx context.Context, v int) (int, error) {
+			if v == 10 {
+				cancel()
+			}
+			return v, nil
+		})
+
+		dest := slist.New[int]()
+		require.ErrorIs(t, Into[int](ctx, p, dest), context.Canceled)
+		require.Less(t, dest.Count(), 100000)
+	})
+}
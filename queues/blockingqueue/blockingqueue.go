@@ -0,0 +1,323 @@
+/*
+Package blockingqueue provides a bounded FIFO queue for passing values between goroutines.
+
+Enqueue blocks while the queue is full and Dequeue blocks while it is empty, so a BlockingQueue
+may connect producers and consumers directly, applying back-pressure to producers that outpace
+their consumers. Each blocking method has a variant that accepts a context and returns its error
+if it is done before the operation completes, and TryDequeueTimeout bounds the wait for a value.
+
+Closing the queue wakes all waiting goroutines. No further values may be enqueued, but the values
+already in the queue may still be dequeued, so consumers can drain it before stopping.
+*/
+package blockingqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// ErrClosed is returned by the context variants of Enqueue and Dequeue and by TryAdd when the queue
+// has been closed, or for Dequeue, when it has also been drained. It is [collections.ErrClosed].
+var ErrClosed = collections.ErrClosed
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*BlockingQueue[int])(nil)
+
+// WaitStats reports how the waits of producers or consumers on a BlockingQueue were satisfied.
+type WaitStats = util.WaitStats
+
+// BlockingQueueOptionFunc is the signature of a function
+// for providing options to the BlockingQueue constructor.
+type BlockingQueueOptionFunc[T any] func(*BlockingQueue[T])
+
+// BlockingQueue implements a first-in, first-out queue of bounded capacity whose Enqueue and Dequeue
+// block until the operation can be performed. It is always thread-safe.
+type BlockingQueue[T any] struct {
+	lock     sync.Mutex
+	buffer   []T
+	head     int
+	count    int
+	spins    int
+	clock    functions.Clock
+	notEmpty *util.Waiter
+	notFull  *util.Waiter
+	closed   bool
+}
+
+// New constructs a BlockingQueue that holds at most capacity values.
+// As for a buffered channel, storage for capacity values is allocated up front.
+//
+// Panics if capacity is less than 1.
+func New[T any](capacity int, options ...BlockingQueueOptionFunc[T]) *BlockingQueue[T] {
+	if capacity < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "capacity"))
+	}
+
+	q := &BlockingQueue[T]{
+		buffer: make([]T, capacity),
+		clock:  functions.SystemClock,
+	}
+
+	for _, o := range options {
+		o(q)
+	}
+
+	q.notEmpty = util.NewWaiter(q.spins, q.clock)
+	q.notFull = util.NewWaiter(q.spins, q.clock)
+
+	return q
+}
+
+// Option function for New to spin up to spins times, yielding the processor, before a blocked goroutine is parked.
+// When the queue is busy, the wait is likely to be satisfied while spinning, which avoids the cost of parking and
+// waking the goroutine and so reduces tail latency, at the cost of CPU. The default is not to spin.
+//
+// Panics if spins is negative.
+func WithSpins[T any](spins int) BlockingQueueOptionFunc[T] {
+	if spins < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "spins"))
+	}
+
+	return func(q *BlockingQueue[T]) {
+		q.spins = spins
+	}
+}
+
+// Option function for New to provide the clock by which waits are timed for [BlockingQueue.WaitStats].
+// The default is [functions.SystemClock]. Timeouts are always measured by the system clock.
+//
+// Panics if clock is nil.
+func WithClock[T any](clock functions.Clock) BlockingQueueOptionFunc[T] {
+	if clock == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "clock"))
+	}
+
+	return func(q *BlockingQueue[T]) {
+		q.clock = clock
+	}
+}
+
+// Enqueue adds a value to the back of the queue, blocking while the queue is full.
+//
+// Panics if the queue is closed, including while blocked.
+func (q *BlockingQueue[T]) Enqueue(value T) {
+
+	if err := q.enqueue(context.Background(), value); err != nil {
+		panic(messages.COLLECTION_CLOSED)
+	}
+}
+
+// EnqueueContext adds a value to the back of the queue, blocking while the queue is full.
+//
+// Returns the error of ctx if it is done before the value could be added,
+// or [ErrClosed] if the queue is closed.
+func (q *BlockingQueue[T]) EnqueueContext(ctx context.Context, value T) error {
+
+	return q.enqueue(ctx, value)
+}
+
+// TryEnqueue adds a value to the back of the queue without blocking.
+// Returns false if the queue is full or closed; else true.
+func (q *BlockingQueue[T]) TryEnqueue(value T) bool {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed || q.count == len(q.buffer) {
+		return false
+	}
+
+	q.push(value)
+	return true
+}
+
+// TryAdd adds a value to the back of the queue without blocking, as TryEnqueue does.
+//
+// Returns true and nil if the value was added; else false and [collections.ErrFull]
+// if the queue is full, or [ErrClosed] if it has been closed.
+func (q *BlockingQueue[T]) TryAdd(value T) (bool, error) {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed {
+		return false, ErrClosed
+	}
+
+	if q.count == len(q.buffer) {
+		return false, collections.ErrFull
+	}
+
+	q.push(value)
+	return true, nil
+}
+
+// Dequeue removes and returns the value at the front of the queue, blocking while the queue is empty.
+//
+// Panics if the queue is closed and has been drained, including while blocked.
+func (q *BlockingQueue[T]) Dequeue() T {
+
+	value, err := q.dequeue(context.Background())
+
+	if err != nil {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	return value
+}
+
+// DequeueContext removes and returns the value at the front of the queue, blocking while the queue is empty.
+//
+// Returns the error of ctx if it is done before a value could be removed,
+// or [ErrClosed] if the queue is closed and has been drained.
+func (q *BlockingQueue[T]) DequeueContext(ctx context.Context) (T, error) {
+
+	return q.dequeue(ctx)
+}
+
+// TryDequeue removes and returns the value at the front of the queue and true without blocking,
+// or zero value of T and false if the queue is empty.
+func (q *BlockingQueue[T]) TryDequeue() (T, bool) {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.count == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.pop(), true
+}
+
+// TryDequeueTimeout removes and returns the value at the front of the queue and true, blocking for at most
+// timeout while the queue is empty. Returns zero value of T and false if no value arrived within timeout,
+// or the queue is closed and has been drained.
+func (q *BlockingQueue[T]) TryDequeueTimeout(timeout time.Duration) (T, bool) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	value, err := q.dequeue(ctx)
+	return value, err == nil
+}
+
+// Close marks the queue as closed and wakes all blocked goroutines. No further values may be enqueued,
+// however values already in the queue may still be dequeued.
+//
+// Once the queue is drained, Dequeue panics indicating closure, DequeueContext returns [ErrClosed]
+// and TryDequeue returns false. Closing a closed queue has no effect.
+func (q *BlockingQueue[T]) Close() {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
+
+// IsClosed returns true if the queue has been closed.
+func (q *BlockingQueue[T]) IsClosed() bool {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.closed
+}
+
+// Count returns the number of values in the queue.
+func (q *BlockingQueue[T]) Count() int {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.count
+}
+
+// IsEmpty returns true if the queue has no values.
+func (q *BlockingQueue[T]) IsEmpty() bool {
+
+	return q.Count() == 0
+}
+
+// Capacity returns the maximum number of values the queue holds.
+func (q *BlockingQueue[T]) Capacity() int {
+
+	return len(q.buffer)
+}
+
+// WaitStats returns the statistics of the waits so far of producers blocked on a full queue,
+// and of consumers blocked on an empty queue.
+func (q *BlockingQueue[T]) WaitStats() (producers, consumers WaitStats) {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.notFull.Stats(), q.notEmpty.Stats()
+}
+
+func (q *BlockingQueue[T]) enqueue(ctx context.Context, value T) error {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for !q.closed && q.count == len(q.buffer) {
+		if err := q.notFull.Wait(ctx, &q.lock); err != nil {
+			return err
+		}
+	}
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	q.push(value)
+	return nil
+}
+
+func (q *BlockingQueue[T]) dequeue(ctx context.Context) (T, error) {
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for !q.closed && q.count == 0 {
+		if err := q.notEmpty.Wait(ctx, &q.lock); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+
+	if q.count == 0 {
+		var zero T
+		return zero, ErrClosed
+	}
+
+	return q.pop(), nil
+}
+
+// push adds a value and wakes a waiting consumer. The caller must hold the lock.
+func (q *BlockingQueue[T]) push(value T) {
+	q.buffer[(q.head+q.count)%len(q.buffer)] = value
+	q.count++
+	q.notEmpty.Signal()
+}
+
+// pop removes a value and wakes a waiting producer. The caller must hold the lock.
+func (q *BlockingQueue[T]) pop() T {
+	var zero T
+
+	value := q.buffer[q.head]
+	q.buffer[q.head] = zero // Release any reference held by the value
+	q.head = (q.head + 1) % len(q.buffer)
+	q.count--
+	q.notFull.Signal()
+	return value
+}
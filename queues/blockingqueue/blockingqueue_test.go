@@ -0,0 +1,238 @@
+package blockingqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructor(t *testing.T) {
+
+	q := New[int](3)
+	require.Equal(t, 3, q.Capacity())
+	require.True(t, q.IsEmpty())
+
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "capacity"), func() { New[int](0) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "spins"), func() { WithSpins[int](-1) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "clock"), func() { WithClock[int](nil) })
+}
+
+func TestBlockingQueue(t *testing.T) {
+
+	t.Run("Values are dequeued in order across wrap-around", func(t *testing.T) {
+		q := New[int](3)
+
+		for i := 0; i < 10; i++ {
+			require.True(t, q.TryEnqueue(i))
+			require.True(t, q.TryEnqueue(i+100))
+			require.Equal(t, 2, q.Count())
+			require.Equal(t, i, q.Dequeue())
+			require.Equal(t, i+100, q.Dequeue())
+		}
+
+		_, ok := q.TryDequeue()
+		require.False(t, ok)
+	})
+
+	t.Run("Values need not be comparable", func(t *testing.T) {
+		type job struct{ id []int }
+		q := New[job](1)
+
+		q.Enqueue(job{id: []int{1}})
+		require.Equal(t, []int{1}, q.Dequeue().id)
+	})
+
+	t.Run("TryEnqueue fails when full", func(t *testing.T) {
+		q := New[int](2)
+
+		require.True(t, q.TryEnqueue(1))
+		require.True(t, q.TryEnqueue(2))
+		require.False(t, q.TryEnqueue(3))
+	})
+
+	t.Run("Enqueue blocks until there is room", func(t *testing.T) {
+		q := New[int](1)
+		q.Enqueue(1)
+		enqueued := make(chan struct{})
+
+		go func() {
+			q.Enqueue(2)
+			close(enqueued)
+		}()
+
+		select {
+		case <-enqueued:
+			t.Fatal("Enqueue did not block on a full queue")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		require.Equal(t, 1, q.Dequeue())
+		<-enqueued
+		require.Equal(t, 2, q.Dequeue())
+
+		producers, _ := q.WaitStats()
+		require.Equal(t, 1, producers.Parked)
+	})
+
+	t.Run("Dequeue blocks until a value arrives", func(t *testing.T) {
+		q := New[int](1)
+		result := make(chan int)
+
+		go func() { result <- q.Dequeue() }()
+
+		time.Sleep(10 * time.Millisecond)
+		q.Enqueue(42)
+		require.Equal(t, 42, <-result)
+	})
+
+	t.Run("Context variants return the context error", func(t *testing.T) {
+		q := New[int](1)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := q.DequeueContext(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		require.NoError(t, q.EnqueueContext(context.Background(), 1))
+
+		ctx, cancel = context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, q.EnqueueContext(ctx, 2), context.Canceled)
+
+		// A value available is returned even though ctx is done
+		v, err := q.DequeueContext(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, v)
+
+		_, consumers := q.WaitStats()
+		require.Equal(t, 1, consumers.Cancelled)
+	})
+
+	t.Run("TryDequeueTimeout", func(t *testing.T) {
+		q := New[int](1)
+
+		start := time.Now()
+		_, ok := q.TryDequeueTimeout(20 * time.Millisecond)
+		require.False(t, ok)
+		require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			q.Enqueue(7)
+		}()
+
+		v, ok := q.TryDequeueTimeout(time.Second)
+		require.True(t, ok)
+		require.Equal(t, 7, v)
+	})
+
+	t.Run("Close wakes blocked goroutines and allows draining", func(t *testing.T) {
+		q := New[int](1)
+		errs := make(chan error, 2)
+
+		go func() {
+			_, err := q.DequeueContext(context.Background())
+			errs <- err
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		q.Close()
+		require.ErrorIs(t, <-errs, ErrClosed)
+		require.True(t, q.IsClosed())
+
+		q = New[int](1)
+		q.Enqueue(1)
+
+		go func() { errs <- q.EnqueueContext(context.Background(), 2) }()
+
+		time.Sleep(10 * time.Millisecond)
+		q.Close()
+		require.ErrorIs(t, <-errs, ErrClosed)
+
+		require.False(t, q.TryEnqueue(3))
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { q.Enqueue(3) })
+		require.Equal(t, 1, q.Dequeue())
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { q.Dequeue() })
+
+		_, ok := q.TryDequeueTimeout(time.Second)
+		require.False(t, ok)
+	})
+}
+
+func TestProducerConsumer(t *testing.T) {
+
+	const producers, perProducer = 4, 1000
+
+	for _, spins := range []int{0, 100} {
+		t.Run(fmt.Sprintf("%d spins", spins), func(t *testing.T) {
+			q := New(4, WithSpins[int](spins), WithClock[int](functions.SystemClock))
+			var wg sync.WaitGroup
+
+			for p := 0; p < producers; p++ {
+				wg.Add(1)
+				go func(p int) {
+					defer wg.Done()
+					for i := 0; i < perProducer; i++ {
+						q.Enqueue(p*perProducer + i)
+					}
+				}(p)
+			}
+
+			go func() {
+				wg.Wait()
+				q.Close()
+			}()
+
+			seen := make([]bool, producers*perProducer)
+			last := make([]int, producers)
+
+			for i := range last {
+				last[i] = -1
+			}
+
+			for {
+				v, err := q.DequeueContext(context.Background())
+				if err != nil {
+					require.ErrorIs(t, err, ErrClosed)
+					break
+				}
+
+				// Values of each producer arrive in the order it enqueued them
+				p := v / perProducer
+				require.Greater(t, v, last[p])
+				last[p] = v
+				seen[v] = true
+			}
+
+			for v, ok := range seen {
+				require.True(t, ok, "value %d lost", v)
+			}
+		})
+	}
+}
+
+func TestTryAdd(t *testing.T) {
+
+	q := New[int](1)
+
+	added, err := q.TryAdd(1)
+	require.True(t, added)
+	require.NoError(t, err)
+
+	added, err = q.TryAdd(2)
+	require.False(t, added)
+	require.ErrorIs(t, err, collections.ErrFull)
+
+	q.Close()
+	_, err = q.TryAdd(3)
+	require.ErrorIs(t, err, ErrClosed)
+	require.ErrorIs(t, err, collections.ErrClosed)
+	require.Equal(t, 1, q.Count())
+}
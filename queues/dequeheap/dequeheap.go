@@ -0,0 +1,590 @@
+/*
+Package dequeheap provides a double-ended priority queue implemented as a min-max heap.
+
+A min-max heap is a binary heap whose levels alternate between min levels and max levels.
+Every value on a min level is less than or equal to all of its descendants, and every value on a max level
+is greater than or equal to all of its descendants. The minimum is therefore at the root and the maximum
+is one of the root's children, so both ends of the queue may be peeked in O(1) and popped in O(log n).
+
+This makes it suitable for keeping a bounded top-K (pop the minimum when the heap grows too large,
+whilst still being able to see the maximum), or for maintaining a running median with a pair of heaps.
+*/
+package dequeheap
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"golang.org/x/exp/constraints"
+)
+
+// direction determines whether a heap level orders values least first or greatest first.
+type direction bool
+
+const (
+	less    direction = true
+	greater direction = false
+)
+
+// DequeHeapOptionFunc is the signature of a function
+// for providing options to the DequeHeap constructor.
+type DequeHeapOptionFunc[T any] func(*DequeHeap[T])
+
+// DequeHeap implements a double-ended priority queue.
+type DequeHeap[T any] struct {
+	lock            *sync.RWMutex
+	initialCapacity int
+	compare         functions.ComparerFunc[T]
+	copy            functions.DeepCopyFunc[T]
+	copyPolicy      collections.CopyPolicy
+	buffer          []T
+}
+
+// New constructs a new, empty DequeHeap.
+func New[T any](options ...DequeHeapOptionFunc[T]) *DequeHeap[T] {
+	h := &DequeHeap[T]{
+		initialCapacity: util.DefaultCapacity,
+	}
+
+	for _, o := range options {
+		o(h)
+	}
+
+	h.buffer = make([]T, 0, h.initialCapacity)
+
+	if h.copy == nil || h.copyPolicy == collections.Shallow {
+		h.copy = util.DefaultDeepCopy[T]
+	}
+
+	if h.compare == nil {
+		h.compare = util.GetDefaultComparer[T]()
+	}
+
+	return h
+}
+
+// Of constructs a new heap containing the given values, e.g. dequeheap.Of(1, 2, 3).
+func Of[T any](values ...T) *DequeHeap[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	h := dequeheap.OfWith([]dequeheap.DequeHeapOptionFunc[int]{dequeheap.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []DequeHeapOptionFunc[T], values ...T) *DequeHeap[T] {
+	h := New(options...)
+	h.AddRange(values)
+	return h
+}
+
+// NewFunc is as [New], using compare to order the heap.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...DequeHeapOptionFunc[T]) *DequeHeap[T] {
+	return New(append([]DequeHeapOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...DequeHeapOptionFunc[T]) *DequeHeap[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[T any]() DequeHeapOptionFunc[T] {
+	return func(h *DequeHeap[T]) {
+		h.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function for New to set initial capacity to
+// something other than the default 16 elements.
+func WithCapacity[T any](capacity int) DequeHeapOptionFunc[T] {
+	if capacity < 0 {
+		panic(messages.NEGATIVE_CAPACITY)
+	}
+	return func(h *DequeHeap[T]) {
+		h.initialCapacity = capacity
+	}
+}
+
+// Option function for New to provide a comparer function for values of type T.
+// Required if the element type is not numeric, bool, pointer or string.
+func WithComparer[T any](comparer functions.ComparerFunc[T]) DequeHeapOptionFunc[T] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(h *DequeHeap[T]) {
+		h.compare = comparer
+	}
+}
+
+// Option func to provide a deep copy implementation for collection elements.
+func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) DequeHeapOptionFunc[T] {
+	// Can be nil
+	return func(h *DequeHeap[T]) {
+		h.copy = copier
+	}
+}
+
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) DequeHeapOptionFunc[T] {
+	return func(h *DequeHeap[T]) {
+		h.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+//
+// Concurrent is ignored by this collection.
+func WithOptions[T any](options collections.CommonOptions[T]) DequeHeapOptionFunc[T] {
+	opts := make([]DequeHeapOptionFunc[T], 0, 5)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Capacity != 0 {
+		opts = append(opts, WithCapacity[T](options.Capacity))
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	return func(h *DequeHeap[T]) {
+		for _, o := range opts {
+			o(h)
+		}
+	}
+}
+
+// FromCollection constructs a new DequeHeap containing the values of the given collection.
+//
+// The heap is built in O(n).
+func FromCollection[T any](collection collections.Collection[T], options ...DequeHeapOptionFunc[T]) *DequeHeap[T] {
+	h := New(options...)
+	h.buffer = append(h.buffer, util.ImportValues(collection, h.copyPolicy)...)
+	h.heapify()
+	return h
+}
+
+// Add adds a value to the heap. O(log n).
+func (h *DequeHeap[T]) Add(value T) {
+
+	if h.lock != nil {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+	}
+
+	h.buffer = append(h.buffer, value)
+	h.pushUp(len(h.buffer) - 1)
+}
+
+// AddRange adds a slice of values to the heap.
+//
+// Where the number of values is large relative to the heap, the heap is rebuilt in O(n)
+// rather than adding each value in O(log n).
+func (h *DequeHeap[T]) AddRange(values []T) {
+
+	if h.lock != nil {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+	}
+
+	if len(values) > len(h.buffer) {
+		h.buffer = append(h.buffer, values...)
+		h.heapify()
+		return
+	}
+
+	for _, v := range values {
+		h.buffer = append(h.buffer, v)
+		h.pushUp(len(h.buffer) - 1)
+	}
+}
+
+// PeekMin returns the minimum value in the heap without removing it. O(1).
+//
+// Panics if the heap is empty.
+func (h *DequeHeap[T]) PeekMin() T {
+
+	if h.lock != nil {
+		h.lock.RLock()
+		defer h.lock.RUnlock()
+	}
+
+	if len(h.buffer) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return h.buffer[0]
+}
+
+// PeekMax returns the maximum value in the heap without removing it. O(1).
+//
+// Panics if the heap is empty.
+func (h *DequeHeap[T]) PeekMax() T {
+
+	if h.lock != nil {
+		h.lock.RLock()
+		defer h.lock.RUnlock()
+	}
+
+	if len(h.buffer) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return h.buffer[h.maxIndex()]
+}
+
+// TryPeekMin returns the minimum value in the heap and true if
+// the heap is not empty; else zero value of T and false.
+func (h *DequeHeap[T]) TryPeekMin() (T, bool) {
+
+	if h.lock != nil {
+		h.lock.RLock()
+		defer h.lock.RUnlock()
+	}
+
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.buffer[0], true
+}
+
+// TryPeekMax returns the maximum value in the heap and true if
+// the heap is not empty; else zero value of T and false.
+func (h *DequeHeap[T]) TryPeekMax() (T, bool) {
+
+	if h.lock != nil {
+		h.lock.RLock()
+		defer h.lock.RUnlock()
+	}
+
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.buffer[h.maxIndex()], true
+}
+
+// PopMin removes and returns the minimum value in the heap. O(log n).
+//
+// Panics if the heap is empty.
+func (h *DequeHeap[T]) PopMin() T {
+
+	if h.lock != nil {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+	}
+
+	if len(h.buffer) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return h.removeAt(0)
+}
+
+// PopMax removes and returns the maximum value in the heap. O(log n).
+//
+// Panics if the heap is empty.
+func (h *DequeHeap[T]) PopMax() T {
+
+	if h.lock != nil {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+	}
+
+	if len(h.buffer) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return h.removeAt(h.maxIndex())
+}
+
+// TryPopMin removes and returns the minimum value in the heap and true if
+// the heap is not empty; else zero value of T and false.
+func (h *DequeHeap[T]) TryPopMin() (T, bool) {
+
+	if h.lock != nil {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+	}
+
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.removeAt(0), true
+}
+
+// TryPopMax removes and returns the maximum value in the heap and true if
+// the heap is not empty; else zero value of T and false.
+func (h *DequeHeap[T]) TryPopMax() (T, bool) {
+
+	if h.lock != nil {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+	}
+
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.removeAt(h.maxIndex()), true
+}
+
+// Count returns the number of values in the heap.
+func (h *DequeHeap[T]) Count() int {
+
+	if h.lock != nil {
+		h.lock.RLock()
+		defer h.lock.RUnlock()
+	}
+
+	return len(h.buffer)
+}
+
+// IsEmpty returns true if the heap has no values.
+func (h *DequeHeap[T]) IsEmpty() bool {
+	return h.Count() == 0
+}
+
+// Clear removes all values from the heap.
+func (h *DequeHeap[T]) Clear() {
+
+	if h.lock != nil {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+	}
+
+	h.buffer = make([]T, 0, h.initialCapacity)
+}
+
+// ToSlice returns a copy of the heap content as a slice, in ascending order.
+func (h *DequeHeap[T]) ToSlice() []T {
+
+	if h.lock != nil {
+		h.lock.RLock()
+		defer h.lock.RUnlock()
+	}
+
+	return h.toSlice(false)
+}
+
+// ToSliceDeep returns a copy of the heap content as a slice, in ascending order.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (h *DequeHeap[T]) ToSliceDeep() []T {
+
+	if h.lock != nil {
+		h.lock.RLock()
+		defer h.lock.RUnlock()
+	}
+
+	return h.toSlice(true)
+}
+
+// String returns a string representation of container.
+func (h *DequeHeap[T]) String() string {
+
+	var values []string
+	for _, value := range h.ToSlice() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+
+	return "DequeHeap\n" + strings.Join(values, ", ")
+}
+
+func (h *DequeHeap[T]) toSlice(deepCopy bool) []T {
+	slc := make([]T, len(h.buffer))
+
+	if deepCopy {
+		util.DeepCopySlice(slc, h.buffer, h.copy)
+	} else {
+		copy(slc, h.buffer)
+	}
+
+	util.Gosort(slc, len(slc), h.compare)
+	return slc
+}
+
+// maxIndex returns the index of the maximum value, which is one of the children of the root.
+// Must not be called on an empty heap.
+func (h *DequeHeap[T]) maxIndex() int {
+	switch len(h.buffer) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	default:
+		if h.compare(h.buffer[1], h.buffer[2]) >= 0 {
+			return 1
+		}
+		return 2
+	}
+}
+
+func (h *DequeHeap[T]) removeAt(index int) T {
+	var empty T
+	last := len(h.buffer) - 1
+	value := h.buffer[index]
+	h.buffer[index] = h.buffer[last]
+	h.buffer[last] = empty
+	h.buffer = h.buffer[:last]
+
+	if index < last {
+		h.pushDown(index)
+	}
+
+	return value
+}
+
+// heapify establishes the heap property over the entire buffer in O(n).
+func (h *DequeHeap[T]) heapify() {
+	for i := len(h.buffer)/2 - 1; i >= 0; i-- {
+		h.pushDown(i)
+	}
+}
+
+func (h *DequeHeap[T]) pushUp(index int) {
+	if index == 0 {
+		return
+	}
+
+	parent := (index - 1) / 2
+
+	if isMinLevel(index) {
+		if h.compare(h.buffer[index], h.buffer[parent]) > 0 {
+			h.swap(index, parent)
+			h.pushUpTo(parent, greater)
+		} else {
+			h.pushUpTo(index, less)
+		}
+	} else {
+		if h.compare(h.buffer[index], h.buffer[parent]) < 0 {
+			h.swap(index, parent)
+			h.pushUpTo(parent, less)
+		} else {
+			h.pushUpTo(index, greater)
+		}
+	}
+}
+
+// pushUpTo moves the value at index up through its grandparents while it is
+// ordered before them according to dir.
+func (h *DequeHeap[T]) pushUpTo(index int, dir direction) {
+	for index > 2 {
+		grandparent := ((index-1)/2 - 1) / 2
+
+		if !h.ordered(index, grandparent, dir) {
+			return
+		}
+
+		h.swap(index, grandparent)
+		index = grandparent
+	}
+}
+
+func (h *DequeHeap[T]) pushDown(index int) {
+	if isMinLevel(index) {
+		h.pushDownTo(index, less)
+	} else {
+		h.pushDownTo(index, greater)
+	}
+}
+
+// pushDownTo moves the value at index down through its descendants while one of its children
+// or grandchildren is ordered before it according to dir.
+func (h *DequeHeap[T]) pushDownTo(index int, dir direction) {
+	for {
+		m, isGrandchild := h.extremeDescendant(index, dir)
+
+		if m == -1 || !h.ordered(m, index, dir) {
+			return
+		}
+
+		h.swap(m, index)
+
+		if !isGrandchild {
+			return
+		}
+
+		if parent := (m - 1) / 2; h.ordered(parent, m, dir) {
+			h.swap(m, parent)
+		}
+
+		index = m
+	}
+}
+
+// extremeDescendant returns the index of the child or grandchild of index that is ordered first
+// according to dir, and whether that index is a grandchild. Returns -1 if index has no children.
+func (h *DequeHeap[T]) extremeDescendant(index int, dir direction) (int, bool) {
+	n := len(h.buffer)
+	first := 2*index + 1
+
+	if first >= n {
+		return -1, false
+	}
+
+	m := first
+	isGrandchild := false
+
+	if first+1 < n && h.ordered(first+1, m, dir) {
+		m = first + 1
+	}
+
+	for g := 2*first + 1; g < n && g <= 2*first+4; g++ {
+		if h.ordered(g, m, dir) {
+			m = g
+			isGrandchild = true
+		}
+	}
+
+	return m, isGrandchild
+}
+
+// ordered returns true if the value at i should be nearer the root than the value at j
+// on a level of the given direction.
+func (h *DequeHeap[T]) ordered(i, j int, dir direction) bool {
+	c := h.compare(h.buffer[i], h.buffer[j])
+
+	if dir == less {
+		return c < 0
+	}
+
+	return c > 0
+}
+
+func (h *DequeHeap[T]) swap(i, j int) {
+	h.buffer[i], h.buffer[j] = h.buffer[j], h.buffer[i]
+}
+
+// isMinLevel returns true if the given index is on a min level of the heap.
+// The root is on level 0, which is a min level.
+func isMinLevel(index int) bool {
+	return bits.Len(uint(index+1))%2 == 1
+}
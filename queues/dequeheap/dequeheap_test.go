@@ -0,0 +1,209 @@
+package dequeheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyHeap asserts that the min-max heap property holds for every value.
+func verifyHeap[T any](t *testing.T, h *DequeHeap[T]) {
+	t.Helper()
+
+	for i := range h.buffer {
+		for d := 2*i + 1; d < len(h.buffer) && d <= 2*i+2; d++ {
+			checkDescendants(t, h, i, d)
+		}
+	}
+}
+
+func checkDescendants[T any](t *testing.T, h *DequeHeap[T], ancestor, index int) {
+	t.Helper()
+
+	if index >= len(h.buffer) {
+		return
+	}
+
+	c := h.compare(h.buffer[ancestor], h.buffer[index])
+
+	if isMinLevel(ancestor) {
+		require.True(t, c <= 0, "min level value greater than descendant")
+	} else {
+		require.True(t, c >= 0, "max level value less than descendant")
+	}
+
+	checkDescendants(t, h, ancestor, 2*index+1)
+	checkDescendants(t, h, ancestor, 2*index+2)
+}
+
+func TestAddPop(t *testing.T) {
+
+	t.Run("PopMin returns values in ascending order", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(2163))
+		h := New[int]()
+		values := rnd.Perm(200)
+
+		for _, v := range values {
+			h.Add(v)
+		}
+
+		verifyHeap(t, h)
+
+		for i := 0; i < 200; i++ {
+			require.Equal(t, i, h.PopMin())
+		}
+
+		require.True(t, h.IsEmpty())
+	})
+
+	t.Run("PopMax returns values in descending order", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(2163))
+		h := New[int]()
+		h.AddRange(rnd.Perm(200))
+		verifyHeap(t, h)
+
+		for i := 199; i >= 0; i-- {
+			require.Equal(t, i, h.PopMax())
+		}
+	})
+
+	t.Run("Interleaved operations match model", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(2163))
+		h := New[int]()
+		model := []int{}
+
+		for i := 0; i < 2000; i++ {
+			switch op := rnd.Intn(4); {
+			case op < 2 || len(model) == 0:
+				v := rnd.Intn(100)
+				h.Add(v)
+				model = append(model, v)
+				sort.Ints(model)
+			case op == 2:
+				require.Equal(t, model[0], h.PopMin())
+				model = model[1:]
+			default:
+				require.Equal(t, model[len(model)-1], h.PopMax())
+				model = model[:len(model)-1]
+			}
+
+			require.Equal(t, len(model), h.Count())
+
+			if len(model) > 0 {
+				require.Equal(t, model[0], h.PeekMin())
+				require.Equal(t, model[len(model)-1], h.PeekMax())
+			}
+		}
+
+		verifyHeap(t, h)
+		require.Equal(t, model, h.ToSlice())
+	})
+
+	t.Run("Small heaps", func(t *testing.T) {
+		h := New[int]()
+		h.Add(2)
+		require.Equal(t, 2, h.PeekMin())
+		require.Equal(t, 2, h.PeekMax())
+		h.Add(1)
+		require.Equal(t, 1, h.PeekMin())
+		require.Equal(t, 2, h.PeekMax())
+		require.Equal(t, 2, h.PopMax())
+		require.Equal(t, 1, h.PopMax())
+	})
+
+	t.Run("Custom comparer", func(t *testing.T) {
+		h := New(WithComparer(func(a, b string) int { return len(a) - len(b) }))
+		h.AddRange([]string{"ccc", "a", "bb"})
+		require.Equal(t, "a", h.PeekMin())
+		require.Equal(t, "ccc", h.PeekMax())
+	})
+}
+
+func TestEmpty(t *testing.T) {
+
+	h := New[int]()
+
+	t.Run("Peek and Pop panic", func(t *testing.T) {
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { h.PeekMin() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { h.PeekMax() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { h.PopMin() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { h.PopMax() })
+	})
+
+	t.Run("Try operations return false", func(t *testing.T) {
+		_, ok := h.TryPeekMin()
+		require.False(t, ok)
+		_, ok = h.TryPeekMax()
+		require.False(t, ok)
+		_, ok = h.TryPopMin()
+		require.False(t, ok)
+		_, ok = h.TryPopMax()
+		require.False(t, ok)
+	})
+
+	t.Run("Try operations return values", func(t *testing.T) {
+		h := New(WithThreadSafe[int](), WithCapacity[int](4))
+		h.AddRange([]int{3, 1, 2})
+		v, ok := h.TryPeekMin()
+		require.True(t, ok)
+		require.Equal(t, 1, v)
+		v, ok = h.TryPeekMax()
+		require.True(t, ok)
+		require.Equal(t, 3, v)
+		v, ok = h.TryPopMin()
+		require.True(t, ok)
+		require.Equal(t, 1, v)
+		v, ok = h.TryPopMax()
+		require.True(t, ok)
+		require.Equal(t, 3, v)
+		h.Clear()
+		require.True(t, h.IsEmpty())
+	})
+}
+
+func TestFromCollection(t *testing.T) {
+
+	rnd := rand.New(rand.NewSource(2163))
+	ll := dlist.New[int]()
+	ll.AddRange(rnd.Perm(100))
+	h := FromCollection[int](ll)
+
+	verifyHeap(t, h)
+	require.Equal(t, 100, h.Count())
+	require.Equal(t, 0, h.PeekMin())
+	require.Equal(t, 99, h.PeekMax())
+}
+
+func TestTopK(t *testing.T) {
+
+	// Keep the 10 largest values seen, evicting the minimum.
+	rnd := rand.New(rand.NewSource(2163))
+	h := New[int]()
+
+	for _, v := range rnd.Perm(1000) {
+		h.Add(v)
+
+		if h.Count() > 10 {
+			h.PopMin()
+		}
+	}
+
+	require.Equal(t, []int{990, 991, 992, 993, 994, 995, 996, 997, 998, 999}, h.ToSlice())
+	require.Equal(t, "DequeHeap\n990, 991, 992, 993, 994, 995, 996, 997, 998, 999", h.String())
+}
+
+func TestOf(t *testing.T) {
+
+	h := Of(3, 1, 2)
+	verifyHeap(t, h)
+	require.Equal(t, 1, h.PeekMin())
+	require.Equal(t, 3, h.PeekMax())
+
+	h = OfWith([]DequeHeapOptionFunc[int]{WithThreadSafe[int]()}, 4)
+	require.NotNil(t, h.lock)
+	require.Equal(t, 4, h.PopMax())
+}
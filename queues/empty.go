@@ -0,0 +1,118 @@
+package queues
+
+import (
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Assert emptyQueue implements required interfaces.
+var _ Queue[int] = (*emptyQueue[int])(nil)
+
+// emptyQueue is an immutable queue with no values.
+type emptyQueue[T any] struct {
+	// version and lock are never set, but are laid out as in every other
+	// collection as they are read by the internal element and lock helpers.
+	version int
+	lock    *sync.RWMutex
+
+	collections.Collection[T]
+}
+
+// Empty returns an immutable queue with no values, that may be returned instead of nil
+// by functions returning a Queue, so that callers need not check for nil.
+//
+// Methods that read the queue behave as those of any other empty queue, and methods that would
+// modify it panic, as described for [collections.Empty]. Peek panics as the queue is empty, and
+// IsClosed returns false. Map, Select, Sorted, Clone and the like return the empty queue itself.
+//
+// There is one empty queue for each type of value, so the result may be compared with ==.
+func Empty[T any]() Queue[T] {
+	return local.Singleton(func() *emptyQueue[T] {
+		return &emptyQueue[T]{Collection: collections.Empty[T]()}
+	})
+}
+
+// Enqueue panics as the queue is read-only.
+func (*emptyQueue[T]) Enqueue(T) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Dequeue panics as the queue is read-only.
+func (*emptyQueue[T]) Dequeue() T {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// TryDequeue panics as the queue is read-only.
+func (*emptyQueue[T]) TryDequeue() (T, bool) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Close panics as the queue is read-only.
+func (*emptyQueue[T]) Close() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Peek panics as the queue is empty.
+func (*emptyQueue[T]) Peek() T {
+	panic(messages.COLLECTION_EMPTY)
+}
+
+// TryPeek returns the zero value of T and false.
+func (*emptyQueue[T]) TryPeek() (T, bool) {
+	var zero T
+	return zero, false
+}
+
+// IsClosed returns false.
+func (*emptyQueue[T]) IsClosed() bool {
+	return false
+}
+
+// Sort panics as the queue is read-only.
+func (*emptyQueue[T]) Sort() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// SortDescending panics as the queue is read-only.
+func (*emptyQueue[T]) SortDescending() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Sorted returns this queue.
+func (q *emptyQueue[T]) Sorted() collections.Collection[T] {
+	return q
+}
+
+// SortedDescending returns this queue.
+func (q *emptyQueue[T]) SortedDescending() collections.Collection[T] {
+	return q
+}
+
+// Map returns this queue.
+func (q *emptyQueue[T]) Map(func(T) T) collections.Collection[T] {
+	return q
+}
+
+// Select returns this queue.
+func (q *emptyQueue[T]) Select(functions.PredicateFunc[T]) collections.Collection[T] {
+	return q
+}
+
+// SelectDeep returns this queue.
+func (q *emptyQueue[T]) SelectDeep(functions.PredicateFunc[T]) collections.Collection[T] {
+	return q
+}
+
+// Clone returns this queue.
+func (q *emptyQueue[T]) Clone() collections.Collection[T] {
+	return q
+}
+
+// CloneDeep returns this queue.
+func (q *emptyQueue[T]) CloneDeep() collections.Collection[T] {
+	return q
+}
@@ -0,0 +1,358 @@
+/*
+Package keyedqueue provides a FIFO queue of key/value pairs in which each key appears at most once.
+
+Enqueuing a value for a key that is already queued coalesces with the existing entry, replacing
+its value rather than adding another entry. This suits work queues where only the most recent
+job for a given key is of interest, e.g. a controller reconciling the state of named resources.
+By default a coalesced entry keeps its original position in the queue; use [WithMoveToBack]
+to move it to the back instead.
+*/
+package keyedqueue
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// KeyedQueueOptionFunc is the signature of a function
+// for providing options to the KeyedQueue constructor.
+type KeyedQueueOptionFunc[K comparable, V any] func(*KeyedQueue[K, V])
+
+// KeyedQueue implements a first-in, first-out queue of key/value pairs with unique keys.
+type KeyedQueue[K comparable, V any] struct {
+	lock       *sync.RWMutex
+	entries    map[K]*entry[K, V]
+	head       *entry[K, V]
+	tail       *entry[K, V]
+	moveToBack bool
+}
+
+// entry is a single key/value pair within the queue.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *entry[K, V]
+	next  *entry[K, V]
+}
+
+// New constructs a new, empty KeyedQueue.
+func New[K comparable, V any](options ...KeyedQueueOptionFunc[K, V]) *KeyedQueue[K, V] {
+	q := &KeyedQueue[K, V]{}
+
+	for _, o := range options {
+		o(q)
+	}
+
+	if q.entries == nil {
+		q.entries = make(map[K]*entry[K, V])
+	}
+
+	return q
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[K comparable, V any]() KeyedQueueOptionFunc[K, V] {
+	return func(q *KeyedQueue[K, V]) {
+		q.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function for New to preallocate storage for the given number of keys.
+func WithCapacity[K comparable, V any](capacity int) KeyedQueueOptionFunc[K, V] {
+	if capacity < 0 {
+		panic(messages.NEGATIVE_CAPACITY)
+	}
+	return func(q *KeyedQueue[K, V]) {
+		q.entries = make(map[K]*entry[K, V], capacity)
+	}
+}
+
+// Option function for New to move an entry to the back of the queue when
+// a value is enqueued for a key that is already queued.
+//
+// By default the entry keeps its original position.
+func WithMoveToBack[K comparable, V any]() KeyedQueueOptionFunc[K, V] {
+	return func(q *KeyedQueue[K, V]) {
+		q.moveToBack = true
+	}
+}
+
+// Enqueue adds a key/value pair to the back of the queue.
+//
+// If the key is already queued, its value is replaced and the entry either keeps
+// its position or is moved to the back of the queue according to [WithMoveToBack].
+//
+// Returns true if the key was added; false if it was already queued.
+func (q *KeyedQueue[K, V]) Enqueue(key K, value V) bool {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if e, ok := q.entries[key]; ok {
+		e.value = value
+
+		if q.moveToBack && e != q.tail {
+			q.unlink(e)
+			q.link(e)
+		}
+
+		return false
+	}
+
+	e := &entry[K, V]{
+		key:   key,
+		value: value,
+	}
+
+	q.entries[key] = e
+	q.link(e)
+	return true
+}
+
+// Dequeue removes the entry at the front of the queue and returns its key and value.
+//
+// Panics if the queue is empty.
+func (q *KeyedQueue[K, V]) Dequeue() (K, V) {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if q.head == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return q.dequeue()
+}
+
+// TryDequeue removes the entry at the front of the queue and returns its key, value and true
+// if the queue is not empty; else zero values of K and V and false.
+func (q *KeyedQueue[K, V]) TryDequeue() (K, V, bool) {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if q.head == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+
+	key, value := q.dequeue()
+	return key, value, true
+}
+
+// Peek returns the key and value at the front of the queue without removing it.
+//
+// Panics if the queue is empty.
+func (q *KeyedQueue[K, V]) Peek() (K, V) {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if q.head == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return q.head.key, q.head.value
+}
+
+// TryPeek returns the key and value at the front of the queue and true
+// if the queue is not empty; else zero values of K and V and false.
+func (q *KeyedQueue[K, V]) TryPeek() (K, V, bool) {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if q.head == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+
+	return q.head.key, q.head.value, true
+}
+
+// ContainsKey returns true if the key is queued. O(1).
+func (q *KeyedQueue[K, V]) ContainsKey(key K) bool {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	_, ok := q.entries[key]
+	return ok
+}
+
+// Get returns the value queued for the key and true if the key is queued;
+// else zero value of V and false. O(1).
+func (q *KeyedQueue[K, V]) Get(key K) (V, bool) {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if e, ok := q.entries[key]; ok {
+		return e.value, true
+	}
+
+	var value V
+	return value, false
+}
+
+// Remove removes the entry for the key from the queue wherever it is positioned. O(1).
+//
+// Returns true if the key was removed; false if it was not queued.
+func (q *KeyedQueue[K, V]) Remove(key K) bool {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	e, ok := q.entries[key]
+
+	if !ok {
+		return false
+	}
+
+	delete(q.entries, key)
+	q.unlink(e)
+	return true
+}
+
+// Clear removes all entries from the queue.
+func (q *KeyedQueue[K, V]) Clear() {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	q.entries = make(map[K]*entry[K, V])
+	q.head = nil
+	q.tail = nil
+}
+
+// Count returns the number of entries in the queue.
+func (q *KeyedQueue[K, V]) Count() int {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	return len(q.entries)
+}
+
+// IsEmpty returns true if the queue has no entries.
+func (q *KeyedQueue[K, V]) IsEmpty() bool {
+	return q.Count() == 0
+}
+
+// Keys returns the queued keys as a slice, ordered from front to back of the queue.
+func (q *KeyedQueue[K, V]) Keys() []K {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	keys := make([]K, 0, len(q.entries))
+
+	for e := q.head; e != nil; e = e.next {
+		keys = append(keys, e.key)
+	}
+
+	return keys
+}
+
+// Walk calls the action delegate for each entry from front to back of the queue.
+// If the action delegate returns false, stop the walk.
+//
+// Returns true if all entries have been visited.
+// Otherwise returns false.
+func (q *KeyedQueue[K, V]) Walk(action func(K, V) bool) bool {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	for e := q.head; e != nil; e = e.next {
+		if !action(e.key, e.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns a string representation of container.
+func (q *KeyedQueue[K, V]) String() string {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	var values []string
+	for e := q.head; e != nil; e = e.next {
+		values = append(values, fmt.Sprintf("%v: %v", e.key, e.value))
+	}
+
+	return "KeyedQueue\n" + strings.Join(values, ", ")
+}
+
+func (q *KeyedQueue[K, V]) dequeue() (K, V) {
+	e := q.head
+	delete(q.entries, e.key)
+	q.unlink(e)
+	return e.key, e.value
+}
+
+// link adds an entry to the back of the queue.
+func (q *KeyedQueue[K, V]) link(e *entry[K, V]) {
+	e.prev = q.tail
+	e.next = nil
+
+	if q.tail == nil {
+		q.head = e
+	} else {
+		q.tail.next = e
+	}
+
+	q.tail = e
+}
+
+// unlink removes an entry from the queue.
+func (q *KeyedQueue[K, V]) unlink(e *entry[K, V]) {
+	if e.prev == nil {
+		q.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+
+	if e.next == nil {
+		q.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+
+	e.prev = nil
+	e.next = nil
+}
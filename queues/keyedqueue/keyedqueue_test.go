@@ -0,0 +1,163 @@
+package keyedqueue
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueueDequeue(t *testing.T) {
+
+	t.Run("Entries are dequeued in order", func(t *testing.T) {
+		q := New[string, int]()
+		require.True(t, q.Enqueue("a", 1))
+		require.True(t, q.Enqueue("b", 2))
+		require.True(t, q.Enqueue("c", 3))
+		require.Equal(t, 3, q.Count())
+
+		for _, expected := range []string{"a", "b", "c"} {
+			key, _ := q.Dequeue()
+			require.Equal(t, expected, key)
+		}
+
+		require.True(t, q.IsEmpty())
+	})
+
+	t.Run("Coalesced entry keeps position by default", func(t *testing.T) {
+		q := New[string, int]()
+		q.Enqueue("a", 1)
+		q.Enqueue("b", 2)
+		require.False(t, q.Enqueue("a", 10))
+
+		require.Equal(t, 2, q.Count())
+		require.Equal(t, []string{"a", "b"}, q.Keys())
+		key, value := q.Dequeue()
+		require.Equal(t, "a", key)
+		require.Equal(t, 10, value)
+	})
+
+	t.Run("Coalesced entry moves to back with option", func(t *testing.T) {
+		q := New(WithMoveToBack[string, int]())
+		q.Enqueue("a", 1)
+		q.Enqueue("b", 2)
+		q.Enqueue("c", 3)
+		require.False(t, q.Enqueue("a", 10))
+		require.False(t, q.Enqueue("c", 30))
+
+		require.Equal(t, []string{"b", "a", "c"}, q.Keys())
+		q.Walk(func(k string, v int) bool {
+			if k == "a" {
+				require.Equal(t, 10, v)
+			}
+			return true
+		})
+	})
+
+	t.Run("Dequeue empty queue panics", func(t *testing.T) {
+		q := New[string, int]()
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { q.Dequeue() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { q.Peek() })
+	})
+
+	t.Run("Try operations on empty queue return false", func(t *testing.T) {
+		q := New[string, int]()
+		_, _, ok := q.TryDequeue()
+		require.False(t, ok)
+		_, _, ok = q.TryPeek()
+		require.False(t, ok)
+	})
+
+	t.Run("Try operations return front entry", func(t *testing.T) {
+		q := New[string, int]()
+		q.Enqueue("a", 1)
+		key, value, ok := q.TryPeek()
+		require.True(t, ok)
+		require.Equal(t, "a", key)
+		require.Equal(t, 1, value)
+		key, value, ok = q.TryDequeue()
+		require.True(t, ok)
+		require.Equal(t, "a", key)
+		require.Equal(t, 1, value)
+		require.False(t, q.ContainsKey("a"))
+	})
+}
+
+func TestKeys(t *testing.T) {
+
+	t.Run("ContainsKey and Get", func(t *testing.T) {
+		q := New[string, int]()
+		q.Enqueue("a", 1)
+		require.True(t, q.ContainsKey("a"))
+		require.False(t, q.ContainsKey("b"))
+
+		v, ok := q.Get("a")
+		require.True(t, ok)
+		require.Equal(t, 1, v)
+		_, ok = q.Get("b")
+		require.False(t, ok)
+	})
+
+	t.Run("Remove from any position", func(t *testing.T) {
+		q := New[string, int]()
+		q.Enqueue("a", 1)
+		q.Enqueue("b", 2)
+		q.Enqueue("c", 3)
+
+		require.True(t, q.Remove("b"))
+		require.False(t, q.Remove("b"))
+		require.Equal(t, []string{"a", "c"}, q.Keys())
+		require.True(t, q.Remove("c"))
+		require.True(t, q.Remove("a"))
+		require.True(t, q.IsEmpty())
+
+		q.Enqueue("d", 4)
+		require.Equal(t, []string{"d"}, q.Keys())
+	})
+
+	t.Run("Clear empties the queue", func(t *testing.T) {
+		q := New(WithCapacity[string, int](10))
+		q.Enqueue("a", 1)
+		q.Clear()
+		require.True(t, q.IsEmpty())
+		require.False(t, q.ContainsKey("a"))
+		_, _, ok := q.TryPeek()
+		require.False(t, ok)
+	})
+
+	t.Run("Walk stops when action returns false", func(t *testing.T) {
+		q := New[string, int]()
+		q.Enqueue("a", 1)
+		q.Enqueue("b", 2)
+		visited := 0
+		require.False(t, q.Walk(func(string, int) bool { visited++; return false }))
+		require.Equal(t, 1, visited)
+		require.True(t, q.Walk(func(string, int) bool { return true }))
+	})
+
+	t.Run("Negative capacity panics", func(t *testing.T) {
+		require.PanicsWithValue(t, messages.NEGATIVE_CAPACITY, func() { WithCapacity[string, int](-1) })
+	})
+}
+
+func TestThreadSafety(t *testing.T) {
+
+	q := New(WithThreadSafe[int, int]())
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 1000; k++ {
+				q.Enqueue(k%100, k)
+			}
+		}()
+	}
+
+	wg.Wait()
+	require.Equal(t, 100, q.Count())
+	require.True(t, strings.HasPrefix(q.String(), "KeyedQueue\n0: "))
+}
@@ -0,0 +1,20 @@
+package priorityfair
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Clone returns a new PriorityFair holding the values of this queue, copied by value.
+// The result is that of Select with a predicate that is always true.
+func (pf *PriorityFair[T]) Clone() collections.Collection[T] {
+
+	return pf.Select(util.DefaultPredicate[T])
+}
+
+// CloneDeep returns a new PriorityFair holding the values of this queue, copied using the provided
+// [functions.DeepCopyFunc] if any. The result is that of SelectDeep with a predicate that is always true.
+func (pf *PriorityFair[T]) CloneDeep() collections.Collection[T] {
+
+	return pf.SelectDeep(util.DefaultPredicate[T])
+}
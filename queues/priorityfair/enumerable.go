@@ -0,0 +1,305 @@
+package priorityfair
+
+import (
+	"errors"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Enumerable[int] = (*PriorityFair[int])(nil)
+
+// Any returns true for the first element found where the predicate function returns true.
+// It returns false if no element matches the predicate.
+func (pf *PriorityFair[T]) Any(predicate functions.PredicateFunc[T]) bool {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return newForwardIterator[T](pf, predicate).Start() != nil
+}
+
+// All applies the predicate function to every element in the collection,
+// and returns true if all elements match the predicate.
+func (pf *PriorityFair[T]) All(predicate functions.PredicateFunc[T]) bool {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return newForwardIterator[T](pf, func(v T) bool { return !predicate(v) }).Start() == nil
+}
+
+// ForEach applies function f to all elements in the collection, in the order of Iterator.
+func (pf *PriorityFair[T]) ForEach(f func(collections.Element[T])) {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	iter := newForwardIterator[T](pf, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		f(e)
+	}
+}
+
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (pf *PriorityFair[T]) TryForEach(f func(T) error) error {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](pf, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (pf *PriorityFair[T]) TryForEachAll(f func(T) error) error {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	var errs []error
+	iter := newForwardIterator[T](pf, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Map applies function f to all elements in the collection
+// and returns a new PriorityFair containing the result of f.
+// Each result is enqueued at the level given by the priority function for the result.
+func (pf *PriorityFair[T]) Map(f func(T) T) collections.Collection[T] {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	pf1 := pf.makeEmptyCopy()
+
+	for _, v := range pf.toSlice(false) {
+		pf1.enqueue(f(v))
+	}
+
+	return pf1
+}
+
+// Select returns a new PriorityFair containing only the items for which predicate is true.
+func (pf *PriorityFair[T]) Select(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return pf.doSelect(predicate, false)
+}
+
+// SelectDeep returns a new PriorityFair containing only the items for which predicate is true
+//
+// Elements are deep copied to the new collection using the provided [functions.DeepCopyFunc] if any.
+func (pf *PriorityFair[T]) SelectDeep(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return pf.doSelect(predicate, true)
+}
+
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (pf *PriorityFair[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(pf.copyWhere(predicate))
+}
+
+// Find finds the first occurrence of an element matching the predicate, in the order of Iterator.
+//
+// The function returns nil if no match.
+func (pf *PriorityFair[T]) Find(predicate functions.PredicateFunc[T]) collections.Element[T] {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return newForwardIterator[T](pf, predicate).Start()
+}
+
+// FindAll finds all occurrences of an element matching the predicate.
+//
+// The function returns an empty slice if none match.
+func (pf *PriorityFair[T]) FindAll(predicate functions.PredicateFunc[T]) []collections.Element[T] {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](pf, predicate)
+	result := make([]collections.Element[T], 0, util.DefaultCapacity)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		result = append(result, e)
+	}
+
+	return result
+}
+
+// Min returns the minimum value in the collection according to the Comparer function.
+func (pf *PriorityFair[T]) Min() T {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	if pf.size == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return util.Min(pf.toSlice(false), pf.compare, false)
+}
+
+// Max returns the maximum value in the collection according to the Comparer function.
+func (pf *PriorityFair[T]) Max() T {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	if pf.size == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return util.Max(pf.toSlice(false), pf.compare, false)
+}
+
+// doSelect returns a new queue holding the values for which predicate is true, at the same levels.
+func (pf *PriorityFair[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
+
+	pf1 := pf.makeEmptyCopy()
+
+	for i := range pf.levels {
+		for _, e := range pf.levels[i].entries[pf.levels[i].head:] {
+			if !predicate(e.value) {
+				continue
+			}
+
+			if deepCopy {
+				e.value = pf.copy(e.value)
+			}
+
+			pf1.levels[i].push(e)
+			pf1.size++
+		}
+	}
+
+	return pf1
+}
+
+func (pf *PriorityFair[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+	iter := newForwardIterator[T](pf, predicate)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, util.DeepCopy(e.Value(), pf.copy))
+	}
+
+	return values
+}
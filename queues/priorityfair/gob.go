@@ -0,0 +1,125 @@
+package priorityfair
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// gobLevel is the encoded form of a level, holding its values in FIFO order
+// with the times at which they were enqueued.
+type gobLevel[T any] struct {
+	Values []T
+	Since  []time.Time
+}
+
+// GobEncode implements gob.GobEncoder, encoding the values of each level of the queue in FIFO order
+// with the times at which they were enqueued, so that the queue may be persisted or sent over RPC
+// with encoding/gob. The values must themselves be encodable by encoding/gob.
+// The values follow a header describing the [schema] of the queue, including its number of levels,
+// but its other options, including its weights, are not encoded.
+func (pf *PriorityFair[T]) GobEncode() ([]byte, error) {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	pf.lazyInit()
+
+	levels := make([]gobLevel[T], len(pf.levels))
+
+	for i := range pf.levels {
+		entries := pf.levels[i].entries[pf.levels[i].head:]
+		levels[i] = gobLevel[T]{
+			Values: make([]T, len(entries)),
+			Since:  make([]time.Time, len(entries)),
+		}
+
+		for j, e := range entries {
+			levels[i].Values[j] = e.value
+			levels[i].Since[j] = e.since
+		}
+	}
+
+	return util.GobEncode(pf.schema(), levels)
+}
+
+// GobDecode implements gob.GobDecoder, replacing the values of the queue with those encoded by GobEncode.
+// Each value is restored to the level from which it was encoded, with the time at which it was enqueued,
+// without calling the priority function.
+//
+// As the zero value cannot be used, decode into a queue constructed with New with the same number of levels
+// as that encoded. Returns an error if the number of levels differs.
+//
+// Panics if the queue has been closed.
+func (pf *PriorityFair[T]) GobDecode(data []byte) error {
+
+	var levels []gobLevel[T]
+
+	if err := util.GobDecode(data, &levels, pf.schema(), pf.verifySchema); err != nil {
+		return err
+	}
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	if pf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	if len(levels) != len(pf.levels) {
+		return fmt.Errorf(messages.DECODE_LEVELS_FMT, len(levels), len(pf.levels))
+	}
+
+	for _, l := range levels {
+		if len(l.Since) != len(l.Values) {
+			return fmt.Errorf(messages.DECODE_INVALID_FMT, "PriorityFair")
+		}
+	}
+
+	pf.clear()
+
+	for i, l := range levels {
+		for j, v := range l.Values {
+			pf.levels[i].push(entry[T]{value: v, since: l.Since[j]})
+			pf.recorder.Add(v)
+		}
+
+		pf.size += len(l.Values)
+	}
+
+	return nil
+}
+
+// Option function for New to verify the schema header of data decoded by GobDecode,
+// which then returns an error wrapping [schema.ErrMismatch] if the data was encoded
+// from another type of collection or value, or with a different number of levels,
+// or [schema.ErrMissing] if it has no header.
+func WithSchemaValidation[T any]() PriorityFairOptionFunc[T] {
+	return func(pf *PriorityFair[T]) {
+		pf.verifySchema = true
+	}
+}
+
+// schema returns the schema of the queue, which names its number of levels.
+func (pf *PriorityFair[T]) schema() schema.Schema {
+	return schema.Of[T](collections.COLLECTION_PRIORITYFAIR, map[string]string{"levels": strconv.Itoa(len(pf.levels))})
+}
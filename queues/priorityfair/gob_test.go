@@ -0,0 +1,60 @@
+package priorityfair
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func modThree(v int) int {
+	return v % 3
+}
+
+func TestGob(t *testing.T) {
+
+	t.Run("Round trip preserves levels, order and ages", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := functions.ClockFunc(func() time.Time { return now })
+		options := []PriorityFairOptionFunc[int]{WithAging[int](time.Minute), WithClock[int](clock)}
+
+		pf := New(3, modThree, options...)
+		pf.AddRange([]int{2, 4, 1, 3, 5})
+
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(pf))
+
+		decoded := New(3, modThree, options...)
+		require.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+		require.Equal(t, pf.ToSlice(), decoded.ToSlice())
+
+		for i := 0; i < 3; i++ {
+			require.Equal(t, pf.CountAt(i), decoded.CountAt(i))
+		}
+
+		now = now.Add(time.Hour)
+
+		for !pf.IsEmpty() {
+			require.Equal(t, pf.Dequeue(), decoded.Dequeue())
+		}
+
+		require.Equal(t, pf.Promotions(), decoded.Promotions())
+	})
+
+	t.Run("Levels must match", func(t *testing.T) {
+		data, err := New(3, modThree).GobEncode()
+		require.NoError(t, err)
+
+		require.EqualError(t, New(2, modThree).GobDecode(data), fmt.Sprintf(messages.DECODE_LEVELS_FMT, 3, 2))
+
+		var mismatch *schema.MismatchError
+		require.ErrorAs(t, New(2, modThree, WithSchemaValidation[int]()).GobDecode(data), &mismatch)
+		require.Equal(t, "option levels", mismatch.Field)
+	})
+}
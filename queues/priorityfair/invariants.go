@@ -0,0 +1,19 @@
+package priorityfair
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the queue is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (pf *PriorityFair[T]) verifyInvariants() {
+	util.AssertInvariant(len(pf.weights) == len(pf.levels) && len(pf.current) == len(pf.levels), "queue weights do not match levels")
+
+	count := 0
+
+	for i := range pf.levels {
+		util.AssertInvariant(pf.weights[i] >= 1, "queue level weight less than 1")
+		util.AssertInvariant(pf.levels[i].head >= 0 && pf.levels[i].head <= len(pf.levels[i].entries), "queue level head outside level")
+		count += pf.levels[i].count()
+	}
+
+	util.AssertInvariant(count == pf.size, "queue size does not match levels")
+}
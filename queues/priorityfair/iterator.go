@@ -0,0 +1,137 @@
+package priorityfair
+
+import (
+	"math/rand"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Iterable[int] = (*PriorityFair[int])(nil)
+
+// PriorityFairIterator implements an iterator over the elements in the queue.
+type PriorityFairIterator[T any] struct {
+	util.IteratorBase[T]
+	queue     *PriorityFair[T]
+	level     int
+	index     int
+	predicate functions.PredicateFunc[T]
+
+	local.InternalImpl
+}
+
+func newForwardIterator[T any](pf *PriorityFair[T], predicate functions.PredicateFunc[T]) collections.Iterator[T] {
+	return &PriorityFairIterator[T]{
+		queue:     pf,
+		predicate: predicate,
+		IteratorBase: util.IteratorBase[T]{
+			Version:    pf.version,
+			NilElement: nil,
+		},
+	}
+}
+
+// Iterator returns an iterator that walks the queue in order of level from highest priority,
+// and in FIFO order within each level.
+//
+//	iter := pf.Iterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (pf *PriorityFair[T]) Iterator() collections.Iterator[T] {
+
+	return newForwardIterator(pf, util.DefaultPredicate[T])
+}
+
+// TakeWhile returns an iterater that walks the collection as Iterator, returning only
+// those elements for which predicate returns true.
+func (pf *PriorityFair[T]) TakeWhile(predicate functions.PredicateFunc[T]) collections.Iterator[T] {
+
+	return newForwardIterator(pf, predicate)
+}
+
+// SampleIterator returns an iterator that yields n values chosen at random from the queue,
+// without replacement and in random order. If the queue holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the queue.
+// If r is nil, the default source of the math/rand package is used.
+//
+// Panics if n is negative.
+func (pf *PriorityFair[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](pf, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the queue at the time of the call,
+// in the same order as ToSlice, so that the queue may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the queue's immutable slice, so it is only copied if the queue has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the queue, and their ValuePtr method panics.
+//
+//	iter := pf.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		pf.Remove(e.Value())
+//	}
+func (pf *PriorityFair[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(pf.ToImmutableSlice())
+}
+
+// Start begins an iteration across the queue returning the first element,
+// which will be nil if the collection is empty.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *PriorityFairIterator[T]) Start() collections.Element[T] {
+	i.validateIterator()
+
+	if i.queue.size == 0 {
+		return i.NilElement
+	}
+
+	i.level = 0
+	i.index = i.queue.levels[0].head - 1
+	return i.Next()
+}
+
+// Next returns the next element in the collection,
+// which will be nil if the end has been reached.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *PriorityFairIterator[T]) Next() collections.Element[T] {
+	i.validateIterator()
+
+	for i.level < len(i.queue.levels) {
+		lv := &i.queue.levels[i.level]
+		i.index++
+
+		if i.index >= len(lv.entries) {
+			i.level++
+
+			if i.level < len(i.queue.levels) {
+				i.index = i.queue.levels[i.level].head - 1
+			}
+
+			continue
+		}
+
+		valPtr := &(lv.entries[i.index].value)
+
+		if i.predicate(*valPtr) {
+			return util.NewElementType[T](i.queue, valPtr, i.queue.compare)
+		}
+	}
+
+	return i.NilElement
+}
+
+func (i *PriorityFairIterator[T]) validateIterator() {
+	if i.Version != i.queue.version {
+		panic(messages.COLLECTION_MODIFIED)
+	}
+}
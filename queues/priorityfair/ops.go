@@ -0,0 +1,98 @@
+package priorityfair
+
+import (
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// ApplyOps applies the given operations to the queue in order.
+// Add enqueues the value at the level given by the priority function, Remove removes
+// the first occurrence of the value searching from the highest priority level, and Clear empties the queue.
+//
+// Panics if an op has an invalid kind, or an Add op is applied to a closed queue.
+func (pf *PriorityFair[T]) ApplyOps(operations []ops.Op[T]) {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	util.ApplyOps(
+		operations,
+		func(value T) {
+			if pf.closed {
+				panic(messages.COLLECTION_CLOSED)
+			}
+			if pf.validator.Accept(value) {
+				pf.enqueue(value)
+			}
+		},
+		func(value T) {
+			if pf.size > 0 {
+				pf.remove(value)
+			}
+		},
+		pf.clear,
+	)
+}
+
+// StartRecording begins capturing mutations of the queue as ops,
+// discarding any ops previously recorded.
+//
+// Dequeued values are recorded as a Remove of the value. Promotions by aging are not recorded,
+// as a queue to which the ops are applied ages its own values. Sorting the queue
+// is recorded as a Clear followed by an Add of each value.
+func (pf *PriorityFair[T]) StartRecording() {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	pf.recorder.Start(pf.copy)
+}
+
+// StopRecording ends recording and returns the ops recorded since StartRecording.
+func (pf *PriorityFair[T]) StopRecording() []ops.Op[T] {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	return pf.recorder.Stop()
+}
+
+// IsRecording returns true if the queue is recording mutations.
+func (pf *PriorityFair[T]) IsRecording() bool {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return pf.recorder.IsRecording()
+}
@@ -0,0 +1,992 @@
+/*
+Package priorityfair provides a queue with a fixed number of discrete priority levels that
+does not starve its lower priority levels.
+
+Each value is enqueued at the level returned for it by the queue's priority function, where
+level 0 is the highest priority. Within a level, values are dequeued first-in, first-out.
+Between levels, Dequeue chooses by smooth weighted round-robin, so that whilst every level
+has values, each level is served in proportion to its weight. By default the weight of each
+level is the number of levels below it plus one, e.g. 3, 2, 1 for three levels.
+
+Optionally, values that have waited at a level for longer than a given period are promoted
+to the next higher level (see [WithAging]), so that a value is eventually served at the
+highest priority however busy the levels above it.
+*/
+package priorityfair
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/queues"
+	"github.com/fireflycons/generic_collections/registry"
+	"golang.org/x/exp/constraints"
+)
+
+// Assert PriorityFair implements required interfaces.
+var _ queues.Queue[int] = (*PriorityFair[int])(nil)
+
+// PriorityFairOptionFunc is the signature of a function
+// for providing options to the PriorityFair constructor.
+type PriorityFairOptionFunc[T any] func(*PriorityFair[T])
+
+// PriorityFair implements a multi-level priority queue with weighted dequeue and aging.
+//
+// The zero value cannot be used, as it has no priority levels. Construct it with New.
+type PriorityFair[T any] struct {
+	version         int
+	lock            *sync.RWMutex
+	levels          []level[T]
+	size            int
+	priority        func(T) int
+	weights         []int
+	current         []int
+	aging           time.Duration
+	clock           functions.Clock
+	promotions      int
+	initialCapacity int
+	compare         functions.ComparerFunc[T]
+	copy            functions.DeepCopyFunc[T]
+	copyPolicy      collections.CopyPolicy
+	closed          bool
+	recorder        util.OpRecorder[T]
+	guard           util.AccessGuard
+	immutable       util.SliceCache[T]
+	validator       *util.Validator[T]
+	verifySchema    bool
+	name            string
+	registration    *registry.Registration
+
+	local.InternalImpl
+}
+
+// level is the FIFO queue of values at one priority.
+type level[T any] struct {
+	entries []entry[T]
+	head    int
+}
+
+// entry is a single value within a level.
+type entry[T any] struct {
+	value T
+	since time.Time
+}
+
+// New creates a new queue with the given number of priority levels.
+// The priority function returns the level of each enqueued value, from 0 (highest) to levels-1 (lowest).
+//
+// Panics if levels is less than 1 or priority is nil.
+func New[T any](levels int, priority func(T) int, options ...PriorityFairOptionFunc[T]) *PriorityFair[T] {
+	if levels < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "levels"))
+	}
+
+	if priority == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "priority"))
+	}
+
+	pf := &PriorityFair[T]{
+		levels:          make([]level[T], levels),
+		priority:        priority,
+		weights:         make([]int, levels),
+		current:         make([]int, levels),
+		clock:           functions.SystemClock,
+		initialCapacity: util.DefaultCapacity,
+	}
+
+	for i := range pf.weights {
+		pf.weights[i] = levels - i
+	}
+
+	for _, o := range options {
+		o(pf)
+	}
+
+	if len(pf.weights) != levels {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "weights"))
+	}
+
+	if pf.copy == nil || pf.copyPolicy == collections.Shallow {
+		pf.copy = util.DefaultDeepCopy[T]
+	}
+
+	for i := range pf.levels {
+		pf.levels[i].entries = make([]entry[T], 0, pf.initialCapacity)
+	}
+
+	if pf.compare == nil {
+		pf.compare = util.GetDefaultComparer[T]()
+	}
+
+	pf.register()
+
+	return pf
+}
+
+// lazyInit is called on modification of a PriorityFair.
+// A zero value PriorityFair cannot be used, as it has no priority levels
+// and no means of determining the priority of a value.
+func (pf *PriorityFair[T]) lazyInit() {
+	if pf.levels == nil {
+		panic(fmt.Sprintf(messages.ZERO_VALUE_UNUSABLE_FMT, "PriorityFair"))
+	}
+}
+
+// NewFunc is as [New], using compare to find values, e.g. in Contains and Sort.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](levels int, priority func(T) int, compare functions.ComparerFunc[T], options ...PriorityFairOptionFunc[T]) *PriorityFair[T] {
+	return New(levels, priority, append([]PriorityFairOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](levels int, priority func(T) int, options ...PriorityFairOptionFunc[T]) *PriorityFair[T] {
+	return NewFunc(levels, priority, util.GetOrderedComparer[T](), options...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[T any]() PriorityFairOptionFunc[T] {
+	return func(pf *PriorityFair[T]) {
+		pf.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function to set the initial capacity of each level to
+// something other than the default 16 elements.
+func WithCapacity[T any](capacity int) PriorityFairOptionFunc[T] {
+	if capacity < 0 {
+		panic(messages.NEGATIVE_CAPACITY)
+	}
+	return func(pf *PriorityFair[T]) {
+		pf.initialCapacity = capacity
+	}
+}
+
+// Option function to provide a comparer function for values of type T.
+// Required if the element type is not a supported type.
+func WithComparer[T any](comparer functions.ComparerFunc[T]) PriorityFairOptionFunc[T] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(pf *PriorityFair[T]) {
+		pf.compare = comparer
+	}
+}
+
+// Option func to provide a deep copy implementation for collection elements.
+func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) PriorityFairOptionFunc[T] {
+	// Can be nil
+	return func(pf *PriorityFair[T]) {
+		pf.copy = copier
+	}
+}
+
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) PriorityFairOptionFunc[T] {
+	return func(pf *PriorityFair[T]) {
+		pf.copyPolicy = policy
+	}
+}
+
+// Option function for New to set the weight of each level, highest priority first.
+// Whilst every level has values, each level is served in proportion to its weight.
+//
+// Panics if any weight is less than 1. New panics if the number of weights is not the number of levels.
+func WithWeights[T any](weights ...int) PriorityFairOptionFunc[T] {
+	validateWeights(weights)
+	return func(pf *PriorityFair[T]) {
+		pf.weights = append([]int{}, weights...)
+	}
+}
+
+// Option function for New to promote a value to the next higher level once it has waited
+// at its level for the given period. The waiting time restarts at each promotion.
+// Values are promoted as the queue is dequeued.
+//
+// Panics if period is not positive.
+func WithAging[T any](period time.Duration) PriorityFairOptionFunc[T] {
+	if period <= 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "period"))
+	}
+	return func(pf *PriorityFair[T]) {
+		pf.aging = period
+	}
+}
+
+// Option function for New to provide the [functions.Clock] used to age values.
+// The default is [functions.SystemClock].
+//
+// Panics if clock is nil.
+func WithClock[T any](clock functions.Clock) PriorityFairOptionFunc[T] {
+	if clock == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "clock"))
+	}
+	return func(pf *PriorityFair[T]) {
+		pf.clock = clock
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+//
+// Concurrent is ignored by this collection.
+func WithOptions[T any](options collections.CommonOptions[T]) PriorityFairOptionFunc[T] {
+	opts := make([]PriorityFairOptionFunc[T], 0, 5)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Capacity != 0 {
+		opts = append(opts, WithCapacity[T](options.Capacity))
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(pf *PriorityFair[T]) {
+		for _, o := range opts {
+			o(pf)
+		}
+	}
+}
+
+// SetWeights replaces the weight of each level, highest priority first,
+// and restarts the round-robin between levels.
+//
+// Panics if the number of weights is not the number of levels, or any weight is less than 1.
+func (pf *PriorityFair[T]) SetWeights(weights ...int) {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	if len(weights) != len(pf.levels) {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "weights"))
+	}
+
+	validateWeights(weights)
+	copy(pf.weights, weights)
+
+	for i := range pf.current {
+		pf.current[i] = 0
+	}
+}
+
+// Weights returns a copy of the weight of each level, highest priority first.
+func (pf *PriorityFair[T]) Weights() []int {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return append([]int{}, pf.weights...)
+}
+
+// Levels returns the number of priority levels.
+func (pf *PriorityFair[T]) Levels() int {
+	return len(pf.levels)
+}
+
+// CountAt returns the number of values waiting at the given level.
+//
+// Panics if level is out of range.
+func (pf *PriorityFair[T]) CountAt(level int) int {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	util.ValidateIndex(level, len(pf.levels))
+	return pf.levels[level].count()
+}
+
+// Promotions returns the number of times a value has been promoted by aging.
+func (pf *PriorityFair[T]) Promotions() int {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return pf.promotions
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection,
+// ReplaceAll and Enqueue with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) PriorityFairOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(pf *PriorityFair[T]) {
+		pf.validator = util.NewValidator(validator, policy)
+	}
+}
+
+// Add enqueues a value at the level given by the priority function.
+//
+// Returns true unless the queue has been closed or the value was rejected by the validator.
+//
+// Panics if the priority function returns a level out of range.
+func (pf *PriorityFair[T]) Add(value T) bool {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	if pf.closed || !pf.validator.Accept(value) {
+		return false
+	}
+
+	pf.enqueue(value)
+	return true
+}
+
+// AddCollection enqueues the values of the given collection, in the order defined by the other collection.
+func (pf *PriorityFair[T]) AddCollection(collection collections.Collection[T]) {
+
+	pf.AddRange(util.ImportValues(collection, pf.copyPolicy))
+}
+
+// ReplaceAll replaces the content of the queue with the values of the given collection.
+// Values are enqueued in the order defined by the other collection.
+//
+// Panics if the queue has been closed.
+func (pf *PriorityFair[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	values := util.ImportValues(collection, pf.copyPolicy)
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	if pf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	values = pf.validator.Filter(values)
+	pf.clear()
+
+	for _, v := range values {
+		pf.enqueue(v)
+	}
+}
+
+// AddRange enqueues the values in the given slice.
+//
+// Panics if the queue has been closed.
+func (pf *PriorityFair[T]) AddRange(values []T) {
+
+	if len(values) == 0 {
+		return
+	}
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	if pf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	for _, v := range pf.validator.Filter(values) {
+		pf.enqueue(v)
+	}
+}
+
+// Clear removes all values from the queue.
+func (pf *PriorityFair[T]) Clear() {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	pf.clear()
+}
+
+// Contains returns true if the given value is in the queue; else false.
+func (pf *PriorityFair[T]) Contains(value T) bool {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	l, _ := pf.find(value)
+	return l != -1
+}
+
+// Count returns the number of elements in the queue.
+func (pf *PriorityFair[T]) Count() int {
+	return pf.size
+}
+
+// IsEmpty returns true if the collection has no elements.
+func (pf *PriorityFair[T]) IsEmpty() bool {
+	return pf.size == 0
+}
+
+// Dequeue promotes any values that have waited long enough, then removes and returns
+// the value at the front of the next level to be served.
+//
+// Panics if the queue is empty, or if the queue is closed and has been drained.
+func (pf *PriorityFair[T]) Dequeue() T {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	if pf.size == 0 {
+		if pf.closed {
+			panic(messages.COLLECTION_CLOSED)
+		}
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return pf.dequeue()
+}
+
+// TryDequeue is as Dequeue, returning the value and true if
+// the queue is not empty; else zero value of T and false.
+func (pf *PriorityFair[T]) TryDequeue() (T, bool) {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	if pf.size == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return pf.dequeue(), true
+}
+
+// Enqueue adds a value to the back of the level given by the priority function.
+//
+// Panics if the queue has been closed, or the priority function returns a level out of range.
+func (pf *PriorityFair[T]) Enqueue(value T) {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	if pf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	if pf.validator.Accept(value) {
+		pf.enqueue(value)
+	}
+}
+
+// Close marks the queue as closed. No further values may be enqueued,
+// however values already in the queue may still be dequeued.
+//
+// Once the queue is drained, Dequeue panics indicating closure
+// and TryDequeue returns false.
+func (pf *PriorityFair[T]) Close() {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	pf.closed = true
+}
+
+// IsClosed returns true if the queue has been closed.
+func (pf *PriorityFair[T]) IsClosed() bool {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return pf.closed
+}
+
+// Peek returns the value that the next call to Dequeue will return, without removing it,
+// provided no values are promoted by aging in the meantime.
+//
+// Panics if the queue is empty.
+func (pf *PriorityFair[T]) Peek() T {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	if pf.size == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return pf.peek()
+}
+
+// TryPeek is as Peek, returning the value and true if
+// the queue is not empty; else zero value of T and false.
+func (pf *PriorityFair[T]) TryPeek() (T, bool) {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	if pf.size == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return pf.peek(), true
+}
+
+// Remove removes the first occurrence of the given value from the queue,
+// searching from the front of the highest priority level.
+//
+// Returns true if the value was present and was removed; else false.
+func (pf *PriorityFair[T]) Remove(value T) bool {
+
+	if pf.size == 0 {
+		return false
+	}
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	return pf.remove(value)
+}
+
+// ToSlice returns a copy of the queue content as a slice, in order of level
+// from highest priority, and in FIFO order within each level.
+func (pf *PriorityFair[T]) ToSlice() []T {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return pf.toSlice(false)
+}
+
+// ToImmutableSlice returns the content of the queue as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the queue has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified. Values modified in place, e.g. via [collections.Element.ValuePtr],
+// are not reflected until the queue is otherwise modified.
+func (pf *PriorityFair[T]) ToImmutableSlice() []T {
+
+	if pf.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		pf.lock.RLock()
+		values, ok := pf.immutable.Load(pf.version)
+		pf.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	return pf.immutable.Get(pf.version, func() []T { return pf.toSlice(false) })
+}
+
+// ToSliceDeep returns a copy of the queue content as a slice, in the same order as ToSlice,
+// using the provided [functions.DeepCopyFunc] if any.
+func (pf *PriorityFair[T]) ToSliceDeep() []T {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return pf.toSlice(true)
+}
+
+// Type returns the type of this collection.
+func (*PriorityFair[T]) Type() collections.CollectionType {
+	return collections.COLLECTION_PRIORITYFAIR
+}
+
+// String returns a string representation of container.
+func (pf *PriorityFair[T]) String() string {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	var values []string
+	for _, value := range pf.toSlice(false) {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+
+	return "PriorityFair\n" + strings.Join(values, ", ")
+}
+
+func (pf *PriorityFair[T]) toSlice(deepCopy bool) []T {
+	slc := make([]T, 0, pf.size)
+
+	for i := range pf.levels {
+		for _, e := range pf.levels[i].entries[pf.levels[i].head:] {
+			if deepCopy {
+				e.value = util.DeepCopy(e.value, pf.copy)
+			}
+
+			slc = append(slc, e.value)
+		}
+	}
+
+	return slc
+}
+
+// find returns the level and index within the level of the first occurrence of value; else -1, -1.
+func (pf *PriorityFair[T]) find(value T) (int, int) {
+	for l := range pf.levels {
+		lv := &pf.levels[l]
+
+		for i := lv.head; i < len(lv.entries); i++ {
+			if pf.compare(lv.entries[i].value, value) == 0 {
+				return l, i
+			}
+		}
+	}
+
+	return -1, -1
+}
+
+func (pf *PriorityFair[T]) enqueue(value T) {
+	l := pf.priority(value)
+
+	if l < 0 || l >= len(pf.levels) {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "priority"))
+	}
+
+	e := entry[T]{value: value}
+
+	if pf.aging > 0 {
+		e.since = pf.clock.Now()
+	}
+
+	pf.levels[l].push(e)
+	pf.size++
+	pf.version++
+	pf.recorder.Add(value)
+}
+
+func (pf *PriorityFair[T]) dequeue() T {
+	pf.age()
+
+	value := pf.levels[pf.next(pf.current)].pop().value
+	pf.size--
+	pf.version++
+	pf.recorder.Remove(value)
+	return value
+}
+
+func (pf *PriorityFair[T]) peek() T {
+	current := append(make([]int, 0, len(pf.current)), pf.current...)
+	lv := &pf.levels[pf.next(current)]
+	return lv.entries[lv.head].value
+}
+
+// next selects the level to serve by smooth weighted round-robin over the levels that have values,
+// updating the round-robin state in current.
+func (pf *PriorityFair[T]) next(current []int) int {
+	total := 0
+	selected := -1
+
+	for i := range pf.levels {
+		if pf.levels[i].count() == 0 {
+			continue
+		}
+
+		current[i] += pf.weights[i]
+		total += pf.weights[i]
+
+		if selected == -1 || current[i] > current[selected] {
+			selected = i
+		}
+	}
+
+	current[selected] -= total
+	return selected
+}
+
+// age promotes the values that have waited at their level for the aging period to the next higher level.
+// Levels are aged from the highest so that a value is promoted at most once per call.
+func (pf *PriorityFair[T]) age() {
+	if pf.aging <= 0 {
+		return
+	}
+
+	now := pf.clock.Now()
+
+	for l := 1; l < len(pf.levels); l++ {
+		lv := &pf.levels[l]
+
+		// Waiting times restart at each promotion, so each level is in order of waiting time.
+		for lv.count() > 0 && now.Sub(lv.entries[lv.head].since) >= pf.aging {
+			e := lv.pop()
+			e.since = now
+			pf.levels[l-1].push(e)
+			pf.promotions++
+			pf.version++
+		}
+	}
+}
+
+func (pf *PriorityFair[T]) remove(value T) bool {
+	l, i := pf.find(value)
+
+	if l == -1 {
+		return false
+	}
+
+	lv := &pf.levels[l]
+	last := len(lv.entries) - 1
+	copy(lv.entries[i:], lv.entries[i+1:])
+	lv.entries[last] = entry[T]{}
+	lv.entries = lv.entries[:last]
+	pf.size--
+	pf.version++
+	pf.recorder.Remove(value)
+	return true
+}
+
+func (pf *PriorityFair[T]) clear() {
+	for i := range pf.levels {
+		pf.levels[i] = level[T]{entries: make([]entry[T], 0, pf.initialCapacity)}
+		pf.current[i] = 0
+	}
+
+	pf.size = 0
+	pf.version++
+	pf.recorder.Clear()
+}
+
+// makeEmptyCopy returns a new, empty queue with the options of this queue.
+func (pf *PriorityFair[T]) makeEmptyCopy() *PriorityFair[T] {
+	other := &PriorityFair[T]{
+		levels:          make([]level[T], len(pf.levels)),
+		priority:        pf.priority,
+		weights:         append([]int{}, pf.weights...),
+		current:         make([]int, len(pf.levels)),
+		aging:           pf.aging,
+		clock:           pf.clock,
+		initialCapacity: pf.initialCapacity,
+		compare:         pf.compare,
+		copy:            pf.copy,
+		copyPolicy:      pf.copyPolicy,
+	}
+
+	if pf.lock != nil {
+		other.lock = &sync.RWMutex{}
+	}
+
+	for i := range other.levels {
+		other.levels[i].entries = make([]entry[T], 0, pf.initialCapacity)
+	}
+
+	return other
+}
+
+// makeDeepCopy returns a copy of this queue holding deep copies of its values at the same levels.
+func (pf *PriorityFair[T]) makeDeepCopy() *PriorityFair[T] {
+	other := pf.makeEmptyCopy()
+
+	for i := range pf.levels {
+		for _, e := range pf.levels[i].entries[pf.levels[i].head:] {
+			other.levels[i].push(entry[T]{value: util.DeepCopy(e.value, pf.copy), since: e.since})
+		}
+	}
+
+	other.size = pf.size
+	return other
+}
+
+func validateWeights(weights []int) {
+	for _, w := range weights {
+		if w < 1 {
+			panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "weights"))
+		}
+	}
+}
+
+func (lv *level[T]) count() int {
+	return len(lv.entries) - lv.head
+}
+
+func (lv *level[T]) push(e entry[T]) {
+	lv.entries = append(lv.entries, e)
+}
+
+// pop removes and returns the entry at the front of the level,
+// compacting the level once half of its slice has been dequeued.
+func (lv *level[T]) pop() entry[T] {
+	e := lv.entries[lv.head]
+	lv.entries[lv.head] = entry[T]{}
+	lv.head++
+
+	if lv.head == len(lv.entries) {
+		lv.entries = lv.entries[:0]
+		lv.head = 0
+	} else if lv.head >= util.DefaultCapacity && lv.head*2 >= len(lv.entries) {
+		n := copy(lv.entries, lv.entries[lv.head:])
+
+		for i := n; i < len(lv.entries); i++ {
+			lv.entries[i] = entry[T]{}
+		}
+
+		lv.entries = lv.entries[:n]
+		lv.head = 0
+	}
+
+	return e
+}
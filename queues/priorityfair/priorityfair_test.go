@@ -0,0 +1,247 @@
+package priorityfair
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/stretchr/testify/require"
+)
+
+type job struct {
+	name     string
+	priority int
+}
+
+func jobPriority(j job) int {
+	return j.priority
+}
+
+func compareJobs(a, b job) int {
+	switch {
+	case a.name < b.name:
+		return -1
+	case a.name > b.name:
+		return 1
+	}
+	return 0
+}
+
+func newJobs(count, priority int) []job {
+	jobs := make([]job, count)
+
+	for i := range jobs {
+		jobs[i] = job{name: fmt.Sprintf("p%d-%03d", priority, i), priority: priority}
+	}
+
+	return jobs
+}
+
+// byLevel is the priority function for tests with int values, where the level is the tens digit.
+func byLevel(v int) int {
+	return v / 10
+}
+
+func TestWeightedDequeue(t *testing.T) {
+
+	t.Run("Levels are served in proportion to weight", func(t *testing.T) {
+		pf := New(3, jobPriority, WithComparer(compareJobs))
+
+		for p := 0; p < 3; p++ {
+			pf.AddRange(newJobs(60, p))
+		}
+
+		served := make([]int, 3)
+
+		for i := 0; i < 60; i++ {
+			served[pf.Dequeue().priority]++
+		}
+
+		// Default weights are 3, 2, 1
+		require.Equal(t, []int{30, 20, 10}, served)
+	})
+
+	t.Run("Values are FIFO within a level", func(t *testing.T) {
+		pf := New(3, jobPriority, WithComparer(compareJobs))
+		jobs := newJobs(10, 1)
+		pf.AddRange(jobs)
+
+		for _, j := range jobs {
+			require.Equal(t, j, pf.Dequeue())
+		}
+	})
+
+	t.Run("Peek returns next value to be dequeued", func(t *testing.T) {
+		pf := NewOrdered(3, byLevel, WithWeights[int](1, 1, 1))
+		pf.AddRange([]int{20, 21, 10, 0, 1})
+
+		for !pf.IsEmpty() {
+			v := pf.Peek()
+			require.Equal(t, v, pf.Dequeue())
+		}
+	})
+
+	t.Run("SetWeights changes service ratio", func(t *testing.T) {
+		pf := New(2, jobPriority, WithComparer(compareJobs))
+		pf.AddRange(newJobs(40, 0))
+		pf.AddRange(newJobs(40, 1))
+		pf.SetWeights(1, 3)
+		require.Equal(t, []int{1, 3}, pf.Weights())
+
+		served := make([]int, 2)
+
+		for i := 0; i < 40; i++ {
+			served[pf.Dequeue().priority]++
+		}
+
+		require.Equal(t, []int{10, 30}, served)
+	})
+
+	t.Run("Empty levels are skipped", func(t *testing.T) {
+		pf := NewOrdered(3, byLevel)
+		pf.AddRange([]int{20, 21})
+		require.Equal(t, 20, pf.Dequeue())
+		require.Equal(t, 21, pf.Dequeue())
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		require.Panics(t, func() { New(0, byLevel) })
+		require.Panics(t, func() { New[int](1, nil) })
+		require.Panics(t, func() { New(2, byLevel, WithWeights[int](1)) })
+		require.Panics(t, func() { WithWeights[int](1, 0) })
+		require.Panics(t, func() { NewOrdered(2, byLevel).SetWeights(1, 2, 3) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "priority"), func() { NewOrdered(2, byLevel).Enqueue(30) })
+
+		var pf PriorityFair[int]
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ZERO_VALUE_UNUSABLE_FMT, "PriorityFair"), func() { pf.Add(1) })
+	})
+}
+
+func TestAging(t *testing.T) {
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := functions.ClockFunc(func() time.Time { return now })
+
+	pf := NewOrdered(3, byLevel, WithAging[int](time.Minute), WithClock[int](clock), WithWeights[int](100, 10, 1))
+	pf.Enqueue(20)
+
+	now = now.Add(30 * time.Second)
+	pf.AddRange([]int{0, 1, 2, 3})
+	require.Equal(t, 0, pf.Dequeue())
+	require.Equal(t, 1, pf.CountAt(2))
+
+	now = now.Add(30 * time.Second)
+	pf.Dequeue()
+	require.Equal(t, 0, pf.CountAt(2))
+	require.Equal(t, 1, pf.CountAt(1))
+	require.Equal(t, 1, pf.Promotions())
+
+	now = now.Add(time.Minute)
+	pf.Dequeue()
+	require.Equal(t, 0, pf.CountAt(1))
+	require.Equal(t, 2, pf.Promotions())
+	require.Contains(t, pf.ToSlice()[:pf.CountAt(0)], 20)
+}
+
+func TestClose(t *testing.T) {
+	pf := NewOrdered(2, byLevel)
+	pf.Enqueue(1)
+	pf.Close()
+
+	require.True(t, pf.IsClosed())
+	require.False(t, pf.Add(2))
+	require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { pf.Enqueue(2) })
+	require.Equal(t, 1, pf.Dequeue())
+
+	_, ok := pf.TryDequeue()
+	require.False(t, ok)
+	require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { pf.Dequeue() })
+}
+
+func TestCollection(t *testing.T) {
+	pf := NewOrdered(2, byLevel)
+	pf.AddRange([]int{12, 3, 11, 1})
+
+	require.Equal(t, []int{3, 1, 12, 11}, pf.ToSlice())
+	require.True(t, pf.Contains(11))
+	require.True(t, pf.Remove(3))
+	require.False(t, pf.Remove(3))
+	require.Equal(t, 1, pf.Min())
+	require.Equal(t, 12, pf.Max())
+
+	pf.Sort()
+	require.Equal(t, []int{1, 11, 12}, pf.ToSlice())
+	require.Equal(t, []int{1, 12, 11}, pf.SortedDescending().ToSlice())
+
+	SortByKeyDescending(pf, func(v int) int { return v % 10 })
+	require.Equal(t, []int{1, 12, 11}, pf.ToSlice())
+	SortByKey(pf, func(v int) int { return v % 10 })
+	require.Equal(t, []int{1, 11, 12}, pf.ToSlice())
+
+	selected := pf.Select(func(v int) bool { return v > 5 })
+	require.Equal(t, []int{11, 12}, selected.ToSlice())
+
+	mapped := pf.Map(func(v int) int { return v - 10 })
+	require.Equal(t, []int{-9, 1, 2}, mapped.ToSlice())
+
+	l := dlist.New[int]()
+	l.AddCollection(pf)
+	require.Equal(t, []int{1, 11, 12}, l.ToSlice())
+
+	pf.ReplaceAll(dlist.Of(15, 5))
+	require.Equal(t, []int{5, 15}, pf.ToSlice())
+}
+
+func TestRecording(t *testing.T) {
+	pf := NewOrdered(2, byLevel)
+	pf.StartRecording()
+	pf.AddRange([]int{11, 1, 2})
+	pf.Dequeue()
+	recorded := pf.StopRecording()
+
+	other := NewOrdered(2, byLevel)
+	other.ApplyOps(recorded)
+	require.Equal(t, pf.ToSlice(), other.ToSlice())
+}
+
+func TestThreadSafety(t *testing.T) {
+	pf := NewOrdered(4, func(v int) int { return v % 4 }, WithThreadSafe[int]())
+	wg := sync.WaitGroup{}
+
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				pf.Enqueue(g*100 + i)
+				pf.TryDequeue()
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	require.Equal(t, 0, pf.Count())
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(2, func(v *int) int { return *v % 2 }, WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(2, func(v *int) int { return *v % 2 }, WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+	})
+}
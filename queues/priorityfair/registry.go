@@ -0,0 +1,67 @@
+package priorityfair
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+)
+
+// Option function for New to give the queue a name, under which it is registered with the
+// [registry] so that its size, version and operation counts are included in [registry.Snapshot].
+// The queue remains registered until Dispose is called.
+//
+// Panics if name is empty.
+func WithName[T any](name string) PriorityFairOptionFunc[T] {
+	if name == "" {
+		panic(messages.NAME_EMPTY)
+	}
+	return func(pf *PriorityFair[T]) {
+		pf.name = name
+	}
+}
+
+// Dispose removes the queue from the [registry] if it was created with [WithName].
+// The queue remains usable, but no longer appears in [registry.Snapshot].
+// Calling Dispose more than once has no effect.
+func (pf *PriorityFair[T]) Dispose() {
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.registration.Deregister()
+	pf.registration = nil
+}
+
+// register registers the queue with the registry if it has been given a name.
+func (pf *PriorityFair[T]) register() {
+	if pf.name == "" {
+		return
+	}
+
+	pf.recorder.EnableCounting()
+	pf.registration = registry.Register(pf.name, pf.stats)
+}
+
+func (pf *PriorityFair[T]) stats() registry.Stats {
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	return registry.Stats{
+		Type:    collections.COLLECTION_PRIORITYFAIR,
+		Count:   pf.size,
+		Version: pf.version,
+		Ops:     pf.recorder.Counts(),
+	}
+}
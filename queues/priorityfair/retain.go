@@ -0,0 +1,68 @@
+package priorityfair
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*PriorityFair[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single pass,
+// keeping the remaining values at their levels in FIFO order, and returns the number of values removed.
+// Values keep the time at which they were enqueued, so are aged as before.
+//
+// Values may be removed from a queue that has been closed.
+func (pf *PriorityFair[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	removed := 0
+
+	for l := range pf.levels {
+		lv := &pf.levels[l]
+		kept := lv.head
+
+		for i := lv.head; i < len(lv.entries); i++ {
+			if predicate(lv.entries[i].value) {
+				lv.entries[kept] = lv.entries[i]
+				kept++
+			}
+		}
+
+		for i := kept; i < len(lv.entries); i++ {
+			lv.entries[i] = entry[T]{}
+		}
+
+		removed += len(lv.entries) - kept
+		lv.entries = lv.entries[:kept]
+	}
+
+	if removed == 0 {
+		return 0
+	}
+
+	pf.size -= removed
+	pf.version++
+	pf.recorder.Reset(func() []T { return pf.toSlice(false) })
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (pf *PriorityFair[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](pf, other, pf.RetainWhere)
+}
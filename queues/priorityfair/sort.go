@@ -0,0 +1,131 @@
+package priorityfair
+
+import (
+	"sort"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"golang.org/x/exp/constraints"
+)
+
+// Sort performs an in-place sort of the values within each level of the queue.
+//
+// Values remain at their levels, and the smallest value at each level is placed at the front of the level.
+// Each value keeps its waiting time, however only the value at the front of a level is considered for promotion.
+func (pf *PriorityFair[T]) Sort() {
+
+	pf.doSort(false)
+}
+
+// Sorted returns a copy of this queue with the values within each level sorted,
+// using the provided [functions.DeepCopyFunc] if any.
+func (pf *PriorityFair[T]) Sorted() collections.Collection[T] {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	pf1 := pf.makeDeepCopy()
+	pf1.sortLevels(false)
+
+	return pf1
+}
+
+// SortDescending performs an in-place sort of the values within each level of the queue.
+//
+// Values remain at their levels, and the largest value at each level is placed at the front of the level.
+func (pf *PriorityFair[T]) SortDescending() {
+
+	pf.doSort(true)
+}
+
+// SortedDescending returns a copy of this queue with the values within each level sorted in descending order,
+// using the provided [functions.DeepCopyFunc] if any.
+func (pf *PriorityFair[T]) SortedDescending() collections.Collection[T] {
+
+	if pf.lock != nil {
+		pf.lock.RLock()
+		defer pf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Read(pf.lock != nil)()
+	}
+
+	pf1 := pf.makeDeepCopy()
+	pf1.sortLevels(true)
+
+	return pf1
+}
+
+func (pf *PriorityFair[T]) doSort(descending bool) {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	pf.sortLevels(descending)
+	pf.version++
+	pf.recorder.Reset(func() []T { return pf.toSlice(false) })
+}
+
+func (pf *PriorityFair[T]) sortLevels(descending bool) {
+	for i := range pf.levels {
+		entries := pf.levels[i].entries[pf.levels[i].head:]
+
+		sort.Slice(entries, func(a, b int) bool {
+			c := pf.compare(entries[a].value, entries[b].value)
+			return util.Iif(descending, c > 0, c < 0)
+		})
+	}
+}
+
+// SortByKey performs an in-place sort of the values within each level of the queue by the keys computed for them by key.
+// Values remain at their levels, and the value with the smallest key at each level is placed at the front of the level.
+//
+// Each key is computed once before sorting, so where comparing values is expensive, e.g. comparing strings
+// with collation, sorting by a cheaply compared key derived from each value is much faster than Sort.
+func SortByKey[T any, K constraints.Ordered](pf *PriorityFair[T], key func(T) K) {
+
+	sortByKey(pf, key, false)
+}
+
+// SortByKeyDescending is as [SortByKey], placing the value with the largest key at each level at the front of the level.
+func SortByKeyDescending[T any, K constraints.Ordered](pf *PriorityFair[T], key func(T) K) {
+
+	sortByKey(pf, key, true)
+}
+
+func sortByKey[T any, K constraints.Ordered](pf *PriorityFair[T], key func(T) K, descending bool) {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	for i := range pf.levels {
+		entries := pf.levels[i].entries[pf.levels[i].head:]
+		util.SortByKey(entries, func(e entry[T]) K { return key(e.value) }, util.GetOrderedComparer[K](), descending)
+	}
+
+	pf.version++
+	pf.recorder.Reset(func() []T { return pf.toSlice(false) })
+}
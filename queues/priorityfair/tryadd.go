@@ -0,0 +1,41 @@
+package priorityfair
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*PriorityFair[int])(nil)
+
+// TryAdd enqueues a value at the level given by the priority function.
+//
+// Returns true and nil if the value was enqueued; else false and [collections.ErrClosed] if the queue
+// has been closed, or an error wrapping [collections.ErrInvalidValue] and the error of the validator
+// if it was rejected, whatever the validation policy.
+//
+// Panics if the priority function returns a level out of range.
+func (pf *PriorityFair[T]) TryAdd(value T) (bool, error) {
+
+	if pf.lock != nil {
+		pf.lock.Lock()
+		defer pf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pf.guard.Write(pf.lock != nil, pf.verifyInvariants)()
+	}
+
+	pf.lazyInit()
+
+	if pf.closed {
+		return false, collections.ErrClosed
+	}
+
+	if err := pf.validator.Check(value); err != nil {
+		return false, err
+	}
+
+	pf.enqueue(value)
+	return true, nil
+}
@@ -0,0 +1,20 @@
+package priorityqueue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Clone returns a new PriorityQueue holding the values of this queue, copied by value.
+// The result is that of Select with a predicate that is always true.
+func (pq *PriorityQueue[T]) Clone() collections.Collection[T] {
+
+	return pq.Select(util.DefaultPredicate[T])
+}
+
+// CloneDeep returns a new PriorityQueue holding the values of this queue, copied using the provided
+// [functions.DeepCopyFunc] if any. The result is that of SelectDeep with a predicate that is always true.
+func (pq *PriorityQueue[T]) CloneDeep() collections.Collection[T] {
+
+	return pq.SelectDeep(util.DefaultPredicate[T])
+}
@@ -0,0 +1,337 @@
+package priorityqueue
+
+import (
+	"errors"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Enumerable[int] = (*PriorityQueue[int])(nil)
+
+// Any returns true for the first element found where the predicate function returns true.
+// It returns false if no element matches the predicate.
+func (pq *PriorityQueue[T]) Any(predicate functions.PredicateFunc[T]) bool {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](pq, predicate)
+
+	return iter.Start() != nil
+}
+
+// All applies the predicate function to every element in the collection,
+// and returns true if all elements match the predicate.
+func (pq *PriorityQueue[T]) All(predicate functions.PredicateFunc[T]) bool {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	for _, v := range pq.buffer {
+		if !predicate(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ForEach applies function f to all elements in the collection, in heap order.
+func (pq *PriorityQueue[T]) ForEach(f func(collections.Element[T])) {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	iter := newForwardIterator[T](pq, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		f(e)
+	}
+}
+
+// TryForEach calls f for each value in the collection, in heap order, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (pq *PriorityQueue[T]) TryForEach(f func(T) error) error {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	for _, v := range pq.buffer {
+		if err := f(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, in heap order, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (pq *PriorityQueue[T]) TryForEachAll(f func(T) error) error {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	var errs []error
+
+	for _, v := range pq.buffer {
+		if err := f(v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Map applies function f to all elements in the collection
+// and returns a new PriorityQueue containing the result of f,
+// with the same priority order as this queue.
+func (pq *PriorityQueue[T]) Map(f func(T) T) collections.Collection[T] {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	pq1 := pq.makeEmptyCopy(len(pq.buffer))
+
+	for _, v := range pq.buffer {
+		pq1.buffer = append(pq1.buffer, f(v))
+	}
+
+	pq1.heapify()
+	return pq1
+}
+
+// Select returns a new PriorityQueue containing only the items for which predicate is true.
+func (pq *PriorityQueue[T]) Select(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	return pq.doSelect(predicate, false)
+}
+
+// SelectDeep returns a new PriorityQueue containing only the items for which predicate is true
+//
+// Elements are deep copied to the new collection using the provided [functions.DeepCopyFunc] if any.
+func (pq *PriorityQueue[T]) SelectDeep(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	return pq.doSelect(predicate, true)
+}
+
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (pq *PriorityQueue[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(pq.copyWhere(predicate))
+}
+
+// Find finds the first occurrence of an element matching the predicate, in heap order.
+//
+// The function returns nil if no match.
+func (pq *PriorityQueue[T]) Find(predicate functions.PredicateFunc[T]) collections.Element[T] {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	return newForwardIterator[T](pq, predicate).Start()
+}
+
+// FindAll finds all occurrences of an element matching the predicate.
+//
+// The function returns an empty slice if none match.
+func (pq *PriorityQueue[T]) FindAll(predicate functions.PredicateFunc[T]) []collections.Element[T] {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](pq, predicate)
+	result := make([]collections.Element[T], 0, util.DefaultCapacity)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		result = append(result, e)
+	}
+
+	return result
+}
+
+// Min returns the minimum value in the collection according to the Comparer function.
+// O(1) for a least first queue; else O(n).
+func (pq *PriorityQueue[T]) Min() T {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	if len(pq.buffer) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	if !pq.maxFirst {
+		return pq.buffer[0]
+	}
+
+	return util.Min(pq.buffer, pq.compare, false)
+}
+
+// Max returns the maximum value in the collection according to the Comparer function.
+// O(1) for a greatest first queue; else O(n).
+func (pq *PriorityQueue[T]) Max() T {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	if len(pq.buffer) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	if pq.maxFirst {
+		return pq.buffer[0]
+	}
+
+	return util.Max(pq.buffer, pq.compare, false)
+}
+
+func (pq *PriorityQueue[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
+
+	pq1 := pq.makeEmptyCopy(util.DefaultCapacity)
+
+	// Values are visited in heap order, and a subsequence of a heap
+	// taken in order is not necessarily a heap, so the heap is rebuilt.
+	for _, v := range pq.buffer {
+		if !predicate(v) {
+			continue
+		}
+
+		if deepCopy {
+			v = pq.copy(v)
+		}
+
+		pq1.buffer = append(pq1.buffer, v)
+	}
+
+	pq1.heapify()
+	return pq1
+}
+
+func (pq *PriorityQueue[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+
+	for _, v := range pq.buffer {
+		if predicate(v) {
+			values = append(values, util.DeepCopy(v, pq.copy))
+		}
+	}
+
+	return values
+}
+
+// makeEmptyCopy returns a new, empty priority queue with the options of this queue.
+func (pq *PriorityQueue[T]) makeEmptyCopy(capacity int) *PriorityQueue[T] {
+	options := []PriorityQueueOptionFunc[T]{
+		WithComparer(pq.compare),
+		WithCapacity[T](util.Iif(pq.initialCapacity > capacity, pq.initialCapacity, capacity)),
+		WithDeepCopy(pq.copy),
+		WithCopyPolicy[T](pq.copyPolicy),
+	}
+
+	if pq.maxFirst {
+		options = append(options, WithMaxFirst[T]())
+	}
+
+	return New(options...)
+}
@@ -0,0 +1,11 @@
+package priorityqueue
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the priority queue is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (pq *PriorityQueue[T]) verifyInvariants() {
+	for i := 1; i < len(pq.buffer); i++ {
+		util.AssertInvariant(!pq.before(i, (i-1)/2), "priority queue value dequeued before its parent")
+	}
+}
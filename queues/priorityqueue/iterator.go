@@ -0,0 +1,142 @@
+package priorityqueue
+
+import (
+	"math/rand"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Iterable[int] = (*PriorityQueue[int])(nil)
+
+// PriorityQueueIterator implements an iterator over the elements in the priority queue.
+type PriorityQueueIterator[T any] struct {
+	util.IteratorBase[T]
+	queue     *PriorityQueue[T]
+	index     int
+	predicate functions.PredicateFunc[T]
+
+	local.InternalImpl
+}
+
+func newForwardIterator[T any](pq *PriorityQueue[T], predicate functions.PredicateFunc[T]) collections.Iterator[T] {
+	return &PriorityQueueIterator[T]{
+		queue:     pq,
+		index:     0,
+		predicate: predicate,
+		IteratorBase: util.IteratorBase[T]{
+			Version:    pq.version,
+			NilElement: nil,
+		},
+	}
+}
+
+// Iterator returns an iterator that walks the PriorityQueue in heap order.
+// The first element is that of highest priority, however the remaining elements
+// are not in priority order.
+//
+//	iter := pq.Iterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (pq *PriorityQueue[T]) Iterator() collections.Iterator[T] {
+
+	return newForwardIterator(pq, util.DefaultPredicate[T])
+}
+
+// TakeWhile returns an iterater that walks the collection in heap order returning only
+// those elements for which predicate returns true.
+//
+//	pq := priorityqueue.New[int]()
+//	// add values
+//	iter := pq.TakeWhile(func (val int) bool { return val % 2 == 0 })
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (pq *PriorityQueue[T]) TakeWhile(predicate functions.PredicateFunc[T]) collections.Iterator[T] {
+
+	return newForwardIterator(pq, predicate)
+}
+
+// SampleIterator returns an iterator that yields n values chosen at random from the priority queue,
+// without replacement and in random order. If the queue holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the queue.
+// If r is nil, the default source of the math/rand package is used.
+//
+// Panics if n is negative.
+func (pq *PriorityQueue[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](pq, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the priority queue at the time of the call,
+// in the same order as ToSlice, so that the queue may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the queue's immutable slice, so it is only copied if the queue has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the queue, and their ValuePtr method panics.
+//
+//	iter := pq.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		pq.Remove(e.Value())
+//	}
+func (pq *PriorityQueue[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(pq.ToImmutableSlice())
+}
+
+// Start begins an iteration across the priority queue returning the first element,
+// which will be nil if the collection is empty.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *PriorityQueueIterator[T]) Start() collections.Element[T] {
+	i.validateIterator()
+
+	if len(i.queue.buffer) == 0 {
+		return i.NilElement
+	}
+
+	i.index = 0
+	valPtr := &(i.queue.buffer[i.index])
+
+	if !i.predicate(*valPtr) {
+		return i.Next()
+	}
+
+	return util.NewElementType[T](i.queue, valPtr, i.queue.compare)
+}
+
+// Next returns the next element in the collection,
+// which will be nil if the end has been reached.
+//
+// Panics if the collection has been modified since creation of the iterator.
+func (i *PriorityQueueIterator[T]) Next() collections.Element[T] {
+	i.validateIterator()
+
+	for {
+		i.index++
+
+		if i.index >= len(i.queue.buffer) {
+			return i.NilElement
+		}
+
+		valPtr := &(i.queue.buffer[i.index])
+
+		if i.predicate(*valPtr) {
+			return util.NewElementType[T](i.queue, valPtr, i.queue.compare)
+		}
+	}
+}
+
+func (i *PriorityQueueIterator[T]) validateIterator() {
+	if i.Version != i.queue.version {
+		panic(messages.COLLECTION_MODIFIED)
+	}
+}
@@ -0,0 +1,95 @@
+package priorityqueue
+
+import (
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// ApplyOps applies the given operations to the priority queue in order.
+// Add enqueues the value, Remove removes an occurrence of the value,
+// and Clear empties the queue.
+//
+// Panics if an op has an invalid kind, or an Add op is applied to a closed queue.
+func (pq *PriorityQueue[T]) ApplyOps(operations []ops.Op[T]) {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	util.ApplyOps(
+		operations,
+		func(value T) {
+			if pq.closed {
+				panic(messages.COLLECTION_CLOSED)
+			}
+			if pq.validator.Accept(value) {
+				pq.push(value)
+			}
+		},
+		func(value T) {
+			pq.remove(value)
+		},
+		pq.clear,
+	)
+}
+
+// StartRecording begins capturing mutations of the priority queue as ops,
+// discarding any ops previously recorded.
+//
+// Dequeued values are recorded as a Remove of the value. Sorting the queue
+// is recorded as a Clear followed by an Add of each value.
+func (pq *PriorityQueue[T]) StartRecording() {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	pq.recorder.Start(pq.copy)
+}
+
+// StopRecording ends recording and returns the ops recorded since StartRecording.
+func (pq *PriorityQueue[T]) StopRecording() []ops.Op[T] {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	return pq.recorder.Stop()
+}
+
+// IsRecording returns true if the priority queue is recording mutations.
+func (pq *PriorityQueue[T]) IsRecording() bool {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	return pq.recorder.IsRecording()
+}
@@ -0,0 +1,792 @@
+/*
+Package priorityqueue provides a priority queue implemented as a binary heap.
+
+Values are dequeued least first according to the queue's comparer, or greatest first
+if the queue is constructed with [WithMaxFirst]. Enqueue and Dequeue are O(log n), and Peek is O(1).
+
+Iteration, ToSlice and the other methods that visit every value do so in the order the values
+are held in the heap, which is not priority order. Use Sorted, or Dequeue repeatedly, to visit
+the values in priority order.
+*/
+package priorityqueue
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/queues"
+	"github.com/fireflycons/generic_collections/registry"
+	"golang.org/x/exp/constraints"
+)
+
+// Assert PriorityQueue implements required interfaces.
+var _ queues.Queue[int] = (*PriorityQueue[int])(nil)
+
+// PriorityQueueOptionFunc is the signature of a function
+// for providing options to the PriorityQueue constructor.
+type PriorityQueueOptionFunc[T any] func(*PriorityQueue[T])
+
+// PriorityQueue implements a collection from which the value of highest priority is dequeued first.
+//
+// The zero value is an empty, least first priority queue with default options, ready to use. It is not thread-safe.
+type PriorityQueue[T any] struct {
+	version         int
+	lock            *sync.RWMutex
+	initialCapacity int
+	compare         functions.ComparerFunc[T]
+	copy            functions.DeepCopyFunc[T]
+	copyPolicy      collections.CopyPolicy
+	buffer          []T
+	maxFirst        bool
+	closed          bool
+	recorder        util.OpRecorder[T]
+	guard           util.AccessGuard
+	immutable       util.SliceCache[T]
+	validator       *util.Validator[T]
+	verifySchema    bool
+	name            string
+	registration    *registry.Registration
+
+	local.InternalImpl
+}
+
+// New creates a new, least first priority queue.
+//
+// The queue is backed by a slice of T holding a binary heap.
+func New[T any](options ...PriorityQueueOptionFunc[T]) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{
+		initialCapacity: util.DefaultCapacity,
+	}
+
+	for _, o := range options {
+		o(pq)
+	}
+
+	if pq.copy == nil || pq.copyPolicy == collections.Shallow {
+		pq.copy = util.DefaultDeepCopy[T]
+	}
+
+	pq.buffer = make([]T, 0, pq.initialCapacity)
+
+	if pq.compare == nil {
+		pq.compare = util.GetDefaultComparer[T]()
+	}
+
+	pq.register()
+
+	return pq
+}
+
+// lazyInit completes the construction of a zero value PriorityQueue on its first modification,
+// as New would have done.
+func (pq *PriorityQueue[T]) lazyInit() {
+	if pq.compare != nil {
+		return
+	}
+
+	pq.compare = util.GetZeroValueComparer[T]()
+
+	if pq.copy == nil {
+		pq.copy = util.DefaultDeepCopy[T]
+	}
+
+	if pq.buffer == nil {
+		pq.initialCapacity = util.DefaultCapacity
+		pq.buffer = make([]T, 0, pq.initialCapacity)
+	}
+}
+
+// Of constructs a new priority queue containing the given values, e.g. priorityqueue.Of(3, 1, 2).
+func Of[T any](values ...T) *PriorityQueue[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	pq := priorityqueue.OfWith([]priorityqueue.PriorityQueueOptionFunc[int]{priorityqueue.WithMaxFirst[int]()}, 3, 1, 2)
+func OfWith[T any](options []PriorityQueueOptionFunc[T], values ...T) *PriorityQueue[T] {
+	pq := New(options...)
+	pq.AddRange(values)
+	return pq
+}
+
+// NewFunc is as [New], using compare to determine the priority of values.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...PriorityQueueOptionFunc[T]) *PriorityQueue[T] {
+	return New(append([]PriorityQueueOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...PriorityQueueOptionFunc[T]) *PriorityQueue[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[T any]() PriorityQueueOptionFunc[T] {
+	return func(pq *PriorityQueue[T]) {
+		pq.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function to set initial capacity to
+// something other than the default 16 elements.
+func WithCapacity[T any](capacity int) PriorityQueueOptionFunc[T] {
+	if capacity < 0 {
+		panic(messages.NEGATIVE_CAPACITY)
+	}
+	return func(pq *PriorityQueue[T]) {
+		pq.initialCapacity = capacity
+	}
+}
+
+// Option function to provide a comparer function for values of type T.
+// Required if the element type is not a supported type.
+func WithComparer[T any](comparer functions.ComparerFunc[T]) PriorityQueueOptionFunc[T] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(pq *PriorityQueue[T]) {
+		pq.compare = comparer
+	}
+}
+
+// Option function for New to dequeue the greatest value first, rather than the least.
+func WithMaxFirst[T any]() PriorityQueueOptionFunc[T] {
+	return func(pq *PriorityQueue[T]) {
+		pq.maxFirst = true
+	}
+}
+
+// Option func to provide a deep copy implementation for collection elements.
+func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) PriorityQueueOptionFunc[T] {
+	// Can be nil
+	return func(pq *PriorityQueue[T]) {
+		pq.copy = copier
+	}
+}
+
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) PriorityQueueOptionFunc[T] {
+	return func(pq *PriorityQueue[T]) {
+		pq.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+//
+// Concurrent is ignored by this collection.
+func WithOptions[T any](options collections.CommonOptions[T]) PriorityQueueOptionFunc[T] {
+	opts := make([]PriorityQueueOptionFunc[T], 0, 5)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Capacity != 0 {
+		opts = append(opts, WithCapacity[T](options.Capacity))
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(pq *PriorityQueue[T]) {
+		for _, o := range opts {
+			o(pq)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection,
+// ReplaceAll and Enqueue with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) PriorityQueueOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(pq *PriorityQueue[T]) {
+		pq.validator = util.NewValidator(validator, policy)
+	}
+}
+
+// Add enqueues a value in the priority queue.
+//
+// Returns true unless the queue has been closed or the value was rejected by the validator.
+func (pq *PriorityQueue[T]) Add(value T) bool {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	if pq.closed || !pq.validator.Accept(value) {
+		return false
+	}
+
+	pq.push(value)
+	return true
+}
+
+// AddCollection enqueues the values of the given collection in this priority queue.
+func (pq *PriorityQueue[T]) AddCollection(collection collections.Collection[T]) {
+
+	pq.AddRange(util.ImportValues(collection, pq.copyPolicy))
+}
+
+// ReplaceAll replaces the content of the priority queue with the values of the given collection.
+//
+// Panics if the queue has been closed.
+func (pq *PriorityQueue[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	pq.replaceAll(util.ImportValues(collection, pq.copyPolicy))
+}
+
+// replaceAll replaces the values of the queue with the given values under a single lock.
+func (pq *PriorityQueue[T]) replaceAll(values []T) {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	if pq.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	values = pq.validator.Filter(values)
+	pq.buffer = append(make([]T, 0, util.Iif(len(values) > pq.initialCapacity, len(values), pq.initialCapacity)), values...)
+	pq.heapify()
+	pq.version++
+	pq.recorder.Reset(func() []T { return values })
+}
+
+// AddRange enqueues the values in the given slice.
+//
+// Panics if the queue has been closed.
+func (pq *PriorityQueue[T]) AddRange(values []T) {
+
+	if len(values) == 0 {
+		return
+	}
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	if pq.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	for _, v := range pq.validator.Filter(values) {
+		pq.push(v)
+	}
+}
+
+// Clear removes all values from the priority queue.
+func (pq *PriorityQueue[T]) Clear() {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	pq.clear()
+}
+
+// Contains returns true if the given value is in the priority queue; else false. O(n).
+func (pq *PriorityQueue[T]) Contains(value T) bool {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	return pq.find(value) != -1
+}
+
+// Count returns the number of elements in the priority queue.
+func (pq *PriorityQueue[T]) Count() int {
+	return len(pq.buffer)
+}
+
+// IsEmpty returns true if the collection has no elements.
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	return len(pq.buffer) == 0
+}
+
+// IsMaxFirst returns true if the greatest value is dequeued first, i.e. the queue
+// was constructed with [WithMaxFirst] or has been sorted with SortDescending.
+func (pq *PriorityQueue[T]) IsMaxFirst() bool {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	return pq.maxFirst
+}
+
+// Dequeue removes the value of highest priority from the queue and returns it. O(log n).
+//
+// Panics if the queue is empty, or if the queue is closed and has been drained.
+func (pq *PriorityQueue[T]) Dequeue() T {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	if len(pq.buffer) == 0 {
+		if pq.closed {
+			panic(messages.COLLECTION_CLOSED)
+		}
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return pq.removeAt(0)
+}
+
+// TryDequeue removes and returns the value of highest priority and true if
+// the queue is not empty; else zero value of T and false.
+func (pq *PriorityQueue[T]) TryDequeue() (T, bool) {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	if len(pq.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return pq.removeAt(0), true
+}
+
+// Enqueue adds a value to the priority queue. O(log n).
+//
+// Panics if the queue has been closed.
+func (pq *PriorityQueue[T]) Enqueue(value T) {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	if pq.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	if pq.validator.Accept(value) {
+		pq.push(value)
+	}
+}
+
+// Close marks the queue as closed. No further values may be enqueued,
+// however values already in the queue may still be dequeued.
+//
+// Once the queue is drained, Dequeue panics indicating closure
+// and TryDequeue returns false.
+func (pq *PriorityQueue[T]) Close() {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	pq.closed = true
+}
+
+// IsClosed returns true if the queue has been closed.
+func (pq *PriorityQueue[T]) IsClosed() bool {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	return pq.closed
+}
+
+// Peek returns the value of highest priority without removing it. O(1).
+//
+// Panics if the queue is empty.
+func (pq *PriorityQueue[T]) Peek() T {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	if len(pq.buffer) == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return pq.buffer[0]
+}
+
+// TryPeek returns the value of highest priority and true if
+// the queue is not empty; else zero value of T and false.
+func (pq *PriorityQueue[T]) TryPeek() (T, bool) {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	if len(pq.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return pq.buffer[0], true
+}
+
+// Remove removes an occurrence of the given value from the priority queue. O(n).
+//
+// Returns true if the value was present and was removed; else false.
+func (pq *PriorityQueue[T]) Remove(value T) bool {
+
+	if len(pq.buffer) == 0 {
+		return false
+	}
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	return pq.remove(value)
+}
+
+// ToSlice returns a copy of the priority queue content as a slice, in heap order.
+func (pq *PriorityQueue[T]) ToSlice() []T {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	return pq.toSlice(false)
+}
+
+// ToImmutableSlice returns the content of the priority queue as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the queue has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified.
+func (pq *PriorityQueue[T]) ToImmutableSlice() []T {
+
+	if pq.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		pq.lock.RLock()
+		values, ok := pq.immutable.Load(pq.version)
+		pq.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	return pq.immutable.Get(pq.version, func() []T { return pq.toSlice(false) })
+}
+
+// ToSliceDeep returns a copy of the priority queue content as a slice, in heap order,
+// using the provided [functions.DeepCopyFunc] if any.
+func (pq *PriorityQueue[T]) ToSliceDeep() []T {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	return pq.toSlice(true)
+}
+
+// Type returns the type of this collection.
+func (*PriorityQueue[T]) Type() collections.CollectionType {
+	return collections.COLLECTION_PRIORITYQUEUE
+}
+
+// String returns a string representation of container.
+func (pq *PriorityQueue[T]) String() string {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	var values []string
+	for _, value := range pq.buffer {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+
+	return "PriorityQueue\n" + strings.Join(values, ", ")
+}
+
+func (pq *PriorityQueue[T]) toSlice(deepCopy bool) []T {
+	slc := make([]T, len(pq.buffer))
+
+	if deepCopy {
+		util.DeepCopySlice(slc, pq.buffer, pq.copy)
+	} else {
+		copy(slc, pq.buffer)
+	}
+
+	return slc
+}
+
+func (pq *PriorityQueue[T]) find(value T) int {
+	for i := range pq.buffer {
+		if pq.compare(pq.buffer[i], value) == 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (pq *PriorityQueue[T]) push(value T) {
+	pq.buffer = append(pq.buffer, value)
+	pq.up(len(pq.buffer) - 1)
+	pq.version++
+	pq.recorder.Add(value)
+}
+
+func (pq *PriorityQueue[T]) remove(value T) bool {
+	index := pq.find(value)
+
+	if index == -1 {
+		return false
+	}
+
+	pq.removeAt(index)
+	return true
+}
+
+// removeAt removes and returns the value at the given index of the heap,
+// moving the last value into its place and restoring the heap property.
+func (pq *PriorityQueue[T]) removeAt(index int) T {
+	var empty T
+	removed := pq.buffer[index]
+	last := len(pq.buffer) - 1
+
+	pq.buffer[index] = pq.buffer[last]
+	pq.buffer[last] = empty
+	pq.buffer = pq.buffer[:last]
+
+	if index < last && !pq.down(index) {
+		pq.up(index)
+	}
+
+	pq.version++
+	pq.recorder.Remove(removed)
+	return removed
+}
+
+func (pq *PriorityQueue[T]) clear() {
+	pq.buffer = make([]T, 0, pq.initialCapacity)
+	pq.version++
+	pq.recorder.Clear()
+}
+
+// before returns true if the value at index i must be dequeued before the value at index j.
+func (pq *PriorityQueue[T]) before(i, j int) bool {
+	if pq.maxFirst {
+		return pq.compare(pq.buffer[i], pq.buffer[j]) > 0
+	}
+
+	return pq.compare(pq.buffer[i], pq.buffer[j]) < 0
+}
+
+// up moves the value at index i towards the root until its parent is dequeued before it.
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+
+		if !pq.before(i, parent) {
+			break
+		}
+
+		pq.buffer[i], pq.buffer[parent] = pq.buffer[parent], pq.buffer[i]
+		i = parent
+	}
+}
+
+// down moves the value at index i towards the leaves until it is dequeued before both its children.
+// Returns true if the value was moved.
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(pq.buffer)
+
+	for {
+		child := 2*i + 1
+
+		if child >= n {
+			break
+		}
+
+		if right := child + 1; right < n && pq.before(right, child) {
+			child = right
+		}
+
+		if !pq.before(child, i) {
+			break
+		}
+
+		pq.buffer[i], pq.buffer[child] = pq.buffer[child], pq.buffer[i]
+		i = child
+	}
+
+	return i > start
+}
+
+// heapify establishes the heap property over the whole buffer. O(n).
+func (pq *PriorityQueue[T]) heapify() {
+	for i := len(pq.buffer)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) makeDeepCopy() *PriorityQueue[T] {
+	other := &PriorityQueue[T]{
+		version:         0,
+		initialCapacity: pq.initialCapacity,
+		compare:         pq.compare,
+		copy:            pq.copy,
+		copyPolicy:      pq.copyPolicy,
+		maxFirst:        pq.maxFirst,
+	}
+
+	other.buffer = make([]T, len(pq.buffer), cap(pq.buffer))
+
+	if pq.lock != nil {
+		other.lock = &sync.RWMutex{}
+	}
+
+	util.DeepCopySlice(other.buffer, pq.buffer, pq.copy)
+	return other
+}
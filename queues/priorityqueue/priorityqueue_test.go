@@ -0,0 +1,329 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueueDequeue(t *testing.T) {
+
+	seed := int64(2163)
+	values := util.CreateSingleIntListData(100, &seed)
+	ascending := sortedCopy(values)
+
+	t.Run("Values are dequeued least first", func(t *testing.T) {
+		pq := New[int]()
+
+		for _, v := range values {
+			pq.Enqueue(v)
+		}
+
+		require.Equal(t, len(values), pq.Count())
+
+		for _, v := range ascending {
+			require.Equal(t, v, pq.Peek())
+			require.Equal(t, v, pq.Dequeue())
+		}
+
+		require.True(t, pq.IsEmpty())
+	})
+
+	t.Run("Values are dequeued greatest first with WithMaxFirst", func(t *testing.T) {
+		pq := New(WithMaxFirst[int]())
+		pq.AddRange(values)
+
+		require.True(t, pq.IsMaxFirst())
+
+		for i := len(ascending) - 1; i >= 0; i-- {
+			require.Equal(t, ascending[i], pq.Dequeue())
+		}
+	})
+
+	t.Run("Duplicate values are all dequeued", func(t *testing.T) {
+		pq := Of(3, 1, 3, 2, 1)
+		require.Equal(t, []int{1, 1, 2, 3, 3}, drain(pq))
+	})
+
+	t.Run("Custom comparer determines priority", func(t *testing.T) {
+		pq := NewFunc(strings.Compare)
+		pq.AddRange([]string{"pear", "apple", "orange"})
+		require.Equal(t, []string{"apple", "orange", "pear"}, drain(pq))
+	})
+
+	t.Run("Empty queue panics", func(t *testing.T) {
+		pq := New[int]()
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { pq.Dequeue() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { pq.Peek() })
+	})
+
+	t.Run("Try operations", func(t *testing.T) {
+		pq := New[int]()
+
+		_, ok := pq.TryPeek()
+		require.False(t, ok)
+		_, ok = pq.TryDequeue()
+		require.False(t, ok)
+
+		pq.AddRange([]int{2, 1})
+		v, ok := pq.TryPeek()
+		require.True(t, ok)
+		require.Equal(t, 1, v)
+		v, ok = pq.TryDequeue()
+		require.True(t, ok)
+		require.Equal(t, 1, v)
+		require.Equal(t, 1, pq.Count())
+	})
+}
+
+func TestRemove(t *testing.T) {
+
+	seed := int64(2163)
+	values := util.CreateSingleIntListData(100, &seed)
+	r := rand.New(rand.NewSource(seed))
+
+	pq := New[int]()
+	pq.AddRange(values)
+	remaining := append([]int{}, values...)
+
+	for len(remaining) > 0 {
+		i := r.Intn(len(remaining))
+		require.True(t, pq.Remove(remaining[i]))
+		remaining = append(remaining[:i], remaining[i+1:]...)
+		requireHeap(t, pq)
+		require.ElementsMatch(t, remaining, pq.ToSlice())
+	}
+
+	require.False(t, pq.Remove(values[0]))
+}
+
+func TestContains(t *testing.T) {
+	pq := Of(5, 3, 8)
+	require.True(t, pq.Contains(8))
+	require.False(t, pq.Contains(4))
+}
+
+func TestClose(t *testing.T) {
+
+	t.Run("Add to closed queue returns false", func(t *testing.T) {
+		pq := New[int]()
+		pq.Close()
+		require.True(t, pq.IsClosed())
+		require.False(t, pq.Add(1))
+		require.True(t, pq.IsEmpty())
+	})
+
+	t.Run("Enqueue to closed queue panics", func(t *testing.T) {
+		pq := New[int]()
+		pq.Close()
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { pq.Enqueue(1) })
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { pq.AddRange([]int{1}) })
+	})
+
+	t.Run("Closed queue drains remaining items", func(t *testing.T) {
+		pq := Of(2, 1)
+		pq.Close()
+
+		require.Equal(t, 1, pq.Dequeue())
+		require.Equal(t, 2, pq.Dequeue())
+
+		_, ok := pq.TryDequeue()
+		require.False(t, ok)
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { pq.Dequeue() })
+	})
+}
+
+func TestSort(t *testing.T) {
+
+	seed := int64(2163)
+	values := util.CreateSingleIntListData(50, &seed)
+	ascending := sortedCopy(values)
+
+	t.Run("Sort iterates in ascending order", func(t *testing.T) {
+		pq := New(WithMaxFirst[int]())
+		pq.AddRange(values)
+		pq.Sort()
+
+		require.False(t, pq.IsMaxFirst())
+		require.Equal(t, ascending, pq.ToSlice())
+		require.Equal(t, ascending, drain(pq))
+	})
+
+	t.Run("SortDescending makes queue greatest first", func(t *testing.T) {
+		pq := New[int]()
+		pq.AddRange(values)
+		pq.SortDescending()
+
+		require.True(t, pq.IsMaxFirst())
+		require.Equal(t, ascending[len(ascending)-1], pq.Dequeue())
+		requireHeap(t, pq)
+	})
+
+	t.Run("Sorted returns sorted copy", func(t *testing.T) {
+		pq := New[int]()
+		pq.AddRange(values)
+		sorted := pq.Sorted()
+
+		require.Equal(t, ascending, sorted.ToSlice())
+		require.Equal(t, len(values), pq.Count())
+
+		descending := pq.SortedDescending().(*PriorityQueue[int])
+		require.Equal(t, ascending[len(ascending)-1], descending.Peek())
+	})
+}
+
+func TestEnumerable(t *testing.T) {
+
+	pq := Of(7, 3, 9, 1, 4)
+
+	require.True(t, pq.Any(func(v int) bool { return v > 8 }))
+	require.False(t, pq.All(func(v int) bool { return v > 1 }))
+	require.Equal(t, 1, pq.Min())
+	require.Equal(t, 9, pq.Max())
+	require.Equal(t, 2, len(pq.FindAll(func(v int) bool { return v > 5 })))
+	require.Nil(t, pq.Find(func(v int) bool { return v > 10 }))
+
+	selected := pq.Select(func(v int) bool { return v%2 == 1 }).(*PriorityQueue[int])
+	requireHeap(t, selected)
+	require.Equal(t, []int{1, 3, 7, 9}, drain(selected))
+
+	mapped := pq.Map(func(v int) int { return -v }).(*PriorityQueue[int])
+	requireHeap(t, mapped)
+	require.Equal(t, -9, mapped.Peek())
+
+	sum := 0
+	pq.ForEach(func(e collections.Element[int]) { sum += e.Value() })
+	require.Equal(t, 24, sum)
+
+	e := pq.Iterator().Start()
+	require.PanicsWithValue(t, messages.HEAP_POINTER_MODIFICATION, func() { e.ValuePtr() })
+}
+
+func TestAddCollection(t *testing.T) {
+	l := dlist.Of(4, 2, 6)
+	pq := Of(5)
+	pq.AddCollection(l)
+	require.Equal(t, []int{2, 4, 5, 6}, drain(pq))
+
+	pq = Of(5)
+	pq.ReplaceAll(l)
+	requireHeap(t, pq)
+	require.Equal(t, []int{2, 4, 6}, drain(pq))
+
+	// A priority queue may be added to other collections.
+	l = dlist.New[int]()
+	l.AddCollection(Of(3, 1, 2))
+	require.ElementsMatch(t, []int{1, 2, 3}, l.ToSlice())
+}
+
+func TestApplyOps(t *testing.T) {
+	pq := Of(3, 1)
+	pq.StartRecording()
+	pq.Enqueue(2)
+	pq.Dequeue()
+	recorded := pq.StopRecording()
+
+	other := Of(3, 1)
+	other.ApplyOps(recorded)
+	require.Equal(t, drain(pq), drain(other))
+
+	other.ApplyOps([]ops.Op[int]{ops.Add(5), ops.Clear[int](), ops.Add(4)})
+	require.Equal(t, []int{4}, other.ToSlice())
+}
+
+func TestZeroValue(t *testing.T) {
+	var pq PriorityQueue[int]
+	pq.Enqueue(2)
+	pq.Enqueue(1)
+	require.Equal(t, 1, pq.Dequeue())
+}
+
+func TestThreadSafety(t *testing.T) {
+	pq := New(WithThreadSafe[int]())
+	wg := sync.WaitGroup{}
+
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				pq.Enqueue(g*100 + i)
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	require.Equal(t, 800, pq.Count())
+
+	for i := 0; i < 800; i++ {
+		require.Equal(t, i, pq.Dequeue())
+	}
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+	})
+}
+
+func drain[T any](pq *PriorityQueue[T]) []T {
+	values := make([]T, 0, pq.Count())
+
+	for !pq.IsEmpty() {
+		values = append(values, pq.Dequeue())
+	}
+
+	return values
+}
+
+func requireHeap[T any](t *testing.T, pq *PriorityQueue[T]) {
+	t.Helper()
+
+	for i := 1; i < len(pq.buffer); i++ {
+		require.False(t, pq.before(i, (i-1)/2), "value at %d is dequeued before its parent", i)
+	}
+}
+
+func sortedCopy(values []int) []int {
+	s := append([]int{}, values...)
+	sort.Ints(s)
+	return s
+}
+
+func TestRetainWhere(t *testing.T) {
+
+	pq := New(WithMaxFirst[int]())
+	pq.AddRange(rand.New(rand.NewSource(5)).Perm(100))
+
+	require.Equal(t, 80, pq.RetainWhere(func(v int) bool { return v%5 == 0 }))
+	pq.verifyInvariants()
+
+	var dequeued []int
+	for !pq.IsEmpty() {
+		dequeued = append(dequeued, pq.Dequeue())
+	}
+
+	require.Equal(t, []int{95, 90, 85, 80, 75, 70, 65, 60, 55, 50, 45, 40, 35, 30, 25, 20, 15, 10, 5, 0}, dequeued)
+}
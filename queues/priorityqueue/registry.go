@@ -0,0 +1,67 @@
+package priorityqueue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+)
+
+// Option function for New to give the priority queue a name, under which it is registered with the
+// [registry] so that its size, version and operation counts are included in [registry.Snapshot].
+// The queue remains registered until Dispose is called.
+//
+// Panics if name is empty.
+func WithName[T any](name string) PriorityQueueOptionFunc[T] {
+	if name == "" {
+		panic(messages.NAME_EMPTY)
+	}
+	return func(pq *PriorityQueue[T]) {
+		pq.name = name
+	}
+}
+
+// Dispose removes the priority queue from the [registry] if it was created with [WithName].
+// The queue remains usable, but no longer appears in [registry.Snapshot].
+// Calling Dispose more than once has no effect.
+func (pq *PriorityQueue[T]) Dispose() {
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.registration.Deregister()
+	pq.registration = nil
+}
+
+// register registers the priority queue with the registry if it has been given a name.
+func (pq *PriorityQueue[T]) register() {
+	if pq.name == "" {
+		return
+	}
+
+	pq.recorder.EnableCounting()
+	pq.registration = registry.Register(pq.name, pq.stats)
+}
+
+func (pq *PriorityQueue[T]) stats() registry.Stats {
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	return registry.Stats{
+		Type:    collections.COLLECTION_PRIORITYQUEUE,
+		Count:   len(pq.buffer),
+		Version: pq.version,
+		Ops:     pq.recorder.Counts(),
+	}
+}
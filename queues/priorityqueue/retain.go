@@ -0,0 +1,65 @@
+package priorityqueue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*PriorityQueue[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single pass,
+// and returns the number of values removed. Rather than restoring the heap after each removal,
+// the heap is rebuilt once from the remaining values, so the whole is O(n).
+//
+// Values may be removed from a queue that has been closed.
+func (pq *PriorityQueue[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	kept := 0
+
+	for _, v := range pq.buffer {
+		if predicate(v) {
+			pq.buffer[kept] = v
+			kept++
+		} else {
+			pq.recorder.Remove(v)
+		}
+	}
+
+	removed := len(pq.buffer) - kept
+
+	if removed == 0 {
+		return 0
+	}
+
+	var empty T
+	for i := kept; i < len(pq.buffer); i++ {
+		pq.buffer[i] = empty
+	}
+
+	pq.buffer = pq.buffer[:kept]
+	pq.heapify()
+	pq.version++
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (pq *PriorityQueue[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](pq, other, pq.RetainWhere)
+}
@@ -0,0 +1,83 @@
+package priorityqueue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Sort performs an in-place sort of this collection, and makes it dequeue the least value first.
+//
+// As a sorted slice is a valid heap, the values are then iterated in ascending order
+// until the queue is next modified.
+func (pq *PriorityQueue[T]) Sort() {
+
+	pq.doSort(util.Gosort[T], false)
+}
+
+// Sorted returns a sorted, least first copy of this priority queue as a new priority queue
+// using the provided [functions.DeepCopyFunc] if any.
+func (pq *PriorityQueue[T]) Sorted() collections.Collection[T] {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	pq1 := pq.makeDeepCopy()
+	pq1.maxFirst = false
+	util.Gosort(pq1.buffer, len(pq1.buffer), pq1.compare)
+
+	return pq1
+}
+
+// SortDescending performs an in-place sort of this collection, and makes it dequeue the greatest value first.
+//
+// As a sorted slice is a valid heap, the values are then iterated in descending order
+// until the queue is next modified.
+func (pq *PriorityQueue[T]) SortDescending() {
+
+	pq.doSort(util.GosortDescending[T], true)
+}
+
+// SortedDescending returns a descending order sorted, greatest first copy of this priority queue
+// as a new priority queue using the provided [functions.DeepCopyFunc] if any.
+func (pq *PriorityQueue[T]) SortedDescending() collections.Collection[T] {
+
+	if pq.lock != nil {
+		pq.lock.RLock()
+		defer pq.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Read(pq.lock != nil)()
+	}
+
+	pq1 := pq.makeDeepCopy()
+	pq1.maxFirst = true
+	util.GosortDescending(pq1.buffer, len(pq1.buffer), pq1.compare)
+
+	return pq1
+}
+
+func (pq *PriorityQueue[T]) doSort(f util.SortFunc[T], maxFirst bool) {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	pq.maxFirst = maxFirst
+	f(pq.buffer, len(pq.buffer), pq.compare)
+	pq.version++
+	pq.recorder.Reset(func() []T { return pq.toSlice(false) })
+}
@@ -0,0 +1,39 @@
+package priorityqueue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*PriorityQueue[int])(nil)
+
+// TryAdd enqueues a value in the priority queue.
+//
+// Returns true and nil if the value was enqueued; else false and [collections.ErrClosed] if the queue
+// has been closed, or an error wrapping [collections.ErrInvalidValue] and the error of the validator
+// if it was rejected, whatever the validation policy.
+func (pq *PriorityQueue[T]) TryAdd(value T) (bool, error) {
+
+	if pq.lock != nil {
+		pq.lock.Lock()
+		defer pq.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer pq.guard.Write(pq.lock != nil, pq.verifyInvariants)()
+	}
+
+	pq.lazyInit()
+
+	if pq.closed {
+		return false, collections.ErrClosed
+	}
+
+	if err := pq.validator.Check(value); err != nil {
+		return false, err
+	}
+
+	pq.push(value)
+	return true, nil
+}
@@ -0,0 +1,51 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// ToChan returns a channel that receives the values of the queue in the order they are dequeued,
+// dequeuing each as it is sent. The channel is closed once the queue is empty or ctx is done,
+// so it drains the values in the queue at the time, and any enqueued before it is found to be empty.
+//
+//	for v := range q.ToChan(ctx) {
+//		process(v)
+//	}
+//
+// A value is dequeued before it is sent, so if ctx is done while a value is waiting to be received,
+// that value is discarded. If other goroutines are to use the queue while it is drained, the queue must be thread-safe.
+func (q *Queue[T]) ToChan(ctx context.Context) <-chan T {
+
+	return util.ToChan(ctx, q.TryDequeue)
+}
+
+// FromChan constructs a new thread-safe queue, and in a new goroutine enqueues the values received from src,
+// until src is closed, ctx is done or the queue is closed. The queue may be used while it is filled.
+//
+// The returned channel receives nil once src is closed or the queue is closed, else the error of ctx,
+// then is closed.
+//
+//	q, done := queue.FromChan(ctx, events)
+//	// ...
+//	err := <-done
+//
+// Panics if src is nil.
+func FromChan[T any](ctx context.Context, src <-chan T, options ...QueueOptionFunc[T]) (*Queue[T], <-chan error) {
+
+	if src == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "src"))
+	}
+
+	q := New(append([]QueueOptionFunc[T]{WithThreadSafe[T]()}, options...)...)
+
+	done := util.FromChan(ctx, src, func(value T) bool {
+		// Add also returns false for a value conflated with one already queued.
+		return q.Add(value) || !q.IsClosed()
+	})
+
+	return q, done
+}
@@ -0,0 +1,106 @@
+package queue
+
+import (
+	"io"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// Position returns the position of the value at the front of the queue, being the number of values
+// that have been dequeued or acknowledged since the queue was created or last restored.
+// The value at index i of the queue is at position Position() + i.
+//
+// Values removed by Clear, ReplaceAll or removal by value do not advance the position.
+func (q *Queue[T]) Position() int {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	return q.position
+}
+
+// AcknowledgeUpTo removes values from the front of the queue until the value at the front
+// is at the given position, or the queue is empty, returning the number of values removed.
+//
+// This allows a batch processor to read values with Peek, Page or an iterator, and only
+// remove them once they have been processed. Does nothing if position is not beyond [Queue.Position].
+func (q *Queue[T]) AcknowledgeUpTo(position int) int {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	removed := 0
+
+	for q.size > 0 && q.position < position {
+		q.removeItem()
+		removed++
+	}
+
+	return removed
+}
+
+// Checkpoint returns the position and values of the queue from head to tail, using enc to encode
+// each value, so that a batch processor may persist its progress and resume it with [Queue.Restore].
+// The checkpoint begins with a header describing the [schema] of the queue, but its options are not encoded.
+//
+// Returns the first error returned by enc.
+func (q *Queue[T]) Checkpoint(enc func(io.Writer, T) error) ([]byte, error) {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	return util.EncodeCheckpoint(schema.Of[T](collections.COLLECTION_QUEUE, nil), q.position, q.size, func(yield func(T) bool) {
+		for i := 0; i < q.size && yield(q.at(i)); i++ {
+		}
+	}, enc)
+}
+
+// Restore replaces the values and position of the queue with those of a checkpoint
+// returned by [Queue.Checkpoint], using dec to decode each value.
+//
+// The checkpoint is decoded in full before the queue is modified, so the queue is unchanged if
+// an error is returned. If the queue was created [WithSchemaValidation], returns an error if the
+// checkpoint has no header or was written by another type of collection or value. Returns the first
+// error returned by dec, or io.ErrUnexpectedEOF if the checkpoint is truncated.
+//
+// Panics if the queue has been closed.
+func (q *Queue[T]) Restore(state []byte, dec func(io.Reader) (T, error)) error {
+
+	position, values, err := util.DecodeCheckpoint(state, schema.Of[T](collections.COLLECTION_QUEUE, nil), q.verifySchema, dec)
+
+	if err != nil {
+		return err
+	}
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+	q.setValues(values)
+	q.position = position
+	return nil
+}
@@ -0,0 +1,119 @@
+package queue
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeInt(w io.Writer, v int) error {
+	return binary.Write(w, binary.LittleEndian, int64(v))
+}
+
+func decodeInt(r io.Reader) (int, error) {
+	var v int64
+	err := binary.Read(r, binary.LittleEndian, &v)
+	return int(v), err
+}
+
+func TestCheckpoint(t *testing.T) {
+
+	t.Run("Dequeue advances position", func(t *testing.T) {
+		q := Of(1, 2, 3, 4)
+		require.Equal(t, 0, q.Position())
+
+		q.Dequeue()
+		_, _ = q.TryDequeue()
+		require.Equal(t, 2, q.Position())
+
+		q.Remove(4)
+		q.Clear()
+		require.Equal(t, 2, q.Position())
+	})
+
+	t.Run("AcknowledgeUpTo removes values before position", func(t *testing.T) {
+		q := Of(1, 2, 3, 4, 5)
+
+		require.Equal(t, 2, q.AcknowledgeUpTo(2))
+		require.Equal(t, []int{3, 4, 5}, q.ToSlice())
+		require.Equal(t, 0, q.AcknowledgeUpTo(1))
+		require.Equal(t, 3, q.AcknowledgeUpTo(10))
+		require.True(t, q.IsEmpty())
+		require.Equal(t, 5, q.Position())
+	})
+
+	t.Run("Restore resumes from checkpoint", func(t *testing.T) {
+		q := Of(1, 2, 3, 4, 5)
+		q.AcknowledgeUpTo(2)
+
+		state, err := q.Checkpoint(encodeInt)
+		require.NoError(t, err)
+
+		restored := New[int]()
+		require.NoError(t, restored.Restore(state, decodeInt))
+		require.Equal(t, 2, restored.Position())
+		require.Equal(t, []int{3, 4, 5}, restored.ToSlice())
+
+		restored.AcknowledgeUpTo(4)
+		require.Equal(t, []int{5}, restored.ToSlice())
+	})
+
+	t.Run("Restore of truncated checkpoint leaves queue unchanged", func(t *testing.T) {
+		state, err := Of(1, 2, 3).Checkpoint(encodeInt)
+		require.NoError(t, err)
+
+		q := Of(9)
+		require.ErrorIs(t, q.Restore(state[:len(state)-1], decodeInt), io.ErrUnexpectedEOF)
+		require.Equal(t, []int{9}, q.ToSlice())
+		require.Equal(t, 0, q.Position())
+	})
+
+	t.Run("Restore panics if closed", func(t *testing.T) {
+		state, err := Of(1).Checkpoint(encodeInt)
+		require.NoError(t, err)
+
+		q := New[int]()
+		q.Close()
+		require.Panics(t, func() { _ = q.Restore(state, decodeInt) })
+	})
+	t.Run("Schema validation", func(t *testing.T) {
+		state, err := Of(1, 2, 3).Checkpoint(encodeInt)
+		require.NoError(t, err)
+
+		s, _, err := schema.Read(state)
+		require.NoError(t, err)
+		require.Equal(t, "Queue", s.Collection)
+
+		restored := New(WithSchemaValidation[int]())
+		require.NoError(t, restored.Restore(state, decodeInt))
+		require.Equal(t, []int{1, 2, 3}, restored.ToSlice())
+
+		other, err := schema.Append(nil, schema.Of[string](collections.COLLECTION_QUEUE, nil))
+		require.NoError(t, err)
+
+		var mismatch *schema.MismatchError
+		require.ErrorAs(t, New(WithSchemaValidation[int]()).Restore(append(other, body(state)...), decodeInt), &mismatch)
+		require.Equal(t, "element", mismatch.Field)
+	})
+
+	t.Run("Checkpoint without a schema header", func(t *testing.T) {
+		state, err := Of(1, 2, 3).Checkpoint(encodeInt)
+		require.NoError(t, err)
+		legacy := body(state)
+
+		restored := New[int]()
+		require.NoError(t, restored.Restore(legacy, decodeInt))
+		require.Equal(t, []int{1, 2, 3}, restored.ToSlice())
+		require.ErrorIs(t, New(WithSchemaValidation[int]()).Restore(legacy, decodeInt), schema.ErrMissing)
+	})
+}
+
+// body returns a checkpoint without its schema header, as written before headers were added.
+func body(state []byte) []byte {
+	_, data, _ := schema.Read(state)
+	return data
+}
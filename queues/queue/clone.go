@@ -0,0 +1,89 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// CloneInto replaces the content of dst with a deep copy of this queue's values,
+// using the provided [functions.DeepCopyFunc] if any.
+//
+// The backing slice of dst is reused if it has sufficient capacity, so periodically cloning
+// into the same destination does not allocate. The comparer, deep copy function and copy policy of
+// this queue are copied to dst; its thread safety, closed state and conflation are not changed.
+//
+// Panics if dst is nil.
+func (q *Queue[T]) CloneInto(dst *Queue[T]) {
+
+	if dst == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dst"))
+	}
+
+	if dst == q {
+		return
+	}
+
+	// Locked in a consistent order, so that a concurrent dst.CloneInto(q) cannot deadlock
+	util.LockPair(q.lock, dst.lock)
+	defer util.UnlockPair(q.lock, dst.lock)
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	if util.Debug {
+		defer dst.guard.Write(dst.lock != nil, dst.verifyInvariants)()
+	}
+
+	dst.lazyInit()
+
+	if cap(dst.buffer) < q.size {
+		dst.buffer = make([]T, q.size)
+	} else {
+		// Release references held by dst before reuse
+		var empty T
+		for i := 0; i < dst.size; i++ {
+			dst.buffer[dst.bufferIndex(i)] = empty
+		}
+
+		dst.buffer = dst.buffer[:cap(dst.buffer)]
+	}
+
+	for i := 0; i < q.size; i++ {
+		dst.buffer[i] = util.DeepCopy(q.buffer[q.bufferIndex(i)], q.copy)
+	}
+
+	dst.head = 0
+	dst.tail = util.Iif(q.size == len(dst.buffer), 0, q.size)
+	dst.size = q.size
+	dst.compare = q.compare
+	dst.copy = q.copy
+	dst.copyPolicy = q.copyPolicy
+	dst.version++
+	dst.recorder.Reset(func() []T { return dst.toSlice(false) })
+
+	if dst.conflator != nil {
+		dst.conflator.Reset(dst.size, dst.at)
+	}
+
+	if dst.weigher != nil {
+		dst.weigher.Reset(dst.size, dst.at)
+	}
+}
+
+// Clone returns a new Queue holding the values of this queue, copied by value.
+// The result is that of Select with a predicate that is always true.
+func (q *Queue[T]) Clone() collections.Collection[T] {
+
+	return q.Select(util.DefaultPredicate[T])
+}
+
+// CloneDeep returns a new Queue holding the values of this queue, copied using the provided
+// [functions.DeepCopyFunc] if any. The result is that of SelectDeep with a predicate that is always true.
+func (q *Queue[T]) CloneDeep() collections.Collection[T] {
+
+	return q.SelectDeep(util.DefaultPredicate[T])
+}
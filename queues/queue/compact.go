@@ -0,0 +1,93 @@
+package queue
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// BufferStats describes how the values of a queue are laid out in its circular buffer.
+type BufferStats struct {
+	// Capacity is the length of the buffer.
+	Capacity int
+
+	// Count is the number of values in the queue.
+	Count int
+
+	// Head is the buffer index of the value at the front of the queue.
+	Head int
+
+	// Wrapped is true if the values wrap around the end of the buffer,
+	// so are stored in two segments.
+	Wrapped bool
+
+	// Compactions is the number of times the head has been moved to the start of the buffer,
+	// either by Compact or automatically.
+	Compactions int
+}
+
+// Compact moves the values of the queue to the start of its buffer, so that the
+// head is at index 0 and the values are stored contiguously. The values are rotated
+// in place, so the buffer is not reallocated and its capacity is unchanged.
+//
+// As values are dequeued, the head advances through the buffer. It is moved back to
+// the start automatically whenever the buffer is reallocated to grow, and whenever the
+// queue becomes empty, so Compact is only needed to lay out a queue whose head has drifted
+// before e.g. a large number of values are enqueued with [Queue.EnqueueBatch].
+//
+// O(capacity). Has no effect if the head is already at the start of the buffer.
+func (q *Queue[T]) Compact() {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	if q.head == 0 {
+		return
+	}
+
+	// Rotate the buffer left by head
+	util.ReverseSubset(q.buffer, 0, q.head)
+	util.ReverseSubset(q.buffer, q.head, len(q.buffer)-q.head)
+	util.Reverse(q.buffer)
+
+	q.head = 0
+	q.tail = util.Iif(q.size == len(q.buffer), 0, q.size)
+	q.compactions++
+	q.version++
+}
+
+// BufferStats returns the layout of the queue's values in its buffer,
+// e.g. to monitor how far the head has drifted.
+func (q *Queue[T]) BufferStats() BufferStats {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	return BufferStats{
+		Capacity:    len(q.buffer),
+		Count:       q.size,
+		Head:        q.head,
+		Wrapped:     q.head+q.size > len(q.buffer),
+		Compactions: q.compactions,
+	}
+}
+
+// resetIfEmpty moves the head to the start of the buffer if the queue is empty,
+// which costs nothing as there are no values to move.
+func (q *Queue[T]) resetIfEmpty() {
+	if q.size == 0 && q.head != 0 {
+		q.head = 0
+		q.tail = 0
+		q.compactions++
+	}
+}
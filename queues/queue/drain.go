@@ -0,0 +1,78 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// DequeueN removes up to n values from the front of the queue under a single acquisition
+// of the lock, and returns them in the order they were queued. Fewer than n values are returned
+// if the queue holds fewer, and none if it is empty. The version is incremented once.
+//
+// Panics if n is negative.
+func (q *Queue[T]) DequeueN(n int) []T {
+
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	if n > q.size {
+		n = q.size
+	}
+
+	values := make([]T, n)
+
+	if n == 0 {
+		return values
+	}
+
+	version := q.version
+
+	for i := range values {
+		values[i] = q.removeItem()
+	}
+
+	q.version = version + 1
+	return values
+}
+
+// DrainTo removes up to max values from the front of the queue as per [Queue.DequeueN],
+// adds them to dst with its AddRange method, and returns the number of values removed.
+//
+// The lock of the queue is released before the values are added, so dst may be the queue itself,
+// or a collection being drained into the queue by another goroutine. Values rejected by dst,
+// such as duplicates added to a set, are not returned to the queue.
+//
+// Panics if dst is nil or max is negative.
+func (q *Queue[T]) DrainTo(dst collections.Collection[T], max int) int {
+
+	if dst == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dst"))
+	}
+
+	if max < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "max"))
+	}
+
+	values := q.DequeueN(max)
+
+	if len(values) > 0 {
+		dst.AddRange(values)
+	}
+
+	return len(values)
+}
@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"errors"
 	"sync"
 
 	"github.com/fireflycons/generic_collections/collections"
@@ -21,6 +22,12 @@ func (q *Queue[T]) Any(predicate functions.PredicateFunc[T]) bool {
 		defer q.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
 	iter := newForwardIterator[T](q, predicate)
 
 	return iter.Start() != nil
@@ -35,6 +42,12 @@ func (q *Queue[T]) All(predicate functions.PredicateFunc[T]) bool {
 		defer q.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
 	iter := newForwardIterator[T](q, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -54,6 +67,12 @@ func (q *Queue[T]) ForEach(f func(collections.Element[T])) {
 		defer q.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
 	iter := newForwardIterator[T](q, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -61,6 +80,59 @@ func (q *Queue[T]) ForEach(f func(collections.Element[T])) {
 	}
 }
 
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (q *Queue[T]) TryForEach(f func(T) error) error {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](q, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (q *Queue[T]) TryForEachAll(f func(T) error) error {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	var errs []error
+	iter := newForwardIterator[T](q, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Map applies function f to all elements in the collection
 // and returns a new Queue containing the result of f.
 func (q *Queue[T]) Map(f func(T) T) collections.Collection[T] {
@@ -70,6 +142,12 @@ func (q *Queue[T]) Map(f func(T) T) collections.Collection[T] {
 		defer q.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
 	iter := newForwardIterator[T](q, util.DefaultPredicate[T])
 
 	q1 := New[T](WithComparer[T](q.compare))
@@ -89,6 +167,10 @@ func (q *Queue[T]) Select(predicate functions.PredicateFunc[T]) collections.Coll
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	return q.doSelect(predicate, false)
 }
 
@@ -102,9 +184,21 @@ func (q *Queue[T]) SelectDeep(predicate functions.PredicateFunc[T]) collections.
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	return q.doSelect(predicate, true)
 }
 
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (q *Queue[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(q.copyWhere(predicate))
+}
+
 // Find finds the first occurrence of an element matching the predicate.
 //
 // The function returns nil if no match.
@@ -115,6 +209,10 @@ func (q *Queue[T]) Find(predicate functions.PredicateFunc[T]) collections.Elemen
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	result := q.doFind(predicate, false)
 
 	if len(result) == 0 {
@@ -134,6 +232,10 @@ func (q *Queue[T]) FindAll(predicate functions.PredicateFunc[T]) []collections.E
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	result := q.doFind(predicate, true)
 
 	return result
@@ -147,6 +249,10 @@ func (q *Queue[T]) Min() T {
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	if q.size == 0 {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -206,6 +312,10 @@ func (q *Queue[T]) Max() T {
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	if q.size == 0 {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -277,7 +387,7 @@ func (q *Queue[T]) doFind(predicate functions.PredicateFunc[T], all bool) []coll
 
 func (q *Queue[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
 
-	q1 := New[T](WithComparer[T](q.compare), WithCapacity[T](util.Iif[int](q.initialCapacity > q.size, q.initialCapacity, q.size)))
+	q1 := New[T](WithComparer[T](q.compare), WithCapacity[T](util.Iif[int](q.initialCapacity > q.size, q.initialCapacity, q.size)), WithDeepCopy(q.copy), WithCopyPolicy[T](q.copyPolicy))
 	iter := newForwardIterator[T](q, predicate)
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -290,3 +400,24 @@ func (q *Queue[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool)
 
 	return q1
 }
+
+func (q *Queue[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+	iter := newForwardIterator[T](q, predicate)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, util.DeepCopy(e.Value(), q.copy))
+	}
+
+	return values
+}
@@ -0,0 +1,101 @@
+package queue_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/queues/queue"
+)
+
+func Example() {
+	q := queue.New[string]()
+	q.Enqueue("first")
+	q.Enqueue("second")
+	q.Enqueue("third")
+
+	for !q.IsEmpty() {
+		fmt.Println(q.Dequeue())
+	}
+	// Output:
+	// first
+	// second
+	// third
+}
+
+func ExampleOf() {
+	q := queue.Of(1, 2, 3)
+
+	fmt.Println(q.Peek(), q.Count())
+	// Output:
+	// 1 3
+}
+
+func ExampleQueue_TryDequeue() {
+	q := queue.Of(1)
+
+	for {
+		value, ok := q.TryDequeue()
+		if !ok {
+			break
+		}
+		fmt.Println(value)
+	}
+	// Output:
+	// 1
+}
+
+func ExampleQueue_Iterator() {
+	q := queue.Of("a", "b", "c")
+	iter := q.Iterator()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Print(e.Value(), " ")
+	}
+	fmt.Println()
+	// Output:
+	// a b c
+}
+
+func ExampleWithConflation() {
+	type tick struct {
+		symbol string
+		price  int
+	}
+
+	q := queue.New(
+		queue.WithComparer(func(a, b tick) int { return a.price - b.price }),
+		queue.WithConflation(func(t tick) string { return t.symbol }),
+	)
+	q.Enqueue(tick{"ABC", 100})
+	q.Enqueue(tick{"XYZ", 200})
+	q.Enqueue(tick{"ABC", 101})
+
+	for !q.IsEmpty() {
+		fmt.Println(q.Dequeue())
+	}
+	// Output:
+	// {ABC 101}
+	// {XYZ 200}
+}
+
+func ExampleWithWeigher() {
+	q := queue.New(queue.WithWeigher(func(s string) int { return len(s) }, 10))
+
+	fmt.Println(q.Offer("hello"))
+	fmt.Println(q.Offer("world"))
+	fmt.Println(q.Offer("!"))
+	fmt.Println(q.Weight(), q.MaxWeight())
+	// Output:
+	// true
+	// true
+	// false
+	// 10 10
+}
+
+func ExampleQueue_Select() {
+	q := queue.Of(1, 2, 3, 4, 5, 6)
+	even := q.Select(func(v int) bool { return v%2 == 0 })
+
+	fmt.Println(even.ToSlice())
+	// Output:
+	// [2 4 6]
+}
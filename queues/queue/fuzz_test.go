@@ -0,0 +1,58 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzRemove applies a sequence of operations decoded from ops to a queue and to a slice
+// that models it, checking after each operation that they hold the same values and that
+// the internal state of the queue is consistent. Each pair of bytes in ops is an operation
+// and a value, so that Remove is exercised with the head and tail in all relative positions.
+//
+//	go test -run=^$ -fuzz=FuzzRemove ./queues/queue
+func FuzzRemove(f *testing.F) {
+	f.Add(uint8(4), []byte{0, 1, 0, 2, 0, 3, 2, 0, 0, 4, 0, 5, 3, 3})
+	f.Add(uint8(0), []byte{0, 1, 1, 2, 2, 0, 0, 3, 3, 2, 0, 4, 3, 4, 3, 3})
+
+	f.Fuzz(func(t *testing.T, capacity uint8, ops []byte) {
+		q := New(WithCapacity[byte](int(capacity % 32)))
+		model := []byte{}
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := ops[i+1] % 16
+
+			switch ops[i] % 4 {
+			case 0, 1:
+				q.Enqueue(value)
+				model = append(model, value)
+			case 2:
+				if len(model) > 0 {
+					require.Equal(t, model[0], q.Dequeue())
+					model = model[1:]
+				}
+			case 3:
+				index := modelIndex(model, value)
+				require.Equal(t, index != -1, q.Remove(value))
+
+				if index != -1 {
+					model = append(model[:index:index], model[index+1:]...)
+				}
+			}
+
+			q.verifyInvariants()
+			require.Equal(t, model, q.ToSlice())
+		}
+	})
+}
+
+func modelIndex(model []byte, value byte) int {
+	for i, v := range model {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,40 @@
+package queue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// GobEncode implements gob.GobEncoder, encoding the values of the queue from head to tail,
+// so that the queue may be persisted or sent over RPC with encoding/gob.
+// The values must themselves be encodable by encoding/gob. The values follow a header describing the [schema] of the queue, but its options are not encoded.
+func (q *Queue[T]) GobEncode() ([]byte, error) {
+
+	return util.GobEncode(schema.Of[T](collections.COLLECTION_QUEUE, nil), q.ToSlice())
+}
+
+// GobDecode implements gob.GobDecoder, replacing the values of the queue with those encoded by GobEncode.
+//
+// The options of the queue are retained, so decode into a queue constructed with the options
+// of that encoded, or into a zero value for the default options.
+func (q *Queue[T]) GobDecode(data []byte) error {
+
+	var values []T
+
+	if err := util.GobDecode(data, &values, schema.Of[T](collections.COLLECTION_QUEUE, nil), q.verifySchema); err != nil {
+		return err
+	}
+
+	q.replaceAll(values)
+	return nil
+}
+
+// Option function for New to verify the schema header of data decoded by GobDecode and Restore,
+// which then returns an error wrapping [schema.ErrMismatch] if the data was encoded
+// from another type of collection or value, or [schema.ErrMissing] if it has no header.
+func WithSchemaValidation[T any]() QueueOptionFunc[T] {
+	return func(q *Queue[T]) {
+		q.verifySchema = true
+	}
+}
@@ -0,0 +1,85 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/schema"
+	"github.com/fireflycons/generic_collections/stacks/stack"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGob(t *testing.T) {
+
+	t.Run("Round trip preserves values", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		s.Dequeue()
+		s.Enqueue(4)
+
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(s))
+
+		decoded := New[int]()
+		require.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+		require.Equal(t, []int{2, 3, 4}, decoded.ToSlice())
+	})
+
+	t.Run("Decodes into a zero value field", func(t *testing.T) {
+		type message struct {
+			Values *Queue[string]
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(message{Values: Of("a", "b")}))
+
+		var decoded message
+		require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+		require.Equal(t, 2, decoded.Values.Count())
+		require.True(t, decoded.Values.Contains("b"))
+	})
+
+	t.Run("Decoding replaces values", func(t *testing.T) {
+		data, err := Of(1, 2).GobEncode()
+		require.NoError(t, err)
+
+		s := Of(7, 8, 9)
+		require.NoError(t, s.GobDecode(data))
+		require.Equal(t, 2, s.Count())
+		require.False(t, s.Contains(7))
+	})
+
+	t.Run("Schema validation", func(t *testing.T) {
+		data, err := Of(1, 2).GobEncode()
+		require.NoError(t, err)
+
+		q := New(WithSchemaValidation[int]())
+		require.NoError(t, q.GobDecode(data))
+		require.Equal(t, []int{1, 2}, q.ToSlice())
+
+		strings := New(WithSchemaValidation[string]())
+		require.ErrorIs(t, strings.GobDecode(data), schema.ErrMismatch)
+
+		stackData, err := stack.Of(3).GobEncode()
+		require.NoError(t, err)
+
+		err = q.GobDecode(stackData)
+		var mismatch *schema.MismatchError
+		require.ErrorAs(t, err, &mismatch)
+		require.Equal(t, "collection", mismatch.Field)
+		require.Equal(t, []int{1, 2}, q.ToSlice(), "Queue should be unchanged by a mismatch")
+
+		require.NoError(t, New[int]().GobDecode(stackData), "Schema should not be verified by default")
+	})
+
+	t.Run("Data without a schema header", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode([]int{1, 2}))
+
+		q := New[int]()
+		require.NoError(t, q.GobDecode(buf.Bytes()))
+		require.Equal(t, []int{1, 2}, q.ToSlice())
+
+		require.ErrorIs(t, New(WithSchemaValidation[int]()).GobDecode(buf.Bytes()), schema.ErrMissing)
+	})
+}
@@ -0,0 +1,76 @@
+package queue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert Indexable implementation
+var _ collections.Indexable[int] = (*Queue[int])(nil)
+
+// At returns the value at the given position, where position 0 is the head of the queue.
+//
+// Panics if index is out of range.
+func (q *Queue[T]) At(index int) T {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	util.ValidateIndex(index, q.size)
+	return q.buffer[q.bufferIndex(index)]
+}
+
+// Swap exchanges the values at the given positions, where position 0 is the head of the queue.
+//
+// Panics if either index is out of range.
+func (q *Queue[T]) Swap(i, j int) {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	util.ValidateIndex(i, q.size)
+	util.ValidateIndex(j, q.size)
+	i, j = q.bufferIndex(i), q.bufferIndex(j)
+	q.buffer[i], q.buffer[j] = q.buffer[j], q.buffer[i]
+	q.version++
+	q.recorder.Reset(func() []T { return q.toSlice(false) })
+}
+
+// CompareAt compares the values at the given positions using the collection's comparer,
+// where position 0 is the head of the queue.
+//
+// Panics if either index is out of range.
+func (q *Queue[T]) CompareAt(i, j int) int {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	util.ValidateIndex(i, q.size)
+	util.ValidateIndex(j, q.size)
+	return q.compare(q.buffer[q.bufferIndex(i)], q.buffer[q.bufferIndex(j)])
+}
+
+// bufferIndex converts a position to an index into the underlying buffer.
+func (q *Queue[T]) bufferIndex(index int) int {
+	return (q.head + index) % len(q.buffer)
+}
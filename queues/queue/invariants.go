@@ -0,0 +1,22 @@
+package queue
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the queue is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (q *Queue[T]) verifyInvariants() {
+	util.AssertInvariant(q.size >= 0 && q.size <= len(q.buffer), "queue size exceeds buffer")
+
+	if q.weigher != nil {
+		total := q.weigher.Total()
+		q.weigher.Reset(q.size, q.at)
+		util.AssertInvariant(total == q.weigher.Total(), "queue weight does not match values")
+	}
+
+	if len(q.buffer) == 0 {
+		return
+	}
+
+	util.AssertInvariant(q.head >= 0 && q.head < len(q.buffer), "queue head outside buffer")
+	util.AssertInvariant(q.tail == (q.head+q.size)%len(q.buffer), "queue tail does not follow last value")
+}
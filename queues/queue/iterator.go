@@ -1,6 +1,8 @@
 package queue
 
 import (
+	"math/rand"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/local"
@@ -95,6 +97,40 @@ func (q *Queue[T]) TakeWhile(predicate functions.PredicateFunc[T]) collections.I
 	return newForwardIterator(q, predicate)
 }
 
+// SampleIterator returns an iterator that yields n values chosen at random from the queue,
+// without replacement and in random order. If the queue holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the queue.
+// If r is nil, the default source of the math/rand package is used.
+//
+//	iter := q.SampleIterator(100, nil)
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// audit e.Value()
+//	}
+//
+// Panics if n is negative.
+func (q *Queue[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](q, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the queue at the time of the call,
+// in the same order as ToSlice, so that the queue may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the queue's immutable slice, so it is only copied if the queue has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the queue, and their ValuePtr method panics.
+//
+//	iter := q.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		q.Remove(e.Value())
+//	}
+func (q *Queue[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(q.ToImmutableSlice())
+}
+
 // Start begins an iteration across the queue returning the fisrt element,
 // which will be nil if the collection is empty.
 //
@@ -113,7 +149,7 @@ func (i *QueueIterator[T]) Start() collections.Element[T] {
 		return i.Next()
 	}
 
-	return util.NewElementType[T](i.queue, valPtr)
+	return util.NewElementType[T](i.queue, valPtr, i.queue.compare)
 }
 
 // Next returns the next element in the collection,
@@ -133,7 +169,7 @@ func (i *QueueIterator[T]) Next() collections.Element[T] {
 		valPtr := &(i.queue.buffer[i.toBufferPosition()])
 
 		if i.predicate(*valPtr) {
-			return util.NewElementType[T](i.queue, valPtr)
+			return util.NewElementType[T](i.queue, valPtr, i.queue.compare)
 		}
 	}
 }
@@ -1,10 +1,14 @@
 package queue
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/stretchr/testify/require"
+	"math/rand"
 )
 
 func TestForwardIterator(t *testing.T) {
@@ -330,3 +334,124 @@ func TestQueueReverseIterator_Negative(t *testing.T) {
 		require.Panics(t, func() { element.ValuePtr() })
 	})
 }
+
+func TestSampleIterator(t *testing.T) {
+
+	sample := func(iter collections.Iterator[int]) []int {
+		values := []int{}
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+		}
+		return values
+	}
+
+	t.Run("Values are distinct members of the collection", func(t *testing.T) {
+		q := New[int]()
+		for i := 0; i < 1000; i++ {
+			q.Enqueue(i)
+		}
+
+		values := sample(q.SampleIterator(50, rand.New(rand.NewSource(1))))
+		require.Len(t, values, 50)
+
+		seen := make(map[int]bool)
+		for _, v := range values {
+			require.True(t, v >= 0 && v < 1000)
+			require.False(t, seen[v])
+			seen[v] = true
+		}
+	})
+
+	t.Run("Each value is equally likely", func(t *testing.T) {
+		q := Of(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+		iter := q.SampleIterator(2, rand.New(rand.NewSource(2)))
+		counts := make([]int, 10)
+
+		for i := 0; i < 5000; i++ {
+			for _, v := range sample(iter) {
+				counts[v]++
+			}
+		}
+
+		// Each value is expected 1000 times.
+		for _, c := range counts {
+			require.True(t, c > 850 && c < 1150)
+		}
+	})
+
+	t.Run("All values when n exceeds count", func(t *testing.T) {
+		require.ElementsMatch(t, []int{1, 2, 3}, sample(Of(1, 2, 3).SampleIterator(5, nil)))
+		require.Empty(t, sample(Of(1, 2, 3).SampleIterator(0, nil)))
+		require.Empty(t, sample(New[int]().SampleIterator(5, nil)))
+	})
+
+	t.Run("Elements refer to the collection", func(t *testing.T) {
+		q := Of(1, 2, 3)
+		iter := q.SampleIterator(3, nil)
+
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			*e.ValuePtr() *= 10
+		}
+
+		require.Equal(t, []int{10, 20, 30}, q.ToSlice())
+	})
+
+	t.Run("Modification panics", func(t *testing.T) {
+		q := Of(1, 2, 3)
+		iter := q.SampleIterator(2, nil)
+		iter.Start()
+		q.Enqueue(4)
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Next() })
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Start() })
+	})
+
+	t.Run("Negative n panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { Of(1).SampleIterator(-1, nil) })
+	})
+}
+
+func TestElementRefresh(t *testing.T) {
+
+	t.Run("Element survives reallocation", func(t *testing.T) {
+		q := New(WithCapacity[int](2))
+		q.AddRange([]int{1, 2})
+		iter := q.Iterator()
+		iter.Start()
+		e := iter.Next()
+		require.True(t, e.IsValid())
+
+		for i := 3; i < 20; i++ {
+			q.Enqueue(i)
+		}
+
+		require.False(t, e.IsValid())
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { e.Value() })
+		require.True(t, e.Refresh())
+		require.True(t, e.IsValid())
+		require.Equal(t, 2, e.Value())
+
+		*e.ValuePtr() = 200
+		require.Equal(t, 200, q.ToSlice()[1])
+	})
+
+	t.Run("In-place modification is tracked", func(t *testing.T) {
+		q := Of(1, 2, 3)
+		e := q.Iterator().Start()
+		*e.ValuePtr() = 10
+		require.True(t, e.IsValid())
+
+		q.Dequeue()
+		q.Enqueue(10)
+		require.True(t, e.Refresh())
+		require.Same(t, &q.buffer[q.bufferIndex(2)], e.ValuePtr())
+	})
+
+	t.Run("Removed value cannot be refreshed", func(t *testing.T) {
+		q := Of(1, 2, 3)
+		e := q.Iterator().Start()
+		q.Dequeue()
+
+		require.False(t, e.Refresh())
+		require.False(t, e.IsValid())
+	})
+}
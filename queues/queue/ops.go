@@ -0,0 +1,98 @@
+package queue
+
+import (
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// ApplyOps applies the given operations to the queue in order.
+// Add enqueues the value, Remove removes the first occurrence of the value
+// searching from the front, and Clear empties the queue.
+//
+// Panics if an op has an invalid kind, or an Add op is applied to a closed queue.
+func (q *Queue[T]) ApplyOps(operations []ops.Op[T]) {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	util.ApplyOps(
+		operations,
+		func(value T) {
+			if q.closed {
+				panic(messages.COLLECTION_CLOSED)
+			}
+			if q.validator.Accept(value) {
+				q.enqueue(value)
+			}
+		},
+		func(value T) {
+			if q.size > 0 {
+				q.remove(value)
+			}
+		},
+		q.clear,
+	)
+}
+
+// StartRecording begins capturing mutations of the queue as ops,
+// discarding any ops previously recorded.
+//
+// Dequeued values are recorded as a Remove of the value, which when replayed
+// removes the value at the front of the queue. Sorting and swapping values
+// are recorded as a Clear followed by an Add of each value.
+func (q *Queue[T]) StartRecording() {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	q.recorder.Start(q.copy)
+}
+
+// StopRecording ends recording and returns the ops recorded since StartRecording.
+func (q *Queue[T]) StopRecording() []ops.Op[T] {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	return q.recorder.Stop()
+}
+
+// IsRecording returns true if the queue is recording mutations.
+func (q *Queue[T]) IsRecording() bool {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	return q.recorder.IsRecording()
+}
@@ -0,0 +1,124 @@
+package queue
+
+import (
+	"sync"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// PartitionInPlace reorders the queue so that all values matching the predicate
+// are nearer the head than those that do not, and returns the number of matching values.
+//
+// After the call, positions 0 to split-1 (counting from the head of the queue) hold the matching values
+// in their original relative order, followed by the non-matching values in their original relative order.
+// O(n), using a buffer for the non-matching values.
+func (q *Queue[T]) PartitionInPlace(predicate functions.PredicateFunc[T]) int {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	// Matching values are moved forward in place, and the values they pass over
+	// are held aside to be copied back after them, so that both keep their order.
+	split := 0
+	moved := false
+	var rest []T
+
+	for i := 0; i < q.size; i++ {
+		value := q.buffer[q.bufferIndex(i)]
+
+		if !predicate(value) {
+			rest = append(rest, value)
+			continue
+		}
+
+		if i != split {
+			q.buffer[q.bufferIndex(split)] = value
+			moved = true
+		}
+
+		split++
+	}
+
+	if moved {
+		for i, value := range rest {
+			q.buffer[q.bufferIndex(split+i)] = value
+		}
+
+		q.version++
+		q.recorder.Reset(func() []T { return q.toSlice(false) })
+	}
+
+	return split
+}
+
+// ExtractWhere removes all values matching the predicate from the queue in a single pass,
+// and returns them as a new queue in their original relative order.
+//
+// Values may be extracted from a queue that has been closed.
+func (q *Queue[T]) ExtractWhere(predicate functions.PredicateFunc[T]) *Queue[T] {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	extracted := q.makeEmptyCopy()
+	kept := 0
+
+	for i := 0; i < q.size; i++ {
+		value := q.buffer[q.bufferIndex(i)]
+
+		if predicate(value) {
+			extracted.enqueue(value)
+		} else {
+			q.buffer[q.bufferIndex(kept)] = value
+			kept++
+		}
+	}
+
+	if extracted.size == 0 {
+		return extracted
+	}
+
+	var empty T
+	for i := kept; i < q.size; i++ {
+		q.buffer[q.bufferIndex(i)] = empty
+	}
+
+	q.size = kept
+	q.tail = q.bufferIndex(kept)
+	q.version++
+
+	if q.weigher != nil {
+		q.weigher.Reset(q.size, q.at)
+	}
+
+	q.recorder.Reset(func() []T { return q.toSlice(false) })
+	return extracted
+}
+
+func (q *Queue[T]) makeEmptyCopy() *Queue[T] {
+	other := New[T](WithComparer[T](q.compare), WithDeepCopy[T](q.copy))
+
+	if q.lock != nil {
+		other.lock = &sync.RWMutex{}
+	}
+
+	other.concurrent = q.concurrent
+	return other
+}
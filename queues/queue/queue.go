@@ -14,11 +14,14 @@ import (
 	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/fireflycons/generic_collections/queues"
+	"github.com/fireflycons/generic_collections/registry"
+	"golang.org/x/exp/constraints"
 )
 
 // Assert Queue implements required interfaces.
 var _ queues.Queue[int] = (*Queue[int])(nil)
 var _ collections.ReverseIterable[int] = (*Queue[int])(nil)
+var _ collections.Pageable[int] = (*Queue[int])(nil)
 
 const (
 	growFactor  = 200
@@ -30,6 +33,8 @@ const (
 type QueueOptionFunc[T any] func(*Queue[T])
 
 // Queue implements a first-in, first-out collection.
+//
+// The zero value is an empty queue with default options, ready to use. It is not thread-safe.
 type Queue[T any] struct {
 	version         int
 	lock            *sync.RWMutex
@@ -39,8 +44,21 @@ type Queue[T any] struct {
 	initialCapacity int
 	compare         functions.ComparerFunc[T]
 	copy            functions.DeepCopyFunc[T]
+	copyPolicy      collections.CopyPolicy
 	buffer          []T
 	concurrent      bool
+	closed          bool
+	recorder        util.OpRecorder[T]
+	guard           util.AccessGuard
+	immutable       util.SliceCache[T]
+	conflator       util.Conflator[T]
+	weigher         *util.Weigher[T]
+	validator       *util.Validator[T]
+	verifySchema    bool
+	compactions     int
+	position        int
+	name            string
+	registration    *registry.Registration
 
 	local.InternalImpl
 }
@@ -57,7 +75,7 @@ func New[T any](options ...QueueOptionFunc[T]) *Queue[T] {
 		o(queue)
 	}
 
-	if queue.copy == nil {
+	if queue.copy == nil || queue.copyPolicy == collections.Shallow {
 		queue.copy = util.DefaultDeepCopy[T]
 	}
 
@@ -67,9 +85,60 @@ func New[T any](options ...QueueOptionFunc[T]) *Queue[T] {
 		queue.compare = util.GetDefaultComparer[T]()
 	}
 
+	queue.register()
+
 	return queue
 }
 
+// lazyInit completes the construction of a zero value Queue on its first modification,
+// as New would have done.
+func (q *Queue[T]) lazyInit() {
+	if q.compare != nil {
+		return
+	}
+
+	q.compare = util.GetZeroValueComparer[T]()
+
+	if q.copy == nil {
+		q.copy = util.DefaultDeepCopy[T]
+	}
+
+	if q.buffer == nil {
+		q.initialCapacity = util.DefaultCapacity
+		q.buffer = make([]T, q.initialCapacity)
+	}
+}
+
+// Of constructs a new queue containing the given values, e.g. queue.Of(1, 2, 3).
+//
+// The values are enqueued in order, so the first value is at the head of the queue.
+func Of[T any](values ...T) *Queue[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	q := queue.OfWith([]queue.QueueOptionFunc[int]{queue.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []QueueOptionFunc[T], values ...T) *Queue[T] {
+	q := New(options...)
+	q.AddRange(values)
+	return q
+}
+
+// NewFunc is as [New], using compare to find values, e.g. in Contains and Sort.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...QueueOptionFunc[T]) *Queue[T] {
+	return New(append([]QueueOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...QueueOptionFunc[T]) *Queue[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
 // Option function for New to make the collection thread-safe. Adds overhead.
 func WithThreadSafe[T any]() QueueOptionFunc[T] {
 	return func(s *Queue[T]) {
@@ -114,13 +183,107 @@ func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) QueueOptionFunc[T] {
 	}
 }
 
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) QueueOptionFunc[T] {
+	return func(q *Queue[T]) {
+		q.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+func WithOptions[T any](options collections.CommonOptions[T]) QueueOptionFunc[T] {
+	opts := make([]QueueOptionFunc[T], 0, 6)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Concurrent {
+		opts = append(opts, WithConcurrent[T]())
+	}
+
+	if options.Capacity != 0 {
+		opts = append(opts, WithCapacity[T](options.Capacity))
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(q *Queue[T]) {
+		for _, o := range opts {
+			o(q)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection,
+// ReplaceAll, Enqueue, EnqueueBatch and Offer with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) QueueOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(q *Queue[T]) {
+		q.validator = util.NewValidator(validator, policy)
+	}
+}
+
+// Option function for New to conflate values by key. When a value is enqueued
+// with the same key as a value already in the queue, the queued value is replaced
+// in place, keeping its position, rather than the new value being added.
+// This bounds the size of the queue to the number of distinct keys, e.g. when only
+// the latest of a burst of updates for each key needs to be processed.
+//
+//	q := queue.New(queue.WithConflation(func(t Tick) string { return t.Symbol }))
+//
+// Panics if keyFn is nil.
+func WithConflation[T any, K comparable](keyFn func(T) K) QueueOptionFunc[T] {
+	if keyFn == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "keyFn"))
+	}
+	return func(q *Queue[T]) {
+		q.conflator = util.NewConflator(keyFn)
+	}
+}
+
 // Add enqueues a value in the queue.
 //
-// Always returns true.
+// Returns true unless the queue has been closed, the value was rejected by the validator,
+// or the value replaced a queued value with the same key when conflating.
 func (q *Queue[T]) Add(value T) bool {
 
-	q.Enqueue(value)
-	return true
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	if q.closed || !q.validator.Accept(value) {
+		return false
+	}
+
+	return q.enqueue(value)
 }
 
 // AddCollection adds the values of the given collection to the end of this queue.
@@ -128,10 +291,74 @@ func (q *Queue[T]) Add(value T) bool {
 // Values are added in the order defined by the other collection.
 func (q *Queue[T]) AddCollection(collection collections.Collection[T]) {
 
-	q.AddRange(collection.ToSliceDeep())
+	q.AddRange(util.ImportValues(collection, q.copyPolicy))
+}
+
+// ReplaceAll replaces the content of the queue with the values of the given collection.
+// Values are enqueued in the order defined by the other collection.
+//
+// Panics if the queue has been closed.
+func (q *Queue[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	q.replaceAll(util.ImportValues(collection, q.copyPolicy))
+}
+
+// replaceAll replaces the values of the queue with the given values under a single lock.
+func (q *Queue[T]) replaceAll(values []T) {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+	q.setValues(values)
+}
+
+// setValues replaces the values of the queue with the given values.
+func (q *Queue[T]) setValues(values []T) {
+
+	if q.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	values = q.validator.Filter(values)
+
+	if q.conflator != nil || q.weigher != nil {
+		// Values must be conflated or weighed as they are enqueued.
+		q.clear()
+
+		for _, v := range values {
+			q.enqueue(v)
+		}
+
+		return
+	}
+
+	if len(values) > len(q.buffer) {
+		q.buffer = make([]T, len(values))
+	} else {
+		var empty T
+		for i := range q.buffer {
+			q.buffer[i] = empty
+		}
+	}
+
+	copy(q.buffer, values)
+	q.head = 0
+	q.size = len(values)
+	q.tail = util.Iif(q.size == len(q.buffer), 0, q.size)
+	q.version++
+	q.recorder.Reset(func() []T { return values })
 }
 
 // AddRange enqueues the values in the given slice.
+//
+// Panics if the queue has been closed.
 func (q *Queue[T]) AddRange(values []T) {
 
 	if len(values) == 0 {
@@ -143,6 +370,34 @@ func (q *Queue[T]) AddRange(values []T) {
 		defer q.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	if q.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	if values = q.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
+	if q.conflator != nil || q.weigher != nil {
+		for _, v := range values {
+			q.enqueue(v)
+		}
+
+		return
+	}
+
+	for _, v := range values {
+		q.recorder.Add(v)
+	}
+
+	q.version++
+
 	var newBufferSize int
 
 	lv := len(values)
@@ -178,10 +433,13 @@ func (q *Queue[T]) Clear() {
 		defer q.lock.Unlock()
 	}
 
-	q.buffer = make([]T, cap(q.buffer))
-	q.head = 0
-	q.tail = 0
-	q.size = 0
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	q.clear()
 }
 
 // Contains returns true if the given value is in the queue; else false.
@@ -192,6 +450,10 @@ func (q *Queue[T]) Contains(value T) bool {
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	return q.find(value) != -1
 }
 
@@ -207,7 +469,7 @@ func (q *Queue[T]) IsEmpty() bool {
 
 // Dequeue removes the value at the front of the queue and returns it.
 //
-// Panics if the queue is empty.
+// Panics if the queue is empty, or if the queue is closed and has been drained.
 func (q *Queue[T]) Dequeue() T {
 
 	if q.lock != nil {
@@ -215,7 +477,16 @@ func (q *Queue[T]) Dequeue() T {
 		defer q.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
 	if q.size == 0 {
+		if q.closed {
+			panic(messages.COLLECTION_CLOSED)
+		}
 		panic(messages.COLLECTION_EMPTY)
 	}
 
@@ -231,6 +502,12 @@ func (q *Queue[T]) TryDequeue() (T, bool) {
 		defer q.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
 	if q.size == 0 {
 		var empty T
 		return empty, false
@@ -240,6 +517,9 @@ func (q *Queue[T]) TryDequeue() (T, bool) {
 }
 
 // Enqueue adds a value to the back of the queue.
+// When conflating, a queued value with the same key is replaced instead.
+//
+// Panics if the queue has been closed.
 func (q *Queue[T]) Enqueue(value T) {
 
 	if q.lock != nil {
@@ -247,7 +527,121 @@ func (q *Queue[T]) Enqueue(value T) {
 		defer q.lock.Unlock()
 	}
 
-	q.enqueue(value)
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	if q.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	if q.validator.Accept(value) {
+		q.enqueue(value)
+	}
+}
+
+// EnqueueBatch adds the values of the given slice to the back of the queue in slice order,
+// so values[0] is dequeued first. Under a single acquisition of the lock, the backing
+// slice is grown at most once and the version is incremented once, so values enqueued
+// concurrently by other goroutines are never interleaved with the batch.
+// When conflating, each value is conflated as per Enqueue.
+//
+// Panics if the queue has been closed.
+func (q *Queue[T]) EnqueueBatch(values []T) {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	if q.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	if values = q.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
+	version := q.version
+
+	if q.conflator != nil || q.weigher != nil {
+		for _, v := range values {
+			q.enqueue(v)
+		}
+
+		q.version = version + 1
+		return
+	}
+
+	if required := q.size + len(values); required > len(q.buffer) {
+		newCapacity := len(q.buffer) * growFactor / 100
+
+		if newCapacity < len(q.buffer)+minimumGrow {
+			newCapacity = len(q.buffer) + minimumGrow
+		}
+
+		if newCapacity < required {
+			newCapacity = required
+		}
+
+		q.setLength(newCapacity)
+	}
+
+	// Copy in at most two parts, wrapping around the end of the buffer.
+	n := copy(q.buffer[q.tail:], values)
+	copy(q.buffer, values[n:])
+
+	for _, v := range values {
+		q.recorder.Add(v)
+	}
+
+	q.size += len(values)
+	q.tail = (q.tail + len(values)) % len(q.buffer)
+	q.version = version + 1
+}
+
+// Close marks the queue as closed. No further values may be enqueued,
+// however values already in the queue may still be dequeued.
+//
+// Once the queue is drained, Dequeue panics indicating closure
+// and TryDequeue returns false.
+func (q *Queue[T]) Close() {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	q.closed = true
+}
+
+// IsClosed returns true if the queue has been closed.
+func (q *Queue[T]) IsClosed() bool {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	return q.closed
 }
 
 // Peek returns the value at the front of the queue without removing it.
@@ -260,6 +654,10 @@ func (q *Queue[T]) Peek() T {
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	if q.size == 0 {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -276,6 +674,10 @@ func (q *Queue[T]) TryPeek() (T, bool) {
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	if q.size == 0 {
 		var empty T
 		return empty, false
@@ -284,6 +686,31 @@ func (q *Queue[T]) TryPeek() (T, bool) {
 	return q.buffer[q.head], true
 }
 
+// Page returns a copy of the values on the given zero-based page of the queue,
+// counting from the front of the queue.
+//
+// Panics if pageIndex is negative or pageSize is less than 1.
+func (q *Queue[T]) Page(pageIndex, pageSize int) []T {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	start, end := util.PageBounds(q.size, pageIndex, pageSize)
+	page := make([]T, end-start)
+
+	for i := range page {
+		page[i] = q.buffer[(q.head+start+i)%len(q.buffer)]
+	}
+
+	return page
+}
+
 // Remove removes the first occurrence of the given value from the queue, searching from front.
 //
 // Returns true if the value was present and was removed; else false.
@@ -298,51 +725,13 @@ func (q *Queue[T]) Remove(value T) bool {
 		defer q.lock.Unlock()
 	}
 
-	index := q.find(value)
-
-	if index == -1 {
-		return false
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
 	}
 
-	var empty T
-	q.buffer[index] = empty
-	q.size--
-	buf := make([]T, len(q.buffer))
-
-	switch {
-	case q.head < q.tail || (q.head == 0 && q.tail == 0):
-		util.PartialCopy(q.buffer, 0, buf, 0, index)
-		util.PartialCopy(q.buffer, index+1, buf, index, len(q.buffer)-index-1)
-		if q.head < q.tail {
-			q.tail--
-		}
-	case index == q.head:
-		q.head++
-		headToEnd := q.size - q.head + (q.head - q.tail)
-		util.PartialCopy(q.buffer, q.head, buf, 0, headToEnd)
-		util.PartialCopy(q.buffer, 0, buf, headToEnd, q.tail)
-		q.head = 0
-		q.tail = q.size
-	case index > q.head:
-		removedToEnd := q.size - index + util.Iif(q.tail < q.head, q.head-q.tail, 0)
-		util.PartialCopy(q.buffer, q.head, buf, 0, index-q.head)
-		util.PartialCopy(q.buffer, index+1, buf, index-q.head, removedToEnd)
-		util.PartialCopy(q.buffer, 0, buf, index-q.head+removedToEnd, q.tail)
-		q.head = 0
-		q.tail = q.size
-	case q.head > q.tail && q.tail > index:
-		headToEnd := q.size - q.head + (q.head - q.tail + 1)
-		util.PartialCopy(q.buffer, q.head, buf, 0, headToEnd)
-		util.PartialCopy(q.buffer, 0, buf, headToEnd, index)
-		util.PartialCopy(q.buffer, index+1, buf, headToEnd+index, q.tail-index-1)
-		q.head = 0
-		q.tail = q.size
-	default:
-		panic(fmt.Sprintf("BUG: Queue.Remove - Head: %d, tail %d, index: %d", q.head, q.tail, index))
-	}
+	q.lazyInit()
 
-	q.buffer = buf
-	return true
+	return q.remove(value)
 }
 
 // ToSlice returns a copy of the queue content as a slice.
@@ -352,9 +741,47 @@ func (q *Queue[T]) ToSlice() []T {
 		q.lock.Lock()
 		defer q.lock.Unlock()
 	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
 	return q.toSlice(false)
 }
 
+// ToImmutableSlice returns the content of the queue as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the queue has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified. Values modified in place, e.g. via [collections.Element.ValuePtr],
+// are not reflected until the queue is otherwise modified.
+func (q *Queue[T]) ToImmutableSlice() []T {
+
+	if q.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		q.lock.RLock()
+		values, ok := q.immutable.Load(q.version)
+		q.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	return q.immutable.Get(q.version, func() []T { return q.toSlice(false) })
+}
+
 // ToSliceDeep returns a copy of the queue content as a slice using the provided [functions.DeepCopyFunc] if any.
 func (q *Queue[T]) ToSliceDeep() []T {
 
@@ -363,7 +790,13 @@ func (q *Queue[T]) ToSliceDeep() []T {
 		defer q.lock.Unlock()
 	}
 
-	return q.toSlice(false)
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	return q.toSlice(true)
 }
 
 func (q *Queue[T]) toSlice(deepCopy bool) []T {
@@ -385,6 +818,10 @@ func (q *Queue[T]) String() string {
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	var values []string
 	for _, value := range q.toSlice(false) {
 		values = append(values, fmt.Sprintf("%v", value))
@@ -413,6 +850,11 @@ func (q *Queue[T]) setLength(capacity int) {
 
 	q.copyTo(newBuffer, false)
 	q.buffer = newBuffer
+
+	if q.head != 0 {
+		q.compactions++
+	}
+
 	q.head = 0
 	q.tail = util.Iif(q.size == capacity, 0, q.size)
 	q.version++
@@ -422,9 +864,9 @@ func (q *Queue[T]) copyTo(slc []T, deepCopy bool) {
 	if q.size > 0 {
 		if q.head < q.tail || (q.head == 0 && q.tail == 0) {
 			if deepCopy {
-				util.DeepCopySlice(slc, q.buffer, q.copy)
+				util.DeepCopySlice(slc, q.buffer[q.head:q.head+q.size], q.copy)
 			} else {
-				copy(slc, q.buffer)
+				copy(slc, q.buffer[q.head:q.head+q.size])
 			}
 		} else {
 			headToEnd := q.size - q.head + (q.head - q.tail)
@@ -439,7 +881,22 @@ func (q *Queue[T]) copyTo(slc []T, deepCopy bool) {
 	}
 }
 
-func (q *Queue[T]) enqueue(value T) {
+// enqueue adds a value to the back of the queue, returning false
+// if it instead replaced a queued value with the same key.
+func (q *Queue[T]) enqueue(value T) bool {
+	if q.conflator != nil {
+		if i := q.conflator.Find(value, q.size, q.at); i != -1 {
+			if q.weigher != nil {
+				q.weigher.Adjust(q.weigher.Weigh(value) - q.weigher.Weigh(q.at(i)))
+			}
+
+			q.buffer[q.bufferIndex(i)] = value
+			q.version++
+			q.recorder.Reset(func() []T { return q.toSlice(false) })
+			return false
+		}
+	}
+
 	if q.size == len(q.buffer) {
 		newCapacity := len(q.buffer) * growFactor / 100
 		if newCapacity < len(q.buffer)+minimumGrow {
@@ -452,15 +909,43 @@ func (q *Queue[T]) enqueue(value T) {
 	q.tail = (q.tail + 1) % len(q.buffer)
 	q.size++
 	q.version++
+	q.recorder.Add(value)
+
+	if q.conflator != nil {
+		q.conflator.Appended(value, q.size, q.at)
+	}
+
+	if q.weigher != nil {
+		q.weigher.Adjust(q.weigher.Weigh(value))
+	}
+
+	return true
+}
+
+// at returns the value at the given logical index.
+func (q *Queue[T]) at(index int) T {
+	return q.buffer[q.bufferIndex(index)]
 }
 
 func (q *Queue[T]) removeItem() T {
 	var empty T
 	removed := q.buffer[q.head]
+
+	if q.conflator != nil {
+		q.conflator.Removed(removed, q.size)
+	}
+
+	if q.weigher != nil {
+		q.weigher.Adjust(-q.weigher.Weigh(removed))
+	}
+
 	q.buffer[q.head] = empty
 	q.head = (q.head + 1) % len(q.buffer)
 	q.size--
+	q.position++
+	q.resetIfEmpty()
 	q.version++
+	q.recorder.Remove(removed)
 	return removed
 }
 
@@ -473,6 +958,7 @@ func (q *Queue[T]) makeDeepCopy() *Queue[T] {
 		initialCapacity: q.initialCapacity,
 		compare:         q.compare,
 		copy:            q.copy,
+		copyPolicy:      q.copyPolicy,
 	}
 
 	if q.lock != nil {
@@ -483,3 +969,59 @@ func (q *Queue[T]) makeDeepCopy() *Queue[T] {
 	util.DeepCopySlice(other.buffer, q.buffer, q.copy)
 	return other
 }
+
+func (q *Queue[T]) remove(value T) bool {
+	index := q.find(value)
+
+	if index == -1 {
+		return false
+	}
+
+	if q.weigher != nil {
+		q.weigher.Adjust(-q.weigher.Weigh(q.buffer[index]))
+	}
+
+	// Close the gap in place by shifting whichever side
+	// of the removed value holds fewer values.
+	position := (index - q.head + len(q.buffer)) % len(q.buffer)
+	var empty T
+
+	if position < q.size/2 {
+		for i := position; i > 0; i-- {
+			q.buffer[q.bufferIndex(i)] = q.buffer[q.bufferIndex(i-1)]
+		}
+
+		q.buffer[q.head] = empty
+		q.head = (q.head + 1) % len(q.buffer)
+	} else {
+		for i := position; i < q.size-1; i++ {
+			q.buffer[q.bufferIndex(i)] = q.buffer[q.bufferIndex(i+1)]
+		}
+
+		q.tail = (q.tail - 1 + len(q.buffer)) % len(q.buffer)
+		q.buffer[q.tail] = empty
+	}
+
+	q.size--
+	q.resetIfEmpty()
+	q.version++
+	q.recorder.Remove(value)
+	return true
+}
+
+func (q *Queue[T]) clear() {
+	q.buffer = make([]T, cap(q.buffer))
+	q.head = 0
+	q.tail = 0
+	q.size = 0
+	q.version++
+	q.recorder.Clear()
+
+	if q.conflator != nil {
+		q.conflator.Reset(0, nil)
+	}
+
+	if q.weigher != nil {
+		q.weigher.Reset(0, nil)
+	}
+}
@@ -1,13 +1,21 @@
 package queue
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/ops"
 	"github.com/stretchr/testify/require"
 )
 
@@ -205,6 +213,21 @@ func TestToSlice(t *testing.T) {
 		util.PartialCopy(additionalItems, 0, tempItems2, arraySize, additionalArraySize)
 		require.Equal(t, slc, tempItems2)
 	})
+
+	t.Run("Dequeued only", func(t *testing.T) {
+
+		queue = New[int]()
+
+		for _, v := range queueItems {
+			queue.Enqueue(v)
+		}
+
+		queue.Dequeue()
+		queue.Dequeue()
+
+		require.Equal(t, queueItems[2:], queue.ToSlice())
+		require.Equal(t, queueItems[2:], queue.ToSliceDeep())
+	})
 }
 
 func TestQueueTryOperations(t *testing.T) {
@@ -590,6 +613,139 @@ func TestRemove(t *testing.T) {
 	})
 }
 
+func TestReplaceAll(t *testing.T) {
+
+	seed := int64(2163)
+	queueItems := util.CreateSingleIntListData(util.DefaultCapacity, &seed)
+	replacementItems := util.CreateSingleIntListData(util.DefaultCapacity/2, &seed)
+
+	t.Run("Smaller collection reuses buffer", func(t *testing.T) {
+		queue := New[int]()
+		queue.AddRange(queueItems)
+		bufferLength := len(queue.buffer)
+		queue.ReplaceAll(dlist.New[int]())
+		verifyQueueState(t, queue, []int{})
+
+		other := dlist.New[int]()
+		other.AddRange(replacementItems)
+		queue.ReplaceAll(other)
+		require.Equal(t, bufferLength, len(queue.buffer))
+		verifyQueueState(t, queue, replacementItems)
+	})
+
+	t.Run("Larger collection grows buffer", func(t *testing.T) {
+		queue := New(WithCapacity[int](4))
+		queue.AddRange(replacementItems[:2])
+		other := dlist.New[int]()
+		other.AddRange(queueItems)
+		queue.ReplaceAll(other)
+		verifyQueueState(t, queue, queueItems)
+		queue.Enqueue(replacementItems[0])
+		require.Equal(t, len(queueItems)+1, queue.Count())
+	})
+
+	t.Run("Wrapped queue is replaced", func(t *testing.T) {
+		queue := New[int]()
+		expectedItems := removeAndReAdd(&queue, queueItems)
+		verifyQueueState(t, queue, expectedItems)
+		other := dlist.New[int]()
+		other.AddRange(replacementItems)
+		queue.ReplaceAll(other)
+		verifyQueueState(t, queue, replacementItems)
+	})
+
+	t.Run("ReplaceAll on closed queue panics", func(t *testing.T) {
+		queue := New[int]()
+		queue.Close()
+		require.Panics(t, func() { queue.ReplaceAll(dlist.New[int]()) })
+	})
+}
+
+func TestPage(t *testing.T) {
+
+	seed := int64(2163)
+	queueItems := util.CreateSingleIntListData(10, &seed)
+
+	t.Run("Page of empty queue is empty", func(t *testing.T) {
+		queue := New[int]()
+		require.Equal(t, []int{}, queue.Page(0, 4))
+	})
+
+	t.Run("Pages are returned from front of queue", func(t *testing.T) {
+		queue := New[int]()
+		queue.AddRange(queueItems)
+		require.Equal(t, queueItems[0:4], queue.Page(0, 4))
+		require.Equal(t, queueItems[4:8], queue.Page(1, 4))
+		require.Equal(t, queueItems[8:], queue.Page(2, 4))
+		require.Equal(t, []int{}, queue.Page(3, 4))
+	})
+
+	t.Run("Pages are correct when buffer has wrapped", func(t *testing.T) {
+		queue := New(WithCapacity[int](len(queueItems)))
+		queue.AddRange(queueItems)
+
+		for i := 0; i < 4; i++ {
+			queue.Enqueue(queue.Dequeue())
+		}
+
+		expected := append(append([]int{}, queueItems[4:]...), queueItems[:4]...)
+		require.Equal(t, expected[0:4], queue.Page(0, 4))
+		require.Equal(t, expected[4:8], queue.Page(1, 4))
+		require.Equal(t, expected[8:], queue.Page(2, 4))
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		queue := New[int]()
+		require.Panics(t, func() { queue.Page(-1, 4) })
+		require.Panics(t, func() { queue.Page(0, 0) })
+	})
+}
+
+func TestClose(t *testing.T) {
+
+	seed := int64(2163)
+	queueItems := util.CreateSingleIntListData(util.DefaultCapacity, &seed)
+
+	t.Run("New queue is not closed", func(t *testing.T) {
+		queue := New[int]()
+		require.False(t, queue.IsClosed())
+	})
+
+	t.Run("Add to closed queue returns false", func(t *testing.T) {
+		queue := New[int]()
+		queue.Close()
+		require.True(t, queue.IsClosed())
+		require.False(t, queue.Add(1))
+		verifyQueueState(t, queue, []int{})
+	})
+
+	t.Run("Enqueue to closed queue panics", func(t *testing.T) {
+		queue := New[int]()
+		queue.Close()
+		require.Panics(t, func() { queue.Enqueue(1) })
+	})
+
+	t.Run("AddRange to closed queue panics", func(t *testing.T) {
+		queue := New[int]()
+		queue.Close()
+		require.Panics(t, func() { queue.AddRange(queueItems) })
+	})
+
+	t.Run("Closed queue drains remaining items", func(t *testing.T) {
+		queue := New[int]()
+		queue.AddRange(queueItems)
+		queue.Close()
+
+		for _, v := range queueItems {
+			require.Equal(t, v, queue.Dequeue())
+		}
+
+		_, ok := queue.TryDequeue()
+		require.False(t, ok)
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { queue.Dequeue() })
+	})
+}
+
 func TestQueue_Negative(t *testing.T) {
 
 	t.Run("Dequeue on empty queue panics", func(t *testing.T) {
@@ -700,6 +856,46 @@ func TestQueueAddRange(t *testing.T) {
 	})
 }
 
+func TestBulkAdd(t *testing.T) {
+
+	t.Run("Enqueues batches in order", func(t *testing.T) {
+		q := New(WithThreadSafe[int]())
+		src := make(chan []int, 2)
+
+		go func() {
+			for i := 0; i < 100; i += 10 {
+				batch := make([]int, 10)
+				for j := range batch {
+					batch[j] = i + j
+				}
+				src <- batch
+			}
+			close(src)
+		}()
+
+		require.NoError(t, q.BulkAdd(context.Background(), src))
+		require.Equal(t, 100, q.Count())
+
+		for i := 0; i < 100; i++ {
+			require.Equal(t, i, q.Dequeue())
+		}
+	})
+
+	t.Run("Stops when context cancelled", func(t *testing.T) {
+		q := New[int]()
+		ctx, cancel := context.WithCancel(context.Background())
+		src := make(chan []int)
+
+		go func() {
+			src <- []int{1, 2}
+			cancel()
+		}()
+
+		require.ErrorIs(t, q.BulkAdd(ctx, src), context.Canceled)
+		require.Equal(t, []int{1, 2}, q.ToSlice())
+	})
+}
+
 func TestUnsafe(t *testing.T) {
 
 	t.Run("GetVersion", func(t *testing.T) {
@@ -788,6 +984,25 @@ func verifyQueueState[T any](t *testing.T, queue *Queue[T], expectedItems []T) {
 	require.Equal(t, expectedItems, items)
 }
 
+// benchmarkBulkAdd feeds data to the queue through BulkAdd in batches of 1000,
+// with at most four batches waiting.
+func benchmarkBulkAdd(s *Queue[int], data []int) {
+	src := make(chan []int, 4)
+
+	go func() {
+		for start := 0; start < len(data); start += 1000 {
+			end := start + 1000
+			if end > len(data) {
+				end = len(data)
+			}
+			src <- data[start:end]
+		}
+		close(src)
+	}()
+
+	_ = s.BulkAdd(context.Background(), src)
+}
+
 func benchmarkEnqueue(s *Queue[int], data []int) {
 	for _, v := range data {
 		s.Enqueue(v)
@@ -848,6 +1063,22 @@ func BenchmarkQueue(b *testing.B) {
 			})
 		}
 
+		for _, elems := range elements {
+			b.Run(fmt.Sprintf("Queue-BulkAdd-%d-%s-NoPresize-NA", elems, util.Iif(threadsafe, "ThreadSafe", "NoThreadSafe")), func(b *testing.B) {
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					if threadsafe {
+						q = New(WithThreadSafe[int]())
+					} else {
+						q = New[int]()
+					}
+					b.StartTimer()
+					benchmarkBulkAdd(q, data[elems])
+				}
+			})
+		}
+
 		for _, elems := range elements {
 			b.Run(fmt.Sprintf("Queue-Dequeue-%d-%s-NA-NA", elems, util.Iif(threadsafe, "ThreadSafe", "NoThreadSafe")), func(b *testing.B) {
 				b.ResetTimer()
@@ -929,3 +1160,948 @@ func BenchmarkQueue(b *testing.B) {
 		}
 	})
 }
+
+func TestIndexable(t *testing.T) {
+
+	t.Run("At returns values from head of queue", func(t *testing.T) {
+		var q *Queue[int]
+		expected := createGappedQueue(&q, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+		for i, v := range expected {
+			require.Equal(t, v, q.At(i))
+		}
+	})
+
+	t.Run("Swap exchanges values", func(t *testing.T) {
+		q := New[int]()
+		q.AddRange([]int{1, 2, 3})
+		q.Swap(0, 2)
+		require.Equal(t, []int{3, 2, 1}, q.ToSlice())
+	})
+
+	t.Run("CompareAt compares values", func(t *testing.T) {
+		q := New[int]()
+		q.AddRange([]int{1, 2, 3})
+		require.Less(t, q.CompareAt(0, 1), 0)
+		require.Greater(t, q.CompareAt(2, 1), 0)
+		require.Equal(t, 0, q.CompareAt(1, 1))
+	})
+
+	t.Run("Index out of range panics", func(t *testing.T) {
+		q := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "index"), func() { q.At(0) })
+		q.AddRange([]int{1, 2, 3})
+		require.Panics(t, func() { q.At(-1) })
+		require.Panics(t, func() { q.Swap(0, 3) })
+		require.Panics(t, func() { q.CompareAt(3, 0) })
+	})
+}
+
+func TestApplyOps(t *testing.T) {
+
+	t.Run("Script is applied in order", func(t *testing.T) {
+		q := New[int]()
+		q.Enqueue(10)
+		q.ApplyOps([]ops.Op[int]{
+			ops.Clear[int](),
+			ops.Add(1),
+			ops.Add(2),
+			ops.Add(1),
+			ops.Remove(1),
+			ops.Remove(5),
+		})
+
+		require.Equal(t, []int{2, 1}, q.ToSlice())
+	})
+
+	t.Run("Invalid op kind panics", func(t *testing.T) {
+		q := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.OP_KIND_INVALID_FMT, 9), func() {
+			q.ApplyOps([]ops.Op[int]{ops.Add(1), {Kind: 9}})
+		})
+		require.Equal(t, []int{1}, q.ToSlice())
+	})
+
+	t.Run("Add to closed queue panics", func(t *testing.T) {
+		q := New[int]()
+		q.Close()
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() {
+			q.ApplyOps([]ops.Op[int]{ops.Add(1)})
+		})
+	})
+}
+
+func TestRecording(t *testing.T) {
+
+	t.Run("Replaying recorded ops reproduces queue", func(t *testing.T) {
+		q := New[int]()
+		q.AddRange([]int{9, 8})
+		q.StartRecording()
+		require.True(t, q.IsRecording())
+
+		for i := 0; i < 20; i++ {
+			q.Enqueue(i)
+		}
+		q.AddRange([]int{2, 3, 2})
+		q.Dequeue()
+		q.TryDequeue()
+		q.Remove(2)
+		q.Swap(0, 3)
+		q.Sort()
+		q.Dequeue()
+		q.ReplaceAll(New[int]())
+		q.Add(5)
+
+		recorded := q.StopRecording()
+		require.False(t, q.IsRecording())
+
+		replica := New[int]()
+		replica.AddRange([]int{9, 8})
+		replica.ApplyOps(recorded)
+		require.Equal(t, q.ToSlice(), replica.ToSlice())
+	})
+
+	t.Run("Enqueue and dequeue are recorded compactly", func(t *testing.T) {
+		q := New[int]()
+		q.StartRecording()
+		q.Enqueue(1)
+		q.Enqueue(2)
+		q.Dequeue()
+		q.Clear()
+
+		require.Equal(t, []ops.Op[int]{ops.Add(1), ops.Add(2), ops.Remove(1), ops.Clear[int]()}, q.StopRecording())
+	})
+}
+
+func TestPartitionInPlace(t *testing.T) {
+
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Matching values are moved to head", func(t *testing.T) {
+		var q *Queue[int]
+		createGappedQueue(&q, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		split := q.PartitionInPlace(isEven)
+		require.Equal(t, 7, split)
+
+		// Gapped queue is 5..16, 1, 2
+		values := q.ToSlice()
+		require.Equal(t, []int{6, 8, 10, 12, 14, 16, 2}, values[:split])
+		require.Equal(t, []int{5, 7, 9, 11, 13, 15, 1}, values[split:])
+	})
+
+	t.Run("Non-matching values keep their order", func(t *testing.T) {
+		q := New[int]()
+		q.AddRange([]int{1, 3, 2})
+		require.Equal(t, 1, q.PartitionInPlace(isEven))
+		require.Equal(t, []int{2, 1, 3}, q.ToSlice())
+	})
+
+	t.Run("No match returns zero and leaves queue unchanged", func(t *testing.T) {
+		q := New[int]()
+		q.AddRange([]int{1, 3, 5})
+		iter := q.Iterator()
+		iter.Start()
+		require.Equal(t, 0, q.PartitionInPlace(isEven))
+		require.Equal(t, []int{1, 3, 5}, q.ToSlice())
+		require.NotPanics(t, func() { iter.Next() })
+	})
+
+	t.Run("Replaying recorded ops reproduces queue", func(t *testing.T) {
+		q := New[int]()
+		q.AddRange([]int{1, 2, 3, 4})
+		q.StartRecording()
+		q.PartitionInPlace(isEven)
+		q1 := New[int]()
+		q1.ApplyOps(q.StopRecording())
+		require.Equal(t, q.ToSlice(), q1.ToSlice())
+	})
+}
+
+func TestExtractWhere(t *testing.T) {
+
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Matching values are extracted in order", func(t *testing.T) {
+		var q *Queue[int]
+		createGappedQueue(&q, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		extracted := q.ExtractWhere(isEven)
+
+		// Gapped queue is 5..16, 1, 2
+		require.Equal(t, []int{6, 8, 10, 12, 14, 16, 2}, extracted.ToSlice())
+		require.Equal(t, []int{5, 7, 9, 11, 13, 15, 1}, q.ToSlice())
+
+		q.Enqueue(17)
+		require.Equal(t, 5, q.Dequeue())
+		require.Equal(t, []int{7, 9, 11, 13, 15, 1, 17}, q.ToSlice())
+	})
+
+	t.Run("All values extracted leaves empty queue", func(t *testing.T) {
+		q := New[int]()
+		q.AddRange([]int{2, 4})
+		require.Equal(t, []int{2, 4}, q.ExtractWhere(isEven).ToSlice())
+		require.True(t, q.IsEmpty())
+		q.Enqueue(1)
+		require.Equal(t, []int{1}, q.ToSlice())
+	})
+
+	t.Run("Extracted queue inherits thread safety", func(t *testing.T) {
+		q := New[int](WithThreadSafe[int]())
+		q.AddRange([]int{1, 2})
+		require.NotNil(t, q.ExtractWhere(isEven).lock)
+	})
+
+	t.Run("Values can be extracted from closed queue", func(t *testing.T) {
+		q := New[int]()
+		q.AddRange([]int{1, 2, 3})
+		q.Close()
+		require.Equal(t, []int{2}, q.ExtractWhere(isEven).ToSlice())
+		require.Equal(t, []int{1, 3}, q.ToSlice())
+	})
+
+	t.Run("Replaying recorded ops reproduces queue", func(t *testing.T) {
+		q := New[int]()
+		q.AddRange([]int{1, 2, 3, 4})
+		q.StartRecording()
+		q.ExtractWhere(isEven)
+		q1 := New[int]()
+		q1.ApplyOps(q.StopRecording())
+		require.Equal(t, q.ToSlice(), q1.ToSlice())
+	})
+}
+
+func TestCloneInto(t *testing.T) {
+
+	t.Run("Destination receives copy of values", func(t *testing.T) {
+		var q *Queue[int]
+		expected := createGappedQueue(&q, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		var dst *Queue[int]
+		createGappedQueue(&dst, []int{20, 21, 22, 23, 24, 25})
+		q.CloneInto(dst)
+
+		require.Equal(t, expected, dst.ToSlice())
+		dst.Enqueue(17)
+		require.Equal(t, expected[0], dst.Dequeue())
+		require.Equal(t, append(expected[1:], 17), dst.ToSlice())
+		require.Equal(t, expected, q.ToSlice())
+	})
+
+	t.Run("Destination storage is reused", func(t *testing.T) {
+		var q *Queue[int]
+		createGappedQueue(&q, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		dst := New[int]()
+		q.CloneInto(dst)
+
+		if util.Debug {
+			t.Skip("assertions allocate in debug builds")
+		}
+
+		allocs := testing.AllocsPerRun(10, func() { q.CloneInto(dst) })
+		require.Zero(t, allocs)
+	})
+
+	t.Run("Nil destination panics", func(t *testing.T) {
+		q := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dst"), func() { q.CloneInto(nil) })
+	})
+
+}
+
+func TestOf(t *testing.T) {
+
+	q := Of(1, 2, 3)
+	require.Equal(t, 1, q.Dequeue())
+	require.Equal(t, []int{2, 3}, q.ToSlice())
+
+	q = OfWith([]QueueOptionFunc[int]{WithThreadSafe[int]()}, 1, 2)
+	require.NotNil(t, q.lock)
+	require.Equal(t, 2, q.Count())
+	require.True(t, Of[int]().IsEmpty())
+}
+
+func TestConflation(t *testing.T) {
+
+	type tick struct {
+		symbol string
+		price  int
+	}
+
+	bySymbol := func(t tick) string { return t.symbol }
+	byPrice := WithComparer(func(a, b tick) int { return a.price - b.price })
+
+	t.Run("Latest value replaces queued value in place", func(t *testing.T) {
+		q := New(WithConflation(bySymbol), byPrice)
+		require.True(t, q.Add(tick{"A", 1}))
+		q.Enqueue(tick{"B", 1})
+		require.False(t, q.Add(tick{"A", 2}))
+		q.AddRange([]tick{{"C", 1}, {"B", 2}})
+
+		require.Equal(t, []tick{{"A", 2}, {"B", 2}, {"C", 1}}, q.ToSlice())
+		require.Equal(t, tick{"A", 2}, q.Dequeue())
+
+		// A is no longer queued, so is added at the back.
+		q.Enqueue(tick{"A", 3})
+		require.Equal(t, []tick{{"B", 2}, {"C", 1}, {"A", 3}}, q.ToSlice())
+	})
+
+	t.Run("Removal from middle of queue", func(t *testing.T) {
+		q := New(WithConflation(func(v int) int { return v % 10 }))
+		q.AddRange([]int{1, 2, 3, 4})
+		require.True(t, q.Remove(2))
+		q.Enqueue(14)
+		q.Enqueue(12)
+		require.Equal(t, []int{1, 3, 14, 12}, q.ToSlice())
+	})
+
+	t.Run("Matches model under random operations", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(2163))
+		q := New(WithConflation(func(v int) int { return v % 20 }), WithCapacity[int](4))
+		model := []int{}
+
+		for i := 0; i < 5000; i++ {
+			switch op := rnd.Intn(10); {
+			case op < 6:
+				v := rnd.Intn(1000)
+				replaced := false
+				for j := range model {
+					if model[j]%20 == v%20 {
+						model[j] = v
+						replaced = true
+						break
+					}
+				}
+				if !replaced {
+					model = append(model, v)
+				}
+				require.Equal(t, !replaced, q.Add(v))
+			case op < 9:
+				if len(model) > 0 {
+					require.Equal(t, model[0], q.Dequeue())
+					model = model[1:]
+				}
+			default:
+				if len(model) > 0 {
+					j := rnd.Intn(len(model))
+					require.True(t, q.Remove(model[j]))
+					model = append(model[:j], model[j+1:]...)
+				}
+			}
+
+			require.Equal(t, model, append([]int{}, q.ToSlice()...))
+		}
+	})
+
+	t.Run("ReplaceAll and Clear", func(t *testing.T) {
+		q := New(WithConflation(bySymbol), byPrice)
+		q.ReplaceAll(OfWith([]QueueOptionFunc[tick]{byPrice}, tick{"A", 1}, tick{"B", 1}, tick{"A", 2}))
+		require.Equal(t, []tick{{"A", 2}, {"B", 1}}, q.ToSlice())
+
+		q.Clear()
+		q.Add(tick{"B", 3})
+		q.Add(tick{"B", 4})
+		require.Equal(t, []tick{{"B", 4}}, q.ToSlice())
+	})
+
+	t.Run("CloneInto retains conflation of destination", func(t *testing.T) {
+		src := OfWith([]QueueOptionFunc[tick]{byPrice}, tick{"A", 1}, tick{"B", 1})
+		dst := New(WithConflation(bySymbol), byPrice)
+		src.CloneInto(dst)
+		dst.Add(tick{"B", 2})
+		require.Equal(t, []tick{{"A", 1}, {"B", 2}}, dst.ToSlice())
+	})
+
+	t.Run("Nil key function panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "keyFn"), func() { WithConflation[int, int](nil) })
+	})
+}
+
+func TestEnqueueBatch(t *testing.T) {
+
+	t.Run("Values are enqueued in order with one version increment", func(t *testing.T) {
+		q := New[int]()
+		q.Enqueue(0)
+		version := q.version
+		q.EnqueueBatch([]int{1, 2, 3})
+
+		require.Equal(t, version+1, q.version)
+		require.Equal(t, []int{0, 1, 2, 3}, q.ToSlice())
+	})
+
+	t.Run("Wraps around end of buffer without reallocating", func(t *testing.T) {
+		q := New(WithCapacity[int](4))
+		q.EnqueueBatch([]int{1, 2, 3})
+		q.Dequeue()
+		q.Dequeue()
+		buffer := &q.buffer[0]
+
+		q.EnqueueBatch([]int{4, 5, 6})
+
+		require.Same(t, buffer, &q.buffer[0])
+		require.Equal(t, []int{5, 6, 3, 4}, q.buffer)
+		require.Equal(t, []int{3, 4, 5, 6}, q.ToSlice())
+		require.Equal(t, 3, q.Dequeue())
+	})
+
+	t.Run("Grows once to fit batch", func(t *testing.T) {
+		q := New(WithCapacity[int](2))
+		q.Enqueue(0)
+		batch := make([]int, 20)
+		for i := range batch {
+			batch[i] = i + 1
+		}
+
+		q.EnqueueBatch(batch)
+
+		require.Equal(t, 21, len(q.buffer))
+		require.Equal(t, append([]int{0}, batch...), q.ToSlice())
+		q.Enqueue(22)
+		require.Equal(t, 22, q.Count())
+	})
+
+	t.Run("Batch is recorded", func(t *testing.T) {
+		q := New[int]()
+		q.StartRecording()
+		q.EnqueueBatch([]int{1, 2})
+		require.Equal(t, []ops.Op[int]{ops.Add(1), ops.Add(2)}, q.StopRecording())
+	})
+
+	t.Run("Closed queue panics", func(t *testing.T) {
+		q := New[int]()
+		q.Close()
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { q.EnqueueBatch([]int{1}) })
+	})
+}
+
+func TestToImmutableSlice(t *testing.T) {
+
+	t.Run("Repeated calls return the same backing array", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc1)
+		require.Same(t, &slc1[0], &slc2[0])
+		require.Equal(t, len(slc1), cap(slc1))
+	})
+
+	t.Run("Recomputed after modification", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		col.Remove(2)
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc2)
+		require.Len(t, slc1, 3)
+		require.Len(t, slc2, 2)
+
+		col.Clear()
+		require.Empty(t, col.ToImmutableSlice())
+	})
+
+	t.Run("Recomputed after AddRange", func(t *testing.T) {
+		col := New[int]()
+		require.Empty(t, col.ToImmutableSlice())
+		col.AddRange([]int{1, 2, 3})
+		require.Equal(t, []int{1, 2, 3}, col.ToImmutableSlice())
+
+		var values []int
+		iter := col.SnapshotIterator()
+
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+		}
+
+		require.Equal(t, []int{1, 2, 3}, values)
+		col.AddRange([]int{4, 5})
+		require.Equal(t, []int{1, 2, 3, 4, 5}, col.ToImmutableSlice())
+	})
+
+	t.Run("Empty collection", func(t *testing.T) {
+		require.Empty(t, New[int]().ToImmutableSlice())
+	})
+
+	t.Run("Cached slice is served while another reader holds the lock", func(t *testing.T) {
+		col := New[int](WithThreadSafe[int]())
+		col.AddRange([]int{1, 2, 3})
+		cached := col.ToImmutableSlice()
+
+		col.lock.RLock()
+		defer col.lock.RUnlock()
+		var slc []int
+		var first int
+		done := make(chan struct{})
+
+		go func() {
+			slc = col.ToImmutableSlice()
+			first = col.SnapshotIterator().Start().Value()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			require.Same(t, &cached[0], &slc[0])
+			require.Equal(t, 1, first)
+		case <-time.After(time.Second):
+			require.Fail(t, "ToImmutableSlice blocked on a read-locked queue")
+		}
+	})
+}
+
+func TestCompact(t *testing.T) {
+
+	t.Run("Compact moves head to start of buffer", func(t *testing.T) {
+		queue := New(WithCapacity[int](8))
+		queue.AddRange([]int{1, 2, 3, 4, 5, 6})
+		queue.Dequeue()
+		queue.Dequeue()
+
+		for _, v := range []int{7, 8, 9} {
+			queue.Enqueue(v)
+		}
+
+		stats := queue.BufferStats()
+		require.Equal(t, BufferStats{Capacity: 8, Count: 7, Head: 2, Wrapped: true}, stats)
+
+		version := queue.version
+		queue.Compact()
+
+		require.Equal(t, BufferStats{Capacity: 8, Count: 7, Head: 0, Compactions: 1}, queue.BufferStats())
+		require.Equal(t, []int{3, 4, 5, 6, 7, 8, 9}, queue.ToSlice())
+		require.Equal(t, version+1, queue.version)
+
+		queue.Compact()
+		require.Equal(t, 1, queue.BufferStats().Compactions)
+		require.Equal(t, version+1, queue.version)
+
+		queue.Enqueue(10)
+		require.Equal(t, BufferStats{Capacity: 8, Count: 8, Head: 0, Compactions: 1}, queue.BufferStats())
+		require.Equal(t, []int{3, 4, 5, 6, 7, 8, 9, 10}, queue.ToSlice())
+	})
+
+	t.Run("Head is reset when queue becomes empty", func(t *testing.T) {
+		queue := Of(1, 2, 3)
+		queue.Dequeue()
+		queue.Remove(3)
+		queue.Dequeue()
+
+		require.Equal(t, 0, queue.BufferStats().Head)
+		require.Equal(t, 1, queue.BufferStats().Compactions)
+	})
+
+	t.Run("Head is reset when buffer grows", func(t *testing.T) {
+		queue := New(WithCapacity[int](4))
+		queue.AddRange([]int{1, 2, 3, 4})
+		queue.Dequeue()
+		queue.Enqueue(5)
+		queue.Enqueue(6)
+
+		require.Equal(t, 0, queue.BufferStats().Head)
+		require.Equal(t, []int{2, 3, 4, 5, 6}, queue.ToSlice())
+	})
+
+	t.Run("Remove does not reallocate buffer", func(t *testing.T) {
+		queue := New(WithCapacity[int](8))
+		queue.AddRange([]int{1, 2, 3, 4, 5, 6})
+		buffer := &queue.buffer[0]
+
+		require.True(t, queue.Remove(2))
+		require.True(t, queue.Remove(5))
+		require.Same(t, buffer, &queue.buffer[0])
+		require.Equal(t, []int{1, 3, 4, 6}, queue.ToSlice())
+	})
+
+	t.Run("Interleaved operations match reference", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(2163))
+		queue := New(WithCapacity[int](8))
+		expected := []int{}
+
+		for i := 0; i < 5000; i++ {
+			switch op := rng.Intn(10); {
+			case op < 5:
+				queue.Enqueue(i)
+				expected = append(expected, i)
+			case op < 8:
+				if len(expected) > 0 {
+					require.Equal(t, expected[0], queue.Dequeue())
+					expected = expected[1:]
+				}
+			case op < 9:
+				if len(expected) > 0 {
+					j := rng.Intn(len(expected))
+					require.True(t, queue.Remove(expected[j]))
+					expected = append(expected[:j:j], expected[j+1:]...)
+				}
+			default:
+				queue.Compact()
+				require.Equal(t, 0, queue.BufferStats().Head)
+			}
+
+			require.Equal(t, expected, queue.ToSlice())
+		}
+	})
+}
+
+func TestWeigher(t *testing.T) {
+
+	byLength := func(s string) int { return len(s) }
+
+	t.Run("Weight is tracked", func(t *testing.T) {
+		q := New(WithWeigher(byLength, 10))
+		q.Enqueue("abc")
+		q.AddRange([]string{"de", "fghi"})
+		require.Equal(t, 9, q.Weight())
+		require.Equal(t, 10, q.MaxWeight())
+
+		q.Dequeue()
+		q.Remove("fghi")
+		require.Equal(t, 2, q.Weight())
+
+		q.Clear()
+		require.Equal(t, 0, q.Weight())
+	})
+
+	t.Run("Offer refuses values over budget", func(t *testing.T) {
+		q := New(WithWeigher(byLength, 5))
+		require.True(t, q.Offer("abc"))
+		require.False(t, q.Offer("def"))
+		require.True(t, q.Offer("de"))
+		require.Equal(t, []string{"abc", "de"}, q.ToSlice())
+
+		q.Enqueue("fgh")
+		require.Equal(t, 8, q.Weight())
+	})
+
+	t.Run("Offer discounts conflated value", func(t *testing.T) {
+		q := New(WithWeigher(byLength, 5), WithConflation(func(s string) byte { return s[0] }))
+		q.AddRange([]string{"abc", "de"})
+		require.True(t, q.Offer("a"))
+		require.False(t, q.Offer("dddddd"))
+		require.Equal(t, []string{"a", "de"}, q.ToSlice())
+		require.Equal(t, 3, q.Weight())
+	})
+
+	t.Run("Bulk operations are reweighed", func(t *testing.T) {
+		q := OfWith([]QueueOptionFunc[string]{WithWeigher(byLength, 100)}, "a", "bb", "ccc")
+		q.ExtractWhere(func(s string) bool { return len(s) > 1 })
+		require.Equal(t, 1, q.Weight())
+
+		q.ReplaceAll(Of("dddd"))
+		require.Equal(t, 4, q.Weight())
+
+		q.EnqueueBatch([]string{"e", "f"})
+		require.Equal(t, 6, q.Weight())
+
+		dst := New(WithWeigher(byLength, 100))
+		q.CloneInto(dst)
+		require.Equal(t, 6, dst.Weight())
+	})
+
+	t.Run("No weigher", func(t *testing.T) {
+		q := Of("abc")
+		require.True(t, q.Offer("def"))
+		require.Equal(t, 0, q.Weight())
+		require.Equal(t, 0, q.MaxWeight())
+
+		q.Close()
+		require.False(t, q.Offer("ghi"))
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "weigher"), func() { WithWeigher[string](nil, 1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxWeight"), func() { WithWeigher(byLength, 0) })
+	})
+}
+
+func TestZeroValue(t *testing.T) {
+
+	t.Run("Usable without New", func(t *testing.T) {
+		var q Queue[int]
+
+		require.True(t, q.IsEmpty())
+		require.False(t, q.Contains(1))
+		require.Empty(t, q.ToSlice())
+		require.Nil(t, q.Iterator().Start())
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { q.Dequeue() })
+
+		q.Enqueue(1)
+		q.AddRange([]int{2, 3})
+
+		for i := 4; i <= 40; i++ {
+			q.Enqueue(i)
+		}
+
+		require.Equal(t, 40, q.Count())
+		require.True(t, q.Contains(2))
+		require.Equal(t, 1, q.Dequeue())
+		require.Equal(t, 2, q.Peek())
+	})
+
+	t.Run("Panics on first modification if no default comparer", func(t *testing.T) {
+		type point struct{ x, y int }
+		var q Queue[point]
+
+		require.True(t, q.IsEmpty())
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ZERO_VALUE_COMPARER_FMT, point{}), func() { q.Enqueue(point{}) })
+	})
+}
+
+func TestNewOrdered(t *testing.T) {
+
+	t.Run("NewFunc", func(t *testing.T) {
+		q := NewFunc(func(a, b int) int { return b - a })
+		q.AddRange([]int{2, 3, 1})
+		q.Sort()
+
+		require.Equal(t, []int{3, 2, 1}, q.ToSlice())
+	})
+
+	t.Run("NewOrdered with defined type", func(t *testing.T) {
+		type celsius float64
+		q := NewOrdered[celsius](WithCapacity[celsius](2))
+		q.AddRange([]celsius{2.5, -1, 0})
+		q.Sort()
+
+		require.Equal(t, []celsius{-1, 0, 2.5}, q.ToSlice())
+		require.True(t, q.Contains(2.5))
+	})
+}
+
+func TestCopyPolicy(t *testing.T) {
+	type box struct{ v int }
+	compareBox := func(a, b *box) int { return a.v - b.v }
+	copyBox := func(b *box) *box { c := *b; return &c }
+	values := []*box{{1}, {2}}
+
+	t.Run("Deep", func(t *testing.T) {
+		q := New(WithComparer(compareBox), WithDeepCopy(copyBox))
+		q.AddRange(values)
+
+		deep := q.ToSliceDeep()
+		require.NotSame(t, values[0], deep[0])
+		require.Equal(t, *values[0], *deep[0])
+
+		q1 := New(WithComparer(compareBox))
+		q1.AddCollection(q)
+		require.NotSame(t, values[0], q1.Peek())
+	})
+
+	t.Run("DeepOnExport", func(t *testing.T) {
+		q := New(WithComparer(compareBox), WithDeepCopy(copyBox))
+		q.AddRange(values)
+
+		q1 := New(WithComparer(compareBox), WithDeepCopy(copyBox), WithCopyPolicy[*box](collections.DeepOnExport))
+		q1.AddCollection(q)
+		require.Same(t, values[0], q1.Peek())
+		require.NotSame(t, values[0], q1.ToSliceDeep()[0])
+	})
+
+	t.Run("Shallow", func(t *testing.T) {
+		q := New(WithOptions(collections.CommonOptions[*box]{Comparer: compareBox, DeepCopy: copyBox, CopyPolicy: collections.Shallow}))
+		q.AddRange(values)
+
+		require.Same(t, values[0], q.ToSliceDeep()[0])
+		require.Same(t, values[1], q.SelectDeep(func(b *box) bool { return b.v == 2 }).ToSlice()[0])
+		require.Same(t, values[0], q.Sorted().ToSliceDeep()[0])
+	})
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+	})
+}
+
+func TestChannels(t *testing.T) {
+
+	t.Run("ToChan drains in order", func(t *testing.T) {
+		q := Of(1, 2, 3)
+		received := make([]int, 0)
+
+		for v := range q.ToChan(context.Background()) {
+			received = append(received, v)
+		}
+
+		require.Equal(t, []int{1, 2, 3}, received)
+		require.True(t, q.IsEmpty())
+	})
+
+	t.Run("ToChan stops when context cancelled", func(t *testing.T) {
+		q := Of(1, 2, 3)
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := q.ToChan(ctx)
+
+		require.Equal(t, 1, <-ch)
+		cancel()
+
+		for range ch {
+		}
+
+		require.GreaterOrEqual(t, q.Count(), 1)
+	})
+
+	t.Run("FromChan fills in the background", func(t *testing.T) {
+		src := make(chan int)
+		q, done := FromChan(context.Background(), src, WithCapacity[int](4))
+
+		require.NotNil(t, q.lock)
+
+		for i := 0; i < 10; i++ {
+			src <- i
+		}
+
+		close(src)
+		require.NoError(t, <-done)
+		require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, q.ToSlice())
+	})
+
+	t.Run("FromChan stops when queue closed", func(t *testing.T) {
+		src := make(chan int, 2)
+		q, done := FromChan(context.Background(), src)
+		q.Close()
+		src <- 1
+
+		require.NoError(t, <-done)
+		require.True(t, q.IsEmpty())
+	})
+
+	t.Run("FromChan stops when context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, done := FromChan(ctx, make(chan int))
+		cancel()
+
+		require.ErrorIs(t, <-done, context.Canceled)
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "src"), func() { FromChan[int](ctx, nil) })
+	})
+}
+
+func TestTryAdd(t *testing.T) {
+
+	t.Run("Full by weight", func(t *testing.T) {
+		q := New(WithWeigher(func(s string) int { return len(s) }, 5))
+
+		added, err := q.TryAdd("abc")
+		require.True(t, added)
+		require.NoError(t, err)
+
+		added, err = q.TryAdd("def")
+		require.False(t, added)
+		require.ErrorIs(t, err, collections.ErrFull)
+		require.Equal(t, []string{"abc"}, q.ToSlice())
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		q := New[int]()
+		q.Close()
+
+		added, err := q.TryAdd(1)
+		require.False(t, added)
+		require.ErrorIs(t, err, collections.ErrClosed)
+	})
+}
+
+func TestDequeueN(t *testing.T) {
+
+	t.Run("Removes values across the end of the buffer in order", func(t *testing.T) {
+		q := New(WithCapacity[int](4))
+		q.AddRange([]int{1, 2, 3})
+		q.Dequeue()
+		q.Dequeue()
+		q.AddRange([]int{4, 5, 6})
+		version := q.version
+
+		require.Equal(t, []int{3, 4, 5}, q.DequeueN(3))
+		require.Equal(t, version+1, q.version)
+		require.Equal(t, []int{6}, q.ToSlice())
+		require.Equal(t, 5, q.Position())
+	})
+
+	t.Run("Returns fewer values than requested", func(t *testing.T) {
+		q := Of(1, 2)
+
+		require.Equal(t, []int{1, 2}, q.DequeueN(5))
+		require.Equal(t, []int{}, q.DequeueN(1))
+		require.True(t, q.IsEmpty())
+	})
+
+	t.Run("Records each removal", func(t *testing.T) {
+		q := Of(1, 2, 3)
+		q.StartRecording()
+		q.DequeueN(2)
+
+		require.Equal(t, []ops.Op[int]{{Kind: ops.KindRemove, Value: 1}, {Kind: ops.KindRemove, Value: 2}}, q.StopRecording())
+	})
+
+	t.Run("Panics if n is negative", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { Of(1).DequeueN(-1) })
+	})
+}
+
+func TestDrainTo(t *testing.T) {
+
+	t.Run("Adds values to the destination in order", func(t *testing.T) {
+		q := Of(1, 2, 3, 4)
+		dst := dlist.Of(0)
+
+		require.Equal(t, 3, q.DrainTo(dst, 3))
+		require.Equal(t, []int{0, 1, 2, 3}, dst.ToSlice())
+		require.Equal(t, []int{4}, q.ToSlice())
+	})
+
+	t.Run("Drains into itself", func(t *testing.T) {
+		q := New(WithThreadSafe[int]())
+		q.AddRange([]int{1, 2, 3})
+
+		require.Equal(t, 2, q.DrainTo(q, 2))
+		require.Equal(t, []int{3, 1, 2}, q.ToSlice())
+	})
+
+	t.Run("Panics on invalid arguments", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dst"), func() { Of(1).DrainTo(nil, 1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "max"), func() { Of(1).DrainTo(New[int](), -1) })
+	})
+}
+
+func TestRetainWhere(t *testing.T) {
+
+	t.Run("Removes values across the end of the buffer", func(t *testing.T) {
+		q := New(WithCapacity[int](4))
+		q.AddRange([]int{1, 2, 3})
+		q.Dequeue()
+		q.Dequeue()
+		q.AddRange([]int{4, 5, 6})
+
+		require.Equal(t, 2, q.RetainWhere(func(v int) bool { return v != 4 && v != 5 }))
+		require.Equal(t, []int{3, 6}, q.ToSlice())
+
+		q.AddRange([]int{7, 8})
+		require.Equal(t, []int{3, 6, 7, 8}, q.ToSlice())
+	})
+
+	t.Run("Keeps conflating", func(t *testing.T) {
+		type tick struct {
+			key   string
+			price int
+		}
+
+		q := New(
+			WithConflation(func(t tick) string { return t.key }),
+			WithComparer(func(a, b tick) int { return strings.Compare(a.key, b.key) }),
+		)
+		q.AddRange([]tick{{"a", 1}, {"b", 2}, {"c", 3}})
+
+		require.Equal(t, 1, q.RetainWhere(func(t tick) bool { return t.key != "a" }))
+		q.Enqueue(tick{"c", 4})
+		q.Enqueue(tick{"a", 5})
+		require.Equal(t, []tick{{"b", 2}, {"c", 4}, {"a", 5}}, q.ToSlice())
+	})
+}
@@ -0,0 +1,67 @@
+package queue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+)
+
+// Option function for New to give the queue a name, under which it is registered with the
+// [registry] so that its size, version and operation counts are included in [registry.Snapshot].
+// The queue remains registered until Dispose is called.
+//
+// Panics if name is empty.
+func WithName[T any](name string) QueueOptionFunc[T] {
+	if name == "" {
+		panic(messages.NAME_EMPTY)
+	}
+	return func(q *Queue[T]) {
+		q.name = name
+	}
+}
+
+// Dispose removes the queue from the [registry] if it was created with [WithName].
+// The queue remains usable, but no longer appears in [registry.Snapshot].
+// Calling Dispose more than once has no effect.
+func (q *Queue[T]) Dispose() {
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.registration.Deregister()
+	q.registration = nil
+}
+
+// register registers the queue with the registry if it has been given a name.
+func (q *Queue[T]) register() {
+	if q.name == "" {
+		return
+	}
+
+	q.recorder.EnableCounting()
+	q.registration = registry.Register(q.name, q.stats)
+}
+
+func (q *Queue[T]) stats() registry.Stats {
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	return registry.Stats{
+		Type:    collections.COLLECTION_QUEUE,
+		Count:   q.size,
+		Version: q.version,
+		Ops:     q.recorder.Counts(),
+	}
+}
@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*Queue[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single pass,
+// keeping the remaining values in their order, and returns the number of values removed.
+//
+// Values may be removed from a queue that has been closed.
+func (q *Queue[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	kept := 0
+
+	for i := 0; i < q.size; i++ {
+		value := q.buffer[q.bufferIndex(i)]
+
+		if predicate(value) {
+			q.buffer[q.bufferIndex(kept)] = value
+			kept++
+		}
+	}
+
+	removed := q.size - kept
+
+	if removed == 0 {
+		return 0
+	}
+
+	var empty T
+	for i := kept; i < q.size; i++ {
+		q.buffer[q.bufferIndex(i)] = empty
+	}
+
+	q.size = kept
+	q.tail = q.bufferIndex(kept)
+	q.resetIfEmpty()
+	q.version++
+
+	if q.conflator != nil {
+		q.conflator.Reset(q.size, q.at)
+	}
+
+	if q.weigher != nil {
+		q.weigher.Reset(q.size, q.at)
+	}
+
+	q.recorder.Reset(func() []T { return q.toSlice(false) })
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (q *Queue[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](q, other, q.RetainWhere)
+}
@@ -1,8 +1,13 @@
 package queue
 
 import (
+	"fmt"
+
 	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
+	"golang.org/x/exp/constraints"
 )
 
 // Sort performs an in-place sort of this collection.
@@ -22,6 +27,10 @@ func (q *Queue[T]) Sorted() collections.Collection[T] {
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	q1 := q.makeDeepCopy()
 
 	if q1.size > 1 {
@@ -47,6 +56,10 @@ func (q *Queue[T]) SortedDescending() collections.Collection[T] {
 		defer q.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
 	q1 := q.makeDeepCopy()
 
 	if q1.size > 1 {
@@ -56,6 +69,41 @@ func (q *Queue[T]) SortedDescending() collections.Collection[T] {
 	return q1
 }
 
+// SortBy performs an in-place sort of this collection with respect to the given comparer,
+// rather than the comparer of the queue, which is unchanged.
+//
+// The item that comparer orders first will be placed at the head of the queue.
+//
+// Panics if comparer is nil.
+func (q *Queue[T]) SortBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	q.doSort(util.CompareSort(util.Gosort[T], comparer))
+}
+
+// SortStable performs an in-place sort of this collection as per Sort,
+// except that values which compare equal keep their relative order.
+func (q *Queue[T]) SortStable() {
+
+	q.doSort(util.GosortStable[T])
+}
+
+// SortStableBy performs an in-place sort of this collection as per [Queue.SortBy],
+// except that values which compare equal keep their relative order.
+//
+// Panics if comparer is nil.
+func (q *Queue[T]) SortStableBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	q.doSort(util.CompareSort(util.GosortStable[T], comparer))
+}
+
 func (q *Queue[T]) doSort(f util.SortFunc[T]) {
 
 	if q.size <= 1 {
@@ -67,6 +115,12 @@ func (q *Queue[T]) doSort(f util.SortFunc[T]) {
 		defer q.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
 	length := len(q.buffer)
 	slc := make([]T, length)
 	q.copyTo(slc, true)
@@ -75,4 +129,26 @@ func (q *Queue[T]) doSort(f util.SortFunc[T]) {
 	q.tail = util.Iif(q.size == length, 0, q.size)
 	q.buffer = slc
 	q.version++
+	q.recorder.Reset(func() []T { return q.toSlice(false) })
+}
+
+// SortByKey performs an in-place sort of the queue by the keys computed for its values by key.
+// The value with the smallest key will be placed at the head of the queue.
+//
+// Each key is computed once before sorting, so where comparing values is expensive, e.g. comparing strings
+// with collation, sorting by a cheaply compared key derived from each value is much faster than Sort.
+func SortByKey[T any, K constraints.Ordered](q *Queue[T], key func(T) K) {
+
+	sortByKey(q, key, false)
+}
+
+// SortByKeyDescending is as [SortByKey], placing the value with the largest key at the head of the queue.
+func SortByKeyDescending[T any, K constraints.Ordered](q *Queue[T], key func(T) K) {
+
+	sortByKey(q, key, true)
+}
+
+func sortByKey[T any, K constraints.Ordered](q *Queue[T], key func(T) K, descending bool) {
+
+	q.doSort(util.KeySort(key, util.GetOrderedComparer[K](), descending))
 }
@@ -0,0 +1,45 @@
+package queue
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*Queue[int])(nil)
+
+// TryAdd enqueues a value if it fits within the weight budget set by [WithWeigher], as [Queue.Offer] does.
+// When conflating, a queued value with the same key is replaced, and its weight discounted.
+//
+// Returns true and nil if the value was enqueued or replaced a queued value; else false and
+// [collections.ErrClosed] if the queue has been closed, [collections.ErrFull] if the value would take
+// the total weight of the queue over the budget, or an error wrapping [collections.ErrInvalidValue]
+// and the error of the validator if it was rejected, whatever the validation policy.
+func (q *Queue[T]) TryAdd(value T) (bool, error) {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	if q.closed {
+		return false, collections.ErrClosed
+	}
+
+	if err := q.validator.Check(value); err != nil {
+		return false, err
+	}
+
+	if q.weigher != nil && !q.weigher.Fits(q.weightDelta(value)) {
+		return false, collections.ErrFull
+	}
+
+	q.enqueue(value)
+	return true, nil
+}
@@ -0,0 +1,114 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Option function for New to weigh values, e.g. by their size in bytes, so that the queue may be
+// bounded by the total weight of its values rather than their number. The total weight is tracked as
+// values are added and removed. [Queue.Offer] refuses a value that would take the total over maxWeight,
+// whereas Enqueue and Add always add the value, so may exceed it.
+//
+//	q := queue.New(queue.WithWeigher(func(p []byte) int { return len(p) }, 1<<20))
+//
+// Values modified in place, e.g. via [collections.Element.ValuePtr], are not reweighed.
+//
+// Panics if weigher is nil or maxWeight is less than 1. Operations that add or remove values
+// panic if weigher returns a negative weight.
+func WithWeigher[T any](weigher func(T) int, maxWeight int) QueueOptionFunc[T] {
+	if weigher == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "weigher"))
+	}
+
+	if maxWeight < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxWeight"))
+	}
+
+	return func(q *Queue[T]) {
+		q.weigher = util.NewWeigher(weigher, maxWeight)
+	}
+}
+
+// Offer enqueues a value if it fits within the weight budget set by [WithWeigher].
+//
+// Returns false if the queue has been closed, the value is rejected by the validator, or the value would take the total weight of
+// the queue over the budget; else the value is enqueued and true is returned.
+// When conflating, the weight of the queued value that would be replaced is discounted.
+// Without a weigher, Offer is equivalent to Add except that it returns true when the value
+// replaced a queued value.
+func (q *Queue[T]) Offer(value T) bool {
+
+	if q.lock != nil {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Write(q.lock != nil, q.verifyInvariants)()
+	}
+
+	q.lazyInit()
+
+	if q.closed {
+		return false
+	}
+
+	if !q.validator.Accept(value) {
+		return false
+	}
+
+	if q.weigher != nil && !q.weigher.Fits(q.weightDelta(value)) {
+		return false
+	}
+
+	q.enqueue(value)
+	return true
+}
+
+// Weight returns the total weight of the values in the queue,
+// or zero if the queue was not created [WithWeigher].
+func (q *Queue[T]) Weight() int {
+
+	if q.lock != nil {
+		q.lock.RLock()
+		defer q.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer q.guard.Read(q.lock != nil)()
+	}
+
+	if q.weigher == nil {
+		return 0
+	}
+
+	return q.weigher.Total()
+}
+
+// MaxWeight returns the weight budget set by [WithWeigher],
+// or zero if the queue was not created with a weigher.
+func (q *Queue[T]) MaxWeight() int {
+
+	if q.weigher == nil {
+		return 0
+	}
+
+	return q.weigher.Max()
+}
+
+// weightDelta returns the change in total weight if value were enqueued,
+// discounting the weight of a queued value it would replace when conflating.
+func (q *Queue[T]) weightDelta(value T) int {
+	delta := q.weigher.Weigh(value)
+
+	if q.conflator != nil {
+		if i := q.conflator.Find(value, q.size, q.at); i != -1 {
+			delta -= q.weigher.Weigh(q.at(i))
+		}
+	}
+
+	return delta
+}
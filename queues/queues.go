@@ -10,7 +10,7 @@ import (
 
 // Queue is the abstract interface for collections that operate as FIFO queues.
 //
-// Implemented by Queue[T], RingBuffer[T].
+// Implemented by Queue[T], RingBuffer[T], PriorityQueue[T], PriorityFair[T].
 type Queue[T any] interface {
 	// Queue implements Collection
 	collections.Collection[T]
@@ -39,6 +39,17 @@ type Queue[T any] interface {
 	// the queue is not empty; else zero value of T and false.
 	TryPeek() (T, bool)
 
+	// Close marks the queue as closed. No further values may be enqueued,
+	// however values already in the queue may still be dequeued.
+	//
+	// Once closed, Add returns false and Enqueue panics. When a closed queue
+	// has been drained, Dequeue panics indicating that the queue is closed
+	// rather than empty.
+	Close()
+
+	// IsClosed returns true if Close has been called on the queue.
+	IsClosed() bool
+
 	// Prevent external implementations of this interface
 	local.InternalInter
 }
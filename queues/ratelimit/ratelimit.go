@@ -0,0 +1,279 @@
+/*
+Package ratelimit provides sliding window rate limiters.
+
+A limiter allows at most a given number of events within any period of a given length. The time of each
+event allowed is held in a [ringbuffer.RingBuffer] of that many timestamps, from which the timestamps that
+have fallen out of the window are discarded as the limiter is queried. Unlike a fixed window counter,
+a burst cannot exceed the limit by straddling the boundary between two windows, and unlike a token bucket,
+the limit is exact over every window.
+
+[Limiter] limits a single stream of events. [KeyedLimiter] limits the events of each key separately,
+e.g. the requests of each client, holding a buffer for each key.
+*/
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/queues/ringbuffer"
+)
+
+// OptionFunc is the signature of a function
+// for providing options to the limiter constructors.
+type OptionFunc func(*config)
+
+// config holds the options of a limiter.
+type config struct {
+	clock functions.Clock
+}
+
+// Option function for a limiter constructor to supply the clock against which events are timed.
+// The default is [functions.SystemClock].
+//
+// Panics if clock is nil.
+func WithClock(clock functions.Clock) OptionFunc {
+	if clock == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "clock"))
+	}
+
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// Limiter allows at most a given number of events in any sliding window of time. It is always thread-safe.
+type Limiter struct {
+	lock   sync.Mutex
+	clock  functions.Clock
+	events *eventLog
+}
+
+// New constructs a Limiter that allows at most limit events within any period of length window.
+//
+// Panics if limit is less than 1 or window is not positive.
+func New(limit int, window time.Duration, options ...OptionFunc) *Limiter {
+	c := newConfig(limit, window, options)
+
+	return &Limiter{
+		clock:  c.clock,
+		events: newEventLog(limit, window),
+	}
+}
+
+// Allow records an event and returns true if it is within the limit; else returns false without recording it.
+func (l *Limiter) Allow() bool {
+
+	return l.AllowN(1)
+}
+
+// AllowN records n events at once and returns true if all are within the limit;
+// else returns false without recording any of them. AllowN(0) returns true.
+//
+// Panics if n is negative.
+func (l *Limiter) AllowN(n int) bool {
+
+	validateN(n)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.events.allow(l.clock.Now(), n)
+}
+
+// Remaining returns the number of events that would be allowed now.
+func (l *Limiter) Remaining() int {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.events.remaining(l.clock.Now())
+}
+
+// Reset discards all events recorded, so that the full limit is available.
+func (l *Limiter) Reset() {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.events.buffer.Clear()
+}
+
+// KeyedLimiter allows at most a given number of events of each key in any sliding window of time.
+// It is always thread-safe.
+//
+// A buffer of timestamps is held for each key with events in the window. Call [KeyedLimiter.Prune]
+// periodically to discard the buffers of keys with no recent events.
+type KeyedLimiter[K comparable] struct {
+	lock  sync.Mutex
+	clock functions.Clock
+	limit int
+	span  time.Duration
+	logs  map[K]*eventLog
+}
+
+// NewKeyed constructs a KeyedLimiter that allows at most limit events of each key within any period of length window.
+//
+// Panics if limit is less than 1 or window is not positive.
+func NewKeyed[K comparable](limit int, window time.Duration, options ...OptionFunc) *KeyedLimiter[K] {
+	c := newConfig(limit, window, options)
+
+	return &KeyedLimiter[K]{
+		clock: c.clock,
+		limit: limit,
+		span:  window,
+		logs:  make(map[K]*eventLog),
+	}
+}
+
+// Allow records an event for key and returns true if it is within the limit of the key;
+// else returns false without recording it.
+func (l *KeyedLimiter[K]) Allow(key K) bool {
+
+	return l.AllowN(key, 1)
+}
+
+// AllowN records n events for key at once and returns true if all are within the limit of the key;
+// else returns false without recording any of them. AllowN(key, 0) returns true.
+//
+// Panics if n is negative.
+func (l *KeyedLimiter[K]) AllowN(key K, n int) bool {
+
+	validateN(n)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	log, ok := l.logs[key]
+
+	if !ok {
+		// Don't hold a log for a key whose events could never be allowed.
+		if n == 0 || n > l.limit {
+			return n == 0
+		}
+
+		log = newEventLog(l.limit, l.span)
+		l.logs[key] = log
+	}
+
+	return log.allow(l.clock.Now(), n)
+}
+
+// Remaining returns the number of events of key that would be allowed now.
+func (l *KeyedLimiter[K]) Remaining(key K) int {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if log, ok := l.logs[key]; ok {
+		return log.remaining(l.clock.Now())
+	}
+
+	return l.limit
+}
+
+// Reset discards the events recorded for key, so that its full limit is available.
+func (l *KeyedLimiter[K]) Reset(key K) {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.logs, key)
+}
+
+// Prune discards the buffers of keys with no events in the window, and returns the number of keys discarded.
+// O(k), where k is the number of keys held.
+func (l *KeyedLimiter[K]) Prune() int {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Now()
+	pruned := 0
+
+	for key, log := range l.logs {
+		if log.remaining(now) == l.limit {
+			delete(l.logs, key)
+			pruned++
+		}
+	}
+
+	return pruned
+}
+
+// Keys returns the number of keys for which a buffer is held.
+func (l *KeyedLimiter[K]) Keys() int {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return len(l.logs)
+}
+
+// eventLog holds the timestamps of the events allowed within the last span of time, oldest first.
+type eventLog struct {
+	buffer *ringbuffer.RingBuffer[time.Time]
+	limit  int
+	span   time.Duration
+}
+
+func newEventLog(limit int, span time.Duration) *eventLog {
+	return &eventLog{
+		buffer: ringbuffer.New[time.Time](limit),
+		limit:  limit,
+		span:   span,
+	}
+}
+
+// allow records n events at now if they are within the limit.
+func (e *eventLog) allow(now time.Time, n int) bool {
+
+	if e.remaining(now) < n {
+		return false
+	}
+
+	for i := 0; i < n; i++ {
+		e.buffer.Enqueue(now)
+	}
+
+	return true
+}
+
+// remaining discards the events that have left the window at now and returns the number of events still allowed.
+func (e *eventLog) remaining(now time.Time) int {
+
+	cutoff := now.Add(-e.span)
+
+	for oldest, ok := e.buffer.TryPeek(); ok && !oldest.After(cutoff); oldest, ok = e.buffer.TryPeek() {
+		e.buffer.Dequeue()
+	}
+
+	return e.limit - e.buffer.Count()
+}
+
+func newConfig(limit int, window time.Duration, options []OptionFunc) *config {
+	if limit < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "limit"))
+	}
+
+	if window <= 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "window"))
+	}
+
+	c := &config{clock: functions.SystemClock}
+
+	for _, o := range options {
+		o(c)
+	}
+
+	return c
+}
+
+func validateN(n int) {
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+}
@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func testClock() (*time.Time, functions.Clock) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &now, functions.ClockFunc(func() time.Time { return now })
+}
+
+func TestConstructor(t *testing.T) {
+
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "limit"), func() { New(0, time.Second) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "window"), func() { NewKeyed[string](1, 0) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "clock"), func() { WithClock(nil) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { New(1, time.Second).AllowN(-1) })
+}
+
+func TestLimiter(t *testing.T) {
+
+	t.Run("Window slides", func(t *testing.T) {
+		now, clock := testClock()
+		l := New(3, time.Second, WithClock(clock))
+
+		require.True(t, l.Allow())
+		*now = now.Add(400 * time.Millisecond)
+		require.True(t, l.AllowN(2))
+		require.False(t, l.Allow())
+		require.Zero(t, l.Remaining())
+
+		// The first event leaves the window one second after it was allowed
+		*now = now.Add(599 * time.Millisecond)
+		require.False(t, l.Allow())
+		*now = now.Add(time.Millisecond)
+		require.Equal(t, 1, l.Remaining())
+		require.True(t, l.Allow())
+		require.False(t, l.Allow())
+
+		*now = now.Add(time.Second)
+		require.Equal(t, 3, l.Remaining())
+	})
+
+	t.Run("AllowN is all or nothing", func(t *testing.T) {
+		_, clock := testClock()
+		l := New(3, time.Second, WithClock(clock))
+
+		require.True(t, l.AllowN(2))
+		require.False(t, l.AllowN(2))
+		require.Equal(t, 1, l.Remaining())
+		require.True(t, l.AllowN(0))
+		require.False(t, l.AllowN(4))
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		_, clock := testClock()
+		l := New(2, time.Second, WithClock(clock))
+
+		require.True(t, l.AllowN(2))
+		l.Reset()
+		require.Equal(t, 2, l.Remaining())
+	})
+
+	t.Run("Concurrent callers never exceed the limit", func(t *testing.T) {
+		l := New(100, time.Hour)
+		var allowed atomic.Int32
+		var wg sync.WaitGroup
+
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					if l.Allow() {
+						allowed.Add(1)
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+		require.Equal(t, int32(100), allowed.Load())
+	})
+}
+
+func TestKeyedLimiter(t *testing.T) {
+
+	now, clock := testClock()
+	l := NewKeyed[string](2, time.Minute, WithClock(clock))
+
+	require.True(t, l.Allow("alice"))
+	require.True(t, l.Allow("alice"))
+	require.False(t, l.Allow("alice"))
+	require.True(t, l.AllowN("bob", 2))
+	require.Equal(t, 0, l.Remaining("bob"))
+	require.Equal(t, 2, l.Remaining("carol"))
+
+	// Requests that could never be allowed do not create a log
+	require.False(t, l.AllowN("carol", 3))
+	require.True(t, l.AllowN("carol", 0))
+	require.Equal(t, 2, l.Keys())
+
+	l.Reset("bob")
+	require.True(t, l.Allow("bob"))
+
+	*now = now.Add(30 * time.Second)
+	require.True(t, l.Allow("dave"))
+	require.Zero(t, l.Prune())
+
+	*now = now.Add(30 * time.Second)
+	require.Equal(t, 2, l.Prune())
+	require.Equal(t, 1, l.Keys())
+	require.Equal(t, 1, l.Remaining("dave"))
+	require.Equal(t, 2, l.Remaining("alice"))
+}
@@ -0,0 +1,32 @@
+package ringbuffer
+
+import (
+	"context"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.BulkLoader[int] = (*RingBuffer[int])(nil)
+
+// BulkAdd adds each batch of values received from src to the ring buffer, until src is closed or ctx is done.
+// Values are appended in the order they are received, displacing the oldest values once the buffer is full.
+//
+// Batches are received and added one at a time, so the number of values in flight is bounded
+// by the capacity of src, and a producer blocks while the ring buffer catches up. Each batch is added
+// under a single acquisition of the lock of a thread-safe ring buffer, so other goroutines may use it
+// between batches.
+//
+//	src := make(chan []Row, 4) // at most 4 batches waiting
+//	go readBatches(file, src)
+//	err := buf.BulkAdd(ctx, src)
+//
+// Returns nil once src is closed, else the error of ctx.
+// The values of batches added before ctx is done remain in the ring buffer.
+//
+// Panics if src is nil.
+func (buf *RingBuffer[T]) BulkAdd(ctx context.Context, src <-chan []T) error {
+
+	return util.BulkAdd(ctx, src, buf.AddRange)
+}
@@ -0,0 +1,53 @@
+package ringbuffer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// ToChan returns a channel that receives the values of the buffer in the order they are dequeued,
+// dequeuing each as it is sent. The channel is closed once the buffer is empty or ctx is done,
+// so it drains the values in the buffer at the time, and any enqueued before it is found to be empty.
+//
+//	for v := range buf.ToChan(ctx) {
+//		process(v)
+//	}
+//
+// A value is dequeued before it is sent, so if ctx is done while a value is waiting to be received,
+// that value is discarded. If other goroutines are to use the buffer while it is drained, the buffer must be thread-safe.
+func (buf *RingBuffer[T]) ToChan(ctx context.Context) <-chan T {
+
+	return util.ToChan(ctx, buf.TryDequeue)
+}
+
+// FromChan constructs a new thread-safe buffer holding at most maxSize values, and in a new goroutine
+// enqueues the values received from src, until src is closed, ctx is done or the buffer is closed.
+// As for Enqueue, when the buffer is full the oldest value is discarded, so the buffer holds the most
+// recent values received. The buffer may be used while it is filled.
+//
+// The returned channel receives nil once src is closed or the buffer is closed, else the error of ctx,
+// then is closed.
+//
+//	latest, done := ringbuffer.FromChan(ctx, 100, readings)
+//	// ...
+//	err := <-done
+//
+// Panics if src is nil or maxSize is less than 1.
+func FromChan[T any](ctx context.Context, maxSize int, src <-chan T, options ...RingBufferOptionFunc[T]) (*RingBuffer[T], <-chan error) {
+
+	if src == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "src"))
+	}
+
+	buf := New(maxSize, append([]RingBufferOptionFunc[T]{WithThreadSafe[T]()}, options...)...)
+
+	done := util.FromChan(ctx, src, func(value T) bool {
+		// Add also returns false for a value conflated with one already buffered.
+		return buf.Add(value) || !buf.IsClosed()
+	})
+
+	return buf, done
+}
@@ -0,0 +1,120 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// Position returns the position of the value at the front of the buffer, being the number of values
+// that have been dequeued, acknowledged or displaced by enqueuing into a full buffer since the buffer
+// was created or last restored.
+// The value at index i of the buffer is at position Position() + i.
+//
+// Values removed by Clear, ReplaceAll or removal by value do not advance the position.
+func (buf *RingBuffer[T]) Position() int {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.position
+}
+
+// AcknowledgeUpTo removes values from the front of the buffer until the value at the front
+// is at the given position, or the buffer is empty, returning the number of values removed.
+//
+// This allows a batch processor to read values with Peek, Page or an iterator, and only
+// remove them once they have been processed. Does nothing if position is not beyond [RingBuffer.Position].
+func (buf *RingBuffer[T]) AcknowledgeUpTo(position int) int {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	removed := 0
+
+	for buf.size > 0 && buf.position < position {
+		buf.removeHead()
+		removed++
+	}
+
+	return removed
+}
+
+// Checkpoint returns the position and values of the buffer from head to tail, using enc to encode
+// each value, so that a batch processor may persist its progress and resume it with [RingBuffer.Restore].
+// The checkpoint begins with a header describing the [schema] of the buffer, including its maximum size,
+// but its other options are not encoded.
+//
+// Returns the first error returned by enc.
+func (buf *RingBuffer[T]) Checkpoint(enc func(io.Writer, T) error) ([]byte, error) {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	s := schema.Of[T](collections.COLLECTION_RINGBUFFER, map[string]string{"maxSize": strconv.Itoa(buf.maxSize)})
+
+	return util.EncodeCheckpoint(s, buf.position, buf.size, func(yield func(T) bool) {
+		for i := 0; i < buf.size && yield(buf.at(i)); i++ {
+		}
+	}, enc)
+}
+
+// Restore replaces the values and position of the buffer with those of a checkpoint
+// returned by [RingBuffer.Checkpoint], using dec to decode each value.
+//
+// The checkpoint is decoded in full before the buffer is modified, so the buffer is unchanged if
+// an error is returned. If the buffer was created [WithSchemaValidation], returns an error if the
+// checkpoint has no header or was written by another type of collection or value. Returns the first
+// error returned by dec, io.ErrUnexpectedEOF if the checkpoint is truncated, or an error if it holds
+// more values than the maximum size of the buffer.
+//
+// Panics if the buffer has been closed.
+func (buf *RingBuffer[T]) Restore(state []byte, dec func(io.Reader) (T, error)) error {
+
+	// The values are checked against the maximum size of the buffer, so the maximum size is not verified.
+	position, values, err := util.DecodeCheckpoint(state, schema.Of[T](collections.COLLECTION_RINGBUFFER, nil), buf.verifySchema, dec)
+
+	if err != nil {
+		return err
+	}
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	if len(values) > buf.maxSize {
+		return fmt.Errorf(messages.DECODE_INVALID_FMT, "RingBuffer checkpoint")
+	}
+
+	buf.setValues(values)
+	buf.position = position
+	return nil
+}
@@ -0,0 +1,112 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeInt(w io.Writer, v int) error {
+	return binary.Write(w, binary.LittleEndian, int64(v))
+}
+
+func decodeInt(r io.Reader) (int, error) {
+	var v int64
+	err := binary.Read(r, binary.LittleEndian, &v)
+	return int(v), err
+}
+
+func TestCheckpoint(t *testing.T) {
+
+	t.Run("Displaced values advance position", func(t *testing.T) {
+		buf := New[int](3)
+		buf.AddRange([]int{1, 2, 3})
+		buf.Enqueue(4)
+		require.Equal(t, 1, buf.Position())
+
+		buf.AddRange([]int{5})
+		require.Equal(t, 2, buf.Position())
+
+		buf.AddRange([]int{6, 7, 8, 9})
+		require.Equal(t, 6, buf.Position())
+		require.Equal(t, []int{7, 8, 9}, buf.ToSlice())
+
+		buf.Dequeue()
+		require.Equal(t, 7, buf.Position())
+	})
+
+	t.Run("AcknowledgeUpTo removes values before position", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5})
+
+		require.Equal(t, 3, buf.AcknowledgeUpTo(3))
+		require.Equal(t, []int{4, 5}, buf.ToSlice())
+		require.Equal(t, 0, buf.AcknowledgeUpTo(3))
+	})
+
+	t.Run("Restore resumes from checkpoint", func(t *testing.T) {
+		buf := New[int](4)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6})
+
+		state, err := buf.Checkpoint(encodeInt)
+		require.NoError(t, err)
+
+		restored := New[int](4)
+		require.NoError(t, restored.Restore(state, decodeInt))
+		require.Equal(t, 2, restored.Position())
+		require.Equal(t, []int{3, 4, 5, 6}, restored.ToSlice())
+	})
+
+	t.Run("Restore into smaller buffer fails", func(t *testing.T) {
+		buf := New[int](4)
+		buf.AddRange([]int{1, 2, 3})
+
+		state, err := buf.Checkpoint(encodeInt)
+		require.NoError(t, err)
+
+		small := New[int](2)
+		small.Enqueue(9)
+		require.Error(t, small.Restore(state, decodeInt))
+		require.Equal(t, []int{9}, small.ToSlice())
+	})
+	t.Run("Schema validation", func(t *testing.T) {
+		state, err := Of(1, 2, 3).Checkpoint(encodeInt)
+		require.NoError(t, err)
+
+		s, _, err := schema.Read(state)
+		require.NoError(t, err)
+		require.Equal(t, "RingBuffer", s.Collection)
+
+		restored := New(4, WithSchemaValidation[int]())
+		require.NoError(t, restored.Restore(state, decodeInt))
+		require.Equal(t, []int{1, 2, 3}, restored.ToSlice())
+
+		other, err := schema.Append(nil, schema.Of[string](collections.COLLECTION_RINGBUFFER, nil))
+		require.NoError(t, err)
+
+		var mismatch *schema.MismatchError
+		require.ErrorAs(t, New(4, WithSchemaValidation[int]()).Restore(append(other, body(state)...), decodeInt), &mismatch)
+		require.Equal(t, "element", mismatch.Field)
+	})
+
+	t.Run("Checkpoint without a schema header", func(t *testing.T) {
+		state, err := Of(1, 2, 3).Checkpoint(encodeInt)
+		require.NoError(t, err)
+		legacy := body(state)
+
+		restored := New[int](4)
+		require.NoError(t, restored.Restore(legacy, decodeInt))
+		require.Equal(t, []int{1, 2, 3}, restored.ToSlice())
+		require.ErrorIs(t, New(4, WithSchemaValidation[int]()).Restore(legacy, decodeInt), schema.ErrMissing)
+	})
+}
+
+// body returns a checkpoint without its schema header, as written before headers were added.
+func body(state []byte) []byte {
+	_, data, _ := schema.Read(state)
+	return data
+}
@@ -0,0 +1,93 @@
+package ringbuffer
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// CloneInto replaces the content of dst with a deep copy of this buffer's values,
+// using the provided [functions.DeepCopyFunc] if any.
+//
+// dst takes on the maximum size of this buffer. Its backing slice is reused if it has
+// sufficient capacity, so periodically cloning into the same destination does not allocate.
+// The comparer, deep copy function and copy policy of this buffer are copied to dst; its thread safety,
+// closed state and conflation are not changed.
+//
+// Panics if dst is nil.
+func (buf *RingBuffer[T]) CloneInto(dst *RingBuffer[T]) {
+
+	if dst == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dst"))
+	}
+
+	if dst == buf {
+		return
+	}
+
+	// Locked in a consistent order, so that a concurrent dst.CloneInto(buf) cannot deadlock
+	util.LockPair(buf.lock, dst.lock)
+	defer util.UnlockPair(buf.lock, dst.lock)
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	if util.Debug {
+		defer dst.guard.Write(dst.lock != nil, dst.verifyInvariants)()
+	}
+
+	if cap(dst.buffer) < buf.maxSize {
+		dst.buffer = make([]T, buf.maxSize)
+	} else {
+		// Release references held by dst before reuse
+		var empty T
+		dst.buffer = dst.buffer[:cap(dst.buffer)]
+		for i := range dst.buffer {
+			dst.buffer[i] = empty
+		}
+
+		dst.buffer = dst.buffer[:buf.maxSize]
+	}
+
+	for i := 0; i < buf.size; i++ {
+		dst.buffer[i] = util.DeepCopy(buf.buffer[buf.bufferIndex(i)], buf.copy)
+	}
+
+	dst.maxSize = buf.maxSize
+	dst.head = 0
+	dst.tail = buf.size % buf.maxSize
+	dst.size = buf.size
+	dst.full = buf.full
+	dst.compare = buf.compare
+	dst.copy = buf.copy
+	dst.copyPolicy = buf.copyPolicy
+	dst.version++
+	dst.recorder.Reset(func() []T { return dst.toSlice(false, false) })
+
+	if dst.conflator != nil {
+		dst.conflator.Reset(dst.size, dst.at)
+	}
+
+	if dst.weigher != nil {
+		dst.weigher.Reset(dst.size, dst.at)
+	}
+
+	dst.signalRoom()
+}
+
+// Clone returns a new RingBuffer holding the values of this buffer, copied by value.
+// The result is that of Select with a predicate that is always true.
+func (buf *RingBuffer[T]) Clone() collections.Collection[T] {
+
+	return buf.Select(util.DefaultPredicate[T])
+}
+
+// CloneDeep returns a new RingBuffer holding the values of this buffer, copied using the provided
+// [functions.DeepCopyFunc] if any. The result is that of SelectDeep with a predicate that is always true.
+func (buf *RingBuffer[T]) CloneDeep() collections.Collection[T] {
+
+	return buf.SelectDeep(util.DefaultPredicate[T])
+}
@@ -0,0 +1,79 @@
+package ringbuffer
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// DequeueN removes up to n values from the front of the buffer under a single acquisition
+// of the lock, and returns them in the order they were enqueued. Fewer than n values are returned
+// if the buffer holds fewer, and none if it is empty. The version is incremented once.
+//
+// Panics if n is negative.
+func (buf *RingBuffer[T]) DequeueN(n int) []T {
+
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	if n > buf.size {
+		n = buf.size
+	}
+
+	values := make([]T, n)
+
+	if n == 0 {
+		return values
+	}
+
+	version := buf.version
+
+	for i := range values {
+		values[i] = buf.removeHead()
+		buf.recorder.Remove(values[i])
+	}
+
+	buf.version = version + 1
+	return values
+}
+
+// DrainTo removes up to max values from the front of the buffer as per [RingBuffer.DequeueN],
+// adds them to dst with its AddRange method, and returns the number of values removed.
+//
+// The lock of the buffer is released before the values are added, so dst may be the buffer itself,
+// or a collection being drained into the buffer by another goroutine. Values rejected by dst,
+// such as duplicates added to a set, are not returned to the buffer.
+//
+// Panics if dst is nil or max is negative.
+func (buf *RingBuffer[T]) DrainTo(dst collections.Collection[T], max int) int {
+
+	if dst == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dst"))
+	}
+
+	if max < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "max"))
+	}
+
+	values := buf.DequeueN(max)
+
+	if len(values) > 0 {
+		dst.AddRange(values)
+	}
+
+	return len(values)
+}
@@ -1,6 +1,8 @@
 package ringbuffer
 
 import (
+	"errors"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/messages"
@@ -20,6 +22,12 @@ func (buf *RingBuffer[T]) Any(predicate functions.PredicateFunc[T]) bool {
 		defer buf.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
 	iter := newForwardIterator[T](buf, predicate)
 
 	return iter.Start() != nil
@@ -35,6 +43,12 @@ func (buf *RingBuffer[T]) All(predicate functions.PredicateFunc[T]) bool {
 		defer buf.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
 	iter := newForwardIterator[T](buf, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -55,6 +69,12 @@ func (buf *RingBuffer[T]) ForEach(f func(collections.Element[T])) {
 		defer buf.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
 	iter := newForwardIterator[T](buf, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -62,6 +82,59 @@ func (buf *RingBuffer[T]) ForEach(f func(collections.Element[T])) {
 	}
 }
 
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (buf *RingBuffer[T]) TryForEach(f func(T) error) error {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](buf, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (buf *RingBuffer[T]) TryForEachAll(f func(T) error) error {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	var errs []error
+	iter := newForwardIterator[T](buf, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Map applies function f to all elements in the collection
 // and returns a new RingBuffer containing the result of f.
 func (buf *RingBuffer[T]) Map(f func(T) T) collections.Collection[T] {
@@ -72,6 +145,12 @@ func (buf *RingBuffer[T]) Map(f func(T) T) collections.Collection[T] {
 		defer buf.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
 	iter := newForwardIterator[T](buf, util.DefaultPredicate[T])
 
 	buf1 := New[T](buf.maxSize, WithComparer[T](buf.compare))
@@ -93,6 +172,10 @@ func (buf *RingBuffer[T]) Select(predicate functions.PredicateFunc[T]) collectio
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	return buf.doSelect(predicate, false)
 }
 
@@ -108,9 +191,21 @@ func (buf *RingBuffer[T]) SelectDeep(predicate functions.PredicateFunc[T]) colle
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	return buf.doSelect(predicate, true)
 }
 
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (buf *RingBuffer[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(buf.copyWhere(predicate))
+}
+
 // Find finds the first occurrence of an element matching the predicate.
 //
 // The function returns nil if no match.
@@ -122,6 +217,10 @@ func (buf *RingBuffer[T]) Find(predicate functions.PredicateFunc[T]) collections
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	result := buf.doFind(predicate, false)
 
 	if len(result) == 0 {
@@ -142,6 +241,10 @@ func (buf *RingBuffer[T]) FindAll(predicate functions.PredicateFunc[T]) []collec
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	result := buf.doFind(predicate, true)
 
 	return result
@@ -155,6 +258,10 @@ func (buf *RingBuffer[T]) Min() T {
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	if buf.size == 0 {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -182,6 +289,10 @@ func (buf *RingBuffer[T]) Max() T {
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	if buf.size == 0 {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -223,7 +334,7 @@ func (q *RingBuffer[T]) doFind(predicate functions.PredicateFunc[T], all bool) [
 // Buffer capacity is the same as the source buffer.
 func (buf *RingBuffer[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
 
-	buf1 := New[T](buf.maxSize, WithComparer[T](buf.compare))
+	buf1 := New[T](buf.maxSize, WithComparer[T](buf.compare), WithDeepCopy(buf.copy), WithCopyPolicy[T](buf.copyPolicy))
 	iter := newForwardIterator[T](buf, predicate)
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -236,3 +347,24 @@ func (buf *RingBuffer[T]) doSelect(predicate functions.PredicateFunc[T], deepCop
 
 	return buf1
 }
+
+func (buf *RingBuffer[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+	iter := newForwardIterator[T](buf, predicate)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, util.DeepCopy(e.Value(), buf.copy))
+	}
+
+	return values
+}
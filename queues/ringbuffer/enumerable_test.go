@@ -1,6 +1,8 @@
 package ringbuffer
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/fireflycons/generic_collections/collections"
@@ -108,6 +110,37 @@ func TestEnumerable(t *testing.T) {
 		// Output won't be in the same order as input slice
 		require.ElementsMatch(t, expected, q1.ToSlice())
 	})
+
+	t.Run("CopyTo copies all values <= 6 to destination", func(t *testing.T) {
+		q = New[int](util.DefaultCapacity)
+		expected := []int{1, 2, 4, 6}
+
+		q.AddRange(evens)
+		dest := New[int](util.DefaultCapacity)
+		dest.Add(1)
+		q.CopyTo(dest, func(i int) bool { return i <= 6 })
+
+		require.ElementsMatch(t, expected, dest.ToSlice())
+		require.Equal(t, len(evens), q.Count())
+	})
+
+	t.Run("CopyTo deep copies values", func(t *testing.T) {
+		values := []int{1, 2, 3}
+		src := New(util.DefaultCapacity, WithDeepCopy(deepCopyIntPtr))
+
+		for i := range values {
+			src.Add(&values[i])
+		}
+
+		dest := New[*int](util.DefaultCapacity)
+		src.CopyTo(dest, func(p *int) bool { return *p >= 2 })
+		require.Equal(t, 2, dest.Count())
+
+		dest.ForEach(func(e collections.Element[*int]) {
+			require.NotSame(t, &values[1], e.Value())
+			require.NotSame(t, &values[2], e.Value())
+		})
+	})
 }
 
 func TestFindAll(t *testing.T) {
@@ -154,3 +187,46 @@ func TestMinMax(t *testing.T) {
 		require.Equal(t, max, buf.Max())
 	})
 }
+
+func deepCopyIntPtr(p *int) *int {
+	v := *p
+	return &v
+}
+
+func TestTryForEach(t *testing.T) {
+
+	errOdd := errors.New("odd")
+	failOdd := func(v int) error {
+		if v%2 != 0 {
+			return fmt.Errorf("%d: %w", v, errOdd)
+		}
+		return nil
+	}
+
+	t.Run("TryForEach stops at first error", func(t *testing.T) {
+		c := Of(2, 4, 5, 6, 7)
+		visited := 0
+		err := c.TryForEach(func(v int) error {
+			visited++
+			return failOdd(v)
+		})
+
+		require.True(t, errors.Is(err, errOdd))
+		require.Less(t, visited, 5)
+		require.NoError(t, Of(2, 4).TryForEach(failOdd))
+	})
+
+	t.Run("TryForEachAll collects all errors", func(t *testing.T) {
+		c := Of(2, 4, 5, 6, 7)
+		visited := 0
+		err := c.TryForEachAll(func(v int) error {
+			visited++
+			return failOdd(v)
+		})
+
+		require.True(t, errors.Is(err, errOdd))
+		require.Equal(t, 5, visited)
+		require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+		require.NoError(t, Of(2, 4).TryForEachAll(failOdd))
+	})
+}
@@ -0,0 +1,71 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/queues/ringbuffer"
+)
+
+func Example() {
+	// Keep only the three most recent values.
+	buf := ringbuffer.New[int](3)
+
+	for i := 1; i <= 5; i++ {
+		buf.Enqueue(i)
+	}
+
+	fmt.Println(buf.ToSlice(), buf.Full())
+	// Output:
+	// [3 4 5] true
+}
+
+func ExampleRingBuffer_Offer() {
+	buf := ringbuffer.New[int](2)
+
+	fmt.Println(buf.Offer(1), buf.Offer(2), buf.Offer(3))
+	fmt.Println(buf.ToSlice())
+	// Output:
+	// true true false
+	// [1 2]
+}
+
+func ExampleRingBuffer_ReverseIterator() {
+	buf := ringbuffer.Of(1, 2, 3)
+	iter := buf.ReverseIterator()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Print(e.Value(), " ")
+	}
+	fmt.Println()
+	// Output:
+	// 3 2 1
+}
+
+func ExampleRingBuffer_Downsample() {
+	buf := ringbuffer.New[float64](8)
+	buf.AddRange([]float64{1, 3, 2, 4, 6, 8, 7, 9})
+
+	mean := func(bucket []float64) float64 {
+		total := 0.0
+		for _, v := range bucket {
+			total += v
+		}
+		return total / float64(len(bucket))
+	}
+
+	fmt.Println(buf.Downsample(4, mean).ToSlice())
+	fmt.Println(buf.Thin(3).ToSlice())
+	// Output:
+	// [2 3 7 8]
+	// [1 4 7]
+}
+
+func ExampleWithWeigher() {
+	buf := ringbuffer.New(10, ringbuffer.WithWeigher(func(s string) int { return len(s) }, 8))
+	buf.AddRange([]string{"abc", "de", "fgh"})
+	buf.Enqueue("ijkl")
+
+	fmt.Println(buf.ToSlice(), buf.Weight())
+	// Output:
+	// [fgh ijkl] 7
+}
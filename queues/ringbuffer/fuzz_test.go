@@ -0,0 +1,65 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzRemove applies a sequence of operations decoded from ops to a buffer and to a slice
+// that models it, checking after each operation that they hold the same values and that
+// the internal state of the buffer is consistent. Each pair of bytes in ops is an operation
+// and a value, so that Remove is exercised with the head and tail in all relative positions,
+// including when the buffer is full.
+//
+//	go test -run=^$ -fuzz=FuzzRemove ./queues/ringbuffer
+func FuzzRemove(f *testing.F) {
+	f.Add(uint8(4), []byte{0, 1, 0, 2, 0, 3, 0, 4, 0, 5, 3, 3, 0, 6, 3, 6})
+	f.Add(uint8(2), []byte{0, 1, 0, 2, 0, 3, 3, 2, 2, 0, 0, 4, 3, 4})
+
+	f.Fuzz(func(t *testing.T, capacity uint8, ops []byte) {
+		maxSize := int(capacity%16) + 1
+		buf := New[byte](maxSize)
+		model := []byte{}
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			value := ops[i+1] % 16
+
+			switch ops[i] % 4 {
+			case 0, 1:
+				buf.Enqueue(value)
+
+				if len(model) == maxSize {
+					model = model[1:]
+				}
+
+				model = append(model, value)
+			case 2:
+				if len(model) > 0 {
+					require.Equal(t, model[0], buf.Dequeue())
+					model = model[1:]
+				}
+			case 3:
+				index := modelIndex(model, value)
+				require.Equal(t, index != -1, buf.Remove(value))
+
+				if index != -1 {
+					model = append(model[:index:index], model[index+1:]...)
+				}
+			}
+
+			buf.verifyInvariants()
+			require.Equal(t, model, buf.ToSlice())
+		}
+	})
+}
+
+func modelIndex(model []byte, value byte) int {
+	for i, v := range model {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
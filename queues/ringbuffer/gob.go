@@ -0,0 +1,116 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// gobState is the encoded form of a buffer. Values are held from head to tail,
+// and placed back in the same positions of a buffer of the same maximum size when decoded.
+type gobState[T any] struct {
+	MaxSize int
+	Head    int
+	Values  []T
+}
+
+// GobEncode implements gob.GobEncoder, encoding the values of the buffer from head to tail
+// together with its maximum size and the position of its head, so that the buffer may be persisted
+// or sent over RPC with encoding/gob. The values must themselves be encodable by encoding/gob.
+// The values follow a header describing the [schema] of the buffer, including its maximum size,
+// but its other options are not encoded.
+func (buf *RingBuffer[T]) GobEncode() ([]byte, error) {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	return util.GobEncode(schema.Of[T](collections.COLLECTION_RINGBUFFER, map[string]string{"maxSize": strconv.Itoa(buf.maxSize)}), gobState[T]{
+		MaxSize: buf.maxSize,
+		Head:    buf.head,
+		Values:  buf.toSlice(false, false),
+	})
+}
+
+// GobDecode implements gob.GobDecoder, replacing the values of the buffer with those encoded by GobEncode.
+// The maximum size, head, tail and full state of the encoded buffer are restored, so a zero value
+// buffer may be decoded into.
+//
+// The options of the buffer are retained, so decode into a buffer constructed with the options
+// of that encoded, or into a zero value for the default options.
+//
+// Panics if the buffer has been closed.
+func (buf *RingBuffer[T]) GobDecode(data []byte) error {
+
+	var state gobState[T]
+
+	// The maximum size is restored from the data, so is not verified.
+	if err := util.GobDecode(data, &state, schema.Of[T](collections.COLLECTION_RINGBUFFER, nil), buf.verifySchema); err != nil {
+		return err
+	}
+
+	if state.MaxSize < 1 || len(state.Values) > state.MaxSize || state.Head < 0 || state.Head >= state.MaxSize {
+		return fmt.Errorf(messages.DECODE_INVALID_FMT, "RingBuffer")
+	}
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	if buf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	if buf.compare == nil {
+		// Completing the construction of a zero value.
+		buf.compare = util.GetZeroValueComparer[T]()
+		buf.copy = util.DefaultDeepCopy[T]
+	}
+
+	buf.maxSize = state.MaxSize
+	buf.buffer = make([]T, buf.maxSize)
+	buf.head = state.Head
+	buf.size = len(state.Values)
+	buf.tail = (buf.head + buf.size) % buf.maxSize
+	buf.full = buf.size == buf.maxSize
+
+	for i, v := range state.Values {
+		buf.buffer[buf.bufferIndex(i)] = v
+	}
+
+	if buf.conflator != nil {
+		buf.conflator.Reset(buf.size, buf.at)
+	}
+
+	if buf.weigher != nil {
+		buf.weigher.Reset(buf.size, buf.at)
+	}
+
+	buf.version++
+	buf.recorder.Reset(func() []T { return state.Values })
+	buf.signalRoom()
+	return nil
+}
+
+// Option function for New to verify the schema header of data decoded by GobDecode and Restore,
+// which then returns an error wrapping [schema.ErrMismatch] if the data was encoded
+// from another type of collection or value, or [schema.ErrMissing] if it has no header.
+func WithSchemaValidation[T any]() RingBufferOptionFunc[T] {
+	return func(buf *RingBuffer[T]) {
+		buf.verifySchema = true
+	}
+}
@@ -0,0 +1,55 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGob(t *testing.T) {
+
+	t.Run("Round trip preserves values and state", func(t *testing.T) {
+		buf := New[int](4)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6})
+		buf.Dequeue()
+
+		var b bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&b).Encode(buf))
+
+		var decoded RingBuffer[int]
+		require.NoError(t, gob.NewDecoder(&b).Decode(&decoded))
+
+		require.Equal(t, []int{4, 5, 6}, decoded.ToSlice())
+		require.Equal(t, buf.head, decoded.head)
+		require.Equal(t, buf.tail, decoded.tail)
+		require.Equal(t, buf.full, decoded.full)
+		require.Equal(t, buf.maxSize, decoded.maxSize)
+
+		decoded.Add(7)
+		decoded.Add(8)
+		require.Equal(t, []int{5, 6, 7, 8}, decoded.ToSlice())
+	})
+
+	t.Run("Full buffer", func(t *testing.T) {
+		buf := Of(1, 2, 3)
+		buf.Add(4)
+		data, err := buf.GobEncode()
+		require.NoError(t, err)
+
+		decoded := New[int](10)
+		require.NoError(t, decoded.GobDecode(data))
+		require.True(t, decoded.full)
+		require.Equal(t, []int{2, 3, 4}, decoded.ToSlice())
+	})
+
+	t.Run("Invalid state is rejected", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&b).Encode(gobState[int]{MaxSize: 2, Values: []int{1, 2, 3}}))
+
+		require.EqualError(t, New[int](2).GobDecode(b.Bytes()), fmt.Sprintf(messages.DECODE_INVALID_FMT, "RingBuffer"))
+	})
+}
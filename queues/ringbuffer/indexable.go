@@ -0,0 +1,114 @@
+package ringbuffer
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert Indexable implementation
+var _ collections.Indexable[int] = (*RingBuffer[int])(nil)
+
+// At returns the value at the given position, where position 0 is the head of the buffer.
+//
+// Panics if index is out of range.
+func (buf *RingBuffer[T]) At(index int) T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	util.ValidateIndex(index, buf.size)
+	return buf.buffer[buf.bufferIndex(index)]
+}
+
+// SetAt replaces the value at the given position, where position 0 is the head of the buffer.
+// Together with At, this permits the values in the window to be updated in place, e.g.
+//
+//	for i := 1; i < buf.Count(); i++ {
+//		buf.SetAt(i, (buf.At(i-1) + buf.At(i)) / 2)
+//	}
+//
+// As with modifying a value via [collections.Element], this does not invalidate iterators.
+//
+// Panics if index is out of range.
+func (buf *RingBuffer[T]) SetAt(index int, value T) {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	util.ValidateIndex(index, buf.size)
+
+	if !buf.validator.Accept(value) {
+		return
+	}
+
+	if buf.weigher != nil {
+		buf.weigher.Adjust(buf.weigher.Weigh(value) - buf.weigher.Weigh(buf.at(index)))
+	}
+
+	buf.buffer[buf.bufferIndex(index)] = value
+	buf.immutable.Invalidate()
+	buf.recorder.Reset(func() []T { return buf.toSlice(false, false) })
+}
+
+// Swap exchanges the values at the given positions, where position 0 is the head of the buffer.
+//
+// Panics if either index is out of range.
+func (buf *RingBuffer[T]) Swap(i, j int) {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	util.ValidateIndex(i, buf.size)
+	util.ValidateIndex(j, buf.size)
+	i, j = buf.bufferIndex(i), buf.bufferIndex(j)
+	buf.buffer[i], buf.buffer[j] = buf.buffer[j], buf.buffer[i]
+	buf.version++
+	buf.recorder.Reset(func() []T { return buf.toSlice(false, false) })
+}
+
+// CompareAt compares the values at the given positions using the collection's comparer,
+// where position 0 is the head of the buffer.
+//
+// Panics if either index is out of range.
+func (buf *RingBuffer[T]) CompareAt(i, j int) int {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	util.ValidateIndex(i, buf.size)
+	util.ValidateIndex(j, buf.size)
+	return buf.compare(buf.buffer[buf.bufferIndex(i)], buf.buffer[buf.bufferIndex(j)])
+}
+
+// bufferIndex converts a position to an index into the underlying buffer.
+func (buf *RingBuffer[T]) bufferIndex(index int) int {
+	return (buf.head + index) % buf.maxSize
+}
@@ -0,0 +1,25 @@
+package ringbuffer
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the buffer is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (buf *RingBuffer[T]) verifyInvariants() {
+	if buf.maxSize == 0 {
+		// Zero value, never initialized.
+		util.AssertInvariant(buf.buffer == nil && buf.size == 0, "zero value buffer is not empty")
+		return
+	}
+
+	util.AssertInvariant(len(buf.buffer) == buf.maxSize, "buffer length is not max size")
+	util.AssertInvariant(buf.size >= 0 && buf.size <= buf.maxSize, "buffer size exceeds max size")
+	util.AssertInvariant(buf.head >= 0 && buf.head < buf.maxSize, "buffer head outside buffer")
+	util.AssertInvariant(buf.tail == (buf.head+buf.size)%buf.maxSize, "buffer tail does not follow last value")
+	util.AssertInvariant(buf.full == (buf.size == buf.maxSize), "buffer full flag does not match size")
+
+	if buf.weigher != nil {
+		total := buf.weigher.Total()
+		buf.weigher.Reset(buf.size, buf.at)
+		util.AssertInvariant(total == buf.weigher.Total(), "buffer weight does not match values")
+	}
+}
@@ -1,6 +1,8 @@
 package ringbuffer
 
 import (
+	"math/rand"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/local"
@@ -81,6 +83,40 @@ func (buf *RingBuffer[T]) TakeWhile(predicate functions.PredicateFunc[T]) collec
 	return newForwardIterator(buf, predicate)
 }
 
+// SampleIterator returns an iterator that yields n values chosen at random from the buffer,
+// without replacement and in random order. If the buffer holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the buffer.
+// If r is nil, the default source of the math/rand package is used.
+//
+//	iter := buf.SampleIterator(100, nil)
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// audit e.Value()
+//	}
+//
+// Panics if n is negative.
+func (buf *RingBuffer[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](buf, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the buffer at the time of the call,
+// in the same order as ToSlice, so that the buffer may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the buffer's immutable slice, so it is only copied if the buffer has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the buffer, and their ValuePtr method panics.
+//
+//	iter := buf.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		buf.Remove(e.Value())
+//	}
+func (buf *RingBuffer[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(buf.ToImmutableSlice())
+}
+
 // Start begins an iteration across the queue returning the fisrt element,
 // which will be nil if the collection is empty.
 //
@@ -100,7 +136,7 @@ func (i *RingBufferIterator[T]) Start() collections.Element[T] {
 		return i.Next()
 	}
 
-	return util.NewElementType[T](i.buffer, valPtr)
+	return util.NewElementType[T](i.buffer, valPtr, i.buffer.compare)
 }
 
 // Next returns the next element in the collection,
@@ -120,7 +156,7 @@ func (i *RingBufferIterator[T]) Next() collections.Element[T] {
 		valPtr := &(i.buffer.buffer[i.toBufferPosition()])
 
 		if i.predicate(*valPtr) {
-			return util.NewElementType[T](i.buffer, valPtr)
+			return util.NewElementType[T](i.buffer, valPtr, i.buffer.compare)
 		}
 	}
 }
@@ -0,0 +1,95 @@
+package ringbuffer
+
+import (
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// ApplyOps applies the given operations to the buffer in order.
+// Add enqueues the value, displacing the value at the front if the buffer is full, Remove removes the first occurrence of the value
+// searching from the front, and Clear empties the buffer.
+//
+// Panics if an op has an invalid kind, or an Add op is applied to a closed buffer.
+func (buf *RingBuffer[T]) ApplyOps(operations []ops.Op[T]) {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	util.ApplyOps(
+		operations,
+		func(value T) {
+			if buf.closed {
+				panic(messages.COLLECTION_CLOSED)
+			}
+			if buf.validator.Accept(value) {
+				buf.enqueue(value)
+			}
+		},
+		func(value T) { buf.remove(value) },
+		buf.clear,
+	)
+}
+
+// StartRecording begins capturing mutations of the buffer as ops,
+// discarding any ops previously recorded.
+//
+// Dequeued values are recorded as a Remove of the value, which when replayed
+// removes the value at the front of the buffer. Values displaced by adding to a full buffer
+// are not recorded, as replaying the Add on a buffer of the same size displaces them. Sorting and swapping values
+// are recorded as a Clear followed by an Add of each value.
+func (buf *RingBuffer[T]) StartRecording() {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	buf.recorder.Start(buf.copy)
+}
+
+// StopRecording ends recording and returns the ops recorded since StartRecording.
+func (buf *RingBuffer[T]) StopRecording() []ops.Op[T] {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	return buf.recorder.Stop()
+}
+
+// IsRecording returns true if the buffer is recording mutations.
+func (buf *RingBuffer[T]) IsRecording() bool {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	return buf.recorder.IsRecording()
+}
@@ -0,0 +1,108 @@
+package ringbuffer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// OverflowPolicy determines what a buffer does with a value enqueued while it is full.
+type OverflowPolicy int
+
+const (
+	// OverwriteOldest displaces the value at the head of the buffer to make room for the new value.
+	// This is the default.
+	OverwriteOldest OverflowPolicy = iota
+
+	// Reject discards the new value, as [RingBuffer.Offer] does.
+	Reject
+
+	// Block waits until a value is dequeued or removed to make room for the new value.
+	Block
+)
+
+// String returns the name of the policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverwriteOldest:
+		return "OverwriteOldest"
+	case Reject:
+		return "Reject"
+	case Block:
+		return "Block"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// Option function for New to determine what Enqueue, Add, AddRange and AddCollection
+// do with a value when the buffer is full, or when the value would take the total weight
+// of the buffer over the budget set by [WithWeigher]. The default is [OverwriteOldest].
+//
+// With [Reject], each of these behaves as [RingBuffer.Offer]. Enqueue and AddRange discard the values
+// that do not fit, and Add returns false. With [Block], each waits until there is room for each value
+// in turn, so AddRange of more values than the buffer can hold returns only once consumers have
+// dequeued enough of them. A value that alone outweighs the budget waits until the buffer is empty.
+// If the buffer is closed while waiting, Add returns false and Enqueue and AddRange panic.
+// Block implies [WithThreadSafe], as other goroutines must be able to make room.
+//
+// With either policy, ReplaceAll keeps the values that fit, from the start of the collection,
+// rather than the end-most values, and conflation still replaces a buffered value with the same key.
+//
+// Panics if policy is not one of the defined policies.
+func WithOverflowPolicy[T any](policy OverflowPolicy) RingBufferOptionFunc[T] {
+	if policy < OverwriteOldest || policy > Block {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "policy"))
+	}
+
+	return func(buf *RingBuffer[T]) {
+		buf.overflow = policy
+	}
+}
+
+// OverflowPolicy returns the policy set by [WithOverflowPolicy].
+func (buf *RingBuffer[T]) OverflowPolicy() OverflowPolicy {
+	return buf.overflow
+}
+
+// put adds a value to the end of the buffer according to the overflow policy,
+// returning false if it was not added, or replaced a buffered value with the same key.
+func (buf *RingBuffer[T]) put(value T) bool {
+	switch buf.overflow {
+	case Reject:
+		return buf.hasRoom(value) && buf.enqueue(value)
+
+	case Block:
+		for !buf.closed && buf.size > 0 && !buf.hasRoom(value) {
+			// The context is never done, so there is no error.
+			_ = buf.notFull.Wait(context.Background(), buf.lock)
+		}
+
+		return !buf.closed && buf.enqueue(value)
+
+	default:
+		return buf.enqueue(value)
+	}
+}
+
+// hasRoom returns true if value can be enqueued without displacing another value.
+func (buf *RingBuffer[T]) hasRoom(value T) bool {
+	if buf.weigher != nil && !buf.weigher.Fits(buf.weightDelta(value)) {
+		return false
+	}
+
+	return !buf.full || (buf.conflator != nil && buf.conflator.Find(value, buf.size, buf.at) != -1)
+}
+
+// signalRoom wakes goroutines waiting for room in the buffer, if it was created to Block.
+// Called when values are removed or the buffer is closed.
+//
+// All waiters are woken, since a removal may free room for several of them, and with a weigher
+// the room freed may suit some waiting values but not others. Each removal therefore costs
+// a wake of every blocked producer, most of which find the buffer full again and park.
+func (buf *RingBuffer[T]) signalRoom() {
+	if buf.notFull != nil {
+		buf.notFull.Broadcast()
+	}
+}
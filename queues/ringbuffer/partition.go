@@ -0,0 +1,124 @@
+package ringbuffer
+
+import (
+	"sync"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// PartitionInPlace reorders the buffer so that all values matching the predicate
+// are nearer the head than those that do not, and returns the number of matching values.
+//
+// After the call, positions 0 to split-1 (counting from the head of the buffer) hold the matching values
+// in their original relative order, followed by the non-matching values in their original relative order.
+// O(n), using a buffer for the non-matching values.
+func (buf *RingBuffer[T]) PartitionInPlace(predicate functions.PredicateFunc[T]) int {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	// Matching values are moved forward in place, and the values they pass over
+	// are held aside to be copied back after them, so that both keep their order.
+	split := 0
+	moved := false
+	var rest []T
+
+	for i := 0; i < buf.size; i++ {
+		value := buf.buffer[buf.bufferIndex(i)]
+
+		if !predicate(value) {
+			rest = append(rest, value)
+			continue
+		}
+
+		if i != split {
+			buf.buffer[buf.bufferIndex(split)] = value
+			moved = true
+		}
+
+		split++
+	}
+
+	if moved {
+		for i, value := range rest {
+			buf.buffer[buf.bufferIndex(split+i)] = value
+		}
+
+		buf.version++
+		buf.recorder.Reset(func() []T { return buf.toSlice(false, false) })
+	}
+
+	return split
+}
+
+// ExtractWhere removes all values matching the predicate from the buffer in a single pass,
+// and returns them as a new buffer of the same maximum size in their original relative order.
+//
+// Values may be extracted from a buffer that has been closed.
+func (buf *RingBuffer[T]) ExtractWhere(predicate functions.PredicateFunc[T]) *RingBuffer[T] {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	extracted := buf.makeEmptyCopy()
+	kept := 0
+
+	for i := 0; i < buf.size; i++ {
+		value := buf.buffer[buf.bufferIndex(i)]
+
+		if predicate(value) {
+			extracted.enqueue(value)
+		} else {
+			buf.buffer[buf.bufferIndex(kept)] = value
+			kept++
+		}
+	}
+
+	if extracted.size == 0 {
+		return extracted
+	}
+
+	var empty T
+	for i := kept; i < buf.size; i++ {
+		buf.buffer[buf.bufferIndex(i)] = empty
+	}
+
+	buf.size = kept
+	buf.tail = buf.bufferIndex(kept)
+	buf.full = false
+	buf.version++
+
+	if buf.weigher != nil {
+		buf.weigher.Reset(buf.size, buf.at)
+	}
+	buf.recorder.Reset(func() []T { return buf.toSlice(false, false) })
+	buf.signalRoom()
+	return extracted
+}
+
+func (buf *RingBuffer[T]) makeEmptyCopy() *RingBuffer[T] {
+	other := New[T](buf.maxSize, WithComparer[T](buf.compare), WithDeepCopy[T](buf.copy))
+
+	if buf.lock != nil {
+		other.lock = &sync.RWMutex{}
+	}
+
+	return other
+}
@@ -0,0 +1,67 @@
+package ringbuffer
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+)
+
+// Option function for New to give the ring buffer a name, under which it is registered with the
+// [registry] so that its size, version and operation counts are included in [registry.Snapshot].
+// The ring buffer remains registered until Dispose is called.
+//
+// Panics if name is empty.
+func WithName[T any](name string) RingBufferOptionFunc[T] {
+	if name == "" {
+		panic(messages.NAME_EMPTY)
+	}
+	return func(buf *RingBuffer[T]) {
+		buf.name = name
+	}
+}
+
+// Dispose removes the ring buffer from the [registry] if it was created with [WithName].
+// The ring buffer remains usable, but no longer appears in [registry.Snapshot].
+// Calling Dispose more than once has no effect.
+func (buf *RingBuffer[T]) Dispose() {
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.registration.Deregister()
+	buf.registration = nil
+}
+
+// register registers the ring buffer with the registry if it has been given a name.
+func (buf *RingBuffer[T]) register() {
+	if buf.name == "" {
+		return
+	}
+
+	buf.recorder.EnableCounting()
+	buf.registration = registry.Register(buf.name, buf.stats)
+}
+
+func (buf *RingBuffer[T]) stats() registry.Stats {
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	return registry.Stats{
+		Type:    collections.COLLECTION_RINGBUFFER,
+		Count:   buf.size,
+		Version: buf.version,
+		Ops:     buf.recorder.Counts(),
+	}
+}
@@ -0,0 +1,54 @@
+package ringbuffer
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// Thin returns a new RingBuffer with the same capacity and properties as this one,
+// containing every keepEvery'th value counting from the head, starting with the head itself.
+// This is a cheap way to reduce a high-rate window of samples to a series that can be plotted.
+//
+// Panics if keepEvery is less than 1.
+func (buf *RingBuffer[T]) Thin(keepEvery int) *RingBuffer[T] {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	return buf.resampled(util.Thin(buf.toSlice(false, false), keepEvery))
+}
+
+// Downsample returns a new RingBuffer with the same capacity and properties as this one,
+// containing n values. The values of this buffer are divided from head to tail into n
+// consecutive buckets whose sizes differ by at most one, and each bucket is aggregated
+// into a single value by reducer, e.g. to take the mean or maximum of each bucket.
+//
+// If the buffer holds no more than n values, each value is a bucket of its own.
+//
+// Panics if n is less than 1 or reducer is nil.
+func (buf *RingBuffer[T]) Downsample(n int, reducer func([]T) T) *RingBuffer[T] {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	return buf.resampled(util.Downsample(buf.toSlice(false, false), n, reducer))
+}
+
+func (buf *RingBuffer[T]) resampled(values []T) *RingBuffer[T] {
+	buf1 := buf.makeEmptyCopy()
+
+	for _, v := range values {
+		buf1.enqueue(v)
+	}
+
+	return buf1
+}
@@ -0,0 +1,109 @@
+package ringbuffer
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// MaxSize returns the maximum number of values the buffer can hold.
+func (buf *RingBuffer[T]) MaxSize() int {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	return buf.maxSize
+}
+
+// Resize changes the maximum number of values the buffer can hold, reallocating it
+// with the values in the same order from the head.
+//
+// If the buffer holds more than newMax values, those nearest the head are discarded as though
+// displaced by new values, whatever the overflow policy. Growing the buffer wakes goroutines
+// waiting for room to enqueue when it was created to [Block].
+//
+// O(n).
+//
+// Panics if newMax is less than 1.
+func (buf *RingBuffer[T]) Resize(newMax int) {
+
+	if newMax < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "newMax"))
+	}
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+	buf.resize(newMax)
+}
+
+// EnsureCapacity grows the buffer if necessary so that it can hold at least n values.
+// A buffer that can already hold n values is not changed, so values are never discarded.
+//
+// Panics if n is negative.
+func (buf *RingBuffer[T]) EnsureCapacity(n int) {
+
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	if n > buf.maxSize {
+		buf.resize(n)
+	}
+}
+
+func (buf *RingBuffer[T]) resize(newMax int) {
+	if newMax == buf.maxSize {
+		return
+	}
+
+	discarded := buf.size > newMax
+
+	for buf.size > newMax {
+		buf.removeHead()
+	}
+
+	buffer := make([]T, newMax)
+
+	for i := 0; i < buf.size; i++ {
+		buffer[i] = buf.at(i)
+	}
+
+	buf.buffer = buffer
+	buf.maxSize = newMax
+	buf.head = 0
+	buf.tail = buf.size % newMax
+	buf.full = buf.size == newMax
+	buf.version++
+
+	if discarded {
+		buf.recorder.Reset(func() []T { return buf.toSlice(false, false) })
+	}
+
+	buf.signalRoom()
+}
@@ -0,0 +1,76 @@
+package ringbuffer
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*RingBuffer[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single pass,
+// keeping the remaining values in their order, and returns the number of values removed.
+//
+// Values may be removed from a buffer that has been closed.
+func (buf *RingBuffer[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	kept := 0
+
+	for i := 0; i < buf.size; i++ {
+		value := buf.buffer[buf.bufferIndex(i)]
+
+		if predicate(value) {
+			buf.buffer[buf.bufferIndex(kept)] = value
+			kept++
+		}
+	}
+
+	removed := buf.size - kept
+
+	if removed == 0 {
+		return 0
+	}
+
+	var empty T
+	for i := kept; i < buf.size; i++ {
+		buf.buffer[buf.bufferIndex(i)] = empty
+	}
+
+	buf.size = kept
+	buf.tail = buf.bufferIndex(kept)
+	buf.full = false
+	buf.version++
+
+	if buf.conflator != nil {
+		buf.conflator.Reset(buf.size, buf.at)
+	}
+
+	if buf.weigher != nil {
+		buf.weigher.Reset(buf.size, buf.at)
+	}
+
+	buf.recorder.Reset(func() []T { return buf.toSlice(false, false) })
+	buf.signalRoom()
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (buf *RingBuffer[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](buf, other, buf.RetainWhere)
+}
@@ -14,35 +14,56 @@ import (
 	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/fireflycons/generic_collections/queues"
+	"github.com/fireflycons/generic_collections/registry"
+	"golang.org/x/exp/constraints"
 )
 
 var _ queues.Queue[int] = (*RingBuffer[int])(nil)
 var _ collections.ReverseIterable[int] = (*RingBuffer[int])(nil)
+var _ collections.Pageable[int] = (*RingBuffer[int])(nil)
 
 // RingBufferOptionFunc is the signature of a function
 // for providing options to the RingBuffer constructor.
 type RingBufferOptionFunc[T any] func(*RingBuffer[T])
 
 // RingBuffer implements a first-in, first-out collection,
-// of fixed size. When the buffer is full, items added to
-// the end displace items at the front.
+// of bounded size. When the buffer is full, items added to
+// the end displace items at the front, unless another
+// policy is set with [WithOverflowPolicy].
+//
+// As a RingBuffer has no default size, it must be constructed with New.
+// Modifying a zero value RingBuffer panics.
 type RingBuffer[T any] struct {
-	version int
-	lock    *sync.RWMutex
-	head    int
-	tail    int
-	full    bool
-	maxSize int
-	size    int
-	compare functions.ComparerFunc[T]
-	copy    functions.DeepCopyFunc[T]
-	buffer  []T
+	version      int
+	lock         *sync.RWMutex
+	head         int
+	tail         int
+	full         bool
+	maxSize      int
+	size         int
+	compare      functions.ComparerFunc[T]
+	copy         functions.DeepCopyFunc[T]
+	copyPolicy   collections.CopyPolicy
+	buffer       []T
+	closed       bool
+	recorder     util.OpRecorder[T]
+	guard        util.AccessGuard
+	immutable    util.SliceCache[T]
+	conflator    util.Conflator[T]
+	weigher      *util.Weigher[T]
+	validator    *util.Validator[T]
+	verifySchema bool
+	overflow     OverflowPolicy
+	notFull      *util.Waiter
+	position     int
+	name         string
+	registration *registry.Registration
 
 	local.InternalImpl
 }
 
 // New instantiates a new empty buffer with the specified size of maximum number of elements that it can hold.
-// This max size of the buffer cannot be changed.
+// The max size of the buffer may be changed later with Resize or EnsureCapacity.
 func New[T any](maxSize int, options ...RingBufferOptionFunc[T]) *RingBuffer[T] {
 	if maxSize < 1 {
 		panic("Invalid maxSize, should be at least 1")
@@ -57,7 +78,7 @@ func New[T any](maxSize int, options ...RingBufferOptionFunc[T]) *RingBuffer[T]
 		o(buf)
 	}
 
-	if buf.copy == nil {
+	if buf.copy == nil || buf.copyPolicy == collections.Shallow {
 		buf.copy = util.DefaultDeepCopy[T]
 	}
 
@@ -65,9 +86,64 @@ func New[T any](maxSize int, options ...RingBufferOptionFunc[T]) *RingBuffer[T]
 		buf.compare = util.GetDefaultComparer[T]()
 	}
 
+	if buf.overflow == Block {
+		if buf.lock == nil {
+			buf.lock = &sync.RWMutex{}
+		}
+
+		buf.notFull = util.NewWaiter(0, functions.SystemClock)
+	}
+
+	buf.register()
+
+	return buf
+}
+
+// lazyInit is called on modification of a RingBuffer.
+// A zero value RingBuffer cannot be used, as it has no maximum size and there
+// is no default that would not silently discard values.
+func (buf *RingBuffer[T]) lazyInit() {
+	if buf.maxSize == 0 {
+		panic(fmt.Sprintf(messages.ZERO_VALUE_UNUSABLE_FMT, "RingBuffer"))
+	}
+}
+
+// Of constructs a new buffer containing the given values, e.g. ringbuffer.Of(1, 2, 3).
+//
+// The maximum size of the buffer is the number of values, or 1 if there are none.
+func Of[T any](values ...T) *RingBuffer[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	buf := ringbuffer.OfWith([]ringbuffer.RingBufferOptionFunc[int]{ringbuffer.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []RingBufferOptionFunc[T], values ...T) *RingBuffer[T] {
+	maxSize := len(values)
+
+	if maxSize == 0 {
+		maxSize = 1
+	}
+
+	buf := New(maxSize, options...)
+	buf.AddRange(values)
 	return buf
 }
 
+// NewFunc is as [New], using compare to find values, e.g. in Contains and Sort.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](maxSize int, compare functions.ComparerFunc[T], options ...RingBufferOptionFunc[T]) *RingBuffer[T] {
+	return New(maxSize, append([]RingBufferOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](maxSize int, options ...RingBufferOptionFunc[T]) *RingBuffer[T] {
+	return NewFunc(maxSize, util.GetOrderedComparer[T](), options...)
+}
+
 // Option function for New to provide a comparer function for values of type T.
 // Required if the element type is not numeric, bool, pointer or string.
 func WithComparer[T any](comparer functions.ComparerFunc[T]) RingBufferOptionFunc[T] {
@@ -94,13 +170,100 @@ func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) RingBufferOptionFunc[
 	}
 }
 
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) RingBufferOptionFunc[T] {
+	return func(buf *RingBuffer[T]) {
+		buf.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+//
+// Concurrent and Capacity are ignored by this collection.
+func WithOptions[T any](options collections.CommonOptions[T]) RingBufferOptionFunc[T] {
+	opts := make([]RingBufferOptionFunc[T], 0, 4)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(buf *RingBuffer[T]) {
+		for _, o := range opts {
+			o(buf)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection,
+// ReplaceAll, Enqueue, Offer and SetAt with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) RingBufferOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(buf *RingBuffer[T]) {
+		buf.validator = util.NewValidator(validator, policy)
+	}
+}
+
+// Option function for New to conflate values by key. When a value is enqueued
+// with the same key as a value already in the buffer, the buffered value is replaced
+// in place, keeping its position, rather than the new value displacing the value at the head.
+//
+//	buf := ringbuffer.New(100, ringbuffer.WithConflation(func(t Tick) string { return t.Symbol }))
+//
+// Panics if keyFn is nil.
+func WithConflation[T any, K comparable](keyFn func(T) K) RingBufferOptionFunc[T] {
+	if keyFn == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "keyFn"))
+	}
+	return func(buf *RingBuffer[T]) {
+		buf.conflator = util.NewConflator(keyFn)
+	}
+}
+
 // Add enqueues a value in the buffer. It is an alias for Enqueue.
 //
-// Always returns true.
+// Returns true unless the buffer has been closed, the value was rejected by the validator
+// or the overflow policy, or the value replaced a buffered value with the same key when conflating.
 func (buf *RingBuffer[T]) Add(value T) bool {
 	// util.ValidatePointerNotNil(unsafe.Pointer(buf))
-	buf.Enqueue(value)
-	return true
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	if buf.closed || !buf.validator.Accept(value) {
+		return false
+	}
+
+	return buf.put(value)
 }
 
 // AddCollection adds the values of the given collection to the end of this buffer.
@@ -114,7 +277,82 @@ func (buf *RingBuffer[T]) Add(value T) bool {
 // head of the buffer as necessary.
 func (buf *RingBuffer[T]) AddCollection(collection collections.Collection[T]) {
 	// util.ValidatePointerNotNil(unsafe.Pointer(buf))
-	buf.AddRange(collection.ToSliceDeep())
+	buf.AddRange(util.ImportValues(collection, buf.copyPolicy))
+}
+
+// ReplaceAll replaces the content of the buffer with the values of the given collection.
+//
+// If the collection is larger than the buffer, then only the end-most
+// portion of the collection that will fit into the buffer is retained,
+// as per AddCollection.
+//
+// Panics if the buffer has been closed.
+func (buf *RingBuffer[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	values := util.ImportValues(collection, buf.copyPolicy)
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+	buf.setValues(values)
+}
+
+// setValues replaces the values of the buffer with the given values. If there are more
+// values than the buffer can hold, only the end-most portion of the values is kept.
+func (buf *RingBuffer[T]) setValues(values []T) {
+
+	if buf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	values = buf.validator.Filter(values)
+
+	if buf.conflator != nil || buf.weigher != nil || buf.overflow != OverwriteOldest {
+		// Values must be conflated or weighed as they are enqueued, and kept
+		// from the start rather than the end unless overwriting the oldest.
+		// Values displaced while doing so do not advance the position.
+		position := buf.position
+		buf.clear()
+
+		for _, v := range values {
+			if buf.overflow == OverwriteOldest || buf.hasRoom(v) {
+				buf.enqueue(v)
+			}
+		}
+
+		buf.position = position
+		return
+	}
+
+	var empty T
+	for i := range buf.buffer {
+		buf.buffer[i] = empty
+	}
+
+	buf.head = 0
+
+	if len(values) >= buf.maxSize {
+		util.PartialCopy(values, len(values)-buf.maxSize, buf.buffer, 0, buf.maxSize)
+		buf.full = true
+		buf.size = buf.maxSize
+		buf.tail = 0
+	} else {
+		copy(buf.buffer, values)
+		buf.full = false
+		buf.size = len(values)
+		buf.tail = len(values)
+	}
+
+	buf.version++
+	buf.recorder.Reset(func() []T { return buf.toSlice(false, false) })
+	buf.signalRoom()
 }
 
 // AddRange enqueues the values in the given slice.
@@ -125,6 +363,8 @@ func (buf *RingBuffer[T]) AddCollection(collection collections.Collection[T]) {
 // values enqueued. If the slice is smaller than the buffer, then
 // all slice elements will be enqueued, displacing elements from the
 // head of the buffer as necessary.
+//
+// Panics if the buffer has been closed.
 func (buf *RingBuffer[T]) AddRange(values []T) {
 	// util.ValidatePointerNotNil(unsafe.Pointer(buf))
 	if len(values) == 0 {
@@ -136,10 +376,47 @@ func (buf *RingBuffer[T]) AddRange(values []T) {
 		defer buf.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	if buf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	if values = buf.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
+	if buf.overflow != OverwriteOldest {
+		for _, v := range values {
+			if !buf.put(v) && buf.closed {
+				panic(messages.COLLECTION_CLOSED)
+			}
+		}
+
+		return
+	}
+
+	if buf.conflator != nil || buf.weigher != nil {
+		for _, v := range values {
+			buf.enqueue(v)
+		}
+
+		return
+	}
+
+	for _, v := range values {
+		buf.recorder.Add(v)
+	}
+
 	if len(values) >= buf.maxSize {
 		// Buffer will be filled from incoming slice and any
 		// existing values completely displaced
 		startIndex := len(values) - buf.maxSize
+		buf.position += buf.size + startIndex
 		util.PartialCopy(values, startIndex, buf.buffer, 0, buf.maxSize)
 		buf.full = true
 		buf.size = buf.maxSize
@@ -149,14 +426,9 @@ func (buf *RingBuffer[T]) AddRange(values []T) {
 		for _, v := range values {
 			if buf.full {
 				buf.head = (buf.head + 1) % buf.maxSize
+				buf.position++
 			}
-			buf.buffer[buf.tail] = v
-			buf.tail = (buf.tail + 1) % buf.maxSize
-			if buf.tail == buf.head {
-				buf.full = true
-			} else {
-				buf.size++
-			}
+			buf.append(v)
 		}
 	}
 
@@ -175,6 +447,10 @@ func (buf *RingBuffer[T]) Contains(value T) bool {
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	return buf.find(value) != -1
 }
 
@@ -186,7 +462,11 @@ func (buf *RingBuffer[T]) CurrentVersion() int {
 // Enqueue adds a value to the end of the buffer
 //
 // If the buffer is full, the item at the head
-// is discarded.
+// is discarded, unless another policy is set with
+// [WithOverflowPolicy]. When conflating, a buffered value
+// with the same key is replaced instead.
+//
+// Panics if the buffer has been closed.
 func (buf *RingBuffer[T]) Enqueue(value T) {
 	// util.ValidatePointerNotNil(unsafe.Pointer(buf))
 
@@ -195,10 +475,36 @@ func (buf *RingBuffer[T]) Enqueue(value T) {
 		defer buf.lock.Unlock()
 	}
 
-	buf.enqueue(value)
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	if buf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	if buf.validator.Accept(value) && !buf.put(value) && buf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
 }
 
-func (buf *RingBuffer[T]) enqueue(value T) {
+// enqueue adds a value to the end of the buffer, returning false
+// if it instead replaced a buffered value with the same key.
+func (buf *RingBuffer[T]) enqueue(value T) bool {
+
+	if buf.conflate(value) {
+		if buf.weigher != nil {
+			buf.evictOverweight(0, 1)
+		}
+
+		return false
+	}
+
+	if buf.weigher != nil {
+		buf.evictOverweight(buf.weigher.Weigh(value), 0)
+	}
 
 	if buf.full {
 		// increments version
@@ -208,12 +514,44 @@ func (buf *RingBuffer[T]) enqueue(value T) {
 	}
 
 	buf.append(value)
+	buf.recorder.Add(value)
+	return true
+}
+
+// conflate replaces the buffered value with the same key as value, if conflating
+// and there is one. Returns true if a value was replaced.
+func (buf *RingBuffer[T]) conflate(value T) bool {
+	if buf.conflator == nil {
+		return false
+	}
+
+	i := buf.conflator.Find(value, buf.size, buf.at)
+
+	if i == -1 {
+		return false
+	}
+
+	if buf.weigher != nil {
+		buf.weigher.Adjust(buf.weigher.Weigh(value) - buf.weigher.Weigh(buf.at(i)))
+	}
+
+	buf.buffer[buf.bufferIndex(i)] = value
+	buf.version++
+	buf.recorder.Reset(func() []T { return buf.toSlice(false, false) })
+	return true
+}
+
+// at returns the value at the given logical index.
+func (buf *RingBuffer[T]) at(index int) T {
+	return buf.buffer[buf.bufferIndex(index)]
 }
 
 // Offer offers a value to the buffer.
 //
-// If the buffer is full, then false is returned;
-// else the value is enqueued and true is returned.
+// If the buffer is full or has been closed, the value is rejected by the validator, or the value would take the total weight
+// of the buffer over the budget set by [WithWeigher], then false is returned;
+// else the value is enqueued and true is returned. When conflating, a buffered
+// value with the same key is replaced even if the buffer is full, provided the weight fits.
 func (buf *RingBuffer[T]) Offer(value T) bool {
 	// util.ValidatePointerNotNil(unsafe.Pointer(buf))
 
@@ -222,17 +560,24 @@ func (buf *RingBuffer[T]) Offer(value T) bool {
 		defer buf.lock.Unlock()
 	}
 
-	if buf.full {
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	if buf.closed || !buf.validator.Accept(value) || !buf.hasRoom(value) {
 		return false
 	}
 
-	buf.append(value)
-	buf.version++
+	buf.enqueue(value)
 	return true
 }
 
 // Dequeue removes first element of the buffer and returns it, or nil if buffer is empty.
 // Second return parameter is true, unless the buffer was empty and there was nothing to dequeue.
+//
+// Panics if the buffer is empty, or if the buffer is closed and has been drained.
 func (buf *RingBuffer[T]) Dequeue() T {
 	// util.ValidatePointerNotNil(unsafe.Pointer(buf))
 
@@ -241,7 +586,56 @@ func (buf *RingBuffer[T]) Dequeue() T {
 		defer buf.lock.Unlock()
 	}
 
-	return buf.removeHead()
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	if buf.size == 0 && buf.closed {
+		panic(messages.COLLECTION_CLOSED)
+	}
+
+	value := buf.removeHead()
+	buf.recorder.Remove(value)
+	return value
+}
+
+// Close marks the buffer as closed. No further values may be enqueued,
+// however values already in the buffer may still be dequeued.
+//
+// Once the buffer is drained, Dequeue panics indicating closure
+// and TryDequeue returns false.
+func (buf *RingBuffer[T]) Close() {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	buf.closed = true
+	buf.signalRoom()
+}
+
+// IsClosed returns true if the buffer has been closed.
+func (buf *RingBuffer[T]) IsClosed() bool {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	return buf.closed
 }
 
 // TryDequeue removes and returns the value at the front of the buffer and true if
@@ -254,12 +648,20 @@ func (buf *RingBuffer[T]) TryDequeue() (T, bool) {
 		defer buf.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
 	if buf.size == 0 {
 		var empty T
 		return empty, false
 	}
 
-	return buf.removeHead(), true
+	value := buf.removeHead()
+	buf.recorder.Remove(value)
+	return value, true
 }
 
 // Peek returns the value at the front of the buffer without removing it.
@@ -283,6 +685,10 @@ func (buf *RingBuffer[T]) TryPeek() (T, bool) {
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	if buf.size == 0 {
 		var empty T
 		return empty, false
@@ -291,6 +697,31 @@ func (buf *RingBuffer[T]) TryPeek() (T, bool) {
 	return buf.buffer[buf.head], true
 }
 
+// Page returns a copy of the values on the given zero-based page of the buffer,
+// counting from the front of the buffer.
+//
+// Panics if pageIndex is negative or pageSize is less than 1.
+func (buf *RingBuffer[T]) Page(pageIndex, pageSize int) []T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	start, end := util.PageBounds(buf.size, pageIndex, pageSize)
+	page := make([]T, end-start)
+
+	for i := range page {
+		page[i] = buf.buffer[(buf.head+start+i)%buf.maxSize]
+	}
+
+	return page
+}
+
 // Remove removes the first occurrence of the given value from the buffer, searching from front.
 //
 // Returns true if the value was present and was removed; else false.
@@ -302,53 +733,13 @@ func (buf *RingBuffer[T]) Remove(value T) bool {
 		defer buf.lock.Unlock()
 	}
 
-	index := buf.find(value)
-
-	if index == -1 {
-		return false
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
 	}
 
-	var empty T
-	buf.buffer[index] = empty
-	buf.size--
-	newBuffer := make([]T, buf.maxSize)
-
-	switch {
-	case buf.head < buf.tail || (buf.head == 0 && buf.tail == 0):
-		util.PartialCopy(buf.buffer, 0, newBuffer, 0, index)
-		util.PartialCopy(buf.buffer, index+1, newBuffer, index, len(buf.buffer)-index-1)
-		if buf.head < buf.tail {
-			buf.tail--
-		}
-	case index == buf.head:
-		buf.head++
-		headToEnd := buf.size - buf.head + (buf.head - buf.tail)
-		util.PartialCopy(buf.buffer, buf.head, newBuffer, 0, headToEnd)
-		util.PartialCopy(buf.buffer, 0, newBuffer, headToEnd, buf.tail)
-		buf.head = 0
-		buf.tail = buf.size
-	case index > buf.head:
-		removedToEnd := buf.size - index + util.Iif(buf.tail < buf.head, buf.head-buf.tail, 0)
-		util.PartialCopy(buf.buffer, buf.head, newBuffer, 0, index-buf.head)
-		util.PartialCopy(buf.buffer, index+1, newBuffer, index-buf.head, removedToEnd)
-		util.PartialCopy(buf.buffer, 0, newBuffer, index-buf.head+removedToEnd, buf.tail)
-		buf.head = 0
-		buf.tail = buf.size
-	case buf.head > buf.tail && buf.tail > index:
-		headToEnd := buf.size - buf.head + (buf.head - buf.tail + 1)
-		util.PartialCopy(buf.buffer, buf.head, newBuffer, 0, headToEnd)
-		util.PartialCopy(buf.buffer, 0, newBuffer, headToEnd, index)
-		util.PartialCopy(buf.buffer, index+1, newBuffer, headToEnd+index, buf.tail-index-1)
-		buf.head = 0
-		buf.tail = buf.size
-	default:
-		panic(fmt.Sprintf("BUG: RingBuffer.Remove - Head: %d, tail %d, index: %d", buf.head, buf.tail, index))
-	}
-
-	buf.buffer = newBuffer
-	buf.full = false
-	return true
+	buf.lazyInit()
 
+	return buf.remove(value)
 }
 
 // Empty returns true if buffer does not contain any elements.
@@ -383,11 +774,13 @@ func (buf *RingBuffer[T]) Clear() {
 		defer buf.lock.Unlock()
 	}
 
-	buf.buffer = make([]T, buf.maxSize)
-	buf.head = 0
-	buf.tail = 0
-	buf.full = false
-	buf.size = 0
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	buf.clear()
 }
 
 // ToSlice returns a copy of the buffer content as a slice
@@ -401,9 +794,45 @@ func (buf *RingBuffer[T]) ToSlice() []T {
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	return buf.toSlice(false, false)
 }
 
+// ToImmutableSlice returns the content of the buffer as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the buffer has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified. Values modified in place, e.g. via [collections.Element.ValuePtr],
+// are not reflected until the buffer is otherwise modified.
+func (buf *RingBuffer[T]) ToImmutableSlice() []T {
+
+	if buf.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		buf.lock.RLock()
+		values, ok := buf.immutable.Load(buf.version)
+		buf.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	return buf.immutable.Get(buf.version, func() []T { return buf.toSlice(false, false) })
+}
+
 // ToSlice returns a copy of the buffer content as a slice
 //
 // O(n).
@@ -415,6 +844,10 @@ func (buf *RingBuffer[T]) ToSliceDeep() []T {
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	return buf.toSlice(false, true)
 }
 
@@ -427,6 +860,10 @@ func (buf *RingBuffer[T]) String() string {
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	str := "RingBuffer\n"
 	var values []string
 	for _, value := range buf.toSlice(false, false) {
@@ -450,6 +887,14 @@ func (buf *RingBuffer[T]) append(value T) {
 	}
 
 	buf.size = buf.calculateSize()
+
+	if buf.conflator != nil {
+		buf.conflator.Appended(value, buf.size, buf.at)
+	}
+
+	if buf.weigher != nil {
+		buf.weigher.Adjust(buf.weigher.Weigh(value))
+	}
 }
 
 func (buf *RingBuffer[T]) find(value T) int {
@@ -475,6 +920,15 @@ func (buf *RingBuffer[T]) removeHead() T {
 	var empty T
 
 	value := buf.buffer[buf.head]
+
+	if buf.conflator != nil {
+		buf.conflator.Removed(value, buf.size)
+	}
+
+	if buf.weigher != nil {
+		buf.weigher.Adjust(-buf.weigher.Weigh(value))
+	}
+
 	buf.buffer[buf.head] = empty
 
 	buf.head = buf.head + 1
@@ -484,8 +938,10 @@ func (buf *RingBuffer[T]) removeHead() T {
 
 	buf.full = false
 	buf.size = buf.size - 1
+	buf.position++
 
 	buf.version++
+	buf.signalRoom()
 	return value
 }
 
@@ -517,13 +973,15 @@ func (buf *RingBuffer[T]) calculateSize() int {
 
 func (buf *RingBuffer[T]) makeDeepCopy() *RingBuffer[T] {
 	other := &RingBuffer[T]{
-		head:    buf.head,
-		tail:    buf.tail,
-		size:    buf.size,
-		version: 0,
-		maxSize: buf.maxSize,
-		full:    buf.full,
-		compare: buf.compare,
+		head:       buf.head,
+		tail:       buf.tail,
+		size:       buf.size,
+		version:    0,
+		maxSize:    buf.maxSize,
+		full:       buf.full,
+		compare:    buf.compare,
+		copy:       buf.copy,
+		copyPolicy: buf.copyPolicy,
 	}
 
 	other.buffer = make([]T, len(buf.buffer), cap(buf.buffer))
@@ -535,3 +993,62 @@ func (buf *RingBuffer[T]) makeDeepCopy() *RingBuffer[T] {
 	util.DeepCopySlice(other.buffer, buf.buffer, buf.copy)
 	return other
 }
+
+func (buf *RingBuffer[T]) remove(value T) bool {
+	index := buf.find(value)
+
+	if index == -1 {
+		return false
+	}
+
+	if buf.weigher != nil {
+		buf.weigher.Adjust(-buf.weigher.Weigh(buf.buffer[index]))
+	}
+
+	// Close the gap in place by shifting whichever side
+	// of the removed value holds fewer values.
+	position := (index - buf.head + buf.maxSize) % buf.maxSize
+	var empty T
+
+	if position < buf.size/2 {
+		for i := position; i > 0; i-- {
+			buf.buffer[buf.bufferIndex(i)] = buf.buffer[buf.bufferIndex(i-1)]
+		}
+
+		buf.buffer[buf.head] = empty
+		buf.head = (buf.head + 1) % buf.maxSize
+	} else {
+		for i := position; i < buf.size-1; i++ {
+			buf.buffer[buf.bufferIndex(i)] = buf.buffer[buf.bufferIndex(i+1)]
+		}
+
+		buf.tail = (buf.tail - 1 + buf.maxSize) % buf.maxSize
+		buf.buffer[buf.tail] = empty
+	}
+
+	buf.size--
+	buf.full = false
+	buf.version++
+	buf.recorder.Remove(value)
+	buf.signalRoom()
+	return true
+}
+
+func (buf *RingBuffer[T]) clear() {
+	buf.buffer = make([]T, buf.maxSize)
+	buf.head = 0
+	buf.tail = 0
+	buf.full = false
+	buf.size = 0
+	buf.version++
+	buf.recorder.Clear()
+	buf.signalRoom()
+
+	if buf.conflator != nil {
+		buf.conflator.Reset(0, nil)
+	}
+
+	if buf.weigher != nil {
+		buf.weigher.Reset(0, nil)
+	}
+}
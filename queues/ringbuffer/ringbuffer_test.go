@@ -1,13 +1,19 @@
 package ringbuffer
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"sort"
 	"sync"
 	"testing"
 
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
 	"github.com/fireflycons/generic_collections/sets/orderedset"
 	"github.com/stretchr/testify/require"
 )
@@ -143,6 +149,13 @@ func TestAddRange(t *testing.T) {
 		// to first added item
 		verifyBufferState(t, ringBuffer, queueItems)
 	})
+
+	t.Run("Partially filled buffer and slice that exactly fills it", func(t *testing.T) {
+		ringBuffer = New[int](3)
+		ringBuffer.Enqueue(1)
+		ringBuffer.AddRange([]int{2, 3})
+		verifyBufferState(t, ringBuffer, []int{1, 2, 3})
+	})
 }
 
 func TestAddCollection(t *testing.T) {
@@ -351,6 +364,13 @@ func TestRemove(t *testing.T) {
 	additionalItems = util.CreateSerialIntListData(additionalArraySize, &seed)
 
 	_ = additionalItems
+	t.Run("Remove from full buffer then add", func(t *testing.T) {
+		buf := Of(1, 2, 3)
+		require.True(t, buf.Remove(2))
+		buf.Add(4)
+		require.Equal(t, []int{1, 3, 4}, buf.ToSlice())
+	})
+
 	t.Run("Empty queue", func(t *testing.T) {
 
 		ringBuffer = New[int](util.DefaultCapacity)
@@ -628,6 +648,121 @@ func TestRemove(t *testing.T) {
 	})
 }
 
+func TestReplaceAll(t *testing.T) {
+
+	seed := int64(2163)
+	bufferItems := util.CreateSingleIntListData(util.DefaultCapacity, &seed)
+	replacementItems := util.CreateSingleIntListData(util.DefaultCapacity+4, &seed)
+
+	t.Run("Smaller collection replaces content", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		ringBuffer.AddRange(bufferItems)
+		other := orderedset.New[int]()
+		other.AddRange(replacementItems[:4])
+		ringBuffer.ReplaceAll(other)
+		require.False(t, ringBuffer.Full())
+		verifyBufferState(t, ringBuffer, other.ToSlice())
+	})
+
+	t.Run("Larger collection retains end-most values", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		ringBuffer.AddRange(bufferItems[:4])
+		other := orderedset.New[int]()
+		other.AddRange(replacementItems)
+		ringBuffer.ReplaceAll(other)
+		require.True(t, ringBuffer.Full())
+		verifyBufferState(t, ringBuffer, other.ToSlice()[4:])
+	})
+
+	t.Run("ReplaceAll on closed buffer panics", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		ringBuffer.Close()
+		require.Panics(t, func() { ringBuffer.ReplaceAll(orderedset.New[int]()) })
+	})
+}
+
+func TestPage(t *testing.T) {
+
+	seed := int64(2163)
+	bufferItems := util.CreateSingleIntListData(10, &seed)
+
+	t.Run("Page of empty buffer is empty", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		require.Equal(t, []int{}, ringBuffer.Page(0, 4))
+	})
+
+	t.Run("Pages are returned from front of buffer", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		ringBuffer.AddRange(bufferItems)
+		require.Equal(t, bufferItems[0:4], ringBuffer.Page(0, 4))
+		require.Equal(t, bufferItems[4:8], ringBuffer.Page(1, 4))
+		require.Equal(t, bufferItems[8:], ringBuffer.Page(2, 4))
+		require.Equal(t, []int{}, ringBuffer.Page(3, 4))
+	})
+
+	t.Run("Pages are correct when buffer has wrapped", func(t *testing.T) {
+		ringBuffer := New[int](8)
+		ringBuffer.AddRange(bufferItems[:4])
+		ringBuffer.AddRange(bufferItems[4:])
+		expected := bufferItems[2:]
+		require.Equal(t, expected[0:3], ringBuffer.Page(0, 3))
+		require.Equal(t, expected[3:6], ringBuffer.Page(1, 3))
+		require.Equal(t, expected[6:], ringBuffer.Page(2, 3))
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		require.Panics(t, func() { ringBuffer.Page(-1, 4) })
+		require.Panics(t, func() { ringBuffer.Page(0, 0) })
+	})
+}
+
+func TestClose(t *testing.T) {
+
+	seed := int64(2163)
+	bufferItems := util.CreateSingleIntListData(util.DefaultCapacity, &seed)
+
+	t.Run("New buffer is not closed", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		require.False(t, ringBuffer.IsClosed())
+	})
+
+	t.Run("Add and Offer to closed buffer return false", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		ringBuffer.Close()
+		require.True(t, ringBuffer.IsClosed())
+		require.False(t, ringBuffer.Add(1))
+		require.False(t, ringBuffer.Offer(1))
+		verifyBufferState(t, ringBuffer, []int{})
+	})
+
+	t.Run("Enqueue to closed buffer panics", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		ringBuffer.Close()
+		require.Panics(t, func() { ringBuffer.Enqueue(1) })
+	})
+
+	t.Run("AddRange to closed buffer panics", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		ringBuffer.Close()
+		require.Panics(t, func() { ringBuffer.AddRange(bufferItems) })
+	})
+
+	t.Run("Closed buffer drains remaining items", func(t *testing.T) {
+		ringBuffer := New[int](util.DefaultCapacity)
+		ringBuffer.AddRange(bufferItems)
+		ringBuffer.Close()
+
+		for _, v := range bufferItems {
+			require.Equal(t, v, ringBuffer.Dequeue())
+		}
+
+		_, ok := ringBuffer.TryDequeue()
+		require.False(t, ok)
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() { ringBuffer.Dequeue() })
+	})
+}
+
 func TestBuffer_Negative(t *testing.T) {
 
 	t.Run("Dequeue on empty buffer panics", func(t *testing.T) {
@@ -862,3 +997,873 @@ func BenchmarkRingBuffer(b *testing.B) {
 		})
 	}
 }
+
+func TestIndexable(t *testing.T) {
+
+	t.Run("At returns values from head of wrapped buffer", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6, 7})
+
+		for i, v := range []int{3, 4, 5, 6, 7} {
+			require.Equal(t, v, buf.At(i))
+		}
+	})
+
+	t.Run("SetAt replaces values from head of wrapped buffer", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6, 7})
+		it := buf.Iterator()
+		it.Start()
+
+		for i := 0; i < buf.Count(); i++ {
+			buf.SetAt(i, buf.At(i)*10)
+		}
+
+		require.Equal(t, []int{30, 40, 50, 60, 70}, buf.ToSlice())
+		require.NotPanics(t, func() { it.Next() })
+	})
+
+	t.Run("SetAt is recorded", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6, 7})
+		buf.StartRecording()
+		buf.SetAt(2, 0)
+
+		replica := New[int](5)
+		replica.ApplyOps(buf.StopRecording())
+		require.Equal(t, buf.ToSlice(), replica.ToSlice())
+	})
+
+	t.Run("Swap exchanges values", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6, 7})
+		buf.Swap(0, 4)
+		require.Equal(t, []int{7, 4, 5, 6, 3}, buf.ToSlice())
+	})
+
+	t.Run("CompareAt compares values", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3})
+		require.Less(t, buf.CompareAt(0, 1), 0)
+		require.Greater(t, buf.CompareAt(2, 1), 0)
+		require.Equal(t, 0, buf.CompareAt(1, 1))
+	})
+
+	t.Run("Index out of range panics", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3})
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "index"), func() { buf.At(3) })
+		require.Panics(t, func() { buf.At(-1) })
+		require.Panics(t, func() { buf.SetAt(3, 0) })
+		require.Panics(t, func() { buf.SetAt(-1, 0) })
+		require.Panics(t, func() { buf.Swap(0, 3) })
+		require.Panics(t, func() { buf.CompareAt(3, 0) })
+	})
+}
+
+func TestApplyOps(t *testing.T) {
+
+	t.Run("Script is applied in order", func(t *testing.T) {
+		buf := New[int](3)
+		buf.Enqueue(10)
+		buf.ApplyOps([]ops.Op[int]{
+			ops.Clear[int](),
+			ops.Add(1),
+			ops.Add(2),
+			ops.Add(1),
+			ops.Add(3),
+			ops.Remove(1),
+			ops.Remove(5),
+		})
+
+		require.Equal(t, []int{2, 3}, buf.ToSlice())
+	})
+
+	t.Run("Invalid op kind panics", func(t *testing.T) {
+		buf := New[int](3)
+		require.PanicsWithValue(t, fmt.Sprintf(messages.OP_KIND_INVALID_FMT, 9), func() {
+			buf.ApplyOps([]ops.Op[int]{ops.Add(1), {Kind: 9}})
+		})
+		require.Equal(t, []int{1}, buf.ToSlice())
+	})
+
+	t.Run("Add to closed buffer panics", func(t *testing.T) {
+		buf := New[int](3)
+		buf.Close()
+		require.PanicsWithValue(t, messages.COLLECTION_CLOSED, func() {
+			buf.ApplyOps([]ops.Op[int]{ops.Add(1)})
+		})
+	})
+}
+
+func TestRecording(t *testing.T) {
+
+	t.Run("Replaying recorded ops reproduces buffer", func(t *testing.T) {
+		buf := New[int](10)
+		buf.AddRange([]int{9, 8})
+		buf.StartRecording()
+		require.True(t, buf.IsRecording())
+
+		for i := 0; i < 15; i++ {
+			buf.Enqueue(i)
+		}
+		buf.AddRange([]int{2, 3, 2})
+		buf.Dequeue()
+		buf.TryDequeue()
+		buf.Offer(20)
+		buf.Remove(2)
+		buf.Swap(0, 3)
+		buf.Sort()
+		buf.Dequeue()
+		buf.ReplaceAll(orderedset.New[int]())
+		buf.Add(5)
+
+		recorded := buf.StopRecording()
+		require.False(t, buf.IsRecording())
+
+		replica := New[int](10)
+		replica.AddRange([]int{9, 8})
+		replica.ApplyOps(recorded)
+		require.Equal(t, buf.ToSlice(), replica.ToSlice())
+	})
+
+	t.Run("Displaced values are not recorded", func(t *testing.T) {
+		buf := New[int](2)
+		buf.StartRecording()
+		buf.Enqueue(1)
+		buf.Enqueue(2)
+		buf.Enqueue(3)
+		buf.Dequeue()
+
+		require.Equal(t, []ops.Op[int]{ops.Add(1), ops.Add(2), ops.Add(3), ops.Remove(2)}, buf.StopRecording())
+	})
+}
+
+func TestPartitionInPlace(t *testing.T) {
+
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Matching values are moved to head of wrapped buffer", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6, 7, 8})
+		split := buf.PartitionInPlace(isEven)
+		require.Equal(t, 3, split)
+
+		values := buf.ToSlice()
+		require.Equal(t, []int{4, 6, 8}, values[:split])
+		require.Equal(t, []int{5, 7}, values[split:])
+		require.True(t, buf.Full())
+	})
+
+	t.Run("Non-matching values keep their order", func(t *testing.T) {
+		buf := New[int](3)
+		buf.AddRange([]int{1, 3, 2})
+		require.Equal(t, 1, buf.PartitionInPlace(isEven))
+		require.Equal(t, []int{2, 1, 3}, buf.ToSlice())
+	})
+
+	t.Run("Replaying recorded ops reproduces buffer", func(t *testing.T) {
+		buf := New[int](4)
+		buf.AddRange([]int{1, 2, 3, 4})
+		buf.StartRecording()
+		buf.PartitionInPlace(isEven)
+		buf1 := New[int](4)
+		buf1.ApplyOps(buf.StopRecording())
+		require.Equal(t, buf.ToSlice(), buf1.ToSlice())
+	})
+}
+
+func TestExtractWhere(t *testing.T) {
+
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Matching values are extracted from wrapped buffer in order", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6, 7, 8})
+		extracted := buf.ExtractWhere(isEven)
+		require.Equal(t, []int{4, 6, 8}, extracted.ToSlice())
+		require.Equal(t, []int{5, 7}, buf.ToSlice())
+		require.False(t, buf.Full())
+
+		buf.AddRange([]int{9, 10, 11, 12})
+		require.Equal(t, []int{7, 9, 10, 11, 12}, buf.ToSlice())
+	})
+
+	t.Run("Extracted buffer has same maximum size", func(t *testing.T) {
+		buf := New[int](3, WithThreadSafe[int]())
+		buf.AddRange([]int{2, 4, 6})
+		extracted := buf.ExtractWhere(isEven)
+		require.True(t, extracted.Full())
+		require.True(t, buf.IsEmpty())
+		require.NotNil(t, extracted.lock)
+	})
+
+	t.Run("Replaying recorded ops reproduces buffer", func(t *testing.T) {
+		buf := New[int](4)
+		buf.AddRange([]int{1, 2, 3, 4})
+		buf.StartRecording()
+		buf.ExtractWhere(isEven)
+		buf1 := New[int](4)
+		buf1.ApplyOps(buf.StopRecording())
+		require.Equal(t, buf.ToSlice(), buf1.ToSlice())
+	})
+}
+
+func TestCloneInto(t *testing.T) {
+
+	t.Run("Destination receives copy of values and maximum size", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6, 7})
+		dst := New[int](3)
+		dst.AddRange([]int{9, 8})
+		buf.CloneInto(dst)
+
+		require.Equal(t, []int{3, 4, 5, 6, 7}, dst.ToSlice())
+		require.True(t, dst.Full())
+		dst.Enqueue(8)
+		require.Equal(t, []int{4, 5, 6, 7, 8}, dst.ToSlice())
+		require.Equal(t, []int{3, 4, 5, 6, 7}, buf.ToSlice())
+	})
+
+	t.Run("Destination storage is reused", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6, 7})
+		dst := New[int](5)
+		buf.CloneInto(dst)
+
+		if util.Debug {
+			t.Skip("assertions allocate in debug builds")
+		}
+
+		allocs := testing.AllocsPerRun(10, func() { buf.CloneInto(dst) })
+		require.Zero(t, allocs)
+	})
+
+	t.Run("Nil destination panics", func(t *testing.T) {
+		buf := New[int](1)
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dst"), func() { buf.CloneInto(nil) })
+	})
+}
+
+func TestOf(t *testing.T) {
+
+	buf := Of(1, 2, 3)
+	require.True(t, buf.Full())
+	require.Equal(t, []int{1, 2, 3}, buf.ToSlice())
+	buf.Enqueue(4)
+	require.Equal(t, []int{2, 3, 4}, buf.ToSlice())
+
+	buf = OfWith[int]([]RingBufferOptionFunc[int]{WithThreadSafe[int]()})
+	require.NotNil(t, buf.lock)
+	require.True(t, buf.IsEmpty())
+	require.Equal(t, 1, buf.maxSize)
+}
+
+func TestConflation(t *testing.T) {
+
+	byTens := func(v int) int { return v / 10 }
+
+	t.Run("Conflated value does not displace head", func(t *testing.T) {
+		buf := New(3, WithConflation(byTens))
+		buf.AddRange([]int{10, 20, 30})
+		require.False(t, buf.Add(21))
+		require.Equal(t, []int{10, 21, 30}, buf.ToSlice())
+
+		require.True(t, buf.Offer(32))
+		require.False(t, buf.Offer(40))
+		require.Equal(t, []int{10, 21, 32}, buf.ToSlice())
+
+		// A new key displaces the head as normal, and the displaced key may be added again.
+		buf.Enqueue(40)
+		buf.Enqueue(11)
+		require.Equal(t, []int{32, 40, 11}, buf.ToSlice())
+		buf.Enqueue(42)
+		require.Equal(t, []int{32, 42, 11}, buf.ToSlice())
+	})
+
+	t.Run("Dequeue, ReplaceAll and CloneInto", func(t *testing.T) {
+		buf := New(4, WithConflation(byTens))
+		buf.ReplaceAll(orderedset.Of(10, 20, 11))
+		require.Equal(t, []int{11, 20}, buf.ToSlice())
+		require.Equal(t, 11, buf.Dequeue())
+		buf.Enqueue(12)
+		buf.Enqueue(23)
+		require.Equal(t, []int{23, 12}, buf.ToSlice())
+
+		dst := New(2, WithConflation(byTens))
+		Of(30, 40).CloneInto(dst)
+		dst.Enqueue(41)
+		require.Equal(t, []int{30, 41}, dst.ToSlice())
+	})
+
+	t.Run("Nil key function panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "keyFn"), func() { WithConflation[int, int](nil) })
+	})
+}
+
+func TestToImmutableSlice(t *testing.T) {
+
+	t.Run("Repeated calls return the same backing array", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc1)
+		require.Same(t, &slc1[0], &slc2[0])
+		require.Equal(t, len(slc1), cap(slc1))
+	})
+
+	t.Run("Recomputed after modification", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		col.Remove(2)
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc2)
+		require.Len(t, slc1, 3)
+		require.Len(t, slc2, 2)
+
+		col.Clear()
+		require.Empty(t, col.ToImmutableSlice())
+	})
+
+	t.Run("Recomputed after SetAt", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		require.Equal(t, []int{1, 2, 3}, col.ToImmutableSlice())
+		col.SetAt(0, 100)
+		require.Equal(t, []int{100, 2, 3}, col.ToImmutableSlice())
+	})
+
+	t.Run("Empty collection", func(t *testing.T) {
+		require.Empty(t, New[int](1).ToImmutableSlice())
+	})
+}
+
+func TestThin(t *testing.T) {
+
+	t.Run("Keeps every k-th value from the head", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{0, 1, 2, 3, 4, 5, 6})
+		thinned := buf.Thin(2)
+
+		require.Equal(t, []int{2, 4, 6}, thinned.ToSlice())
+		require.Equal(t, 5, thinned.maxSize)
+		require.Equal(t, []int{2, 3, 4, 5, 6}, buf.ToSlice())
+	})
+
+	t.Run("Invalid keepEvery panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "keepEvery"), func() { Of(1).Thin(0) })
+	})
+}
+
+func TestDownsample(t *testing.T) {
+
+	mean := func(bucket []float64) float64 {
+		total := 0.0
+		for _, v := range bucket {
+			total += v
+		}
+		return total / float64(len(bucket))
+	}
+
+	t.Run("Reduces buckets from the head", func(t *testing.T) {
+		buf := New[float64](6)
+		buf.AddRange([]float64{9, 1, 2, 3, 4, 5, 6})
+
+		require.Equal(t, []float64{1.5, 3.5, 5.5}, buf.Downsample(3, mean).ToSlice())
+		require.Equal(t, []float64{3.5}, buf.Downsample(1, mean).ToSlice())
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { Of(1.0).Downsample(0, mean) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "reducer"), func() { Of(1.0).Downsample(1, nil) })
+	})
+}
+
+func TestWeigher(t *testing.T) {
+
+	byLength := func(s string) int { return len(s) }
+
+	t.Run("Enqueue displaces values until new value fits", func(t *testing.T) {
+		buf := New(10, WithWeigher(byLength, 6))
+		buf.AddRange([]string{"ab", "cd", "ef"})
+		require.Equal(t, 6, buf.Weight())
+
+		buf.Enqueue("ghi")
+		require.Equal(t, []string{"ef", "ghi"}, buf.ToSlice())
+		require.Equal(t, 5, buf.Weight())
+		require.Equal(t, 6, buf.MaxWeight())
+
+		buf.Enqueue("heavyweight")
+		require.Equal(t, []string{"heavyweight"}, buf.ToSlice())
+		require.Equal(t, 11, buf.Weight())
+	})
+
+	t.Run("Capacity still bounds the buffer", func(t *testing.T) {
+		buf := New(2, WithWeigher(byLength, 100))
+		buf.AddRange([]string{"a", "bb", "ccc"})
+		require.Equal(t, []string{"bb", "ccc"}, buf.ToSlice())
+		require.Equal(t, 5, buf.Weight())
+	})
+
+	t.Run("Offer refuses values over budget", func(t *testing.T) {
+		buf := New(10, WithWeigher(byLength, 5))
+		require.True(t, buf.Offer("abc"))
+		require.False(t, buf.Offer("def"))
+		require.True(t, buf.Offer("de"))
+		require.Equal(t, []string{"abc", "de"}, buf.ToSlice())
+	})
+
+	t.Run("Conflated value displaces older values", func(t *testing.T) {
+		buf := New(10, WithWeigher(byLength, 6), WithConflation(func(s string) byte { return s[0] }))
+		buf.AddRange([]string{"ab", "cd", "ef"})
+		require.False(t, buf.Offer("cdef"))
+
+		buf.Enqueue("cdef")
+		require.Equal(t, []string{"cdef", "ef"}, buf.ToSlice())
+		require.Equal(t, 6, buf.Weight())
+	})
+
+	t.Run("Removal and replacement are reweighed", func(t *testing.T) {
+		buf := New(10, WithWeigher(byLength, 100))
+		buf.AddRange([]string{"a", "bb", "ccc"})
+		buf.Remove("bb")
+		require.Equal(t, 4, buf.Weight())
+
+		buf.SetAt(0, "dddd")
+		require.Equal(t, 7, buf.Weight())
+
+		buf.ExtractWhere(func(s string) bool { return s == "ccc" })
+		require.Equal(t, 4, buf.Weight())
+
+		buf.Dequeue()
+		require.Equal(t, 0, buf.Weight())
+	})
+
+	t.Run("No weigher", func(t *testing.T) {
+		buf := Of("abc")
+		require.Equal(t, 0, buf.Weight())
+		require.Equal(t, 0, buf.MaxWeight())
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "weigher"), func() { WithWeigher[string](nil, 1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxWeight"), func() { WithWeigher(byLength, 0) })
+	})
+}
+
+func TestSampleIterator(t *testing.T) {
+	col := Of(1, 2, 3, 4, 5, 6, 7, 8)
+	iter := col.SampleIterator(3, rand.New(rand.NewSource(1)))
+	seen := make(map[int]bool)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		require.Contains(t, col.ToSlice(), e.Value())
+		require.False(t, seen[e.Value()])
+		seen[e.Value()] = true
+	}
+
+	require.Len(t, seen, 3)
+}
+
+func TestZeroValue(t *testing.T) {
+	var buf RingBuffer[int]
+
+	require.True(t, buf.IsEmpty())
+	require.False(t, buf.Contains(1))
+	require.Empty(t, buf.ToSlice())
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ZERO_VALUE_UNUSABLE_FMT, "RingBuffer"), func() { buf.Add(1) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ZERO_VALUE_UNUSABLE_FMT, "RingBuffer"), func() { buf.AddRange([]int{1}) })
+
+	// A zero value may receive a clone.
+	New[int](4).CloneInto(&buf)
+	buf.AddRange([]int{1, 2, 3, 4, 5})
+	require.Equal(t, []int{2, 3, 4, 5}, buf.ToSlice())
+}
+
+func TestCopyPolicy(t *testing.T) {
+	type box struct{ v int }
+	compareBox := func(a, b *box) int { return a.v - b.v }
+	copyBox := func(b *box) *box { c := *b; return &c }
+	values := []*box{{2}, {1}}
+
+	t.Run("Sorted copy keeps deep copy function", func(t *testing.T) {
+		buf := New(2, WithComparer(compareBox), WithDeepCopy(copyBox))
+		buf.AddRange(values)
+		sorted := buf.Sorted()
+
+		require.NotSame(t, values[1], sorted.ToSlice()[0])
+		require.NotSame(t, sorted.ToSlice()[0], sorted.ToSliceDeep()[0])
+	})
+
+	t.Run("Shallow", func(t *testing.T) {
+		buf := New(2, WithComparer(compareBox), WithDeepCopy(copyBox), WithCopyPolicy[*box](collections.Shallow))
+		buf.AddRange(values)
+
+		require.Same(t, values[0], buf.ToSliceDeep()[0])
+		require.Same(t, values[1], buf.Sorted().ToSlice()[0])
+	})
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(4, WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(4, WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+	})
+}
+
+func TestChannels(t *testing.T) {
+
+	buf := New[int](3)
+	buf.AddRange([]int{1, 2, 3})
+	received := make([]int, 0)
+
+	for v := range buf.ToChan(context.Background()) {
+		received = append(received, v)
+	}
+
+	require.Equal(t, []int{1, 2, 3}, received)
+	require.True(t, buf.IsEmpty())
+
+	// The buffer keeps the most recent values received
+	src := make(chan int)
+	latest, done := FromChan(context.Background(), 3, src)
+
+	for i := 0; i < 10; i++ {
+		src <- i
+	}
+
+	close(src)
+	require.NoError(t, <-done)
+	require.Equal(t, []int{7, 8, 9}, latest.ToSlice())
+	require.NotNil(t, latest.lock)
+}
+
+func TestOverflowPolicy(t *testing.T) {
+
+	t.Run("OverwriteOldest is the default", func(t *testing.T) {
+		buf := New[int](2)
+		require.Equal(t, OverwriteOldest, buf.OverflowPolicy())
+		buf.AddRange([]int{1, 2, 3})
+		require.Equal(t, []int{2, 3}, buf.ToSlice())
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		buf := New(2, WithOverflowPolicy[int](Reject))
+		require.True(t, buf.Add(1))
+		buf.Enqueue(2)
+		buf.Enqueue(3)
+		require.False(t, buf.Add(4))
+		require.Equal(t, []int{1, 2}, buf.ToSlice())
+
+		buf.Dequeue()
+		buf.AddRange([]int{5, 6, 7})
+		require.Equal(t, []int{2, 5}, buf.ToSlice())
+		require.Equal(t, 1, buf.Position())
+
+		buf.ReplaceAll(Of(8, 9, 10))
+		require.Equal(t, []int{8, 9}, buf.ToSlice())
+	})
+
+	t.Run("Reject conflates when full", func(t *testing.T) {
+		type tick struct {
+			key   string
+			price int
+		}
+
+		buf := New(2, WithOverflowPolicy[tick](Reject), WithConflation(func(t tick) string { return t.key }),
+			WithComparer(func(a, b tick) int { return a.price - b.price }))
+		buf.AddRange([]tick{{"a", 1}, {"b", 2}, {"c", 3}})
+		require.False(t, buf.Add(tick{"a", 4}), "Add should return false when a value is conflated")
+		require.Equal(t, []tick{{"a", 4}, {"b", 2}}, buf.ToSlice())
+	})
+
+	t.Run("Reject by weight", func(t *testing.T) {
+		buf := New(10, WithOverflowPolicy[string](Reject), WithWeigher(func(s string) int { return len(s) }, 5))
+		buf.AddRange([]string{"abc", "de", "f"})
+		require.Equal(t, []string{"abc", "de"}, buf.ToSlice())
+	})
+
+	t.Run("Block waits for room", func(t *testing.T) {
+		buf := New(2, WithOverflowPolicy[int](Block))
+		require.NotNil(t, buf.lock, "Block should imply thread safety")
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			buf.AddRange([]int{1, 2, 3, 4, 5})
+		}()
+
+		received := make([]int, 0, 5)
+		for len(received) < 5 {
+			if v, ok := buf.TryDequeue(); ok {
+				received = append(received, v)
+			}
+		}
+
+		<-done
+		require.Equal(t, []int{1, 2, 3, 4, 5}, received)
+		require.Equal(t, 5, buf.Position())
+	})
+
+	t.Run("Block is released by Close", func(t *testing.T) {
+		buf := New(1, WithOverflowPolicy[int](Block))
+		buf.Enqueue(1)
+
+		added := make(chan bool)
+		go func() { added <- buf.Add(2) }()
+
+		panicked := make(chan any)
+		go func() {
+			defer func() { panicked <- recover() }()
+			buf.Enqueue(3)
+		}()
+
+		buf.Close()
+		require.False(t, <-added)
+		require.Equal(t, messages.COLLECTION_CLOSED, <-panicked)
+		require.Equal(t, []int{1}, buf.ToSlice())
+	})
+
+	t.Run("Block enqueues an overweight value when empty", func(t *testing.T) {
+		buf := New(10, WithOverflowPolicy[string](Block), WithWeigher(func(s string) int { return len(s) }, 2))
+		buf.Enqueue("abc")
+		require.Equal(t, []string{"abc"}, buf.ToSlice())
+	})
+
+	t.Run("Panics on invalid policy", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "policy"), func() { WithOverflowPolicy[int](Block + 1) })
+		require.Equal(t, "Reject", Reject.String())
+	})
+}
+
+func TestResize(t *testing.T) {
+
+	t.Run("Growing keeps wrapped values in order", func(t *testing.T) {
+		buf := New[int](4)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6})
+		buf.Resize(6)
+
+		require.Equal(t, 6, buf.MaxSize())
+		require.Equal(t, []int{3, 4, 5, 6}, buf.ToSlice())
+		require.False(t, buf.Full())
+
+		buf.AddRange([]int{7, 8, 9})
+		require.Equal(t, []int{4, 5, 6, 7, 8, 9}, buf.ToSlice())
+	})
+
+	t.Run("Shrinking discards values from the head", func(t *testing.T) {
+		buf := New[int](5)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6})
+		buf.Resize(3)
+
+		require.Equal(t, []int{4, 5, 6}, buf.ToSlice())
+		require.True(t, buf.Full())
+		require.Equal(t, 3, buf.Position(), "Discarded values should advance the position")
+
+		buf.Enqueue(7)
+		require.Equal(t, []int{5, 6, 7}, buf.ToSlice())
+	})
+
+	t.Run("Shrinking reweighs and keeps conflating", func(t *testing.T) {
+		type tick struct {
+			key   string
+			price int
+		}
+
+		buf := New(4, WithConflation(func(t tick) string { return t.key }),
+			WithWeigher(func(tick) int { return 1 }, 10),
+			WithComparer(func(a, b tick) int { return a.price - b.price }))
+		buf.AddRange([]tick{{"a", 1}, {"b", 2}, {"c", 3}})
+		buf.Resize(2)
+
+		require.Equal(t, 2, buf.Weight())
+		require.False(t, buf.Add(tick{"c", 4}))
+		require.True(t, buf.Add(tick{"a", 5}))
+		require.Equal(t, []tick{{"c", 4}, {"a", 5}}, buf.ToSlice())
+	})
+
+	t.Run("Modifies version", func(t *testing.T) {
+		buf := Of(1, 2, 3)
+		iter := buf.Iterator()
+		iter.Start()
+		buf.Resize(4)
+
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Next() })
+	})
+
+	t.Run("Growing releases blocked producers", func(t *testing.T) {
+		buf := New(1, WithOverflowPolicy[int](Block))
+		buf.Enqueue(1)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			buf.Enqueue(2)
+		}()
+
+		buf.Resize(2)
+		<-done
+		require.Equal(t, []int{1, 2}, buf.ToSlice())
+	})
+
+	t.Run("EnsureCapacity only grows", func(t *testing.T) {
+		buf := Of(1, 2, 3)
+		buf.EnsureCapacity(2)
+		require.Equal(t, 3, buf.MaxSize())
+		require.Equal(t, []int{1, 2, 3}, buf.ToSlice())
+
+		buf.EnsureCapacity(10)
+		require.Equal(t, 10, buf.MaxSize())
+		require.Equal(t, []int{1, 2, 3}, buf.ToSlice())
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		buf := New[int](1)
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "newMax"), func() { buf.Resize(0) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { buf.EnsureCapacity(-1) })
+	})
+}
+
+func TestTryAdd(t *testing.T) {
+
+	t.Run("Full does not displace", func(t *testing.T) {
+		buf := New[int](2)
+		buf.AddRange([]int{1, 2})
+
+		added, err := buf.TryAdd(3)
+		require.False(t, added)
+		require.ErrorIs(t, err, collections.ErrFull)
+		require.Equal(t, []int{1, 2}, buf.ToSlice())
+	})
+
+	t.Run("Full does not block", func(t *testing.T) {
+		buf := New(1, WithOverflowPolicy[int](Block))
+		buf.Enqueue(1)
+
+		_, err := buf.TryAdd(2)
+		require.ErrorIs(t, err, collections.ErrFull)
+	})
+
+	t.Run("Conflates when full", func(t *testing.T) {
+		type tick struct {
+			key   string
+			price int
+		}
+
+		buf := New(1, WithConflation(func(t tick) string { return t.key }),
+			WithComparer(func(a, b tick) int { return a.price - b.price }))
+		buf.Enqueue(tick{"a", 1})
+
+		added, err := buf.TryAdd(tick{"a", 2})
+		require.True(t, added)
+		require.NoError(t, err)
+		require.Equal(t, []tick{{"a", 2}}, buf.ToSlice())
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		buf := New[int](1)
+		buf.Close()
+
+		added, err := buf.TryAdd(1)
+		require.False(t, added)
+		require.ErrorIs(t, err, collections.ErrClosed)
+	})
+}
+
+func TestDequeueN(t *testing.T) {
+
+	t.Run("Removes wrapped values in order", func(t *testing.T) {
+		buf := New[int](4)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6})
+		version := buf.version
+
+		require.Equal(t, []int{3, 4, 5}, buf.DequeueN(3))
+		require.Equal(t, version+1, buf.version)
+		require.Equal(t, []int{6}, buf.ToSlice())
+		require.False(t, buf.Full())
+		require.Equal(t, 5, buf.Position())
+	})
+
+	t.Run("Returns fewer values than requested", func(t *testing.T) {
+		buf := New[int](4)
+		buf.AddRange([]int{1, 2})
+
+		require.Equal(t, []int{1, 2}, buf.DequeueN(5))
+		require.Equal(t, []int{}, buf.DequeueN(1))
+		require.True(t, buf.IsEmpty())
+	})
+
+	t.Run("Makes room for blocked producers", func(t *testing.T) {
+		buf := New(2, WithOverflowPolicy[int](Block))
+		buf.AddRange([]int{1, 2})
+		done := make(chan struct{})
+
+		go func() {
+			buf.AddRange([]int{3, 4})
+			close(done)
+		}()
+
+		require.Equal(t, []int{1, 2}, buf.DequeueN(2))
+		<-done
+		require.Equal(t, []int{3, 4}, buf.ToSlice())
+	})
+
+	t.Run("Panics if n is negative", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { New[int](1).DequeueN(-1) })
+	})
+}
+
+func TestDrainTo(t *testing.T) {
+
+	buf := New[int](4)
+	buf.AddRange([]int{1, 2, 3})
+	dst := orderedset.Of(2, 5)
+
+	require.Equal(t, 2, buf.DrainTo(dst, 2))
+	require.Equal(t, []int{1, 2, 5}, dst.ToSlice())
+	require.Equal(t, []int{3}, buf.ToSlice())
+
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dst"), func() { buf.DrainTo(nil, 1) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "max"), func() { buf.DrainTo(dst, -1) })
+}
+
+func TestRetainWhere(t *testing.T) {
+
+	t.Run("Makes room in a full buffer", func(t *testing.T) {
+		buf := New[int](4)
+		buf.AddRange([]int{1, 2, 3, 4, 5, 6})
+
+		require.Equal(t, 2, buf.RetainWhere(func(v int) bool { return v%2 == 0 }))
+		require.False(t, buf.Full())
+		require.Equal(t, []int{4, 6}, buf.ToSlice())
+
+		buf.AddRange([]int{7, 8})
+		require.Equal(t, []int{4, 6, 7, 8}, buf.ToSlice())
+	})
+
+	t.Run("Releases blocked producers", func(t *testing.T) {
+		buf := New(2, WithOverflowPolicy[int](Block))
+		buf.AddRange([]int{1, 2})
+		done := make(chan struct{})
+
+		go func() {
+			buf.Enqueue(3)
+			close(done)
+		}()
+
+		require.Equal(t, 1, buf.RetainAll(orderedset.Of(2)))
+		<-done
+		require.Equal(t, []int{2, 3}, buf.ToSlice())
+	})
+}
@@ -1,8 +1,13 @@
 package ringbuffer
 
 import (
+	"fmt"
+
 	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
+	"golang.org/x/exp/constraints"
 )
 
 // Sort performs an in-place sort of this collection.
@@ -23,6 +28,10 @@ func (buf *RingBuffer[T]) Sorted() collections.Collection[T] {
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	buf1 := buf.makeDeepCopy()
 
 	if buf1.size > 1 {
@@ -50,6 +59,10 @@ func (buf *RingBuffer[T]) SortedDescending() collections.Collection[T] {
 		defer buf.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
 	buf1 := buf.makeDeepCopy()
 
 	if buf1.size > 1 {
@@ -59,6 +72,41 @@ func (buf *RingBuffer[T]) SortedDescending() collections.Collection[T] {
 	return buf1
 }
 
+// SortBy performs an in-place sort of this collection with respect to the given comparer,
+// rather than the comparer of the buffer, which is unchanged.
+//
+// The item that comparer orders first will be placed at the head of the buffer.
+//
+// Panics if comparer is nil.
+func (buf *RingBuffer[T]) SortBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	buf.doSort(util.CompareSort(util.Gosort[T], comparer))
+}
+
+// SortStable performs an in-place sort of this collection as per Sort,
+// except that values which compare equal keep their relative order.
+func (buf *RingBuffer[T]) SortStable() {
+
+	buf.doSort(util.GosortStable[T])
+}
+
+// SortStableBy performs an in-place sort of this collection as per [RingBuffer.SortBy],
+// except that values which compare equal keep their relative order.
+//
+// Panics if comparer is nil.
+func (buf *RingBuffer[T]) SortStableBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	buf.doSort(util.CompareSort(util.GosortStable[T], comparer))
+}
+
 func (buf *RingBuffer[T]) doSort(f util.SortFunc[T]) {
 
 	if buf.size <= 1 {
@@ -70,10 +118,38 @@ func (buf *RingBuffer[T]) doSort(f util.SortFunc[T]) {
 		defer buf.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
 	slc := buf.toSlice(true, false)
 	f(slc, buf.size, buf.compare)
 	buf.head = 0
 	buf.tail = buf.size % buf.maxSize
 	buf.buffer = slc
 	buf.version++
+	buf.recorder.Reset(func() []T { return buf.toSlice(false, false) })
+}
+
+// SortByKey performs an in-place sort of the buffer by the keys computed for its values by key.
+// The value with the smallest key will be placed at the head of the buffer.
+//
+// Each key is computed once before sorting, so where comparing values is expensive, e.g. comparing strings
+// with collation, sorting by a cheaply compared key derived from each value is much faster than Sort.
+func SortByKey[T any, K constraints.Ordered](buf *RingBuffer[T], key func(T) K) {
+
+	sortByKey(buf, key, false)
+}
+
+// SortByKeyDescending is as [SortByKey], placing the value with the largest key at the head of the buffer.
+func SortByKeyDescending[T any, K constraints.Ordered](buf *RingBuffer[T], key func(T) K) {
+
+	sortByKey(buf, key, true)
+}
+
+func sortByKey[T any, K constraints.Ordered](buf *RingBuffer[T], key func(T) K, descending bool) {
+
+	buf.doSort(util.KeySort(key, util.GetOrderedComparer[K](), descending))
 }
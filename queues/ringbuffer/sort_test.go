@@ -1,9 +1,11 @@
 package ringbuffer
 
 import (
+	"fmt"
 	"sort"
 	"testing"
 
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/stretchr/testify/require"
 )
@@ -173,3 +175,83 @@ func TestSortedDescending(t *testing.T) {
 		require.Equal(t, buf1.maxSize, len(buf1.buffer))
 	})
 }
+
+func TestSortByKey(t *testing.T) {
+
+	values := []string{"ccc", "a", "dddd", "bb"}
+	calls := 0
+	length := func(v string) int { calls++; return len(v) }
+
+	c := New[string](8)
+	c.AddRange(values)
+	version := c.version
+
+	SortByKey(c, length)
+	require.Equal(t, []string{"a", "bb", "ccc", "dddd"}, c.ToSlice())
+	require.Equal(t, len(values), calls)
+	require.Greater(t, c.version, version)
+
+	SortByKeyDescending(c, length)
+	require.Equal(t, []string{"dddd", "ccc", "bb", "a"}, c.ToSlice())
+}
+
+func TestSortBy(t *testing.T) {
+
+	// Ordered differently by length than by the default comparer
+	byLength := func(a, b string) int { return len(a) - len(b) }
+
+	c := New[string](8)
+	c.AddRange([]string{"zz", "a", "bbb", "yyyy"})
+	version := c.version
+
+	c.SortBy(byLength)
+	require.Equal(t, []string{"a", "zz", "bbb", "yyyy"}, c.ToSlice())
+	require.Greater(t, c.version, version)
+
+	c.SortBy(func(a, b string) int { return byLength(b, a) })
+	require.Equal(t, []string{"yyyy", "bbb", "zz", "a"}, c.ToSlice())
+
+	// The comparer of the collection is unchanged
+	c.Sort()
+	require.Equal(t, []string{"a", "bbb", "yyyy", "zz"}, c.ToSlice())
+
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"), func() { c.SortBy(nil) })
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"), func() { c.SortStableBy(nil) })
+}
+
+func TestSortStable(t *testing.T) {
+
+	type record struct {
+		key int
+		seq int
+	}
+
+	// Enough records with equal keys that an unstable sort would reorder them
+	records := make([]record, 200)
+	for i := range records {
+		records[i] = record{key: (i * 7) % 5, seq: i}
+	}
+
+	byKey := func(a, b record) int { return a.key - b.key }
+	byKeyDescending := func(a, b record) int { return b.key - a.key }
+
+	stableSorted := func(compare func(a, b record) int) []record {
+		expected := append([]record{}, records...)
+		sort.SliceStable(expected, func(i, j int) bool { return compare(expected[i], expected[j]) < 0 })
+		return expected
+	}
+
+	t.Run("SortStable", func(t *testing.T) {
+		c := NewFunc(len(records), byKey)
+		c.AddRange(records)
+		c.SortStable()
+		require.Equal(t, stableSorted(byKey), c.ToSlice())
+	})
+
+	t.Run("SortStableBy", func(t *testing.T) {
+		c := NewFunc(len(records), byKey)
+		c.AddRange(records)
+		c.SortStableBy(byKeyDescending)
+		require.Equal(t, stableSorted(byKeyDescending), c.ToSlice())
+	})
+}
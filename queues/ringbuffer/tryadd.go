@@ -0,0 +1,47 @@
+package ringbuffer
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*RingBuffer[int])(nil)
+
+// TryAdd enqueues a value if the buffer has room for it, as [RingBuffer.Offer] does, so it never
+// displaces a buffered value or blocks, whatever the overflow policy. When conflating, a buffered value
+// with the same key is replaced even if the buffer is full, provided the weight fits.
+//
+// Returns true and nil if the value was enqueued or replaced a buffered value; else false and
+// [collections.ErrClosed] if the buffer has been closed, [collections.ErrFull] if the buffer is full
+// or the value would take the total weight of the buffer over the budget set by [WithWeigher],
+// or an error wrapping [collections.ErrInvalidValue] and the error of the validator if it was rejected,
+// whatever the validation policy.
+func (buf *RingBuffer[T]) TryAdd(value T) (bool, error) {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Write(buf.lock != nil, buf.verifyInvariants)()
+	}
+
+	buf.lazyInit()
+
+	if buf.closed {
+		return false, collections.ErrClosed
+	}
+
+	if err := buf.validator.Check(value); err != nil {
+		return false, err
+	}
+
+	if !buf.hasRoom(value) {
+		return false, collections.ErrFull
+	}
+
+	buf.enqueue(value)
+	return true, nil
+}
@@ -0,0 +1,88 @@
+package ringbuffer
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Option function for New to weigh values, e.g. by their size in bytes, so that the buffer is
+// bounded by the total weight of its values as well as their number. The total weight is tracked
+// as values are added and removed. Enqueue and Add displace values from the head of the buffer until
+// the new value fits within maxWeight, whereas [RingBuffer.Offer] refuses a value that would not fit.
+// A single value that outweighs maxWeight is enqueued on its own, displacing all other values.
+//
+//	buf := ringbuffer.New(1000, ringbuffer.WithWeigher(func(p []byte) int { return len(p) }, 1<<20))
+//
+// Values replaced with SetAt are reweighed, but do not displace other values.
+// Values modified in place, e.g. via [collections.Element.ValuePtr], are not reweighed.
+//
+// Panics if weigher is nil or maxWeight is less than 1. Operations that add or remove values
+// panic if weigher returns a negative weight.
+func WithWeigher[T any](weigher func(T) int, maxWeight int) RingBufferOptionFunc[T] {
+	if weigher == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "weigher"))
+	}
+
+	if maxWeight < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxWeight"))
+	}
+
+	return func(buf *RingBuffer[T]) {
+		buf.weigher = util.NewWeigher(weigher, maxWeight)
+	}
+}
+
+// Weight returns the total weight of the values in the buffer,
+// or zero if the buffer was not created [WithWeigher].
+func (buf *RingBuffer[T]) Weight() int {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer buf.guard.Read(buf.lock != nil)()
+	}
+
+	if buf.weigher == nil {
+		return 0
+	}
+
+	return buf.weigher.Total()
+}
+
+// MaxWeight returns the weight budget set by [WithWeigher],
+// or zero if the buffer was not created with a weigher.
+func (buf *RingBuffer[T]) MaxWeight() int {
+
+	if buf.weigher == nil {
+		return 0
+	}
+
+	return buf.weigher.Max()
+}
+
+// weightDelta returns the change in total weight if value were enqueued,
+// discounting the weight of a buffered value it would replace when conflating.
+func (buf *RingBuffer[T]) weightDelta(value T) int {
+	delta := buf.weigher.Weigh(value)
+
+	if buf.conflator != nil {
+		if i := buf.conflator.Find(value, buf.size, buf.at); i != -1 {
+			delta -= buf.weigher.Weigh(buf.at(i))
+		}
+	}
+
+	return delta
+}
+
+// evictOverweight removes values from the head of the buffer while more than keep values remain
+// and the total weight plus weight exceeds the budget.
+func (buf *RingBuffer[T]) evictOverweight(weight, keep int) {
+	for buf.size > keep && !buf.weigher.Fits(weight) {
+		buf.removeHead()
+	}
+}
@@ -0,0 +1,318 @@
+/*
+Package sortedringbuffer provides a sliding window over the most recently added values
+that supports ordered queries.
+
+A SortedRingBuffer holds its values both in a ring buffer in the order they were added, and in
+an order-statistics tree. Adding a value, evicting the oldest and querying the minimum, maximum,
+median, any quantile or the rank of a value are all O(log n), so e.g. a percentile over a
+sliding window may be monitored without sorting the window on each tick.
+*/
+package sortedringbuffer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/queues/ringbuffer"
+	"github.com/fireflycons/generic_collections/stats/quantile"
+	"golang.org/x/exp/constraints"
+)
+
+// SortedRingBufferOptionFunc is the signature of a function
+// for providing options to the SortedRingBuffer constructor.
+type SortedRingBufferOptionFunc[T any] func(*SortedRingBuffer[T])
+
+// SortedRingBuffer is a fixed size window of the most recently added values,
+// indexed in order of value.
+type SortedRingBuffer[T any] struct {
+	lock    *sync.RWMutex
+	compare functions.ComparerFunc[T]
+	window  *ringbuffer.RingBuffer[T]
+	index   *quantile.Tracker[T]
+}
+
+// New constructs a SortedRingBuffer holding at most maxSize values.
+//
+// Panics if maxSize is less than 1.
+func New[T any](maxSize int, options ...SortedRingBufferOptionFunc[T]) *SortedRingBuffer[T] {
+	buf := &SortedRingBuffer[T]{}
+
+	for _, o := range options {
+		o(buf)
+	}
+
+	if buf.compare == nil {
+		buf.compare = util.GetDefaultComparer[T]()
+	}
+
+	buf.window = ringbuffer.New(maxSize, ringbuffer.WithComparer(buf.compare))
+	buf.index = quantile.New(quantile.WithComparer(buf.compare))
+
+	return buf
+}
+
+// NewFunc is as [New], using compare to order the values.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](maxSize int, compare functions.ComparerFunc[T], options ...SortedRingBufferOptionFunc[T]) *SortedRingBuffer[T] {
+	return New(maxSize, append([]SortedRingBufferOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](maxSize int, options ...SortedRingBufferOptionFunc[T]) *SortedRingBuffer[T] {
+	return NewFunc(maxSize, util.GetOrderedComparer[T](), options...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[T any]() SortedRingBufferOptionFunc[T] {
+	return func(buf *SortedRingBuffer[T]) {
+		buf.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function for New to provide a comparer function for values of type T.
+// Required if the element type is not numeric, bool, pointer or string.
+func WithComparer[T any](comparer functions.ComparerFunc[T]) SortedRingBufferOptionFunc[T] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(buf *SortedRingBuffer[T]) {
+		buf.compare = comparer
+	}
+}
+
+// Add adds a value to the buffer. If the buffer is full, the oldest value is evicted
+// and returned with true; else zero value of T and false. O(log n).
+func (buf *SortedRingBuffer[T]) Add(value T) (T, bool) {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	return buf.add(value)
+}
+
+// AddRange adds a slice of values to the buffer in order, evicting the oldest values as necessary.
+func (buf *SortedRingBuffer[T]) AddRange(values []T) {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	for _, v := range values {
+		buf.add(v)
+	}
+}
+
+// Count returns the number of values in the buffer.
+func (buf *SortedRingBuffer[T]) Count() int {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.window.Count()
+}
+
+// IsEmpty returns true if the buffer has no values.
+func (buf *SortedRingBuffer[T]) IsEmpty() bool {
+	return buf.Count() == 0
+}
+
+// Full returns true if the buffer holds its maximum number of values,
+// so that adding a value will evict the oldest.
+func (buf *SortedRingBuffer[T]) Full() bool {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.window.Full()
+}
+
+// Clear removes all values from the buffer.
+func (buf *SortedRingBuffer[T]) Clear() {
+
+	if buf.lock != nil {
+		buf.lock.Lock()
+		defer buf.lock.Unlock()
+	}
+
+	buf.window.Clear()
+	buf.index.Clear()
+}
+
+// Oldest returns the value that will next be evicted, without removing it.
+//
+// Panics if the buffer is empty.
+func (buf *SortedRingBuffer[T]) Oldest() T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.window.Peek()
+}
+
+// Min returns the least value in the buffer. O(log n).
+//
+// Panics if the buffer is empty.
+func (buf *SortedRingBuffer[T]) Min() T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.index.Min()
+}
+
+// Max returns the greatest value in the buffer. O(log n).
+//
+// Panics if the buffer is empty.
+func (buf *SortedRingBuffer[T]) Max() T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.index.Max()
+}
+
+// Median returns the median of the values in the buffer. O(log n).
+// If there is an even number of values, the lower of the two middle values is returned.
+//
+// Panics if the buffer is empty.
+func (buf *SortedRingBuffer[T]) Median() T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.index.Median()
+}
+
+// Quantile returns the value at the given quantile q of the values in the buffer,
+// where q is in the range 0 to 1, using the nearest-rank method. O(log n).
+//
+// Panics if the buffer is empty or q is out of range.
+func (buf *SortedRingBuffer[T]) Quantile(q float64) T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.index.Quantile(q)
+}
+
+// Nth returns the value with the given zero-based rank, i.e. Nth(0) is the minimum
+// and Nth(Count()-1) is the maximum. O(log n).
+//
+// Panics if n is out of range.
+func (buf *SortedRingBuffer[T]) Nth(n int) T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.index.Nth(n)
+}
+
+// Rank returns the number of values in the buffer that are less than the given value. O(log n).
+func (buf *SortedRingBuffer[T]) Rank(value T) int {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.index.Rank(value)
+}
+
+// ClosestTo returns the value in the buffer nearest to value. O(log n).
+//
+// If value is in the buffer it is returned. Else the nearer of the greatest value less than it and the least value
+// greater than it is returned, as measured by distance, which is only called when there are both.
+// If they are equally near, the lesser is returned. Returns false if the buffer is empty.
+//
+// Panics if distance is nil.
+func (buf *SortedRingBuffer[T]) ClosestTo(value T, distance functions.DistanceFunc[T]) (T, bool) {
+
+	if distance == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "distance"))
+	}
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	count := buf.index.Count()
+
+	if count == 0 {
+		var zero T
+		return zero, false
+	}
+
+	// Rank is the index of the least value not less than value.
+	rank := buf.index.Rank(value)
+
+	if rank == count {
+		return buf.index.Nth(count - 1), true
+	}
+
+	above := buf.index.Nth(rank)
+
+	if rank == 0 || buf.compare(above, value) == 0 {
+		return above, true
+	}
+
+	return util.Closest(value, buf.index.Nth(rank-1), above, distance), true
+}
+
+// ToSlice returns the values in the buffer in the order they were added, oldest first.
+func (buf *SortedRingBuffer[T]) ToSlice() []T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.window.ToSlice()
+}
+
+// ToSortedSlice returns the values in the buffer in ascending order.
+func (buf *SortedRingBuffer[T]) ToSortedSlice() []T {
+
+	if buf.lock != nil {
+		buf.lock.RLock()
+		defer buf.lock.RUnlock()
+	}
+
+	return buf.index.ToSlice()
+}
+
+func (buf *SortedRingBuffer[T]) add(value T) (evicted T, ok bool) {
+
+	if buf.window.Full() {
+		evicted, ok = buf.window.Dequeue(), true
+		buf.index.Remove(evicted)
+	}
+
+	buf.window.Enqueue(value)
+	buf.index.Add(value)
+	return
+}
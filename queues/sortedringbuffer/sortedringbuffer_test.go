@@ -0,0 +1,110 @@
+package sortedringbuffer
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedRingBuffer(t *testing.T) {
+
+	t.Run("Oldest values are evicted", func(t *testing.T) {
+		buf := New[int](3)
+		buf.AddRange([]int{10, 1, 2})
+		require.True(t, buf.Full())
+		require.Equal(t, 2, buf.Median())
+
+		evicted, ok := buf.Add(3)
+		require.True(t, ok)
+		require.Equal(t, 10, evicted)
+		require.Equal(t, []int{1, 2, 3}, buf.ToSlice())
+		require.Equal(t, 1, buf.Oldest())
+	})
+
+	t.Run("Add returns false until full", func(t *testing.T) {
+		buf := New[int](2)
+		_, ok := buf.Add(1)
+		require.False(t, ok)
+		require.False(t, buf.Full())
+	})
+
+	t.Run("Duplicate values are evicted one at a time", func(t *testing.T) {
+		buf := New[int](2)
+		buf.AddRange([]int{5, 5, 5})
+		require.Equal(t, []int{5, 5}, buf.ToSortedSlice())
+	})
+
+	t.Run("Ordered queries match sorted window", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(2163))
+		const window = 50
+		buf := NewOrdered[int](window, WithThreadSafe[int]())
+		values := make([]int, 0, 500)
+
+		for i := 0; i < 500; i++ {
+			v := rnd.Intn(100)
+			buf.Add(v)
+			values = append(values, v)
+
+			start := len(values) - window
+			if start < 0 {
+				start = 0
+			}
+
+			require.Equal(t, values[start:], buf.ToSlice())
+
+			model := append([]int{}, values[start:]...)
+			sort.Ints(model)
+			require.Equal(t, model, buf.ToSortedSlice())
+			require.Equal(t, model[0], buf.Min())
+			require.Equal(t, model[len(model)-1], buf.Max())
+			require.Equal(t, model[(len(model)+1)/2-1], buf.Median())
+			require.Equal(t, sort.SearchInts(model, 50), buf.Rank(50))
+		}
+	})
+
+	t.Run("Custom comparer", func(t *testing.T) {
+		buf := NewFunc(2, func(a, b string) int { return len(a) - len(b) })
+		buf.AddRange([]string{"ccc", "a", "bb"})
+		require.Equal(t, []string{"a", "bb"}, buf.ToSortedSlice())
+		require.Equal(t, "bb", buf.Nth(1))
+	})
+
+	t.Run("Clear empties the buffer", func(t *testing.T) {
+		buf := New[int](2)
+		buf.AddRange([]int{1, 2})
+		buf.Clear()
+		require.True(t, buf.IsEmpty())
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { buf.Median() })
+		buf.AddRange([]int{3, 4})
+		require.Equal(t, []int{3, 4}, buf.ToSlice())
+	})
+
+	t.Run("ClosestTo", func(t *testing.T) {
+		buf := New[int](4)
+		distance := functions.AbsDifference[int]
+
+		_, ok := buf.ClosestTo(5, distance)
+		require.False(t, ok)
+
+		buf.AddRange([]int{10, 20, 20, 40})
+
+		closest := func(v int) int {
+			c, ok := buf.ClosestTo(v, distance)
+			require.True(t, ok)
+			return c
+		}
+
+		require.Equal(t, 10, closest(0))
+		require.Equal(t, 20, closest(20))
+		require.Equal(t, 20, closest(24))
+		require.Equal(t, 20, closest(30))
+		require.Equal(t, 40, closest(31))
+		require.Equal(t, 40, closest(100))
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "distance"), func() { buf.ClosestTo(1, nil) })
+	})
+}
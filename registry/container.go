@@ -0,0 +1,285 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Container holds singleton collections keyed by element type and name, so that the components
+// of an application can resolve the collections they share rather than having each instance
+// passed through their constructors.
+//
+//	c := registry.NewContainer()
+//
+//	registry.Provide[Order](c, "pending", queue.New[Order](queue.WithThreadSafe[Order]()))
+//	registry.ProvideFunc(c, "seen", func() collections.Collection[string] { return hashset.New[string]() })
+//
+//	pending := registry.MustResolve[Order](c, "pending")
+//	defer c.Shutdown()
+//
+// The same name may be used for collections of different element types.
+// Unlike the collections, a Container is always thread-safe.
+//
+// The zero value is an empty container, ready to use.
+type Container struct {
+	lock     sync.Mutex
+	bindings map[bindingKey]binding
+	order    []bindingKey
+}
+
+// ProvideOptionFunc is the signature of a function
+// for providing options to Provide and ProvideFunc.
+type ProvideOptionFunc[T any] func(*provider[T])
+
+// ShutdownFunc is the signature of a function called for a collection when its container is shut down.
+type ShutdownFunc[T any] func(collections.Collection[T]) error
+
+type bindingKey struct {
+	elem reflect.Type
+	name string
+}
+
+// binding is implemented by provider[T] for each element type.
+type binding interface {
+	shutdown() error
+}
+
+type provider[T any] struct {
+	once       sync.Once
+	created    atomic.Bool
+	factory    func() collections.Collection[T]
+	collection collections.Collection[T]
+	hooks      []ShutdownFunc[T]
+	keepValues bool
+}
+
+// NewContainer constructs a new, empty Container.
+func NewContainer() *Container {
+	return &Container{}
+}
+
+// Option function for Provide and ProvideFunc to add a function called for the collection when the
+// container is shut down, before the collection is cleared. Hooks are called in the order given.
+// Any error returned by a hook is returned by Shutdown.
+//
+// Panics if hook is nil.
+func WithShutdownHook[T any](hook ShutdownFunc[T]) ProvideOptionFunc[T] {
+	if hook == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "hook"))
+	}
+	return func(p *provider[T]) {
+		p.hooks = append(p.hooks, hook)
+	}
+}
+
+// Option function for Provide and ProvideFunc to leave the values of the collection
+// in place when the container is shut down.
+func WithKeepValues[T any]() ProvideOptionFunc[T] {
+	return func(p *provider[T]) {
+		p.keepValues = true
+	}
+}
+
+// Provide adds a collection of element type T to the container under the given name.
+//
+// Panics if name is empty, collection is nil, or a collection of element type T has
+// already been provided under the name.
+func Provide[T any](c *Container, name string, collection collections.Collection[T], options ...ProvideOptionFunc[T]) {
+	if collection == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "collection"))
+	}
+
+	p := newProvider(func() collections.Collection[T] { return collection }, options)
+	p.get()
+	c.add(keyOf[T](name), p)
+}
+
+// ProvideFunc adds a collection of element type T to the container under the given name,
+// to be constructed by factory when it is first resolved. A collection that is never resolved
+// is never constructed.
+//
+// The factory may itself resolve other collections from the container, but not,
+// directly or indirectly, the collection it is constructing, which would deadlock.
+//
+// Panics if name is empty, factory is nil, or a collection of element type T has
+// already been provided under the name.
+func ProvideFunc[T any](c *Container, name string, factory func() collections.Collection[T], options ...ProvideOptionFunc[T]) {
+	if factory == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "factory"))
+	}
+
+	c.add(keyOf[T](name), newProvider(factory, options))
+}
+
+// Resolve returns the collection of element type T provided under the given name, and true;
+// else nil and false if there is none.
+func Resolve[T any](c *Container, name string) (collections.Collection[T], bool) {
+	b, ok := c.lookup(keyOf[T](name))
+
+	if !ok {
+		return nil, false
+	}
+
+	return b.(*provider[T]).get(), true
+}
+
+// ResolveAs is as [Resolve], returning the collection as its concrete type C, e.g.
+//
+//	q, ok := registry.ResolveAs[Order, *queue.Queue[Order]](c, "pending")
+//
+// Returns the zero value of C and false if there is no such collection, or it is not of type C.
+func ResolveAs[T any, C collections.Collection[T]](c *Container, name string) (C, bool) {
+	collection, ok := Resolve[T](c, name)
+
+	if !ok {
+		var zero C
+		return zero, false
+	}
+
+	concrete, ok := collection.(C)
+	return concrete, ok
+}
+
+// MustResolve is as [Resolve], for collections that the application cannot run without.
+//
+// Panics if there is no collection of element type T provided under the given name.
+func MustResolve[T any](c *Container, name string) collections.Collection[T] {
+	collection, ok := Resolve[T](c, name)
+
+	if !ok {
+		panic(fmt.Sprintf(messages.BINDING_NOT_FOUND_FMT, elemType[T](), name))
+	}
+
+	return collection
+}
+
+// Shutdown removes all collections from the container, in the reverse of the order in which they were provided.
+// For each collection that has been constructed, its shutdown hooks are called, then unless provided
+// with [WithKeepValues] it is cleared, then if it has a Dispose method, such as that of a collection
+// created with a WithName option, Dispose is called.
+//
+// Returns the errors returned by the hooks joined with [errors.Join], or nil if there were none.
+// The container may be used again once it has been shut down.
+func (c *Container) Shutdown() error {
+	c.lock.Lock()
+	bindings := make([]binding, 0, len(c.order))
+
+	for i := len(c.order) - 1; i >= 0; i-- {
+		bindings = append(bindings, c.bindings[c.order[i]])
+	}
+
+	c.bindings = nil
+	c.order = nil
+	c.lock.Unlock()
+
+	// Hooks are called outside the container lock,
+	// so that they may resolve from or provide to the container.
+	var errs []error
+
+	for _, b := range bindings {
+		if err := b.shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Count returns the number of collections provided to the container.
+func (c *Container) Count() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.order)
+}
+
+func (c *Container) add(key bindingKey, b binding) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.bindings[key]; ok {
+		panic(fmt.Sprintf(messages.BINDING_DUPLICATE_FMT, key.elem, key.name))
+	}
+
+	if c.bindings == nil {
+		c.bindings = make(map[bindingKey]binding)
+	}
+
+	c.bindings[key] = b
+	c.order = append(c.order, key)
+}
+
+func (c *Container) lookup(key bindingKey) (binding, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	b, ok := c.bindings[key]
+	return b, ok
+}
+
+func keyOf[T any](name string) bindingKey {
+	if name == "" {
+		panic(messages.NAME_EMPTY)
+	}
+
+	return bindingKey{
+		elem: elemType[T](),
+		name: name,
+	}
+}
+
+func elemType[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func newProvider[T any](factory func() collections.Collection[T], options []ProvideOptionFunc[T]) *provider[T] {
+	p := &provider[T]{
+		factory: factory,
+	}
+
+	for _, o := range options {
+		o(p)
+	}
+
+	return p
+}
+
+// get returns the collection, constructing it on the first call.
+func (p *provider[T]) get() collections.Collection[T] {
+	p.once.Do(func() {
+		p.collection = p.factory()
+		p.created.Store(true)
+	})
+
+	return p.collection
+}
+
+func (p *provider[T]) shutdown() error {
+	if !p.created.Load() {
+		return nil
+	}
+
+	var errs []error
+
+	for _, hook := range p.hooks {
+		if err := hook(p.collection); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if !p.keepValues {
+		p.collection.Clear()
+	}
+
+	if d, ok := p.collection.(interface{ Dispose() }); ok {
+		d.Dispose()
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,127 @@
+package registry_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/registry"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/stacks/stack"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer(t *testing.T) {
+
+	t.Run("Resolves by element type and name", func(t *testing.T) {
+		var c registry.Container
+		ints := queue.New[int]()
+		strs := queue.New[string]()
+
+		registry.Provide[int](&c, "pending", ints)
+		registry.Provide[string](&c, "pending", strs)
+
+		resolved, ok := registry.Resolve[int](&c, "pending")
+		require.True(t, ok)
+		require.Same(t, ints, resolved)
+		require.Same(t, strs, registry.MustResolve[string](&c, "pending"))
+
+		_, ok = registry.Resolve[int](&c, "other")
+		require.False(t, ok)
+		_, ok = registry.Resolve[float64](&c, "pending")
+		require.False(t, ok)
+		require.Equal(t, 2, c.Count())
+	})
+
+	t.Run("ResolveAs returns concrete type", func(t *testing.T) {
+		c := registry.NewContainer()
+		registry.Provide[int](c, "pending", queue.New[int]())
+
+		q, ok := registry.ResolveAs[int, *queue.Queue[int]](c, "pending")
+		require.True(t, ok)
+		q.Enqueue(1)
+
+		_, ok = registry.ResolveAs[int, *stack.Stack[int]](c, "pending")
+		require.False(t, ok)
+	})
+
+	t.Run("Factory is called once on first resolve", func(t *testing.T) {
+		c := registry.NewContainer()
+		calls := 0
+		registry.ProvideFunc(c, "seen", func() collections.Collection[string] {
+			calls++
+			return hashset.New[string]()
+		})
+
+		require.Zero(t, calls)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.MustResolve[string](c, "seen")
+			}()
+		}
+		wg.Wait()
+
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("Duplicate and missing panic", func(t *testing.T) {
+		c := registry.NewContainer()
+		registry.Provide[int](c, "pending", queue.New[int]())
+
+		require.PanicsWithValue(t, fmt.Sprintf(messages.BINDING_DUPLICATE_FMT, "int", "pending"), func() {
+			registry.Provide[int](c, "pending", stack.New[int]())
+		})
+		require.PanicsWithValue(t, fmt.Sprintf(messages.BINDING_NOT_FOUND_FMT, "string", "pending"), func() {
+			registry.MustResolve[string](c, "pending")
+		})
+		require.PanicsWithValue(t, messages.NAME_EMPTY, func() {
+			registry.Provide[int](c, "", queue.New[int]())
+		})
+		require.Panics(t, func() { registry.Provide[int](c, "nil", nil) })
+	})
+
+	t.Run("Shutdown clears and disposes in reverse order", func(t *testing.T) {
+		c := registry.NewContainer()
+		var order []string
+
+		hook := func(name string, err error) registry.ShutdownFunc[int] {
+			return func(coll collections.Collection[int]) error {
+				require.False(t, coll.IsEmpty())
+				order = append(order, name)
+				return err
+			}
+		}
+
+		first := queue.OfWith([]queue.QueueOptionFunc[int]{queue.WithName[int]("container-first")}, 1, 2)
+		second := stack.Of(3)
+		kept := stack.Of(4)
+
+		registry.Provide[int](c, "first", first, registry.WithShutdownHook(hook("first", errors.New("first failed"))))
+		registry.Provide[int](c, "second", second, registry.WithShutdownHook(hook("second", nil)))
+		registry.Provide[int](c, "kept", kept, registry.WithKeepValues[int]())
+		registry.ProvideFunc(c, "never", func() collections.Collection[int] {
+			panic("never constructed")
+		})
+		require.Len(t, snapshot("container-"), 1)
+
+		require.EqualError(t, c.Shutdown(), "first failed")
+		require.Equal(t, []string{"second", "first"}, order)
+		require.True(t, first.IsEmpty())
+		require.True(t, second.IsEmpty())
+		require.Equal(t, 1, kept.Count())
+		require.Empty(t, snapshot("container-"))
+
+		require.Zero(t, c.Count())
+		_, ok := registry.Resolve[int](c, "first")
+		require.False(t, ok)
+		registry.Provide[int](c, "first", first)
+	})
+}
@@ -0,0 +1,142 @@
+/*
+Package registry tracks the live collections that have been given a name with the WithName
+constructor option of their package, so that their statistics can be inspected at runtime,
+e.g. from a debug or health endpoint.
+
+	q := queue.New(queue.WithName[Order]("orders-queue"), queue.WithThreadSafe[Order]())
+	defer q.Dispose()
+
+	for _, s := range registry.Snapshot() {
+		fmt.Printf("%s: %d values, %d added\n", s.Name, s.Count, s.Ops.Adds)
+	}
+
+Registration is opt-in. Collections created without a name are not registered and do not count
+their operations. A named collection remains registered until its Dispose method is called,
+so a collection that is no longer required should be disposed to allow it to be garbage collected.
+
+The package also provides a [Container], in which an application may provide singleton collections
+by element type and name for its components to resolve, and which clears and disposes them on shutdown.
+*/
+package registry
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+)
+
+// OpCounts holds the number of mutations applied to a collection since it was created.
+type OpCounts struct {
+	// Adds is the number of values added.
+	Adds uint64
+
+	// Removes is the number of values removed, other than by Clear.
+	Removes uint64
+
+	// Clears is the number of times the collection was cleared.
+	Clears uint64
+
+	// Resets is the number of mutations that could not be expressed as individual adds
+	// and removes, such as sorting, and were therefore not counted as such.
+	Resets uint64
+}
+
+// Stats is a point-in-time view of a registered collection.
+type Stats struct {
+	// Name is the name given to the collection by WithName.
+	Name string
+
+	// Type is the type of the collection.
+	Type collections.CollectionType
+
+	// Count is the number of values in the collection.
+	Count int
+
+	// Version is incremented by each modification of the collection.
+	Version int
+
+	// Ops counts the mutations applied to the collection.
+	Ops OpCounts
+}
+
+// Registration is the handle to a registered collection.
+type Registration struct {
+	name   string
+	seq    uint64
+	source func() Stats
+}
+
+var (
+	lock    sync.Mutex
+	entries = make(map[*Registration]struct{})
+	nextSeq uint64
+)
+
+// Register adds a collection to the registry. source is called by [Snapshot] to obtain
+// the current statistics of the collection, and must be safe to call from any goroutine.
+//
+// Collections in this module register themselves when constructed with a name, so there is
+// no need to call this directly.
+//
+// Names need not be unique.
+func Register(name string, source func() Stats) *Registration {
+	lock.Lock()
+	defer lock.Unlock()
+
+	r := &Registration{
+		name:   name,
+		seq:    nextSeq,
+		source: source,
+	}
+
+	nextSeq++
+	entries[r] = struct{}{}
+	return r
+}
+
+// Deregister removes the collection from the registry.
+// Calling it more than once, or on a nil registration, has no effect.
+func (r *Registration) Deregister() {
+	if r == nil {
+		return
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	delete(entries, r)
+}
+
+// Snapshot returns the statistics of all registered collections, ordered by name,
+// then by order of registration.
+//
+// The statistics of each collection are read under that collection's lock if it is thread-safe.
+// Collections that are not thread-safe should not be modified while a snapshot is being taken.
+func Snapshot() []Stats {
+	lock.Lock()
+	registrations := make([]*Registration, 0, len(entries))
+
+	for r := range entries {
+		registrations = append(registrations, r)
+	}
+
+	lock.Unlock()
+
+	// Sources are called outside the registry lock, so that a collection
+	// being disposed under its own lock cannot deadlock with a snapshot.
+	sort.Slice(registrations, func(i, j int) bool {
+		if registrations[i].name != registrations[j].name {
+			return registrations[i].name < registrations[j].name
+		}
+		return registrations[i].seq < registrations[j].seq
+	})
+
+	stats := make([]Stats, len(registrations))
+
+	for i, r := range registrations {
+		stats[i] = r.source()
+		stats[i].Name = r.name
+	}
+
+	return stats
+}
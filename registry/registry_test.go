@@ -0,0 +1,150 @@
+package registry_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/lists/slist"
+	"github.com/fireflycons/generic_collections/queues/queue"
+	"github.com/fireflycons/generic_collections/queues/ringbuffer"
+	"github.com/fireflycons/generic_collections/registry"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/fireflycons/generic_collections/stacks/stack"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshot returns the registered collections whose names begin with prefix,
+// as the registry is shared by all tests in the package.
+func snapshot(prefix string) []registry.Stats {
+	stats := []registry.Stats{}
+
+	for _, s := range registry.Snapshot() {
+		if strings.HasPrefix(s.Name, prefix) {
+			stats = append(stats, s)
+		}
+	}
+
+	return stats
+}
+
+func TestSnapshot(t *testing.T) {
+
+	t.Run("Named collections are registered", func(t *testing.T) {
+		disposers := []func(){
+			stack.New(stack.WithName[int]("all-stack")).Dispose,
+			queue.New(queue.WithName[int]("all-queue")).Dispose,
+			ringbuffer.New(1, ringbuffer.WithName[int]("all-ringbuffer")).Dispose,
+			slist.New(slist.WithName[int]("all-slist")).Dispose,
+			dlist.New(dlist.WithName[int]("all-dlist")).Dispose,
+			hashset.New(hashset.WithName[int]("all-hashset")).Dispose,
+			orderedset.New(orderedset.WithName[int]("all-orderedset")).Dispose,
+		}
+
+		queue.New[int]()
+
+		types := []collections.CollectionType{}
+		for _, s := range snapshot("all-") {
+			require.Equal(t, "all-"+strings.ToLower(s.Type.String()), s.Name)
+			types = append(types, s.Type)
+		}
+
+		require.ElementsMatch(t, []collections.CollectionType{
+			collections.COLLECTION_STACK,
+			collections.COLLECTION_QUEUE,
+			collections.COLLECTION_RINGBUFFER,
+			collections.COLLECTION_SLIST,
+			collections.COLLECTION_DLIST,
+			collections.COLLECTION_HASHSET,
+			collections.COLLECTION_ORDEREDSET,
+		}, types)
+
+		for _, dispose := range disposers {
+			dispose()
+		}
+
+		require.Empty(t, snapshot("all-"))
+	})
+
+	t.Run("Stats reflect the collection", func(t *testing.T) {
+		q := queue.OfWith([]queue.QueueOptionFunc[int]{queue.WithName[int]("stats-orders")}, 1, 2, 3)
+		defer q.Dispose()
+
+		q.Dequeue()
+		q.Remove(3)
+		q.Add(4)
+		q.Sort()
+		q.Clear()
+		q.Add(5)
+
+		stats := snapshot("stats-")
+		require.Len(t, stats, 1)
+		require.Equal(t, "stats-orders", stats[0].Name)
+		require.Equal(t, collections.COLLECTION_QUEUE, stats[0].Type)
+		require.Equal(t, 1, stats[0].Count)
+		require.Equal(t, registry.OpCounts{Adds: 5, Removes: 2, Clears: 1, Resets: 1}, stats[0].Ops)
+	})
+
+	t.Run("Ordered by name then registration", func(t *testing.T) {
+		b := hashset.New(hashset.WithName[int]("order-b"))
+		a1 := stack.New(stack.WithName[int]("order-a"))
+		a2 := dlist.New(dlist.WithName[int]("order-a"))
+
+		stats := snapshot("order-")
+		require.Len(t, stats, 3)
+		require.Equal(t, collections.COLLECTION_STACK, stats[0].Type)
+		require.Equal(t, collections.COLLECTION_DLIST, stats[1].Type)
+		require.Equal(t, collections.COLLECTION_HASHSET, stats[2].Type)
+
+		a1.Dispose()
+		a1.Dispose()
+		a2.Dispose()
+		b.Dispose()
+		require.Empty(t, snapshot("order-"))
+	})
+
+	t.Run("Derived collections are not registered", func(t *testing.T) {
+		set := orderedset.OfWith([]orderedset.OrderedSetOptionFunc[int]{orderedset.WithName[int]("clone-set")}, 1, 2)
+		defer set.Dispose()
+
+		clone := set.Select(func(int) bool { return true })
+		clone.Add(3)
+
+		stats := snapshot("clone-")
+		require.Len(t, stats, 1)
+		require.Equal(t, 2, stats[0].Count)
+	})
+
+	t.Run("Concurrent with thread-safe collection", func(t *testing.T) {
+		q := queue.New(queue.WithName[int]("concurrent-queue"), queue.WithThreadSafe[int]())
+		defer q.Dispose()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				q.Add(i)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				snapshot("concurrent-")
+			}
+		}()
+
+		wg.Wait()
+		require.Equal(t, uint64(1000), snapshot("concurrent-")[0].Ops.Adds)
+	})
+
+	t.Run("Empty name panics", func(t *testing.T) {
+		require.PanicsWithValue(t, messages.NAME_EMPTY, func() { queue.WithName[int]("") })
+	})
+}
@@ -0,0 +1,234 @@
+/*
+Package schema describes the data serialized by the GobEncode, Encode and Checkpoint methods of the collections
+in this module, so that data written by one service can be checked before it is loaded by another.
+
+The encoded form of each collection begins with a header holding a [Schema], which names the type
+of the collection, the type of its values and any options that determine how the data is interpreted.
+A collection constructed with the WithSchemaValidation option of its package checks the header when
+decoding with GobDecode, Decode or Restore, and returns an error wrapping [ErrMismatch] rather than
+loading data of another type.
+
+	q := queue.New(queue.WithSchemaValidation[Order]())
+
+	if err := gob.NewDecoder(r).Decode(q); errors.Is(err, schema.ErrMismatch) {
+		var mismatch *schema.MismatchError
+		errors.As(err, &mismatch)
+		log.Printf("expected %s, got %s", mismatch.Expected, mismatch.Actual)
+	}
+
+The header is written in a form that may be read without encoding/gob, so that tools in other languages
+can identify the data: a zero byte, the ASCII characters "gcs", the format version 1, the length of the
+header as an unsigned varint, then the Schema as a JSON object. The encoded values follow the header.
+As no gob stream begins with a zero byte, data encoded before headers were added is still decoded,
+except by collections that validate the schema. The same holds for the streams written by Encode
+and the checkpoints written by Checkpoint, unless the data begins with a header by coincidence.
+*/
+package schema
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// magic introduces a header, and holds the version of its format.
+var magic = []byte("\x00gcs\x01")
+
+// maxHeader is the length of the longest header read by [ReadFrom], beyond which the header is taken to be corrupt.
+const maxHeader = 1 << 16
+
+// ErrMissing is returned when data that should be validated has no schema header.
+var ErrMissing = errors.New(messages.SCHEMA_MISSING)
+
+// ErrMismatch is wrapped by the [MismatchError] returned when data does not match the expected schema.
+var ErrMismatch = errors.New(messages.SCHEMA_MISMATCH)
+
+// Schema describes serialized collection data.
+type Schema struct {
+	// Collection is the name of the type of the collection, as returned by [collections.CollectionType.String].
+	Collection string `json:"collection"`
+
+	// Element is the name of the Go type of the values, as returned by [reflect.Type.String], e.g. "int" or "orders.Order".
+	Element string `json:"element"`
+
+	// Options holds the options of the collection that determine how the data is interpreted,
+	// e.g. "levels" for a PriorityFair. Options such as comparers cannot be described, so are not included.
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// MismatchError describes the field in which data differs from the expected schema.
+type MismatchError struct {
+	// Field is "collection", "element" or "option " followed by the name of the option.
+	Field string
+
+	// Expected is the value of the field expected by the collection decoding the data.
+	Expected string
+
+	// Actual is the value of the field in the data.
+	Actual string
+}
+
+// Error returns a description of the mismatch.
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf(messages.SCHEMA_MISMATCH_FMT, e.Field, e.Actual, e.Expected)
+}
+
+// Unwrap returns [ErrMismatch].
+func (e *MismatchError) Unwrap() error {
+	return ErrMismatch
+}
+
+// Of returns the schema of a collection of the given type holding values of type T.
+func Of[T any](collection collections.CollectionType, options map[string]string) Schema {
+	return Schema{
+		Collection: collection.String(),
+		Element:    reflect.TypeOf((*T)(nil)).Elem().String(),
+		Options:    options,
+	}
+}
+
+// Verify returns nil if s matches expected; else a [*MismatchError] for the first field that differs.
+// Only the options named in expected are compared, so data may describe options that the reader ignores.
+func (s Schema) Verify(expected Schema) error {
+	if s.Collection != expected.Collection {
+		return &MismatchError{Field: "collection", Expected: expected.Collection, Actual: s.Collection}
+	}
+
+	if s.Element != expected.Element {
+		return &MismatchError{Field: "element", Expected: expected.Element, Actual: s.Element}
+	}
+
+	for name, value := range expected.Options {
+		if actual := s.Options[name]; actual != value {
+			return &MismatchError{Field: "option " + name, Expected: value, Actual: actual}
+		}
+	}
+
+	return nil
+}
+
+// Read returns the schema in the header of serialized data and the data that follows it.
+//
+// Returns [ErrMissing] and the data unchanged if it has no header, or an error if the header is corrupt.
+func Read(data []byte) (Schema, []byte, error) {
+	var s Schema
+
+	if !bytes.HasPrefix(data, magic) {
+		return s, data, ErrMissing
+	}
+
+	length, n := binary.Uvarint(data[len(magic):])
+	start := len(magic) + n
+
+	if n <= 0 || length > uint64(len(data)-start) {
+		return s, nil, fmt.Errorf(messages.DECODE_INVALID_FMT, "schema header")
+	}
+
+	end := start + int(length)
+
+	if err := json.Unmarshal(data[start:end], &s); err != nil {
+		return s, nil, fmt.Errorf(messages.DECODE_INVALID_FMT+": %w", "schema header", err)
+	}
+
+	return s, data[end:], nil
+}
+
+// Append appends a header holding the schema to dst, and returns the extended slice.
+func Append(dst []byte, s Schema) ([]byte, error) {
+	header, err := json.Marshal(s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dst = append(dst, magic...)
+	dst = binary.AppendUvarint(dst, uint64(len(header)))
+	return append(dst, header...), nil
+}
+
+// Write writes a header holding the schema to w.
+func Write(w io.Writer, s Schema) error {
+	header, err := Append(nil, s)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(header)
+	return err
+}
+
+// ReadFrom reads the header at the start of r, and returns its schema and a reader of the data that follows it.
+// No more than the header is read from r, so that further data in the same stream may be read after the data.
+//
+// Returns [ErrMissing] if r has no header, with a reader of the data of r that first yields any bytes
+// read while looking for the header. Returns an error if the header is corrupt or cannot be read.
+func ReadFrom(r io.Reader) (Schema, io.Reader, error) {
+	var s Schema
+	prefix := make([]byte, len(magic))
+
+	// Data that does not begin with a zero byte is not read any further.
+	n, err := io.ReadFull(r, prefix[:1])
+
+	if n == 0 {
+		if err == io.EOF {
+			return s, r, ErrMissing
+		}
+
+		return s, nil, err
+	}
+
+	if prefix[0] == magic[0] {
+		n, err = io.ReadFull(r, prefix[1:])
+		n++
+
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return s, nil, err
+		}
+	}
+
+	if !bytes.Equal(prefix[:n], magic) {
+		return s, io.MultiReader(bytes.NewReader(prefix[:n]), r), ErrMissing
+	}
+
+	length, err := binary.ReadUvarint(byteReader{r})
+
+	if err != nil || length > maxHeader {
+		return s, nil, fmt.Errorf(messages.DECODE_INVALID_FMT, "schema header")
+	}
+
+	header := make([]byte, length)
+
+	if _, err := io.ReadFull(r, header); err != nil {
+		return s, nil, fmt.Errorf(messages.DECODE_INVALID_FMT+": %w", "schema header", err)
+	}
+
+	if err := json.Unmarshal(header, &s); err != nil {
+		return s, nil, fmt.Errorf(messages.DECODE_INVALID_FMT+": %w", "schema header", err)
+	}
+
+	return s, r, nil
+}
+
+// byteReader reads the length of the header a byte at a time, so that no more
+// than the header is consumed from the underlying reader.
+type byteReader struct {
+	io.Reader
+}
+
+func (b byteReader) ReadByte() (byte, error) {
+	var buf [1]byte
+
+	if _, err := io.ReadFull(b.Reader, buf[:]); err != nil {
+		return 0, err
+	}
+
+	return buf[0], nil
+}
@@ -0,0 +1,152 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+type order struct {
+	ID int
+}
+
+func TestOf(t *testing.T) {
+
+	require.Equal(t, Schema{Collection: "Queue", Element: "int"}, Of[int](collections.COLLECTION_QUEUE, nil))
+	require.Equal(t, "schema.order", Of[order](collections.COLLECTION_STACK, nil).Element)
+	require.Equal(t, "[]string", Of[[]string](collections.COLLECTION_STACK, nil).Element)
+	require.Equal(t, "interface {}", Of[any](collections.COLLECTION_STACK, nil).Element)
+}
+
+func TestReadAppend(t *testing.T) {
+
+	t.Run("Round trip", func(t *testing.T) {
+		s := Of[int](collections.COLLECTION_PRIORITYFAIR, map[string]string{"levels": "3"})
+		data, err := Append(nil, s)
+		require.NoError(t, err)
+		data = append(data, "payload"...)
+
+		read, payload, err := Read(data)
+		require.NoError(t, err)
+		require.Equal(t, s, read)
+		require.Equal(t, []byte("payload"), payload)
+	})
+
+	t.Run("Header is JSON after the magic and length", func(t *testing.T) {
+		data, err := Append(nil, Of[int](collections.COLLECTION_QUEUE, nil))
+		require.NoError(t, err)
+
+		header := `{"collection":"Queue","element":"int"}`
+		require.Equal(t, append([]byte{0, 'g', 'c', 's', 1, byte(len(header))}, header...), data)
+	})
+
+	t.Run("Missing header", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode([]int{1, 2}))
+
+		_, payload, err := Read(buf.Bytes())
+		require.ErrorIs(t, err, ErrMissing)
+		require.Equal(t, buf.Bytes(), payload, "Data without a header should be returned unchanged")
+	})
+
+	t.Run("Corrupt header", func(t *testing.T) {
+		data, err := Append(nil, Of[int](collections.COLLECTION_QUEUE, nil))
+		require.NoError(t, err)
+
+		_, _, err = Read(data[:len(data)-1])
+		require.EqualError(t, err, fmt.Sprintf(messages.DECODE_INVALID_FMT, "schema header"))
+
+		data[len(data)-1] = '!'
+		_, _, err = Read(data)
+		require.ErrorContains(t, err, fmt.Sprintf(messages.DECODE_INVALID_FMT, "schema header"))
+	})
+}
+
+func TestWriteReadFrom(t *testing.T) {
+
+	readAll := func(r io.Reader) string {
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("Round trip", func(t *testing.T) {
+		s := Of[int](collections.COLLECTION_DLIST, nil)
+
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, s))
+		buf.WriteString("payload")
+
+		read, payload, err := ReadFrom(&buf)
+		require.NoError(t, err)
+		require.Equal(t, s, read)
+		require.Equal(t, "payload", readAll(payload))
+	})
+
+	t.Run("Only the header is read", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, Of[int](collections.COLLECTION_DLIST, nil)))
+		buf.WriteString("payload")
+
+		// A reader without ReadByte or buffering
+		_, _, err := ReadFrom(io.LimitReader(&buf, int64(buf.Len())))
+		require.NoError(t, err)
+		require.Equal(t, "payload", buf.String())
+	})
+
+	t.Run("Missing header", func(t *testing.T) {
+		for _, data := range []string{"\x03abc", "\x00abcdef", "\x00", ""} {
+			_, payload, err := ReadFrom(bytes.NewReader([]byte(data)))
+			require.ErrorIs(t, err, ErrMissing)
+			require.Equal(t, data, readAll(payload), "Data without a header should be returned unchanged")
+		}
+	})
+
+	t.Run("Corrupt header", func(t *testing.T) {
+		data, err := Append(nil, Of[int](collections.COLLECTION_QUEUE, nil))
+		require.NoError(t, err)
+
+		_, _, err = ReadFrom(bytes.NewReader(data[:len(data)-1]))
+		require.ErrorContains(t, err, fmt.Sprintf(messages.DECODE_INVALID_FMT, "schema header"))
+
+		_, _, err = ReadFrom(bytes.NewReader(append(data[:len(magic)], 0xff, 0xff, 0xff, 0x7f)))
+		require.EqualError(t, err, fmt.Sprintf(messages.DECODE_INVALID_FMT, "schema header"))
+	})
+}
+
+func TestVerify(t *testing.T) {
+
+	s := Of[int](collections.COLLECTION_PRIORITYFAIR, map[string]string{"levels": "3", "extra": "x"})
+
+	t.Run("Match", func(t *testing.T) {
+		require.NoError(t, s.Verify(Of[int](collections.COLLECTION_PRIORITYFAIR, nil)))
+		require.NoError(t, s.Verify(Of[int](collections.COLLECTION_PRIORITYFAIR, map[string]string{"levels": "3"})))
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		for expected, field := range map[*Schema]string{
+			{Collection: "Queue", Element: "int"}:                                                   "collection",
+			{Collection: "PriorityFair", Element: "string"}:                                         "element",
+			{Collection: "PriorityFair", Element: "int", Options: map[string]string{"levels": "2"}}: "option levels",
+		} {
+			err := s.Verify(*expected)
+
+			var mismatch *MismatchError
+			require.True(t, errors.As(err, &mismatch))
+			require.ErrorIs(t, err, ErrMismatch)
+			require.Equal(t, field, mismatch.Field)
+		}
+	})
+
+	t.Run("Message", func(t *testing.T) {
+		err := s.Verify(Of[string](collections.COLLECTION_PRIORITYFAIR, nil))
+		require.EqualError(t, err, fmt.Sprintf(messages.SCHEMA_MISMATCH_FMT, "element", "int", "string"))
+	})
+}
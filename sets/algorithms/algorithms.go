@@ -0,0 +1,48 @@
+/*
+Package algorithms provides combinatorial algorithms over sets, such as enumerating
+the subsets of a given size and choosing a small number of sets that cover a universe,
+as arise in scheduling and selection problems.
+
+	teams := algorithms.SubsetsOfSize[string](players, 3)
+
+	for e := teams.Start(); e != nil; e = teams.Next() {
+		fmt.Println(e.Value())
+	}
+
+	chosen, uncovered := algorithms.SetCover[string](skillsNeeded, candidateSkills)
+
+The algorithms work on any [sets.Set], and read the values of a set in its iteration order,
+so the results for a set that orders its values, such as an OrderedSet, are deterministic.
+*/
+package algorithms
+
+import (
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// element is an Element whose value is computed rather than stored in a collection.
+type element[T any] struct {
+	value T
+	local.InternalImpl
+}
+
+// Value returns the value of this element.
+func (e *element[T]) Value() T {
+	return e.value
+}
+
+// ValuePtr panics, as the values are derived from sets, and modifying them would break the sets.
+func (e *element[T]) ValuePtr() *T {
+	panic(messages.SET_POINTER_MODIFICATION)
+}
+
+// IsValid returns true, as the value is held by the element rather than referring into a collection.
+func (e *element[T]) IsValid() bool {
+	return true
+}
+
+// Refresh returns true, as the element is always valid.
+func (e *element[T]) Refresh() bool {
+	return true
+}
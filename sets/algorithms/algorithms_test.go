@@ -0,0 +1,181 @@
+package algorithms
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/stretchr/testify/require"
+)
+
+func collect[T any](iter collections.Iterator[T]) []T {
+	values := []T{}
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, e.Value())
+	}
+
+	return values
+}
+
+func TestSubsetsOfSize(t *testing.T) {
+
+	t.Run("Every subset of the size is produced in order", func(t *testing.T) {
+		iter := SubsetsOfSize[string](orderedset.Of("a", "b", "c", "d"), 2)
+
+		require.Equal(t, [][]string{
+			{"a", "b"}, {"a", "c"}, {"a", "d"},
+			{"b", "c"}, {"b", "d"},
+			{"c", "d"},
+		}, collect[[]string](iter))
+	})
+
+	t.Run("Number of subsets is the binomial coefficient", func(t *testing.T) {
+		s := orderedset.New[int]()
+		for i := 0; i < 10; i++ {
+			s.Add(i)
+		}
+
+		for k, expected := range []int{1, 10, 45, 120, 210, 252, 210, 120, 45, 10, 1} {
+			require.Len(t, collect[[]int](SubsetsOfSize[int](s, k)), expected, "k = %d", k)
+		}
+	})
+
+	t.Run("Subsets are distinct", func(t *testing.T) {
+		seen := map[string]bool{}
+
+		for _, subset := range collect[[]int](SubsetsOfSize[int](hashset.Of(1, 2, 3, 4, 5, 6), 3)) {
+			key := fmt.Sprint(subset)
+			require.False(t, seen[key], key)
+			seen[key] = true
+		}
+
+		require.Len(t, seen, 20)
+	})
+
+	t.Run("Size zero produces the empty subset", func(t *testing.T) {
+		require.Equal(t, [][]int{{}}, collect[[]int](SubsetsOfSize[int](orderedset.Of(1, 2), 0)))
+		require.Equal(t, [][]int{{}}, collect[[]int](SubsetsOfSize[int](orderedset.New[int](), 0)))
+	})
+
+	t.Run("Size of the set produces the set", func(t *testing.T) {
+		require.Equal(t, [][]int{{1, 2, 3}}, collect[[]int](SubsetsOfSize[int](orderedset.Of(1, 2, 3), 3)))
+	})
+
+	t.Run("Size greater than the set produces nothing", func(t *testing.T) {
+		iter := SubsetsOfSize[int](orderedset.Of(1, 2), 3)
+
+		require.Nil(t, iter.Start())
+		require.Nil(t, iter.Next())
+	})
+
+	t.Run("Start restarts iteration with the current values", func(t *testing.T) {
+		s := orderedset.Of(1, 2)
+		iter := SubsetsOfSize[int](s, 2)
+		collect[[]int](iter)
+		s.Add(3)
+
+		require.Equal(t, [][]int{{1, 2}, {1, 3}, {2, 3}}, collect[[]int](iter))
+	})
+
+	t.Run("Subsets are not shared", func(t *testing.T) {
+		iter := SubsetsOfSize[int](orderedset.Of(1, 2, 3), 2)
+		first := iter.Start().Value()
+		iter.Next()
+
+		require.Equal(t, []int{1, 2}, first)
+	})
+
+	t.Run("Element pointer panics", func(t *testing.T) {
+		e := SubsetsOfSize[int](orderedset.Of(1), 1).Start()
+		require.PanicsWithValue(t, messages.SET_POINTER_MODIFICATION, func() { e.ValuePtr() })
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "s"), func() { SubsetsOfSize[int](nil, 1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "k"), func() { SubsetsOfSize[int](orderedset.Of(1), -1) })
+	})
+}
+
+func TestSetCover(t *testing.T) {
+
+	t.Run("Greedy choice covers the universe", func(t *testing.T) {
+		universe := orderedset.Of(1, 2, 3, 4, 5)
+		candidates := []sets.Set[int]{
+			orderedset.Of(1, 2, 3),
+			orderedset.Of(2, 4),
+			orderedset.Of(3, 4),
+			orderedset.Of(4, 5),
+		}
+
+		chosen, uncovered := SetCover[int](universe, candidates)
+
+		require.Equal(t, []int{0, 3}, chosen)
+		require.Empty(t, uncovered)
+	})
+
+	t.Run("Candidate with the most uncovered values is chosen first", func(t *testing.T) {
+		universe := orderedset.Of(1, 2, 3, 4, 5, 6)
+		candidates := []sets.Set[int]{
+			orderedset.Of(1, 2),
+			orderedset.Of(3, 4, 5, 6),
+			orderedset.Of(1, 2, 3),
+		}
+
+		chosen, _ := SetCover[int](universe, candidates)
+
+		// Once 3 to 6 are covered, candidates 0 and 2 each cover two more values,
+		// so the lower index is chosen.
+		require.Equal(t, []int{1, 0}, chosen)
+	})
+
+	t.Run("Values outside the universe are ignored", func(t *testing.T) {
+		universe := orderedset.Of(1, 2)
+		candidates := []sets.Set[int]{
+			orderedset.Of(1, 10, 11, 12),
+			orderedset.Of(1, 2),
+		}
+
+		chosen, uncovered := SetCover[int](universe, candidates)
+
+		require.Equal(t, []int{1}, chosen)
+		require.Empty(t, uncovered)
+	})
+
+	t.Run("Values that cannot be covered are returned", func(t *testing.T) {
+		universe := orderedset.Of(1, 2, 3, 4)
+		candidates := []sets.Set[int]{
+			orderedset.Of(1),
+			hashset.Of(3),
+		}
+
+		chosen, uncovered := SetCover[int](universe, candidates)
+
+		require.ElementsMatch(t, []int{0, 1}, chosen)
+		require.Equal(t, []int{2, 4}, uncovered)
+	})
+
+	t.Run("Empty universe needs no candidates", func(t *testing.T) {
+		chosen, uncovered := SetCover[int](orderedset.New[int](), []sets.Set[int]{orderedset.Of(1)})
+
+		require.Empty(t, chosen)
+		require.Empty(t, uncovered)
+	})
+
+	t.Run("No candidates leave the universe uncovered", func(t *testing.T) {
+		chosen, uncovered := SetCover[int](orderedset.Of(1, 2), nil)
+
+		require.Empty(t, chosen)
+		require.Equal(t, []int{1, 2}, uncovered)
+	})
+
+	t.Run("Nil arguments panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "universe"), func() { SetCover[int](nil, nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "candidates[1]"), func() {
+			SetCover[int](orderedset.Of(1), []sets.Set[int]{orderedset.Of(1), nil})
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package algorithms_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/sets"
+	"github.com/fireflycons/generic_collections/sets/algorithms"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+)
+
+func ExampleSubsetsOfSize() {
+	iter := algorithms.SubsetsOfSize[string](orderedset.Of("ann", "bob", "cat", "dan"), 3)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Println(e.Value())
+	}
+
+	// Output:
+	// [ann bob cat]
+	// [ann bob dan]
+	// [ann cat dan]
+	// [bob cat dan]
+}
+
+func ExampleSetCover() {
+	needed := orderedset.Of("go", "rust", "sql", "k8s")
+	names := []string{"ann", "bob", "cat"}
+	skills := []sets.Set[string]{
+		orderedset.Of("go", "sql"),
+		orderedset.Of("go", "rust", "k8s"),
+		orderedset.Of("sql"),
+	}
+
+	chosen, uncovered := algorithms.SetCover[string](needed, skills)
+
+	for _, i := range chosen {
+		fmt.Println(names[i])
+	}
+
+	fmt.Println(uncovered)
+
+	// Output:
+	// bob
+	// ann
+	// []
+}
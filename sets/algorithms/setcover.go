@@ -0,0 +1,94 @@
+package algorithms
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets"
+)
+
+// SetCover chooses candidates whose union covers the universe, using the greedy algorithm that
+// repeatedly chooses the candidate covering the most values not yet covered, and returns the indices
+// of the chosen candidates in the order they were chosen. Where candidates cover equally many values,
+// the one with the lowest index is chosen. Values of the candidates that are not in the universe are ignored.
+//
+// Finding the smallest cover is NP-hard. The greedy cover has at most ln(n) + 1 times as many
+// candidates as the smallest, for a universe of n values, and is usually much closer.
+//
+// If the candidates cannot cover the universe, the cover is of as many values as they can cover,
+// and the values left uncovered are returned in the iteration order of the universe;
+// else uncovered is empty.
+//
+// O(n * m) calls to Contains for m candidates, plus O(m) to choose each candidate.
+//
+// Panics if universe or any candidate is nil.
+func SetCover[T any](universe sets.Set[T], candidates []sets.Set[T]) (chosen []int, uncovered []T) {
+	if universe == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "universe"))
+	}
+
+	for i, c := range candidates {
+		if c == nil {
+			panic(fmt.Sprintf(messages.ARG_NIL_FMT, fmt.Sprintf("candidates[%d]", i)))
+		}
+	}
+
+	values := universe.ToSlice()
+
+	// The positions of the values of the universe that each candidate contains, the candidates
+	// that contain each value, and the number of values each candidate would newly cover.
+	contains := make([][]int, len(candidates))
+	containedBy := make([][]int, len(values))
+	gain := make([]int, len(candidates))
+
+	for v, value := range values {
+		for c, candidate := range candidates {
+			if candidate.Contains(value) {
+				contains[c] = append(contains[c], v)
+				containedBy[v] = append(containedBy[v], c)
+				gain[c]++
+			}
+		}
+	}
+
+	covered := make([]bool, len(values))
+	chosen = []int{}
+
+	for {
+		best := -1
+
+		for c, g := range gain {
+			if g > 0 && (best < 0 || g > gain[best]) {
+				best = c
+			}
+		}
+
+		if best < 0 {
+			break
+		}
+
+		chosen = append(chosen, best)
+
+		for _, v := range contains[best] {
+			if covered[v] {
+				continue
+			}
+
+			covered[v] = true
+
+			for _, c := range containedBy[v] {
+				gain[c]--
+			}
+		}
+	}
+
+	uncovered = []T{}
+
+	for v, value := range values {
+		if !covered[v] {
+			uncovered = append(uncovered, value)
+		}
+	}
+
+	return chosen, uncovered
+}
@@ -0,0 +1,104 @@
+package algorithms
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets"
+)
+
+// SubsetIterator iterates the subsets of a given size of a set.
+type SubsetIterator[T any] struct {
+	set     sets.Set[T]
+	k       int
+	values  []T
+	indices []int
+	local.InternalImpl
+}
+
+// SubsetsOfSize returns an iterator over every subset of s that has exactly k values.
+// Each subset is a new slice of values in the iteration order of s.
+//
+// Subsets are produced in lexicographic order of the positions of their values, so for
+// values a, b, c, d and k = 2, the subsets are ab, ac, ad, bc, bd, cd. A set of n values has
+// n! / (k! (n-k)!) subsets of size k, which are not stored. If k is zero, the only subset is the
+// empty one, and if k is greater than the number of values, there are none.
+//
+// The values of s are read when iteration starts.
+//
+// Panics if s is nil or k is negative.
+func SubsetsOfSize[T any](s sets.Set[T], k int) *SubsetIterator[T] {
+	if s == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "s"))
+	}
+
+	if k < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "k"))
+	}
+
+	return &SubsetIterator[T]{
+		set: s,
+		k:   k,
+	}
+}
+
+// Start reads the values of the set and begins the iteration, returning the first subset,
+// which will be nil if the set has fewer than k values.
+func (i *SubsetIterator[T]) Start() collections.Element[[]T] {
+	values := i.set.ToSlice()
+
+	if i.k > len(values) {
+		i.indices = nil
+		return nil
+	}
+
+	i.values = values
+	i.indices = make([]int, i.k)
+
+	for j := range i.indices {
+		i.indices[j] = j
+	}
+
+	return i.subset()
+}
+
+// Next returns the next subset, which will be nil if the end has been reached.
+func (i *SubsetIterator[T]) Next() collections.Element[[]T] {
+	if i.indices == nil {
+		return nil
+	}
+
+	// Advance the rightmost position that has not reached its last possible value,
+	// and place each position after it immediately after its predecessor.
+	n := len(i.values)
+	j := i.k - 1
+
+	for j >= 0 && i.indices[j] == n-i.k+j {
+		j--
+	}
+
+	if j < 0 {
+		i.indices = nil
+		return nil
+	}
+
+	i.indices[j]++
+
+	for j++; j < i.k; j++ {
+		i.indices[j] = i.indices[j-1] + 1
+	}
+
+	return i.subset()
+}
+
+func (i *SubsetIterator[T]) subset() collections.Element[[]T] {
+	subset := make([]T, i.k)
+
+	for j, index := range i.indices {
+		subset[j] = i.values[index]
+	}
+
+	return &element[[]T]{value: subset}
+}
@@ -0,0 +1,452 @@
+/*
+Package bitset provides a dense set of non-negative integers stored as an array of bits.
+
+Where the values to be stored are small non-negative integers (e.g. indexes or IDs),
+a BitSet uses far less memory than HashSet[int] or OrderedSet[int], and set operations
+between bitsets are performed a machine word at a time.
+*/
+package bitset
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+)
+
+const (
+	wordBits     = 64
+	log2WordBits = 6
+)
+
+// BitSetOptionFunc is the signature of a function
+// for providing options to the BitSet constructor.
+type BitSetOptionFunc func(*BitSet)
+
+// BitSet stores a set of non-negative integers as an array of bits.
+//
+// The set grows as required to accommodate the largest bit that is set.
+type BitSet struct {
+	lock  *sync.RWMutex
+	words []uint64
+}
+
+// New constructs a new, empty BitSet.
+func New(options ...BitSetOptionFunc) *BitSet {
+	b := &BitSet{}
+
+	for _, o := range options {
+		o(b)
+	}
+
+	return b
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe() BitSetOptionFunc {
+	return func(b *BitSet) {
+		b.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function for New to preallocate storage for the given number of bits.
+func WithCapacity(capacity int) BitSetOptionFunc {
+	if capacity < 0 {
+		panic(messages.NEGATIVE_CAPACITY)
+	}
+	return func(b *BitSet) {
+		b.words = make([]uint64, wordsNeeded(capacity))
+	}
+}
+
+// FromCollection constructs a new BitSet containing the values of the given collection.
+//
+// Panics if the collection contains a negative value.
+func FromCollection(collection collections.Collection[int], options ...BitSetOptionFunc) *BitSet {
+	b := New(options...)
+
+	for _, v := range collection.ToSlice() {
+		b.set(v)
+	}
+
+	return b
+}
+
+// Set sets the given bit, adding it to the set.
+//
+// Panics if bit is negative.
+func (b *BitSet) Set(bit int) {
+
+	if b.lock != nil {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+	}
+
+	b.set(bit)
+}
+
+// Clear clears the given bit, removing it from the set.
+//
+// Panics if bit is negative.
+func (b *BitSet) Clear(bit int) {
+
+	if b.lock != nil {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+	}
+
+	validateBit(bit)
+	index := bit >> log2WordBits
+
+	if index < len(b.words) {
+		b.words[index] &^= 1 << (uint(bit) & (wordBits - 1))
+	}
+}
+
+// ClearAll clears all bits, retaining the allocated storage.
+func (b *BitSet) ClearAll() {
+
+	if b.lock != nil {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+	}
+
+	for i := range b.words {
+		b.words[i] = 0
+	}
+}
+
+// Test returns true if the given bit is set.
+//
+// Panics if bit is negative.
+func (b *BitSet) Test(bit int) bool {
+
+	if b.lock != nil {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+	}
+
+	return b.test(bit)
+}
+
+// Count returns the number of bits that are set.
+func (b *BitSet) Count() int {
+
+	if b.lock != nil {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+	}
+
+	return b.count()
+}
+
+// IsEmpty returns true if no bits are set.
+func (b *BitSet) IsEmpty() bool {
+
+	if b.lock != nil {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+	}
+
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NextSetBit returns the index of the first set bit that is greater than or equal to from,
+// or -1 if there is no such bit.
+//
+//	for i := b.NextSetBit(0); i >= 0; i = b.NextSetBit(i + 1) {
+//		// do something with i
+//	}
+//
+// Panics if from is negative.
+func (b *BitSet) NextSetBit(from int) int {
+
+	if b.lock != nil {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+	}
+
+	return b.nextSetBit(from)
+}
+
+// NextClearBit returns the index of the first clear bit that is greater than or equal to from.
+// As the set grows as required, there is always such a bit, which may lie beyond the current storage.
+//
+// Panics if from is negative.
+func (b *BitSet) NextClearBit(from int) int {
+
+	if b.lock != nil {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+	}
+
+	return b.nextClearBit(from)
+}
+
+// Walk calls the action delegate for each set bit in ascending order.
+// If the action delegate returns false, stop the walk.
+//
+// Returns true if all set bits have been visited.
+// Otherwise returns false.
+func (b *BitSet) Walk(action func(int) bool) bool {
+
+	if b.lock != nil {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+	}
+
+	return b.walk(action)
+}
+
+// And returns a new BitSet containing the bits that are set in both this and the other bitset.
+func (b *BitSet) And(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or returns a new BitSet containing the bits that are set in either this or the other bitset.
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor returns a new BitSet containing the bits that are set in exactly one of this and the other bitset.
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot returns a new BitSet containing the bits that are set in this bitset, but not in the other.
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// ToSlice returns the set bits as a slice in ascending order.
+func (b *BitSet) ToSlice() []int {
+
+	if b.lock != nil {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+	}
+
+	slc := make([]int, 0, b.count())
+	b.walk(func(bit int) bool {
+		slc = append(slc, bit)
+		return true
+	})
+
+	return slc
+}
+
+// ToHashSet returns a new HashSet containing the set bits.
+// Options are passed to the HashSet constructor.
+func (b *BitSet) ToHashSet(options ...hashset.HashSetOptionFunc[int]) *hashset.HashSet[int] {
+	s := hashset.New(options...)
+	s.AddRange(b.ToSlice())
+	return s
+}
+
+// String returns a string representation of container.
+func (b *BitSet) String() string {
+
+	var values []string
+	for _, bit := range b.ToSlice() {
+		values = append(values, fmt.Sprintf("%d", bit))
+	}
+
+	return "BitSet\n" + strings.Join(values, ", ")
+}
+
+func (b *BitSet) set(bit int) {
+	validateBit(bit)
+	index := bit >> log2WordBits
+
+	if index >= len(b.words) {
+		b.grow(index + 1)
+	}
+
+	b.words[index] |= 1 << (uint(bit) & (wordBits - 1))
+}
+
+func (b *BitSet) test(bit int) bool {
+	validateBit(bit)
+	index := bit >> log2WordBits
+
+	if index >= len(b.words) {
+		return false
+	}
+
+	return b.words[index]&(1<<(uint(bit)&(wordBits-1))) != 0
+}
+
+func (b *BitSet) count() int {
+	c := 0
+	for _, w := range b.words {
+		c += bits.OnesCount64(w)
+	}
+
+	return c
+}
+
+func (b *BitSet) nextSetBit(from int) int {
+	validateBit(from)
+	index := from >> log2WordBits
+
+	if index >= len(b.words) {
+		return -1
+	}
+
+	// Mask off bits below from in the first word.
+	w := b.words[index] & (^uint64(0) << (uint(from) & (wordBits - 1)))
+
+	for {
+		if w != 0 {
+			return index<<log2WordBits + bits.TrailingZeros64(w)
+		}
+
+		index++
+
+		if index >= len(b.words) {
+			return -1
+		}
+
+		w = b.words[index]
+	}
+}
+
+func (b *BitSet) nextClearBit(from int) int {
+	validateBit(from)
+	index := from >> log2WordBits
+
+	if index >= len(b.words) {
+		return from
+	}
+
+	// Treat bits below from in the first word as set.
+	w := ^b.words[index] & (^uint64(0) << (uint(from) & (wordBits - 1)))
+
+	for {
+		if w != 0 {
+			return index<<log2WordBits + bits.TrailingZeros64(w)
+		}
+
+		index++
+
+		if index >= len(b.words) {
+			return index << log2WordBits
+		}
+
+		w = ^b.words[index]
+	}
+}
+
+func (b *BitSet) walk(action func(int) bool) bool {
+	for index, w := range b.words {
+		for w != 0 {
+			t := bits.TrailingZeros64(w)
+			if !action(index<<log2WordBits + t) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+
+	return true
+}
+
+func (b *BitSet) grow(numWords int) {
+	newLength := len(b.words) * 2
+	if newLength < numWords {
+		newLength = numWords
+	}
+
+	words := make([]uint64, newLength)
+	copy(words, b.words)
+	b.words = words
+}
+
+func (b *BitSet) combine(other *BitSet, op func(x, y uint64) uint64) *BitSet {
+
+	if other == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "other"))
+	}
+
+	// The words of other are copied before this bitset is locked, so that the locks of both
+	// are never held together, which could deadlock with a concurrent other.combine(b).
+	var otherWords []uint64
+
+	if other != b {
+		otherWords = other.copyWords()
+	}
+
+	if b.lock != nil {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+	}
+
+	if other == b {
+		otherWords = b.words
+	}
+
+	length := len(b.words)
+	if len(otherWords) > length {
+		length = len(otherWords)
+	}
+
+	result := b.makeEmptyCopy(length)
+
+	for i := range result.words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(otherWords) {
+			y = otherWords[i]
+		}
+		result.words[i] = op(x, y)
+	}
+
+	return result
+}
+
+// copyWords returns a copy of the words of the bitset, under its lock.
+func (b *BitSet) copyWords() []uint64 {
+
+	if b.lock != nil {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+	}
+
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return words
+}
+
+func (b *BitSet) makeEmptyCopy(numWords int) *BitSet {
+	other := &BitSet{
+		words: make([]uint64, numWords),
+	}
+
+	if b.lock != nil {
+		other.lock = &sync.RWMutex{}
+	}
+
+	return other
+}
+
+func wordsNeeded(numBits int) int {
+	return (numBits + wordBits - 1) >> log2WordBits
+}
+
+func validateBit(bit int) {
+	if bit < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "bit"))
+	}
+}
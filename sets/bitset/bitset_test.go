@@ -0,0 +1,296 @@
+package bitset
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructor(t *testing.T) {
+
+	t.Run("With capacity", func(t *testing.T) {
+		b := New(WithCapacity(130))
+		require.Equal(t, 3, len(b.words))
+		require.True(t, b.IsEmpty())
+	})
+
+	t.Run("With negative capacity panics", func(t *testing.T) {
+		require.Panics(t, func() { New(WithCapacity(-1)) })
+	})
+}
+
+func TestSetClearTest(t *testing.T) {
+
+	bitsToSet := []int{0, 1, 63, 64, 65, 127, 128, 1000}
+
+	t.Run("Set bits are set", func(t *testing.T) {
+		b := New()
+
+		for _, bit := range bitsToSet {
+			b.Set(bit)
+		}
+
+		for _, bit := range bitsToSet {
+			require.True(t, b.Test(bit))
+		}
+
+		require.False(t, b.Test(2))
+		require.False(t, b.Test(100000))
+		require.Equal(t, len(bitsToSet), b.Count())
+	})
+
+	t.Run("Cleared bits are not set", func(t *testing.T) {
+		b := New()
+
+		for _, bit := range bitsToSet {
+			b.Set(bit)
+		}
+
+		b.Clear(64)
+		b.Clear(100000)
+		require.False(t, b.Test(64))
+		require.Equal(t, len(bitsToSet)-1, b.Count())
+	})
+
+	t.Run("ClearAll clears all bits", func(t *testing.T) {
+		b := New()
+
+		for _, bit := range bitsToSet {
+			b.Set(bit)
+		}
+
+		b.ClearAll()
+		require.True(t, b.IsEmpty())
+		require.Equal(t, 0, b.Count())
+	})
+
+	t.Run("Negative bit panics", func(t *testing.T) {
+		b := New()
+		require.Panics(t, func() { b.Set(-1) })
+		require.Panics(t, func() { b.Clear(-1) })
+		require.Panics(t, func() { b.Test(-1) })
+		require.Panics(t, func() { b.NextSetBit(-1) })
+	})
+}
+
+func TestNextSetBit(t *testing.T) {
+
+	bitsToSet := []int{3, 63, 64, 200}
+	b := New()
+
+	for _, bit := range bitsToSet {
+		b.Set(bit)
+	}
+
+	t.Run("Iterate with NextSetBit", func(t *testing.T) {
+		found := make([]int, 0, len(bitsToSet))
+
+		for i := b.NextSetBit(0); i >= 0; i = b.NextSetBit(i + 1) {
+			found = append(found, i)
+		}
+
+		require.Equal(t, bitsToSet, found)
+	})
+
+	t.Run("NextSetBit beyond last bit is -1", func(t *testing.T) {
+		require.Equal(t, -1, b.NextSetBit(201))
+		require.Equal(t, -1, b.NextSetBit(100000))
+	})
+
+	t.Run("NextSetBit on set bit returns that bit", func(t *testing.T) {
+		require.Equal(t, 64, b.NextSetBit(64))
+	})
+}
+
+func TestNextClearBit(t *testing.T) {
+
+	b := New()
+
+	for bit := 0; bit < 130; bit++ {
+		if bit != 70 {
+			b.Set(bit)
+		}
+	}
+
+	t.Run("NextClearBit finds gap", func(t *testing.T) {
+		require.Equal(t, 70, b.NextClearBit(0))
+		require.Equal(t, 70, b.NextClearBit(70))
+	})
+
+	t.Run("NextClearBit beyond gap finds end of set bits", func(t *testing.T) {
+		require.Equal(t, 130, b.NextClearBit(71))
+	})
+
+	t.Run("NextClearBit beyond storage returns from", func(t *testing.T) {
+		require.Equal(t, 100000, b.NextClearBit(100000))
+	})
+
+	t.Run("NextClearBit of full words is start of next word", func(t *testing.T) {
+		full := New()
+		for bit := 0; bit < 128; bit++ {
+			full.Set(bit)
+		}
+		require.Equal(t, 128, full.NextClearBit(5))
+	})
+
+	t.Run("Negative from panics", func(t *testing.T) {
+		require.Panics(t, func() { b.NextClearBit(-1) })
+	})
+}
+
+func TestWalk(t *testing.T) {
+
+	b := New()
+	b.Set(1)
+	b.Set(70)
+	b.Set(140)
+
+	t.Run("Walk visits all bits", func(t *testing.T) {
+		visited := []int{}
+		require.True(t, b.Walk(func(bit int) bool {
+			visited = append(visited, bit)
+			return true
+		}))
+		require.Equal(t, []int{1, 70, 140}, visited)
+	})
+
+	t.Run("Walk terminates early", func(t *testing.T) {
+		visited := []int{}
+		require.False(t, b.Walk(func(bit int) bool {
+			visited = append(visited, bit)
+			return bit < 70
+		}))
+		require.Equal(t, []int{1, 70}, visited)
+	})
+}
+
+func TestSetOperations(t *testing.T) {
+
+	b1 := New()
+	b2 := New()
+
+	for _, bit := range []int{1, 2, 3, 100} {
+		b1.Set(bit)
+	}
+
+	for _, bit := range []int{2, 3, 4, 300} {
+		b2.Set(bit)
+	}
+
+	t.Run("And", func(t *testing.T) {
+		require.Equal(t, []int{2, 3}, b1.And(b2).ToSlice())
+	})
+
+	t.Run("Or", func(t *testing.T) {
+		require.Equal(t, []int{1, 2, 3, 4, 100, 300}, b1.Or(b2).ToSlice())
+	})
+
+	t.Run("Xor", func(t *testing.T) {
+		require.Equal(t, []int{1, 4, 100, 300}, b1.Xor(b2).ToSlice())
+	})
+
+	t.Run("AndNot", func(t *testing.T) {
+		require.Equal(t, []int{1, 100}, b1.AndNot(b2).ToSlice())
+		require.Equal(t, []int{4, 300}, b2.AndNot(b1).ToSlice())
+	})
+
+	t.Run("Operation with self", func(t *testing.T) {
+		require.Equal(t, b1.ToSlice(), b1.And(b1).ToSlice())
+		require.True(t, b1.Xor(b1).IsEmpty())
+	})
+
+	t.Run("Operation with nil panics", func(t *testing.T) {
+		require.Panics(t, func() { b1.And(nil) })
+	})
+}
+
+func TestHashSetConversion(t *testing.T) {
+
+	seed := int64(2163)
+	values := util.CreateSingleIntListData(100, &seed)
+
+	for i := range values {
+		values[i] &= 0xffff
+	}
+
+	t.Run("From HashSet", func(t *testing.T) {
+		s := hashset.New[int]()
+		s.AddRange(values)
+		b := FromCollection(s)
+		expected := s.ToSlice()
+		sort.Ints(expected)
+		require.Equal(t, expected, b.ToSlice())
+	})
+
+	t.Run("To HashSet", func(t *testing.T) {
+		b := New()
+
+		for _, v := range values {
+			b.Set(v)
+		}
+
+		s := b.ToHashSet()
+		require.Equal(t, b.Count(), s.Count())
+		require.ElementsMatch(t, b.ToSlice(), s.ToSlice())
+	})
+
+	t.Run("From collection with negative value panics", func(t *testing.T) {
+		s := hashset.New[int]()
+		s.Add(-1)
+		require.Panics(t, func() { FromCollection(s) })
+	})
+}
+
+func TestThreadSafety(t *testing.T) {
+
+	t.Run("Parallel Set", func(t *testing.T) {
+		b := New(WithThreadSafe())
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+
+		setFunc := func(start int) {
+			for i := start; i < 4096; i += 2 {
+				b.Set(i)
+			}
+			wg.Done()
+		}
+
+		go setFunc(0)
+		go setFunc(1)
+		wg.Wait()
+		require.Equal(t, 4096, b.Count())
+	})
+
+	t.Run("Argument is not locked while waiting for receiver", func(t *testing.T) {
+		b1 := New(WithThreadSafe())
+		b2 := New(WithThreadSafe())
+		b1.Set(1)
+		b2.Set(2)
+
+		// Were b1 still read-locked while b2.Or(b1) waits for b2,
+		// a writer waiting for b1 would deadlock with a concurrent b1.Or(b2).
+		b2.lock.Lock()
+		result := make(chan *BitSet)
+
+		go func() {
+			result <- b2.Or(b1)
+		}()
+
+		require.Eventually(t, func() bool {
+			if !b1.lock.TryLock() {
+				return false
+			}
+
+			b1.lock.Unlock()
+			return true
+		}, time.Second, time.Millisecond)
+
+		b2.lock.Unlock()
+		require.Equal(t, []int{1, 2}, (<-result).ToSlice())
+	})
+}
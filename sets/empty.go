@@ -0,0 +1,118 @@
+package sets
+
+import (
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Assert emptySet implements required interfaces.
+var _ Set[int] = (*emptySet[int])(nil)
+
+// emptySet is an immutable set with no values.
+type emptySet[T any] struct {
+	// version and lock are never set, but are laid out as in every other
+	// collection as they are read by the internal element and lock helpers.
+	version int
+	lock    *sync.RWMutex
+
+	collections.Collection[T]
+}
+
+// emptySnapshot is the snapshot of an empty set.
+type emptySnapshot[T any] struct {
+	local.InternalImpl
+}
+
+// Empty returns an immutable set with no values, that may be returned instead of nil
+// by functions returning a Set, so that callers need not check for nil.
+//
+// Methods that read the set behave as those of any other empty set, and methods that would
+// modify it panic, as described for [collections.Empty]. Union returns a copy of the other set,
+// while Difference and Intersection, as well as Map, Select, Clone and the like, return the empty set itself.
+//
+// There is one empty set for each type of value, so the result may be compared with ==.
+func Empty[T any]() Set[T] {
+	return local.Singleton(func() *emptySet[T] {
+		return &emptySet[T]{Collection: collections.Empty[T]()}
+	})
+}
+
+// Get returns nil.
+func (*emptySet[T]) Get(T) collections.Element[T] {
+	return nil
+}
+
+// Difference returns this set.
+func (s *emptySet[T]) Difference(Set[T]) Set[T] {
+	return s
+}
+
+// Intersection returns this set.
+func (s *emptySet[T]) Intersection(Set[T]) Set[T] {
+	return s
+}
+
+// Union returns a shallow copy of the other set.
+func (*emptySet[T]) Union(other Set[T]) Set[T] {
+	return other.Clone().(Set[T])
+}
+
+// UnlockedContains returns false.
+func (*emptySet[T]) UnlockedContains(T) bool {
+	return false
+}
+
+// Snapshot returns a snapshot with no values.
+func (*emptySet[T]) Snapshot() Snapshot[T] {
+	return local.Singleton(func() *emptySnapshot[T] { return &emptySnapshot[T]{} })
+}
+
+// Map returns this set.
+func (s *emptySet[T]) Map(func(T) T) collections.Collection[T] {
+	return s
+}
+
+// Select returns this set.
+func (s *emptySet[T]) Select(functions.PredicateFunc[T]) collections.Collection[T] {
+	return s
+}
+
+// SelectDeep returns this set.
+func (s *emptySet[T]) SelectDeep(functions.PredicateFunc[T]) collections.Collection[T] {
+	return s
+}
+
+// Clone returns this set.
+func (s *emptySet[T]) Clone() collections.Collection[T] {
+	return s
+}
+
+// CloneDeep returns this set.
+func (s *emptySet[T]) CloneDeep() collections.Collection[T] {
+	return s
+}
+
+// Count returns zero.
+func (*emptySnapshot[T]) Count() int {
+	return 0
+}
+
+// ToSlice returns an empty slice.
+func (*emptySnapshot[T]) ToSlice() []T {
+	return []T{}
+}
+
+// Diff returns no values if the later snapshot was also taken from an empty set.
+//
+// Panics if the later snapshot was not taken from an empty set.
+func (*emptySnapshot[T]) Diff(later Snapshot[T]) (added, removed []T) {
+	if _, ok := later.(*emptySnapshot[T]); !ok {
+		panic(messages.SNAPSHOT_TYPE_MISMATCH)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,133 @@
+package hashset
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// CloneInto replaces the content of dst with a deep copy of this set's values,
+// using the provided [functions.DeepCopyFunc] if any.
+//
+// The hash table of dst is reused, and the copied values are stored in a single allocation
+// shared by all buckets rather than one allocation per bucket. Buckets of dst are not reused
+// as they may be shared with snapshots. The hasher, comparer, deep copy function and copy policy of
+// this set are copied to dst; its thread safety is not changed.
+//
+// The values are copied under the lock of this set, which is released before dst is locked,
+// so that a concurrent dst.CloneInto(s) cannot deadlock.
+//
+// Panics if dst is nil.
+func (s *HashSet[T]) CloneInto(dst *HashSet[T]) {
+
+	if dst == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dst"))
+	}
+
+	if dst == s {
+		return
+	}
+
+	src, values, spans := s.cloneValues()
+
+	if dst.lock != nil {
+		dst.lock.Lock()
+		defer dst.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer dst.guard.Write(dst.lock != nil, dst.verifyInvariants)()
+	}
+
+	dst.lazyInit()
+
+	// The compiler recognises this as clearing the map, which retains its storage.
+	for hash := range dst.buffer {
+		delete(dst.buffer, hash)
+	}
+
+	start := 0
+
+	for _, span := range spans {
+		// Clip capacity so that appending to one bucket cannot overwrite the next.
+		dst.buffer[span.hash] = values[start:span.end:span.end]
+		start = span.end
+	}
+
+	dst.size = len(values)
+
+	if dst.size > dst.peak {
+		dst.peak = dst.size
+	}
+
+	dst.collisionCount = src.collisionCount
+	dst.bucketCapacity = src.bucketCapacity
+	dst.hasher = src.hasher
+	dst.compare = src.compare
+	dst.copy = src.copy
+	dst.copyPolicy = src.copyPolicy
+	dst.version++
+	dst.recorder.Reset(func() []T { return dst.toSlice(false) })
+}
+
+// bucketSpan locates the values of a bucket copied by cloneValues.
+type bucketSpan struct {
+	hash uintptr
+	end  int
+}
+
+// cloneValues returns the settings of the set, and a deep copy of its values in a single slice
+// with the hash and end of each bucket within it, under the lock of the set.
+func (s *HashSet[T]) cloneValues() (HashSet[T], []T, []bucketSpan) {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	values := make([]T, s.size)
+	spans := make([]bucketSpan, 0, len(s.buffer))
+	index := 0
+
+	for hash, bucket := range s.buffer {
+		if len(bucket) == 0 {
+			continue
+		}
+
+		end := index + len(bucket)
+		util.DeepCopySlice(values[index:end], bucket, s.copy)
+		spans = append(spans, bucketSpan{hash, end})
+		index = end
+	}
+
+	src := HashSet[T]{
+		collisionCount: s.collisionCount,
+		bucketCapacity: s.bucketCapacity,
+		hasher:         s.hasher,
+		compare:        s.compare,
+		copy:           s.copy,
+		copyPolicy:     s.copyPolicy,
+	}
+
+	return src, values, spans
+}
+
+// Clone returns a new HashSet holding the values of this set, copied by value.
+// The result is that of Select with a predicate that is always true.
+func (s *HashSet[T]) Clone() collections.Collection[T] {
+
+	return s.Select(util.DefaultPredicate[T])
+}
+
+// CloneDeep returns a new HashSet holding the values of this set, copied using the provided
+// [functions.DeepCopyFunc] if any. The result is that of SelectDeep with a predicate that is always true.
+func (s *HashSet[T]) CloneDeep() collections.Collection[T] {
+
+	return s.SelectDeep(util.DefaultPredicate[T])
+}
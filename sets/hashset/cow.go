@@ -0,0 +1,107 @@
+package hashset
+
+import (
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/sets"
+)
+
+// Assert CopyOnWrite implements required interfaces.
+var _ sets.Set[int] = (*CopyOnWrite[int])(nil)
+
+// CopyOnWrite is a thread-safe hash set for sets that are read far more often than they are written,
+// such as allow lists or feature flags.
+//
+// Each write is applied to a private working set, after which an immutable snapshot of its values
+// is published atomically. Reads use the latest snapshot without taking a lock, so they never wait
+// for a writer, however long the write. Contains and Get remain O(1) as the snapshot includes a copy
+// of the hash table. Publishing a snapshot copies the set, so each write is O(n).
+// Use [CopyOnWrite.Update] to make several modifications for the cost of one.
+//
+// Elements yielded by iterators, Find and Get belong to a snapshot, so are never invalidated by modification,
+// and their ValuePtr method panics.
+type CopyOnWrite[T any] struct {
+	// version and lock are never set, but are laid out as in every other
+	// collection as they are read by the internal element and lock helpers.
+	version int
+	lock    *sync.RWMutex
+
+	*util.CowCollection[T, *HashSet[T]]
+}
+
+// NewCopyOnWrite constructs a copy-on-write set, whose working set is constructed with the given options.
+// WithThreadSafe is implied.
+func NewCopyOnWrite[T any](options ...HashSetOptionFunc[T]) *CopyOnWrite[T] {
+	return newCopyOnWrite(New(append(options[:len(options):len(options)], WithThreadSafe[T]())...))
+}
+
+func newCopyOnWrite[T any](work *HashSet[T]) *CopyOnWrite[T] {
+	hasher, compare, deepCopy, copyPolicy, bucketCapacity := work.hasher, work.compare, work.copy, work.copyPolicy, work.bucketCapacity
+
+	derive := func(values []T) collections.Collection[T] {
+		s := New(WithHasher(hasher), WithComparer(compare), WithDeepCopy(deepCopy), WithCopyPolicy[T](copyPolicy),
+			WithHashBucketCapacity[T](bucketCapacity), WithThreadSafe[T]())
+		s.AddRange(values)
+		return newCopyOnWrite(s)
+	}
+
+	return &CopyOnWrite[T]{
+		CowCollection: util.NewCowCollection(work, compare, deepCopy, derive, index[T]),
+	}
+}
+
+// index returns a function that looks up values in a copy of the working set.
+func index[T any](work *HashSet[T]) func(T) (T, bool) {
+	frozen := work.Clone().(*HashSet[T])
+
+	return func(value T) (T, bool) {
+		if frozen.size > 0 {
+			hash := frozen.hasher(value)
+
+			if i := frozen.contains(hash, value); i >= 0 {
+				return frozen.buffer[hash][i], true
+			}
+		}
+
+		var zero T
+		return zero, false
+	}
+}
+
+// UnlockedContains returns true if the value is present in the latest snapshot,
+// which is the same as Contains, as reads never take a lock.
+func (s *CopyOnWrite[T]) UnlockedContains(value T) bool {
+	return s.Contains(value)
+}
+
+// Union returns a new copy-on-write set of the values of the latest snapshot and those of the other set.
+func (s *CopyOnWrite[T]) Union(other sets.Set[T]) sets.Set[T] {
+	union := s.Clone().(*CopyOnWrite[T])
+	union.AddCollection(other)
+	return union
+}
+
+// Intersection returns a new copy-on-write set of the values of the latest snapshot that are also in the other set.
+func (s *CopyOnWrite[T]) Intersection(other sets.Set[T]) sets.Set[T] {
+	return s.Select(other.Contains).(*CopyOnWrite[T])
+}
+
+// Difference returns a new copy-on-write set of the values of the latest snapshot that are not in the other set.
+func (s *CopyOnWrite[T]) Difference(other sets.Set[T]) sets.Set[T] {
+	return s.Select(func(value T) bool { return !other.Contains(value) }).(*CopyOnWrite[T])
+}
+
+// Snapshot returns an immutable record of the values currently in the set,
+// for later comparison with [sets.DiffSnapshots]. It may be compared with snapshots of any HashSet.
+func (s *CopyOnWrite[T]) Snapshot() sets.Snapshot[T] {
+	var snap sets.Snapshot[T]
+	s.Update(func(w *HashSet[T]) { snap = w.Snapshot() })
+	return snap
+}
+
+// Dispose removes the working set from the [registry] if it was created with [WithName].
+func (s *CopyOnWrite[T]) Dispose() {
+	s.Update(func(w *HashSet[T]) { w.Dispose() })
+}
@@ -0,0 +1,111 @@
+package hashset
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyOnWriteConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	hasher := func(v *int) uintptr { return uintptr(*v) }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return NewCopyOnWrite(WithComparer(compare), WithDeepCopy(deepCopy), WithHasher(hasher))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return NewCopyOnWrite(WithComparer(compare), WithDeepCopy(deepCopy), WithHasher(hasher), WithValidator(validator, policy))
+		},
+		SnapshotElements: true,
+	})
+}
+
+func TestCopyOnWrite(t *testing.T) {
+
+	t.Run("Get reads the snapshot", func(t *testing.T) {
+		s := NewCopyOnWrite[int]()
+		s.AddRange([]int{1, 2, 3})
+		e := s.Get(2)
+		require.NotNil(t, e)
+		require.Equal(t, 2, e.Value())
+		require.PanicsWithValue(t, messages.SNAPSHOT_POINTER, func() { e.ValuePtr() })
+
+		s.Remove(2)
+		require.Nil(t, s.Get(2))
+		require.False(t, s.Contains(2))
+		require.True(t, e.IsValid())
+		require.Equal(t, 2, e.Value())
+	})
+
+	t.Run("Set operations", func(t *testing.T) {
+		s := NewCopyOnWrite[int]()
+		s.AddRange([]int{1, 2, 3})
+		other := Of(2, 3, 4)
+
+		require.IsType(t, s, s.Union(other))
+		require.ElementsMatch(t, []int{1, 2, 3, 4}, s.Union(other).ToSlice())
+		require.ElementsMatch(t, []int{2, 3}, s.Intersection(other).ToSlice())
+		require.ElementsMatch(t, []int{1}, s.Difference(other).ToSlice())
+		require.ElementsMatch(t, []int{1, 2, 3, 4}, other.Union(s).ToSlice())
+		require.ElementsMatch(t, []int{2, 3}, other.Intersection(s).ToSlice())
+		require.ElementsMatch(t, []int{4}, other.Difference(s).ToSlice())
+		require.ElementsMatch(t, []int{1, 2, 3}, s.ToSlice(), "set operations should not modify the set")
+	})
+
+	t.Run("Snapshots", func(t *testing.T) {
+		s := NewCopyOnWrite[int]()
+		s.AddRange([]int{1, 2})
+		snap := s.Snapshot()
+		s.Update(func(w *HashSet[int]) {
+			w.Remove(1)
+			w.Add(3)
+		})
+
+		added, removed := sets.DiffSnapshots(snap, s.Snapshot())
+		require.Equal(t, []int{3}, added)
+		require.Equal(t, []int{1}, removed)
+	})
+
+	t.Run("Reads are concurrent with writes", func(t *testing.T) {
+		s := NewCopyOnWrite[int]()
+		var wg sync.WaitGroup
+
+		for w := 0; w < 4; w++ {
+			w := w
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					s.Add(w*100 + i)
+				}
+			}()
+		}
+
+		for r := 0; r < 4; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					if e := s.Get(i); e != nil {
+						require.Equal(t, i, e.Value())
+					}
+					require.LessOrEqual(t, len(s.ToImmutableSlice()), 400)
+				}
+			}()
+		}
+
+		wg.Wait()
+		require.Equal(t, 400, s.Count())
+	})
+}
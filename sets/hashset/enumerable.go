@@ -1,6 +1,8 @@
 package hashset
 
 import (
+	"errors"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/messages"
@@ -19,6 +21,12 @@ func (s *HashSet[T]) Any(predicate functions.PredicateFunc[T]) bool {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, predicate)
 
 	return iter.Start() != nil
@@ -33,6 +41,12 @@ func (s *HashSet[T]) All(predicate functions.PredicateFunc[T]) bool {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -52,6 +66,12 @@ func (s *HashSet[T]) ForEach(f func(collections.Element[T])) {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -59,6 +79,59 @@ func (s *HashSet[T]) ForEach(f func(collections.Element[T])) {
 	}
 }
 
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (s *HashSet[T]) TryForEach(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (s *HashSet[T]) TryForEachAll(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	var errs []error
+	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Map applies function f to all elements in the collection
 // and returns a new HashSet containing the result of f.
 func (s *HashSet[T]) Map(f func(T) T) collections.Collection[T] {
@@ -68,9 +141,15 @@ func (s *HashSet[T]) Map(f func(T) T) collections.Collection[T] {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
 
-	s1 := New[T](WithCapacity[T](len(s.buffer)), WithHashBucketCapacity[T](s.bucketCapacity), WithComparer[T](s.compare))
+	s1 := New[T](WithCapacity[T](len(s.buffer)), WithHashBucketCapacity[T](s.bucketCapacity), WithHasher[T](s.hasher), WithComparer[T](s.compare))
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
 		s1.add(f(e.Value()))
@@ -87,6 +166,12 @@ func (s *HashSet[T]) Select(predicate functions.PredicateFunc[T]) collections.Co
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	return s.doSelect(predicate, false)
 }
 
@@ -100,9 +185,23 @@ func (s *HashSet[T]) SelectDeep(predicate functions.PredicateFunc[T]) collection
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	return s.doSelect(predicate, true)
 }
 
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (s *HashSet[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(s.copyWhere(predicate))
+}
+
 // Find finds the first occurrence of an element matching the predicate.
 //
 // The function returns nil if no match.
@@ -113,6 +212,10 @@ func (s *HashSet[T]) Find(predicate functions.PredicateFunc[T]) collections.Elem
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.find(predicate, false)
 
 	if len(result) == 0 {
@@ -132,6 +235,10 @@ func (s *HashSet[T]) FindAll(predicate functions.PredicateFunc[T]) []collections
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.find(predicate, true)
 
 	return result
@@ -145,6 +252,10 @@ func (s *HashSet[T]) Min() T {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	if s.size == 0 {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -175,6 +286,10 @@ func (s *HashSet[T]) Max() T {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	if s.size == 0 {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -215,7 +330,7 @@ func (s *HashSet[T]) find(predicate functions.PredicateFunc[T], all bool) []coll
 }
 
 func (s *HashSet[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
-	s1 := New[T](WithCapacity[T](len(s.buffer)), WithHashBucketCapacity[T](s.bucketCapacity), WithComparer[T](s.compare))
+	s1 := New[T](WithCapacity[T](len(s.buffer)), WithHashBucketCapacity[T](s.bucketCapacity), WithHasher[T](s.hasher), WithComparer[T](s.compare), WithDeepCopy(s.copy), WithCopyPolicy[T](s.copyPolicy))
 	iter := newForwardIterator[T](s, predicate)
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -228,3 +343,24 @@ func (s *HashSet[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy boo
 
 	return s1
 }
+
+func (s *HashSet[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+	iter := newForwardIterator[T](s, predicate)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, util.DeepCopy(e.Value(), s.copy))
+	}
+
+	return values
+}
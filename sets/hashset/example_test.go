@@ -0,0 +1,72 @@
+package hashset_test
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fireflycons/generic_collections/sets/hashset"
+)
+
+func Example() {
+	s := hashset.Of("apple", "banana", "apple")
+
+	fmt.Println(s.Count(), s.Contains("banana"), s.Contains("cherry"))
+	fmt.Println(s.Add("cherry"), s.Add("apple"))
+	// Output:
+	// 2 true false
+	// true false
+}
+
+// Set algebra returns a new set. Hash sets are unordered, so the values are sorted for display.
+func Example_setAlgebra() {
+	a := hashset.Of(1, 2, 3, 4)
+	b := hashset.Of(3, 4, 5)
+
+	sorted := func(values []int) []int {
+		sort.Ints(values)
+		return values
+	}
+
+	fmt.Println(sorted(a.Union(b).ToSlice()))
+	fmt.Println(sorted(a.Intersection(b).ToSlice()))
+	fmt.Println(sorted(a.Difference(b).ToSlice()))
+	// Output:
+	// [1 2 3 4 5]
+	// [3 4]
+	// [1 2]
+}
+
+func ExampleHashSet_Iterator() {
+	s := hashset.Of(1, 2, 3)
+	iter := s.Iterator()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Println(e.Value())
+	}
+	// Unordered output:
+	// 1
+	// 2
+	// 3
+}
+
+func ExampleWithHasher() {
+	type point struct{ x, y int }
+
+	s := hashset.New(
+		hashset.WithComparer(func(a, b point) int {
+			if a.x != b.x {
+				return a.x - b.x
+			}
+			return a.y - b.y
+		}),
+		hashset.WithHasher(func(p point) uintptr { return uintptr(p.x*31 + p.y) }),
+	)
+
+	s.Add(point{1, 2})
+	s.Add(point{1, 2})
+	s.Add(point{2, 1})
+
+	fmt.Println(s.Count())
+	// Output:
+	// 2
+}
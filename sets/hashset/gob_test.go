@@ -0,0 +1,47 @@
+package hashset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGob(t *testing.T) {
+
+	t.Run("Round trip preserves values", func(t *testing.T) {
+		s := Of(3, 1, 2)
+
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(s))
+
+		decoded := New[int]()
+		require.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+		require.ElementsMatch(t, s.ToSlice(), decoded.ToSlice())
+	})
+
+	t.Run("Decodes into a zero value field", func(t *testing.T) {
+		type message struct {
+			Values *HashSet[string]
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(message{Values: Of("a", "b")}))
+
+		var decoded message
+		require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+		require.Equal(t, 2, decoded.Values.Count())
+		require.True(t, decoded.Values.Contains("b"))
+	})
+
+	t.Run("Decoding replaces values", func(t *testing.T) {
+		data, err := Of(1, 2).GobEncode()
+		require.NoError(t, err)
+
+		s := Of(7, 8, 9)
+		require.NoError(t, s.GobDecode(data))
+		require.Equal(t, 2, s.Count())
+		require.False(t, s.Contains(7))
+	})
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/fireflycons/generic_collections/internal/local"
 	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
 	"github.com/fireflycons/generic_collections/sets"
+	"golang.org/x/exp/constraints"
 )
 
 // Assert HashSet implements required interfaces.
@@ -29,6 +31,8 @@ const defaultBucketCapacity = 2
 type HashSetOptionFunc[T any] func(*HashSet[T])
 
 // HashSet stores an unordered collection of unique elements.
+//
+// The zero value is an empty set with default options, ready to use. It is not thread-safe.
 type HashSet[T any] struct {
 	version        int
 	lock           *sync.RWMutex
@@ -36,11 +40,22 @@ type HashSet[T any] struct {
 	collisionCount int
 	size           int
 	capacity       int
+	peak           int
+	autoShrink     bool
 	hasher         func(T) uintptr
 	compare        functions.ComparerFunc[T]
 	copy           functions.DeepCopyFunc[T]
+	copyPolicy     collections.CopyPolicy
 	buffer         map[uintptr][]T
 	concurrent     bool
+	recorder       util.OpRecorder[T]
+	guard          util.AccessGuard
+	immutable      util.SliceCache[T]
+	validator      *util.Validator[T]
+	verifySchema   bool
+	lastSnapshot   *snapshot[T]
+	name           string
+	registration   *registry.Registration
 	local.InternalImpl
 }
 
@@ -57,7 +72,7 @@ func New[T any](options ...HashSetOptionFunc[T]) *HashSet[T] {
 		s.setDefaultHasher()
 	}
 
-	if s.copy == nil {
+	if s.copy == nil || s.copyPolicy == collections.Shallow {
 		s.copy = util.DefaultDeepCopy[T]
 	}
 
@@ -74,9 +89,68 @@ func New[T any](options ...HashSetOptionFunc[T]) *HashSet[T] {
 		s.bucketCapacity = defaultBucketCapacity
 	}
 
+	s.register()
+
+	return s
+}
+
+// lazyInit completes the construction of a zero value HashSet on its first modification,
+// as New would have done.
+func (s *HashSet[T]) lazyInit() {
+	if s.compare != nil {
+		return
+	}
+
+	s.compare = util.GetZeroValueComparer[T]()
+
+	if s.hasher == nil {
+		s.setDefaultHasher()
+	}
+
+	if s.copy == nil {
+		s.copy = util.DefaultDeepCopy[T]
+	}
+
+	if s.buffer == nil {
+		s.buffer = make(map[uintptr][]T, util.DefaultCapacity)
+		s.capacity = util.DefaultCapacity
+	}
+
+	if s.bucketCapacity == 0 {
+		s.bucketCapacity = defaultBucketCapacity
+	}
+}
+
+// Of constructs a new set containing the given values, e.g. hashset.Of(1, 2, 3).
+//
+// Duplicate values are added once.
+func Of[T any](values ...T) *HashSet[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	s := hashset.OfWith([]hashset.HashSetOptionFunc[int]{hashset.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []HashSetOptionFunc[T], values ...T) *HashSet[T] {
+	s := New(options...)
+	s.AddRange(values)
 	return s
 }
 
+// NewFunc is as [New], using compare to match values within a hash bucket.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...HashSetOptionFunc[T]) *HashSet[T] {
+	return New(append([]HashSetOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...HashSetOptionFunc[T]) *HashSet[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
 // Option function for New to make the collection thread-safe. Adds overhead.
 func WithThreadSafe[T any]() HashSetOptionFunc[T] {
 	return func(s *HashSet[T]) {
@@ -125,6 +199,13 @@ func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) HashSetOptionFunc[T]
 	}
 }
 
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) HashSetOptionFunc[T] {
+	return func(s *HashSet[T]) {
+		s.copyPolicy = policy
+	}
+}
+
 // Option function for NewSet to set the initial hash bucket capacity associated with a new hash key.
 // The default capacity is 2, which should be sufficient for the default hashing algorithms.
 func WithHashBucketCapacity[T any](bucketCapacity int) HashSetOptionFunc[T] {
@@ -136,6 +217,18 @@ func WithHashBucketCapacity[T any](bucketCapacity int) HashSetOptionFunc[T] {
 	}
 }
 
+// Option function for NewSet to return memory to the runtime as values are removed.
+//
+// Go maps never release their storage as keys are deleted. With this option, once the
+// number of values falls to a quarter of the highest count reached since the hash table
+// was last sized, the table is rebuilt with room for twice the remaining values. The gap
+// between the two thresholds prevents a set whose size oscillates from rebuilding repeatedly.
+func WithAutoShrink[T any]() HashSetOptionFunc[T] {
+	return func(s *HashSet[T]) {
+		s.autoShrink = true
+	}
+}
+
 // Option function for NewSet to set initial key capacity to
 // something other than the default 16 elements. If you have
 // some idea of hom many elements you will be storing, addition
@@ -150,11 +243,65 @@ func WithCapacity[T any](capacity int) HashSetOptionFunc[T] {
 	}
 }
 
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+func WithOptions[T any](options collections.CommonOptions[T]) HashSetOptionFunc[T] {
+	opts := make([]HashSetOptionFunc[T], 0, 6)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Concurrent {
+		opts = append(opts, WithConcurrent[T]())
+	}
+
+	if options.Capacity != 0 {
+		opts = append(opts, WithCapacity[T](options.Capacity))
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(s *HashSet[T]) {
+		for _, o := range opts {
+			o(s)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection
+// and ReplaceAll with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) HashSetOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(s *HashSet[T]) {
+		s.validator = util.NewValidator(validator, policy)
+	}
+}
+
 // AddCollection inserts the values of the given collection into this set.
 // Values are added in the order defined by the other collection.
 func (s *HashSet[T]) AddCollection(collection collections.Collection[T]) {
 
-	s.AddRange(collection.ToSliceDeep())
+	s.AddRange(util.ImportValues(collection, s.copyPolicy))
 }
 
 // Clear removes all values from the set, restoring it to its initial capacity.
@@ -165,13 +312,56 @@ func (s *HashSet[T]) Clear() {
 		defer s.lock.Unlock()
 	}
 
-	s.buffer = make(map[uintptr][]T, max(s.bucketCapacity, util.DefaultCapacity))
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.clear()
+}
+
+// ReplaceAll replaces the content of the set with the values of the given collection.
+//
+// The existing hash table is retained to receive the new values.
+func (s *HashSet[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	s.replaceAll(util.ImportValues(collection, s.copyPolicy))
+}
+
+// replaceAll replaces the values of the set with the given values under a single lock.
+func (s *HashSet[T]) replaceAll(values []T) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	values = s.validator.Filter(values)
+
+	for key := range s.buffer {
+		delete(s.buffer, key)
+	}
+
 	s.size = 0
+	s.collisionCount = 0
+	s.recorder.Clear()
+
+	for _, v := range values {
+		s.add(v)
+	}
+
+	s.shrinkIfSparse()
 	s.version++
 }
 
 // Add adds a value into the set. Returns true if the value was added;
-// else false if the value already exists in the set.
+// else false if the value already exists in the set or was rejected by the validator.
 func (s *HashSet[T]) Add(value T) bool {
 
 	if s.lock != nil {
@@ -179,6 +369,16 @@ func (s *HashSet[T]) Add(value T) bool {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if !s.validator.Accept(value) {
+		return false
+	}
+
 	s.version++
 	return s.add(value)
 }
@@ -195,6 +395,16 @@ func (s *HashSet[T]) AddRange(values []T) {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if values = s.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
 	for _, v := range values {
 		s.add(v)
 	}
@@ -218,21 +428,42 @@ func (s *HashSet[T]) Contains(value T) bool {
 		defer s.lock.RUnlock()
 	}
 
-	return s.contains(s.hasher(value), value) >= 0
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.UnlockedContains(value)
 }
 
 func (s *HashSet[T]) UnlockedContains(value T) bool {
+	if s.size == 0 {
+		// Also covers the zero value, which has no hasher until first modified.
+		return false
+	}
+
 	return s.contains(s.hasher(value), value) >= 0
 }
 
 // Get returns the collection element that matches the given value, or nil if it is not found.
-// Useful if the set contains struct elements you want to modify in-place.
+//
+// The element refers to the value's slot in its hash bucket. Adding to or removing from
+// the bucket may move the value, so the element is invalidated by any modification of the set,
+// after which its Value method panics rather than read a stale slot.
+// Use IsValid to detect this, and Refresh to re-locate the value.
 func (s *HashSet[T]) Get(value T) collections.Element[T] {
 	if s.lock != nil {
 		s.lock.RLock()
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.size == 0 {
+		return nil
+	}
+
 	hash := s.hasher(value)
 	ind := s.contains(hash, value)
 
@@ -240,7 +471,7 @@ func (s *HashSet[T]) Get(value T) collections.Element[T] {
 		return nil
 	}
 
-	return util.NewElementType[T](s, &s.buffer[hash][ind])
+	return util.NewElementType[T](s, &s.buffer[hash][ind], s.compare)
 }
 
 // IsEmpty returns true if the collection has no elements.
@@ -256,9 +487,44 @@ func (s *HashSet[T]) ToSlice() []T {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	return s.toSlice(false)
 }
 
+// ToImmutableSlice returns the content of the set as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the set has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified.
+func (s *HashSet[T]) ToImmutableSlice() []T {
+
+	if s.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		s.lock.RLock()
+		values, ok := s.immutable.Load(s.version)
+		s.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	return s.immutable.Get(s.version, func() []T { return s.toSlice(false) })
+}
+
 // ToSliceDeep returns a copy of the set content as a slice using the provided [functions.DeepCopyFunc] if any.
 func (s *HashSet[T]) ToSliceDeep() []T {
 
@@ -267,6 +533,10 @@ func (s *HashSet[T]) ToSliceDeep() []T {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	return s.toSlice(true)
 }
 
@@ -281,26 +551,31 @@ func (s *HashSet[T]) Remove(value T) bool {
 		defer s.lock.Unlock()
 	}
 
-	hash := s.hasher(value)
-	index := s.contains(hash, value)
-	if index == -1 {
-		return false
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
 	}
 
-	if len(s.buffer[hash]) == 1 {
-		delete(s.buffer, hash)
-		//s.buffer[hash] = make([]T, 0, s.bucketCapacity)
-	} else {
-		// More than one value for this hash
-		s.collisionCount--
-		tmp := s.buffer[hash]
-		tmp[index] = tmp[len(tmp)-1]
-		s.buffer[hash] = tmp[:len(tmp)-1]
+	s.lazyInit()
+
+	return s.remove(value)
+}
+
+// TrimExcess rebuilds the hash table with room for only the values currently
+// in the set, releasing the storage retained after values have been removed.
+func (s *HashSet[T]) TrimExcess() {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
 	}
 
-	s.version++
-	s.size--
-	return true
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.resize(s.size)
 }
 
 // Type returns the type of this collection.
@@ -327,6 +602,10 @@ func (s *HashSet[T]) Difference(other sets.Set[T]) sets.Set[T] {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.makeEmptyCopy(s.capacity)
 
 	if other.Count() == 0 {
@@ -338,6 +617,7 @@ func (s *HashSet[T]) Difference(other sets.Set[T]) sets.Set[T] {
 		}
 
 		result.size = len(s.buffer)
+		result.peak = result.size
 		result.collisionCount = s.collisionCount
 		return result
 	}
@@ -362,7 +642,7 @@ func (s *HashSet[T]) Intersection(other sets.Set[T]) sets.Set[T] {
 
 	if s.size == 0 || other.Count() == 0 {
 		// No intersection if either set empty
-		return New[T]()
+		return s.makeEmptyCopy(util.DefaultCapacity)
 	}
 
 	ol := util.GetLock[T](other)
@@ -376,6 +656,10 @@ func (s *HashSet[T]) Intersection(other sets.Set[T]) sets.Set[T] {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	// It's much quicker to scan the smaller collection
 	// and look up values in the larger one as lookup
 	// is very fast in sets.
@@ -434,6 +718,10 @@ func (s *HashSet[T]) Union(other sets.Set[T]) sets.Set[T] {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.makeEmptyCopy(s.size + other.Count())
 	result.AddCollection(s)
 	result.AddCollection(other)
@@ -465,6 +753,10 @@ func (s *HashSet[T]) String() string {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	var values []string
 	for _, value := range s.toSlice(false) {
 		values = append(values, fmt.Sprintf("%v", value))
@@ -512,9 +804,73 @@ func (s *HashSet[T]) add(value T) bool {
 	bucket = append(bucket, value)
 	s.buffer[hash] = bucket
 	s.size++
+
+	if s.size > s.peak {
+		s.peak = s.size
+	}
+
+	s.recorder.Add(value)
+	return true
+}
+
+func (s *HashSet[T]) remove(value T) bool {
+	hash := s.hasher(value)
+	index := s.contains(hash, value)
+	if index == -1 {
+		return false
+	}
+
+	if len(s.buffer[hash]) == 1 {
+		delete(s.buffer, hash)
+		//s.buffer[hash] = make([]T, 0, s.bucketCapacity)
+	} else {
+		// More than one value for this hash.
+		// Allocate a new bucket rather than modify in place,
+		// as the existing bucket may be shared with a snapshot.
+		s.collisionCount--
+		tmp := s.buffer[hash]
+		bucket := make([]T, 0, max(len(tmp)-1, s.bucketCapacity))
+		bucket = append(bucket, tmp[:index]...)
+		s.buffer[hash] = append(bucket, tmp[index+1:]...)
+	}
+
+	s.version++
+	s.size--
+	s.recorder.Remove(value)
+	s.shrinkIfSparse()
 	return true
 }
 
+// shrinkIfSparse rebuilds the hash table if auto-shrink is enabled
+// and the set has fallen to a quarter of its peak size.
+func (s *HashSet[T]) shrinkIfSparse() {
+	if s.autoShrink && s.peak > util.DefaultCapacity && s.size <= s.peak/4 {
+		s.resize(s.size * 2)
+	}
+}
+
+// resize moves the hash buckets to a new map sized for the given capacity.
+//
+// Buckets are moved rather than copied, so snapshots sharing them are unaffected.
+func (s *HashSet[T]) resize(capacity int) {
+	buffer := make(map[uintptr][]T, capacity)
+
+	for hash, bucket := range s.buffer {
+		buffer[hash] = bucket
+	}
+
+	s.buffer = buffer
+	s.peak = s.size
+}
+
+func (s *HashSet[T]) clear() {
+	s.buffer = make(map[uintptr][]T, max(s.bucketCapacity, util.DefaultCapacity))
+	s.size = 0
+	s.peak = 0
+	s.version++
+	s.recorder.Clear()
+}
+
 func (s *HashSet[T]) makeEmptyCopy(capacity int) *HashSet[T] {
 	other := &HashSet[T]{
 		bucketCapacity: s.bucketCapacity,
@@ -522,8 +878,10 @@ func (s *HashSet[T]) makeEmptyCopy(capacity int) *HashSet[T] {
 		hasher:         s.hasher,
 		compare:        s.compare,
 		copy:           s.copy,
+		copyPolicy:     s.copyPolicy,
 		buffer:         make(map[uintptr][]T, capacity),
 		concurrent:     s.concurrent,
+		autoShrink:     s.autoShrink,
 	}
 
 	if s.lock != nil {
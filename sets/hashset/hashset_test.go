@@ -1,14 +1,22 @@
 package hashset
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/fireflycons/generic_collections/sets"
 	"github.com/fireflycons/generic_collections/sets/orderedset"
 	"github.com/stretchr/testify/require"
 )
@@ -23,6 +31,26 @@ func TestConstructor(t *testing.T) {
 		require.Equal(t, magic, set.compare(1, 0))
 	})
 
+	t.Run("With common options", func(t *testing.T) {
+		magic := 42
+		opts := collections.CommonOptions[int]{
+			ThreadSafe: true,
+			Concurrent: true,
+			Capacity:   100,
+			Comparer:   func(v1, v2 int) int { return magic },
+		}
+		set := New(WithOptions(opts))
+
+		require.NotNil(t, set.lock)
+		require.True(t, set.concurrent)
+		require.Equal(t, 100, set.capacity)
+		require.Equal(t, magic, set.compare(1, 0))
+	})
+
+	t.Run("With common options validates values", func(t *testing.T) {
+		require.PanicsWithValue(t, messages.NEGATIVE_CAPACITY, func() { New(WithOptions(collections.CommonOptions[int]{Capacity: -1})) })
+	})
+
 	t.Run("With nil comparer panics", func(t *testing.T) {
 		var comp func(v1, v2 int) int
 		require.Panics(t, func() { New(WithComparer(comp)) })
@@ -269,6 +297,69 @@ func TestGet(t *testing.T) {
 	require.ElementsMatch(t, setItems, set.ToSlice())
 
 	require.Equal(t, setItems[4], set.Get(setItems[4]).Value())
+
+	t.Run("Element is invalidated by bucket reallocation", func(t *testing.T) {
+		s := New(WithHasher(func(v int) uintptr { return uintptr(v % 4) }), WithHashBucketCapacity[int](1))
+		s.Add(1)
+		e := s.Get(1)
+
+		// Same bucket, which must grow.
+		s.Add(5)
+		s.Add(9)
+
+		require.False(t, e.IsValid())
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { e.Value() })
+		require.True(t, e.Refresh())
+		require.Equal(t, 1, e.Value())
+		require.Same(t, &s.buffer[1][0], e.(*util.ElementType[int]).ValueP)
+	})
+
+	t.Run("Element is invalidated by removal from bucket", func(t *testing.T) {
+		s := New(WithHasher(func(v int) uintptr { return uintptr(v % 4) }))
+		s.AddRange([]int{1, 5, 9})
+		e := s.Get(9)
+
+		s.Remove(1)
+
+		require.False(t, e.IsValid())
+		require.True(t, e.Refresh())
+		require.Equal(t, 9, e.Value())
+
+		s.Remove(9)
+		require.False(t, e.Refresh())
+	})
+}
+
+func TestBulkAdd(t *testing.T) {
+
+	t.Run("Adds all batches", func(t *testing.T) {
+		s := New(WithThreadSafe[int]())
+		src := make(chan []int, 2)
+
+		go func() {
+			src <- []int{1, 2, 3}
+			src <- []int{3, 4}
+			src <- []int{5}
+			close(src)
+		}()
+
+		require.NoError(t, s.BulkAdd(context.Background(), src))
+		require.ElementsMatch(t, []int{1, 2, 3, 4, 5}, s.ToSlice())
+	})
+
+	t.Run("Stops when context cancelled", func(t *testing.T) {
+		s := New[int]()
+		ctx, cancel := context.WithCancel(context.Background())
+		src := make(chan []int)
+
+		go func() {
+			src <- []int{1, 2}
+			cancel()
+		}()
+
+		require.ErrorIs(t, s.BulkAdd(ctx, src), context.Canceled)
+		require.ElementsMatch(t, []int{1, 2}, s.ToSlice())
+	})
 }
 
 func TestIntersection(t *testing.T) {
@@ -403,6 +494,35 @@ func TestDifference(t *testing.T) {
 	})
 }
 
+func TestReplaceAll(t *testing.T) {
+
+	seed := int64(2163)
+	items := util.CreateSingleIntListData(32, &seed)
+
+	t.Run("Content is replaced", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange(items[:16])
+		other := dlist.New[int]()
+		other.AddRange(items[16:])
+		other.Add(items[16])
+		set.ReplaceAll(other)
+		require.Equal(t, 16, set.Count())
+		require.ElementsMatch(t, items[16:], set.ToSlice())
+
+		for _, v := range items[:16] {
+			require.False(t, set.Contains(v))
+		}
+	})
+
+	t.Run("Replace with empty collection", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange(items)
+		set.ReplaceAll(dlist.New[int]())
+		require.True(t, set.IsEmpty())
+		require.Equal(t, []int{}, set.ToSlice())
+	})
+}
+
 func TestTime(t *testing.T) {
 	var set *HashSet[time.Time]
 	arraySize := 16
@@ -497,6 +617,25 @@ func benchmarkRemove(s *HashSet[int], data []int) {
 	}
 }
 
+// benchmarkBulkAdd feeds data to the set through BulkAdd in batches of 1000,
+// with at most four batches waiting.
+func benchmarkBulkAdd(s *HashSet[int], data []int) {
+	src := make(chan []int, 4)
+
+	go func() {
+		for start := 0; start < len(data); start += 1000 {
+			end := start + 1000
+			if end > len(data) {
+				end = len(data)
+			}
+			src <- data[start:end]
+		}
+		close(src)
+	}()
+
+	_ = s.BulkAdd(context.Background(), src)
+}
+
 func BenchmarkHashSet(b *testing.B) {
 
 	seed := int64(2163)
@@ -545,6 +684,22 @@ func BenchmarkHashSet(b *testing.B) {
 			})
 		}
 
+		for _, elems := range elements {
+			b.Run(fmt.Sprintf("Set-BulkAdd-%d-%s-NoPresize-NA", elems, util.Iif(threadsafe, "ThreadSafe", "NoThreadSafe")), func(b *testing.B) {
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					if threadsafe {
+						s = New(WithThreadSafe[int]())
+					} else {
+						s = New[int]()
+					}
+					b.StartTimer()
+					benchmarkBulkAdd(s, data[elems])
+				}
+			})
+		}
+
 		for _, elems := range elements {
 			b.Run(fmt.Sprintf("Set-Remove-%d-%s-NA-NA", elems, util.Iif(threadsafe, "ThreadSafe", "NoThreadSafe")), func(b *testing.B) {
 				b.ResetTimer()
@@ -598,3 +753,482 @@ func BenchmarkHashSet(b *testing.B) {
 	}
 
 }
+
+func TestApplyOps(t *testing.T) {
+
+	t.Run("Script is applied in order", func(t *testing.T) {
+		s := New[int]()
+		s.Add(10)
+		s.ApplyOps([]ops.Op[int]{
+			ops.Clear[int](),
+			ops.Add(1),
+			ops.Add(2),
+			ops.Add(1),
+			ops.Add(3),
+			ops.Remove(1),
+			ops.Remove(5),
+		})
+
+		require.ElementsMatch(t, []int{2, 3}, s.ToSlice())
+	})
+
+	t.Run("Invalid op kind panics", func(t *testing.T) {
+		s := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.OP_KIND_INVALID_FMT, 9), func() {
+			s.ApplyOps([]ops.Op[int]{ops.Add(1), {Kind: 9}})
+		})
+		require.Equal(t, []int{1}, s.ToSlice())
+	})
+}
+
+func TestRecording(t *testing.T) {
+
+	t.Run("Replaying recorded ops reproduces set", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{9, 8})
+		s.StartRecording()
+		require.True(t, s.IsRecording())
+
+		for i := 0; i < 20; i++ {
+			s.Add(i)
+		}
+		s.AddRange([]int{2, 3, 100})
+		s.Remove(2)
+		s.Remove(9)
+		other := New[int]()
+		other.AddRange([]int{50, 51, 52})
+		s.ReplaceAll(other)
+		s.AddCollection(dlist.New[int]())
+		s.Add(5)
+		s.Remove(51)
+
+		recorded := s.StopRecording()
+		require.False(t, s.IsRecording())
+
+		replica := New[int]()
+		replica.AddRange([]int{9, 8})
+		replica.ApplyOps(recorded)
+		require.ElementsMatch(t, s.ToSlice(), replica.ToSlice())
+	})
+
+	t.Run("Only changes are recorded", func(t *testing.T) {
+		s := New[int]()
+		s.StartRecording()
+		s.Add(1)
+		s.Add(1)
+		s.Remove(2)
+		s.Remove(1)
+		s.Clear()
+
+		require.Equal(t, []ops.Op[int]{ops.Add(1), ops.Remove(1), ops.Clear[int]()}, s.StopRecording())
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+
+	t.Run("Diff reports added and removed values", func(t *testing.T) {
+		s := New[int]()
+		for i := 0; i < 1000; i++ {
+			s.Add(i)
+		}
+
+		before := s.Snapshot()
+		s.Remove(10)
+		s.Remove(500)
+		s.Add(1000)
+		s.Add(2000)
+		after := s.Snapshot()
+
+		added, removed := sets.DiffSnapshots(before, after)
+		require.ElementsMatch(t, []int{1000, 2000}, added)
+		require.ElementsMatch(t, []int{10, 500}, removed)
+		require.Equal(t, 1000, before.Count())
+		require.Equal(t, 1000, after.Count())
+	})
+
+	t.Run("Snapshot is unaffected by later modifications", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		snap := s.Snapshot()
+		s.Remove(2)
+		s.Add(4)
+		s.Clear()
+
+		require.ElementsMatch(t, []int{1, 2, 3}, snap.ToSlice())
+	})
+
+	t.Run("Snapshot is unaffected by removal from colliding bucket", func(t *testing.T) {
+		// Hash all values to two buckets
+		s := New(WithHasher(func(v int) uintptr { return uintptr(v % 2) }))
+		s.AddRange([]int{1, 2, 3, 4, 5, 6})
+		snap := s.Snapshot()
+		s.Remove(3)
+		s.Add(7)
+		s.Remove(2)
+
+		require.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6}, snap.ToSlice())
+		added, removed := sets.DiffSnapshots(snap, s.Snapshot())
+		require.Equal(t, []int{7}, added)
+		require.ElementsMatch(t, []int{2, 3}, removed)
+	})
+
+	t.Run("Unmodified set returns same snapshot and empty diff", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		a := s.Snapshot()
+		b := s.Snapshot()
+
+		require.Same(t, a, b)
+		added, removed := sets.DiffSnapshots(a, b)
+		require.Empty(t, added)
+		require.Empty(t, removed)
+	})
+
+	t.Run("Diff snapshots of different sets", func(t *testing.T) {
+		s1 := New[int]()
+		s1.AddRange([]int{1, 2, 3})
+		s2 := New[int]()
+		s2.AddRange([]int{2, 3, 4})
+
+		added, removed := sets.DiffSnapshots(s1.Snapshot(), s2.Snapshot())
+		require.Equal(t, []int{4}, added)
+		require.Equal(t, []int{1}, removed)
+	})
+
+	t.Run("Diff with snapshot of another set type panics", func(t *testing.T) {
+		require.PanicsWithValue(t, messages.SNAPSHOT_TYPE_MISMATCH, func() {
+			sets.DiffSnapshots(New[int]().Snapshot(), orderedset.New[int]().Snapshot())
+		})
+	})
+
+	t.Run("Nil snapshot panics", func(t *testing.T) {
+		require.Panics(t, func() { sets.DiffSnapshots(New[int]().Snapshot(), nil) })
+	})
+}
+
+func TestCloneInto(t *testing.T) {
+
+	t.Run("Destination receives copy of values", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3, 4})
+		dst := New[int](WithThreadSafe[int]())
+		dst.AddRange([]int{9, 8})
+		s.CloneInto(dst)
+
+		require.ElementsMatch(t, []int{1, 2, 3, 4}, dst.ToSlice())
+		require.False(t, dst.Contains(9))
+		require.True(t, dst.Add(5))
+		require.True(t, dst.Remove(1))
+		require.ElementsMatch(t, []int{2, 3, 4, 5}, dst.ToSlice())
+		require.ElementsMatch(t, []int{1, 2, 3, 4}, s.ToSlice())
+		require.NotNil(t, dst.lock)
+	})
+
+	t.Run("Colliding values are cloned", func(t *testing.T) {
+		s := New(WithHasher(func(v int) uintptr { return uintptr(v % 2) }))
+		s.AddRange([]int{1, 2, 3, 4, 5})
+		dst := New[int]()
+		s.CloneInto(dst)
+
+		require.ElementsMatch(t, []int{1, 2, 3, 4, 5}, dst.ToSlice())
+		require.True(t, dst.Add(7))
+		require.True(t, dst.Add(6))
+		require.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7}, dst.ToSlice())
+	})
+
+	t.Run("Snapshot of destination is unaffected", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2})
+		dst := New[int]()
+		dst.AddRange([]int{3, 4})
+		snap := dst.Snapshot()
+		s.CloneInto(dst)
+
+		require.ElementsMatch(t, []int{3, 4}, snap.ToSlice())
+		added, removed := sets.DiffSnapshots(snap, dst.Snapshot())
+		require.ElementsMatch(t, []int{1, 2}, added)
+		require.ElementsMatch(t, []int{3, 4}, removed)
+	})
+
+	t.Run("Nil destination panics", func(t *testing.T) {
+		s := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dst"), func() { s.CloneInto(nil) })
+	})
+
+	t.Run("Source is not locked while waiting for destination", func(t *testing.T) {
+		src := New(WithThreadSafe[int]())
+		dst := New(WithThreadSafe[int]())
+		src.AddRange([]int{1, 2, 3})
+
+		// Were src still read-locked while dst.CloneInto(src) waits for src,
+		// that call would deadlock with this one.
+		dst.lock.Lock()
+		done := make(chan struct{})
+
+		go func() {
+			src.CloneInto(dst)
+			close(done)
+		}()
+
+		require.Eventually(t, func() bool {
+			if !src.lock.TryLock() {
+				return false
+			}
+
+			src.lock.Unlock()
+			return true
+		}, time.Second, time.Millisecond)
+
+		dst.lock.Unlock()
+		<-done
+		require.ElementsMatch(t, []int{1, 2, 3}, dst.ToSlice())
+	})
+
+}
+
+func TestShrink(t *testing.T) {
+
+	mapOf := func(s *HashSet[int]) uintptr { return reflect.ValueOf(s.buffer).Pointer() }
+
+	sequence := func(n int) []int {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = i + 1
+		}
+		return values
+	}
+
+	t.Run("TrimExcess rebuilds the hash table", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange(sequence(1000))
+
+		for i := 1; i <= 990; i++ {
+			set.Remove(i)
+		}
+
+		before := mapOf(set)
+		version := set.version
+		set.TrimExcess()
+
+		require.NotEqual(t, before, mapOf(set))
+		require.Equal(t, version, set.version)
+		require.ElementsMatch(t, []int{991, 992, 993, 994, 995, 996, 997, 998, 999, 1000}, set.ToSlice())
+	})
+
+	t.Run("Set does not shrink by default", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange(sequence(100))
+		before := mapOf(set)
+
+		for i := 1; i <= 100; i++ {
+			set.Remove(i)
+		}
+
+		require.Equal(t, before, mapOf(set))
+	})
+
+	t.Run("Auto shrink at a quarter of peak", func(t *testing.T) {
+		set := New(WithAutoShrink[int]())
+		set.AddRange(sequence(100))
+		before := mapOf(set)
+
+		for i := 1; i < 75; i++ {
+			set.Remove(i)
+		}
+
+		require.Equal(t, before, mapOf(set))
+		set.Remove(75)
+		require.NotEqual(t, before, mapOf(set))
+		require.Equal(t, 25, set.peak)
+
+		// Hysteresis: adding back towards the old peak and removing again
+		// only rebuilds once the new, lower peak is crossed.
+		before = mapOf(set)
+		set.AddRange([]int{1, 2, 3})
+		set.Remove(1)
+		require.Equal(t, before, mapOf(set))
+
+		for i := 76; i <= 100; i++ {
+			require.True(t, set.Contains(i))
+		}
+	})
+
+	t.Run("Snapshot is unaffected by shrink", func(t *testing.T) {
+		set := New(WithAutoShrink[int]())
+		set.AddRange(sequence(100))
+		snap := set.Snapshot()
+
+		for i := 1; i <= 80; i++ {
+			set.Remove(i)
+		}
+
+		require.Equal(t, 100, snap.Count())
+		added, removed := sets.DiffSnapshots(snap, set.Snapshot())
+		require.Empty(t, added)
+		require.Len(t, removed, 80)
+	})
+}
+
+func TestOf(t *testing.T) {
+
+	set := Of("a", "b", "a")
+	require.ElementsMatch(t, []string{"a", "b"}, set.ToSlice())
+
+	set = OfWith([]HashSetOptionFunc[string]{WithThreadSafe[string]()}, "c")
+	require.NotNil(t, set.lock)
+	require.True(t, set.Contains("c"))
+}
+
+func TestToImmutableSlice(t *testing.T) {
+
+	t.Run("Repeated calls return the same backing array", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		slc2 := col.ToImmutableSlice()
+
+		require.ElementsMatch(t, col.ToSlice(), slc1)
+		require.Same(t, &slc1[0], &slc2[0])
+		require.Equal(t, len(slc1), cap(slc1))
+	})
+
+	t.Run("Recomputed after modification", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		col.Remove(2)
+		slc2 := col.ToImmutableSlice()
+
+		require.ElementsMatch(t, col.ToSlice(), slc2)
+		require.Len(t, slc1, 3)
+		require.Len(t, slc2, 2)
+
+		col.Clear()
+		require.Empty(t, col.ToImmutableSlice())
+	})
+
+	t.Run("Empty collection", func(t *testing.T) {
+		require.Empty(t, New[int]().ToImmutableSlice())
+	})
+}
+
+func TestZeroValue(t *testing.T) {
+
+	t.Run("Usable without New", func(t *testing.T) {
+		var s HashSet[int]
+
+		require.True(t, s.IsEmpty())
+		require.False(t, s.Contains(1))
+		require.Nil(t, s.Get(1))
+		require.False(t, s.Remove(1))
+
+		for i := 0; i < 100; i++ {
+			s.Add(i % 50)
+		}
+
+		require.Equal(t, 50, s.Count())
+		require.True(t, s.Contains(49))
+		require.Equal(t, 10, s.Get(10).Value())
+	})
+
+	t.Run("Panics on first modification if no default comparer", func(t *testing.T) {
+		type point struct{ x, y int }
+		var s HashSet[point]
+
+		require.False(t, s.Contains(point{}))
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ZERO_VALUE_COMPARER_FMT, point{}), func() { s.Add(point{}) })
+	})
+}
+
+func TestNewOrdered(t *testing.T) {
+	type name string
+	s := NewOrdered[name](WithThreadSafe[name]())
+	s.AddRange([]name{"carol", "alice", "bob", "alice"})
+
+	require.Equal(t, 3, s.Count())
+	require.True(t, s.Contains("bob"))
+	require.False(t, s.Contains("dave"))
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithHasher(func(v *int) uintptr { return uintptr(*v) }))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithHasher(func(v *int) uintptr { return uintptr(*v) }), WithValidator(validator, policy))
+		},
+	})
+}
+
+func TestTryAdd(t *testing.T) {
+
+	s := New[int]()
+
+	added, err := s.TryAdd(1)
+	require.True(t, added)
+	require.NoError(t, err)
+
+	added, err = s.TryAdd(1)
+	require.False(t, added)
+	require.ErrorIs(t, err, collections.ErrDuplicate)
+	require.Equal(t, 1, s.Count())
+}
+
+func TestRetainWhere(t *testing.T) {
+
+	t.Run("Colliding values", func(t *testing.T) {
+		s := New(WithHasher(func(v int) uintptr { return uintptr(v % 4) }))
+		s.AddRange([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+		snap := s.Snapshot()
+
+		require.Equal(t, 5, s.RetainWhere(func(v int) bool { return v%2 == 0 }))
+		s.verifyInvariants()
+		require.ElementsMatch(t, []int{0, 2, 4, 6, 8}, s.ToSlice())
+		require.Equal(t, 3, s.collisionCount)
+		require.Equal(t, 10, snap.Count(), "Snapshot should be unaffected")
+
+		require.Equal(t, 3, s.RetainAll(orderedset.Of(1, 2, 4)))
+		s.verifyInvariants()
+		require.ElementsMatch(t, []int{2, 4}, s.ToSlice())
+		require.Equal(t, 0, s.collisionCount)
+	})
+
+	t.Run("Concurrent RetainAll of each other does not deadlock", func(t *testing.T) {
+		values := make([]int, 100)
+		for i := range values {
+			values[i] = i
+		}
+
+		a := New(WithThreadSafe[int]())
+		b := New(WithThreadSafe[int]())
+		done := make(chan struct{})
+		wg := sync.WaitGroup{}
+
+		for _, pair := range [][2]*HashSet[int]{{a, b}, {b, a}} {
+			wg.Add(1)
+			go func(s, other *HashSet[int]) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					s.AddRange(values)
+					s.RetainAll(other)
+				}
+			}(pair[0], pair[1])
+		}
+
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "RetainAll deadlocked")
+		}
+	})
+}
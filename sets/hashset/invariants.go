@@ -0,0 +1,21 @@
+package hashset
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the set is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (s *HashSet[T]) verifyInvariants() {
+	count := 0
+
+	for hash, bucket := range s.buffer {
+		util.AssertInvariant(len(bucket) > 0, "set has empty bucket")
+
+		for _, value := range bucket {
+			util.AssertInvariant(s.hasher(value) == hash, "set value is in wrong bucket")
+		}
+
+		count += len(bucket)
+	}
+
+	util.AssertInvariant(count == s.size, "set size does not match values")
+}
@@ -1,6 +1,8 @@
 package hashset
 
 import (
+	"math/rand"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/messages"
@@ -69,6 +71,40 @@ func (s *HashSet[T]) TakeWhile(predicate functions.PredicateFunc[T]) collections
 	return newForwardIterator(s, predicate)
 }
 
+// SampleIterator returns an iterator that yields n values chosen at random from the set,
+// without replacement and in random order. If the set holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the set.
+// If r is nil, the default source of the math/rand package is used.
+//
+//	iter := s.SampleIterator(100, nil)
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// audit e.Value()
+//	}
+//
+// Panics if n is negative.
+func (s *HashSet[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](s, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the set at the time of the call,
+// in the same order as ToSlice, so that the set may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the set's immutable slice, so it is only copied if the set has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the set, and their ValuePtr method panics.
+//
+//	iter := s.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		s.Remove(e.Value())
+//	}
+func (s *HashSet[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(s.ToImmutableSlice())
+}
+
 // Start begins iteration across the set returning the fisrt element,
 // which will be nil if the set is empty.
 //
@@ -86,7 +122,7 @@ func (i *HashSetIterator[T]) Start() collections.Element[T] {
 		return i.Next()
 	}
 
-	elem := util.NewElementType[T](i.set, valPtr)
+	elem := util.NewElementType[T](i.set, valPtr, i.set.compare)
 	i.bucketPosition++
 	return elem
 }
@@ -117,7 +153,7 @@ func (i *HashSetIterator[T]) validateIterator() {
 func moveForward[T any](i *HashSetIterator[T]) collections.Element[T] {
 
 	if i.bucketPosition < len(i.set.buffer[i.keys[i.position]]) {
-		val := util.NewElementType[T](i.set, &i.set.buffer[i.keys[i.position]][i.bucketPosition])
+		val := util.NewElementType[T](i.set, &i.set.buffer[i.keys[i.position]][i.bucketPosition], i.set.compare)
 		i.bucketPosition++
 		return val
 	}
@@ -132,7 +168,7 @@ func moveForward[T any](i *HashSetIterator[T]) collections.Element[T] {
 		return i.NilElement
 	}
 
-	val := util.NewElementType[T](i.set, &i.set.buffer[i.keys[i.position]][0])
+	val := util.NewElementType[T](i.set, &i.set.buffer[i.keys[i.position]][0], i.set.compare)
 	i.bucketPosition = 1
 	return val
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/stretchr/testify/require"
+	"math/rand"
 )
 
 func TestForwardIterator(t *testing.T) {
@@ -134,3 +135,33 @@ func TestForwardIteratorWithHashCollision(t *testing.T) {
 		require.ElementsMatch(t, setItems, iteratedItems)
 	})
 }
+
+func TestSampleIterator(t *testing.T) {
+	col := Of(1, 2, 3, 4, 5, 6, 7, 8)
+	iter := col.SampleIterator(3, rand.New(rand.NewSource(1)))
+	seen := make(map[int]bool)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		require.Contains(t, col.ToSlice(), e.Value())
+		require.False(t, seen[e.Value()])
+		seen[e.Value()] = true
+	}
+
+	require.Len(t, seen, 3)
+}
+
+func TestElementRefresh(t *testing.T) {
+	s := Of(1, 2, 3)
+	e := s.Get(2)
+
+	for i := 10; i < 100; i++ {
+		s.Add(i)
+	}
+
+	require.False(t, e.IsValid())
+	require.True(t, e.Refresh())
+	require.Equal(t, 2, e.Value())
+
+	s.Remove(2)
+	require.False(t, e.Refresh())
+}
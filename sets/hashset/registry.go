@@ -0,0 +1,67 @@
+package hashset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+)
+
+// Option function for New to give the set a name, under which it is registered with the
+// [registry] so that its size, version and operation counts are included in [registry.Snapshot].
+// The set remains registered until Dispose is called.
+//
+// Panics if name is empty.
+func WithName[T any](name string) HashSetOptionFunc[T] {
+	if name == "" {
+		panic(messages.NAME_EMPTY)
+	}
+	return func(s *HashSet[T]) {
+		s.name = name
+	}
+}
+
+// Dispose removes the set from the [registry] if it was created with [WithName].
+// The set remains usable, but no longer appears in [registry.Snapshot].
+// Calling Dispose more than once has no effect.
+func (s *HashSet[T]) Dispose() {
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.registration.Deregister()
+	s.registration = nil
+}
+
+// register registers the set with the registry if it has been given a name.
+func (s *HashSet[T]) register() {
+	if s.name == "" {
+		return
+	}
+
+	s.recorder.EnableCounting()
+	s.registration = registry.Register(s.name, s.stats)
+}
+
+func (s *HashSet[T]) stats() registry.Stats {
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return registry.Stats{
+		Type:    collections.COLLECTION_HASHSET,
+		Count:   s.size,
+		Version: s.version,
+		Ops:     s.recorder.Counts(),
+	}
+}
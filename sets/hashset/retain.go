@@ -0,0 +1,81 @@
+package hashset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*HashSet[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single pass
+// over the hash buckets, and returns the number of values removed. O(n).
+func (s *HashSet[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	removed := 0
+
+	for hash, bucket := range s.buffer {
+		var kept []T
+
+		for i, v := range bucket {
+			if predicate(v) {
+				if kept != nil {
+					kept = append(kept, v)
+				}
+
+				continue
+			}
+
+			if kept == nil {
+				// Allocate a new bucket rather than modify in place,
+				// as the existing bucket may be shared with a snapshot.
+				kept = make([]T, i, max(len(bucket)-1, s.bucketCapacity))
+				copy(kept, bucket[:i])
+			}
+
+			s.recorder.Remove(v)
+			removed++
+		}
+
+		switch {
+		case kept == nil:
+			// Nothing removed from this bucket
+		case len(kept) == 0:
+			s.collisionCount -= len(bucket) - 1
+			delete(s.buffer, hash)
+		default:
+			s.collisionCount -= len(bucket) - len(kept)
+			s.buffer[hash] = kept
+		}
+	}
+
+	if removed == 0 {
+		return 0
+	}
+
+	s.size -= removed
+	s.version++
+	s.shrinkIfSparse()
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (s *HashSet[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](s, other, s.RetainWhere)
+}
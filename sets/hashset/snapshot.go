@@ -0,0 +1,141 @@
+package hashset
+
+import (
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/sets"
+)
+
+// snapshot records the hash buckets of a HashSet at a point in time.
+//
+// Buckets are shared with the set rather than copied. This is safe because the set never
+// modifies the existing values of a bucket in place: values are appended beyond the end of
+// any bucket a snapshot holds, and removal allocates a new bucket.
+type snapshot[T any] struct {
+	set     *HashSet[T]
+	version int
+	size    int
+	buckets map[uintptr][]T
+	local.InternalImpl
+}
+
+// Snapshot returns an immutable record of the values currently in the set,
+// for later comparison with [sets.DiffSnapshots].
+//
+// Values are not copied, so taking a snapshot is O(b) where b is the number of hash buckets.
+// If the set has not been modified since the last snapshot was taken, that snapshot is
+// returned at no cost. When diffing two snapshots of the same set, buckets that have not changed between them
+// are skipped without comparing values. Snapshots of different sets can only be diffed if
+// the sets use the same hash function.
+func (s *HashSet[T]) Snapshot() sets.Snapshot[T] {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if s.lastSnapshot != nil && s.lastSnapshot.version == s.version {
+		return s.lastSnapshot
+	}
+
+	snap := &snapshot[T]{
+		set:     s,
+		version: s.version,
+		size:    s.size,
+		buckets: make(map[uintptr][]T, len(s.buffer)),
+	}
+
+	for hash, bucket := range s.buffer {
+		snap.buckets[hash] = bucket[:len(bucket):len(bucket)]
+	}
+
+	s.lastSnapshot = snap
+	return snap
+}
+
+// Count returns the number of values in the snapshot.
+func (snap *snapshot[T]) Count() int {
+	return snap.size
+}
+
+// ToSlice returns a copy of the values in the snapshot.
+func (snap *snapshot[T]) ToSlice() []T {
+	slc := make([]T, 0, snap.size)
+
+	for _, bucket := range snap.buckets {
+		slc = append(slc, bucket...)
+	}
+
+	return slc
+}
+
+// Diff returns the values that are in the later snapshot but not this one (added),
+// and the values that are in this snapshot but not the later one (removed).
+//
+// Panics if the later snapshot was not taken from a HashSet.
+func (snap *snapshot[T]) Diff(later sets.Snapshot[T]) (added, removed []T) {
+
+	other, ok := later.(*snapshot[T])
+
+	if !ok {
+		panic(messages.SNAPSHOT_TYPE_MISMATCH)
+	}
+
+	if snap.set == other.set && snap.version == other.version {
+		return nil, nil
+	}
+
+	for hash, bucket := range other.buckets {
+		previous := snap.buckets[hash]
+
+		if !sameBucket(previous, bucket) {
+			added = snap.appendMissing(added, bucket, previous)
+		}
+	}
+
+	for hash, bucket := range snap.buckets {
+		current := other.buckets[hash]
+
+		if !sameBucket(bucket, current) {
+			removed = snap.appendMissing(removed, bucket, current)
+		}
+	}
+
+	return added, removed
+}
+
+// appendMissing appends to result the values of bucket that are not present in other.
+func (snap *snapshot[T]) appendMissing(result, bucket, other []T) []T {
+	for _, v := range bucket {
+		found := false
+
+		for _, o := range other {
+			if snap.set.compare(v, o) == 0 {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// sameBucket returns true if both slices refer to the same values in the same backing array.
+func sameBucket[T any](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	return len(a) == 0 || &a[0] == &b[0]
+}
@@ -9,6 +9,8 @@ import (
 	"reflect"
 	"time"
 	"unsafe"
+
+	"github.com/fireflycons/generic_collections/functions"
 )
 
 const (
@@ -32,6 +34,16 @@ const intSizeBytes = bits.UintSize / 8
 Original algorithms and setDefaultHasher function Copyright (c) 2016 Caleb Spare under MIT license.
 */
 
+// DefaultHasher returns the hash function used by a HashSet[T] constructed without [WithHasher],
+// so that other hashed collections may hash values of type T in the same way.
+//
+// Panics if there is no default hash function for type T.
+func DefaultHasher[T any]() functions.HashFunc[T] {
+	s := &HashSet[T]{}
+	s.setDefaultHasher()
+	return s.hasher
+}
+
 // setDefaultHasher sets the default hasher depending on the key type.
 // Inlines hashing as anonymous functions for performance improvements, other options like
 // returning an anonymous functions from another function turned out to not be as performant.
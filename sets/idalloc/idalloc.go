@@ -0,0 +1,310 @@
+/*
+Package idalloc provides an allocator of integer IDs from a range, such as
+file descriptors, port numbers, slot indexes or connection IDs.
+
+The IDs in use are held in a BitSet, or for large ranges, or where few IDs are in use
+at once, an IntSet. Acquire always returns the smallest free ID, so released IDs
+are reused before the range is extended.
+*/
+package idalloc
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets/bitset"
+	"github.com/fireflycons/generic_collections/sets/intset"
+)
+
+// maxDenseIDs is the number of IDs in the largest range held in a BitSet, which needs 128 MiB
+// when every ID is in use. Larger ranges, including the default range, are held in an IntSet,
+// as a BitSet could not grow to hold the IDs at the end of the range.
+const maxDenseIDs = 1 << 30
+
+// AllocatorOptionFunc is the signature of a function
+// for providing options to the Allocator constructor.
+type AllocatorOptionFunc func(*Allocator)
+
+// Allocator hands out integer IDs from a range, smallest free ID first.
+//
+// IDs are stored as offsets from the minimum of the range,
+// so a range need not start at zero.
+type Allocator struct {
+	lock   *sync.RWMutex
+	min    int
+	max    int
+	sparse bool
+	dense  *bitset.BitSet
+	ids    *intset.IntSet
+	count  int
+
+	// All IDs below this offset are known to be in use.
+	lowWater int
+}
+
+// New constructs a new Allocator with no IDs in use. By default the range
+// is from zero to math.MaxInt and the IDs in use are held in an IntSet.
+// Where the range is restricted [WithRange] to at most 2^30 IDs, they are held in a BitSet
+// unless the allocator is created [WithSparse].
+func New(options ...AllocatorOptionFunc) *Allocator {
+	a := &Allocator{
+		max: math.MaxInt,
+	}
+
+	for _, o := range options {
+		o(a)
+	}
+
+	if a.max-a.min >= maxDenseIDs {
+		a.sparse = true
+	}
+
+	if a.sparse {
+		a.ids = intset.New()
+	} else {
+		a.dense = bitset.New()
+	}
+
+	return a
+}
+
+// Option function for New to make the allocator thread-safe. Adds overhead.
+func WithThreadSafe() AllocatorOptionFunc {
+	return func(a *Allocator) {
+		a.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function for New to restrict IDs to the range min to max inclusive.
+//
+// Panics if max is less than min, or if the range holds more than math.MaxInt IDs.
+func WithRange(min, max int) AllocatorOptionFunc {
+	if max < min {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "max"))
+	}
+
+	if max-min < 0 {
+		// Overflowed, so the offsets cannot be represented.
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "min"))
+	}
+
+	return func(a *Allocator) {
+		a.min = min
+		a.max = max
+	}
+}
+
+// Option function for New to hold the IDs in use in an IntSet rather than a BitSet,
+// which is implied for ranges too large for a BitSet.
+// This uses far less memory where IDs are held for a long time by a few users
+// scattered over a large range, at some cost to the speed of each operation.
+func WithSparse() AllocatorOptionFunc {
+	return func(a *Allocator) {
+		a.sparse = true
+	}
+}
+
+// Acquire marks the smallest free ID in the range as in use and returns it, and true;
+// else zero and false if every ID in the range is in use.
+func (a *Allocator) Acquire() (int, bool) {
+
+	if a.lock != nil {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+	}
+
+	offset := a.nextFree(a.lowWater)
+
+	// Offsets beyond the range may have wrapped around for a range of math.MaxInt IDs.
+	if offset < 0 || offset > a.max-a.min {
+		return 0, false
+	}
+
+	a.add(offset)
+	a.count++
+
+	if offset < a.max-a.min {
+		a.lowWater = offset + 1
+	}
+
+	return a.min + offset, true
+}
+
+// Reserve marks the given ID as in use, so that Acquire will not return it,
+// e.g. for IDs with a fixed meaning or those restored from persistent storage.
+//
+// Returns true if the ID was reserved; false if it was already in use.
+//
+// Panics if the ID is outside the range.
+func (a *Allocator) Reserve(id int) bool {
+
+	if a.lock != nil {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+	}
+
+	offset := a.validateID(id)
+
+	if a.contains(offset) {
+		return false
+	}
+
+	a.add(offset)
+	a.count++
+	return true
+}
+
+// Release returns the given ID to the allocator, so that it may be acquired again.
+//
+// Returns true if the ID was released; false if it was not in use.
+//
+// Panics if the ID is outside the range.
+func (a *Allocator) Release(id int) bool {
+
+	if a.lock != nil {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+	}
+
+	offset := a.validateID(id)
+
+	if !a.remove(offset) {
+		return false
+	}
+
+	a.count--
+
+	if offset < a.lowWater {
+		a.lowWater = offset
+	}
+
+	return true
+}
+
+// ReleaseAll returns all IDs to the allocator.
+func (a *Allocator) ReleaseAll() {
+
+	if a.lock != nil {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+	}
+
+	if a.sparse {
+		a.ids.Clear()
+	} else {
+		a.dense.ClearAll()
+	}
+
+	a.count = 0
+	a.lowWater = 0
+}
+
+// IsAllocated returns true if the given ID is in use.
+// IDs outside the range are never in use.
+func (a *Allocator) IsAllocated(id int) bool {
+
+	if a.lock != nil {
+		a.lock.RLock()
+		defer a.lock.RUnlock()
+	}
+
+	return id >= a.min && id <= a.max && a.contains(id-a.min)
+}
+
+// Count returns the number of IDs in use.
+func (a *Allocator) Count() int {
+
+	if a.lock != nil {
+		a.lock.RLock()
+		defer a.lock.RUnlock()
+	}
+
+	return a.count
+}
+
+// Range returns the minimum and maximum IDs that may be allocated.
+func (a *Allocator) Range() (int, int) {
+	return a.min, a.max
+}
+
+// ToSlice returns the IDs in use as a slice in ascending order.
+func (a *Allocator) ToSlice() []int {
+
+	if a.lock != nil {
+		a.lock.RLock()
+		defer a.lock.RUnlock()
+	}
+
+	var offsets []int
+
+	if a.sparse {
+		offsets = a.ids.ToSlice()
+	} else {
+		offsets = a.dense.ToSlice()
+	}
+
+	for i := range offsets {
+		offsets[i] += a.min
+	}
+
+	return offsets
+}
+
+// String returns a string representation of the allocator.
+func (a *Allocator) String() string {
+
+	var values []string
+	for _, id := range a.ToSlice() {
+		values = append(values, fmt.Sprintf("%d", id))
+	}
+
+	return "Allocator\n" + strings.Join(values, ", ")
+}
+
+func (a *Allocator) nextFree(from int) int {
+	if a.sparse {
+		return a.ids.NextAbsent(from)
+	}
+
+	return a.dense.NextClearBit(from)
+}
+
+func (a *Allocator) add(offset int) {
+	if a.sparse {
+		a.ids.Add(offset)
+	} else {
+		a.dense.Set(offset)
+	}
+}
+
+func (a *Allocator) remove(offset int) bool {
+	if a.sparse {
+		return a.ids.Remove(offset)
+	}
+
+	if !a.dense.Test(offset) {
+		return false
+	}
+
+	a.dense.Clear(offset)
+	return true
+}
+
+func (a *Allocator) contains(offset int) bool {
+	if a.sparse {
+		return a.ids.Contains(offset)
+	}
+
+	return a.dense.Test(offset)
+}
+
+func (a *Allocator) validateID(id int) int {
+	if id < a.min || id > a.max {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "id"))
+	}
+
+	return id - a.min
+}
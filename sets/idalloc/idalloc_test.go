@@ -0,0 +1,219 @@
+package idalloc
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+// Runs each test against both the dense and sparse representations.
+func forEachRepresentation(t *testing.T, test func(t *testing.T, options ...AllocatorOptionFunc)) {
+	t.Run("Dense", func(t *testing.T) { test(t, WithRange(0, 1<<20)) })
+	t.Run("Sparse", func(t *testing.T) { test(t, WithSparse()) })
+}
+
+func TestAcquire(t *testing.T) {
+
+	forEachRepresentation(t, func(t *testing.T, options ...AllocatorOptionFunc) {
+
+		t.Run("Acquires in ascending order", func(t *testing.T) {
+			a := New(options...)
+
+			for i := 0; i < 100; i++ {
+				id, ok := a.Acquire()
+				require.True(t, ok)
+				require.Equal(t, i, id)
+			}
+
+			require.Equal(t, 100, a.Count())
+		})
+
+		t.Run("Released ID is reused first", func(t *testing.T) {
+			a := New(options...)
+
+			for i := 0; i < 10; i++ {
+				a.Acquire()
+			}
+
+			require.True(t, a.Release(7))
+			require.True(t, a.Release(3))
+
+			id, _ := a.Acquire()
+			require.Equal(t, 3, id)
+			id, _ = a.Acquire()
+			require.Equal(t, 7, id)
+			id, _ = a.Acquire()
+			require.Equal(t, 10, id)
+		})
+
+		t.Run("Reserved IDs are skipped", func(t *testing.T) {
+			a := New(options...)
+			require.True(t, a.Reserve(0))
+			require.True(t, a.Reserve(2))
+			require.False(t, a.Reserve(2))
+
+			id, _ := a.Acquire()
+			require.Equal(t, 1, id)
+			id, _ = a.Acquire()
+			require.Equal(t, 3, id)
+			require.Equal(t, 4, a.Count())
+		})
+
+		t.Run("Exhausted range fails", func(t *testing.T) {
+			a := New(append(options, WithRange(10, 12))...)
+
+			for i := 10; i <= 12; i++ {
+				id, ok := a.Acquire()
+				require.True(t, ok)
+				require.Equal(t, i, id)
+			}
+
+			_, ok := a.Acquire()
+			require.False(t, ok)
+
+			a.Release(11)
+			id, ok := a.Acquire()
+			require.True(t, ok)
+			require.Equal(t, 11, id)
+		})
+
+		t.Run("Negative range", func(t *testing.T) {
+			a := New(append(options, WithRange(-5, 5))...)
+			id, _ := a.Acquire()
+			require.Equal(t, -5, id)
+			require.True(t, a.Reserve(-4))
+			id, _ = a.Acquire()
+			require.Equal(t, -3, id)
+			require.Equal(t, []int{-5, -4, -3}, a.ToSlice())
+		})
+
+		t.Run("Full range of int", func(t *testing.T) {
+			a := New(append(options, WithRange(math.MaxInt-1, math.MaxInt))...)
+			a.Acquire()
+			id, ok := a.Acquire()
+			require.True(t, ok)
+			require.Equal(t, math.MaxInt, id)
+			_, ok = a.Acquire()
+			require.False(t, ok)
+		})
+	})
+}
+
+func TestReleaseReserve(t *testing.T) {
+
+	forEachRepresentation(t, func(t *testing.T, options ...AllocatorOptionFunc) {
+
+		t.Run("Release of free ID returns false", func(t *testing.T) {
+			a := New(options...)
+			require.False(t, a.Release(5))
+		})
+
+		t.Run("IsAllocated", func(t *testing.T) {
+			a := New(append(options, WithRange(1, 10))...)
+			a.Reserve(5)
+			require.True(t, a.IsAllocated(5))
+			require.False(t, a.IsAllocated(6))
+			require.False(t, a.IsAllocated(0))
+			require.False(t, a.IsAllocated(11))
+		})
+
+		t.Run("ReleaseAll frees all IDs", func(t *testing.T) {
+			a := New(options...)
+			a.Acquire()
+			a.Reserve(100)
+			a.ReleaseAll()
+			require.Equal(t, 0, a.Count())
+			require.Empty(t, a.ToSlice())
+			id, _ := a.Acquire()
+			require.Equal(t, 0, id)
+		})
+
+		t.Run("Out of range ID panics", func(t *testing.T) {
+			a := New(append(options, WithRange(1, 10))...)
+			msg := fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "id")
+			require.PanicsWithValue(t, msg, func() { a.Reserve(0) })
+			require.PanicsWithValue(t, msg, func() { a.Release(11) })
+		})
+	})
+}
+
+func TestWithRange(t *testing.T) {
+
+	t.Run("Range is reported", func(t *testing.T) {
+		min, max := New(WithRange(3, 9)).Range()
+		require.Equal(t, 3, min)
+		require.Equal(t, 9, max)
+	})
+
+	t.Run("Inverted range panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "max"), func() { WithRange(5, 4) })
+	})
+
+	t.Run("Range too large panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "min"), func() { WithRange(-1, math.MaxInt) })
+	})
+}
+
+func TestRepresentation(t *testing.T) {
+
+	t.Run("Bounded range is dense", func(t *testing.T) {
+		a := New(WithRange(0, maxDenseIDs-1))
+		require.False(t, a.sparse)
+		require.NotNil(t, a.dense)
+	})
+
+	t.Run("Large range is sparse", func(t *testing.T) {
+		a := New(WithRange(-maxDenseIDs, 0))
+		require.True(t, a.sparse)
+	})
+
+	t.Run("Default range is sparse and holds the maximum ID", func(t *testing.T) {
+		a := New()
+		require.True(t, a.sparse)
+		require.True(t, a.Reserve(math.MaxInt))
+		require.True(t, a.IsAllocated(math.MaxInt))
+
+		id, ok := a.Acquire()
+		require.True(t, ok)
+		require.Equal(t, 0, id)
+		require.Equal(t, []int{0, math.MaxInt}, a.ToSlice())
+	})
+}
+
+func TestThreadSafety(t *testing.T) {
+
+	forEachRepresentation(t, func(t *testing.T, options ...AllocatorOptionFunc) {
+		a := New(append(options, WithThreadSafe())...)
+		wg := sync.WaitGroup{}
+		results := make([][]int, 4)
+
+		for g := range results {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					id, ok := a.Acquire()
+					require.True(t, ok)
+					results[g] = append(results[g], id)
+				}
+			}(g)
+		}
+
+		wg.Wait()
+
+		seen := make(map[int]bool)
+		for _, r := range results {
+			for _, id := range r {
+				require.False(t, seen[id])
+				seen[id] = true
+			}
+		}
+
+		require.Equal(t, 4000, a.Count())
+		require.Equal(t, 3999, a.ToSlice()[3999])
+	})
+}
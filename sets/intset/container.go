@@ -0,0 +1,411 @@
+package intset
+
+import (
+	"math/bits"
+	"sort"
+)
+
+const (
+	// Maximum number of values stored in an array container
+	// before it is converted to a bitmap container. At this point
+	// both representations occupy 8KB.
+	arrayMaxSize = 4096
+
+	bitmapWords = 1024
+)
+
+// container stores the low 16 bits of all values in the set
+// that share the same high bits.
+type container interface {
+	// add adds a value, returning the container that now holds
+	// the values (which may be a new representation) and whether
+	// the value was added.
+	add(low uint16) (container, bool)
+
+	// remove removes a value, returning the container that now holds
+	// the values (which may be a new representation) and whether
+	// the value was removed.
+	remove(low uint16) (container, bool)
+	contains(low uint16) bool
+	cardinality() int
+	minimum() uint16
+	maximum() uint16
+
+	// nextAbsent returns the smallest value greater than or equal to low
+	// that is not in the container, and false if there is no such value.
+	nextAbsent(low uint16) (uint16, bool)
+	walk(action func(uint16) bool) bool
+	clone() container
+}
+
+// arrayContainer holds a sorted array of values
+// and is used where the container is sparse.
+type arrayContainer struct {
+	values []uint16
+}
+
+// bitmapContainer holds a bit for every possible value
+// and is used where the container is dense.
+type bitmapContainer struct {
+	words [bitmapWords]uint64
+	card  int
+}
+
+func newArrayContainer(capacity int) *arrayContainer {
+	return &arrayContainer{
+		values: make([]uint16, 0, capacity),
+	}
+}
+
+func (a *arrayContainer) search(low uint16) int {
+	return sort.Search(len(a.values), func(i int) bool { return a.values[i] >= low })
+}
+
+func (a *arrayContainer) add(low uint16) (container, bool) {
+	i := a.search(low)
+
+	if i < len(a.values) && a.values[i] == low {
+		return a, false
+	}
+
+	if len(a.values) >= arrayMaxSize {
+		b := a.toBitmap()
+		b.add(low)
+		return b, true
+	}
+
+	a.values = append(a.values, 0)
+	copy(a.values[i+1:], a.values[i:])
+	a.values[i] = low
+	return a, true
+}
+
+func (a *arrayContainer) remove(low uint16) (container, bool) {
+	i := a.search(low)
+
+	if i == len(a.values) || a.values[i] != low {
+		return a, false
+	}
+
+	copy(a.values[i:], a.values[i+1:])
+	a.values = a.values[:len(a.values)-1]
+	return a, true
+}
+
+func (a *arrayContainer) contains(low uint16) bool {
+	i := a.search(low)
+	return i < len(a.values) && a.values[i] == low
+}
+
+func (a *arrayContainer) cardinality() int {
+	return len(a.values)
+}
+
+func (a *arrayContainer) minimum() uint16 {
+	return a.values[0]
+}
+
+func (a *arrayContainer) maximum() uint16 {
+	return a.values[len(a.values)-1]
+}
+
+func (a *arrayContainer) nextAbsent(low uint16) (uint16, bool) {
+	// Values are sorted and distinct, so low is absent
+	// as soon as it differs from the next value.
+	for i := a.search(low); i < len(a.values) && a.values[i] == low; i++ {
+		if low == 0xffff {
+			return 0, false
+		}
+
+		low++
+	}
+
+	return low, true
+}
+
+func (a *arrayContainer) walk(action func(uint16) bool) bool {
+	for _, v := range a.values {
+		if !action(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a *arrayContainer) clone() container {
+	c := newArrayContainer(len(a.values))
+	c.values = append(c.values, a.values...)
+	return c
+}
+
+func (a *arrayContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{}
+
+	for _, v := range a.values {
+		b.words[v>>6] |= 1 << (v & 63)
+	}
+
+	b.card = len(a.values)
+	return b
+}
+
+func (b *bitmapContainer) add(low uint16) (container, bool) {
+	mask := uint64(1) << (low & 63)
+
+	if b.words[low>>6]&mask != 0 {
+		return b, false
+	}
+
+	b.words[low>>6] |= mask
+	b.card++
+	return b, true
+}
+
+func (b *bitmapContainer) remove(low uint16) (container, bool) {
+	mask := uint64(1) << (low & 63)
+
+	if b.words[low>>6]&mask == 0 {
+		return b, false
+	}
+
+	b.words[low>>6] &^= mask
+	b.card--
+
+	if b.card <= arrayMaxSize {
+		return b.toArray(), true
+	}
+
+	return b, true
+}
+
+func (b *bitmapContainer) contains(low uint16) bool {
+	return b.words[low>>6]&(1<<(low&63)) != 0
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.card
+}
+
+func (b *bitmapContainer) minimum() uint16 {
+	for i, w := range b.words {
+		if w != 0 {
+			return uint16(i<<6 + bits.TrailingZeros64(w))
+		}
+	}
+
+	return 0
+}
+
+func (b *bitmapContainer) maximum() uint16 {
+	for i := bitmapWords - 1; i >= 0; i-- {
+		if w := b.words[i]; w != 0 {
+			return uint16(i<<6 + 63 - bits.LeadingZeros64(w))
+		}
+	}
+
+	return 0
+}
+
+func (b *bitmapContainer) nextAbsent(low uint16) (uint16, bool) {
+	index := int(low >> 6)
+
+	// Treat bits below low in the first word as present.
+	w := ^b.words[index] & (^uint64(0) << (low & 63))
+
+	for {
+		if w != 0 {
+			return uint16(index<<6 + bits.TrailingZeros64(w)), true
+		}
+
+		index++
+
+		if index >= bitmapWords {
+			return 0, false
+		}
+
+		w = ^b.words[index]
+	}
+}
+
+func (b *bitmapContainer) walk(action func(uint16) bool) bool {
+	for i, w := range b.words {
+		for w != 0 {
+			if !action(uint16(i<<6 + bits.TrailingZeros64(w))) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+
+	return true
+}
+
+func (b *bitmapContainer) clone() container {
+	c := *b
+	return &c
+}
+
+func (b *bitmapContainer) toArray() *arrayContainer {
+	a := newArrayContainer(b.card)
+	b.walk(func(v uint16) bool {
+		a.values = append(a.values, v)
+		return true
+	})
+
+	return a
+}
+
+// recount recomputes the cardinality of a bitmap after word-wise operations
+// and converts to an array container if the result has become sparse.
+func (b *bitmapContainer) recount() container {
+	b.card = 0
+	for _, w := range b.words {
+		b.card += bits.OnesCount64(w)
+	}
+
+	if b.card <= arrayMaxSize {
+		return b.toArray()
+	}
+
+	return b
+}
+
+// and returns a new container holding values present in both containers.
+func and(c1, c2 container) container {
+	a1, isArray1 := c1.(*arrayContainer)
+	a2, isArray2 := c2.(*arrayContainer)
+
+	switch {
+	case isArray1 && isArray2:
+		result := newArrayContainer(min(len(a1.values), len(a2.values)))
+		i, j := 0, 0
+		for i < len(a1.values) && j < len(a2.values) {
+			switch {
+			case a1.values[i] < a2.values[j]:
+				i++
+			case a1.values[i] > a2.values[j]:
+				j++
+			default:
+				result.values = append(result.values, a1.values[i])
+				i++
+				j++
+			}
+		}
+		return result
+	case isArray1:
+		return filter(a1, c2, true)
+	case isArray2:
+		return filter(a2, c1, true)
+	default:
+		result := &bitmapContainer{}
+		b1, b2 := c1.(*bitmapContainer), c2.(*bitmapContainer)
+		for i := range result.words {
+			result.words[i] = b1.words[i] & b2.words[i]
+		}
+		return result.recount()
+	}
+}
+
+// or returns a new container holding values present in either container.
+func or(c1, c2 container) container {
+	a1, isArray1 := c1.(*arrayContainer)
+	a2, isArray2 := c2.(*arrayContainer)
+
+	if isArray1 && isArray2 {
+		result := newArrayContainer(len(a1.values) + len(a2.values))
+		i, j := 0, 0
+		for i < len(a1.values) && j < len(a2.values) {
+			switch {
+			case a1.values[i] < a2.values[j]:
+				result.values = append(result.values, a1.values[i])
+				i++
+			case a1.values[i] > a2.values[j]:
+				result.values = append(result.values, a2.values[j])
+				j++
+			default:
+				result.values = append(result.values, a1.values[i])
+				i++
+				j++
+			}
+		}
+		result.values = append(result.values, a1.values[i:]...)
+		result.values = append(result.values, a2.values[j:]...)
+
+		if len(result.values) > arrayMaxSize {
+			return result.toBitmap()
+		}
+
+		return result
+	}
+
+	var result *bitmapContainer
+
+	if isArray1 {
+		result = c2.clone().(*bitmapContainer)
+		for _, v := range a1.values {
+			result.add(v)
+		}
+		return result
+	}
+
+	result = c1.clone().(*bitmapContainer)
+
+	if isArray2 {
+		for _, v := range a2.values {
+			result.add(v)
+		}
+		return result
+	}
+
+	b2 := c2.(*bitmapContainer)
+	for i := range result.words {
+		result.words[i] |= b2.words[i]
+	}
+
+	return result.recount()
+}
+
+// andNot returns a new container holding values present in the first container but not the second.
+func andNot(c1, c2 container) container {
+	if a1, isArray := c1.(*arrayContainer); isArray {
+		return filter(a1, c2, false)
+	}
+
+	result := c1.clone().(*bitmapContainer)
+
+	if a2, isArray := c2.(*arrayContainer); isArray {
+		for _, v := range a2.values {
+			result.words[v>>6] &^= 1 << (v & 63)
+		}
+	} else {
+		b2 := c2.(*bitmapContainer)
+		for i := range result.words {
+			result.words[i] &^= b2.words[i]
+		}
+	}
+
+	return result.recount()
+}
+
+// filter returns a new array container with the values of a
+// for which presence in c is equal to keep.
+func filter(a *arrayContainer, c container, keep bool) *arrayContainer {
+	result := newArrayContainer(len(a.values))
+
+	for _, v := range a.values {
+		if c.contains(v) == keep {
+			result.values = append(result.values, v)
+		}
+	}
+
+	return result
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,496 @@
+/*
+Package intset provides a compressed set of integers for large but sparse domains.
+
+Values are partitioned into chunks by their high bits, and the low 16 bits of each value
+are stored in a container for the chunk. A container is a sorted array when the chunk is
+sparse and a bitmap when it is dense, switching representation as values are added and removed.
+This is the layout popularised by roaring bitmaps.
+
+Compared with HashSet[int], an IntSet uses considerably less memory, and Union,
+Intersection and Difference operate a container at a time rather than value by value.
+*/
+package intset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+)
+
+// IntSetOptionFunc is the signature of a function
+// for providing options to the IntSet constructor.
+type IntSetOptionFunc func(*IntSet)
+
+// IntSet stores a set of integers partitioned into containers by their high bits.
+type IntSet struct {
+	lock       *sync.RWMutex
+	keys       []int
+	containers []container
+	size       int
+}
+
+// New constructs a new, empty IntSet.
+func New(options ...IntSetOptionFunc) *IntSet {
+	s := &IntSet{}
+
+	for _, o := range options {
+		o(s)
+	}
+
+	return s
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe() IntSetOptionFunc {
+	return func(s *IntSet) {
+		s.lock = &sync.RWMutex{}
+	}
+}
+
+// FromCollection constructs a new IntSet containing the values of the given collection.
+//
+// Any collection may be used, including the other set types in this library.
+func FromCollection(collection collections.Collection[int], options ...IntSetOptionFunc) *IntSet {
+	s := New(options...)
+
+	for _, v := range collection.ToSlice() {
+		s.add(v)
+	}
+
+	return s
+}
+
+// Add adds a value to the set.
+//
+// Returns true if the value was added; false if it was already present.
+func (s *IntSet) Add(value int) bool {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	return s.add(value)
+}
+
+// AddRange adds the given values to the set.
+func (s *IntSet) AddRange(values []int) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	for _, v := range values {
+		s.add(v)
+	}
+}
+
+// Remove removes a value from the set.
+//
+// Returns true if the value was removed; false if it was not present.
+func (s *IntSet) Remove(value int) bool {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	high, low := split(value)
+	i, found := s.findKey(high)
+
+	if !found {
+		return false
+	}
+
+	c, removed := s.containers[i].remove(low)
+
+	if !removed {
+		return false
+	}
+
+	s.size--
+
+	if c.cardinality() == 0 {
+		s.removeContainerAt(i)
+	} else {
+		s.containers[i] = c
+	}
+
+	return true
+}
+
+// Contains returns true if the value is present in the set.
+func (s *IntSet) Contains(value int) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	high, low := split(value)
+	i, found := s.findKey(high)
+
+	return found && s.containers[i].contains(low)
+}
+
+// Clear removes all values from the set.
+func (s *IntSet) Clear() {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.keys = nil
+	s.containers = nil
+	s.size = 0
+}
+
+// Count returns the number of values in the set.
+func (s *IntSet) Count() int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return s.size
+}
+
+// IsEmpty returns true if the set has no values.
+func (s *IntSet) IsEmpty() bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return s.size == 0
+}
+
+// Min returns the smallest value in the set.
+//
+// Panics if the set is empty.
+func (s *IntSet) Min() int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if s.size == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return join(s.keys[0], s.containers[0].minimum())
+}
+
+// Max returns the largest value in the set.
+//
+// Panics if the set is empty.
+func (s *IntSet) Max() int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if s.size == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	last := len(s.keys) - 1
+	return join(s.keys[last], s.containers[last].maximum())
+}
+
+// NextAbsent returns the smallest value greater than or equal to from
+// that is not present in the set. Whole containers that are full are skipped,
+// so this is efficient even where the set holds long runs of consecutive values.
+func (s *IntSet) NextAbsent(from int) int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return s.nextAbsent(from)
+}
+
+// Walk calls the action delegate for each value in ascending order.
+// If the action delegate returns false, stop the walk.
+//
+// Returns true if all values have been visited.
+// Otherwise returns false.
+func (s *IntSet) Walk(action func(int) bool) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return s.walk(action)
+}
+
+// Union returns a new IntSet containing the values present in either this or the other set.
+func (s *IntSet) Union(other *IntSet) *IntSet {
+	return s.combine(other, or, true, true)
+}
+
+// Intersection returns a new IntSet containing the values present in both this and the other set.
+func (s *IntSet) Intersection(other *IntSet) *IntSet {
+	return s.combine(other, and, false, false)
+}
+
+// Difference returns a new IntSet containing the values present in this set, but not in the other.
+func (s *IntSet) Difference(other *IntSet) *IntSet {
+	return s.combine(other, andNot, true, false)
+}
+
+// ToSlice returns the values as a slice in ascending order.
+func (s *IntSet) ToSlice() []int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	slc := make([]int, 0, s.size)
+	s.walk(func(v int) bool {
+		slc = append(slc, v)
+		return true
+	})
+
+	return slc
+}
+
+// ToHashSet returns a new HashSet containing the values of this set.
+// Options are passed to the HashSet constructor.
+func (s *IntSet) ToHashSet(options ...hashset.HashSetOptionFunc[int]) *hashset.HashSet[int] {
+	hs := hashset.New(options...)
+	hs.AddRange(s.ToSlice())
+	return hs
+}
+
+// ToOrderedSet returns a new OrderedSet containing the values of this set.
+// Options are passed to the OrderedSet constructor.
+func (s *IntSet) ToOrderedSet(options ...orderedset.OrderedSetOptionFunc[int]) *orderedset.OrderedSet[int] {
+	ordered := orderedset.New(options...)
+	ordered.AddRange(s.ToSlice())
+	return ordered
+}
+
+// String returns a string representation of container.
+func (s *IntSet) String() string {
+
+	var values []string
+	for _, v := range s.ToSlice() {
+		values = append(values, fmt.Sprintf("%d", v))
+	}
+
+	return "IntSet\n" + strings.Join(values, ", ")
+}
+
+func (s *IntSet) add(value int) bool {
+	high, low := split(value)
+	i, found := s.findKey(high)
+
+	if !found {
+		c := newArrayContainer(1)
+		c.values = append(c.values, low)
+		s.insertContainerAt(i, high, c)
+		s.size++
+		return true
+	}
+
+	c, added := s.containers[i].add(low)
+
+	if added {
+		s.containers[i] = c
+		s.size++
+	}
+
+	return added
+}
+
+func (s *IntSet) nextAbsent(from int) int {
+	high, low := split(from)
+	i, found := s.findKey(high)
+
+	for found {
+		if next, ok := s.containers[i].nextAbsent(low); ok {
+			return join(high, next)
+		}
+
+		// The container is full from low upwards, so continue with the next.
+		high, low = high+1, 0
+		i++
+		found = i < len(s.keys) && s.keys[i] == high
+	}
+
+	return join(high, low)
+}
+
+func (s *IntSet) walk(action func(int) bool) bool {
+	for i, c := range s.containers {
+		high := s.keys[i]
+		if !c.walk(func(low uint16) bool { return action(join(high, low)) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findKey returns the index of the container for the given high bits,
+// or the index at which it should be inserted if not found.
+func (s *IntSet) findKey(high int) (int, bool) {
+	i := sort.SearchInts(s.keys, high)
+	return i, i < len(s.keys) && s.keys[i] == high
+}
+
+func (s *IntSet) insertContainerAt(i, high int, c container) {
+	s.keys = append(s.keys, 0)
+	copy(s.keys[i+1:], s.keys[i:])
+	s.keys[i] = high
+
+	s.containers = append(s.containers, nil)
+	copy(s.containers[i+1:], s.containers[i:])
+	s.containers[i] = c
+}
+
+func (s *IntSet) removeContainerAt(i int) {
+	copy(s.keys[i:], s.keys[i+1:])
+	s.keys = s.keys[:len(s.keys)-1]
+
+	copy(s.containers[i:], s.containers[i+1:])
+	s.containers[len(s.containers)-1] = nil
+	s.containers = s.containers[:len(s.containers)-1]
+}
+
+// appendContainer appends a container with a key greater than all existing keys.
+// Empty containers are discarded.
+func (s *IntSet) appendContainer(high int, c container) {
+	if c.cardinality() == 0 {
+		return
+	}
+
+	s.keys = append(s.keys, high)
+	s.containers = append(s.containers, c)
+	s.size += c.cardinality()
+}
+
+// combine merges the containers of this set and the other by key.
+// Where a key exists in both sets, op is applied to the containers.
+// Where a key exists in only one set, its container is copied to the result
+// if the corresponding keep flag is set.
+func (s *IntSet) combine(other *IntSet, op func(c1, c2 container) container, keepThis, keepOther bool) *IntSet {
+
+	if other == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "other"))
+	}
+
+	// The containers of other are copied before this set is locked, so that the locks of both
+	// are never held together, which could deadlock with a concurrent other.combine(s).
+	// The copies are owned by this call, so they are not cloned again for the result.
+	owned := other != s
+
+	if owned {
+		other = other.snapshot()
+	}
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	theirs := func(j int) container {
+		if owned {
+			return other.containers[j]
+		}
+
+		return other.containers[j].clone()
+	}
+
+	result := s.makeEmptyCopy()
+	i, j := 0, 0
+
+	for i < len(s.keys) && j < len(other.keys) {
+		switch {
+		case s.keys[i] < other.keys[j]:
+			if keepThis {
+				result.appendContainer(s.keys[i], s.containers[i].clone())
+			}
+			i++
+		case s.keys[i] > other.keys[j]:
+			if keepOther {
+				result.appendContainer(other.keys[j], theirs(j))
+			}
+			j++
+		default:
+			result.appendContainer(s.keys[i], op(s.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+
+	for ; keepThis && i < len(s.keys); i++ {
+		result.appendContainer(s.keys[i], s.containers[i].clone())
+	}
+
+	for ; keepOther && j < len(other.keys); j++ {
+		result.appendContainer(other.keys[j], theirs(j))
+	}
+
+	return result
+}
+
+// snapshot returns an unsynchronized copy of the set, under its lock.
+func (s *IntSet) snapshot() *IntSet {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	c := &IntSet{
+		keys:       make([]int, len(s.keys)),
+		containers: make([]container, len(s.containers)),
+		size:       s.size,
+	}
+
+	copy(c.keys, s.keys)
+
+	for i, ct := range s.containers {
+		c.containers[i] = ct.clone()
+	}
+
+	return c
+}
+
+func (s *IntSet) makeEmptyCopy() *IntSet {
+	other := &IntSet{}
+
+	if s.lock != nil {
+		other.lock = &sync.RWMutex{}
+	}
+
+	return other
+}
+
+// split separates a value into its high bits (the container key)
+// and low 16 bits (the value stored in the container).
+func split(value int) (int, uint16) {
+	return value >> 16, uint16(value & 0xffff)
+}
+
+// join is the inverse of split.
+func join(high int, low uint16) int {
+	return high<<16 | int(low)
+}
@@ -0,0 +1,363 @@
+package intset
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/stretchr/testify/require"
+)
+
+// generateValues returns values spread across several containers,
+// with some containers dense enough to become bitmaps.
+func generateValues(seed int64) []int {
+	r := rand.New(rand.NewSource(seed))
+	values := make([]int, 0, 20000)
+
+	// Dense chunk
+	for i := 0; i < 10000; i++ {
+		values = append(values, 1<<16+r.Intn(1<<16))
+	}
+
+	// Sparse values across a large domain, including negatives
+	for i := 0; i < 10000; i++ {
+		values = append(values, r.Intn(1<<40)-1<<39)
+	}
+
+	return values
+}
+
+func sortedUnique(m map[int]struct{}) []int {
+	result := make([]int, 0, len(m))
+	for v := range m {
+		result = append(result, v)
+	}
+	sort.Ints(result)
+	return result
+}
+
+func toMap(values []int) map[int]struct{} {
+	m := make(map[int]struct{}, len(values))
+	for _, v := range values {
+		m[v] = struct{}{}
+	}
+	return m
+}
+
+func TestAddRemoveContains(t *testing.T) {
+
+	t.Run("Add returns whether value was added", func(t *testing.T) {
+		s := New()
+		require.True(t, s.Add(5))
+		require.False(t, s.Add(5))
+		require.True(t, s.Contains(5))
+		require.False(t, s.Contains(6))
+		require.Equal(t, 1, s.Count())
+	})
+
+	t.Run("Negative values are supported", func(t *testing.T) {
+		s := New()
+		s.AddRange([]int{-1, -65536, -65537, 0, 65535})
+		require.Equal(t, []int{-65537, -65536, -1, 0, 65535}, s.ToSlice())
+	})
+
+	t.Run("Large random set matches reference", func(t *testing.T) {
+		values := generateValues(2163)
+		s := New()
+		s.AddRange(values)
+		expected := sortedUnique(toMap(values))
+
+		require.Equal(t, len(expected), s.Count())
+		require.Equal(t, expected, s.ToSlice())
+
+		for _, v := range expected {
+			require.True(t, s.Contains(v))
+		}
+	})
+
+	t.Run("Dense container converts to bitmap and back", func(t *testing.T) {
+		s := New()
+		for i := 0; i <= arrayMaxSize; i++ {
+			s.Add(i * 2)
+		}
+
+		_, isBitmap := s.containers[0].(*bitmapContainer)
+		require.True(t, isBitmap)
+
+		require.True(t, s.Remove(0))
+		_, isArray := s.containers[0].(*arrayContainer)
+		require.True(t, isArray)
+		require.Equal(t, arrayMaxSize, s.Count())
+		require.False(t, s.Contains(0))
+		require.True(t, s.Contains(2))
+	})
+
+	t.Run("Remove returns whether value was removed", func(t *testing.T) {
+		s := New()
+		s.AddRange([]int{1, 2, 1 << 20})
+		require.True(t, s.Remove(1<<20))
+		require.False(t, s.Remove(1<<20))
+		require.False(t, s.Remove(3))
+		require.Equal(t, 1, len(s.keys))
+		require.Equal(t, 2, s.Count())
+	})
+
+	t.Run("Remove all values empties set", func(t *testing.T) {
+		values := generateValues(2164)
+		s := New()
+		s.AddRange(values)
+
+		for _, v := range values {
+			s.Remove(v)
+		}
+
+		require.True(t, s.IsEmpty())
+		require.Empty(t, s.keys)
+	})
+
+	t.Run("Clear empties set", func(t *testing.T) {
+		s := New()
+		s.AddRange([]int{1, 2, 3})
+		s.Clear()
+		require.True(t, s.IsEmpty())
+		require.False(t, s.Contains(1))
+	})
+}
+
+func TestMinMax(t *testing.T) {
+
+	t.Run("Min and max of populated set", func(t *testing.T) {
+		s := New()
+		s.AddRange([]int{100, -5, 1 << 30, 7})
+		require.Equal(t, -5, s.Min())
+		require.Equal(t, 1<<30, s.Max())
+	})
+
+	t.Run("Min and max of bitmap container", func(t *testing.T) {
+		s := New()
+		for i := 10; i < 10+arrayMaxSize+10; i++ {
+			s.Add(i)
+		}
+		require.Equal(t, 10, s.Min())
+		require.Equal(t, 10+arrayMaxSize+9, s.Max())
+	})
+
+	t.Run("Empty set panics", func(t *testing.T) {
+		s := New()
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { s.Min() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { s.Max() })
+	})
+}
+
+func TestNextAbsent(t *testing.T) {
+
+	t.Run("Absent value is returned", func(t *testing.T) {
+		s := New()
+		s.AddRange([]int{1, 2, 3})
+		require.Equal(t, 0, s.NextAbsent(0))
+		require.Equal(t, 4, s.NextAbsent(1))
+		require.Equal(t, 10, s.NextAbsent(10))
+	})
+
+	t.Run("Gap in array container", func(t *testing.T) {
+		s := New()
+		s.AddRange([]int{5, 6, 8})
+		require.Equal(t, 7, s.NextAbsent(5))
+	})
+
+	t.Run("Gap in bitmap container", func(t *testing.T) {
+		s := New()
+		for i := 0; i < arrayMaxSize+100; i++ {
+			if i != 3000 {
+				s.Add(i)
+			}
+		}
+		require.Equal(t, 3000, s.NextAbsent(0))
+		require.Equal(t, arrayMaxSize+100, s.NextAbsent(3001))
+	})
+
+	t.Run("Full containers are skipped", func(t *testing.T) {
+		s := New()
+		for i := 0; i < 1<<17; i++ {
+			s.Add(i)
+		}
+		s.Add(1<<17 + 1)
+		require.Equal(t, 1<<17, s.NextAbsent(0))
+		require.Equal(t, 1<<17+2, s.NextAbsent(1<<17+1))
+	})
+
+	t.Run("Negative values", func(t *testing.T) {
+		s := New()
+		s.AddRange([]int{-2, -1, 0})
+		require.Equal(t, 1, s.NextAbsent(-2))
+		require.Equal(t, -3, s.NextAbsent(-3))
+	})
+}
+
+func TestSetOperations(t *testing.T) {
+
+	values1 := generateValues(2163)
+	values2 := generateValues(2164)
+
+	// Overlap the two sets, both sparse and dense.
+	values2 = append(values2, values1[:5000]...)
+	values2 = append(values2, values1[15000:]...)
+
+	m1, m2 := toMap(values1), toMap(values2)
+	s1, s2 := New(), New()
+	s1.AddRange(values1)
+	s2.AddRange(values2)
+
+	t.Run("Union", func(t *testing.T) {
+		expected := make(map[int]struct{})
+		for v := range m1 {
+			expected[v] = struct{}{}
+		}
+		for v := range m2 {
+			expected[v] = struct{}{}
+		}
+
+		result := s1.Union(s2)
+		require.Equal(t, sortedUnique(expected), result.ToSlice())
+		require.Equal(t, len(expected), result.Count())
+	})
+
+	t.Run("Intersection", func(t *testing.T) {
+		expected := make(map[int]struct{})
+		for v := range m1 {
+			if _, ok := m2[v]; ok {
+				expected[v] = struct{}{}
+			}
+		}
+
+		result := s1.Intersection(s2)
+		require.Equal(t, sortedUnique(expected), result.ToSlice())
+		require.Equal(t, len(expected), result.Count())
+	})
+
+	t.Run("Difference", func(t *testing.T) {
+		expected := make(map[int]struct{})
+		for v := range m1 {
+			if _, ok := m2[v]; !ok {
+				expected[v] = struct{}{}
+			}
+		}
+
+		result := s1.Difference(s2)
+		require.Equal(t, sortedUnique(expected), result.ToSlice())
+		require.Equal(t, len(expected), result.Count())
+	})
+
+	t.Run("Operands are unchanged", func(t *testing.T) {
+		require.Equal(t, sortedUnique(m1), s1.ToSlice())
+		require.Equal(t, sortedUnique(m2), s2.ToSlice())
+	})
+
+	t.Run("Operation with self", func(t *testing.T) {
+		s := New(WithThreadSafe())
+		s.AddRange(values1)
+		require.Equal(t, s.ToSlice(), s.Union(s).ToSlice())
+		require.Equal(t, s.ToSlice(), s.Intersection(s).ToSlice())
+		require.True(t, s.Difference(s).IsEmpty())
+	})
+
+	t.Run("Nil argument panics", func(t *testing.T) {
+		require.Panics(t, func() { s1.Union(nil) })
+	})
+
+	t.Run("Result is independent of the argument", func(t *testing.T) {
+		a, b := New(), New()
+		a.AddRange([]int{1, 2})
+		b.AddRange([]int{3, 1 << 20})
+
+		result := a.Union(b)
+		b.Add(4)
+		b.Remove(1 << 20)
+		require.Equal(t, []int{1, 2, 3, 1 << 20}, result.ToSlice())
+	})
+
+	t.Run("Argument is not locked while waiting for receiver", func(t *testing.T) {
+		a, b := New(WithThreadSafe()), New(WithThreadSafe())
+		a.Add(1)
+		b.Add(2)
+
+		// Were a still read-locked while b.Union(a) waits for b,
+		// a writer waiting for a would deadlock with a concurrent a.Union(b).
+		b.lock.Lock()
+		result := make(chan *IntSet)
+
+		go func() {
+			result <- b.Union(a)
+		}()
+
+		require.Eventually(t, func() bool {
+			if !a.lock.TryLock() {
+				return false
+			}
+
+			a.lock.Unlock()
+			return true
+		}, time.Second, time.Millisecond)
+
+		b.lock.Unlock()
+		require.Equal(t, []int{1, 2}, (<-result).ToSlice())
+	})
+}
+
+func TestConversion(t *testing.T) {
+
+	values := generateValues(2163)
+	expected := sortedUnique(toMap(values))
+
+	t.Run("From HashSet", func(t *testing.T) {
+		hs := hashset.New[int]()
+		hs.AddRange(values)
+		s := FromCollection(hs)
+		require.Equal(t, expected, s.ToSlice())
+	})
+
+	t.Run("To HashSet", func(t *testing.T) {
+		s := New()
+		s.AddRange(values)
+		hs := s.ToHashSet()
+		require.Equal(t, len(expected), hs.Count())
+
+		for _, v := range expected {
+			require.True(t, hs.Contains(v))
+		}
+	})
+
+	t.Run("To OrderedSet", func(t *testing.T) {
+		s := New()
+		s.AddRange(values)
+		require.Equal(t, expected, s.ToOrderedSet().ToSlice())
+	})
+}
+
+func TestWalk(t *testing.T) {
+
+	t.Run("Walk stops when action returns false", func(t *testing.T) {
+		s := New()
+		s.AddRange([]int{1, 2, 3, 1 << 20})
+		visited := make([]int, 0)
+		require.False(t, s.Walk(func(v int) bool {
+			visited = append(visited, v)
+			return v < 2
+		}))
+		require.Equal(t, []int{1, 2}, visited)
+	})
+
+	t.Run("Walk visits all values", func(t *testing.T) {
+		s := New()
+		s.AddRange([]int{1, 2, 3, 1 << 20})
+		count := 0
+		require.True(t, s.Walk(func(int) bool {
+			count++
+			return true
+		}))
+		require.Equal(t, 4, count)
+	})
+}
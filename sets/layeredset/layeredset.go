@@ -0,0 +1,185 @@
+/*
+Package layeredset provides an allow/deny list built from layers of sets with precedence.
+
+Each layer pairs a set with an [Effect]. When a value is evaluated, the layers are consulted in
+order and the effect of the first layer whose set contains the value is returned. If no layer
+contains the value, the default effect is returned. Placing a deny layer ahead of an allow layer
+therefore makes deny override allow, in the manner of a firewall or ACL.
+
+The layers may be replaced at any time, and the replacement is atomic: a concurrent evaluation
+sees either the old layers or the new layers, never a mixture. The sets within the layers are
+not copied, so to modify a set that is in use it must itself be thread-safe, or a modified copy
+should be swapped in with [LayeredSet.ReplaceLayer].
+*/
+package layeredset
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets"
+)
+
+// Effect is the outcome of evaluating a value against a LayeredSet.
+type Effect int
+
+const (
+	_ Effect = iota
+	Allow
+	Deny
+)
+
+// LayeredSetOptionFunc is the signature of a function
+// for providing options to the LayeredSet constructor.
+type LayeredSetOptionFunc[T any] func(*LayeredSet[T])
+
+// Layer pairs a set of values with the effect applied to them.
+type Layer[T any] struct {
+	Set    sets.Set[T]
+	Effect Effect
+}
+
+// LayeredSet evaluates values against an ordered list of layers.
+type LayeredSet[T any] struct {
+	layers        atomic.Pointer[[]Layer[T]]
+	defaultEffect Effect
+}
+
+// New constructs a new LayeredSet. Without options, it has no layers
+// and the default effect is Deny.
+func New[T any](options ...LayeredSetOptionFunc[T]) *LayeredSet[T] {
+	ls := &LayeredSet[T]{
+		defaultEffect: Deny,
+	}
+
+	for _, o := range options {
+		o(ls)
+	}
+
+	if ls.layers.Load() == nil {
+		ls.layers.Store(&[]Layer[T]{})
+	}
+
+	return ls
+}
+
+// Option function for New to set the effect returned for values that are in none of the layers.
+//
+// Panics if effect is not Allow or Deny.
+func WithDefaultEffect[T any](effect Effect) LayeredSetOptionFunc[T] {
+	validateEffect(effect)
+	return func(ls *LayeredSet[T]) {
+		ls.defaultEffect = effect
+	}
+}
+
+// Option function for New to set the initial layers, in order of precedence.
+//
+// Panics if any layer has a nil set or an invalid effect.
+func WithLayers[T any](layers ...Layer[T]) LayeredSetOptionFunc[T] {
+	l := copyLayers(layers)
+	return func(ls *LayeredSet[T]) {
+		ls.layers.Store(&l)
+	}
+}
+
+// Evaluate returns the effect of the first layer whose set contains the value,
+// or the default effect if no layer contains it.
+func (ls *LayeredSet[T]) Evaluate(value T) Effect {
+
+	for _, layer := range *ls.layers.Load() {
+		if layer.Set.Contains(value) {
+			return layer.Effect
+		}
+	}
+
+	return ls.defaultEffect
+}
+
+// Contains returns true if the value evaluates to Allow.
+func (ls *LayeredSet[T]) Contains(value T) bool {
+	return ls.Evaluate(value) == Allow
+}
+
+// DefaultEffect returns the effect returned for values that are in none of the layers.
+func (ls *LayeredSet[T]) DefaultEffect() Effect {
+	return ls.defaultEffect
+}
+
+// Layers returns a copy of the current layers, in order of precedence.
+func (ls *LayeredSet[T]) Layers() []Layer[T] {
+	layers := *ls.layers.Load()
+	result := make([]Layer[T], len(layers))
+	copy(result, layers)
+	return result
+}
+
+// SetLayers atomically replaces all the layers.
+//
+// Panics if any layer has a nil set or an invalid effect.
+func (ls *LayeredSet[T]) SetLayers(layers ...Layer[T]) {
+	l := copyLayers(layers)
+	ls.layers.Store(&l)
+}
+
+// ReplaceLayer atomically replaces the set of the layer at the given index,
+// retaining its effect and position.
+//
+// Panics if set is nil or index is out of range.
+func (ls *LayeredSet[T]) ReplaceLayer(index int, set sets.Set[T]) {
+
+	if set == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "set"))
+	}
+
+	for {
+		current := ls.layers.Load()
+
+		if index < 0 || index >= len(*current) {
+			panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "index"))
+		}
+
+		l := make([]Layer[T], len(*current))
+		copy(l, *current)
+		l[index].Set = set
+
+		if ls.layers.CompareAndSwap(current, &l) {
+			return
+		}
+	}
+}
+
+// String returns the name of the effect.
+func (e Effect) String() string {
+	switch e {
+	case Allow:
+		return "Allow"
+	case Deny:
+		return "Deny"
+	default:
+		return fmt.Sprintf("Effect(%d)", int(e))
+	}
+}
+
+// copyLayers validates the given layers and returns a copy that cannot be modified by the caller.
+func copyLayers[T any](layers []Layer[T]) []Layer[T] {
+	result := make([]Layer[T], len(layers))
+
+	for i, layer := range layers {
+		if layer.Set == nil {
+			panic(fmt.Sprintf(messages.ARG_NIL_FMT, "set"))
+		}
+
+		validateEffect(layer.Effect)
+		result[i] = layer
+	}
+
+	return result
+}
+
+func validateEffect(effect Effect) {
+	if effect != Allow && effect != Deny {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "effect"))
+	}
+}
@@ -0,0 +1,121 @@
+package layeredset
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+
+	t.Run("Deny layer overrides allow layer", func(t *testing.T) {
+		ls := New(WithLayers(
+			Layer[string]{Set: hashset.Of("mallory"), Effect: Deny},
+			Layer[string]{Set: hashset.Of("alice", "bob", "mallory"), Effect: Allow},
+		))
+
+		require.True(t, ls.Contains("alice"))
+		require.False(t, ls.Contains("mallory"))
+		require.Equal(t, Deny, ls.Evaluate("mallory"))
+		require.Equal(t, Deny, ls.Evaluate("eve"))
+	})
+
+	t.Run("Default effect applies to unmatched values", func(t *testing.T) {
+		ls := New(
+			WithDefaultEffect[int](Allow),
+			WithLayers(Layer[int]{Set: orderedset.Of(1, 2), Effect: Deny}),
+		)
+
+		require.Equal(t, Allow, ls.DefaultEffect())
+		require.False(t, ls.Contains(1))
+		require.True(t, ls.Contains(3))
+	})
+
+	t.Run("No layers", func(t *testing.T) {
+		ls := New[int]()
+		require.Empty(t, ls.Layers())
+		require.Equal(t, Deny, ls.Evaluate(1))
+	})
+
+	t.Run("Effect names", func(t *testing.T) {
+		require.Equal(t, "Allow", Allow.String())
+		require.Equal(t, "Deny", Deny.String())
+		require.Equal(t, "Effect(0)", Effect(0).String())
+	})
+}
+
+func TestLayers(t *testing.T) {
+
+	t.Run("SetLayers replaces all layers", func(t *testing.T) {
+		ls := New(WithLayers(Layer[int]{Set: hashset.Of(1), Effect: Allow}))
+		ls.SetLayers(Layer[int]{Set: hashset.Of(2), Effect: Allow})
+
+		require.False(t, ls.Contains(1))
+		require.True(t, ls.Contains(2))
+	})
+
+	t.Run("Layers returns a copy", func(t *testing.T) {
+		layers := []Layer[int]{{Set: hashset.Of(1), Effect: Allow}}
+		ls := New(WithLayers(layers...))
+		layers[0].Effect = Deny
+		ls.Layers()[0].Effect = Deny
+
+		require.True(t, ls.Contains(1))
+	})
+
+	t.Run("ReplaceLayer keeps effect and position", func(t *testing.T) {
+		ls := New(WithLayers(
+			Layer[int]{Set: hashset.Of(1), Effect: Deny},
+			Layer[int]{Set: hashset.Of(1, 2), Effect: Allow},
+		))
+		ls.ReplaceLayer(0, hashset.Of(2))
+
+		require.True(t, ls.Contains(1))
+		require.False(t, ls.Contains(2))
+		require.Equal(t, Deny, ls.Layers()[0].Effect)
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		ls := New(WithLayers(Layer[int]{Set: hashset.Of(1), Effect: Allow}))
+
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "index"), func() { ls.ReplaceLayer(1, hashset.Of(1)) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "set"), func() { ls.ReplaceLayer(0, nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "set"), func() { ls.SetLayers(Layer[int]{Effect: Allow}) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "effect"), func() { ls.SetLayers(Layer[int]{Set: hashset.Of(1)}) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "effect"), func() { WithDefaultEffect[int](Effect(3)) })
+	})
+}
+
+func TestConcurrentSwap(t *testing.T) {
+
+	// Each generation allows exactly one value, so a reader must never
+	// see a value allowed by both the old and new layers.
+	ls := New(WithLayers(Layer[int]{Set: hashset.Of(0), Effect: Allow}))
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			ls.SetLayers(Layer[int]{Set: hashset.Of(i), Effect: Allow})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			layers := ls.Layers()
+			require.Len(t, layers, 1)
+			require.Equal(t, 1, layers[0].Set.Count())
+		}
+	}()
+
+	wg.Wait()
+	require.True(t, ls.Contains(1000))
+}
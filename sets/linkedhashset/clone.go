@@ -0,0 +1,20 @@
+package linkedhashset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Clone returns a new LinkedHashSet holding the values of this set, copied by value.
+// The result is that of Select with a predicate that is always true.
+func (s *LinkedHashSet[T]) Clone() collections.Collection[T] {
+
+	return s.Select(util.DefaultPredicate[T])
+}
+
+// CloneDeep returns a new LinkedHashSet holding the values of this set, copied using the provided
+// [functions.DeepCopyFunc] if any. The result is that of SelectDeep with a predicate that is always true.
+func (s *LinkedHashSet[T]) CloneDeep() collections.Collection[T] {
+
+	return s.SelectDeep(util.DefaultPredicate[T])
+}
@@ -0,0 +1,342 @@
+package linkedhashset
+
+import (
+	"errors"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Enumerable[int] = (*LinkedHashSet[int])(nil)
+
+// Any returns true for the first element found where the predicate function returns true.
+// It returns false if no element matches the predicate.
+func (s *LinkedHashSet[T]) Any(predicate functions.PredicateFunc[T]) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	for n := s.head; n != nil; n = n.next {
+		if predicate(n.item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All applies the predicate function to every element in the collection,
+// and returns true if all elements match the predicate.
+func (s *LinkedHashSet[T]) All(predicate functions.PredicateFunc[T]) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	for n := s.head; n != nil; n = n.next {
+		if !predicate(n.item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ForEach applies function f to all elements in the collection,
+// in the order in which they were added.
+func (s *LinkedHashSet[T]) ForEach(f func(collections.Element[T])) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	for n := s.head; n != nil; n = n.next {
+		f(util.NewElementType[T](s, &n.item, s.compare))
+	}
+}
+
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (s *LinkedHashSet[T]) TryForEach(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	for n := s.head; n != nil; n = n.next {
+		if err := f(n.item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (s *LinkedHashSet[T]) TryForEachAll(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	var errs []error
+
+	for n := s.head; n != nil; n = n.next {
+		if err := f(n.item); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Map applies function f to all elements in the collection
+// and returns a new LinkedHashSet containing the result of f,
+// in the order of the values from which they were computed.
+func (s *LinkedHashSet[T]) Map(f func(T) T) collections.Collection[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	s1 := s.makeEmptyCopy(false)
+
+	for n := s.head; n != nil; n = n.next {
+		s1.insert(f(n.item))
+	}
+
+	return s1
+}
+
+// Select returns a new LinkedHashSet containing only the items for which predicate is true.
+func (s *LinkedHashSet[T]) Select(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.doSelect(predicate, false)
+}
+
+// SelectDeep returns a new LinkedHashSet containing only the items for which predicate is true
+//
+// Elements are deep copied to the new collection using the provided [functions.DeepCopyFunc] if any.
+func (s *LinkedHashSet[T]) SelectDeep(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.doSelect(predicate, true)
+}
+
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Values are copied in the order in which they were added.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (s *LinkedHashSet[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(s.copyWhere(predicate))
+}
+
+// Find finds the earliest added element matching the predicate.
+//
+// The function returns nil if no match.
+func (s *LinkedHashSet[T]) Find(predicate functions.PredicateFunc[T]) collections.Element[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	result := s.find(predicate, false)
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result[0]
+}
+
+// FindAll finds all occurrences of an element matching the predicate,
+// in the order in which they were added.
+//
+// The function returns an empty slice if none match.
+func (s *LinkedHashSet[T]) FindAll(predicate functions.PredicateFunc[T]) []collections.Element[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.find(predicate, true)
+}
+
+// Min returns the minimum value in the collection according to the Comparer function.
+func (s *LinkedHashSet[T]) Min() T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.head == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	m := s.head.item
+
+	for n := s.head.next; n != nil; n = n.next {
+		if s.compare(m, n.item) > 0 {
+			m = n.item
+		}
+	}
+
+	return m
+}
+
+// Max returns the maximum value in the collection according to the Comparer function.
+func (s *LinkedHashSet[T]) Max() T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.head == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	m := s.head.item
+
+	for n := s.head.next; n != nil; n = n.next {
+		if s.compare(m, n.item) < 0 {
+			m = n.item
+		}
+	}
+
+	return m
+}
+
+func (s *LinkedHashSet[T]) find(predicate functions.PredicateFunc[T], all bool) []collections.Element[T] {
+
+	result := make([]collections.Element[T], 0, util.DefaultCapacity)
+
+	for n := s.head; n != nil; n = n.next {
+		if predicate(n.item) {
+			result = append(result, util.NewElementType[T](s, &n.item, s.compare))
+
+			if !all {
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func (s *LinkedHashSet[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
+	s1 := s.makeEmptyCopy(false)
+
+	for n := s.head; n != nil; n = n.next {
+		if predicate(n.item) {
+			if deepCopy {
+				s1.insert(s1.copy(n.item))
+			} else {
+				s1.insert(n.item)
+			}
+		}
+	}
+
+	return s1
+}
+
+func (s *LinkedHashSet[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+
+	for n := s.head; n != nil; n = n.next {
+		if predicate(n.item) {
+			values = append(values, util.DeepCopy(n.item, s.copy))
+		}
+	}
+
+	return values
+}
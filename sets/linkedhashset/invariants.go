@@ -0,0 +1,33 @@
+package linkedhashset
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the set is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (s *LinkedHashSet[T]) verifyInvariants() {
+	count := 0
+
+	for hash, bucket := range s.buckets {
+		util.AssertInvariant(len(bucket) > 0, "set has empty bucket")
+
+		for _, n := range bucket {
+			util.AssertInvariant(s.hasher(n.item) == hash, "set value is in wrong bucket")
+		}
+
+		count += len(bucket)
+	}
+
+	util.AssertInvariant(count == s.size, "set size does not match values")
+
+	var prev *node[T]
+	linked := 0
+
+	for n := s.head; n != nil; n = n.next {
+		util.AssertInvariant(n.prev == prev, "set list is not doubly linked")
+		prev = n
+		linked++
+	}
+
+	util.AssertInvariant(prev == s.tail, "set list does not end at tail")
+	util.AssertInvariant(linked == s.size, "set size does not match list")
+}
@@ -0,0 +1,162 @@
+package linkedhashset
+
+import (
+	"math/rand"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+type direction bool
+
+const (
+	forward, reverse direction = true, false
+)
+
+// Assert interface implementation.
+var _ collections.Iterable[int] = (*LinkedHashSet[int])(nil)
+
+// LinkedHashSetIterator implements an iterator over the elements in the set.
+type LinkedHashSetIterator[T any] struct {
+	util.IteratorBase[T]
+	set       *LinkedHashSet[T]
+	current   *node[T]
+	predicate functions.PredicateFunc[T]
+	direction direction
+
+	local.InternalImpl
+}
+
+func newIterator[T any](set *LinkedHashSet[T], predicate functions.PredicateFunc[T], direction direction) collections.Iterator[T] {
+	return &LinkedHashSetIterator[T]{
+		set:       set,
+		predicate: predicate,
+		direction: direction,
+		IteratorBase: util.IteratorBase[T]{
+			Version:    set.version,
+			NilElement: nil,
+		},
+	}
+}
+
+// Iterator returns a forward iterator that walks the set in the order in which values were added.
+//
+//	iter := set.Iterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (s *LinkedHashSet[T]) Iterator() collections.Iterator[T] {
+
+	return newIterator(s, util.DefaultPredicate[T], forward)
+}
+
+// ReverseIterator returns an iterator that walks the set from the most recently added value to the earliest.
+//
+//	iter := set.ReverseIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (s *LinkedHashSet[T]) ReverseIterator() collections.Iterator[T] {
+
+	return newIterator(s, util.DefaultPredicate[T], reverse)
+}
+
+// TakeWhile returns a forward iterater that walks the collection returning only
+// those elements for which predicate returns true.
+//
+//	set := linkedhashset.New[int]()
+//	// add values
+//	iter := set.TakeWhile(func (val int) bool { return val % 2 == 0 })
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (s *LinkedHashSet[T]) TakeWhile(predicate functions.PredicateFunc[T]) collections.Iterator[T] {
+
+	return newIterator(s, predicate, forward)
+}
+
+// SampleIterator returns an iterator that yields n values chosen at random from the set,
+// without replacement and in random order. If the set holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the set.
+// If r is nil, the default source of the math/rand package is used.
+//
+// Panics if n is negative.
+func (s *LinkedHashSet[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](s, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the set at the time of the call,
+// in the same order as ToSlice, so that the set may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the set's immutable slice, so it is only copied if the set has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the set, and their ValuePtr method panics.
+//
+//	iter := s.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		s.Remove(e.Value())
+//	}
+func (s *LinkedHashSet[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(s.ToImmutableSlice())
+}
+
+// Start begins an iteration across the set returning the fisrt element,
+// which will be nil if the collection is empty.
+//
+// Panics if the set has been modified since creation of the iterator.
+func (i *LinkedHashSetIterator[T]) Start() collections.Element[T] {
+	i.validateIterator()
+
+	if i.direction == forward {
+		i.current = i.set.head
+	} else {
+		i.current = i.set.tail
+	}
+
+	if i.current == nil {
+		return i.NilElement
+	}
+
+	if !i.predicate(i.current.item) {
+		return i.Next()
+	}
+
+	return util.NewElementType[T](i.set, &i.current.item, i.set.compare)
+}
+
+// Next returns the next element in the set,
+// which will be nil if the end has been reached.
+//
+// Panics if the set has been modified since creation of the iterator.
+func (i *LinkedHashSetIterator[T]) Next() collections.Element[T] {
+	i.validateIterator()
+
+	for i.current != nil {
+		if i.direction == forward {
+			i.current = i.current.next
+		} else {
+			i.current = i.current.prev
+		}
+
+		if i.current != nil && i.predicate(i.current.item) {
+			return util.NewElementType[T](i.set, &i.current.item, i.set.compare)
+		}
+	}
+
+	return i.NilElement
+}
+
+func (i *LinkedHashSetIterator[T]) validateIterator() {
+	if i.Version != i.set.version {
+		panic(messages.COLLECTION_MODIFIED)
+	}
+}
@@ -0,0 +1,864 @@
+/*
+Package linkedhashset provides a collection of unique items that remembers the order in which they were added.
+
+Values are located by hashing, as in a HashSet, so Add, Remove and Contains are O(1) on average,
+and are also threaded on a doubly linked list, so that ToSlice and iteration visit them in the order
+in which they were first added, e.g. to remove duplicates from a sequence without reordering it.
+*/
+package linkedhashset
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+	"github.com/fireflycons/generic_collections/sets"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"golang.org/x/exp/constraints"
+)
+
+// Assert LinkedHashSet implements required interfaces.
+var _ sets.Set[int] = (*LinkedHashSet[int])(nil)
+var _ collections.ReverseIterable[int] = (*LinkedHashSet[int])(nil)
+var _ collections.Pageable[int] = (*LinkedHashSet[int])(nil)
+
+// LinkedHashSetOptionFunc is the signature of a function
+// for providing options to the LinkedHashSet constructor.
+type LinkedHashSetOptionFunc[T any] func(*LinkedHashSet[T])
+
+// LinkedHashSet stores a collection of unique elements in the order in which they were added.
+//
+// The zero value is an empty set with default options, ready to use. It is not thread-safe.
+type LinkedHashSet[T any] struct {
+	version      int
+	lock         *sync.RWMutex
+	head         *node[T]
+	tail         *node[T]
+	size         int
+	capacity     int
+	hasher       functions.HashFunc[T]
+	compare      functions.ComparerFunc[T]
+	copy         functions.DeepCopyFunc[T]
+	copyPolicy   collections.CopyPolicy
+	buckets      map[uintptr][]*node[T]
+	concurrent   bool
+	recorder     util.OpRecorder[T]
+	guard        util.AccessGuard
+	immutable    util.SliceCache[T]
+	validator    *util.Validator[T]
+	verifySchema bool
+	lastSnapshot *snapshot[T]
+	name         string
+	registration *registry.Registration
+	local.InternalImpl
+}
+
+// node is a single element of the set, linked to those added before and after it.
+type node[T any] struct {
+	item T
+	prev *node[T]
+	next *node[T]
+}
+
+// Constructs a new LinkedHashSet[T].
+func New[T any](options ...LinkedHashSetOptionFunc[T]) *LinkedHashSet[T] {
+	s := &LinkedHashSet[T]{}
+
+	for _, o := range options {
+		o(s)
+	}
+
+	if s.hasher == nil {
+		// Will panic if T is not comparable
+		s.hasher = hashset.DefaultHasher[T]()
+	}
+
+	if s.copy == nil || s.copyPolicy == collections.Shallow {
+		s.copy = util.DefaultDeepCopy[T]
+	}
+
+	if s.compare == nil {
+		s.compare = util.GetDefaultComparer[T]()
+	}
+
+	if s.capacity == 0 {
+		s.capacity = util.DefaultCapacity
+	}
+
+	s.buckets = make(map[uintptr][]*node[T], s.capacity)
+	s.register()
+
+	return s
+}
+
+// lazyInit completes the construction of a zero value LinkedHashSet on its first modification,
+// as New would have done.
+func (s *LinkedHashSet[T]) lazyInit() {
+	if s.compare != nil {
+		return
+	}
+
+	s.compare = util.GetZeroValueComparer[T]()
+
+	if s.hasher == nil {
+		s.hasher = hashset.DefaultHasher[T]()
+	}
+
+	if s.copy == nil {
+		s.copy = util.DefaultDeepCopy[T]
+	}
+
+	if s.capacity == 0 {
+		s.capacity = util.DefaultCapacity
+	}
+
+	s.buckets = make(map[uintptr][]*node[T], s.capacity)
+}
+
+// Of constructs a new set containing the given values in the order given, e.g. linkedhashset.Of(3, 1, 2).
+//
+// Duplicate values are added once, at the position of their first occurrence.
+func Of[T any](values ...T) *LinkedHashSet[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	s := linkedhashset.OfWith([]linkedhashset.LinkedHashSetOptionFunc[int]{linkedhashset.WithThreadSafe[int]()}, 3, 1, 2)
+func OfWith[T any](options []LinkedHashSetOptionFunc[T], values ...T) *LinkedHashSet[T] {
+	s := New(options...)
+	s.AddRange(values)
+	return s
+}
+
+// NewFunc is as [New], using compare to determine whether values are equal.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...LinkedHashSetOptionFunc[T]) *LinkedHashSet[T] {
+	return New(append([]LinkedHashSetOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...LinkedHashSetOptionFunc[T]) *LinkedHashSet[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[T any]() LinkedHashSetOptionFunc[T] {
+	return func(s *LinkedHashSet[T]) {
+		s.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function to enable concurrency feature.
+func WithConcurrent[T any]() LinkedHashSetOptionFunc[T] {
+	return func(s *LinkedHashSet[T]) {
+		s.concurrent = true
+	}
+}
+
+// Option function for New to provide a hash function for values of type T.
+// Required if the element type is not numeric, bool, pointer, string or time.Time.
+//
+// Values that are equal according to the comparer must have the same hash.
+func WithHasher[T any](hasher functions.HashFunc[T]) LinkedHashSetOptionFunc[T] {
+	return func(s *LinkedHashSet[T]) {
+		s.hasher = hasher
+	}
+}
+
+// Option function for New to provide a comparer function for values of type T.
+// Required if the element type is not numeric, bool, pointer or string.
+func WithComparer[T any](comparer functions.ComparerFunc[T]) LinkedHashSetOptionFunc[T] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(s *LinkedHashSet[T]) {
+		s.compare = comparer
+	}
+}
+
+// Option func to provide a deep copy implementation for collection elements.
+func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) LinkedHashSetOptionFunc[T] {
+	// Can be nil
+	return func(s *LinkedHashSet[T]) {
+		s.copy = copier
+	}
+}
+
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) LinkedHashSetOptionFunc[T] {
+	return func(s *LinkedHashSet[T]) {
+		s.copyPolicy = policy
+	}
+}
+
+// Option function for New to set the initial capacity of the hash table
+// to something other than the default 16 values.
+func WithCapacity[T any](capacity int) LinkedHashSetOptionFunc[T] {
+	if capacity < 0 {
+		panic(messages.NEGATIVE_CAPACITY)
+	}
+	return func(s *LinkedHashSet[T]) {
+		s.capacity = capacity
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+func WithOptions[T any](options collections.CommonOptions[T]) LinkedHashSetOptionFunc[T] {
+	opts := make([]LinkedHashSetOptionFunc[T], 0, 6)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Concurrent {
+		opts = append(opts, WithConcurrent[T]())
+	}
+
+	if options.Capacity != 0 {
+		opts = append(opts, WithCapacity[T](options.Capacity))
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(s *LinkedHashSet[T]) {
+		for _, o := range opts {
+			o(s)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection
+// and ReplaceAll with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) LinkedHashSetOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(s *LinkedHashSet[T]) {
+		s.validator = util.NewValidator(validator, policy)
+	}
+}
+
+// Add adds a value to the end of the set. O(1) on average.
+//
+// Returns false if the value is already present, in which case its position is unchanged,
+// or was rejected by the validator; else true if it was added.
+func (s *LinkedHashSet[T]) Add(value T) bool {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if !s.validator.Accept(value) {
+		return false
+	}
+
+	inserted := s.insert(value)
+	s.version++
+	return inserted
+}
+
+// AddRange adds a slice of values to the end of the set, in the order given.
+// Values already present keep their positions.
+func (s *LinkedHashSet[T]) AddRange(values []T) {
+
+	if len(values) == 0 {
+		return
+	}
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if values = s.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
+	s.version++
+
+	for _, v := range values {
+		s.insert(v)
+	}
+}
+
+// AddCollection inserts the values of the given collection into this set.
+// Values are added in the order defined by the other collection.
+func (s *LinkedHashSet[T]) AddCollection(collection collections.Collection[T]) {
+
+	s.AddRange(util.ImportValues(collection, s.copyPolicy))
+}
+
+// ReplaceAll replaces the content of the set with the values of the given collection,
+// in the order defined by the other collection.
+func (s *LinkedHashSet[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	s.replaceAll(util.ImportValues(collection, s.copyPolicy))
+}
+
+func (s *LinkedHashSet[T]) replaceAll(values []T) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	values = s.validator.Filter(values)
+
+	s.reset()
+	s.recorder.Clear()
+
+	for _, v := range values {
+		s.insert(v)
+	}
+
+	s.version++
+}
+
+// Contains returns true if the value is present in the set. O(1) on average.
+func (s *LinkedHashSet[T]) Contains(value T) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.lookup(value) != nil
+}
+
+func (s *LinkedHashSet[T]) UnlockedContains(value T) bool {
+	return s.lookup(value) != nil
+}
+
+// Get returns the collection element that matches the given value, or nil if it is not found.
+// Useful if the comparer matches struct elements on a key, to retrieve the stored element.
+func (s *LinkedHashSet[T]) Get(value T) collections.Element[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	n := s.lookup(value)
+
+	if n == nil {
+		return nil
+	}
+
+	return util.NewElementType[T](s, &n.item, s.compare)
+}
+
+// Remove removes the given value from the set. O(1) on average.
+// The order of the remaining values is unchanged.
+//
+// Returns true if the value was present; else false.
+func (s *LinkedHashSet[T]) Remove(value T) bool {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.version++
+	return s.remove(value)
+}
+
+// Count returns the number of values in the set.
+func (s *LinkedHashSet[T]) Count() int {
+
+	return s.size
+}
+
+// IsEmpty returns true if the set has no values.
+func (s *LinkedHashSet[T]) IsEmpty() bool {
+
+	return s.size == 0
+}
+
+// ToSlice returns the collection content as a slice,
+// in the order in which the values were added.
+func (s *LinkedHashSet[T]) ToSlice() []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.toSlice(false)
+}
+
+// ToImmutableSlice returns the content of the set as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the set has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified.
+func (s *LinkedHashSet[T]) ToImmutableSlice() []T {
+
+	if s.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		s.lock.RLock()
+		values, ok := s.immutable.Load(s.version)
+		s.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	return s.immutable.Get(s.version, func() []T {
+		return s.toSlice(false)
+	})
+}
+
+// ToSliceDeep returns the collection content as a slice,
+// in the order in which the values were added.
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (s *LinkedHashSet[T]) ToSliceDeep() []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.toSlice(true)
+}
+
+// Page returns a copy of the values on the given zero-based page of the set,
+// in the order in which they were added.
+//
+// Panics if pageIndex is negative or pageSize is less than 1.
+func (s *LinkedHashSet[T]) Page(pageIndex, pageSize int) []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	start, end := util.PageBounds(s.size, pageIndex, pageSize)
+	page := make([]T, 0, end-start)
+
+	// Walk from whichever end of the list is nearer the page.
+	if start < s.size-end {
+		n := s.head
+		for i := 0; i < start; i++ {
+			n = n.next
+		}
+
+		for i := start; i < end; i++ {
+			page = append(page, n.item)
+			n = n.next
+		}
+
+		return page
+	}
+
+	n := s.tail
+	for i := s.size - 1; i >= end; i-- {
+		n = n.prev
+	}
+
+	page = page[:end-start]
+
+	for i := end - 1; i >= start; i-- {
+		page[i-start] = n.item
+		n = n.prev
+	}
+
+	return page
+}
+
+// Clear removes all values from the set.
+func (s *LinkedHashSet[T]) Clear() {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.clear()
+}
+
+// String returns a string representation of container.
+func (s *LinkedHashSet[T]) String() string {
+
+	var values []string
+	for _, value := range s.ToSlice() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+
+	return "LinkedHashSet\n" + strings.Join(values, ", ")
+}
+
+// Type returns the type of the collection (to avoid reflecting).
+func (s *LinkedHashSet[T]) Type() collections.CollectionType {
+	return collections.COLLECTION_LINKEDHASHSET
+}
+
+// Difference returns the difference between two sets.
+// The new set consists of all elements that are in this set, but not other set,
+// in the order of this set.
+//
+// The argument can be any implementation of Set[T]. The result is a new LinkedHashSet with the same properties as this one.
+// Items are shallow-copied.
+func (s *LinkedHashSet[T]) Difference(other sets.Set[T]) sets.Set[T] {
+
+	ol := util.GetLock[T](other)
+
+	if ol != nil {
+		ol.RLock()
+		defer ol.RUnlock()
+	}
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	result := s.makeEmptyCopy(s.lock != nil)
+
+	for n := s.head; n != nil; n = n.next {
+		if !other.UnlockedContains(n.item) {
+			result.insert(n.item)
+		}
+	}
+
+	return result
+}
+
+// Intersection returns the intersection between two sets.
+// The new set consists of all elements that are in both this set and the other,
+// in the order of this set.
+//
+// The argument can be any implementation of Set[T]. The result is a new LinkedHashSet with the same properties as this one.
+// Items are shallow-copied.
+func (s *LinkedHashSet[T]) Intersection(other sets.Set[T]) sets.Set[T] {
+
+	ol := util.GetLock[T](other)
+
+	if ol != nil {
+		ol.RLock()
+		defer ol.RUnlock()
+	}
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	result := s.makeEmptyCopy(s.lock != nil)
+
+	for n := s.head; n != nil; n = n.next {
+		if other.UnlockedContains(n.item) {
+			result.insert(n.item)
+		}
+	}
+
+	return result
+}
+
+// Union returns the union of two sets.
+// The new set consists of all elements that are in buth this and the other set,
+// with those of this set first in its order, followed by those only in the other.
+//
+// The argument can be any implementation of Set[T]. The result is a new LinkedHashSet with the same properties as this one.
+// Items are shallow-copied.
+func (s *LinkedHashSet[T]) Union(other sets.Set[T]) sets.Set[T] {
+
+	ol := util.GetLock[T](other)
+
+	if ol != nil {
+		ol.RLock()
+		defer ol.RUnlock()
+	}
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	result := s.makeEmptyCopy(s.lock != nil)
+	result.AddCollection(s)
+	result.AddCollection(other)
+	return result
+}
+
+// First returns the value that was added to the set earliest.
+//
+// Panics if the set is empty.
+func (s *LinkedHashSet[T]) First() T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.head == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return s.head.item
+}
+
+// Last returns the value that was added to the set most recently.
+//
+// Panics if the set is empty.
+func (s *LinkedHashSet[T]) Last() T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.tail == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return s.tail.item
+}
+
+func (s *LinkedHashSet[T]) toSlice(deepCopy bool) []T {
+	slc := make([]T, 0, s.size)
+
+	for n := s.head; n != nil; n = n.next {
+		if deepCopy {
+			slc = append(slc, util.DeepCopy(n.item, s.copy))
+		} else {
+			slc = append(slc, n.item)
+		}
+	}
+
+	return slc
+}
+
+func (s *LinkedHashSet[T]) lookup(value T) *node[T] {
+	if s.buckets == nil {
+		return nil
+	}
+
+	for _, n := range s.buckets[s.hasher(value)] {
+		if s.compare(value, n.item) == 0 {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// insert adds value at the end of the list if it is not already present.
+func (s *LinkedHashSet[T]) insert(value T) bool {
+	hash := s.hasher(value)
+	bucket := s.buckets[hash]
+
+	for _, n := range bucket {
+		if s.compare(value, n.item) == 0 {
+			return false
+		}
+	}
+
+	n := &node[T]{
+		item: value,
+		prev: s.tail,
+	}
+
+	if s.tail == nil {
+		s.head = n
+	} else {
+		s.tail.next = n
+	}
+
+	s.tail = n
+	s.buckets[hash] = append(bucket, n)
+	s.size++
+	s.recorder.Add(value)
+	return true
+}
+
+// remove unlinks value from the list and its hash bucket.
+func (s *LinkedHashSet[T]) remove(value T) bool {
+	hash := s.hasher(value)
+	bucket := s.buckets[hash]
+
+	for i, n := range bucket {
+		if s.compare(value, n.item) != 0 {
+			continue
+		}
+
+		if len(bucket) == 1 {
+			delete(s.buckets, hash)
+		} else {
+			bucket[i] = bucket[len(bucket)-1]
+			bucket[len(bucket)-1] = nil
+			s.buckets[hash] = bucket[:len(bucket)-1]
+		}
+
+		s.unlink(n)
+		s.size--
+		s.recorder.Remove(value)
+		return true
+	}
+
+	return false
+}
+
+func (s *LinkedHashSet[T]) unlink(n *node[T]) {
+	if n.prev == nil {
+		s.head = n.next
+	} else {
+		n.prev.next = n.next
+	}
+
+	if n.next == nil {
+		s.tail = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+
+	n.prev = nil
+	n.next = nil
+}
+
+// reset empties the list and hash table, restoring the table to its initial capacity.
+func (s *LinkedHashSet[T]) reset() {
+	s.head = nil
+	s.tail = nil
+	s.size = 0
+	s.buckets = make(map[uintptr][]*node[T], s.capacity)
+}
+
+func (s *LinkedHashSet[T]) clear() {
+	s.reset()
+	s.version++
+	s.recorder.Clear()
+}
+
+// makeEmptyCopy returns a new, empty set with the same options as this one.
+func (s *LinkedHashSet[T]) makeEmptyCopy(threadSafe bool) *LinkedHashSet[T] {
+	other := &LinkedHashSet[T]{
+		hasher:     s.hasher,
+		compare:    s.compare,
+		copy:       s.copy,
+		copyPolicy: s.copyPolicy,
+		capacity:   s.capacity,
+		concurrent: s.concurrent,
+	}
+
+	if threadSafe {
+		other.lock = &sync.RWMutex{}
+	}
+
+	// Completes construction of a copy of a zero value set
+	other.lazyInit()
+
+	if other.buckets == nil {
+		other.buckets = make(map[uintptr][]*node[T], other.capacity)
+	}
+
+	return other
+}
@@ -0,0 +1,281 @@
+package linkedhashset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"strings"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/fireflycons/generic_collections/sets"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructor(t *testing.T) {
+
+	t.Run("With comparer", func(t *testing.T) {
+		magic := 42
+		comp := func(v1, v2 int) int { return magic }
+		set := New(WithComparer(comp))
+
+		require.Equal(t, magic, set.compare(1, 0))
+	})
+
+	t.Run("With common options", func(t *testing.T) {
+		magic := 42
+		opts := collections.CommonOptions[int]{
+			ThreadSafe: true,
+			Capacity:   100,
+			Comparer:   func(v1, v2 int) int { return magic },
+		}
+		set := New(WithOptions(opts))
+
+		require.NotNil(t, set.lock)
+		require.Equal(t, 100, set.capacity)
+		require.Equal(t, magic, set.compare(1, 0))
+	})
+
+	t.Run("With nil comparer panics", func(t *testing.T) {
+		var comp func(v1, v2 int) int
+		require.Panics(t, func() { New(WithComparer(comp)) })
+	})
+
+	t.Run("With negative capacity panics", func(t *testing.T) {
+		require.PanicsWithValue(t, messages.NEGATIVE_CAPACITY, func() { New(WithCapacity[int](-1)) })
+	})
+}
+
+func TestInsertionOrder(t *testing.T) {
+
+	t.Run("Duplicates keep first position", func(t *testing.T) {
+		s := Of(3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5)
+
+		require.Equal(t, []int{3, 1, 4, 5, 9, 2, 6}, s.ToSlice())
+		require.Equal(t, 7, s.Count())
+		require.False(t, s.Add(4))
+		require.Equal(t, []int{3, 1, 4, 5, 9, 2, 6}, s.ToSlice())
+	})
+
+	t.Run("Remove preserves order of the rest", func(t *testing.T) {
+		s := Of(3, 1, 4, 5, 9)
+
+		require.True(t, s.Remove(3))
+		require.True(t, s.Remove(5))
+		require.True(t, s.Remove(9))
+		require.False(t, s.Remove(9))
+		require.Equal(t, []int{1, 4}, s.ToSlice())
+		require.Equal(t, 1, s.First())
+		require.Equal(t, 4, s.Last())
+
+		// Re-adding a removed value puts it at the end
+		s.Add(3)
+		require.Equal(t, []int{1, 4, 3}, s.ToSlice())
+	})
+
+	t.Run("Colliding hashes", func(t *testing.T) {
+		s := New(WithHasher(func(v int) uintptr { return uintptr(v % 3) }))
+
+		for i := 0; i < 30; i++ {
+			s.Add(29 - i)
+		}
+
+		for i := 0; i < 30; i += 2 {
+			require.True(t, s.Remove(i))
+		}
+
+		for i := 0; i < 30; i++ {
+			require.Equal(t, i%2 == 1, s.Contains(i))
+		}
+
+		require.Equal(t, []int{29, 27, 25, 23, 21, 19, 17, 15, 13, 11, 9, 7, 5, 3, 1}, s.ToSlice())
+	})
+
+	t.Run("First and Last of empty set panic", func(t *testing.T) {
+		s := New[int]()
+
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { s.First() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { s.Last() })
+	})
+}
+
+func TestGet(t *testing.T) {
+
+	type entry struct {
+		key   string
+		value int
+	}
+
+	s := New(
+		WithHasher(func(e entry) uintptr { return uintptr(len(e.key)) }),
+		WithComparer(func(a, b entry) int { return strings.Compare(a.key, b.key) }),
+	)
+
+	s.Add(entry{"a", 1})
+	s.Add(entry{"b", 2})
+
+	require.Equal(t, 2, s.Get(entry{key: "b"}).Value().value)
+	require.Nil(t, s.Get(entry{key: "c"}))
+	require.Panics(t, func() { s.Get(entry{key: "a"}).ValuePtr() })
+}
+
+func TestSetOperations(t *testing.T) {
+
+	s := Of(5, 1, 4, 2, 3)
+	other := orderedset.Of(4, 5, 6, 7)
+
+	require.Equal(t, []int{1, 2, 3}, s.Difference(other).ToSlice())
+	require.Equal(t, []int{5, 4}, s.Intersection(other).ToSlice())
+	require.Equal(t, []int{5, 1, 4, 2, 3, 6, 7}, s.Union(other).ToSlice())
+
+	ts := OfWith([]LinkedHashSetOptionFunc[int]{WithThreadSafe[int]()}, 1, 2)
+	require.NotNil(t, ts.Union(Of(3)).(*LinkedHashSet[int]).lock)
+}
+
+func TestPage(t *testing.T) {
+
+	s := New[int]()
+
+	for i := 0; i < 10; i++ {
+		s.Add(9 - i)
+	}
+
+	require.Equal(t, []int{9, 8, 7}, s.Page(0, 3))
+	require.Equal(t, []int{6, 5, 4}, s.Page(1, 3))
+	require.Equal(t, []int{3, 2, 1}, s.Page(2, 3))
+	require.Equal(t, []int{0}, s.Page(3, 3))
+	require.Empty(t, s.Page(4, 3))
+}
+
+func TestIterator(t *testing.T) {
+
+	s := Of(3, 1, 2)
+
+	collect := func(iter collections.Iterator[int]) []int {
+		var values []int
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+		}
+		return values
+	}
+
+	require.Equal(t, []int{3, 1, 2}, collect(s.Iterator()))
+	require.Equal(t, []int{2, 1, 3}, collect(s.ReverseIterator()))
+	require.Equal(t, []int{2}, collect(s.TakeWhile(func(v int) bool { return v%2 == 0 })))
+	require.Nil(t, New[int]().ReverseIterator().Start())
+
+	iter := s.Iterator()
+	s.Add(4)
+	require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Start() })
+}
+
+func TestEnumerable(t *testing.T) {
+
+	s := Of(3, 1, 4, 5, 9, 2, 6)
+
+	require.Equal(t, 1, s.Min())
+	require.Equal(t, 9, s.Max())
+	require.Equal(t, 4, s.Find(func(v int) bool { return v%2 == 0 }).Value())
+	require.Len(t, s.FindAll(func(v int) bool { return v%2 == 0 }), 3)
+	require.Equal(t, []int{4, 2, 6}, s.Select(func(v int) bool { return v%2 == 0 }).ToSlice())
+	require.Equal(t, []int{6, 2, 8, 10, 18, 4, 12}, s.Map(func(v int) int { return v * 2 }).ToSlice())
+	require.True(t, s.Any(func(v int) bool { return v == 9 }))
+	require.False(t, s.All(func(v int) bool { return v < 9 }))
+	require.Equal(t, "LinkedHashSet\n3, 1, 4, 5, 9, 2, 6", s.String())
+}
+
+func TestApplyOpsAndRecording(t *testing.T) {
+
+	s := Of(1, 2, 3)
+	s.ApplyOps([]ops.Op[int]{ops.Remove(1), ops.Add(1), ops.Add(4)})
+	require.Equal(t, []int{2, 3, 1, 4}, s.ToSlice())
+
+	s.StartRecording()
+	s.Add(4)
+	s.Remove(2)
+	s.Clear()
+	require.Equal(t, []ops.Op[int]{ops.Remove(2), ops.Clear[int]()}, s.StopRecording())
+}
+
+func TestSnapshot(t *testing.T) {
+
+	s := New[int]()
+	for i := 0; i < 100; i++ {
+		s.Add(99 - i)
+	}
+
+	before := s.Snapshot()
+	require.Same(t, before, s.Snapshot())
+	require.Equal(t, 99, before.ToSlice()[0])
+
+	s.Remove(10)
+	s.Remove(20)
+	s.Add(1000)
+	s.Add(-1)
+
+	added, removed := sets.DiffSnapshots(before, s.Snapshot())
+	require.Equal(t, []int{1000, -1}, added)
+	require.Equal(t, []int{20, 10}, removed)
+}
+
+func TestGob(t *testing.T) {
+
+	s := Of(3, 1, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(s))
+
+	decoded := New[int]()
+	require.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	require.Equal(t, []int{3, 1, 2}, decoded.ToSlice())
+}
+
+func TestZeroValue(t *testing.T) {
+
+	var s LinkedHashSet[int]
+
+	require.True(t, s.IsEmpty())
+	require.False(t, s.Contains(1))
+	require.Nil(t, s.Get(1))
+	require.Empty(t, s.ToSlice())
+	require.Nil(t, s.Iterator().Start())
+	require.Empty(t, s.Select(func(int) bool { return true }).ToSlice())
+
+	s.AddRange([]int{30, 10, 20, 10})
+	require.Equal(t, []int{30, 10, 20}, s.ToSlice())
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithHasher(func(v *int) uintptr { return uintptr(*v) }))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithHasher(func(v *int) uintptr { return uintptr(*v) }), WithValidator(validator, policy))
+		},
+	})
+}
+
+func TestTryAdd(t *testing.T) {
+
+	s := Of(1, 2)
+
+	added, err := s.TryAdd(1)
+	require.False(t, added)
+	require.ErrorIs(t, err, collections.ErrDuplicate)
+
+	added, err = s.TryAdd(3)
+	require.True(t, added)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, s.ToSlice())
+}
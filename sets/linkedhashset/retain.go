@@ -0,0 +1,80 @@
+package linkedhashset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*LinkedHashSet[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single pass
+// in insertion order, keeping the remaining values in their order, and returns the number of values removed. O(n).
+func (s *LinkedHashSet[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	removed := 0
+
+	for n := s.head; n != nil; {
+		next := n.next
+
+		if !predicate(n.item) {
+			s.removeFromBucket(n)
+			s.unlink(n)
+			s.recorder.Remove(n.item)
+			removed++
+		}
+
+		n = next
+	}
+
+	if removed == 0 {
+		return 0
+	}
+
+	s.size -= removed
+	s.version++
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (s *LinkedHashSet[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](s, other, s.RetainWhere)
+}
+
+// removeFromBucket removes the given node from its hash bucket, but not from the list.
+func (s *LinkedHashSet[T]) removeFromBucket(n *node[T]) {
+	hash := s.hasher(n.item)
+	bucket := s.buckets[hash]
+
+	for i, b := range bucket {
+		if b != n {
+			continue
+		}
+
+		if len(bucket) == 1 {
+			delete(s.buckets, hash)
+		} else {
+			bucket[i] = bucket[len(bucket)-1]
+			bucket[len(bucket)-1] = nil
+			s.buckets[hash] = bucket[:len(bucket)-1]
+		}
+
+		return
+	}
+}
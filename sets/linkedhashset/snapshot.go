@@ -0,0 +1,113 @@
+package linkedhashset
+
+import (
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/sets"
+)
+
+// snapshot records the values of a LinkedHashSet at a point in time, in the order in which they were added.
+type snapshot[T any] struct {
+	set     *LinkedHashSet[T]
+	version int
+	values  []T
+	local.InternalImpl
+}
+
+// Snapshot returns an immutable record of the values currently in the set,
+// for later comparison with [sets.DiffSnapshots].
+//
+// Taking a snapshot is O(n). However, if the set has not been modified since the
+// last snapshot was taken, that snapshot is returned at no cost.
+// Diffing two snapshots is O(n+m) on average, or O(1) if they were taken from the same set
+// with no modifications in between. Snapshots of different sets can only be diffed if
+// the sets use the same hash function.
+func (s *LinkedHashSet[T]) Snapshot() sets.Snapshot[T] {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if s.lastSnapshot != nil && s.lastSnapshot.version == s.version {
+		return s.lastSnapshot
+	}
+
+	snap := &snapshot[T]{
+		set:     s,
+		version: s.version,
+		values:  s.toSlice(false),
+	}
+
+	s.lastSnapshot = snap
+	return snap
+}
+
+// Count returns the number of values in the snapshot.
+func (snap *snapshot[T]) Count() int {
+	return len(snap.values)
+}
+
+// ToSlice returns a copy of the values in the snapshot, in the order in which they were added.
+func (snap *snapshot[T]) ToSlice() []T {
+	slc := make([]T, len(snap.values))
+	copy(slc, snap.values)
+	return slc
+}
+
+// Diff returns the values that are in the later snapshot but not this one (added),
+// and the values that are in this snapshot but not the later one (removed).
+// Both results are in the order in which the values were added.
+//
+// Panics if the later snapshot was not taken from a LinkedHashSet.
+func (snap *snapshot[T]) Diff(later sets.Snapshot[T]) (added, removed []T) {
+
+	other, ok := later.(*snapshot[T])
+
+	if !ok {
+		panic(messages.SNAPSHOT_TYPE_MISMATCH)
+	}
+
+	if snap.set == other.set && snap.version == other.version {
+		return nil, nil
+	}
+
+	return snap.missingFrom(snap.values, other.values), snap.missingFrom(other.values, snap.values)
+}
+
+// missingFrom returns the values of from that are not present in values.
+func (snap *snapshot[T]) missingFrom(values, from []T) []T {
+	hasher, compare := snap.set.hasher, snap.set.compare
+	index := make(map[uintptr][]T, len(values))
+
+	for _, v := range values {
+		hash := hasher(v)
+		index[hash] = append(index[hash], v)
+	}
+
+	var result []T
+
+	for _, v := range from {
+		found := false
+
+		for _, o := range index[hasher(v)] {
+			if compare(v, o) == 0 {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
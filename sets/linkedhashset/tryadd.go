@@ -0,0 +1,42 @@
+package linkedhashset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*LinkedHashSet[int])(nil)
+
+// TryAdd adds a value to the end of the set. O(1) on average.
+//
+// Returns true and nil if the value was added; else false and [collections.ErrDuplicate] if the value
+// is already present, in which case its position is unchanged, or an error wrapping
+// [collections.ErrInvalidValue] and the error of the validator if it was rejected,
+// whatever the validation policy.
+func (s *LinkedHashSet[T]) TryAdd(value T) (bool, error) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if err := s.validator.Check(value); err != nil {
+		return false, err
+	}
+
+	inserted := s.insert(value)
+	s.version++
+
+	if !inserted {
+		return false, collections.ErrDuplicate
+	}
+
+	return true, nil
+}
@@ -0,0 +1,164 @@
+package orderedset
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+type setOperation int
+
+const (
+	union setOperation = iota
+	intersection
+	difference
+)
+
+// setAlgebraIterator walks two sets side by side in ascending order,
+// yielding the values that satisfy a set operation.
+type setAlgebraIterator[T any] struct {
+	util.IteratorBase[T]
+	set          *OrderedSet[T]
+	other        *OrderedSet[T]
+	otherVersion int
+	operation    setOperation
+	current      *node[T]
+	otherCurrent *node[T]
+	local.InternalImpl
+}
+
+// UnionIter returns an iterator that yields the values that are in this set, the other set or both,
+// in ascending order.
+//
+// Unlike [OrderedSet.Union], no result set is built. The two trees are walked together as the
+// iterator advances, so each value of either set is visited at most once.
+// This suits pipelines that only need to stream the result once.
+//
+// Both sets must order their values with equivalent comparers. Values are compared with the comparer of this set.
+// Where a value is in both sets, the element yielded is that of this set.
+//
+// The iterator panics if either set is modified during the iteration.
+// Panics if other is nil.
+func (s *OrderedSet[T]) UnionIter(other *OrderedSet[T]) collections.Iterator[T] {
+	return newSetAlgebraIterator(s, other, union)
+}
+
+// IntersectIter returns an iterator that yields the values that are in both this set and the other set,
+// in ascending order.
+//
+// Unlike [OrderedSet.Intersection], no result set is built. See [OrderedSet.UnionIter].
+//
+// Panics if other is nil.
+func (s *OrderedSet[T]) IntersectIter(other *OrderedSet[T]) collections.Iterator[T] {
+	return newSetAlgebraIterator(s, other, intersection)
+}
+
+// DifferenceIter returns an iterator that yields the values that are in this set but not the other set,
+// in ascending order.
+//
+// Unlike [OrderedSet.Difference], no result set is built. See [OrderedSet.UnionIter].
+//
+// Panics if other is nil.
+func (s *OrderedSet[T]) DifferenceIter(other *OrderedSet[T]) collections.Iterator[T] {
+	return newSetAlgebraIterator(s, other, difference)
+}
+
+func newSetAlgebraIterator[T any](set, other *OrderedSet[T], operation setOperation) *setAlgebraIterator[T] {
+	if other == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "other"))
+	}
+
+	return &setAlgebraIterator[T]{
+		set:          set,
+		other:        other,
+		otherVersion: other.version,
+		operation:    operation,
+		IteratorBase: util.IteratorBase[T]{
+			Version:    set.version,
+			NilElement: nil,
+		},
+	}
+}
+
+// Start begins the iteration, returning the first element of the result,
+// which will be nil if the result is empty.
+//
+// Panics if either set has been modified since creation of the iterator.
+func (i *setAlgebraIterator[T]) Start() collections.Element[T] {
+	i.validateIterator()
+	i.current = i.set.root.minimumNode()
+	i.otherCurrent = i.other.root.minimumNode()
+	return i.Next()
+}
+
+// Next returns the next element of the result,
+// which will be nil if the end has been reached.
+//
+// Panics if either set has been modified since creation of the iterator.
+func (i *setAlgebraIterator[T]) Next() collections.Element[T] {
+	i.validateIterator()
+
+	for {
+		if i.current == nil {
+			if i.operation != union || i.otherCurrent == nil {
+				return i.NilElement
+			}
+
+			return i.yieldOther()
+		}
+
+		if i.otherCurrent == nil {
+			if i.operation == intersection {
+				return i.NilElement
+			}
+
+			return i.yield()
+		}
+
+		compare := i.set.compare(i.current.item, i.otherCurrent.item)
+
+		switch {
+		case compare < 0:
+			if i.operation != intersection {
+				return i.yield()
+			}
+			i.current = i.current.successor()
+
+		case compare > 0:
+			if i.operation == union {
+				return i.yieldOther()
+			}
+			i.otherCurrent = i.otherCurrent.successor()
+
+		default:
+			i.otherCurrent = i.otherCurrent.successor()
+			if i.operation != difference {
+				return i.yield()
+			}
+			i.current = i.current.successor()
+		}
+	}
+}
+
+// yield returns the element at the current node of this set, and advances past it.
+func (i *setAlgebraIterator[T]) yield() collections.Element[T] {
+	n := i.current
+	i.current = n.successor()
+	return util.NewElementType[T](i.set, &n.item, i.set.compare)
+}
+
+// yieldOther returns the element at the current node of the other set, and advances past it.
+func (i *setAlgebraIterator[T]) yieldOther() collections.Element[T] {
+	n := i.otherCurrent
+	i.otherCurrent = n.successor()
+	return util.NewElementType[T](i.other, &n.item, i.other.compare)
+}
+
+func (i *setAlgebraIterator[T]) validateIterator() {
+	if i.Version != i.set.version || i.otherVersion != i.other.version {
+		panic(messages.COLLECTION_MODIFIED)
+	}
+}
@@ -0,0 +1,137 @@
+package orderedset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.BidirectionalIterable[int] = (*OrderedSet[int])(nil)
+
+// OrderedSetBidirectionalIterator implements an iterator that can move in either direction through the set.
+type OrderedSetBidirectionalIterator[T any] struct {
+	util.IteratorBase[T]
+	set     *OrderedSet[T]
+	bounds  bounds[T]
+	current *node[T]
+	pastEnd bool
+	local.InternalImpl
+}
+
+// BidirectionalIterator returns an iterator that can move in ascending and descending order of values
+// from any position, for instance to find the nearest neighbours of a value.
+//
+//	iter := set.BidirectionalIterator()
+//	above := iter.Seek(value) // least value >= value
+//	below := iter.Prev()      // greatest value < value
+func (s *OrderedSet[T]) BidirectionalIterator() collections.BidirectionalIterator[T] {
+
+	return newBidirectionalIterator(s, bounds[T]{})
+}
+
+func newBidirectionalIterator[T any](set *OrderedSet[T], b bounds[T]) *OrderedSetBidirectionalIterator[T] {
+	return &OrderedSetBidirectionalIterator[T]{
+		set:    set,
+		bounds: b,
+		IteratorBase: util.IteratorBase[T]{
+			Version:    set.version,
+			NilElement: nil,
+		},
+	}
+}
+
+// Start begins an iteration across the set returning the least element,
+// which will be nil if the collection is empty.
+//
+// Panics if the set has been modified since creation of the iterator.
+func (i *OrderedSetBidirectionalIterator[T]) Start() collections.Element[T] {
+	i.validateIterator()
+
+	return i.moveTo(i.set.first(i.bounds), false)
+}
+
+// End begins an iteration across the set returning the greatest element,
+// which will be nil if the collection is empty.
+//
+// Panics if the set has been modified since creation of the iterator.
+func (i *OrderedSetBidirectionalIterator[T]) End() collections.Element[T] {
+	i.validateIterator()
+
+	return i.moveTo(i.set.last(i.bounds), true)
+}
+
+// Next returns the next greater element in the set,
+// which will be nil if the end has been reached.
+//
+// Panics if the set has been modified since creation of the iterator.
+func (i *OrderedSetBidirectionalIterator[T]) Next() collections.Element[T] {
+	i.validateIterator()
+
+	switch {
+	case i.current != nil:
+		return i.moveTo(i.current.successor(), true)
+	case i.pastEnd:
+		return i.NilElement
+	default:
+		return i.moveTo(i.set.first(i.bounds), true)
+	}
+}
+
+// Prev returns the next lesser element in the set,
+// which will be nil if the start has been reached.
+//
+// Panics if the set has been modified since creation of the iterator.
+func (i *OrderedSetBidirectionalIterator[T]) Prev() collections.Element[T] {
+	i.validateIterator()
+
+	switch {
+	case i.current != nil:
+		return i.moveTo(i.current.predecessor(), false)
+	case i.pastEnd:
+		return i.moveTo(i.set.last(i.bounds), false)
+	default:
+		return i.NilElement
+	}
+}
+
+// Seek positions the iterator at the least element in the set that is greater than or equal to value
+// and returns it, or returns nil and positions the iterator beyond the end if there is none.
+// The greatest element less than value may then be found with Prev.
+//
+// Panics if the set has been modified since creation of the iterator.
+func (i *OrderedSetBidirectionalIterator[T]) Seek(value T) collections.Element[T] {
+	i.validateIterator()
+
+	n := i.set.lowerBound(value)
+
+	if n != nil && i.bounds.below(i.set.compare, n.item) {
+		n = i.set.first(i.bounds)
+	}
+
+	return i.moveTo(n, true)
+}
+
+// moveTo positions the iterator at n. If n is nil or outside the bounds of the iterator, the iterator
+// is positioned beyond the end if pastEnd is set, else before the start.
+func (i *OrderedSetBidirectionalIterator[T]) moveTo(n *node[T], pastEnd bool) collections.Element[T] {
+	if n != nil && !i.bounds.contains(i.set.compare, n.item) {
+		n = nil
+	}
+
+	i.current = n
+
+	if n == nil {
+		i.pastEnd = pastEnd
+		return i.NilElement
+	}
+
+	return util.NewElementType[T](i.set, &n.item, i.set.compare)
+}
+
+func (i *OrderedSetBidirectionalIterator[T]) validateIterator() {
+	if i.Version != i.set.version {
+		panic(messages.COLLECTION_MODIFIED)
+	}
+}
@@ -0,0 +1,129 @@
+package orderedset
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// CloneInto replaces the content of dst with a deep copy of this set's values,
+// using the provided [functions.DeepCopyFunc] if any.
+//
+// The tree of this set is copied node for node, reusing the existing nodes of dst,
+// so periodically cloning into the same destination only allocates when this set is larger than dst.
+// No comparisons are performed. The comparer, deep copy function and copy policy of this set are copied to dst;
+// its thread safety is not changed. If this set was created with [WithStableIDs], [WithFiniteOnly] or [WithValidator], so is dst,
+// and each value in dst has the ID of the value it was copied from.
+//
+// Panics if dst is nil.
+func (s *OrderedSet[T]) CloneInto(dst *OrderedSet[T]) {
+
+	if dst == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dst"))
+	}
+
+	if dst == s {
+		return
+	}
+
+	// Locked in a consistent order, so that a concurrent dst.CloneInto(s) cannot deadlock
+	util.LockPair(s.lock, dst.lock)
+	defer util.UnlockPair(s.lock, dst.lock)
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if util.Debug {
+		defer dst.guard.Write(dst.lock != nil, dst.verifyInvariants)()
+	}
+
+	dst.lazyInit()
+
+	free := dst.releaseNodes()
+	dst.root = s.cloneTree(s.root, nil, &free)
+	dst.size = s.size
+	dst.compare = s.compare
+	dst.copy = s.copy
+	dst.copyPolicy = s.copyPolicy
+	dst.stableIDs = s.stableIDs
+	dst.accept = s.accept
+	dst.validator = s.validator
+	dst.nextID = s.nextID
+	dst.reindexIDs()
+	dst.version++
+	dst.recorder.Reset(func() []T {
+		slc := make([]T, dst.size)
+		dst.copyTo(slc, 0, dst.size, false)
+		return slc
+	})
+}
+
+// releaseNodes detaches all nodes from the tree and returns them as a free list linked through
+// their right pointers. Left subtrees are rotated out of the way as the tree is walked, so no
+// additional storage is required.
+func (s *OrderedSet[T]) releaseNodes() *node[T] {
+
+	var free *node[T]
+	var empty T
+
+	for n := s.root; n != nil; {
+		if l := n.left; l != nil {
+			n.left = l.right
+			l.right = n
+			n = l
+			continue
+		}
+
+		next := n.right
+		n.item = empty
+		n.Parent = nil
+		n.right = free
+		free = n
+		n = next
+	}
+
+	s.root = nil
+	return free
+}
+
+// cloneTree deep copies the subtree rooted at n, taking nodes from the free list where possible.
+func (s *OrderedSet[T]) cloneTree(n, parent *node[T], free **node[T]) *node[T] {
+
+	if n == nil {
+		return nil
+	}
+
+	c := *free
+
+	if c != nil {
+		*free = c.right
+	} else {
+		c = &node[T]{}
+	}
+
+	c.item = util.DeepCopy(n.item, s.copy)
+	c.color = n.color
+	c.Parent = parent
+	c.left = s.cloneTree(n.left, c, free)
+	c.right = s.cloneTree(n.right, c, free)
+	c.count = n.count
+	c.id = n.id
+	return c
+}
+
+// Clone returns a new OrderedSet holding the values of this set, copied by value.
+// The result is that of Select with a predicate that is always true.
+func (s *OrderedSet[T]) Clone() collections.Collection[T] {
+
+	return s.Select(util.DefaultPredicate[T])
+}
+
+// CloneDeep returns a new OrderedSet holding the values of this set, copied using the provided
+// [functions.DeepCopyFunc] if any. The result is that of SelectDeep with a predicate that is always true.
+func (s *OrderedSet[T]) CloneDeep() collections.Collection[T] {
+
+	return s.SelectDeep(util.DefaultPredicate[T])
+}
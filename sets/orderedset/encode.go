@@ -0,0 +1,72 @@
+package orderedset
+
+import (
+	"io"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// Encode writes the values of the set to w in ascending order, using enc to encode each value.
+//
+// Values are written as the tree is walked, so no intermediate copy of the set is made.
+// The stream begins with a header describing the [schema] of the set, but not its options, followed
+// by the number of values, allowing [OrderedSet.Decode] to read exactly the values written even if further
+// data follows in the same stream.
+//
+// Returns the first error returned by w or enc.
+func (s *OrderedSet[T]) Encode(w io.Writer, enc func(io.Writer, T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if err := schema.Write(w, schema.Of[T](collections.COLLECTION_ORDEREDSET, nil)); err != nil {
+		return err
+	}
+
+	return util.EncodeStream(w, s.size, func(yield func(T) bool) {
+		s.inOrderTreeWalk(func(n *node[T]) bool {
+			return yield(n.item)
+		})
+	}, enc)
+}
+
+// Decode reads values written by [OrderedSet.Encode] from r, using dec to decode each value,
+// and adds them to the set. Values are added as they are read.
+//
+// If the set was created [WithSchemaValidation], returns an error if the stream has no header or
+// was written by another type of collection or value. Returns the first error returned by dec,
+// or io.ErrUnexpectedEOF if the stream is truncated.
+// Values read before an error remain in the set.
+func (s *OrderedSet[T]) Decode(r io.Reader, dec func(io.Reader) (T, error)) error {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	r, err := util.ReadSchema(r, schema.Of[T](collections.COLLECTION_ORDEREDSET, nil), s.verifySchema)
+
+	if err != nil {
+		return err
+	}
+
+	return util.DecodeStream(r, dec, func(value T) {
+		if s.doInsert(value) {
+			s.version++
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package orderedset
 
 import (
+	"errors"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/messages"
@@ -19,6 +21,12 @@ func (s *OrderedSet[T]) Any(predicate functions.PredicateFunc[T]) bool {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, predicate)
 
 	return iter.Start() != nil
@@ -33,6 +41,12 @@ func (s *OrderedSet[T]) All(predicate functions.PredicateFunc[T]) bool {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -52,6 +66,12 @@ func (s *OrderedSet[T]) ForEach(f func(collections.Element[T])) {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -59,6 +79,59 @@ func (s *OrderedSet[T]) ForEach(f func(collections.Element[T])) {
 	}
 }
 
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (s *OrderedSet[T]) TryForEach(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (s *OrderedSet[T]) TryForEachAll(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	var errs []error
+	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Map applies function f to all elements in the collection
 // and returns a new OrderedSet containing the result of f.
 func (s *OrderedSet[T]) Map(f func(T) T) collections.Collection[T] {
@@ -68,6 +141,12 @@ func (s *OrderedSet[T]) Map(f func(T) T) collections.Collection[T] {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
 
 	s1 := New[T](WithComparer[T](s.compare))
@@ -87,6 +166,10 @@ func (s *OrderedSet[T]) Select(predicate functions.PredicateFunc[T]) collections
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	return s.doSelect(predicate, false)
 }
 
@@ -100,9 +183,23 @@ func (s *OrderedSet[T]) SelectDeep(predicate functions.PredicateFunc[T]) collect
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	return s.doSelect(predicate, true)
 }
 
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Values are copied in ascending order.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (s *OrderedSet[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(s.copyWhere(predicate))
+}
+
 // Find finds the first occurrence of an element matching the predicate.
 //
 // The function returns nil if no match.
@@ -113,6 +210,10 @@ func (s *OrderedSet[T]) Find(predicate functions.PredicateFunc[T]) collections.E
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.find(predicate, false)
 
 	if len(result) == 0 {
@@ -132,23 +233,33 @@ func (s *OrderedSet[T]) FindAll(predicate functions.PredicateFunc[T]) []collecti
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.find(predicate, true)
 
 	return result
 }
 
-// Max returns the maximum value in the collection according to the Comparer function.
+// Max returns the maximum value in the collection according to the Comparer function. O(log n).
+//
+// Panics if the collection is empty.
 func (s *OrderedSet[T]) Max() T {
 
-	if s.root == nil {
-		panic(messages.COLLECTION_EMPTY)
-	}
-
 	if s.lock != nil {
 		s.lock.RLock()
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.root == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
 	current := s.root
 	for current.right != nil {
 		current = current.right
@@ -157,18 +268,24 @@ func (s *OrderedSet[T]) Max() T {
 	return current.item
 }
 
-// Min returns the minimum value in the collection according to the Comparer function.
+// Min returns the minimum value in the collection according to the Comparer function. O(log n).
+//
+// Panics if the collection is empty.
 func (s *OrderedSet[T]) Min() T {
 
-	if s.root == nil {
-		panic(messages.COLLECTION_EMPTY)
-	}
-
 	if s.lock != nil {
 		s.lock.RLock()
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.root == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
 	current := s.root
 	for current.left != nil {
 		current = current.left
@@ -196,7 +313,7 @@ func (s *OrderedSet[T]) find(predicate functions.PredicateFunc[T], all bool) []c
 }
 
 func (s *OrderedSet[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
-	s1 := New[T](WithComparer[T](s.compare))
+	s1 := New[T](WithComparer[T](s.compare), WithDeepCopy(s.copy), WithCopyPolicy[T](s.copyPolicy))
 	iter := newForwardIterator[T](s, predicate)
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -209,3 +326,24 @@ func (s *OrderedSet[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy
 
 	return s1
 }
+
+func (s *OrderedSet[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+	iter := newForwardIterator[T](s, predicate)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, util.DeepCopy(e.Value(), s.copy))
+	}
+
+	return values
+}
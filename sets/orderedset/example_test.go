@@ -0,0 +1,91 @@
+package orderedset_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+)
+
+func Example() {
+	s := orderedset.Of(5, 3, 1, 3, 4)
+
+	fmt.Println(s.ToSlice(), s.Count())
+	fmt.Println(s.Min(), s.Max(), s.Contains(4))
+	// Output:
+	// [1 3 4 5] 4
+	// 1 5 true
+}
+
+// Set algebra returns a new set.
+func Example_setAlgebra() {
+	a := orderedset.Of(1, 2, 3, 4)
+	b := orderedset.Of(3, 4, 5)
+
+	fmt.Println(a.Union(b).ToSlice())
+	fmt.Println(a.Intersection(b).ToSlice())
+	fmt.Println(a.Difference(b).ToSlice())
+	// Output:
+	// [1 2 3 4 5]
+	// [3 4]
+	// [1 2]
+}
+
+// Lazy set algebra iterators produce values in order without building a new set.
+func ExampleOrderedSet_UnionIter() {
+	a := orderedset.Of(1, 3, 5)
+	b := orderedset.Of(2, 3, 4)
+	iter := a.UnionIter(b)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Print(e.Value(), " ")
+	}
+	fmt.Println()
+	// Output:
+	// 1 2 3 4 5
+}
+
+func ExampleOrderedSet_WalkRange() {
+	s := orderedset.Of(10, 20, 30, 40, 50)
+
+	s.WalkRange(15, 40, func(v int) bool {
+		fmt.Println(v)
+		return true
+	})
+	// Output:
+	// 20
+	// 30
+	// 40
+}
+
+func ExampleOrderedSet_ReverseIterator() {
+	s := orderedset.Of("b", "c", "a")
+	iter := s.ReverseIterator()
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Print(e.Value(), " ")
+	}
+	fmt.Println()
+	// Output:
+	// c b a
+}
+
+func ExampleWithComparer() {
+	// Order strings by length, then alphabetically.
+	s := orderedset.New(orderedset.WithComparer(func(a, b string) int {
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+		if a < b {
+			return -1
+		}
+		if a > b {
+			return 1
+		}
+		return 0
+	}))
+
+	s.AddRange([]string{"ccc", "a", "bb", "aa"})
+	fmt.Println(s.ToSlice())
+	// Output:
+	// [a aa bb ccc]
+}
@@ -0,0 +1,30 @@
+package orderedset
+
+import (
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"golang.org/x/exp/constraints"
+)
+
+// Option function for New to reject NaN and infinite values. Adding one panics, leaving the set unchanged.
+//
+// By default a set of floats accepts them. NaN is ordered before every other value and is equal to any other NaN,
+// so a set holds at most one; see [functions.TotalOrderFloat] to order it last instead.
+func WithFiniteOnly[T constraints.Float]() OrderedSetOptionFunc[T] {
+	return func(s *OrderedSet[T]) {
+		s.accept = functions.IsFinite[T]
+	}
+}
+
+// checkValues panics if the set was created with WithFiniteOnly and any of the values is not finite.
+func (s *OrderedSet[T]) checkValues(values ...T) {
+	if s.accept == nil {
+		return
+	}
+
+	for _, v := range values {
+		if !s.accept(v) {
+			panic(messages.VALUE_NOT_FINITE)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package orderedset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// GobEncode implements gob.GobEncoder, encoding the values of the set in ascending order,
+// so that the set may be persisted or sent over RPC with encoding/gob.
+// The values must themselves be encodable by encoding/gob. The values follow a header describing the [schema] of the set, but its options are not encoded.
+func (s *OrderedSet[T]) GobEncode() ([]byte, error) {
+
+	return util.GobEncode(schema.Of[T](collections.COLLECTION_ORDEREDSET, nil), s.ToSlice())
+}
+
+// GobDecode implements gob.GobDecoder, replacing the values of the set with those encoded by GobEncode.
+//
+// The options of the set are retained, so decode into a set constructed with the options
+// of that encoded, or into a zero value for the default options.
+func (s *OrderedSet[T]) GobDecode(data []byte) error {
+
+	var values []T
+
+	if err := util.GobDecode(data, &values, schema.Of[T](collections.COLLECTION_ORDEREDSET, nil), s.verifySchema); err != nil {
+		return err
+	}
+
+	s.replaceAll(values)
+	return nil
+}
+
+// Option function for New to verify the schema header of data decoded by GobDecode and Decode,
+// which then returns an error wrapping [schema.ErrMismatch] if the data was encoded
+// from another type of collection or value, or [schema.ErrMissing] if it has no header.
+func WithSchemaValidation[T any]() OrderedSetOptionFunc[T] {
+	return func(s *OrderedSet[T]) {
+		s.verifySchema = true
+	}
+}
@@ -0,0 +1,180 @@
+package orderedset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Option function for New to assign each value added to the set an ID that is unique within the set,
+// by which it may be retrieved or removed in O(1) with [OrderedSet.GetByID] and [OrderedSet.RemoveByID].
+//
+// A value keeps its ID while it remains in the set, however the tree is rebalanced. IDs are never reused,
+// so an external system may hold the ID of a value as a durable reference to it, and detect that it has been
+// removed. Adds the overhead of an index from ID to value.
+func WithStableIDs[T any]() OrderedSetOptionFunc[T] {
+	return func(s *OrderedSet[T]) {
+		s.stableIDs = true
+	}
+}
+
+// AddWithID adds a value to the set and returns its ID.
+// If the value already exists, its existing ID is returned with false; else the ID of the new value with true.
+// If the value is rejected by the validator, zero, which is never an ID, is returned with false.
+//
+// Panics if the set was not created with [WithStableIDs].
+func (s *OrderedSet[T]) AddWithID(value T) (uint64, bool) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.requireStableIDs()
+	s.lazyInit()
+
+	if n := s.lookup(value); n != nil {
+		return n.id, false
+	}
+
+	if !s.validator.Accept(value) {
+		return 0, false
+	}
+
+	s.doInsert(value)
+	s.version++
+
+	// doInsert assigns the next ID
+	return s.nextID, true
+}
+
+// IDOf returns the ID of the given value, or false if the value is not in the set.
+//
+// Panics if the set was not created with [WithStableIDs].
+func (s *OrderedSet[T]) IDOf(value T) (uint64, bool) {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	s.requireStableIDs()
+
+	n := s.lookup(value)
+
+	if n == nil {
+		return 0, false
+	}
+
+	return n.id, true
+}
+
+// GetByID returns the element with the given ID, or nil if there is no value with that ID in the set. O(1).
+//
+// Panics if the set was not created with [WithStableIDs].
+func (s *OrderedSet[T]) GetByID(id uint64) collections.Element[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	s.requireStableIDs()
+
+	n, ok := s.ids[id]
+
+	if !ok {
+		return nil
+	}
+
+	return util.NewElementType[T](s, &n.item, s.compare)
+}
+
+// RemoveByID removes the value with the given ID from the set.
+// The value is found in O(1), and the tree rebalanced in O(log n).
+//
+// Returns true if the value was present and was removed; else false.
+//
+// Panics if the set was not created with [WithStableIDs].
+func (s *OrderedSet[T]) RemoveByID(id uint64) bool {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.requireStableIDs()
+	s.lazyInit()
+
+	n, ok := s.ids[id]
+
+	if !ok {
+		return false
+	}
+
+	s.version++
+	return s.remove(n.item)
+}
+
+func (s *OrderedSet[T]) requireStableIDs() {
+	if !s.stableIDs {
+		panic(messages.STABLE_IDS_DISABLED)
+	}
+}
+
+// assignID gives a newly inserted node the next ID.
+func (s *OrderedSet[T]) assignID(n *node[T]) {
+	if !s.stableIDs {
+		return
+	}
+
+	s.nextID++
+	n.id = s.nextID
+	s.indexID(n)
+}
+
+func (s *OrderedSet[T]) indexID(n *node[T]) {
+	if !s.stableIDs {
+		return
+	}
+
+	if s.ids == nil {
+		s.ids = make(map[uint64]*node[T])
+	}
+
+	s.ids[n.id] = n
+}
+
+func (s *OrderedSet[T]) releaseID(n *node[T]) {
+	delete(s.ids, n.id)
+}
+
+// reindexIDs rebuilds the index from the IDs held by the nodes.
+func (s *OrderedSet[T]) reindexIDs() {
+	s.ids = nil
+
+	if !s.stableIDs {
+		return
+	}
+
+	s.inOrderTreeWalk(func(n *node[T]) bool {
+		s.indexID(n)
+		return true
+	})
+}
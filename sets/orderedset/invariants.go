@@ -0,0 +1,52 @@
+package orderedset
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the set is inconsistent,
+// including violations of the red-black tree properties.
+// Called after each modification in builds with the collections_debug tag.
+func (s *OrderedSet[T]) verifyInvariants() {
+	util.AssertInvariant(len(s.ids) == util.Iif(s.stableIDs, s.size, 0), "set ID index does not match size")
+
+	if s.root == nil {
+		util.AssertInvariant(s.size == 0, "set size does not match values")
+		return
+	}
+
+	util.AssertInvariant(s.root.Parent == nil, "set root has parent")
+	util.AssertInvariant(nodeColor(s.root) == black, "set root is not black")
+
+	count := 0
+	s.verifyNode(s.root, &count)
+	util.AssertInvariant(count == s.size, "set size does not match values")
+}
+
+// verifyNode verifies the subtree rooted at n, counting its nodes,
+// including the count of nodes held by each node for order statistics,
+// and returns its black height.
+func (s *OrderedSet[T]) verifyNode(n *node[T], count *int) int {
+	if n == nil {
+		return 1
+	}
+
+	*count++
+
+	for _, child := range []*node[T]{n.left, n.right} {
+		if child != nil {
+			util.AssertInvariant(child.Parent == n, "set node is not linked to parent")
+			util.AssertInvariant(nodeColor(n) == black || nodeColor(child) == black, "set has red node with red child")
+		}
+	}
+
+	util.AssertInvariant(!s.stableIDs || s.ids[n.id] == n, "set ID index does not match values")
+	util.AssertInvariant(n.left == nil || s.compare(n.left.item, n.item) < 0, "set values are out of order")
+	util.AssertInvariant(n.right == nil || s.compare(n.right.item, n.item) > 0, "set values are out of order")
+
+	before := *count
+	leftHeight := s.verifyNode(n.left, count)
+	rightHeight := s.verifyNode(n.right, count)
+	util.AssertInvariant(n.count == *count-before+1, "set subtree count does not match values")
+	util.AssertInvariant(leftHeight == rightHeight, "set black heights differ")
+
+	return leftHeight + util.Iif(nodeColor(n) == black, 1, 0)
+}
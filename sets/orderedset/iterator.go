@@ -1,6 +1,8 @@
 package orderedset
 
 import (
+	"math/rand"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/local"
@@ -73,6 +75,40 @@ func (s *OrderedSet[T]) TakeWhile(predicate functions.PredicateFunc[T]) collecti
 	return newForwardIterator(s, predicate)
 }
 
+// SampleIterator returns an iterator that yields n values chosen at random from the set,
+// without replacement and in random order. If the set holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the set.
+// If r is nil, the default source of the math/rand package is used.
+//
+//	iter := s.SampleIterator(100, nil)
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// audit e.Value()
+//	}
+//
+// Panics if n is negative.
+func (s *OrderedSet[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](s, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the set at the time of the call,
+// in the same order as ToSlice, so that the set may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the set's immutable slice, so it is only copied if the set has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the set, and their ValuePtr method panics.
+//
+//	iter := s.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		s.Remove(e.Value())
+//	}
+func (s *OrderedSet[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(s.ToImmutableSlice())
+}
+
 // Start begins an iteration across the set returning the fisrt element,
 // which will be nil if the collection is empty.
 //
@@ -104,7 +140,7 @@ func (i *OrderedSetIterator[T]) Next() collections.Element[T] {
 		i.move(util.Iif(i.direction == reverse, current.left, current.right))
 
 		if i.predicate(current.item) {
-			return util.NewElementType[T](i.set, &current.item)
+			return util.NewElementType[T](i.set, &current.item, i.set.compare)
 		}
 	}
 }
@@ -1,11 +1,15 @@
 package orderedset
 
 import (
+	"fmt"
 	"sort"
 	"testing"
 
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/stretchr/testify/require"
+	"math/rand"
 )
 
 var setSize = 1024
@@ -125,3 +129,164 @@ func TestWhere(t *testing.T) {
 
 	})
 }
+
+func TestSetAlgebraIterators(t *testing.T) {
+
+	collect := func(iter collections.Iterator[int]) []int {
+		values := []int{}
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+		}
+		return values
+	}
+
+	seed := int64(2163)
+	items1, _, _, _ := util.CreateIntListData(setSize, &seed)
+	items2, _, _, _ := util.CreateIntListData(setSize, &seed)
+	set1 := Of(items1...)
+	set2 := Of(append(items2, items1[:setSize/4]...)...)
+
+	t.Run("Results match eager set operations", func(t *testing.T) {
+		require.Equal(t, set1.Union(set2).ToSlice(), collect(set1.UnionIter(set2)))
+		require.Equal(t, set1.Intersection(set2).ToSlice(), collect(set1.IntersectIter(set2)))
+		require.Equal(t, set1.Difference(set2).ToSlice(), collect(set1.DifferenceIter(set2)))
+		require.Equal(t, set2.Difference(set1).ToSlice(), collect(set2.DifferenceIter(set1)))
+	})
+
+	t.Run("Interleaved values", func(t *testing.T) {
+		a := Of(1, 3, 5, 7, 8)
+		b := Of(2, 3, 4, 8, 9, 10)
+
+		require.Equal(t, []int{1, 2, 3, 4, 5, 7, 8, 9, 10}, collect(a.UnionIter(b)))
+		require.Equal(t, []int{3, 8}, collect(a.IntersectIter(b)))
+		require.Equal(t, []int{1, 5, 7}, collect(a.DifferenceIter(b)))
+		require.Equal(t, []int{2, 4, 9, 10}, collect(b.DifferenceIter(a)))
+	})
+
+	t.Run("Empty sets", func(t *testing.T) {
+		empty := New[int]()
+		a := Of(1, 2)
+
+		require.Equal(t, []int{1, 2}, collect(a.UnionIter(empty)))
+		require.Equal(t, []int{1, 2}, collect(empty.UnionIter(a)))
+		require.Empty(t, collect(a.IntersectIter(empty)))
+		require.Empty(t, collect(empty.DifferenceIter(a)))
+		require.Equal(t, []int{1, 2}, collect(a.DifferenceIter(empty)))
+		require.Nil(t, empty.UnionIter(empty).Start())
+	})
+
+	t.Run("Start restarts iteration", func(t *testing.T) {
+		iter := Of(1, 2).UnionIter(Of(3))
+		iter.Start()
+		iter.Next()
+		require.Equal(t, 1, iter.Start().Value())
+	})
+
+	t.Run("Modifying either set panics", func(t *testing.T) {
+		a := Of(1, 2)
+		b := Of(2, 3)
+		iter := a.UnionIter(b)
+		iter.Start()
+		b.Add(4)
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Next() })
+	})
+
+	t.Run("Nil other panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "other"), func() { Of(1).IntersectIter(nil) })
+	})
+}
+
+func TestSampleIterator(t *testing.T) {
+	col := Of(1, 2, 3, 4, 5, 6, 7, 8)
+	iter := col.SampleIterator(3, rand.New(rand.NewSource(1)))
+	seen := make(map[int]bool)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		require.Contains(t, col.ToSlice(), e.Value())
+		require.False(t, seen[e.Value()])
+		seen[e.Value()] = true
+	}
+
+	require.Len(t, seen, 3)
+}
+
+func TestBidirectionalIterator(t *testing.T) {
+
+	var setItems []int
+	seed := int64(2163)
+	setItems = util.CreateSerialIntListData(util.DefaultCapacity, &seed)
+	sortedItems := make([]int, len(setItems))
+	copy(sortedItems, setItems)
+	sort.Ints(sortedItems)
+
+	t.Run("Forwards and backwards", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange(setItems)
+		iter := set.BidirectionalIterator()
+
+		forwards := make([]int, 0, set.Count())
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			forwards = append(forwards, e.Value())
+		}
+
+		backwards := make([]int, 0, set.Count())
+		for e := iter.Prev(); e != nil; e = iter.Prev() {
+			backwards = append(backwards, e.Value())
+		}
+
+		reversedItems := make([]int, len(sortedItems))
+		copy(reversedItems, sortedItems)
+		util.Reverse(reversedItems)
+
+		require.Equal(t, sortedItems, forwards)
+		require.Equal(t, reversedItems, backwards)
+	})
+
+	t.Run("Change direction", func(t *testing.T) {
+		set := Of(1, 2, 3, 4)
+		iter := set.BidirectionalIterator()
+
+		require.Equal(t, 4, iter.End().Value())
+		require.Equal(t, 3, iter.Prev().Value())
+		require.Equal(t, 4, iter.Next().Value())
+		require.Nil(t, iter.Next())
+		require.Equal(t, 4, iter.Prev().Value())
+	})
+
+	t.Run("Nearest neighbours", func(t *testing.T) {
+		set := Of(10, 20, 30, 40)
+		iter := set.BidirectionalIterator()
+
+		require.Equal(t, 30, iter.Seek(25).Value())
+		require.Equal(t, 20, iter.Prev().Value())
+
+		require.Equal(t, 20, iter.Seek(20).Value())
+		require.Equal(t, 10, iter.Prev().Value())
+		require.Nil(t, iter.Prev())
+
+		require.Nil(t, iter.Seek(50))
+		require.Equal(t, 40, iter.Prev().Value())
+
+		require.Equal(t, 10, iter.Seek(5).Value())
+		require.Nil(t, iter.Prev())
+	})
+
+	t.Run("Empty set", func(t *testing.T) {
+		iter := New[int]().BidirectionalIterator()
+
+		require.Nil(t, iter.Start())
+		require.Nil(t, iter.End())
+		require.Nil(t, iter.Seek(1))
+		require.Nil(t, iter.Prev())
+	})
+
+	t.Run("Panics if set modified", func(t *testing.T) {
+		set := Of(1, 2, 3)
+		iter := set.BidirectionalIterator()
+		iter.Start()
+		set.Add(4)
+
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Next() })
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Prev() })
+	})
+}
@@ -0,0 +1,127 @@
+package orderedset
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Floor returns the greatest value in the set that is less than or equal to value. O(log n).
+// Returns false if there is no such value.
+func (s *OrderedSet[T]) Floor(value T) (T, bool) {
+
+	return s.nearest(bounds[T]{high: &bound[T]{value: value, inclusive: true}}, false)
+}
+
+// Ceiling returns the least value in the set that is greater than or equal to value. O(log n).
+// Returns false if there is no such value.
+func (s *OrderedSet[T]) Ceiling(value T) (T, bool) {
+
+	return s.nearest(bounds[T]{low: &bound[T]{value: value, inclusive: true}}, true)
+}
+
+// Lower returns the greatest value in the set that is strictly less than value. O(log n).
+// Returns false if there is no such value.
+func (s *OrderedSet[T]) Lower(value T) (T, bool) {
+
+	return s.nearest(bounds[T]{high: &bound[T]{value: value}}, false)
+}
+
+// Higher returns the least value in the set that is strictly greater than value. O(log n).
+// Returns false if there is no such value.
+func (s *OrderedSet[T]) Higher(value T) (T, bool) {
+
+	return s.nearest(bounds[T]{low: &bound[T]{value: value}}, true)
+}
+
+// ClosestTo returns the value in the set nearest to value. O(log n).
+//
+// If value is in the set it is returned. Else the nearer of the greatest value less than it and the least value
+// greater than it is returned, as measured by distance, which is only called when there are both, e.g. to find
+// the entry nearest a timestamp:
+//
+//	v, ok := set.ClosestTo(t, func(a, b time.Time) float64 { return math.Abs(float64(a.Sub(b))) })
+//
+// If they are equally near, the lesser is returned. Returns false if the set is empty.
+//
+// Panics if distance is nil.
+func (s *OrderedSet[T]) ClosestTo(value T, distance functions.DistanceFunc[T]) (T, bool) {
+
+	if distance == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "distance"))
+	}
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	below := s.floor(value)
+	above := s.lowerBound(value)
+
+	switch {
+	case below == nil && above == nil:
+		var zero T
+		return zero, false
+	case below == nil:
+		return above.item, true
+	case above == nil || below == above:
+		return below.item, true
+	}
+
+	return util.Closest(value, below.item, above.item, distance), true
+}
+
+// Range returns an iterator over the values in the set that are greater than or equal to from
+// and less than or equal to to, in ascending order.
+//
+// Only the nodes of the tree within the range are visited, so finding the first value is O(log n)
+// and each step after it amortized O(1). As with any iterator, the set must not be modified while it is being walked.
+//
+// Panics if from is greater than to.
+func (s *OrderedSet[T]) Range(from, to T) collections.Iterator[T] {
+
+	if s.comparer()(from, to) > 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "from"))
+	}
+
+	return newBidirectionalIterator(s, bounds[T]{
+		low:  &bound[T]{value: from, inclusive: true},
+		high: &bound[T]{value: to, inclusive: true},
+	})
+}
+
+// nearest returns the least value within b if ascending, else the greatest.
+func (s *OrderedSet[T]) nearest(b bounds[T], ascending bool) (T, bool) {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	var n *node[T]
+
+	if ascending {
+		n = s.first(b)
+	} else {
+		n = s.last(b)
+	}
+
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+
+	return n.item, true
+}
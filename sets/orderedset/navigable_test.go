@@ -0,0 +1,162 @@
+package orderedset
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNavigation(t *testing.T) {
+
+	type result struct {
+		value int
+		ok    bool
+	}
+
+	pair := func(v int, ok bool) result { return result{v, ok} }
+
+	t.Run("Floor, Ceiling, Lower and Higher", func(t *testing.T) {
+		s := Of(10, 20, 30)
+
+		require.Equal(t, result{20, true}, pair(s.Floor(20)))
+		require.Equal(t, result{20, true}, pair(s.Floor(25)))
+		require.Equal(t, result{0, false}, pair(s.Floor(5)))
+
+		require.Equal(t, result{20, true}, pair(s.Ceiling(20)))
+		require.Equal(t, result{30, true}, pair(s.Ceiling(25)))
+		require.Equal(t, result{0, false}, pair(s.Ceiling(35)))
+
+		require.Equal(t, result{10, true}, pair(s.Lower(20)))
+		require.Equal(t, result{30, true}, pair(s.Lower(35)))
+		require.Equal(t, result{0, false}, pair(s.Lower(10)))
+
+		require.Equal(t, result{30, true}, pair(s.Higher(20)))
+		require.Equal(t, result{10, true}, pair(s.Higher(5)))
+		require.Equal(t, result{0, false}, pair(s.Higher(30)))
+	})
+
+	t.Run("Agree with a sorted model", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		s := New[int]()
+
+		for i := 0; i < 500; i++ {
+			s.Add(r.Intn(1000))
+		}
+
+		values := s.ToSlice()
+
+		for v := -1; v <= 1001; v++ {
+			i := sort.SearchInts(values, v) // first index >= v
+			present := i < len(values) && values[i] == v
+
+			at := func(i int) result {
+				if i < 0 || i >= len(values) {
+					return result{}
+				}
+				return result{values[i], true}
+			}
+
+			require.Equal(t, at(i), pair(s.Ceiling(v)))
+			require.Equal(t, at(i-1), pair(s.Lower(v)))
+			require.Equal(t, at(util.Iif(present, i, i-1)), pair(s.Floor(v)))
+			require.Equal(t, at(util.Iif(present, i+1, i)), pair(s.Higher(v)))
+		}
+	})
+
+	t.Run("Empty and zero value sets", func(t *testing.T) {
+		var zero OrderedSet[int]
+
+		require.Equal(t, result{0, false}, pair(zero.Floor(1)))
+		require.Equal(t, result{0, false}, pair(zero.Higher(1)))
+		require.Nil(t, zero.Range(1, 2).Start())
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { New[int]().Min() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { New[int]().Max() })
+	})
+}
+
+func TestClosestTo(t *testing.T) {
+
+	distance := functions.AbsDifference[int]
+
+	var zero OrderedSet[int]
+	_, ok := zero.ClosestTo(1, distance)
+	require.False(t, ok)
+
+	s := Of(10, 20, 40)
+	closest := func(v int) int {
+		c, ok := s.ClosestTo(v, distance)
+		require.True(t, ok)
+		return c
+	}
+
+	require.Equal(t, 10, closest(0))
+	require.Equal(t, 20, closest(20))
+	require.Equal(t, 20, closest(24))
+	require.Equal(t, 20, closest(30))
+	require.Equal(t, 40, closest(31))
+	require.Equal(t, 40, closest(100))
+
+	t.Run("Distance is only called between two values", func(t *testing.T) {
+		calls := 0
+		d := func(a, b int) float64 { calls++; return distance(a, b) }
+
+		s.ClosestTo(20, d)
+		s.ClosestTo(5, d)
+		s.ClosestTo(50, d)
+		require.Zero(t, calls)
+
+		s.ClosestTo(25, d)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("Nearest timestamp", func(t *testing.T) {
+		base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		times := NewFunc(func(a, b time.Time) int { return a.Compare(b) })
+		times.AddRange([]time.Time{base, base.Add(time.Hour), base.Add(3 * time.Hour)})
+
+		c, _ := times.ClosestTo(base.Add(130*time.Minute), func(a, b time.Time) float64 { return math.Abs(float64(a.Sub(b))) })
+		require.Equal(t, base.Add(3*time.Hour), c)
+	})
+
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "distance"), func() { s.ClosestTo(1, nil) })
+}
+
+func TestRange(t *testing.T) {
+
+	collect := func(iter collections.Iterator[int]) []int {
+		var values []int
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+		}
+		return values
+	}
+
+	s := Of(10, 20, 30, 40, 50)
+
+	require.Equal(t, []int{20, 30, 40}, collect(s.Range(20, 40)))
+	require.Equal(t, []int{20, 30, 40}, collect(s.Range(15, 45)))
+	require.Equal(t, []int{30}, collect(s.Range(30, 30)))
+	require.Empty(t, collect(s.Range(31, 39)))
+	require.Empty(t, collect(s.Range(60, 70)))
+	require.Equal(t, []int{10, 20, 30, 40, 50}, collect(s.Range(0, 100)))
+
+	// Restarting reflects the same range
+	iter := s.Range(20, 30)
+	require.Equal(t, []int{20, 30}, collect(iter))
+	require.Equal(t, []int{20, 30}, collect(iter))
+
+	iter = s.Range(20, 30)
+	s.Add(25)
+	require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Start() })
+
+	require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "from"), func() { s.Range(2, 1) })
+}
@@ -12,13 +12,16 @@ import (
 	"github.com/fireflycons/generic_collections/internal/local"
 	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
 	"github.com/fireflycons/generic_collections/sets"
 	"github.com/fireflycons/generic_collections/stacks/stack"
+	"golang.org/x/exp/constraints"
 )
 
 // Assert OrderedSet implements required interfaces.
 var _ sets.Set[int] = (*OrderedSet[int])(nil)
 var _ collections.ReverseIterable[int] = (*OrderedSet[int])(nil)
+var _ collections.Pageable[int] = (*OrderedSet[int])(nil)
 
 type color bool
 
@@ -33,14 +36,29 @@ type treeWalkPredicate[T any] func(*node[T]) bool
 type OrderedSetOptionFunc[T any] func(*OrderedSet[T])
 
 // OrderedSet stores an ordered collection of unique elements.
+//
+// The zero value is an empty set with default options, ready to use. It is not thread-safe.
 type OrderedSet[T any] struct {
-	version    int
-	lock       *sync.RWMutex
-	root       *node[T]
-	size       int
-	compare    functions.ComparerFunc[T]
-	copy       functions.DeepCopyFunc[T]
-	concurrent bool
+	version      int
+	lock         *sync.RWMutex
+	root         *node[T]
+	size         int
+	compare      functions.ComparerFunc[T]
+	copy         functions.DeepCopyFunc[T]
+	copyPolicy   collections.CopyPolicy
+	concurrent   bool
+	recorder     util.OpRecorder[T]
+	guard        util.AccessGuard
+	immutable    util.SliceCache[T]
+	lastSnapshot *snapshot[T]
+	stableIDs    bool
+	accept       functions.PredicateFunc[T]
+	validator    *util.Validator[T]
+	verifySchema bool
+	nextID       uint64
+	ids          map[uint64]*node[T]
+	name         string
+	registration *registry.Registration
 	local.InternalImpl
 }
 
@@ -48,15 +66,18 @@ type OrderedSet[T any] struct {
 type node[T any] struct {
 	item   T
 	color  color
+	count  int
 	left   *node[T]
 	right  *node[T]
 	Parent *node[T]
+	id     uint64
 }
 
 func newNode[T any](value T) *node[T] {
 	return &node[T]{
 		item:  value,
 		color: red,
+		count: 1,
 	}
 }
 
@@ -68,7 +89,7 @@ func New[T any](options ...OrderedSetOptionFunc[T]) *OrderedSet[T] {
 		o(set)
 	}
 
-	if set.copy == nil {
+	if set.copy == nil || set.copyPolicy == collections.Shallow {
 		set.copy = util.DefaultDeepCopy[T]
 	}
 
@@ -76,9 +97,65 @@ func New[T any](options ...OrderedSetOptionFunc[T]) *OrderedSet[T] {
 		set.compare = util.GetDefaultComparer[T]()
 	}
 
+	set.register()
+
 	return set
 }
 
+// lazyInit completes the construction of a zero value OrderedSet on its first modification,
+// as New would have done.
+func (s *OrderedSet[T]) lazyInit() {
+	if s.compare != nil {
+		return
+	}
+
+	s.compare = util.GetZeroValueComparer[T]()
+
+	if s.copy == nil {
+		s.copy = util.DefaultDeepCopy[T]
+	}
+}
+
+// comparer returns the comparer of the set, or for a zero value set that has not yet been modified,
+// the comparer it will be given when it is.
+func (s *OrderedSet[T]) comparer() functions.ComparerFunc[T] {
+	if s.compare == nil {
+		return util.GetZeroValueComparer[T]()
+	}
+
+	return s.compare
+}
+
+// Of constructs a new set containing the given values, e.g. orderedset.Of(1, 2, 3).
+//
+// Duplicate values are added once.
+func Of[T any](values ...T) *OrderedSet[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	s := orderedset.OfWith([]orderedset.OrderedSetOptionFunc[int]{orderedset.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []OrderedSetOptionFunc[T], values ...T) *OrderedSet[T] {
+	s := New(options...)
+	s.AddRange(values)
+	return s
+}
+
+// NewFunc is as [New], using compare to order the set.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...OrderedSetOptionFunc[T]) *OrderedSet[T] {
+	return New(append([]OrderedSetOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...OrderedSetOptionFunc[T]) *OrderedSet[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
 // Option function for New to make the collection thread-safe. Adds overhead.
 func WithThreadSafe[T any]() OrderedSetOptionFunc[T] {
 	return func(s *OrderedSet[T]) {
@@ -112,6 +189,65 @@ func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) OrderedSetOptionFunc[
 	}
 }
 
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) OrderedSetOptionFunc[T] {
+	return func(s *OrderedSet[T]) {
+		s.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+//
+// Capacity is ignored by this collection.
+func WithOptions[T any](options collections.CommonOptions[T]) OrderedSetOptionFunc[T] {
+	opts := make([]OrderedSetOptionFunc[T], 0, 5)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Concurrent {
+		opts = append(opts, WithConcurrent[T]())
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(s *OrderedSet[T]) {
+		for _, o := range opts {
+			o(s)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection,
+// ReplaceAll and AddWithID with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) OrderedSetOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(s *OrderedSet[T]) {
+		s.validator = util.NewValidator(validator, policy)
+	}
+}
+
 // AddRange adds a slice of values to the set.
 func (s *OrderedSet[T]) AddRange(values []T) {
 
@@ -124,6 +260,17 @@ func (s *OrderedSet[T]) AddRange(values []T) {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.checkValues(values...)
+
+	if values = s.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
 	s.version++
 
 	for _, v := range values {
@@ -134,11 +281,45 @@ func (s *OrderedSet[T]) AddRange(values []T) {
 // AddCollection inserts the values of the given collection into this set.
 func (s *OrderedSet[T]) AddCollection(collection collections.Collection[T]) {
 
-	s.AddRange(collection.ToSliceDeep())
+	s.AddRange(util.ImportValues(collection, s.copyPolicy))
+}
+
+// ReplaceAll replaces the content of the set with the values of the given collection.
+func (s *OrderedSet[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	s.replaceAll(util.ImportValues(collection, s.copyPolicy))
+}
+
+// replaceAll replaces the values of the set with the given values under a single lock.
+func (s *OrderedSet[T]) replaceAll(values []T) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.checkValues(values...)
+	values = s.validator.Filter(values)
+
+	s.root = nil
+	s.size = 0
+	s.ids = nil
+	s.recorder.Clear()
+
+	for _, v := range values {
+		s.doInsert(v)
+	}
+
+	s.version++
 }
 
 // Add adds a value into the collection.
-// Returns false if the value already exists; else true if it was added.
+// Returns false if the value already exists or was rejected by the validator; else true if it was added.
 func (s *OrderedSet[T]) Add(value T) bool {
 
 	if s.lock != nil {
@@ -146,6 +327,16 @@ func (s *OrderedSet[T]) Add(value T) bool {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if !s.validator.Accept(value) {
+		return false
+	}
+
 	inserted := s.doInsert(value)
 	s.version++
 	return inserted
@@ -159,6 +350,10 @@ func (s *OrderedSet[T]) Contains(value T) bool {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	return s.lookup(value) != nil
 }
 
@@ -167,7 +362,7 @@ func (s *OrderedSet[T]) UnlockedContains(value T) bool {
 }
 
 // Get returns the collection element that matches the given value, or nil if it is not found.
-// Useful if the set contains struct elements you want to modify in-place.
+// Useful if the comparer matches struct elements on a key, to retrieve the stored element.
 func (s *OrderedSet[T]) Get(value T) collections.Element[T] {
 
 	if s.lock != nil {
@@ -175,13 +370,17 @@ func (s *OrderedSet[T]) Get(value T) collections.Element[T] {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	n := s.lookup(value)
 
 	if n == nil {
 		return nil
 	}
 
-	return util.NewElementType[T](s, &n.item)
+	return util.NewElementType[T](s, &n.item, s.compare)
 }
 
 // Remove removes a value from the set.
@@ -195,35 +394,14 @@ func (s *OrderedSet[T]) Remove(key T) bool {
 		defer s.lock.Unlock()
 	}
 
-	s.version++
-	var child *node[T]
-	n := s.lookup(key)
-	if n == nil {
-		return false
-	}
-	if n.left != nil && n.right != nil {
-		pred := n.left.maximumNode()
-		n.item = pred.item
-		n = pred
-	}
-	if n.left == nil || n.right == nil {
-		if n.right == nil {
-			child = n.left
-		} else {
-			child = n.right
-		}
-		if n.color == black {
-			n.color = nodeColor(child)
-			// Delete as per https://en.wikipedia.org/wiki/Red%E2%80%93black_tree
-			s.deleteCase1(n)
-		}
-		s.replaceNode(n, child)
-		if n.Parent == nil && child != nil {
-			child.color = black
-		}
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
 	}
-	s.size--
-	return true
+
+	s.lazyInit()
+
+	s.version++
+	return s.remove(key)
 }
 
 // Empty returns true if tree does not contain any nodes.
@@ -252,11 +430,50 @@ func (s *OrderedSet[T]) ToSlice() []T {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	slc := make([]T, s.size)
 	s.copyTo(slc, 0, s.size, false)
 	return slc
 }
 
+// ToImmutableSlice returns the content of the set as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the set has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified.
+func (s *OrderedSet[T]) ToImmutableSlice() []T {
+
+	if s.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		s.lock.RLock()
+		values, ok := s.immutable.Load(s.version)
+		s.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	return s.immutable.Get(s.version, func() []T {
+		slc := make([]T, s.size)
+		s.copyTo(slc, 0, s.size, false)
+		return slc
+	})
+}
+
 // ToSliceDeep returns the collection content as a slice.
 // The values will be in ascending order.
 // Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
@@ -267,11 +484,47 @@ func (s *OrderedSet[T]) ToSliceDeep() []T {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	slc := make([]T, s.size)
 	s.copyTo(slc, 0, s.size, true)
 	return slc
 }
 
+// Page returns a copy of the values on the given zero-based page of the set,
+// in ascending order.
+//
+// The first value of the page is found from the order statistics of the tree,
+// so this is O(log n + pageSize) rather than requiring a walk of the preceding pages.
+//
+// Panics if pageIndex is negative or pageSize is less than 1.
+func (s *OrderedSet[T]) Page(pageIndex, pageSize int) []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	start, end := util.PageBounds(s.size, pageIndex, pageSize)
+	page := make([]T, 0, end-start)
+
+	if end == start {
+		return page
+	}
+
+	for n := s.nth(start); len(page) < end-start; n = n.successor() {
+		page = append(page, n.item)
+	}
+
+	return page
+}
+
 // Clear removes all nodes from the tree.
 func (s *OrderedSet[T]) Clear() {
 
@@ -280,9 +533,13 @@ func (s *OrderedSet[T]) Clear() {
 		defer s.lock.Unlock()
 	}
 
-	s.root = nil
-	s.size = 0
-	s.version++
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.clear()
 }
 
 // String returns a string representation of container.
@@ -324,6 +581,10 @@ func (s *OrderedSet[T]) Difference(other sets.Set[T]) sets.Set[T] {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.makeEmptyCopy()
 
 	osOther, otherIsOrderedSet := other.(*OrderedSet[T])
@@ -369,6 +630,10 @@ func (s *OrderedSet[T]) Intersection(other sets.Set[T]) sets.Set[T] {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	// It's much quicker to scan the smaller collection
 	// and look up values in the larger one as lookup
 	// is very fast in sets.
@@ -440,6 +705,10 @@ func (s *OrderedSet[T]) Union(other sets.Set[T]) sets.Set[T] {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.makeEmptyCopy()
 	result.AddCollection(s)
 	result.AddCollection(other)
@@ -492,6 +761,34 @@ func (s *OrderedSet[T]) TreeWalk(action func(T) bool) bool {
 	}, false)
 }
 
+// WalkRange calls fn for each value in the set that is greater than or equal to from
+// and less than or equal to to, in ascending order. If fn returns false, stop the walk.
+//
+// Only the values within the range are visited, and the walk does not allocate.
+// fn must not modify the set.
+//
+// Returns true if every value in the range has been walked.
+// Otherwise returns false.
+func (s *OrderedSet[T]) WalkRange(from, to T, fn func(T) bool) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	for n := s.lowerBound(from); n != nil && s.compare(n.item, to) <= 0; n = n.successor() {
+		if !fn(n.item) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Do an in order walk on tree and calls the delegate for each node.
 // If the action delegate returns false, stop the walk.
 //
@@ -588,6 +885,72 @@ func (s *OrderedSet[T]) lookup(key T) *node[T] {
 	return nil
 }
 
+// lowerBound returns the node with the smallest value greater than or equal to key,
+// or nil if there is no such node.
+func (s *OrderedSet[T]) lowerBound(key T) *node[T] {
+	var bound *node[T]
+	node := s.root
+	for node != nil {
+		compare := s.compare(key, node.item)
+		switch {
+		case compare == 0:
+			return node
+		case compare < 0:
+			bound = node
+			node = node.left
+		case compare > 0:
+			node = node.right
+		}
+	}
+	return bound
+}
+
+// floor returns the node with the largest value less than or equal to key,
+// or nil if there is no such node.
+func (s *OrderedSet[T]) floor(key T) *node[T] {
+	var bound *node[T]
+	node := s.root
+	for node != nil {
+		compare := s.compare(key, node.item)
+		switch {
+		case compare == 0:
+			return node
+		case compare < 0:
+			node = node.left
+		case compare > 0:
+			bound = node
+			node = node.right
+		}
+	}
+	return bound
+}
+
+// successor returns the node with the next largest value, or nil if this is the maximum.
+func (n *node[T]) successor() *node[T] {
+	if n.right != nil {
+		n = n.right
+		for n.left != nil {
+			n = n.left
+		}
+		return n
+	}
+	for n.Parent != nil && n == n.Parent.right {
+		n = n.Parent
+	}
+	return n.Parent
+}
+
+// predecessor returns the node with the next smallest value, or nil if this is the minimum.
+func (n *node[T]) predecessor() *node[T] {
+	if n.left != nil {
+		return n.left.maximumNode()
+	}
+	for n.Parent != nil && n == n.Parent.left {
+		n = n.Parent
+	}
+	return n.Parent
+}
+
 func (n *node[T]) grandparent() *node[T] {
 	if n != nil && n.Parent != nil {
 		return n.Parent.Parent
@@ -621,6 +984,8 @@ func (s *OrderedSet[T]) rotateLeft(n *node[T]) {
 	}
 	rightNode.left = n
 	n.Parent = rightNode
+	rightNode.count = n.count
+	n.updateCount()
 }
 
 func (s *OrderedSet[T]) rotateRight(n *node[T]) {
@@ -632,6 +997,8 @@ func (s *OrderedSet[T]) rotateRight(n *node[T]) {
 	}
 	leftNode.right = n
 	n.Parent = leftNode
+	leftNode.count = n.count
+	n.updateCount()
 }
 
 func (s *OrderedSet[T]) replaceNode(oldNode *node[T], newNode *node[T]) {
@@ -650,6 +1017,8 @@ func (s *OrderedSet[T]) replaceNode(oldNode *node[T], newNode *node[T]) {
 }
 
 func (s *OrderedSet[T]) doInsert(value T) bool {
+	s.checkValues(value)
+
 	var insertedNode *node[T]
 	if s.root == nil {
 		s.root = newNode(value)
@@ -681,13 +1050,68 @@ func (s *OrderedSet[T]) doInsert(value T) bool {
 			}
 		}
 		insertedNode.Parent = n
+
+		for ; n != nil; n = n.Parent {
+			n.count++
+		}
 	}
 	// Insertion as per https://en.wikipedia.org/wiki/Red%E2%80%93black_tree
 	s.insertCase1(insertedNode)
+	s.assignID(insertedNode)
 	s.size++
+	s.recorder.Add(value)
+	return true
+}
+
+func (s *OrderedSet[T]) remove(key T) bool {
+	var child *node[T]
+	n := s.lookup(key)
+	if n == nil {
+		return false
+	}
+	s.releaseID(n)
+	if n.left != nil && n.right != nil {
+		pred := n.left.maximumNode()
+		n.item = pred.item
+		// The ID moves with the value, so that it survives the removal of another value
+		n.id = pred.id
+		s.indexID(n)
+		n = pred
+	}
+	if n.left == nil || n.right == nil {
+		if n.right == nil {
+			child = n.left
+		} else {
+			child = n.right
+		}
+		// Discount the node before rebalancing, which leaves it in place while rotating around it.
+		n.count--
+		for p := n.Parent; p != nil; p = p.Parent {
+			p.count--
+		}
+		if n.color == black {
+			n.color = nodeColor(child)
+			// Delete as per https://en.wikipedia.org/wiki/Red%E2%80%93black_tree
+			s.deleteCase1(n)
+		}
+		s.replaceNode(n, child)
+		if n.Parent == nil && child != nil {
+			child.color = black
+		}
+	}
+	s.size--
+	s.recorder.Remove(key)
 	return true
 }
 
+func (s *OrderedSet[T]) clear() {
+	s.root = nil
+	s.size = 0
+	s.ids = nil
+	s.version++
+	s.recorder.Clear()
+}
+
 func (s *OrderedSet[T]) insertCase1(n *node[T]) {
 	if n.Parent == nil {
 		n.color = black
@@ -738,6 +1162,29 @@ func (s *OrderedSet[T]) insertCase5(n *node[T]) {
 	}
 }
 
+// nodeCount returns the number of nodes in the subtree rooted at n.
+func nodeCount[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.count
+}
+
+// updateCount recomputes the number of nodes in the subtree rooted at n from its children.
+func (n *node[T]) updateCount() {
+	n.count = nodeCount(n.left) + nodeCount(n.right) + 1
+}
+
+func (n *node[T]) minimumNode() *node[T] {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
 func (n *node[T]) maximumNode() *node[T] {
 	if n == nil {
 		return nil
@@ -832,7 +1279,11 @@ func (s *OrderedSet[T]) makeEmptyCopy() *OrderedSet[T] {
 	other := &OrderedSet[T]{
 		compare:    s.compare,
 		copy:       s.copy,
+		copyPolicy: s.copyPolicy,
 		concurrent: s.concurrent,
+		stableIDs:  s.stableIDs,
+		accept:     s.accept,
+		validator:  s.validator,
 	}
 
 	if s.lock != nil {
@@ -1,15 +1,26 @@
 package orderedset
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"math"
 	"math/rand"
 	"sort"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
 	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/fireflycons/generic_collections/schema"
+	"github.com/fireflycons/generic_collections/sets"
 	"github.com/fireflycons/generic_collections/sets/hashset"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +35,21 @@ func TestConstructor(t *testing.T) {
 		require.Equal(t, magic, set.compare(1, 0))
 	})
 
+	t.Run("With common options", func(t *testing.T) {
+		magic := 42
+		opts := collections.CommonOptions[int]{
+			ThreadSafe: true,
+			Concurrent: true,
+			Capacity:   100,
+			Comparer:   func(v1, v2 int) int { return magic },
+		}
+		set := New(WithOptions(opts))
+
+		require.NotNil(t, set.lock)
+		require.True(t, set.concurrent)
+		require.Equal(t, magic, set.compare(1, 0))
+	})
+
 	t.Run("With nil comparer panics", func(t *testing.T) {
 		var comp func(v1, v2 int) int
 		require.Panics(t, func() { New(WithComparer(comp)) })
@@ -356,6 +382,80 @@ func TestDifference(t *testing.T) {
 	})
 }
 
+func TestReplaceAll(t *testing.T) {
+
+	seed := int64(2163)
+	items := util.CreateSingleIntListData(32, &seed)
+
+	t.Run("Content is replaced", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange(items[:16])
+		other := dlist.New[int]()
+		other.AddRange(items[16:])
+		set.ReplaceAll(other)
+		expected := make([]int, 16)
+		copy(expected, items[16:])
+		sort.Ints(expected)
+		require.Equal(t, expected, set.ToSlice())
+	})
+
+	t.Run("Replace with empty collection", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange(items)
+		set.ReplaceAll(dlist.New[int]())
+		require.True(t, set.IsEmpty())
+	})
+}
+
+func TestPage(t *testing.T) {
+
+	seed := int64(2163)
+	items := util.CreateSingleIntListData(10, &seed)
+	sorted := make([]int, len(items))
+	copy(sorted, items)
+	sort.Ints(sorted)
+
+	t.Run("Page of empty set is empty", func(t *testing.T) {
+		set := New[int]()
+		require.Equal(t, []int{}, set.Page(0, 4))
+	})
+
+	t.Run("Pages are returned in ascending order", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange(items)
+		require.Equal(t, sorted[0:4], set.Page(0, 4))
+		require.Equal(t, sorted[4:8], set.Page(1, 4))
+		require.Equal(t, sorted[8:], set.Page(2, 4))
+		require.Equal(t, []int{}, set.Page(3, 4))
+	})
+
+	t.Run("Every page of a large set matches the sorted values", func(t *testing.T) {
+		seed := int64(2163)
+		items := util.CreateSingleIntListData(1000, &seed)
+		set := New[int]()
+		set.AddRange(items)
+		sorted := set.ToSlice()
+
+		for _, pageSize := range []int{1, 7, 64, 1000, 1001} {
+			for pageIndex := 0; pageIndex*pageSize <= len(sorted); pageIndex++ {
+				start := pageIndex * pageSize
+				end := start + pageSize
+				if end > len(sorted) {
+					end = len(sorted)
+				}
+
+				require.Equal(t, sorted[start:end], set.Page(pageIndex, pageSize), "page %d of size %d", pageIndex, pageSize)
+			}
+		}
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		set := New[int]()
+		require.Panics(t, func() { set.Page(-1, 4) })
+		require.Panics(t, func() { set.Page(0, 0) })
+	})
+}
+
 func TestUnsafe(t *testing.T) {
 
 	t.Run("GetVersion", func(t *testing.T) {
@@ -470,6 +570,22 @@ func BenchmarkOrderedSet(b *testing.B) {
 			})
 		}
 
+		for _, elems := range elements {
+			b.Run(fmt.Sprintf("Set-AddOrdered-%d-%s-NA-NA", elems, util.Iif(threadsafe, "ThreadSafe", "NoThreadSafe")), func(b *testing.B) {
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					if threadsafe {
+						s = NewOrdered(WithThreadSafe[int]())
+					} else {
+						s = NewOrdered[int]()
+					}
+					b.StartTimer()
+					benchmarkAdd(s, data[elems])
+				}
+			})
+		}
+
 		for _, elems := range elements {
 			b.Run(fmt.Sprintf("Set-Remove-%d-%s-NA-NA", elems, util.Iif(threadsafe, "ThreadSafe", "NoThreadSafe")), func(b *testing.B) {
 				b.ResetTimer()
@@ -504,6 +620,15 @@ func BenchmarkOrderedSet(b *testing.B) {
 				s.Contains(lookup[i%elems])
 			}
 		})
+
+		ordered := NewOrdered[int]()
+		ordered.AddRange(data[elems])
+
+		b.Run(fmt.Sprintf("Set-ContainsOrdered-%d-NA-NA-NA", elems), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ordered.Contains(lookup[i%elems])
+			}
+		})
 	}
 
 	for _, elems := range elements {
@@ -524,3 +649,793 @@ func BenchmarkOrderedSet(b *testing.B) {
 	}
 
 }
+
+func TestApplyOps(t *testing.T) {
+
+	t.Run("Script is applied in order", func(t *testing.T) {
+		s := New[int]()
+		s.Add(10)
+		s.ApplyOps([]ops.Op[int]{
+			ops.Clear[int](),
+			ops.Add(1),
+			ops.Add(2),
+			ops.Add(1),
+			ops.Add(3),
+			ops.Remove(1),
+			ops.Remove(5),
+		})
+
+		require.ElementsMatch(t, []int{2, 3}, s.ToSlice())
+	})
+
+	t.Run("Invalid op kind panics", func(t *testing.T) {
+		s := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.OP_KIND_INVALID_FMT, 9), func() {
+			s.ApplyOps([]ops.Op[int]{ops.Add(1), {Kind: 9}})
+		})
+		require.Equal(t, []int{1}, s.ToSlice())
+	})
+}
+
+func TestRecording(t *testing.T) {
+
+	t.Run("Replaying recorded ops reproduces set", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{9, 8})
+		s.StartRecording()
+		require.True(t, s.IsRecording())
+
+		for i := 0; i < 20; i++ {
+			s.Add(i)
+		}
+		s.AddRange([]int{2, 3, 100})
+		s.Remove(2)
+		s.Remove(9)
+		other := New[int]()
+		other.AddRange([]int{50, 51, 52})
+		s.ReplaceAll(other)
+		s.AddCollection(dlist.New[int]())
+		s.Add(5)
+		s.Remove(51)
+
+		recorded := s.StopRecording()
+		require.False(t, s.IsRecording())
+
+		replica := New[int]()
+		replica.AddRange([]int{9, 8})
+		replica.ApplyOps(recorded)
+		require.ElementsMatch(t, s.ToSlice(), replica.ToSlice())
+	})
+
+	t.Run("Only changes are recorded", func(t *testing.T) {
+		s := New[int]()
+		s.StartRecording()
+		s.Add(1)
+		s.Add(1)
+		s.Remove(2)
+		s.Remove(1)
+		s.Clear()
+
+		require.Equal(t, []ops.Op[int]{ops.Add(1), ops.Remove(1), ops.Clear[int]()}, s.StopRecording())
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+
+	t.Run("Diff reports added and removed values", func(t *testing.T) {
+		s := New[int]()
+		for i := 0; i < 1000; i++ {
+			s.Add(i)
+		}
+
+		before := s.Snapshot()
+		s.Remove(10)
+		s.Remove(500)
+		s.Add(1000)
+		s.Add(2000)
+		after := s.Snapshot()
+
+		added, removed := sets.DiffSnapshots(before, after)
+		require.ElementsMatch(t, []int{1000, 2000}, added)
+		require.ElementsMatch(t, []int{10, 500}, removed)
+		require.Equal(t, 1000, before.Count())
+		require.Equal(t, 1000, after.Count())
+	})
+
+	t.Run("Snapshot is unaffected by later modifications", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		snap := s.Snapshot()
+		s.Remove(2)
+		s.Add(4)
+		s.Clear()
+
+		require.ElementsMatch(t, []int{1, 2, 3}, snap.ToSlice())
+	})
+
+	t.Run("Unmodified set returns same snapshot and empty diff", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		a := s.Snapshot()
+		b := s.Snapshot()
+
+		require.Same(t, a, b)
+		added, removed := sets.DiffSnapshots(a, b)
+		require.Empty(t, added)
+		require.Empty(t, removed)
+	})
+
+	t.Run("Diff snapshots of different sets", func(t *testing.T) {
+		s1 := New[int]()
+		s1.AddRange([]int{1, 2, 3})
+		s2 := New[int]()
+		s2.AddRange([]int{2, 3, 4})
+
+		added, removed := sets.DiffSnapshots(s1.Snapshot(), s2.Snapshot())
+		require.Equal(t, []int{4}, added)
+		require.Equal(t, []int{1}, removed)
+	})
+
+	t.Run("Diff with snapshot of another set type panics", func(t *testing.T) {
+		require.PanicsWithValue(t, messages.SNAPSHOT_TYPE_MISMATCH, func() {
+			sets.DiffSnapshots(New[int]().Snapshot(), hashset.New[int]().Snapshot())
+		})
+	})
+
+	t.Run("Nil snapshot panics", func(t *testing.T) {
+		require.Panics(t, func() { sets.DiffSnapshots(New[int]().Snapshot(), nil) })
+	})
+}
+
+func TestCloneInto(t *testing.T) {
+
+	seed := int64(2163)
+
+	t.Run("Destination receives copy of values", func(t *testing.T) {
+		values := util.CreateSingleIntListData(100, &seed)
+		s := New[int]()
+		s.AddRange(values)
+		dst := New[int](WithThreadSafe[int]())
+		dst.AddRange([]int{-1, -2, -3})
+		s.CloneInto(dst)
+
+		require.Equal(t, s.ToSlice(), dst.ToSlice())
+		require.False(t, dst.Contains(-1))
+		require.NotNil(t, dst.lock)
+	})
+
+	t.Run("Destination remains a valid tree", func(t *testing.T) {
+		values := util.CreateSingleIntListData(100, &seed)
+		s := New[int]()
+		s.AddRange(values)
+		dst := New[int]()
+		dst.AddRange(util.CreateSingleIntListData(20, &seed))
+		s.CloneInto(dst)
+
+		for _, v := range values[:50] {
+			require.True(t, dst.Remove(v))
+		}
+
+		dst.Add(-1)
+		expected := append([]int{-1}, values[50:]...)
+		sort.Ints(expected)
+		require.Equal(t, expected, dst.ToSlice())
+		require.Equal(t, 100, s.Count())
+	})
+
+	t.Run("Destination nodes are reused", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange(util.CreateSingleIntListData(100, &seed))
+		dst := New[int]()
+		s.CloneInto(dst)
+
+		if util.Debug {
+			t.Skip("assertions allocate in debug builds")
+		}
+
+		allocs := testing.AllocsPerRun(10, func() { s.CloneInto(dst) })
+		require.Zero(t, allocs)
+	})
+
+	t.Run("Nil destination panics", func(t *testing.T) {
+		s := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dst"), func() { s.CloneInto(nil) })
+	})
+}
+
+func TestEncode(t *testing.T) {
+
+	encodeInt := func(w io.Writer, v int) error {
+		return binary.Write(w, binary.LittleEndian, int64(v))
+	}
+
+	decodeInt := func(r io.Reader) (int, error) {
+		var v int64
+		err := binary.Read(r, binary.LittleEndian, &v)
+		return int(v), err
+	}
+
+	t.Run("Round trip preserves values in order", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange([]int{5, 3, 9, 1, 7})
+
+		var buf bytes.Buffer
+		require.NoError(t, set.Encode(&buf, encodeInt))
+
+		set1 := New[int]()
+		set1.AddRange([]int{3, 4})
+		require.NoError(t, set1.Decode(&buf, decodeInt))
+		require.Equal(t, []int{1, 3, 4, 5, 7, 9}, set1.ToSlice())
+		require.Equal(t, 0, buf.Len())
+	})
+
+	t.Run("Truncated stream", func(t *testing.T) {
+		set := New[int]()
+		set.AddRange([]int{1, 2, 3})
+
+		var buf bytes.Buffer
+		require.NoError(t, set.Encode(&buf, encodeInt))
+		buf.Truncate(buf.Len() - 8)
+
+		set1 := New[int]()
+		require.ErrorIs(t, set1.Decode(&buf, decodeInt), io.ErrUnexpectedEOF)
+		require.Equal(t, []int{1, 2}, set1.ToSlice())
+	})
+
+	t.Run("Schema validation", func(t *testing.T) {
+		set := Of(3, 1, 2)
+
+		var buf bytes.Buffer
+		require.NoError(t, set.Encode(&buf, encodeInt))
+		data := buf.Bytes()
+
+		set1 := New(WithSchemaValidation[int]())
+		require.NoError(t, set1.Decode(bytes.NewReader(data), decodeInt))
+		require.Equal(t, []int{1, 2, 3}, set1.ToSlice())
+
+		list := dlist.Of(1, 2)
+		buf.Reset()
+		require.NoError(t, list.Encode(&buf, encodeInt))
+
+		var mismatch *schema.MismatchError
+		require.ErrorAs(t, New(WithSchemaValidation[int]()).Decode(&buf, decodeInt), &mismatch)
+		require.Equal(t, "OrderedSet", mismatch.Expected)
+		require.Equal(t, "DList", mismatch.Actual)
+	})
+
+	t.Run("Stream without a schema header", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, util.EncodeStream(&buf, 2, func(yield func(int) bool) { _ = yield(2) && yield(1) }, encodeInt))
+		data := buf.Bytes()
+
+		set := New[int]()
+		require.NoError(t, set.Decode(bytes.NewReader(data), decodeInt))
+		require.Equal(t, []int{1, 2}, set.ToSlice())
+		require.ErrorIs(t, New(WithSchemaValidation[int]()).Decode(bytes.NewReader(data), decodeInt), schema.ErrMissing)
+	})
+}
+
+func TestOf(t *testing.T) {
+
+	set := Of(3, 1, 2, 1)
+	require.Equal(t, []int{1, 2, 3}, set.ToSlice())
+
+	set = OfWith([]OrderedSetOptionFunc[int]{WithThreadSafe[int]()}, 4)
+	require.NotNil(t, set.lock)
+	require.True(t, set.Contains(4))
+}
+
+func TestWalkRange(t *testing.T) {
+
+	set := New[int]()
+
+	for i := 0; i < 100; i += 2 {
+		set.Add(i)
+	}
+
+	collect := func(from, to int) []int {
+		values := []int{}
+		require.True(t, set.WalkRange(from, to, func(v int) bool {
+			values = append(values, v)
+			return true
+		}))
+		return values
+	}
+
+	t.Run("Walks values within range", func(t *testing.T) {
+		require.Equal(t, []int{10, 12, 14, 16, 18, 20}, collect(10, 20))
+		require.Equal(t, []int{12, 14, 16, 18}, collect(11, 19))
+		require.Equal(t, []int{0, 2}, collect(-5, 2))
+		require.Equal(t, []int{96, 98}, collect(95, 200))
+	})
+
+	t.Run("Empty ranges", func(t *testing.T) {
+		require.Empty(t, collect(20, 10))
+		require.Empty(t, collect(13, 13))
+		require.Empty(t, collect(100, 200))
+		require.True(t, New[int]().WalkRange(0, 10, func(int) bool { return false }))
+	})
+
+	t.Run("Stops when fn returns false", func(t *testing.T) {
+		visited := []int{}
+		require.False(t, set.WalkRange(10, 90, func(v int) bool {
+			visited = append(visited, v)
+			return v < 14
+		}))
+		require.Equal(t, []int{10, 12, 14}, visited)
+	})
+
+	t.Run("Matches full walk after removals", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(2163))
+		set := New[int]()
+		set.AddRange(rnd.Perm(500))
+
+		for _, v := range rnd.Perm(500)[:250] {
+			set.Remove(v)
+		}
+
+		expected := []int{}
+		for _, v := range set.ToSlice() {
+			if v >= 100 && v <= 400 {
+				expected = append(expected, v)
+			}
+		}
+
+		actual := []int{}
+		set.WalkRange(100, 400, func(v int) bool {
+			actual = append(actual, v)
+			return true
+		})
+
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("Does not allocate", func(t *testing.T) {
+		sum := 0
+		fn := func(v int) bool {
+			sum += v
+			return true
+		}
+		if util.Debug {
+			t.Skip("assertions allocate in debug builds")
+		}
+
+		allocs := testing.AllocsPerRun(10, func() { set.WalkRange(10, 90, fn) })
+		require.Zero(t, allocs)
+	})
+}
+
+func TestToImmutableSlice(t *testing.T) {
+
+	t.Run("Repeated calls return the same backing array", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc1)
+		require.Same(t, &slc1[0], &slc2[0])
+		require.Equal(t, len(slc1), cap(slc1))
+	})
+
+	t.Run("Recomputed after modification", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		col.Remove(2)
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc2)
+		require.Len(t, slc1, 3)
+		require.Len(t, slc2, 2)
+
+		col.Clear()
+		require.Empty(t, col.ToImmutableSlice())
+	})
+
+	t.Run("Empty collection", func(t *testing.T) {
+		require.Empty(t, New[int]().ToImmutableSlice())
+	})
+}
+
+func TestViews(t *testing.T) {
+
+	newSet := func() *OrderedSet[int] {
+		return Of(10, 20, 30, 40, 50)
+	}
+
+	t.Run("HeadSet", func(t *testing.T) {
+		v := newSet().HeadSet(30)
+
+		require.Equal(t, []int{10, 20}, v.ToSlice())
+		require.Equal(t, 2, v.Count())
+		require.True(t, v.Contains(20))
+		require.False(t, v.Contains(30))
+		require.Equal(t, 10, v.Min())
+		require.Equal(t, 20, v.Max())
+	})
+
+	t.Run("TailSet", func(t *testing.T) {
+		v := newSet().TailSet(30)
+
+		require.Equal(t, []int{30, 40, 50}, v.ToSlice())
+		require.True(t, v.Contains(30))
+		require.False(t, v.Contains(20))
+		require.Equal(t, 30, v.Min())
+		require.Equal(t, 50, v.Max())
+	})
+
+	t.Run("SubSet", func(t *testing.T) {
+		v := newSet().SubSet(15, 45)
+
+		require.Equal(t, []int{20, 30, 40}, v.ToSlice())
+		require.Equal(t, 20, v.Min())
+		require.Equal(t, 40, v.Max())
+		require.False(t, v.Contains(10))
+		require.False(t, v.Contains(50))
+	})
+
+	t.Run("SubSet with bounds reversed panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "from"), func() { newSet().SubSet(40, 20) })
+	})
+
+	t.Run("Empty view", func(t *testing.T) {
+		v := newSet().SubSet(21, 29)
+
+		require.True(t, v.IsEmpty())
+		require.Equal(t, 0, v.Count())
+		require.Empty(t, v.ToSlice())
+		require.Nil(t, v.Iterator().Start())
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { v.Min() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { v.Max() })
+	})
+
+	t.Run("View reflects changes to set", func(t *testing.T) {
+		set := newSet()
+		v := set.SubSet(15, 45)
+
+		set.Add(25)
+		set.Add(60)
+		set.Remove(40)
+
+		require.Equal(t, []int{20, 25, 30}, v.ToSlice())
+	})
+
+	t.Run("Changes through view are made to set", func(t *testing.T) {
+		set := newSet()
+		v := set.SubSet(15, 45)
+
+		require.True(t, v.Add(35))
+		require.False(t, v.Add(30))
+		require.True(t, v.Remove(20))
+		require.False(t, v.Remove(10))
+
+		require.Equal(t, []int{10, 30, 35, 40, 50}, set.ToSlice())
+	})
+
+	t.Run("Add outside view panics", func(t *testing.T) {
+		set := newSet()
+		v := set.SubSet(15, 45)
+
+		require.PanicsWithValue(t, messages.VALUE_OUTSIDE_VIEW, func() { v.Add(45) })
+		require.PanicsWithValue(t, messages.VALUE_OUTSIDE_VIEW, func() { v.Add(5) })
+		require.Equal(t, 5, set.Count())
+	})
+
+	t.Run("Nested views", func(t *testing.T) {
+		v := newSet().TailSet(20)
+
+		require.Equal(t, []int{20, 30}, v.HeadSet(40).ToSlice())
+		require.Equal(t, []int{30, 40, 50}, v.TailSet(25).ToSlice())
+		require.Equal(t, []int{30}, v.SubSet(25, 40).ToSlice())
+
+		h := newSet().HeadSet(40)
+		require.Equal(t, []int{20, 30}, h.TailSet(20).ToSlice())
+		require.PanicsWithValue(t, messages.VALUE_OUTSIDE_VIEW, func() { v.HeadSet(10) })
+		require.PanicsWithValue(t, messages.VALUE_OUTSIDE_VIEW, func() { h.TailSet(45) })
+	})
+
+	t.Run("Iteration is restricted to view", func(t *testing.T) {
+		v := newSet().SubSet(20, 50)
+
+		values := make([]int, 0)
+		fwd := v.Iterator()
+		for e := fwd.Start(); e != nil; e = fwd.Next() {
+			values = append(values, e.Value())
+		}
+
+		require.Equal(t, []int{20, 30, 40}, values)
+
+		iter := v.BidirectionalIterator()
+		values = values[:0]
+		for e := iter.End(); e != nil; e = iter.Prev() {
+			values = append(values, e.Value())
+		}
+
+		require.Equal(t, []int{40, 30, 20}, values)
+
+		require.Equal(t, 20, iter.Seek(5).Value())
+		require.Nil(t, iter.Prev())
+		require.Nil(t, iter.Seek(45))
+		require.Equal(t, 40, iter.Prev().Value())
+	})
+
+	t.Run("Iterator panics if set modified", func(t *testing.T) {
+		set := newSet()
+		v := set.TailSet(30)
+		iter := v.Iterator()
+		iter.Start()
+		set.Add(60)
+
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Next() })
+	})
+
+	t.Run("ToSet is a snapshot", func(t *testing.T) {
+		set := New(WithThreadSafe[int]())
+		set.AddRange([]int{10, 20, 30, 40, 50})
+		snap := set.SubSet(20, 50).ToSet()
+
+		require.Equal(t, []int{20, 30, 40}, snap.ToSlice())
+		require.NotNil(t, snap.lock)
+
+		set.Add(25)
+		snap.Add(60)
+		require.Equal(t, []int{20, 30, 40, 60}, snap.ToSlice())
+		require.Equal(t, []int{10, 20, 25, 30, 40, 50}, set.ToSlice())
+		require.True(t, newSet().HeadSet(5).ToSet().IsEmpty())
+	})
+
+	t.Run("Clear prunes the range from the set", func(t *testing.T) {
+		set := newSet()
+		set.StartRecording()
+		version := set.version
+
+		set.HeadSet(30).Clear()
+		require.Equal(t, []int{30, 40, 50}, set.ToSlice())
+		require.Greater(t, set.version, version)
+		require.Equal(t, []ops.Op[int]{ops.Remove(10), ops.Remove(20)}, set.StopRecording())
+
+		version = set.version
+		set.HeadSet(30).Clear()
+		require.Equal(t, version, set.version)
+
+		set.TailSet(0).SubSet(35, 45).Clear()
+		require.Equal(t, []int{30, 50}, set.ToSlice())
+	})
+}
+
+func TestStableIDs(t *testing.T) {
+
+	seed := int64(4513)
+
+	t.Run("IDs survive rebalancing", func(t *testing.T) {
+		values := util.CreateSingleIntListData(200, &seed)
+		s := New(WithStableIDs[int]())
+		ids := make(map[int]uint64, len(values))
+
+		for _, v := range values {
+			id, added := s.AddWithID(v)
+			require.True(t, added)
+			ids[v] = id
+		}
+
+		// Removing values with two children moves other values between nodes
+		for _, v := range values[:100] {
+			require.True(t, s.RemoveByID(ids[v]))
+			require.False(t, s.RemoveByID(ids[v]))
+			require.Nil(t, s.GetByID(ids[v]))
+		}
+
+		for _, v := range values[100:] {
+			require.Equal(t, v, s.GetByID(ids[v]).Value())
+			id, ok := s.IDOf(v)
+			require.True(t, ok)
+			require.Equal(t, ids[v], id)
+		}
+	})
+
+	t.Run("Existing value keeps its ID", func(t *testing.T) {
+		s := New(WithStableIDs[int]())
+		id, added := s.AddWithID(1)
+		require.True(t, added)
+
+		again, added := s.AddWithID(1)
+		require.False(t, added)
+		require.Equal(t, id, again)
+
+		// IDs are not reused
+		s.Remove(1)
+		again, _ = s.AddWithID(1)
+		require.NotEqual(t, id, again)
+
+		_, ok := s.IDOf(2)
+		require.False(t, ok)
+	})
+
+	t.Run("Clear and ReplaceAll release IDs", func(t *testing.T) {
+		s := OfWith([]OrderedSetOptionFunc[int]{WithStableIDs[int]()}, 1, 2, 3)
+		id, _ := s.IDOf(2)
+		s.ReplaceAll(Of(2, 4))
+		require.Nil(t, s.GetByID(id))
+		require.NotNil(t, s.GetByID(id+2))
+
+		s.Clear()
+		require.Nil(t, s.GetByID(id+2))
+	})
+
+	t.Run("CloneInto copies IDs", func(t *testing.T) {
+		s := OfWith([]OrderedSetOptionFunc[int]{WithStableIDs[int]()}, util.CreateSingleIntListData(50, &seed)...)
+		dst := New[int]()
+		s.CloneInto(dst)
+
+		s.TreeWalk(func(v int) bool {
+			id, _ := s.IDOf(v)
+			require.Equal(t, v, dst.GetByID(id).Value())
+			return true
+		})
+	})
+
+	t.Run("Without option panics", func(t *testing.T) {
+		s := Of(1)
+		require.PanicsWithValue(t, messages.STABLE_IDS_DISABLED, func() { s.AddWithID(2) })
+		require.PanicsWithValue(t, messages.STABLE_IDS_DISABLED, func() { s.GetByID(1) })
+		require.PanicsWithValue(t, messages.STABLE_IDS_DISABLED, func() { s.RemoveByID(1) })
+	})
+}
+
+func TestZeroValue(t *testing.T) {
+
+	t.Run("Usable without New", func(t *testing.T) {
+		var s OrderedSet[int]
+
+		require.True(t, s.IsEmpty())
+		require.False(t, s.Contains(1))
+		require.Nil(t, s.Get(1))
+
+		view := s.SubSet(10, 20)
+		require.True(t, view.IsEmpty())
+
+		s.AddRange([]int{30, 10, 20, 15})
+		require.True(t, view.Add(12))
+
+		require.Equal(t, []int{10, 12, 15, 20, 30}, s.ToSlice())
+		require.Equal(t, []int{10, 12, 15}, view.ToSlice())
+	})
+
+	t.Run("Panics on first modification if no default comparer", func(t *testing.T) {
+		type point struct{ x, y int }
+		var s OrderedSet[point]
+
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ZERO_VALUE_COMPARER_FMT, point{}), func() { s.Add(point{}) })
+	})
+}
+
+func TestNewOrdered(t *testing.T) {
+
+	t.Run("NewFunc", func(t *testing.T) {
+		s := NewFunc(func(a, b string) int { return len(a) - len(b) })
+		s.AddRange([]string{"ccc", "a", "bb", "dd"})
+
+		require.Equal(t, []string{"a", "bb", "ccc"}, s.ToSlice())
+	})
+
+	t.Run("NewOrdered with defined type", func(t *testing.T) {
+		type name string
+		s := NewOrdered[name]()
+		s.AddRange([]name{"carol", "alice", "bob", "alice"})
+
+		require.Equal(t, []name{"alice", "bob", "carol"}, s.ToSlice())
+	})
+}
+
+func TestFloats(t *testing.T) {
+
+	nan := math.NaN()
+	inf := math.Inf(1)
+
+	t.Run("NaN is ordered first and held once", func(t *testing.T) {
+		s := New[float64]()
+		s.AddRange([]float64{1, nan, -inf, nan})
+
+		values := s.ToSlice()
+		require.Len(t, values, 3)
+		require.True(t, math.IsNaN(values[0]))
+		require.Equal(t, []float64{-inf, 1}, values[1:])
+		require.True(t, s.Contains(nan))
+		require.True(t, s.Remove(nan))
+		require.False(t, s.Contains(nan))
+	})
+
+	t.Run("TotalOrderFloat orders NaN last", func(t *testing.T) {
+		s := NewFunc(functions.TotalOrderFloat[float64](functions.NaNLast))
+		s.AddRange([]float64{nan, inf, 0, math.Copysign(0, -1)})
+
+		values := s.ToSlice()
+		require.Len(t, values, 4)
+		require.True(t, math.Signbit(values[0]))
+		require.False(t, math.Signbit(values[1]))
+		require.Equal(t, inf, values[2])
+		require.True(t, math.IsNaN(values[3]))
+	})
+
+	t.Run("WithFiniteOnly rejects NaN and Inf", func(t *testing.T) {
+		s := New(WithFiniteOnly[float64]())
+		s.AddRange([]float64{1, 2})
+		version := s.version
+
+		require.PanicsWithValue(t, messages.VALUE_NOT_FINITE, func() { s.Add(nan) })
+		require.PanicsWithValue(t, messages.VALUE_NOT_FINITE, func() { s.AddRange([]float64{3, -inf}) })
+		require.PanicsWithValue(t, messages.VALUE_NOT_FINITE, func() { s.ReplaceAll(dlist.Of(3, inf)) })
+		require.Equal(t, []float64{1, 2}, s.ToSlice())
+		require.Equal(t, version, s.version)
+
+		clone := New[float64]()
+		s.CloneInto(clone)
+		require.PanicsWithValue(t, messages.VALUE_NOT_FINITE, func() { clone.Add(inf) })
+	})
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+	})
+}
+
+func TestTryAdd(t *testing.T) {
+
+	s := Of(1, 2)
+
+	added, err := s.TryAdd(2)
+	require.False(t, added)
+	require.ErrorIs(t, err, collections.ErrDuplicate)
+
+	added, err = s.TryAdd(0)
+	require.True(t, added)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2}, s.ToSlice())
+}
+
+func TestRetainWhere(t *testing.T) {
+
+	t.Run("Rebuilds a balanced tree", func(t *testing.T) {
+		s := New[int]()
+		for _, v := range rand.New(rand.NewSource(7)).Perm(1000) {
+			s.Add(v)
+		}
+
+		require.Equal(t, 666, s.RetainWhere(func(v int) bool { return v%3 == 0 }))
+		s.verifyInvariants()
+		require.Equal(t, 334, s.Count())
+		require.Equal(t, 999, s.Max())
+
+		for v := 0; v < 1000; v++ {
+			require.Equal(t, v%3 == 0, s.Contains(v))
+		}
+
+		s.Add(1)
+		s.Remove(0)
+		s.verifyInvariants()
+		require.Equal(t, 1, s.Min())
+	})
+
+	t.Run("Retained values keep their IDs", func(t *testing.T) {
+		s := New(WithStableIDs[int]())
+		s.AddRange([]int{1, 2, 3, 4})
+		id, _ := s.IDOf(3)
+		removedID, _ := s.IDOf(2)
+
+		s.RetainAll(hashset.Of(1, 3))
+		require.Equal(t, []int{1, 3}, s.ToSlice())
+		require.Equal(t, 3, s.GetByID(id).Value())
+		require.Nil(t, s.GetByID(removedID))
+	})
+}
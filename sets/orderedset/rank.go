@@ -0,0 +1,212 @@
+package orderedset
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"golang.org/x/exp/constraints"
+)
+
+// Rank returns the number of values in the set less than the given value, which need not be present.
+// For a value that is present, this is its zero-based position in ascending order, i.e. its dense rank. O(log n).
+func (s *OrderedSet[T]) Rank(value T) int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.rank(value, false)
+}
+
+// Nth returns the value at the given zero-based position in ascending order,
+// i.e. Nth(0) is the minimum. O(log n).
+//
+// Panics if index is out of range.
+func (s *OrderedSet[T]) Nth(index int) T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	util.ValidateIndex(index, s.size)
+	return s.nth(index).item
+}
+
+// HistogramByRanges returns the number of values of the set in each of the ranges delimited by the
+// given boundaries, which must be in strictly ascending order. For k boundaries there are k+1 ranges:
+// values less than boundaries[0], values from boundaries[i-1] up to but not including boundaries[i],
+// and values from boundaries[k-1] upwards. The boundaries need not be present in the set.
+//
+// Each count is found from the ranks of its boundaries, so this is O(k log n) rather than requiring a scan of the set.
+//
+// Panics if the boundaries are not in strictly ascending order.
+func (s *OrderedSet[T]) HistogramByRanges(boundaries []T) []int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	compare := s.comparer()
+
+	for i := 1; i < len(boundaries); i++ {
+		if compare(boundaries[i-1], boundaries[i]) >= 0 {
+			panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "boundaries"))
+		}
+	}
+
+	counts := make([]int, len(boundaries)+1)
+	below := 0
+
+	for i, boundary := range boundaries {
+		rank := s.rank(boundary, false)
+		counts[i] = rank - below
+		below = rank
+	}
+
+	counts[len(boundaries)] = s.size - below
+	return counts
+}
+
+// NthMissing returns the n-th (zero-based) smallest integer in the range from to to inclusive
+// that is not present in the set, and true; else zero and false if fewer than n+1 integers in the range
+// are missing from the set. For example, NthMissing(s, 1, 1000, 0) finds the lowest free ID
+// where s holds the IDs in use.
+//
+// The result is found by a binary search of the range using the order statistics of the set,
+// so is O(log r * log n) where r is the size of the range, rather than requiring a scan of the set.
+//
+// Panics if n is negative.
+func NthMissing[T constraints.Integer](s *OrderedSet[T], from, to T, n int) (T, bool) {
+
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if from > to {
+		return 0, false
+	}
+
+	below := s.rank(from, false)
+
+	// There are at least n+1 missing integers in from..x if the number of integers
+	// after from up to x is at least n plus the number present in from..x.
+	// Differences are taken as uint64 so that they cannot overflow T.
+	enough := func(x T) bool {
+		present := uint64(s.rank(x, true) - below)
+		return uint64(x)-uint64(from) >= uint64(n)+present
+	}
+
+	if !enough(to) {
+		return 0, false
+	}
+
+	lo, hi := from, to
+
+	for lo < hi {
+		mid := lo + T((uint64(hi)-uint64(lo))/2)
+
+		if enough(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	return lo, true
+}
+
+// DenseRanks returns a map of each value in the set to its dense rank,
+// i.e. its zero-based position in ascending order, for export to code that
+// works with ranks rather than values, e.g. to compress a sparse domain of keys.
+func DenseRanks[T comparable](s *OrderedSet[T]) map[T]int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	ranks := make(map[T]int, s.size)
+	s.inOrderTreeWalk(func(n *node[T]) bool {
+		ranks[n.item] = len(ranks)
+		return true
+	})
+
+	return ranks
+}
+
+// nth returns the node at the given zero-based position in ascending order,
+// which must be in range.
+func (s *OrderedSet[T]) nth(index int) *node[T] {
+	n := s.root
+
+	for {
+		left := nodeCount(n.left)
+
+		switch {
+		case index < left:
+			n = n.left
+		case index > left:
+			index -= left + 1
+			n = n.right
+		default:
+			return n
+		}
+	}
+}
+
+// rank returns the number of values less than value, or if inclusive,
+// less than or equal to value.
+func (s *OrderedSet[T]) rank(value T, inclusive bool) int {
+	rank := 0
+	n := s.root
+
+	for n != nil {
+		compare := s.compare(value, n.item)
+
+		switch {
+		case compare < 0:
+			n = n.left
+		case compare > 0 || inclusive:
+			rank += nodeCount(n.left) + 1
+
+			if compare == 0 {
+				return rank
+			}
+
+			n = n.right
+		default:
+			return rank + nodeCount(n.left)
+		}
+	}
+
+	return rank
+}
@@ -0,0 +1,143 @@
+package orderedset
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankAndNth(t *testing.T) {
+
+	t.Run("Agree with a sorted model after random modification", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		s := New[int]()
+		model := map[int]bool{}
+
+		for i := 0; i < 2000; i++ {
+			v := r.Intn(500)
+			if r.Intn(3) == 0 {
+				s.Remove(v)
+				delete(model, v)
+			} else {
+				s.Add(v)
+				model[v] = true
+			}
+		}
+
+		sorted := make([]int, 0, len(model))
+		for v := range model {
+			sorted = append(sorted, v)
+		}
+		sort.Ints(sorted)
+
+		for i, v := range sorted {
+			require.Equal(t, v, s.Nth(i))
+			require.Equal(t, i, s.Rank(v))
+		}
+
+		require.Equal(t, sort.SearchInts(sorted, 250), s.Rank(250))
+		require.Equal(t, len(sorted), s.Rank(1000))
+	})
+
+	t.Run("Counts are kept by clones", func(t *testing.T) {
+		s := Of(5, 3, 8, 1)
+		c := New[int]()
+		s.CloneInto(c)
+
+		require.Equal(t, 8, c.Nth(3))
+		require.Equal(t, 2, c.Rank(5))
+	})
+
+	t.Run("Nth panics when out of range", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "index"), func() { Of(1).Nth(1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "index"), func() { New[int]().Nth(0) })
+	})
+}
+
+func TestNthMissing(t *testing.T) {
+
+	t.Run("Finds free IDs", func(t *testing.T) {
+		used := Of(1, 2, 3, 5, 8)
+
+		for n, expected := range []int{4, 6, 7, 9, 10} {
+			v, ok := NthMissing(used, 1, 10, n)
+			require.True(t, ok)
+			require.Equal(t, expected, v)
+		}
+
+		_, ok := NthMissing(used, 1, 10, 5)
+		require.False(t, ok)
+	})
+
+	t.Run("Values outside the range are ignored", func(t *testing.T) {
+		v, ok := NthMissing(Of(-5, 0, 20), 0, 10, 0)
+		require.True(t, ok)
+		require.Equal(t, 1, v)
+	})
+
+	t.Run("Full range of a type does not overflow", func(t *testing.T) {
+		v, ok := NthMissing(Of[int8](math.MinInt8, math.MinInt8+1), math.MinInt8, math.MaxInt8, 0)
+		require.True(t, ok)
+		require.Equal(t, int8(math.MinInt8+2), v)
+
+		v, ok = NthMissing(New[int8](), math.MinInt8, math.MaxInt8, 255)
+		require.True(t, ok)
+		require.Equal(t, int8(math.MaxInt8), v)
+
+		_, ok = NthMissing(Of[uint64](math.MaxUint64), math.MaxUint64, math.MaxUint64, 0)
+		require.False(t, ok)
+	})
+
+	t.Run("Empty range", func(t *testing.T) {
+		_, ok := NthMissing(New[int](), 10, 1, 0)
+		require.False(t, ok)
+	})
+
+	t.Run("Panics if n is negative", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { NthMissing(New[int](), 1, 10, -1) })
+	})
+}
+
+func TestDenseRanks(t *testing.T) {
+	require.Equal(t, map[string]int{"a": 0, "m": 1, "z": 2}, DenseRanks(Of("z", "a", "m")))
+	require.Empty(t, DenseRanks(New[string]()))
+}
+
+func TestHistogramByRanges(t *testing.T) {
+
+	t.Run("Counts agree with a scan", func(t *testing.T) {
+		r := rand.New(rand.NewSource(2))
+		s := New[int]()
+
+		for i := 0; i < 1000; i++ {
+			s.Add(r.Intn(10000))
+		}
+
+		boundaries := []int{-5, 100, 2500, 2501, 5000, 9999, 20000}
+		expected := make([]int, len(boundaries)+1)
+
+		for _, v := range s.ToSlice() {
+			expected[sort.SearchInts(boundaries, v+1)]++
+		}
+
+		require.Equal(t, expected, s.HistogramByRanges(boundaries))
+	})
+
+	t.Run("Boundary values start their range", func(t *testing.T) {
+		s := Of(1, 2, 3, 4, 5, 6)
+
+		require.Equal(t, []int{1, 2, 3}, s.HistogramByRanges([]int{2, 4}))
+		require.Equal(t, []int{6}, s.HistogramByRanges(nil))
+		require.Equal(t, []int{0, 0}, New[int]().HistogramByRanges([]int{1}))
+	})
+
+	t.Run("Panics when boundaries not ascending", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "boundaries"), func() { Of(1).HistogramByRanges([]int{3, 3}) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "boundaries"), func() { Of(1).HistogramByRanges([]int{3, 1}) })
+	})
+}
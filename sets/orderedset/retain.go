@@ -0,0 +1,66 @@
+package orderedset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*OrderedSet[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single in-order pass,
+// and returns the number of values removed. Rather than rebalancing after each removal,
+// the remaining nodes are linked into a new balanced tree, so the whole is O(n).
+// The remaining nodes keep their IDs.
+func (s *OrderedSet[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	nodes := make([]*node[T], 0, s.size)
+
+	s.inOrderTreeWalk(func(n *node[T]) bool {
+		if predicate(n.item) {
+			nodes = append(nodes, n)
+		} else {
+			s.releaseID(n)
+			s.recorder.Remove(n.item)
+		}
+
+		return true
+	})
+
+	removed := s.size - len(nodes)
+
+	if removed == 0 {
+		return 0
+	}
+
+	s.root = buildTree(nodes, nil, 0, intlog2(len(nodes)))
+
+	if s.root != nil {
+		s.root.color = black
+	}
+
+	s.size = len(nodes)
+	s.version++
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (s *OrderedSet[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](s, other, s.RetainWhere)
+}
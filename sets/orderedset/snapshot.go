@@ -0,0 +1,105 @@
+package orderedset
+
+import (
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/sets"
+)
+
+// snapshot records the values of an OrderedSet at a point in time, in order.
+type snapshot[T any] struct {
+	set     *OrderedSet[T]
+	version int
+	values  []T
+	local.InternalImpl
+}
+
+// Snapshot returns an immutable record of the values currently in the set,
+// for later comparison with [sets.DiffSnapshots].
+//
+// Taking a snapshot is O(n). However, if the set has not been modified since the
+// last snapshot was taken, that snapshot is returned at no cost.
+// Diffing two snapshots is O(n+m), or O(1) if they were taken from the same set
+// with no modifications in between.
+func (s *OrderedSet[T]) Snapshot() sets.Snapshot[T] {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if s.lastSnapshot != nil && s.lastSnapshot.version == s.version {
+		return s.lastSnapshot
+	}
+
+	snap := &snapshot[T]{
+		set:     s,
+		version: s.version,
+		values:  make([]T, s.size),
+	}
+
+	s.copyTo(snap.values, 0, s.size, false)
+	s.lastSnapshot = snap
+	return snap
+}
+
+// Count returns the number of values in the snapshot.
+func (snap *snapshot[T]) Count() int {
+	return len(snap.values)
+}
+
+// ToSlice returns a copy of the values in the snapshot, in order.
+func (snap *snapshot[T]) ToSlice() []T {
+	slc := make([]T, len(snap.values))
+	copy(slc, snap.values)
+	return slc
+}
+
+// Diff returns the values that are in the later snapshot but not this one (added),
+// and the values that are in this snapshot but not the later one (removed).
+// Both results are in order.
+//
+// Panics if the later snapshot was not taken from an OrderedSet.
+func (snap *snapshot[T]) Diff(later sets.Snapshot[T]) (added, removed []T) {
+
+	other, ok := later.(*snapshot[T])
+
+	if !ok {
+		panic(messages.SNAPSHOT_TYPE_MISMATCH)
+	}
+
+	if snap.set == other.set && snap.version == other.version {
+		return nil, nil
+	}
+
+	compare := snap.set.compare
+	i, j := 0, 0
+
+	// Merge the two ordered sequences
+	for i < len(snap.values) && j < len(other.values) {
+		order := compare(snap.values[i], other.values[j])
+
+		switch {
+		case order < 0:
+			removed = append(removed, snap.values[i])
+			i++
+		case order > 0:
+			added = append(added, other.values[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+
+	removed = append(removed, snap.values[i:]...)
+	added = append(added, other.values[j:]...)
+	return added, removed
+}
@@ -0,0 +1,431 @@
+package orderedset
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Staging accumulates values to be added to an OrderedSet in an unsorted buffer, and merges them into
+// the set in bulk once the buffer reaches a threshold, or when [Staging.Flush] is called.
+//
+// Inserting values in random order into a red-black tree is dominated by the cost of finding each
+// insertion point and rebalancing. A flush instead sorts the buffer, then either inserts the values
+// in order, or, where many values are staged relative to the size of the set, merges them with the
+// values of the set and rebuilds a balanced tree from the result in linear time.
+//
+// The read methods of the staging area consult both the set and the buffer. Other goroutines may read
+// the set directly, but will not see values until they are flushed. A staging area is thread-safe.
+// The set is locked for each operation on it only if it was created [WithThreadSafe].
+type Staging[T any] struct {
+	lock      sync.Mutex
+	set       *OrderedSet[T]
+	staged    []T
+	sorted    bool
+	threshold int
+}
+
+// NewStaging constructs a staging area for set, which flushes once threshold values are staged.
+//
+// Panics if set is nil or threshold is less than 1.
+func NewStaging[T any](set *OrderedSet[T], threshold int) *Staging[T] {
+	if set == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "set"))
+	}
+
+	if threshold < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "threshold"))
+	}
+
+	return &Staging[T]{
+		set:       set,
+		staged:    make([]T, 0, threshold),
+		threshold: threshold,
+	}
+}
+
+// Add stages a value to be added to the set, flushing if the threshold is reached.
+// Values already in the set are ignored when flushed, and where equal values are staged, which of them
+// is added is unspecified. Values are validated by the validator of the set when flushed.
+func (st *Staging[T]) Add(value T) {
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.staged = append(st.staged, value)
+	st.sorted = false
+
+	if len(st.staged) >= st.threshold {
+		st.flush()
+	}
+}
+
+// AddRange stages the given values, flushing if the threshold is reached.
+func (st *Staging[T]) AddRange(values []T) {
+
+	if len(values) == 0 {
+		return
+	}
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.staged = append(st.staged, values...)
+	st.sorted = false
+
+	if len(st.staged) >= st.threshold {
+		st.flush()
+	}
+}
+
+// Flush merges the staged values into the set, and returns the number of values added to it.
+func (st *Staging[T]) Flush() int {
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return st.flush()
+}
+
+// Pending returns the number of values staged and not yet flushed,
+// which may include values that are already in the set.
+func (st *Staging[T]) Pending() int {
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return len(st.staged)
+}
+
+// Set flushes the staged values and returns the set.
+func (st *Staging[T]) Set() *OrderedSet[T] {
+
+	st.Flush()
+	return st.set
+}
+
+// Contains returns true if the value is in the set or is staged.
+//
+// O(log n + log k) for k staged values, plus O(k log k) to sort the staged values after they change.
+func (st *Staging[T]) Contains(value T) bool {
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return st.set.Contains(value) || st.find(value) >= 0
+}
+
+// Count returns the number of distinct values in the set and staged.
+//
+// O(k log n) for k staged values.
+func (st *Staging[T]) Count() int {
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.sort()
+	count := st.set.Count()
+
+	for _, v := range st.staged {
+		if !st.set.Contains(v) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// IsEmpty returns true if the set is empty and no values are staged.
+func (st *Staging[T]) IsEmpty() bool {
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return len(st.staged) == 0 && st.set.IsEmpty()
+}
+
+// Min returns the least value in the set or staged.
+//
+// Panics if both are empty.
+func (st *Staging[T]) Min() T {
+
+	return st.extreme(false)
+}
+
+// Max returns the greatest value in the set or staged.
+//
+// Panics if both are empty.
+func (st *Staging[T]) Max() T {
+
+	return st.extreme(true)
+}
+
+// ToSlice returns the distinct values of the set and those staged, in order.
+//
+// O(n + k) for n values in the set and k staged values.
+func (st *Staging[T]) ToSlice() []T {
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.sort()
+	compare := st.set.comparer()
+	values := st.set.ToSlice()
+	merged := make([]T, 0, len(values)+len(st.staged))
+	i, j := 0, 0
+
+	for i < len(values) || j < len(st.staged) {
+		switch {
+		case j == len(st.staged):
+			merged = append(merged, values[i])
+			i++
+		case i == len(values):
+			merged = append(merged, st.staged[j])
+			j++
+		default:
+			order := compare(values[i], st.staged[j])
+
+			if order <= 0 {
+				merged = append(merged, values[i])
+				i++
+			}
+
+			if order >= 0 {
+				if order > 0 {
+					merged = append(merged, st.staged[j])
+				}
+				j++
+			}
+		}
+	}
+
+	return merged
+}
+
+// Remove removes the value from the set and the staged values.
+//
+// Returns true if the value was in either; else false.
+func (st *Staging[T]) Remove(value T) bool {
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	removed := st.set.Remove(value)
+
+	if i := st.find(value); i >= 0 {
+		st.staged = append(st.staged[:i], st.staged[i+1:]...)
+		removed = true
+	}
+
+	return removed
+}
+
+func (st *Staging[T]) flush() int {
+	if len(st.staged) == 0 {
+		return 0
+	}
+
+	st.sort()
+	added := st.set.mergeSorted(st.staged)
+
+	// Release references to the values before reusing the buffer.
+	var empty T
+	for i := range st.staged {
+		st.staged[i] = empty
+	}
+
+	st.staged = st.staged[:0]
+	return added
+}
+
+// sort sorts the staged values and removes duplicates, if they have changed since they were last sorted.
+func (st *Staging[T]) sort() {
+	if st.sorted {
+		return
+	}
+
+	compare := st.set.comparer()
+	util.Gosort(st.staged, len(st.staged), compare)
+
+	unique := 0
+
+	for i, v := range st.staged {
+		if i == 0 || compare(v, st.staged[unique-1]) != 0 {
+			st.staged[unique] = v
+			unique++
+		}
+	}
+
+	var empty T
+	for i := unique; i < len(st.staged); i++ {
+		st.staged[i] = empty
+	}
+
+	st.staged = st.staged[:unique]
+	st.sorted = true
+}
+
+// find returns the index of value in the sorted staged values, or -1 if it is not staged.
+func (st *Staging[T]) find(value T) int {
+	st.sort()
+	compare := st.set.comparer()
+	i := sort.Search(len(st.staged), func(i int) bool { return compare(st.staged[i], value) >= 0 })
+
+	if i < len(st.staged) && compare(st.staged[i], value) == 0 {
+		return i
+	}
+
+	return -1
+}
+
+func (st *Staging[T]) extreme(greatest bool) T {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.sort()
+	value, ok := st.set.extreme(greatest)
+
+	if len(st.staged) > 0 {
+		candidate := st.staged[util.Iif(greatest, len(st.staged)-1, 0)]
+
+		if !ok {
+			value, ok = candidate, true
+		} else if order := st.set.comparer()(candidate, value); (greatest && order > 0) || (!greatest && order < 0) {
+			value = candidate
+		}
+	}
+
+	if !ok {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return value
+}
+
+// extreme returns the greatest or least value of the set and true, or false if the set is empty.
+func (s *OrderedSet[T]) extreme(greatest bool) (T, bool) {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.root == nil {
+		var empty T
+		return empty, false
+	}
+
+	if greatest {
+		return s.root.maximumNode().item, true
+	}
+
+	return s.root.minimumNode().item, true
+}
+
+// mergeSorted adds the given values, which must be sorted and free of duplicates, under a single lock,
+// and returns the number of values added. Where few values are added relative to the size of the set,
+// each is inserted in turn. Otherwise the tree is rebuilt from the merged values, which is O(n + k).
+func (s *OrderedSet[T]) mergeSorted(values []T) int {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.checkValues(values...)
+
+	if values = s.validator.Filter(values); len(values) == 0 {
+		return 0
+	}
+
+	s.version++
+
+	if len(values)*4 < s.size {
+		added := 0
+
+		for _, v := range values {
+			if s.doInsert(v) {
+				added++
+			}
+		}
+
+		return added
+	}
+
+	return s.rebuild(values)
+}
+
+// rebuild merges the given sorted values with the nodes of the tree, and links the result into
+// a new balanced tree. Existing nodes are reused, so that they keep their IDs.
+func (s *OrderedSet[T]) rebuild(values []T) int {
+	nodes := make([]*node[T], 0, s.size+len(values))
+
+	var n *node[T]
+	if s.root != nil {
+		n = s.root.minimumNode()
+	}
+
+	for i := 0; n != nil || i < len(values); {
+		var order int
+
+		switch {
+		case i == len(values):
+			order = 1
+		case n == nil:
+			order = -1
+		default:
+			order = s.compare(values[i], n.item)
+		}
+
+		switch {
+		case order < 0:
+			added := newNode(values[i])
+			s.assignID(added)
+			s.recorder.Add(values[i])
+			nodes = append(nodes, added)
+			i++
+		case order == 0:
+			// Already in the set
+			i++
+		default:
+			nodes = append(nodes, n)
+			n = n.successor()
+		}
+	}
+
+	added := len(nodes) - s.size
+	s.root = buildTree(nodes, nil, 0, intlog2(len(nodes)))
+	s.root.color = black
+	s.size = len(nodes)
+	return added
+}
+
+// buildTree links the given nodes, which are in order, into a balanced subtree with the given parent
+// and returns its root. The nodes on the deepest level of a tree of the given height are red and all
+// others black, so that every path from the root to a leaf passes through the same number of black nodes.
+func buildTree[T any](nodes []*node[T], parent *node[T], depth, height int) *node[T] {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	mid := len(nodes) / 2
+	n := nodes[mid]
+	n.Parent = parent
+	n.left = buildTree(nodes[:mid], n, depth+1, height)
+	n.right = buildTree(nodes[mid+1:], n, depth+1, height)
+	n.color = util.Iif(depth == height-1, red, black)
+	n.count = len(nodes)
+	return n
+}
@@ -0,0 +1,181 @@
+package orderedset
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaging(t *testing.T) {
+
+	t.Run("Flushes at the threshold", func(t *testing.T) {
+		s := New[int]()
+		st := NewStaging(s, 3)
+
+		st.Add(3)
+		st.Add(1)
+		require.Equal(t, 2, st.Pending())
+		require.True(t, s.IsEmpty(), "values should not reach the set until flushed")
+
+		st.Add(2)
+		require.Equal(t, 0, st.Pending())
+		require.Equal(t, []int{1, 2, 3}, s.ToSlice())
+
+		st.AddRange([]int{5, 4})
+		require.Equal(t, 2, st.Flush())
+		require.Equal(t, 0, st.Flush())
+		require.Equal(t, []int{1, 2, 3, 4, 5}, st.Set().ToSlice())
+	})
+
+	t.Run("Reads consult the set and staged values", func(t *testing.T) {
+		s := Of(2, 4, 6)
+		st := NewStaging(s, 100)
+		st.AddRange([]int{5, 1, 4, 5, 7})
+
+		require.True(t, st.Contains(1))
+		require.True(t, st.Contains(6))
+		require.False(t, st.Contains(3))
+		require.Equal(t, 6, st.Count())
+		require.Equal(t, 1, st.Min())
+		require.Equal(t, 7, st.Max())
+		require.Equal(t, []int{1, 2, 4, 5, 6, 7}, st.ToSlice())
+		require.False(t, st.IsEmpty())
+
+		require.True(t, st.Remove(5))
+		require.True(t, st.Remove(2))
+		require.False(t, st.Remove(3))
+		require.Equal(t, []int{1, 4, 6, 7}, st.ToSlice())
+
+		st.Add(0)
+		require.Equal(t, 0, st.Min())
+
+		require.Equal(t, 3, st.Flush(), "4 is already in the set")
+		require.Equal(t, []int{0, 1, 4, 6, 7}, s.ToSlice())
+	})
+
+	t.Run("Agrees with a model after random flushes", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+
+		for _, threshold := range []int{1, 7, 64, 1000} {
+			s := New[int](WithStableIDs[int]())
+			st := NewStaging(s, threshold)
+			model := map[int]bool{}
+
+			for i := 0; i < 3000; i++ {
+				v := r.Intn(2000)
+				st.Add(v)
+				model[v] = true
+
+				if r.Intn(50) == 0 {
+					st.Remove(v)
+					delete(model, v)
+				}
+			}
+
+			st.Flush()
+			s.verifyInvariants()
+
+			expected := make([]int, 0, len(model))
+			for v := range model {
+				expected = append(expected, v)
+			}
+			sort.Ints(expected)
+
+			require.Equal(t, expected, s.ToSlice(), "threshold %d", threshold)
+
+			for i, v := range expected {
+				require.Equal(t, i, s.Rank(v))
+			}
+		}
+	})
+
+	t.Run("Rebuilding keeps IDs and records ops", func(t *testing.T) {
+		s := New(WithStableIDs[int]())
+		id, _ := s.AddWithID(10)
+		s.StartRecording()
+
+		st := NewStaging(s, 100)
+		st.AddRange([]int{3, 20, 10, 1})
+		require.Equal(t, 3, st.Flush())
+		s.verifyInvariants()
+
+		e := s.GetByID(id)
+		require.NotNil(t, e)
+		require.Equal(t, 10, e.Value())
+		require.ElementsMatch(t, []ops.Op[int]{ops.Add(1), ops.Add(3), ops.Add(20)}, s.StopRecording())
+	})
+
+	t.Run("Validates when flushed", func(t *testing.T) {
+		s := New(WithValidator(func(v int) error {
+			if v < 0 {
+				return fmt.Errorf("negative")
+			}
+			return nil
+		}, collections.RejectInvalid))
+
+		st := NewStaging(s, 10)
+		st.AddRange([]int{1, -1, 2})
+		require.Equal(t, 2, st.Flush())
+		require.Equal(t, []int{1, 2}, s.ToSlice())
+	})
+
+	t.Run("Is thread-safe", func(t *testing.T) {
+		st := NewStaging(New(WithThreadSafe[int]()), 16)
+		var wg sync.WaitGroup
+
+		for w := 0; w < 4; w++ {
+			w := w
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 250; i++ {
+					st.Add(w*250 + i)
+					st.Contains(i)
+				}
+			}()
+		}
+
+		wg.Wait()
+		require.Equal(t, 1000, st.Set().Count())
+	})
+
+	t.Run("Panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "set"), func() { NewStaging[int](nil, 1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "threshold"), func() { NewStaging(New[int](), 0) })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { NewStaging(New[int](), 1).Min() })
+	})
+}
+
+func BenchmarkStaging(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]int, 100000)
+	for i := range values {
+		values[i] = r.Int()
+	}
+
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s := New[int]()
+			for _, v := range values {
+				s.Add(v)
+			}
+		}
+	})
+
+	b.Run("Staging", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			st := NewStaging(New[int](), 4096)
+			for _, v := range values {
+				st.Add(v)
+			}
+			st.Flush()
+		}
+	})
+}
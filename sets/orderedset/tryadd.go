@@ -0,0 +1,41 @@
+package orderedset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*OrderedSet[int])(nil)
+
+// TryAdd adds a value into the collection.
+//
+// Returns true and nil if the value was added; else false and [collections.ErrDuplicate] if the value
+// already exists, or an error wrapping [collections.ErrInvalidValue] and the error of the validator
+// if it was rejected, whatever the validation policy.
+func (s *OrderedSet[T]) TryAdd(value T) (bool, error) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if err := s.validator.Check(value); err != nil {
+		return false, err
+	}
+
+	inserted := s.doInsert(value)
+	s.version++
+
+	if !inserted {
+		return false, collections.ErrDuplicate
+	}
+
+	return true, nil
+}
@@ -0,0 +1,410 @@
+package orderedset
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// OrderedSetView is a live view of the values of an OrderedSet that lie within a range.
+//
+// A view does not copy the set. Changes to the set are visible through the view,
+// and changes made through the view are made to the set.
+type OrderedSetView[T any] struct {
+	set    *OrderedSet[T]
+	bounds bounds[T]
+}
+
+// bound is one end of the range of a view.
+type bound[T any] struct {
+	value     T
+	inclusive bool
+}
+
+// bounds is the range of a view. A nil bound leaves that end of the range open.
+type bounds[T any] struct {
+	low  *bound[T]
+	high *bound[T]
+}
+
+// HeadSet returns a live view of the values in the set that are less than to.
+func (s *OrderedSet[T]) HeadSet(to T) *OrderedSetView[T] {
+
+	return &OrderedSetView[T]{
+		set:    s,
+		bounds: bounds[T]{high: &bound[T]{value: to}},
+	}
+}
+
+// TailSet returns a live view of the values in the set that are greater than or equal to from.
+func (s *OrderedSet[T]) TailSet(from T) *OrderedSetView[T] {
+
+	return &OrderedSetView[T]{
+		set:    s,
+		bounds: bounds[T]{low: &bound[T]{value: from, inclusive: true}},
+	}
+}
+
+// SubSet returns a live view of the values in the set that are greater than or equal to from
+// and less than to.
+//
+// Panics if from is greater than to.
+func (s *OrderedSet[T]) SubSet(from, to T) *OrderedSetView[T] {
+
+	if s.comparer()(from, to) > 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "from"))
+	}
+
+	return &OrderedSetView[T]{
+		set: s,
+		bounds: bounds[T]{
+			low:  &bound[T]{value: from, inclusive: true},
+			high: &bound[T]{value: to},
+		},
+	}
+}
+
+// HeadSet returns a live view of the values in this view that are less than to.
+//
+// Panics if to is outside the range of this view.
+func (v *OrderedSetView[T]) HeadSet(to T) *OrderedSetView[T] {
+
+	v.validateBound(to)
+
+	return &OrderedSetView[T]{
+		set:    v.set,
+		bounds: bounds[T]{low: v.bounds.low, high: &bound[T]{value: to}},
+	}
+}
+
+// TailSet returns a live view of the values in this view that are greater than or equal to from.
+//
+// Panics if from is outside the range of this view.
+func (v *OrderedSetView[T]) TailSet(from T) *OrderedSetView[T] {
+
+	v.validateBound(from)
+
+	return &OrderedSetView[T]{
+		set:    v.set,
+		bounds: bounds[T]{low: &bound[T]{value: from, inclusive: true}, high: v.bounds.high},
+	}
+}
+
+// SubSet returns a live view of the values in this view that are greater than or equal to from
+// and less than to.
+//
+// Panics if from is greater than to, or either is outside the range of this view.
+func (v *OrderedSetView[T]) SubSet(from, to T) *OrderedSetView[T] {
+
+	v.validateBound(from)
+	v.validateBound(to)
+
+	return v.set.SubSet(from, to)
+}
+
+// Add adds a value to the underlying set.
+// Returns false if the value already exists; else true if it was added.
+//
+// Panics if the value is outside the range of the view.
+func (v *OrderedSetView[T]) Add(value T) bool {
+
+	if !v.bounds.contains(v.set.comparer(), value) {
+		panic(messages.VALUE_OUTSIDE_VIEW)
+	}
+
+	return v.set.Add(value)
+}
+
+// Remove removes a value from the underlying set.
+//
+// Returns true if the value was within the range of the view, present and was removed;
+// else false.
+func (v *OrderedSetView[T]) Remove(value T) bool {
+
+	if !v.bounds.contains(v.set.comparer(), value) {
+		return false
+	}
+
+	return v.set.Remove(value)
+}
+
+// Clear removes the values in the view from the underlying set, e.g. to prune all entries older than a cutoff:
+//
+//	events.HeadSet(cutoff).Clear()
+//
+// O(k log n), where k is the number of values in the view.
+func (v *OrderedSetView[T]) Clear() {
+
+	if v.set.lock != nil {
+		v.set.lock.Lock()
+		defer v.set.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer v.set.guard.Write(v.set.lock != nil, v.set.verifyInvariants)()
+	}
+
+	// Removal moves values between nodes, so collect the values before removing any.
+	values := v.values()
+
+	if len(values) == 0 {
+		return
+	}
+
+	for _, value := range values {
+		v.set.remove(value)
+	}
+
+	v.set.version++
+}
+
+// Contains returns true if the given value is within the range of the view and exists in the set.
+func (v *OrderedSetView[T]) Contains(value T) bool {
+
+	if !v.bounds.contains(v.set.comparer(), value) {
+		return false
+	}
+
+	return v.set.Contains(value)
+}
+
+// Count returns the number of values in the view. O(log n + k),
+// where k is the number of values in the view, as the values are counted on each call.
+func (v *OrderedSetView[T]) Count() int {
+
+	count := 0
+
+	v.walk(func(T) bool {
+		count++
+		return true
+	})
+
+	return count
+}
+
+// IsEmpty returns true if there are no values in the view.
+func (v *OrderedSetView[T]) IsEmpty() bool {
+
+	if v.set.lock != nil {
+		v.set.lock.RLock()
+		defer v.set.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer v.set.guard.Read(v.set.lock != nil)()
+	}
+
+	return v.set.first(v.bounds) == nil
+}
+
+// Min returns the least value in the view.
+//
+// Panics if the view is empty.
+func (v *OrderedSetView[T]) Min() T {
+
+	if v.set.lock != nil {
+		v.set.lock.RLock()
+		defer v.set.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer v.set.guard.Read(v.set.lock != nil)()
+	}
+
+	n := v.set.first(v.bounds)
+
+	if n == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return n.item
+}
+
+// Max returns the greatest value in the view.
+//
+// Panics if the view is empty.
+func (v *OrderedSetView[T]) Max() T {
+
+	if v.set.lock != nil {
+		v.set.lock.RLock()
+		defer v.set.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer v.set.guard.Read(v.set.lock != nil)()
+	}
+
+	n := v.set.last(v.bounds)
+
+	if n == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return n.item
+}
+
+// ToSlice returns the values in the view as a slice, in ascending order.
+func (v *OrderedSetView[T]) ToSlice() []T {
+
+	slc := make([]T, 0)
+
+	v.walk(func(value T) bool {
+		slc = append(slc, value)
+		return true
+	})
+
+	return slc
+}
+
+// ToSet returns a new set containing the values in the view, with the options of the underlying set.
+// Unlike the view, it is a snapshot: later changes to the set are not visible in it, and changes to it are not made to the set.
+//
+// Values are copied as by [OrderedSet.Select], not deep copied.
+func (v *OrderedSetView[T]) ToSet() *OrderedSet[T] {
+
+	if v.set.lock != nil {
+		v.set.lock.RLock()
+		defer v.set.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer v.set.guard.Read(v.set.lock != nil)()
+	}
+
+	result := v.set.makeEmptyCopy()
+
+	for _, value := range v.values() {
+		result.doInsert(value)
+	}
+
+	return result
+}
+
+// Iterator returns an iterator that walks the values in the view in ascending order.
+func (v *OrderedSetView[T]) Iterator() collections.Iterator[T] {
+
+	return v.BidirectionalIterator()
+}
+
+// BidirectionalIterator returns an iterator that can move in ascending and descending order
+// of the values in the view from any position.
+func (v *OrderedSetView[T]) BidirectionalIterator() collections.BidirectionalIterator[T] {
+
+	return newBidirectionalIterator(v.set, v.bounds)
+}
+
+// values returns the values in the view in ascending order. The caller must hold the lock.
+func (v *OrderedSetView[T]) values() []T {
+
+	var slc []T
+
+	for n := v.set.first(v.bounds); n != nil && !v.bounds.above(v.set.compare, n.item); n = n.successor() {
+		slc = append(slc, n.item)
+	}
+
+	return slc
+}
+
+// walk calls fn for each value in the view in ascending order, until fn returns false.
+func (v *OrderedSetView[T]) walk(fn func(T) bool) {
+
+	if v.set.lock != nil {
+		v.set.lock.RLock()
+		defer v.set.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer v.set.guard.Read(v.set.lock != nil)()
+	}
+
+	for n := v.set.first(v.bounds); n != nil && !v.bounds.above(v.set.compare, n.item); n = n.successor() {
+		if !fn(n.item) {
+			return
+		}
+	}
+}
+
+// validateBound panics if value cannot be used as a bound of a view within this view.
+func (v *OrderedSetView[T]) validateBound(value T) {
+
+	low, high, compare := v.bounds.low, v.bounds.high, v.set.comparer()
+
+	if (low != nil && compare(value, low.value) < 0) || (high != nil && compare(value, high.value) > 0) {
+		panic(messages.VALUE_OUTSIDE_VIEW)
+	}
+}
+
+// below returns true if value is less than the low bound.
+func (b bounds[T]) below(compare functions.ComparerFunc[T], value T) bool {
+
+	if b.low == nil {
+		return false
+	}
+
+	c := compare(value, b.low.value)
+	return c < 0 || (c == 0 && !b.low.inclusive)
+}
+
+// above returns true if value is greater than the high bound.
+func (b bounds[T]) above(compare functions.ComparerFunc[T], value T) bool {
+
+	if b.high == nil {
+		return false
+	}
+
+	c := compare(value, b.high.value)
+	return c > 0 || (c == 0 && !b.high.inclusive)
+}
+
+// contains returns true if value lies within the bounds.
+func (b bounds[T]) contains(compare functions.ComparerFunc[T], value T) bool {
+
+	return !b.below(compare, value) && !b.above(compare, value)
+}
+
+// first returns the node with the least value within the given bounds, or nil if there is none.
+func (s *OrderedSet[T]) first(b bounds[T]) *node[T] {
+
+	var n *node[T]
+
+	if b.low == nil {
+		n = s.root.minimumNode()
+	} else {
+		n = s.lowerBound(b.low.value)
+
+		if n != nil && b.below(s.compare, n.item) {
+			n = n.successor()
+		}
+	}
+
+	if n == nil || b.above(s.compare, n.item) {
+		return nil
+	}
+
+	return n
+}
+
+// last returns the node with the greatest value within the given bounds, or nil if there is none.
+func (s *OrderedSet[T]) last(b bounds[T]) *node[T] {
+
+	var n *node[T]
+
+	if b.high == nil {
+		n = s.root.maximumNode()
+	} else {
+		n = s.floor(b.high.value)
+
+		if n != nil && b.above(s.compare, n.item) {
+			n = n.predecessor()
+		}
+	}
+
+	if n == nil || b.below(s.compare, n.item) {
+		return nil
+	}
+
+	return n
+}
@@ -5,19 +5,22 @@ Sub-packages contain implementations.
 package sets
 
 import (
+	"fmt"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
 )
 
 // Set is the abstract interface for collections of unique elements.
 //
-// Implemented by HashSet[T], OrderedSet[T].
+// Implemented by HashSet[T], LinkedHashSet[T], OrderedSet[T], SkipListSet[T].
 type Set[T any] interface {
 	// Set implements Collection
 	collections.Collection[T]
 
 	// Get returns the collection element that matches the given value, or nil if it is not found.
-	// Useful if the set contains struct elements you want to modify in-place.
+	// Useful if the comparer matches struct elements on a key, to retrieve the stored element.
 	Get(value T) collections.Element[T]
 
 	// Difference returns the difference between two sets.
@@ -41,6 +44,49 @@ type Set[T any] interface {
 	// is enabled. Used to speed up the above set operations.
 	UnlockedContains(T) bool
 
+	// Snapshot returns an immutable record of the values currently in the set,
+	// for later comparison with [DiffSnapshots].
+	Snapshot() Snapshot[T]
+
 	// Prevent external implementations of this interface
 	local.InternalInter
 }
+
+// Snapshot is an opaque, immutable record of the values in a set at a point in time.
+//
+// Snapshots are obtained by calling Snapshot on a set, and are compared with [DiffSnapshots].
+type Snapshot[T any] interface {
+	// Count returns the number of values in the snapshot.
+	Count() int
+
+	// ToSlice returns a copy of the values in the snapshot.
+	ToSlice() []T
+
+	// Diff returns the values that are in the later snapshot but not this one (added),
+	// and the values that are in this snapshot but not the later one (removed).
+	//
+	// Panics if the snapshots were taken from different types of set.
+	Diff(later Snapshot[T]) (added, removed []T)
+
+	// Prevent external implementations of this interface
+	local.InternalInter
+}
+
+// DiffSnapshots returns the values that are in snapshot b but not a (added),
+// and the values that are in snapshot a but not b (removed).
+//
+// Where both snapshots were taken from the same set and the set has not been modified
+// between them, the result is determined without examining the values.
+//
+// Panics if either snapshot is nil, or the snapshots were taken from different types of set.
+func DiffSnapshots[T any](a, b Snapshot[T]) (added, removed []T) {
+	if a == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "a"))
+	}
+
+	if b == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "b"))
+	}
+
+	return a.Diff(b)
+}
@@ -0,0 +1,171 @@
+package skiplistset
+
+import "runtime"
+
+// The methods in this file implement the lazy skip list of Herlihy, Lev, Luchangco and Shavit,
+// "A Simple Optimistic Skip-List Algorithm", for sets constructed with WithConcurrent.
+// They are called holding the read lock of the set, so they may run alongside each other
+// and alongside lookups, but not alongside operations that modify the whole set.
+//
+// A node is logically in the set once fullyLinked is set and until marked is set.
+// Nodes are locked from the bottom level upwards, so no two goroutines can deadlock.
+
+// concurrentInsert adds value to the set, locking only the nodes that precede it in each level.
+func (s *SkipListSet[T]) concurrentInsert(value T) bool {
+	var preds, succs [maxLevel]*node[T]
+
+	level := randomLevel()
+
+	for {
+		found := s.find(value, &preds, &succs)
+
+		if found != -1 {
+			existing := succs[found]
+
+			if !existing.marked.Load() {
+				// Another goroutine may still be linking it
+				for !existing.fullyLinked.Load() {
+					runtime.Gosched()
+				}
+
+				return false
+			}
+
+			// Being removed, so retry once it has been unlinked
+			continue
+		}
+
+		highestLocked, valid := lockPreds(&preds, level, func(l int, pred *node[T]) bool {
+			succ := succs[l]
+			return !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[l].Load() == succ
+		})
+
+		if !valid {
+			unlockPreds(&preds, highestLocked)
+			continue
+		}
+
+		n := newNode(value, level)
+
+		for l := 0; l < level; l++ {
+			n.next[l].Store(succs[l])
+		}
+
+		for l := 0; l < level; l++ {
+			preds[l].next[l].Store(n)
+		}
+
+		s.raiseLevel(level)
+
+		// Update the version and record the op before the node becomes visible,
+		// so that a concurrent Remove of the value is always recorded after it.
+		s.meta.Lock()
+		s.size++
+		s.version++
+		s.recorder.Add(value)
+		s.meta.Unlock()
+
+		n.fullyLinked.Store(true)
+		unlockPreds(&preds, highestLocked)
+		return true
+	}
+}
+
+// concurrentRemove removes value from the set, locking only the node that holds it
+// and the nodes that precede it in each level.
+func (s *SkipListSet[T]) concurrentRemove(value T) bool {
+	var preds, succs [maxLevel]*node[T]
+	var victim *node[T]
+
+	isMarked := false
+
+	for {
+		found := s.find(value, &preds, &succs)
+
+		if !isMarked {
+			if found == -1 {
+				return false
+			}
+
+			victim = succs[found]
+
+			// Only a fully linked node found at its top level is ready to be removed.
+			if !victim.fullyLinked.Load() || victim.marked.Load() || len(victim.next)-1 != found {
+				return false
+			}
+
+			victim.lock.Lock()
+
+			if victim.marked.Load() {
+				victim.lock.Unlock()
+				return false
+			}
+
+			victim.marked.Store(true)
+			isMarked = true
+		}
+
+		highestLocked, valid := lockPreds(&preds, len(victim.next), func(l int, pred *node[T]) bool {
+			return !pred.marked.Load() && pred.next[l].Load() == victim
+		})
+
+		if !valid {
+			unlockPreds(&preds, highestLocked)
+			continue
+		}
+
+		for l := len(victim.next) - 1; l >= 0; l-- {
+			preds[l].next[l].Store(victim.next[l].Load())
+		}
+
+		// Record the op while the predecessors are locked,
+		// so that a concurrent Add of the value is always recorded after it.
+		s.meta.Lock()
+		s.size--
+		s.version++
+		s.recorder.Remove(value)
+		s.meta.Unlock()
+
+		victim.lock.Unlock()
+		unlockPreds(&preds, highestLocked)
+		return true
+	}
+}
+
+// lockPreds locks the distinct predecessors in the levels below level, bottom up,
+// stopping at the first for which valid returns false.
+//
+// Returns the highest level whose predecessor was locked, and whether all were valid.
+func lockPreds[T any](preds *[maxLevel]*node[T], level int, valid func(int, *node[T]) bool) (int, bool) {
+	var previous *node[T]
+
+	highestLocked := -1
+
+	for l := 0; l < level; l++ {
+		pred := preds[l]
+
+		if pred != previous {
+			pred.lock.Lock()
+			highestLocked = l
+			previous = pred
+		}
+
+		if !valid(l, pred) {
+			return highestLocked, false
+		}
+	}
+
+	return highestLocked, true
+}
+
+// unlockPreds unlocks the distinct predecessors locked by lockPreds.
+func unlockPreds[T any](preds *[maxLevel]*node[T], highestLocked int) {
+	var previous *node[T]
+
+	for l := 0; l <= highestLocked; l++ {
+		if preds[l] != previous {
+			preds[l].lock.Unlock()
+			previous = preds[l]
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package skiplistset
+
+import (
+	"errors"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Enumerable[int] = (*SkipListSet[int])(nil)
+
+// Any returns true for the first element found where the predicate function returns true.
+// It returns false if no element matches the predicate.
+func (s *SkipListSet[T]) Any(predicate functions.PredicateFunc[T]) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return !s.walk(func(n *node[T]) bool {
+		return !predicate(n.item)
+	})
+}
+
+// All applies the predicate function to every element in the collection,
+// and returns true if all elements match the predicate.
+func (s *SkipListSet[T]) All(predicate functions.PredicateFunc[T]) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.walk(func(n *node[T]) bool {
+		return predicate(n.item)
+	})
+}
+
+// ForEach applies function f to all elements in the collection.
+func (s *SkipListSet[T]) ForEach(f func(collections.Element[T])) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.walk(func(n *node[T]) bool {
+		f(util.NewElementType[T](s, &n.item, s.compare))
+		return true
+	})
+}
+
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (s *SkipListSet[T]) TryForEach(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	var err error
+
+	s.walk(func(n *node[T]) bool {
+		err = f(n.item)
+		return err == nil
+	})
+
+	return err
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (s *SkipListSet[T]) TryForEachAll(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	var errs []error
+
+	s.walk(func(n *node[T]) bool {
+		if err := f(n.item); err != nil {
+			errs = append(errs, err)
+		}
+		return true
+	})
+
+	return errors.Join(errs...)
+}
+
+// Map applies function f to all elements in the collection
+// and returns a new SkipListSet containing the result of f.
+func (s *SkipListSet[T]) Map(f func(T) T) collections.Collection[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	s1 := New[T](WithComparer[T](s.comparer()))
+
+	s.walk(func(n *node[T]) bool {
+		s1.insert(f(n.item))
+		return true
+	})
+
+	return s1
+}
+
+// Select returns a new SkipListSet containing only the items for which predicate is true.
+func (s *SkipListSet[T]) Select(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.doSelect(predicate, false)
+}
+
+// SelectDeep returns a new SkipListSet containing only the items for which predicate is true
+//
+// Elements are deep copied to the new collection using the provided [functions.DeepCopyFunc] if any.
+func (s *SkipListSet[T]) SelectDeep(predicate functions.PredicateFunc[T]) collections.Collection[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.doSelect(predicate, true)
+}
+
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Values are copied in ascending order.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (s *SkipListSet[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(s.copyWhere(predicate))
+}
+
+// Find finds the first occurrence of an element matching the predicate.
+//
+// The function returns nil if no match.
+func (s *SkipListSet[T]) Find(predicate functions.PredicateFunc[T]) collections.Element[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	result := s.findAll(predicate, false)
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result[0]
+}
+
+// FindAll finds all occurrences of an element matching the predicate.
+//
+// The function returns an empty slice if none match.
+func (s *SkipListSet[T]) FindAll(predicate functions.PredicateFunc[T]) []collections.Element[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.findAll(predicate, true)
+}
+
+// Max returns the maximum value in the collection according to the Comparer function.
+//
+// O(log n) on average, by descending from the highest level of the skip list.
+func (s *SkipListSet[T]) Max() T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.head == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	last := s.head
+
+	for l := int(s.level.Load()) - 1; l >= 0; l-- {
+		for next := last.next[l].Load(); next != nil; next = last.next[l].Load() {
+			last = next
+		}
+	}
+
+	if last == s.head {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return last.item
+}
+
+// Min returns the minimum value in the collection according to the Comparer function.
+//
+// O(1).
+func (s *SkipListSet[T]) Min() T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	var first *node[T]
+
+	s.walk(func(n *node[T]) bool {
+		first = n
+		return false
+	})
+
+	if first == nil {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	return first.item
+}
+
+func (s *SkipListSet[T]) findAll(predicate functions.PredicateFunc[T], all bool) []collections.Element[T] {
+
+	result := make([]collections.Element[T], 0, util.DefaultCapacity)
+
+	s.walk(func(n *node[T]) bool {
+		if predicate(n.item) {
+			result = append(result, util.NewElementType[T](s, &n.item, s.compare))
+			return all
+		}
+		return true
+	})
+
+	return result
+}
+
+func (s *SkipListSet[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool) collections.Collection[T] {
+	s1 := New[T](WithComparer[T](s.comparer()), WithDeepCopy(s.copy), WithCopyPolicy[T](s.copyPolicy))
+
+	s.walk(func(n *node[T]) bool {
+		if predicate(n.item) {
+			if deepCopy {
+				s1.insert(s1.copy(n.item))
+			} else {
+				s1.insert(n.item)
+			}
+		}
+		return true
+	})
+
+	return s1
+}
+
+func (s *SkipListSet[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+
+	s.walk(func(n *node[T]) bool {
+		if predicate(n.item) {
+			values = append(values, util.DeepCopy(n.item, s.copy))
+		}
+		return true
+	})
+
+	return values
+}
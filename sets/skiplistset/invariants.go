@@ -0,0 +1,44 @@
+package skiplistset
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the set is inconsistent,
+// including nodes linked out of order or missing from the levels below them.
+// Called after each modification in builds with the collections_debug tag,
+// other than those made by Add and Remove when concurrency is enabled.
+func (s *SkipListSet[T]) verifyInvariants() {
+	if s.head == nil {
+		util.AssertInvariant(s.size == 0, "set size does not match values")
+		return
+	}
+
+	level := int(s.level.Load())
+	util.AssertInvariant(level >= 1 && level <= maxLevel, "set level is out of range")
+
+	for l := level; l < maxLevel; l++ {
+		util.AssertInvariant(s.head.next[l].Load() == nil, "set has values above its level")
+	}
+
+	// Each level must be in order, and a subsequence of the level below.
+	var below map[*node[T]]bool
+
+	for l := 0; l < level; l++ {
+		linked := make(map[*node[T]]bool)
+		var prev *node[T]
+
+		for n := s.head.next[l].Load(); n != nil; n = n.next[l].Load() {
+			util.AssertInvariant(len(n.next) > l, "set node is linked above its level")
+			util.AssertInvariant(n.fullyLinked.Load() && !n.marked.Load(), "set has partially linked node")
+			util.AssertInvariant(prev == nil || s.compare(prev.item, n.item) < 0, "set values are out of order")
+			util.AssertInvariant(below == nil || below[n], "set node is missing from lower level")
+			linked[n] = true
+			prev = n
+		}
+
+		if l == 0 {
+			util.AssertInvariant(len(linked) == s.size, "set size does not match values")
+		}
+
+		below = linked
+	}
+}
@@ -0,0 +1,121 @@
+package skiplistset
+
+import (
+	"math/rand"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Iterable[int] = (*SkipListSet[int])(nil)
+
+type SkipListSetIterator[T any] struct {
+	util.IteratorBase[T]
+	set       *SkipListSet[T]
+	current   *node[T]
+	predicate functions.PredicateFunc[T]
+	local.InternalImpl
+}
+
+func newForwardIterator[T any](set *SkipListSet[T], predicate functions.PredicateFunc[T]) *SkipListSetIterator[T] {
+	return &SkipListSetIterator[T]{
+		set:       set,
+		predicate: predicate,
+		IteratorBase: util.IteratorBase[T]{
+			Version:    set.version,
+			NilElement: nil,
+		},
+	}
+}
+
+// Iterator returns an iterator that walks the collection in ascending order of values.
+func (s *SkipListSet[T]) Iterator() collections.Iterator[T] {
+
+	return newForwardIterator(s, util.DefaultPredicate[T])
+}
+
+// TakeWhile returns a forward iterater that walks the collection returning only
+// those elements for which predicate returns true.
+//
+//	set := skiplistset.New[int]()
+//	// add values
+//	iter := set.TakeWhile(func (val int) bool { return val % 2 == 0 })
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// do something with e.Value()
+//	}
+func (s *SkipListSet[T]) TakeWhile(predicate functions.PredicateFunc[T]) collections.Iterator[T] {
+
+	return newForwardIterator(s, predicate)
+}
+
+// SampleIterator returns an iterator that yields n values chosen at random from the set,
+// without replacement and in random order. If the set holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the set.
+// If r is nil, the default source of the math/rand package is used.
+//
+// Panics if n is negative.
+func (s *SkipListSet[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](s, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the set at the time of the call,
+// in the same order as ToSlice, so that the set may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the set's immutable slice, so it is only copied if the set has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the set, and their ValuePtr method panics.
+//
+//	iter := s.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		s.Remove(e.Value())
+//	}
+func (s *SkipListSet[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(s.ToImmutableSlice())
+}
+
+// Start begins an iteration across the set returning the fisrt element,
+// which will be nil if the collection is empty.
+//
+// Panics if the set has been modified since creation of the iterator.
+func (i *SkipListSetIterator[T]) Start() collections.Element[T] {
+	i.validateIterator()
+
+	if i.set.head == nil {
+		return i.NilElement
+	}
+
+	i.current = i.set.head
+	return i.Next()
+}
+
+// Next returns the next element in the set,
+// which will be nil if the end has been reached.
+//
+// Panics if the set has been modified since creation of the iterator.
+func (i *SkipListSetIterator[T]) Next() collections.Element[T] {
+	i.validateIterator()
+
+	for i.current != nil {
+		i.current = i.current.next[0].Load()
+
+		if i.current != nil && !i.current.marked.Load() && i.predicate(i.current.item) {
+			return util.NewElementType[T](i.set, &i.current.item, i.set.compare)
+		}
+	}
+
+	return i.NilElement
+}
+
+func (i *SkipListSetIterator[T]) validateIterator() {
+	if i.Version != i.set.version {
+		panic(messages.COLLECTION_MODIFIED)
+	}
+}
@@ -0,0 +1,91 @@
+package skiplistset
+
+import (
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// ApplyOps applies the given operations to the set in order.
+// Add adds the value if not already present, Remove removes the value if present,
+// and Clear empties the set.
+//
+// Panics if an op has an invalid kind.
+func (s *SkipListSet[T]) ApplyOps(operations []ops.Op[T]) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	util.ApplyOps(
+		operations,
+		func(value T) {
+			if s.validator.Accept(value) {
+				s.insert(value)
+			}
+		},
+		func(value T) { s.remove(value) },
+		s.clear,
+	)
+
+	s.version++
+}
+
+// StartRecording begins capturing mutations of the set as ops,
+// discarding any ops previously recorded.
+//
+// Only mutations that change the set are recorded, e.g. adding a value
+// that is already present is not recorded.
+func (s *SkipListSet[T]) StartRecording() {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.recorder.Start(s.copy)
+}
+
+// StopRecording ends recording and returns the ops recorded since StartRecording.
+func (s *SkipListSet[T]) StopRecording() []ops.Op[T] {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	return s.recorder.Stop()
+}
+
+// IsRecording returns true if the set is recording mutations.
+func (s *SkipListSet[T]) IsRecording() bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.recorder.IsRecording()
+}
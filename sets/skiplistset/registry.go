@@ -0,0 +1,72 @@
+package skiplistset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+)
+
+// Option function for New to give the set a name, under which it is registered with the
+// [registry] so that its size, version and operation counts are included in [registry.Snapshot].
+// The set remains registered until Dispose is called.
+//
+// Panics if name is empty.
+func WithName[T any](name string) SkipListSetOptionFunc[T] {
+	if name == "" {
+		panic(messages.NAME_EMPTY)
+	}
+	return func(s *SkipListSet[T]) {
+		s.name = name
+	}
+}
+
+// Dispose removes the set from the [registry] if it was created with [WithName].
+// The set remains usable, but no longer appears in [registry.Snapshot].
+// Calling Dispose more than once has no effect.
+func (s *SkipListSet[T]) Dispose() {
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.registration.Deregister()
+	s.registration = nil
+}
+
+// register registers the set with the registry if it has been given a name.
+func (s *SkipListSet[T]) register() {
+	if s.name == "" {
+		return
+	}
+
+	s.recorder.EnableCounting()
+	s.registration = registry.Register(s.name, s.stats)
+}
+
+func (s *SkipListSet[T]) stats() registry.Stats {
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.concurrent {
+		s.meta.Lock()
+		defer s.meta.Unlock()
+	}
+
+	return registry.Stats{
+		Type:    collections.COLLECTION_SKIPLISTSET,
+		Count:   s.size,
+		Version: s.version,
+		Ops:     s.recorder.Counts(),
+	}
+}
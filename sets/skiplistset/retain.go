@@ -0,0 +1,81 @@
+package skiplistset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*SkipListSet[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single pass
+// along the bottom level of the list, and returns the number of values removed. O(n).
+//
+// The whole set is locked, even if it was created [WithConcurrent].
+func (s *SkipListSet[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	// The last node kept at each level, to be linked to the next node kept at that level.
+	var preds [maxLevel]*node[T]
+	level := int(s.level.Load())
+
+	for l := 0; l < level; l++ {
+		preds[l] = s.head
+	}
+
+	removed := 0
+
+	for n := s.head.next[0].Load(); n != nil; n = n.next[0].Load() {
+		if !predicate(n.item) {
+			n.marked.Store(true)
+			s.recorder.Remove(n.item)
+			removed++
+			continue
+		}
+
+		for l := range n.next {
+			if preds[l].next[l].Load() != n {
+				preds[l].next[l].Store(n)
+			}
+
+			preds[l] = n
+		}
+	}
+
+	if removed == 0 {
+		return 0
+	}
+
+	for l := 0; l < level; l++ {
+		preds[l].next[l].Store(nil)
+	}
+
+	for level > 1 && s.head.next[level-1].Load() == nil {
+		level--
+	}
+
+	s.level.Store(int32(level))
+	s.size -= removed
+	s.version++
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (s *SkipListSet[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](s, other, s.RetainWhere)
+}
@@ -0,0 +1,975 @@
+/*
+Package skiplistset provides a skip list backed ordered collection of unique items.
+
+A skip list offers the same ordered operations as the red-black tree of an OrderedSet
+at a similar cost, but each insertion or removal only relinks the neighbours of one value.
+This allows a set constructed with [WithConcurrent] to add, remove and test values from many
+goroutines at once, locking only the nodes being relinked rather than the whole set.
+*/
+package skiplistset
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+	"github.com/fireflycons/generic_collections/sets"
+	"golang.org/x/exp/constraints"
+)
+
+// Assert SkipListSet implements required interfaces.
+var _ sets.Set[int] = (*SkipListSet[int])(nil)
+var _ collections.Pageable[int] = (*SkipListSet[int])(nil)
+
+// The maximum number of levels of the skip list.
+// With each level holding a quarter of the nodes of the level below,
+// this is ample for any set that will fit in memory.
+const maxLevel = 32
+
+// SkipListSetOptionFunc is the signature of a function
+// for providing options to the SkipListSet constructor.
+type SkipListSetOptionFunc[T any] func(*SkipListSet[T])
+
+// SkipListSet stores an ordered collection of unique elements.
+//
+// The zero value is an empty set with default options, ready to use. It is not thread-safe.
+type SkipListSet[T any] struct {
+	version      int
+	lock         *sync.RWMutex
+	head         *node[T]
+	level        atomic.Int32
+	size         int
+	compare      functions.ComparerFunc[T]
+	copy         functions.DeepCopyFunc[T]
+	copyPolicy   collections.CopyPolicy
+	concurrent   bool
+	recorder     util.OpRecorder[T]
+	guard        util.AccessGuard
+	immutable    util.SliceCache[T]
+	validator    *util.Validator[T]
+	verifySchema bool
+	lastSnapshot *snapshot[T]
+	name         string
+	registration *registry.Registration
+
+	// Serialises updates to the version, size and recorder made by
+	// concurrent calls to Add and Remove when concurrency is enabled.
+	meta sync.Mutex
+	local.InternalImpl
+}
+
+// node is a single element within the skip list, linked into as many levels as it has next pointers.
+//
+// The pointers and flags are accessed atomically so that concurrent lookups need take no lock.
+type node[T any] struct {
+	item        T
+	next        []atomic.Pointer[node[T]]
+	lock        sync.Mutex
+	marked      atomic.Bool
+	fullyLinked atomic.Bool
+}
+
+func newNode[T any](value T, level int) *node[T] {
+	return &node[T]{
+		item: value,
+		next: make([]atomic.Pointer[node[T]], level),
+	}
+}
+
+// Constructs a new SkipListSet[T].
+func New[T any](options ...SkipListSetOptionFunc[T]) *SkipListSet[T] {
+	set := &SkipListSet[T]{}
+
+	for _, o := range options {
+		o(set)
+	}
+
+	if set.copy == nil || set.copyPolicy == collections.Shallow {
+		set.copy = util.DefaultDeepCopy[T]
+	}
+
+	if set.compare == nil {
+		set.compare = util.GetDefaultComparer[T]()
+	}
+
+	set.initHead()
+	set.register()
+
+	return set
+}
+
+// lazyInit completes the construction of a zero value SkipListSet on its first modification,
+// as New would have done.
+func (s *SkipListSet[T]) lazyInit() {
+	if s.head != nil {
+		return
+	}
+
+	s.compare = util.GetZeroValueComparer[T]()
+
+	if s.copy == nil {
+		s.copy = util.DefaultDeepCopy[T]
+	}
+
+	s.initHead()
+}
+
+func (s *SkipListSet[T]) initHead() {
+	var zero T
+	s.head = newNode(zero, maxLevel)
+	s.head.fullyLinked.Store(true)
+	s.level.Store(1)
+}
+
+// comparer returns the comparer of the set, or for a zero value set that has not yet been modified,
+// the comparer it will be given when it is.
+func (s *SkipListSet[T]) comparer() functions.ComparerFunc[T] {
+	if s.compare == nil {
+		return util.GetZeroValueComparer[T]()
+	}
+
+	return s.compare
+}
+
+// Of constructs a new set containing the given values, e.g. skiplistset.Of(1, 2, 3).
+//
+// Duplicate values are added once.
+func Of[T any](values ...T) *SkipListSet[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	s := skiplistset.OfWith([]skiplistset.SkipListSetOptionFunc[int]{skiplistset.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []SkipListSetOptionFunc[T], values ...T) *SkipListSet[T] {
+	s := New(options...)
+	s.AddRange(values)
+	return s
+}
+
+// NewFunc is as [New], using compare to order the set.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...SkipListSetOptionFunc[T]) *SkipListSet[T] {
+	return New(append([]SkipListSetOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...SkipListSetOptionFunc[T]) *SkipListSet[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+//
+// Every operation locks the whole set, as for the other collections. See [WithConcurrent]
+// to allow Add, Remove and Contains to proceed in parallel.
+func WithThreadSafe[T any]() SkipListSetOptionFunc[T] {
+	return func(s *SkipListSet[T]) {
+		s.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function for New to make the collection thread-safe with fine-grained locking.
+//
+// Add and Remove lock only the nodes adjacent to the value, and share the lock of the whole set with
+// Contains and Get, so goroutines working on different parts of the set do not wait for each other.
+// Operations over the whole set, such as ToSlice, Min, Max and the Enumerable methods, may run alongside them
+// and see some but not all of the values added or removed meanwhile. Iterators still panic if the set is modified.
+// Operations that modify the whole set, such as AddRange, Clear and ApplyOps, lock the whole set.
+func WithConcurrent[T any]() SkipListSetOptionFunc[T] {
+	return func(s *SkipListSet[T]) {
+		s.lock = &sync.RWMutex{}
+		s.concurrent = true
+	}
+}
+
+// Option function for New to provide a comparer function for values of type T.
+// Required if the element type is not numeric, bool, pointer or string.
+func WithComparer[T any](comparer functions.ComparerFunc[T]) SkipListSetOptionFunc[T] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(s *SkipListSet[T]) {
+		s.compare = comparer
+	}
+}
+
+// Option func to provide a deep copy implementation for collection elements.
+func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) SkipListSetOptionFunc[T] {
+	// Can be nil
+	return func(s *SkipListSet[T]) {
+		s.copy = copier
+	}
+}
+
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) SkipListSetOptionFunc[T] {
+	return func(s *SkipListSet[T]) {
+		s.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+//
+// Capacity is ignored by this collection.
+func WithOptions[T any](options collections.CommonOptions[T]) SkipListSetOptionFunc[T] {
+	opts := make([]SkipListSetOptionFunc[T], 0, 5)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Concurrent {
+		opts = append(opts, WithConcurrent[T]())
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(s *SkipListSet[T]) {
+		for _, o := range opts {
+			o(s)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection
+// and ReplaceAll with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) SkipListSetOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(s *SkipListSet[T]) {
+		s.validator = util.NewValidator(validator, policy)
+	}
+}
+
+// AddRange adds a slice of values to the set.
+func (s *SkipListSet[T]) AddRange(values []T) {
+
+	if len(values) == 0 {
+		return
+	}
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if values = s.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
+	s.version++
+
+	for _, v := range values {
+		s.insert(v)
+	}
+}
+
+// AddCollection inserts the values of the given collection into this set.
+func (s *SkipListSet[T]) AddCollection(collection collections.Collection[T]) {
+
+	s.AddRange(util.ImportValues(collection, s.copyPolicy))
+}
+
+// ReplaceAll replaces the content of the set with the values of the given collection.
+func (s *SkipListSet[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	s.replaceAll(util.ImportValues(collection, s.copyPolicy))
+}
+
+func (s *SkipListSet[T]) replaceAll(values []T) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	values = s.validator.Filter(values)
+
+	s.initHead()
+	s.size = 0
+	s.recorder.Clear()
+
+	for _, v := range values {
+		s.insert(v)
+	}
+
+	s.version++
+}
+
+// Add adds a value into the collection.
+// Returns false if the value already exists or was rejected by the validator; else true if it was added.
+//
+// O(log n) on average.
+func (s *SkipListSet[T]) Add(value T) bool {
+
+	if s.concurrent {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+
+		return s.validator.Accept(value) && s.concurrentInsert(value)
+	}
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if !s.validator.Accept(value) {
+		return false
+	}
+
+	inserted := s.insert(value)
+	s.version++
+	return inserted
+}
+
+// Contains returns true if the value is present in the set.
+//
+// O(log n) on average.
+func (s *SkipListSet[T]) Contains(value T) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.lookup(value) != nil
+}
+
+func (s *SkipListSet[T]) UnlockedContains(value T) bool {
+	return s.lookup(value) != nil
+}
+
+// Get returns the collection element that matches the given value, or nil if it is not found.
+// Useful if the comparer matches struct elements on a key, to retrieve the stored element.
+func (s *SkipListSet[T]) Get(value T) collections.Element[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	n := s.lookup(value)
+
+	if n == nil {
+		return nil
+	}
+
+	return util.NewElementType[T](s, &n.item, s.compare)
+}
+
+// Remove removes the given value from the set.
+// Returns true if the value was present; else false.
+//
+// O(log n) on average.
+func (s *SkipListSet[T]) Remove(value T) bool {
+
+	if s.concurrent {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+
+		return s.concurrentRemove(value)
+	}
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.version++
+	return s.remove(value)
+}
+
+// Count returns the number of values in the set.
+func (s *SkipListSet[T]) Count() int {
+
+	if s.concurrent {
+		s.meta.Lock()
+		defer s.meta.Unlock()
+	}
+
+	return s.size
+}
+
+// IsEmpty returns true if the set has no values.
+func (s *SkipListSet[T]) IsEmpty() bool {
+
+	return s.Count() == 0
+}
+
+// ToSlice returns the collection content as a slice.
+// The values will be in ascending order.
+func (s *SkipListSet[T]) ToSlice() []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.toSlice(false)
+}
+
+// ToImmutableSlice returns the content of the set as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the set has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified.
+func (s *SkipListSet[T]) ToImmutableSlice() []T {
+
+	if s.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		if values, ok := s.cachedSlice(); ok {
+			return values
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	return s.immutable.Get(s.version, func() []T {
+		return s.toSlice(false)
+	})
+}
+
+// ToSliceDeep returns the collection content as a slice.
+// The values will be in ascending order.
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (s *SkipListSet[T]) ToSliceDeep() []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.toSlice(true)
+}
+
+// Page returns a copy of the values on the given zero-based page of the set,
+// in ascending order.
+//
+// The walk stops as soon as the page is filled, so only the values
+// up to and including the requested page are visited.
+//
+// Panics if pageIndex is negative or pageSize is less than 1.
+func (s *SkipListSet[T]) Page(pageIndex, pageSize int) []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	start, end := util.PageBounds(s.Count(), pageIndex, pageSize)
+	page := make([]T, 0, end-start)
+
+	if end == start {
+		return page
+	}
+
+	index := 0
+	s.walk(func(n *node[T]) bool {
+		if index >= start {
+			page = append(page, n.item)
+		}
+		index++
+		return index < end
+	})
+
+	return page
+}
+
+// Clear removes all values from the set.
+func (s *SkipListSet[T]) Clear() {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.clear()
+}
+
+// String returns a string representation of container.
+func (s *SkipListSet[T]) String() string {
+
+	var values []string
+	for _, value := range s.ToSlice() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+
+	return "SkipListSet\n" + strings.Join(values, ", ")
+}
+
+// Type returns the type of the collection (to avoid reflecting).
+func (s *SkipListSet[T]) Type() collections.CollectionType {
+	return collections.COLLECTION_SKIPLISTSET
+}
+
+// Difference returns the difference between two sets.
+// The new set consists of all elements that are in this set, but not other set.
+//
+// The argument can be any implementation of Set[T]. The result is a new SkipListSet with the same properties as this one.
+// Items are shallow-copied.
+func (s *SkipListSet[T]) Difference(other sets.Set[T]) sets.Set[T] {
+
+	ol := util.GetLock[T](other)
+
+	if ol != nil {
+		ol.RLock()
+		defer ol.RUnlock()
+	}
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	result := s.makeEmptyCopy()
+
+	s.walk(func(n *node[T]) bool {
+		if !other.UnlockedContains(n.item) {
+			result.insert(n.item)
+		}
+		return true
+	})
+
+	return result
+}
+
+// Intersection returns the intersection between two sets.
+// The new set consists of all elements that are in both this set and the other.
+//
+// The argument can be any implementation of Set[T]. The result is a new SkipListSet with the same properties as this one.
+// Items are shallow-copied.
+func (s *SkipListSet[T]) Intersection(other sets.Set[T]) sets.Set[T] {
+
+	ol := util.GetLock[T](other)
+
+	if ol != nil {
+		ol.RLock()
+		defer ol.RUnlock()
+	}
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	result := s.makeEmptyCopy()
+
+	// It's much quicker to scan the smaller collection
+	// and look up values in the larger one.
+	if slOther, ok := other.(*SkipListSet[T]); ok && slOther.Count() < s.Count() {
+		slOther.walk(func(n *node[T]) bool {
+			if s.lookup(n.item) != nil {
+				result.insert(n.item)
+			}
+			return true
+		})
+
+		return result
+	}
+
+	if _, ok := other.(*SkipListSet[T]); !ok && other.Count() < s.Count() {
+		for _, value := range other.ToSlice() {
+			if s.lookup(value) != nil {
+				result.insert(value)
+			}
+		}
+
+		return result
+	}
+
+	s.walk(func(n *node[T]) bool {
+		if other.UnlockedContains(n.item) {
+			result.insert(n.item)
+		}
+		return true
+	})
+
+	return result
+}
+
+// Union returns the union of two sets.
+// The new set consists of all elements that are in buth this and the other set.
+//
+// The argument can be any implementation of Set[T]. The result is a new SkipListSet with the same properties as this one.
+// Items are shallow-copied.
+func (s *SkipListSet[T]) Union(other sets.Set[T]) sets.Set[T] {
+
+	ol := util.GetLock[T](other)
+
+	if ol != nil {
+		ol.RLock()
+		defer ol.RUnlock()
+	}
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	result := s.makeEmptyCopy()
+	result.AddCollection(s)
+	result.AddCollection(other)
+	return result
+}
+
+// WalkRange calls fn for each value in the set that is greater than or equal to from
+// and less than or equal to to, in ascending order. If fn returns false, stop the walk.
+//
+// Only the values within the range are visited, and the walk does not allocate.
+// fn must not modify the set.
+//
+// Returns true if every value in the range has been walked.
+// Otherwise returns false.
+func (s *SkipListSet[T]) WalkRange(from, to T, fn func(T) bool) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.head == nil {
+		return true
+	}
+
+	var preds, succs [maxLevel]*node[T]
+	s.find(from, &preds, &succs)
+
+	for n := succs[0]; n != nil && s.compare(n.item, to) <= 0; n = n.next[0].Load() {
+		if n.marked.Load() {
+			continue
+		}
+
+		if !fn(n.item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// toSlice returns the values of the set in ascending order, deep copied if requested.
+// cachedSlice returns the cached immutable slice and true if it is current, under the read lock.
+// The version is read under meta, as concurrent calls to Add and Remove update it holding only the read lock.
+func (s *SkipListSet[T]) cachedSlice() ([]T, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	s.meta.Lock()
+	version := s.version
+	s.meta.Unlock()
+
+	return s.immutable.Load(version)
+}
+
+func (s *SkipListSet[T]) toSlice(deepCopy bool) []T {
+	slc := make([]T, 0, s.Count())
+
+	s.walk(func(n *node[T]) bool {
+		if deepCopy {
+			slc = append(slc, util.DeepCopy(n.item, s.copy))
+		} else {
+			slc = append(slc, n.item)
+		}
+		return true
+	})
+
+	return slc
+}
+
+// walk calls action for each value in the bottom level of the skip list in ascending order,
+// skipping those being removed by concurrent calls to Remove. If the action returns false, stop the walk.
+//
+// Returns true if the entire list has been walked.
+// Otherwise returns false.
+func (s *SkipListSet[T]) walk(action func(*node[T]) bool) bool {
+	if s.head == nil {
+		return true
+	}
+
+	for n := s.head.next[0].Load(); n != nil; n = n.next[0].Load() {
+		if n.marked.Load() {
+			continue
+		}
+
+		if !action(n) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// find locates the position of value in each level of the skip list, setting preds to the last node
+// less than value in each level and succs to the node that follows it.
+//
+// Returns the highest level at which a node holding value was found, or -1 if none was found.
+func (s *SkipListSet[T]) find(value T, preds, succs *[maxLevel]*node[T]) int {
+	found := -1
+	pred := s.head
+	level := int(s.level.Load())
+
+	for l := maxLevel - 1; l >= level; l-- {
+		preds[l] = pred
+		succs[l] = pred.next[l].Load()
+	}
+
+	for l := level - 1; l >= 0; l-- {
+		curr := pred.next[l].Load()
+
+		for curr != nil && s.compare(value, curr.item) > 0 {
+			pred = curr
+			curr = pred.next[l].Load()
+		}
+
+		if found == -1 && curr != nil && s.compare(value, curr.item) == 0 {
+			found = l
+		}
+
+		preds[l] = pred
+		succs[l] = curr
+	}
+
+	return found
+}
+
+// lookup returns the node holding value, or nil if there is none, or it is being
+// added or removed by a concurrent call to Add or Remove. It takes no node locks.
+func (s *SkipListSet[T]) lookup(value T) *node[T] {
+	if s.head == nil {
+		return nil
+	}
+
+	pred := s.head
+
+	for l := int(s.level.Load()) - 1; l >= 0; l-- {
+		curr := pred.next[l].Load()
+
+		for curr != nil {
+			order := s.compare(value, curr.item)
+
+			if order < 0 {
+				break
+			}
+
+			if order == 0 {
+				if curr.fullyLinked.Load() && !curr.marked.Load() {
+					return curr
+				}
+
+				return nil
+			}
+
+			pred = curr
+			curr = pred.next[l].Load()
+		}
+	}
+
+	return nil
+}
+
+// insert adds value to the set, holding the lock of the whole set if any.
+func (s *SkipListSet[T]) insert(value T) bool {
+	var preds, succs [maxLevel]*node[T]
+
+	if s.find(value, &preds, &succs) != -1 {
+		return false
+	}
+
+	level := randomLevel()
+	n := newNode(value, level)
+
+	for l := 0; l < level; l++ {
+		n.next[l].Store(succs[l])
+		preds[l].next[l].Store(n)
+	}
+
+	n.fullyLinked.Store(true)
+	s.raiseLevel(level)
+	s.size++
+	s.recorder.Add(value)
+	return true
+}
+
+// remove removes value from the set, holding the lock of the whole set if any.
+func (s *SkipListSet[T]) remove(value T) bool {
+	var preds, succs [maxLevel]*node[T]
+
+	found := s.find(value, &preds, &succs)
+
+	if found == -1 {
+		return false
+	}
+
+	victim := succs[found]
+	victim.marked.Store(true)
+
+	for l := len(victim.next) - 1; l >= 0; l-- {
+		preds[l].next[l].Store(victim.next[l].Load())
+	}
+
+	level := s.level.Load()
+
+	for level > 1 && s.head.next[level-1].Load() == nil {
+		level--
+	}
+
+	s.level.Store(level)
+	s.size--
+	s.recorder.Remove(value)
+	return true
+}
+
+func (s *SkipListSet[T]) clear() {
+	s.initHead()
+	s.size = 0
+	s.version++
+	s.recorder.Clear()
+}
+
+// raiseLevel raises the number of levels in use to at least level.
+func (s *SkipListSet[T]) raiseLevel(level int) {
+	for {
+		current := s.level.Load()
+
+		if int32(level) <= current || s.level.CompareAndSwap(current, int32(level)) {
+			return
+		}
+	}
+}
+
+// randomLevel returns the number of levels for a new node, where each level
+// above the first is reached with a probability of one in four.
+func randomLevel() int {
+	level := 1
+
+	for level < maxLevel && rand.Uint32()&3 == 0 {
+		level++
+	}
+
+	return level
+}
+
+func (s *SkipListSet[T]) makeEmptyCopy() *SkipListSet[T] {
+	other := &SkipListSet[T]{
+		compare:    s.comparer(),
+		copy:       s.copy,
+		copyPolicy: s.copyPolicy,
+		concurrent: s.concurrent,
+	}
+
+	if s.lock != nil {
+		other.lock = &sync.RWMutex{}
+	}
+
+	if other.copy == nil {
+		other.copy = util.DefaultDeepCopy[T]
+	}
+
+	other.initHead()
+
+	return other
+}
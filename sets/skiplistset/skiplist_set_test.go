@@ -0,0 +1,474 @@
+package skiplistset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/fireflycons/generic_collections/sets"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructor(t *testing.T) {
+
+	t.Run("With comparer", func(t *testing.T) {
+		magic := 42
+		comp := func(v1, v2 int) int { return magic }
+		set := New(WithComparer(comp))
+
+		require.Equal(t, magic, set.compare(1, 0))
+	})
+
+	t.Run("With common options", func(t *testing.T) {
+		magic := 42
+		opts := collections.CommonOptions[int]{
+			Concurrent: true,
+			Capacity:   100,
+			Comparer:   func(v1, v2 int) int { return magic },
+		}
+		set := New(WithOptions(opts))
+
+		require.NotNil(t, set.lock)
+		require.True(t, set.concurrent)
+		require.Equal(t, magic, set.compare(1, 0))
+	})
+
+	t.Run("With nil comparer panics", func(t *testing.T) {
+		var comp func(v1, v2 int) int
+		require.Panics(t, func() { New(WithComparer(comp)) })
+	})
+}
+
+func TestAddRemove(t *testing.T) {
+
+	for _, concurrent := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Matches sorted reference concurrent=%v", concurrent), func(t *testing.T) {
+			var s *SkipListSet[int]
+
+			if concurrent {
+				s = New(WithConcurrent[int]())
+			} else {
+				s = New[int]()
+			}
+
+			r := rand.New(rand.NewSource(2163))
+			reference := map[int]bool{}
+
+			for i := 0; i < 5000; i++ {
+				v := r.Intn(1000)
+
+				if r.Intn(3) == 0 {
+					require.Equal(t, reference[v], s.Remove(v))
+					delete(reference, v)
+				} else {
+					require.Equal(t, !reference[v], s.Add(v))
+					reference[v] = true
+				}
+			}
+
+			expected := make([]int, 0, len(reference))
+			for v := range reference {
+				expected = append(expected, v)
+			}
+			sort.Ints(expected)
+
+			require.Equal(t, expected, s.ToSlice())
+			require.Equal(t, len(expected), s.Count())
+			require.Equal(t, expected[0], s.Min())
+			require.Equal(t, expected[len(expected)-1], s.Max())
+
+			for v := 0; v < 1000; v++ {
+				require.Equal(t, reference[v], s.Contains(v))
+			}
+
+			s.verifyInvariants()
+		})
+	}
+
+	t.Run("Get returns stored element", func(t *testing.T) {
+		type entry struct {
+			key, value int
+		}
+		s := NewFunc(func(a, b entry) int { return a.key - b.key })
+		s.Add(entry{1, 100})
+
+		require.Equal(t, entry{1, 100}, s.Get(entry{key: 1}).Value())
+		require.Nil(t, s.Get(entry{key: 2}))
+	})
+
+	t.Run("Min and Max of empty set panic", func(t *testing.T) {
+		s := New[int]()
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { s.Min() })
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { s.Max() })
+	})
+
+	t.Run("Clear empties the set", func(t *testing.T) {
+		s := Of(3, 1, 2)
+		s.Clear()
+
+		require.True(t, s.IsEmpty())
+		require.Empty(t, s.ToSlice())
+		s.verifyInvariants()
+	})
+}
+
+func TestConcurrent(t *testing.T) {
+
+	t.Run("Parallel adds and removes", func(t *testing.T) {
+		s := New(WithConcurrent[int]())
+		wg := sync.WaitGroup{}
+
+		// Each goroutine adds its own range, removing the odd values,
+		// while all goroutines test values in the ranges of the others.
+		const goroutines, perGoroutine = 8, 2000
+
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(int64(g)))
+
+				for i := 0; i < perGoroutine; i++ {
+					v := g*perGoroutine + i
+					require.True(t, s.Add(v))
+					s.Contains(r.Intn(goroutines * perGoroutine))
+
+					if v%2 == 1 {
+						require.True(t, s.Remove(v))
+					}
+				}
+			}(g)
+		}
+
+		wg.Wait()
+
+		require.Equal(t, goroutines*perGoroutine/2, s.Count())
+		require.True(t, s.All(func(v int) bool { return v%2 == 0 }))
+		s.verifyInvariants()
+	})
+
+	t.Run("Contended values", func(t *testing.T) {
+		s := New(WithConcurrent[int]())
+		s.StartRecording()
+		wg := sync.WaitGroup{}
+
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(int64(g)))
+
+				for i := 0; i < 5000; i++ {
+					if v := r.Intn(50); r.Intn(2) == 0 {
+						s.Add(v)
+					} else {
+						s.Remove(v)
+					}
+				}
+			}(g)
+		}
+
+		wg.Wait()
+		s.verifyInvariants()
+
+		// The ops are recorded in an order consistent with the final content of the set
+		replica := New[int]()
+		replica.ApplyOps(s.StopRecording())
+		require.Equal(t, s.ToSlice(), replica.ToSlice())
+		require.Equal(t, len(s.ToSlice()), s.Count())
+	})
+
+	t.Run("Whole set operations lock out adds", func(t *testing.T) {
+		s := New(WithConcurrent[int]())
+		wg := sync.WaitGroup{}
+
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+
+				for i := 0; i < 1000; i++ {
+					s.Add(g*1000 + i)
+
+					if i%100 == 0 {
+						s.AddRange([]int{-i - 1, -i - 2})
+						_ = s.ToImmutableSlice()
+					}
+				}
+			}(g)
+		}
+
+		wg.Wait()
+		require.Equal(t, 4000+20, s.Count())
+		s.verifyInvariants()
+	})
+}
+
+func TestSetOperations(t *testing.T) {
+
+	others := map[string]func(...int) sets.Set[int]{
+		"SkipListSet": func(v ...int) sets.Set[int] { return Of(v...) },
+		"HashSet": func(v ...int) sets.Set[int] {
+			s := hashset.New[int]()
+			s.AddRange(v)
+			return s
+		},
+		"OrderedSet": func(v ...int) sets.Set[int] { return orderedset.Of(v...) },
+	}
+
+	for name, other := range others {
+		t.Run(name, func(t *testing.T) {
+			s := Of(1, 2, 3, 4, 5)
+
+			require.Equal(t, []int{1, 2}, s.Difference(other(3, 4, 5, 6)).ToSlice())
+			require.Equal(t, []int{3, 4}, s.Intersection(other(3, 4, 9)).ToSlice())
+			require.Equal(t, []int{2, 3, 4, 5}, s.Intersection(other(2, 3, 4, 5, 6, 7, 8)).ToSlice())
+			require.Equal(t, []int{1, 2, 3, 4, 5, 6}, s.Union(other(5, 6)).ToSlice())
+		})
+	}
+}
+
+func TestPageAndWalkRange(t *testing.T) {
+
+	s := New[int]()
+	for i := 0; i < 100; i++ {
+		s.Add(i * 2)
+	}
+
+	t.Run("Page", func(t *testing.T) {
+		require.Equal(t, []int{20, 22, 24, 26, 28}, s.Page(2, 5))
+		require.Equal(t, []int{190, 192, 194, 196, 198}, s.Page(19, 5))
+		require.Empty(t, s.Page(20, 5))
+	})
+
+	t.Run("WalkRange", func(t *testing.T) {
+		var walked []int
+		require.True(t, s.WalkRange(11, 19, func(v int) bool {
+			walked = append(walked, v)
+			return true
+		}))
+		require.Equal(t, []int{12, 14, 16, 18}, walked)
+
+		walked = nil
+		require.False(t, s.WalkRange(0, 100, func(v int) bool {
+			walked = append(walked, v)
+			return v < 4
+		}))
+		require.Equal(t, []int{0, 2, 4}, walked)
+	})
+}
+
+func TestIterator(t *testing.T) {
+
+	t.Run("Iterates in order", func(t *testing.T) {
+		s := Of(5, 3, 9, 1)
+		var values []int
+		iter := s.Iterator()
+
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+		}
+
+		require.Equal(t, []int{1, 3, 5, 9}, values)
+	})
+
+	t.Run("TakeWhile filters values", func(t *testing.T) {
+		s := Of(1, 2, 3, 4, 5, 6)
+		var values []int
+		iter := s.TakeWhile(func(v int) bool { return v%2 == 0 })
+
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			values = append(values, e.Value())
+		}
+
+		require.Equal(t, []int{2, 4, 6}, values)
+	})
+
+	t.Run("Modification invalidates iterator", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		iter := s.Iterator()
+		iter.Start()
+		s.Add(4)
+
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Next() })
+	})
+
+	t.Run("Set element panics on ValuePtr", func(t *testing.T) {
+		s := Of(1)
+		require.Panics(t, func() { s.Iterator().Start().ValuePtr() })
+	})
+}
+
+func TestEnumerable(t *testing.T) {
+
+	s := Of(1, 2, 3, 4, 5, 6)
+	even := func(v int) bool { return v%2 == 0 }
+
+	require.True(t, s.Any(even))
+	require.False(t, s.All(even))
+	require.Equal(t, 2, s.Find(even).Value())
+	require.Len(t, s.FindAll(even), 3)
+	require.Equal(t, []int{2, 4, 6}, s.Select(even).ToSlice())
+	require.Equal(t, []int{2, 4, 6, 8, 10, 12}, s.Map(func(v int) int { return v * 2 }).ToSlice())
+
+	dest := hashset.New[int]()
+	s.CopyTo(dest, even)
+	require.ElementsMatch(t, []int{2, 4, 6}, dest.ToSlice())
+
+	require.EqualError(t, s.TryForEach(func(v int) error {
+		if v == 3 {
+			return fmt.Errorf("three")
+		}
+		return nil
+	}), "three")
+}
+
+func TestApplyOpsAndRecording(t *testing.T) {
+
+	t.Run("Script is applied in order", func(t *testing.T) {
+		s := New[int]()
+		s.Add(10)
+		s.ApplyOps([]ops.Op[int]{
+			ops.Clear[int](),
+			ops.Add(1),
+			ops.Add(2),
+			ops.Add(1),
+			ops.Add(3),
+			ops.Remove(1),
+			ops.Remove(5),
+		})
+
+		require.Equal(t, []int{2, 3}, s.ToSlice())
+	})
+
+	t.Run("Only changes are recorded", func(t *testing.T) {
+		s := New[int]()
+		s.StartRecording()
+		s.Add(1)
+		s.Add(1)
+		s.Remove(2)
+		s.Remove(1)
+		s.Clear()
+
+		require.Equal(t, []ops.Op[int]{ops.Add(1), ops.Remove(1), ops.Clear[int]()}, s.StopRecording())
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+
+	s := New[int]()
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+
+	before := s.Snapshot()
+	require.Same(t, before, s.Snapshot())
+
+	s.Remove(10)
+	s.Add(1000)
+
+	added, removed := sets.DiffSnapshots(before, s.Snapshot())
+	require.Equal(t, []int{1000}, added)
+	require.Equal(t, []int{10}, removed)
+}
+
+func TestGob(t *testing.T) {
+
+	s := Of(3, 1, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(s))
+
+	decoded := New[int]()
+	require.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	require.Equal(t, []int{1, 2, 3}, decoded.ToSlice())
+}
+
+func TestZeroValue(t *testing.T) {
+
+	var s SkipListSet[int]
+
+	require.True(t, s.IsEmpty())
+	require.False(t, s.Contains(1))
+	require.Nil(t, s.Get(1))
+	require.Empty(t, s.ToSlice())
+	require.Nil(t, s.Iterator().Start())
+
+	s.AddRange([]int{30, 10, 20})
+	require.Equal(t, []int{10, 20, 30}, s.ToSlice())
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+	})
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithConcurrent[*int]())
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+	})
+}
+
+func TestRandomLevel(t *testing.T) {
+
+	counts := make([]int, maxLevel+1)
+	for i := 0; i < 100000; i++ {
+		counts[randomLevel()]++
+	}
+
+	require.Zero(t, counts[0])
+	// Roughly three quarters of nodes are only in the bottom level
+	require.InDelta(t, 75000, counts[1], 2000)
+}
+
+func TestRetainWhere(t *testing.T) {
+
+	for _, concurrent := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Concurrent %t", concurrent), func(t *testing.T) {
+			s := New[int]()
+			if concurrent {
+				s = New(WithConcurrent[int]())
+			}
+
+			s.AddRange(rand.New(rand.NewSource(3)).Perm(500))
+
+			require.Equal(t, 495, s.RetainWhere(func(v int) bool { return v%100 == 0 }))
+			s.verifyInvariants()
+			require.Equal(t, []int{0, 100, 200, 300, 400}, s.ToSlice())
+			require.False(t, s.Contains(250))
+
+			s.AddRange([]int{250, 50})
+			s.verifyInvariants()
+			require.Equal(t, []int{0, 50, 100, 200, 250, 300, 400}, s.ToSlice())
+
+			require.Equal(t, 7, s.RetainWhere(func(int) bool { return false }))
+			s.verifyInvariants()
+			require.True(t, s.IsEmpty())
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package skiplistset
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*SkipListSet[int])(nil)
+
+// TryAdd adds a value into the collection.
+//
+// Returns true and nil if the value was added; else false and [collections.ErrDuplicate] if the value
+// already exists, or an error wrapping [collections.ErrInvalidValue] and the error of the validator
+// if it was rejected, whatever the validation policy.
+//
+// O(log n) on average.
+func (s *SkipListSet[T]) TryAdd(value T) (bool, error) {
+
+	if s.concurrent {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+
+		if err := s.validator.Check(value); err != nil {
+			return false, err
+		}
+
+		return tryAddResult(s.concurrentInsert(value))
+	}
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if err := s.validator.Check(value); err != nil {
+		return false, err
+	}
+
+	inserted := s.insert(value)
+	s.version++
+	return tryAddResult(inserted)
+}
+
+func tryAddResult(inserted bool) (bool, error) {
+	if !inserted {
+		return false, collections.ErrDuplicate
+	}
+
+	return true, nil
+}
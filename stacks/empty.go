@@ -0,0 +1,108 @@
+package stacks
+
+import (
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/local"
+	"github.com/fireflycons/generic_collections/internal/messages"
+)
+
+// Assert emptyStack implements required interfaces.
+var _ Stack[int] = (*emptyStack[int])(nil)
+
+// emptyStack is an immutable stack with no values.
+type emptyStack[T any] struct {
+	// version and lock are never set, but are laid out as in every other
+	// collection as they are read by the internal element and lock helpers.
+	version int
+	lock    *sync.RWMutex
+
+	collections.Collection[T]
+}
+
+// Empty returns an immutable stack with no values, that may be returned instead of nil
+// by functions returning a Stack, so that callers need not check for nil.
+//
+// Methods that read the stack behave as those of any other empty stack, and methods that would
+// modify it panic, as described for [collections.Empty]. Peek panics as the stack is empty.
+// Map, Select, Sorted, Clone and the like return the empty stack itself.
+//
+// There is one empty stack for each type of value, so the result may be compared with ==.
+func Empty[T any]() Stack[T] {
+	return local.Singleton(func() *emptyStack[T] {
+		return &emptyStack[T]{Collection: collections.Empty[T]()}
+	})
+}
+
+// Push panics as the stack is read-only.
+func (*emptyStack[T]) Push(T) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Pop panics as the stack is read-only.
+func (*emptyStack[T]) Pop() T {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// TryPop panics as the stack is read-only.
+func (*emptyStack[T]) TryPop() (T, bool) {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Peek panics as the stack is empty.
+func (*emptyStack[T]) Peek() T {
+	panic(messages.COLLECTION_EMPTY)
+}
+
+// TryPeek returns the zero value of T and false.
+func (*emptyStack[T]) TryPeek() (T, bool) {
+	var zero T
+	return zero, false
+}
+
+// Sort panics as the stack is read-only.
+func (*emptyStack[T]) Sort() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// SortDescending panics as the stack is read-only.
+func (*emptyStack[T]) SortDescending() {
+	panic(messages.COLLECTION_READ_ONLY)
+}
+
+// Sorted returns this stack.
+func (s *emptyStack[T]) Sorted() collections.Collection[T] {
+	return s
+}
+
+// SortedDescending returns this stack.
+func (s *emptyStack[T]) SortedDescending() collections.Collection[T] {
+	return s
+}
+
+// Map returns this stack.
+func (s *emptyStack[T]) Map(func(T) T) collections.Collection[T] {
+	return s
+}
+
+// Select returns this stack.
+func (s *emptyStack[T]) Select(functions.PredicateFunc[T]) collections.Collection[T] {
+	return s
+}
+
+// SelectDeep returns this stack.
+func (s *emptyStack[T]) SelectDeep(functions.PredicateFunc[T]) collections.Collection[T] {
+	return s
+}
+
+// Clone returns this stack.
+func (s *emptyStack[T]) Clone() collections.Collection[T] {
+	return s
+}
+
+// CloneDeep returns this stack.
+func (s *emptyStack[T]) CloneDeep() collections.Collection[T] {
+	return s
+}
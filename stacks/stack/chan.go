@@ -0,0 +1,50 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// ToChan returns a channel that receives the values of the stack in the order they are popped,
+// popping each as it is sent. The channel is closed once the stack is empty or ctx is done,
+// so it drains the values on the stack at the time, and any pushed before it is found to be empty.
+//
+//	for v := range s.ToChan(ctx) {
+//		process(v)
+//	}
+//
+// A value is popped before it is sent, so if ctx is done while a value is waiting to be received,
+// that value is discarded. If other goroutines are to use the stack while it is drained, the stack must be thread-safe.
+func (s *Stack[T]) ToChan(ctx context.Context) <-chan T {
+
+	return util.ToChan(ctx, s.TryPop)
+}
+
+// FromChan constructs a new thread-safe stack, and in a new goroutine pushes the values received from src,
+// until src is closed or ctx is done. The stack may be used while it is filled.
+//
+// The returned channel receives nil once src is closed, else the error of ctx, then is closed.
+//
+//	s, done := stack.FromChan(ctx, tasks)
+//	// ...
+//	err := <-done
+//
+// Panics if src is nil.
+func FromChan[T any](ctx context.Context, src <-chan T, options ...StackOptionFunc[T]) (*Stack[T], <-chan error) {
+
+	if src == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "src"))
+	}
+
+	s := New(append([]StackOptionFunc[T]{WithThreadSafe[T]()}, options...)...)
+
+	done := util.FromChan(ctx, src, func(value T) bool {
+		s.Push(value)
+		return true
+	})
+
+	return s, done
+}
@@ -0,0 +1,107 @@
+package stack
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// CloneInto replaces the content of dst with a deep copy of this stack's values,
+// using the provided [functions.DeepCopyFunc] if any.
+//
+// The backing slice of dst is reused if it has sufficient capacity, so periodically cloning
+// into the same destination does not allocate. The comparer, deep copy function and copy policy of
+// this stack are copied to dst; its thread safety is not changed.
+//
+// Panics if dst is nil.
+func (s *Stack[T]) CloneInto(dst *Stack[T]) {
+
+	if dst == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "dst"))
+	}
+
+	if dst == s {
+		return
+	}
+
+	// Locked in a consistent order, so that a concurrent dst.CloneInto(s) cannot deadlock
+	util.LockPair(s.lock, dst.lock)
+	defer util.UnlockPair(s.lock, dst.lock)
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if util.Debug {
+		defer dst.guard.Write(dst.lock != nil, dst.verifyInvariants)()
+	}
+
+	dst.lazyInit()
+
+	values := s.values()
+
+	if cap(dst.buffer) < len(values) {
+		dst.buffer = make([]T, len(values))
+	} else {
+		dst.buffer = dst.buffer[:cap(dst.buffer)]
+	}
+
+	util.DeepCopySlice(dst.buffer, values, s.copy)
+
+	var empty T
+	for i := len(values); i < dst.size; i++ {
+		dst.buffer[i] = empty
+	}
+
+	dst.size = len(values)
+	dst.tombstones = nil
+	dst.compare = s.compare
+	dst.copy = s.copy
+	dst.copyPolicy = s.copyPolicy
+	dst.version++
+	dst.recorder.Reset(func() []T { return util.Reverse(dst.toSlice(false)) })
+	dst.reweigh()
+}
+
+// Clone returns a new Stack holding the values of this stack in the same order, copied by value,
+// with the comparer, deep copy function and copy policy of this stack.
+func (s *Stack[T]) Clone() collections.Collection[T] {
+
+	return s.doClone(false)
+}
+
+// CloneDeep returns a new Stack holding the values of this stack in the same order, copied using
+// the provided [functions.DeepCopyFunc] if any, with the comparer, deep copy function and copy policy of this stack.
+func (s *Stack[T]) CloneDeep() collections.Collection[T] {
+
+	return s.doClone(true)
+}
+
+// doClone pushes the values from the bottom of the stack up, as Select would
+// push them from the top down and so reverse their order.
+func (s *Stack[T]) doClone(deepCopy bool) collections.Collection[T] {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	s1 := New[T](WithCapacity[T](len(s.buffer)), WithComparer[T](s.compare), WithDeepCopy(s.copy), WithCopyPolicy[T](s.copyPolicy))
+	iter := newReverseIterator[T](s)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if deepCopy {
+			s1.push(s.copy(e.Value()))
+		} else {
+			s1.push(e.Value())
+		}
+	}
+
+	return s1
+}
@@ -0,0 +1,40 @@
+//go:build collections_debug
+
+package stack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugAssertions(t *testing.T) {
+
+	t.Run("Corrupted state is detected by next modification", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		s.size = len(s.buffer) + 1
+
+		require.PanicsWithValue(t, fmt.Sprintf(messages.INVARIANT_VIOLATED_FMT, "stack size exceeds buffer"), func() { s.StartRecording() })
+	})
+
+	t.Run("Access during modification is detected", func(t *testing.T) {
+		s := Of(1, 2, 3)
+
+		// ForEach holds write ownership while calling f
+		require.PanicsWithValue(t, messages.CONCURRENT_ACCESS, func() {
+			s.ForEach(func(e collections.Element[int]) { s.Contains(1) })
+		})
+	})
+
+	t.Run("Thread-safe stack is not marked", func(t *testing.T) {
+		s := OfWith([]StackOptionFunc[int]{WithThreadSafe[int]()}, 1, 2, 3)
+
+		s.TryForEach(func(int) error {
+			s.Contains(1)
+			return nil
+		})
+	})
+}
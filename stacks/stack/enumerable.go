@@ -1,6 +1,8 @@
 package stack
 
 import (
+	"errors"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/util"
@@ -18,6 +20,12 @@ func (s *Stack[T]) Any(predicate functions.PredicateFunc[T]) bool {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, predicate)
 
 	return iter.Start() != nil
@@ -32,6 +40,12 @@ func (s *Stack[T]) All(predicate functions.PredicateFunc[T]) bool {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -51,6 +65,12 @@ func (s *Stack[T]) ForEach(f func(collections.Element[T])) {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -58,6 +78,59 @@ func (s *Stack[T]) ForEach(f func(collections.Element[T])) {
 	}
 }
 
+// TryForEach calls f for each value in the collection, stopping at
+// the first error returned by f.
+//
+// Returns the error returned by f, or nil if there was none.
+func (s *Stack[T]) TryForEach(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TryForEachAll calls f for each value in the collection, continuing
+// after any error returned by f.
+//
+// Returns all errors returned by f joined with [errors.Join], or nil if there were none.
+func (s *Stack[T]) TryForEachAll(f func(T) error) error {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	var errs []error
+	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		if err := f(e.Value()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Map applies function f to all elements in the collection
 // and returns a new Stack containing the result of f.
 func (s *Stack[T]) Map(f func(T) T) collections.Collection[T] {
@@ -67,6 +140,12 @@ func (s *Stack[T]) Map(f func(T) T) collections.Collection[T] {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
 	iter := newForwardIterator[T](s, util.DefaultPredicate[T])
 
 	buf1 := New[T](WithCapacity[T](len(s.buffer)), WithComparer[T](s.compare))
@@ -86,6 +165,10 @@ func (s *Stack[T]) Select(predicate functions.PredicateFunc[T]) collections.Coll
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	return s.doSelect(predicate, false)
 }
 
@@ -99,9 +182,23 @@ func (s *Stack[T]) SelectDeep(predicate functions.PredicateFunc[T]) collections.
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	return s.doSelect(predicate, true)
 }
 
+// CopyTo copies the values for which predicate is true into the destination collection.
+//
+// Values are copied from the top of the stack downwards.
+//
+// Elements are deep copied using the provided [functions.DeepCopyFunc] if any.
+func (s *Stack[T]) CopyTo(dest collections.Collection[T], predicate functions.PredicateFunc[T]) {
+
+	dest.AddRange(s.copyWhere(predicate))
+}
+
 // Find finds the first occurrence of an element matching the predicate.
 //
 // The function returns nil if no match.
@@ -112,6 +209,10 @@ func (s *Stack[T]) Find(predicate functions.PredicateFunc[T]) collections.Elemen
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.doFind(predicate, false)
 
 	if len(result) == 0 {
@@ -131,6 +232,10 @@ func (s *Stack[T]) FindAll(predicate functions.PredicateFunc[T]) []collections.E
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	result := s.doFind(predicate, true)
 
 	return result
@@ -161,7 +266,11 @@ func (s *Stack[T]) doSelect(predicate functions.PredicateFunc[T], deepCopy bool)
 		defer s.lock.RUnlock()
 	}
 
-	s1 := New[T](WithCapacity[T](len(s.buffer)), WithComparer[T](s.compare))
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	s1 := New[T](WithCapacity[T](len(s.buffer)), WithComparer[T](s.compare), WithDeepCopy(s.copy), WithCopyPolicy[T](s.copyPolicy))
 	iter := newForwardIterator[T](s, predicate)
 
 	for e := iter.Start(); e != nil; e = iter.Next() {
@@ -183,7 +292,11 @@ func (s *Stack[T]) Min() T {
 		defer s.lock.RUnlock()
 	}
 
-	return util.Min(s.buffer[0:s.size], s.compare, s.concurrent)
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return util.Min(s.values(), s.compare, s.concurrent)
 }
 
 // Max returns the maximum value in the collection according to the Comparer function.
@@ -194,5 +307,30 @@ func (s *Stack[T]) Max() T {
 		defer s.lock.RUnlock()
 	}
 
-	return util.Max(s.buffer[0:s.size], s.compare, s.concurrent)
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return util.Max(s.values(), s.compare, s.concurrent)
+}
+
+func (s *Stack[T]) copyWhere(predicate functions.PredicateFunc[T]) []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	values := make([]T, 0, util.DefaultCapacity)
+	iter := newForwardIterator[T](s, predicate)
+
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		values = append(values, util.DeepCopy(e.Value(), s.copy))
+	}
+
+	return values
 }
@@ -0,0 +1,66 @@
+package stack_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/stacks/stack"
+)
+
+func Example() {
+	s := stack.New[string]()
+	s.Push("first")
+	s.Push("second")
+	s.Push("third")
+
+	for !s.IsEmpty() {
+		fmt.Println(s.Pop())
+	}
+	// Output:
+	// third
+	// second
+	// first
+}
+
+func ExampleStack_TryPop() {
+	s := stack.Of(1)
+
+	for {
+		value, ok := s.TryPop()
+		if !ok {
+			break
+		}
+		fmt.Println(value)
+	}
+	// Output:
+	// 1
+}
+
+func ExampleStack_Iterator() {
+	s := stack.Of(1, 2, 3)
+	iter := s.Iterator()
+
+	// Iteration begins at the top of the stack.
+	for e := iter.Start(); e != nil; e = iter.Next() {
+		fmt.Print(e.Value(), " ")
+	}
+	fmt.Println()
+	// Output:
+	// 3 2 1
+}
+
+func ExampleStack_Sort() {
+	s := stack.Of(3, 1, 2)
+	s.Sort()
+
+	fmt.Println(s.Peek(), s.ToSlice())
+	// Output:
+	// 1 [1 2 3]
+}
+
+func ExampleStack_IndexOf() {
+	s := stack.Of("a", "b", "c")
+
+	fmt.Println(s.IndexOf("c"), s.IndexOf("a"), s.IndexOf("z"))
+	// Output:
+	// 0 2 -1
+}
@@ -0,0 +1,49 @@
+package stack
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/schema"
+)
+
+// GobEncode implements gob.GobEncoder, encoding the values of the stack in the order in which they were pushed,
+// so that the stack may be persisted or sent over RPC with encoding/gob.
+// The values must themselves be encodable by encoding/gob. The values follow a header describing the [schema] of the stack, but its options are not encoded.
+func (s *Stack[T]) GobEncode() ([]byte, error) {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return util.GobEncode(schema.Of[T](collections.COLLECTION_STACK, nil), s.values())
+}
+
+// GobDecode implements gob.GobDecoder, replacing the values of the stack with those encoded by GobEncode.
+//
+// The options of the stack are retained, so decode into a stack constructed with the options
+// of that encoded, or into a zero value for the default options.
+func (s *Stack[T]) GobDecode(data []byte) error {
+
+	var values []T
+
+	if err := util.GobDecode(data, &values, schema.Of[T](collections.COLLECTION_STACK, nil), s.verifySchema); err != nil {
+		return err
+	}
+
+	s.replaceAll(values)
+	return nil
+}
+
+// Option function for New to verify the schema header of data decoded by GobDecode,
+// which then returns an error wrapping [schema.ErrMismatch] if the data was encoded
+// from another type of collection or value, or [schema.ErrMissing] if it has no header.
+func WithSchemaValidation[T any]() StackOptionFunc[T] {
+	return func(s *Stack[T]) {
+		s.verifySchema = true
+	}
+}
@@ -0,0 +1,93 @@
+package stack
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert Indexable implementation
+var _ collections.Indexable[int] = (*Stack[int])(nil)
+
+// At returns the value at the given position, where position 0 is the top of the stack.
+//
+// Panics if index is out of range.
+func (s *Stack[T]) At(index int) T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	util.ValidateIndex(index, s.count())
+	return s.buffer[s.bufferIndex(index)]
+}
+
+// Swap exchanges the values at the given positions, where position 0 is the top of the stack.
+//
+// Panics if either index is out of range.
+func (s *Stack[T]) Swap(i, j int) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	util.ValidateIndex(i, s.count())
+	util.ValidateIndex(j, s.count())
+	i, j = s.bufferIndex(i), s.bufferIndex(j)
+	s.buffer[i], s.buffer[j] = s.buffer[j], s.buffer[i]
+	s.version++
+	s.recorder.Reset(func() []T { return util.Reverse(s.toSlice(false)) })
+}
+
+// CompareAt compares the values at the given positions using the collection's comparer,
+// where position 0 is the top of the stack.
+//
+// Panics if either index is out of range.
+func (s *Stack[T]) CompareAt(i, j int) int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	util.ValidateIndex(i, s.count())
+	util.ValidateIndex(j, s.count())
+	return s.compare(s.buffer[s.bufferIndex(i)], s.buffer[s.bufferIndex(j)])
+}
+
+// bufferIndex converts a position to an index into the underlying buffer.
+func (s *Stack[T]) bufferIndex(index int) int {
+	if len(s.tombstones) == 0 {
+		return s.size - 1 - index
+	}
+
+	// Count down past removed values awaiting reclamation
+	for i := s.size - 1; i >= 0; i-- {
+		if s.isTombstone(i) {
+			continue
+		}
+
+		if index == 0 {
+			return i
+		}
+
+		index--
+	}
+
+	return -1
+}
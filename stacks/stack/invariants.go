@@ -0,0 +1,21 @@
+package stack
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// verifyInvariants panics if the internal state of the stack is inconsistent.
+// Called after each modification in builds with the collections_debug tag.
+func (s *Stack[T]) verifyInvariants() {
+	util.AssertInvariant(s.size >= 0 && s.size <= len(s.buffer), "stack size exceeds buffer")
+	util.AssertInvariant(s.softDelete || len(s.tombstones) == 0, "stack has tombstones without soft delete")
+	util.AssertInvariant(s.size == 0 || !s.isTombstone(s.size-1), "stack top is a tombstone")
+
+	for index := range s.tombstones {
+		util.AssertInvariant(index >= 0 && index < s.size, "stack tombstone is outside values")
+	}
+
+	if s.weigher != nil {
+		total := s.weigher.Total()
+		s.reweigh()
+		util.AssertInvariant(total == s.weigher.Total(), "stack weight does not match values")
+	}
+}
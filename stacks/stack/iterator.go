@@ -1,6 +1,8 @@
 package stack
 
 import (
+	"math/rand"
+
 	"github.com/fireflycons/generic_collections/collections"
 	"github.com/fireflycons/generic_collections/functions"
 	"github.com/fireflycons/generic_collections/internal/local"
@@ -90,6 +92,40 @@ func (s *Stack[T]) TakeWhile(predicate functions.PredicateFunc[T]) collections.I
 	return newForwardIterator(s, predicate)
 }
 
+// SampleIterator returns an iterator that yields n values chosen at random from the stack,
+// without replacement and in random order. If the stack holds no more than n values, all are yielded.
+//
+// The sample is drawn by reservoir sampling each time Start is called, without copying the stack.
+// If r is nil, the default source of the math/rand package is used.
+//
+//	iter := s.SampleIterator(100, nil)
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		// audit e.Value()
+//	}
+//
+// Panics if n is negative.
+func (s *Stack[T]) SampleIterator(n int, r *rand.Rand) collections.Iterator[T] {
+
+	return util.NewSampleIterator[T](s, n, r)
+}
+
+// SnapshotIterator returns an iterator that yields the values of the stack at the time of the call,
+// in the same order as ToSlice, so that the stack may be modified while iterating without the iterator panicking.
+//
+// The snapshot is the stack's immutable slice, so it is only copied if the stack has been modified since
+// the last snapshot was taken. Elements yielded do not belong to the stack, and their ValuePtr method panics.
+//
+//	iter := s.SnapshotIterator()
+//
+//	for e := iter.Start() ; e != nil; e = iter.Next() {
+//		s.Remove(e.Value())
+//	}
+func (s *Stack[T]) SnapshotIterator() collections.Iterator[T] {
+
+	return util.NewSnapshotIterator(s.ToImmutableSlice())
+}
+
 // Start begins iteration across the stack returning the fisrt element,
 // which will be nil if the stack is empty.
 //
@@ -105,11 +141,11 @@ func (i *StackIterator[T]) Start() collections.Element[T] {
 
 	valPtr := &i.stack.buffer[i.index]
 
-	if !i.predicate(*valPtr) {
+	if i.stack.isTombstone(i.index) || !i.predicate(*valPtr) {
 		return i.Next()
 	}
 
-	return util.NewElementType[T](i.stack, valPtr)
+	return util.NewElementType[T](i.stack, valPtr, i.stack.compare)
 }
 
 // Next returns the next element from the iterator,
@@ -128,8 +164,8 @@ func (i *StackIterator[T]) Next() collections.Element[T] {
 
 		valPtr := &i.stack.buffer[i.index]
 
-		if i.predicate(*valPtr) {
-			return util.NewElementType[T](i.stack, valPtr)
+		if !i.stack.isTombstone(i.index) && i.predicate(*valPtr) {
+			return util.NewElementType[T](i.stack, valPtr, i.stack.compare)
 		}
 	}
 }
@@ -0,0 +1,94 @@
+package stack
+
+import (
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// ApplyOps applies the given operations to the stack in order.
+// Add pushes the value, Remove removes the first occurrence of the value
+// searching from the top, and Clear empties the stack.
+//
+// Panics if an op has an invalid kind.
+func (s *Stack[T]) ApplyOps(operations []ops.Op[T]) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	util.ApplyOps(
+		operations,
+		func(value T) {
+			if s.validator.Accept(value) {
+				s.push(value)
+			}
+		},
+		func(value T) {
+			if s.size > 0 {
+				s.remove(value)
+			}
+		},
+		s.clear,
+	)
+}
+
+// StartRecording begins capturing mutations of the stack as ops,
+// discarding any ops previously recorded.
+//
+// Popped values are recorded as a Remove of the value, which when replayed
+// removes the value at the top of the stack. Sorting and swapping values
+// are recorded as a Clear followed by an Add of each value from the bottom of the stack.
+func (s *Stack[T]) StartRecording() {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	s.recorder.Start(s.copy)
+}
+
+// StopRecording ends recording and returns the ops recorded since StartRecording.
+func (s *Stack[T]) StopRecording() []ops.Op[T] {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	return s.recorder.Stop()
+}
+
+// IsRecording returns true if the stack is recording mutations.
+func (s *Stack[T]) IsRecording() bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.recorder.IsRecording()
+}
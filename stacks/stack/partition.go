@@ -0,0 +1,119 @@
+package stack
+
+import (
+	"sync"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// PartitionInPlace reorders the stack so that all values matching the predicate
+// are nearer the top than those that do not, and returns the number of matching values.
+//
+// After the call, positions 0 to split-1 (counting from the top of the stack) hold the matching values
+// in their original relative order, followed by the non-matching values in their original relative order.
+// O(n), using a buffer for the non-matching values.
+func (s *Stack[T]) PartitionInPlace(predicate functions.PredicateFunc[T]) int {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.purge()
+
+	// Matching values are moved forward in place, and the values they pass over
+	// are held aside to be copied back after them, so that both keep their order.
+	split := 0
+	moved := false
+	var rest []T
+
+	for i := 0; i < s.size; i++ {
+		value := s.buffer[s.bufferIndex(i)]
+
+		if !predicate(value) {
+			rest = append(rest, value)
+			continue
+		}
+
+		if i != split {
+			s.buffer[s.bufferIndex(split)] = value
+			moved = true
+		}
+
+		split++
+	}
+
+	if moved {
+		for i, value := range rest {
+			s.buffer[s.bufferIndex(split+i)] = value
+		}
+
+		s.version++
+		s.recorder.Reset(func() []T { return util.Reverse(s.toSlice(false)) })
+	}
+
+	return split
+}
+
+// ExtractWhere removes all values matching the predicate from the stack in a single pass,
+// and returns them as a new stack in their original relative order.
+func (s *Stack[T]) ExtractWhere(predicate functions.PredicateFunc[T]) *Stack[T] {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.purge()
+
+	extracted := s.makeEmptyCopy()
+	kept := 0
+
+	// Work from the bottom of the stack so that the extracted values
+	// are pushed in their original order.
+	for i := 0; i < s.size; i++ {
+		if predicate(s.buffer[i]) {
+			extracted.push(s.buffer[i])
+		} else {
+			s.buffer[kept] = s.buffer[i]
+			kept++
+		}
+	}
+
+	if extracted.size == 0 {
+		return extracted
+	}
+
+	var empty T
+	for i := kept; i < s.size; i++ {
+		s.buffer[i] = empty
+	}
+
+	s.size = kept
+	s.version++
+	s.recorder.Reset(func() []T { return util.Reverse(s.toSlice(false)) })
+	s.reweigh()
+	return extracted
+}
+
+func (s *Stack[T]) makeEmptyCopy() *Stack[T] {
+	other := New[T](WithComparer[T](s.compare), WithDeepCopy[T](s.copy))
+
+	if s.lock != nil {
+		other.lock = &sync.RWMutex{}
+	}
+
+	other.concurrent = s.concurrent
+	return other
+}
@@ -0,0 +1,67 @@
+package stack
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
+)
+
+// Option function for New to give the stack a name, under which it is registered with the
+// [registry] so that its size, version and operation counts are included in [registry.Snapshot].
+// The stack remains registered until Dispose is called.
+//
+// Panics if name is empty.
+func WithName[T any](name string) StackOptionFunc[T] {
+	if name == "" {
+		panic(messages.NAME_EMPTY)
+	}
+	return func(s *Stack[T]) {
+		s.name = name
+	}
+}
+
+// Dispose removes the stack from the [registry] if it was created with [WithName].
+// The stack remains usable, but no longer appears in [registry.Snapshot].
+// Calling Dispose more than once has no effect.
+func (s *Stack[T]) Dispose() {
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.registration.Deregister()
+	s.registration = nil
+}
+
+// register registers the stack with the registry if it has been given a name.
+func (s *Stack[T]) register() {
+	if s.name == "" {
+		return
+	}
+
+	s.recorder.EnableCounting()
+	s.registration = registry.Register(s.name, s.stats)
+}
+
+func (s *Stack[T]) stats() registry.Stats {
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return registry.Stats{
+		Type:    collections.COLLECTION_STACK,
+		Count:   s.count(),
+		Version: s.version,
+		Ops:     s.recorder.Counts(),
+	}
+}
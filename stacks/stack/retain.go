@@ -0,0 +1,62 @@
+package stack
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.Retainer[int] = (*Stack[int])(nil)
+
+// RetainWhere removes the values for which the predicate returns false in a single pass,
+// keeping the remaining values in their order, and returns the number of values removed.
+func (s *Stack[T]) RetainWhere(predicate functions.PredicateFunc[T]) int {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.purge()
+
+	kept := 0
+
+	for i := 0; i < s.size; i++ {
+		if predicate(s.buffer[i]) {
+			s.buffer[kept] = s.buffer[i]
+			kept++
+		}
+	}
+
+	removed := s.size - kept
+
+	if removed == 0 {
+		return 0
+	}
+
+	var empty T
+	for i := kept; i < s.size; i++ {
+		s.buffer[i] = empty
+	}
+
+	s.size = kept
+	s.version++
+	s.recorder.Reset(func() []T { return util.Reverse(s.toSlice(false)) })
+	s.reweigh()
+	return removed
+}
+
+// RetainAll removes the values that other does not contain in a single pass,
+// and returns the number of values removed. See [collections.Retainer].
+//
+// Panics if other is nil.
+func (s *Stack[T]) RetainAll(other collections.Collection[T]) int {
+
+	return util.RetainAll[T](s, other, s.RetainWhere)
+}
@@ -0,0 +1,147 @@
+package stack
+
+import "github.com/fireflycons/generic_collections/internal/util"
+
+// Option function for New to remove values from the middle of the stack by marking them as deleted,
+// rather than by reallocating the buffer to close the gap.
+//
+// Remove is then O(1) once the value has been found, and never allocates. A deleted value is
+// hidden from all other methods, and its slot in the buffer is reclaimed when it reaches the top
+// of the stack, when [Stack.Compact] is called, or when an operation that already visits every
+// value, such as Sort, ExtractWhere or TrimExcess, is performed. While slots are awaiting reclamation,
+// positional methods such as At and IndexOf are O(n).
+func WithSoftDelete[T any]() StackOptionFunc[T] {
+	return func(s *Stack[T]) {
+		s.softDelete = true
+	}
+}
+
+// Compact reclaims the slots of values removed from a stack created with [WithSoftDelete],
+// moving the remaining values down the buffer in a single pass. The order of the values and
+// the capacity of the buffer are unchanged.
+//
+// Call at a time of your choosing, e.g. when [Stack.Tombstones] exceeds a threshold, so that the
+// cost is not incurred on a latency-sensitive path.
+//
+// O(n). Has no effect if there are no slots to reclaim.
+func (s *Stack[T]) Compact() {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.purge()
+}
+
+// Tombstones returns the number of values removed from a stack created with [WithSoftDelete]
+// whose slots have not yet been reclaimed.
+func (s *Stack[T]) Tombstones() int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return len(s.tombstones)
+}
+
+// isTombstone returns true if the value at the given buffer index has been removed.
+func (s *Stack[T]) isTombstone(index int) bool {
+	if len(s.tombstones) == 0 {
+		return false
+	}
+
+	_, ok := s.tombstones[index]
+	return ok
+}
+
+// markTombstone removes the value at the given buffer index without moving the values above it.
+func (s *Stack[T]) markTombstone(index int) {
+	if s.tombstones == nil {
+		s.tombstones = make(map[int]struct{})
+	}
+
+	var empty T
+	s.buffer[index] = empty
+	s.tombstones[index] = struct{}{}
+	s.trimTombstones()
+}
+
+// trimTombstones reclaims the slots of removed values at the top of the stack,
+// so that the top of the stack is always a value.
+func (s *Stack[T]) trimTombstones() {
+	for s.size > 0 && s.isTombstone(s.size-1) {
+		delete(s.tombstones, s.size-1)
+		s.size--
+	}
+}
+
+// purge reclaims the slots of all removed values.
+func (s *Stack[T]) purge() {
+	if len(s.tombstones) == 0 {
+		return
+	}
+
+	kept := 0
+
+	for i := 0; i < s.size; i++ {
+		if !s.isTombstone(i) {
+			s.buffer[kept] = s.buffer[i]
+			kept++
+		}
+	}
+
+	var empty T
+	for i := kept; i < s.size; i++ {
+		s.buffer[i] = empty
+	}
+
+	s.size = kept
+	s.tombstones = nil
+	s.version++
+}
+
+// values returns the values of the stack from the bottom up. If there are no removed values
+// awaiting reclamation, the result shares the buffer.
+func (s *Stack[T]) values() []T {
+	if len(s.tombstones) == 0 {
+		return s.buffer[:s.size]
+	}
+
+	values := make([]T, 0, s.size-len(s.tombstones))
+
+	for i := 0; i < s.size; i++ {
+		if !s.isTombstone(i) {
+			values = append(values, s.buffer[i])
+		}
+	}
+
+	return values
+}
+
+// position converts an index into the underlying buffer to a position, where position 0 is the top of the stack.
+func (s *Stack[T]) position(index int) int {
+	position := s.size - 1 - index
+
+	if len(s.tombstones) == 0 {
+		return position
+	}
+
+	for i := index + 1; i < s.size; i++ {
+		if s.isTombstone(i) {
+			position--
+		}
+	}
+
+	return position
+}
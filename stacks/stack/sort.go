@@ -1,8 +1,13 @@
 package stack
 
 import (
+	"fmt"
+
 	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
+	"golang.org/x/exp/constraints"
 )
 
 // Sort performs an in-place sort of this collection.
@@ -15,6 +20,10 @@ func (s *Stack[T]) Sort() {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	// Stack is a reverse-ordered slice
 	s.doSort(util.GosortDescending[T])
 }
@@ -27,6 +36,10 @@ func (s *Stack[T]) Sorted() collections.Collection[T] {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	s1 := s.makeDeepCopy()
 
 	if s1.size > 1 {
@@ -46,6 +59,10 @@ func (s *Stack[T]) SortDescending() {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	s.doSort(util.Gosort[T])
 }
 
@@ -57,6 +74,10 @@ func (s *Stack[T]) SortedDescending() collections.Collection[T] {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	s1 := s.makeDeepCopy()
 
 	if s1.size > 1 {
@@ -66,9 +87,97 @@ func (s *Stack[T]) SortedDescending() collections.Collection[T] {
 	return s1
 }
 
+// SortBy performs an in-place sort of this collection with respect to the given comparer,
+// rather than the comparer of the stack, which is unchanged.
+//
+// The item that comparer orders first will be placed at the top of the stack.
+//
+// Panics if comparer is nil.
+func (s *Stack[T]) SortBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	// Stack is a reverse-ordered slice
+	s.sortWith(util.CompareSort(util.GosortDescending[T], comparer))
+}
+
+// SortStable performs an in-place sort of this collection as per Sort,
+// except that values which compare equal keep their relative order.
+func (s *Stack[T]) SortStable() {
+
+	s.sortWith(util.GosortStableDescending[T])
+}
+
+// SortStableBy performs an in-place sort of this collection as per [Stack.SortBy],
+// except that values which compare equal keep their relative order.
+//
+// Panics if comparer is nil.
+func (s *Stack[T]) SortStableBy(comparer functions.ComparerFunc[T]) {
+
+	if comparer == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "comparer"))
+	}
+
+	s.sortWith(util.CompareSort(util.GosortStableDescending[T], comparer))
+}
+
+// sortWith sorts the stack with f under the write lock.
+func (s *Stack[T]) sortWith(f util.SortFunc[T]) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.doSort(f)
+}
+
 func (s *Stack[T]) doSort(f util.SortFunc[T]) {
 
+	s.purge()
+
 	// bottom of stack (largest value ofter sorting) is at front of slice
 	f(s.buffer, s.size, s.compare)
 	s.version++
+	s.recorder.Reset(func() []T { return util.Reverse(s.toSlice(false)) })
+}
+
+// SortByKey performs an in-place sort of the stack by the keys computed for its values by key.
+// The value with the smallest key will be placed at the top of the stack.
+//
+// Each key is computed once before sorting, so where comparing values is expensive, e.g. comparing strings
+// with collation, sorting by a cheaply compared key derived from each value is much faster than Sort.
+func SortByKey[T any, K constraints.Ordered](s *Stack[T], key func(T) K) {
+
+	sortByKey(s, key, false)
+}
+
+// SortByKeyDescending is as [SortByKey], placing the value with the largest key at the top of the stack.
+func SortByKeyDescending[T any, K constraints.Ordered](s *Stack[T], key func(T) K) {
+
+	sortByKey(s, key, true)
+}
+
+func sortByKey[T any, K constraints.Ordered](s *Stack[T], key func(T) K, descending bool) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	// Stack is a reverse-ordered slice
+	s.doSort(util.KeySort(key, util.GetOrderedComparer[K](), !descending))
 }
@@ -13,7 +13,9 @@ import (
 	"github.com/fireflycons/generic_collections/internal/local"
 	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/registry"
 	"github.com/fireflycons/generic_collections/stacks"
+	"golang.org/x/exp/constraints"
 )
 
 // Assert Stack implements required interfaces.
@@ -28,6 +30,8 @@ const (
 type StackOptionFunc[T any] func(*Stack[T])
 
 // Stack implements a last-in, first-out collection.
+//
+// The zero value is an empty stack with default options, ready to use. It is not thread-safe.
 type Stack[T any] struct {
 	version         int
 	lock            *sync.RWMutex
@@ -35,8 +39,19 @@ type Stack[T any] struct {
 	initialCapacity int
 	compare         functions.ComparerFunc[T]
 	copy            functions.DeepCopyFunc[T]
+	copyPolicy      collections.CopyPolicy
 	buffer          []T
 	concurrent      bool
+	recorder        util.OpRecorder[T]
+	guard           util.AccessGuard
+	immutable       util.SliceCache[T]
+	weigher         *util.Weigher[T]
+	validator       *util.Validator[T]
+	verifySchema    bool
+	softDelete      bool
+	tombstones      map[int]struct{}
+	name            string
+	registration    *registry.Registration
 
 	local.InternalImpl
 }
@@ -52,7 +67,7 @@ func New[T any](options ...StackOptionFunc[T]) *Stack[T] {
 
 	stack.buffer = make([]T, stack.initialCapacity)
 
-	if stack.copy == nil {
+	if stack.copy == nil || stack.copyPolicy == collections.Shallow {
 		stack.copy = util.DefaultDeepCopy[T]
 	}
 
@@ -60,9 +75,60 @@ func New[T any](options ...StackOptionFunc[T]) *Stack[T] {
 		stack.compare = util.GetDefaultComparer[T]()
 	}
 
+	stack.register()
+
 	return stack
 }
 
+// lazyInit completes the construction of a zero value Stack on its first modification,
+// as New would have done.
+func (s *Stack[T]) lazyInit() {
+	if s.compare != nil {
+		return
+	}
+
+	s.compare = util.GetZeroValueComparer[T]()
+
+	if s.copy == nil {
+		s.copy = util.DefaultDeepCopy[T]
+	}
+
+	if s.buffer == nil {
+		s.initialCapacity = util.DefaultCapacity
+		s.buffer = make([]T, s.initialCapacity)
+	}
+}
+
+// Of constructs a new stack containing the given values, e.g. stack.Of(1, 2, 3).
+//
+// The values are pushed in order, so the last value is at the top of the stack.
+func Of[T any](values ...T) *Stack[T] {
+	return OfWith(nil, values...)
+}
+
+// OfWith is as [Of], applying the given constructor options.
+// As a variadic parameter must come last, the options are passed as a slice, e.g.
+//
+//	s := stack.OfWith([]stack.StackOptionFunc[int]{stack.WithThreadSafe[int]()}, 1, 2, 3)
+func OfWith[T any](options []StackOptionFunc[T], values ...T) *Stack[T] {
+	s := New(options...)
+	s.AddRange(values)
+	return s
+}
+
+// NewFunc is as [New], using compare to find values, e.g. in Contains and Sort.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...StackOptionFunc[T]) *Stack[T] {
+	return New(append([]StackOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...StackOptionFunc[T]) *Stack[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
 // Option function for New to make the collection thread-safe. Adds overhead.
 func WithThreadSafe[T any]() StackOptionFunc[T] {
 	return func(s *Stack[T]) {
@@ -107,12 +173,88 @@ func WithDeepCopy[T any](copier functions.DeepCopyFunc[T]) StackOptionFunc[T] {
 	}
 }
 
+// Option func to determine when values are deep copied. The default is [collections.Deep].
+func WithCopyPolicy[T any](policy collections.CopyPolicy) StackOptionFunc[T] {
+	return func(s *Stack[T]) {
+		s.copyPolicy = policy
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+func WithOptions[T any](options collections.CommonOptions[T]) StackOptionFunc[T] {
+	opts := make([]StackOptionFunc[T], 0, 6)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Concurrent {
+		opts = append(opts, WithConcurrent[T]())
+	}
+
+	if options.Capacity != 0 {
+		opts = append(opts, WithCapacity[T](options.Capacity))
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	if options.DeepCopy != nil {
+		opts = append(opts, WithDeepCopy(options.DeepCopy))
+	}
+
+	if options.CopyPolicy != collections.Deep {
+		opts = append(opts, WithCopyPolicy[T](options.CopyPolicy))
+	}
+
+	if options.Validator != nil {
+		opts = append(opts, WithValidator(options.Validator, options.ValidationPolicy))
+	}
+
+	return func(s *Stack[T]) {
+		for _, o := range opts {
+			o(s)
+		}
+	}
+}
+
+// Option function for New to check each value inserted by Add, AddRange, AddCollection,
+// ReplaceAll, Push and Offer with the given validator. Values it rejects
+// are dealt with according to policy, see [collections.ValidationPolicy].
+//
+// Panics if validator is nil.
+func WithValidator[T any](validator functions.ValidatorFunc[T], policy collections.ValidationPolicy) StackOptionFunc[T] {
+	if validator == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "validator"))
+	}
+	return func(s *Stack[T]) {
+		s.validator = util.NewValidator(validator, policy)
+	}
+}
+
 // Add is an alias for [stack.Push].
 //
-// Always returns true.
+// Returns true unless the value was rejected by the validator.
 func (s *Stack[T]) Add(value T) bool {
 
-	s.Push(value)
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if !s.validator.Accept(value) {
+		return false
+	}
+
+	s.push(value)
 	return true
 }
 
@@ -135,6 +277,26 @@ func (s *Stack[T]) AddRange(values []T) {
 		defer s.lock.Unlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if values = s.validator.Filter(values); len(values) == 0 {
+		return
+	}
+
+	lv = len(values)
+
+	for _, v := range values {
+		s.recorder.Add(v)
+
+		if s.weigher != nil {
+			s.weigher.Adjust(s.weigher.Weigh(v))
+		}
+	}
+
 	newSize := s.size + lv
 	newCapacity := util.Iif(newSize > s.initialCapacity, newSize, s.initialCapacity)
 	newBuffer := make([]T, newCapacity)
@@ -156,21 +318,116 @@ func (s *Stack[T]) AddRange(values []T) {
 // Values are pushed in the order defined by the other collection.
 func (s *Stack[T]) AddCollection(collection collections.Collection[T]) {
 
-	s.AddRange(collection.ToSliceDeep())
+	s.AddRange(util.ImportValues(collection, s.copyPolicy))
+}
+
+// ReplaceAll replaces the content of the stack with the values of the given collection.
+// Values are pushed in the order defined by the other collection.
+func (s *Stack[T]) ReplaceAll(collection collections.Collection[T]) {
+
+	s.replaceAll(util.ImportValues(collection, s.copyPolicy))
+}
+
+// replaceAll replaces the values of the stack with the given values under a single lock.
+func (s *Stack[T]) replaceAll(values []T) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	values = s.validator.Filter(values)
+
+	if len(values) > len(s.buffer) {
+		s.buffer = make([]T, len(values))
+	} else {
+		var empty T
+		for i := range s.buffer {
+			s.buffer[i] = empty
+		}
+	}
+
+	copy(s.buffer, values)
+	s.size = len(values)
+	s.tombstones = nil
+	s.version++
+	s.recorder.Reset(func() []T { return values })
+	s.reweigh()
 }
 
-// Contains returns true if the stack contains the given value
+// Contains returns true if the stack contains the given value,
+// according to the comparer function of the stack.
 //
 // Stack is searched from most recently pushed value downwards.
 func (s *Stack[T]) Contains(value T) bool {
 
-	return s.size != 0 && util.LastIndexOf(s.buffer, value, s.compare, s.concurrent) != -1
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	return s.lastIndexOf(value) != -1
+}
+
+// ContainsFunc returns true if the stack contains a value for which predicate returns true.
+//
+// Stack is searched from most recently pushed value downwards, stopping at the first match.
+func (s *Stack[T]) ContainsFunc(predicate functions.PredicateFunc[T]) bool {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	for i := s.size - 1; i >= 0; i-- {
+		if !s.isTombstone(i) && predicate(s.buffer[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IndexOf returns the depth from the top of the stack of the most recently pushed occurrence
+// of the given value, where 0 is the top of the stack, or -1 if the value is not in the stack.
+// The result may be passed to At.
+func (s *Stack[T]) IndexOf(value T) int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	index := s.lastIndexOf(value)
+
+	if index == -1 {
+		return -1
+	}
+
+	return s.position(index)
 }
 
 // Count returns the number of values on the stack.
 func (s *Stack[T]) Count() int {
 
-	return s.size
+	return s.count()
 }
 
 // IsEmpty returns true if the collection has no elements.
@@ -187,9 +444,45 @@ func (s *Stack[T]) ToSlice() []T {
 		s.lock.RLock()
 		defer s.lock.RUnlock()
 	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
 	return s.toSlice(false)
 }
 
+// ToImmutableSlice returns the content of the stack as a slice, in the same order as ToSlice.
+//
+// The slice is cached, and is only recomputed when the stack has been modified, so repeated calls
+// between modifications return the same backing array at no cost. The slice is shared by all callers
+// and must not be modified. Values modified in place, e.g. via [collections.Element.ValuePtr],
+// are not reflected until the stack is otherwise modified.
+func (s *Stack[T]) ToImmutableSlice() []T {
+
+	if s.lock != nil {
+		// A cached slice is served under the read lock, so that readers do not block one another.
+		// The write lock is taken only to recompute it.
+		s.lock.RLock()
+		values, ok := s.immutable.Load(s.version)
+		s.lock.RUnlock()
+
+		if ok {
+			return values
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	return s.immutable.Get(s.version, func() []T { return s.toSlice(false) })
+}
+
 // ToSliceDeep returns a copy of the stack content as a slice.
 // The slice is ordered from top to bottom of the stack
 // (most recently pushed value is first in the slice).
@@ -201,16 +494,21 @@ func (s *Stack[T]) ToSliceDeep() []T {
 		s.lock.RLock()
 		defer s.lock.RUnlock()
 	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
 	return s.toSlice(true)
 }
 
 func (s *Stack[T]) toSlice(deepCopy bool) []T {
-	slc := make([]T, s.size)
+	values := s.values()
+	slc := make([]T, len(values))
 
 	if deepCopy {
-		util.DeepCopySlice(slc, s.buffer[:s.size], s.copy)
+		util.DeepCopySlice(slc, values, s.copy)
 	} else {
-		copy(slc, s.buffer[:s.size])
+		copy(slc, values)
 	}
 
 	return util.Reverse(slc)
@@ -223,9 +521,13 @@ func (s *Stack[T]) Clear() {
 		s.lock.Lock()
 		defer s.lock.Unlock()
 	}
-	s.buffer = make([]T, 0, cap(s.buffer))
-	s.size = 0
-	s.version++
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.clear()
 }
 
 // Peek returns the value at the top of the stack without adjusting the stack.
@@ -237,6 +539,10 @@ func (s *Stack[T]) Peek() T {
 		s.lock.RLock()
 		defer s.lock.RUnlock()
 	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
 	if s.size == 0 {
 		panic(messages.COLLECTION_EMPTY)
 	}
@@ -253,6 +559,10 @@ func (s *Stack[T]) TryPeek() (T, bool) {
 		defer s.lock.RUnlock()
 	}
 
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
 	if s.size == 0 {
 		var empty T
 		return empty, false
@@ -268,7 +578,16 @@ func (s *Stack[T]) Push(value T) {
 		s.lock.Lock()
 		defer s.lock.Unlock()
 	}
-	s.push(value)
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if s.validator.Accept(value) {
+		s.push(value)
+	}
 }
 
 // Pop removes and returns the value at the top of the stack.
@@ -280,6 +599,12 @@ func (s *Stack[T]) Pop() T {
 		s.lock.Lock()
 		defer s.lock.Unlock()
 	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
 	return s.pop()
 }
 
@@ -291,6 +616,12 @@ func (s *Stack[T]) TryPop() (T, bool) {
 		s.lock.Lock()
 		defer s.lock.Unlock()
 	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
 	if s.size == 0 {
 		var empty T
 		return empty, false
@@ -299,14 +630,88 @@ func (s *Stack[T]) TryPop() (T, bool) {
 	return s.pop(), true
 }
 
+// PopN removes and returns the n values at the top of the stack, in the order
+// that n calls to Pop would return them, i.e. the top value first.
+//
+// The values are removed under a single acquisition of the lock of a thread-safe stack.
+//
+// Panics if n is negative or greater than the number of values on the stack.
+func (s *Stack[T]) PopN(n int) []T {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if n < 0 || n > s.count() {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	values := make([]T, n)
+
+	for i := range values {
+		values[i] = s.take()
+	}
+
+	if n > 0 {
+		s.version++
+	}
+
+	return values
+}
+
+// PeekN returns the n values at the top of the stack without removing them, the top value first,
+// so that the value at index i of the result is that returned by At(i).
+//
+// Panics if n is negative or greater than the number of values on the stack.
+func (s *Stack[T]) PeekN(n int) []T {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if n < 0 || n > s.count() {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	values := make([]T, 0, n)
+
+	for i := s.size - 1; len(values) < n; i-- {
+		if !s.isTombstone(i) {
+			values = append(values, s.buffer[i])
+		}
+	}
+
+	return values
+}
+
 // TrimExcess resizes the backing store's length and capacity
-// to match the number of elements in the stack.
+// to match the number of elements in the stack, first reclaiming
+// the slots of any values removed from a stack created with [WithSoftDelete].
 func (s *Stack[T]) TrimExcess() {
 
 	if s.lock != nil {
 		s.lock.Lock()
 		defer s.lock.Unlock()
 	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+	s.purge()
 	slc := make([]T, s.size)
 	copy(slc, s.buffer[:s.size])
 	s.buffer = slc
@@ -315,6 +720,9 @@ func (s *Stack[T]) TrimExcess() {
 // Remove removes the first occurrence of value found, searching
 // from the most recently pushed value.
 //
+// By default the values above it are moved down in a new buffer.
+// If the stack was created with [WithSoftDelete], the value is instead marked as deleted in place.
+//
 // Returns true if the value was removed.
 func (s *Stack[T]) Remove(value T) bool {
 
@@ -326,22 +734,14 @@ func (s *Stack[T]) Remove(value T) bool {
 		s.lock.Lock()
 		defer s.lock.Unlock()
 	}
-	index := util.LastIndexOf(s.buffer, value, s.compare, s.concurrent)
 
-	if index == -1 {
-		return false
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
 	}
 
-	var empty T
-	s.buffer[index] = empty
+	s.lazyInit()
 
-	buf := make([]T, len(s.buffer)-1)
-	util.PartialCopy(s.buffer, 0, buf, 0, index)
-	util.PartialCopy(s.buffer, index+1, buf, index, len(s.buffer)-(index+1))
-	s.buffer = buf
-	s.version++
-	s.size--
-	return true
+	return s.remove(value)
 }
 
 // String returns a string representation of container.
@@ -351,6 +751,10 @@ func (s *Stack[T]) String() string {
 		s.lock.RLock()
 		defer s.lock.RUnlock()
 	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
 	var values []string
 	for _, value := range s.toSlice(false) {
 		values = append(values, fmt.Sprintf("%v", value))
@@ -381,6 +785,11 @@ func (s *Stack[T]) push(value T) {
 	s.buffer[s.size] = value
 	s.version++
 	s.size++
+	s.recorder.Add(value)
+
+	if s.weigher != nil {
+		s.weigher.Adjust(s.weigher.Weigh(value))
+	}
 }
 
 func (s *Stack[T]) pop() T {
@@ -389,15 +798,34 @@ func (s *Stack[T]) pop() T {
 		panic(messages.COLLECTION_EMPTY)
 	}
 
+	value := s.take()
+	s.version++
+	return value
+}
+
+// take removes and returns the value at the top of the stack, which must not be empty,
+// without incrementing the version.
+func (s *Stack[T]) take() T {
+
 	var empty T
 	value := s.buffer[s.size-1]
 	s.buffer[s.size-1] = empty
 	s.size--
-	s.version++
+	s.recorder.Remove(value)
 
+	if s.weigher != nil {
+		s.weigher.Adjust(-s.weigher.Weigh(value))
+	}
+
+	s.trimTombstones()
 	return value
 }
 
+// count returns the number of values on the stack, excluding removed values awaiting reclamation.
+func (s *Stack[T]) count() int {
+	return s.size - len(s.tombstones)
+}
+
 func (s *Stack[T]) length() int {
 	return len(s.buffer)
 }
@@ -413,6 +841,7 @@ func (s *Stack[T]) makeDeepCopy() *Stack[T] {
 		initialCapacity: s.initialCapacity,
 		compare:         s.compare,
 		copy:            s.copy,
+		copyPolicy:      s.copyPolicy,
 	}
 
 	if s.lock != nil {
@@ -421,5 +850,75 @@ func (s *Stack[T]) makeDeepCopy() *Stack[T] {
 
 	other.buffer = make([]T, len(s.buffer), cap(s.buffer))
 	util.DeepCopySlice(other.buffer, s.buffer, s.copy)
+
+	if len(s.tombstones) != 0 {
+		other.tombstones = make(map[int]struct{}, len(s.tombstones))
+
+		for index := range s.tombstones {
+			other.tombstones[index] = struct{}{}
+		}
+
+		other.purge()
+	}
+
 	return other
 }
+
+// lastIndexOf returns the buffer index of the most recently pushed occurrence of value, or -1.
+// Only the occupied part of the buffer is searched.
+func (s *Stack[T]) lastIndexOf(value T) int {
+	if len(s.tombstones) == 0 {
+		return util.LastIndexOf(s.buffer[:s.size], value, s.compare, s.concurrent)
+	}
+
+	for i := s.size - 1; i >= 0; i-- {
+		if !s.isTombstone(i) && s.compare(s.buffer[i], value) == 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Stack[T]) remove(value T) bool {
+	index := s.lastIndexOf(value)
+
+	if index == -1 {
+		return false
+	}
+
+	if s.weigher != nil {
+		s.weigher.Adjust(-s.weigher.Weigh(s.buffer[index]))
+	}
+
+	if s.softDelete {
+		s.markTombstone(index)
+		s.version++
+		s.recorder.Remove(value)
+		return true
+	}
+
+	var empty T
+	s.buffer[index] = empty
+
+	buf := make([]T, len(s.buffer)-1)
+	util.PartialCopy(s.buffer, 0, buf, 0, index)
+	util.PartialCopy(s.buffer, index+1, buf, index, len(s.buffer)-(index+1))
+	s.buffer = buf
+	s.version++
+	s.size--
+	s.recorder.Remove(value)
+	return true
+}
+
+func (s *Stack[T]) clear() {
+	s.buffer = make([]T, 0, cap(s.buffer))
+	s.size = 0
+	s.tombstones = nil
+	s.version++
+	s.recorder.Clear()
+
+	if s.weigher != nil {
+		s.weigher.Reset(0, nil)
+	}
+}
@@ -1,12 +1,20 @@
 package stack
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
 	"testing"
 
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/collectiontest"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
 	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/ops"
 	"github.com/stretchr/testify/require"
 )
 
@@ -87,6 +95,72 @@ func TestContainsAllElements(t *testing.T) {
 	}
 }
 
+func TestContainsRespectsSize(t *testing.T) {
+
+	t.Run("Unused capacity is not searched", func(t *testing.T) {
+		stack := New(WithCapacity[int](20))
+		stack.Push(1)
+		stack.Push(2)
+
+		require.False(t, stack.Contains(0))
+		require.False(t, stack.Remove(0))
+		require.Equal(t, -1, stack.IndexOf(0))
+		require.Equal(t, 2, stack.Count())
+	})
+
+	t.Run("Popped values are not found", func(t *testing.T) {
+		stack := Of(1, 2, 3)
+		stack.Pop()
+
+		require.False(t, stack.Contains(3))
+	})
+
+	t.Run("Concurrent search of large stack", func(t *testing.T) {
+		stack := New(WithConcurrent[int](), WithCapacity[int](1<<18))
+
+		for i := 1; i <= 1<<17; i++ {
+			stack.Push(i)
+		}
+
+		require.False(t, stack.Contains(0))
+		require.True(t, stack.Contains(1))
+		require.Equal(t, (1<<17)-1, stack.IndexOf(1))
+	})
+
+	t.Run("Custom comparer", func(t *testing.T) {
+		caseInsensitive := func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		}
+		stack := OfWith([]StackOptionFunc[string]{WithComparer(caseInsensitive)}, "Alice", "Bob")
+
+		require.True(t, stack.Contains("ALICE"))
+		require.Equal(t, 0, stack.IndexOf("bob"))
+	})
+}
+
+func TestContainsFunc(t *testing.T) {
+	stack := Of(1, 2, 3, 4)
+	visited := []int{}
+
+	require.True(t, stack.ContainsFunc(func(v int) bool {
+		visited = append(visited, v)
+		return v%2 == 1
+	}))
+	require.Equal(t, []int{4, 3}, visited)
+	require.False(t, stack.ContainsFunc(func(v int) bool { return v > 4 }))
+	require.False(t, New[int]().ContainsFunc(func(int) bool { return true }))
+}
+
+func TestIndexOf(t *testing.T) {
+	stack := Of(1, 2, 3, 2)
+
+	require.Equal(t, 0, stack.IndexOf(2))
+	require.Equal(t, 1, stack.IndexOf(3))
+	require.Equal(t, 3, stack.IndexOf(1))
+	require.Equal(t, -1, stack.IndexOf(5))
+	require.Equal(t, 3, stack.At(stack.IndexOf(3)))
+}
+
 func TestPeekOnEmptyStackPanics(t *testing.T) {
 	// Should not matter how we size it
 	stack := New(WithCapacity[int](20))
@@ -267,6 +341,31 @@ func TestTrimExcess(t *testing.T) {
 	require.Equal(t, stack.capacity(), originalSize-shrinkBy)
 }
 
+func TestReplaceAll(t *testing.T) {
+
+	t.Run("Smaller collection reuses buffer", func(t *testing.T) {
+		stack := generateIntStack(20)
+		bufferLength := stack.length()
+		other := dlist.New[int]()
+		other.AddRange([]int{1, 2, 3})
+		stack.ReplaceAll(other)
+		require.Equal(t, bufferLength, stack.length())
+		verifyStackState(t, stack, []int{3, 2, 1})
+		require.Equal(t, 3, stack.Pop())
+	})
+
+	t.Run("Larger collection grows buffer", func(t *testing.T) {
+		stack := New(WithCapacity[int](2))
+		stack.Push(10)
+		other := dlist.New[int]()
+		other.AddRange([]int{1, 2, 3, 4, 5})
+		stack.ReplaceAll(other)
+		verifyStackState(t, stack, []int{5, 4, 3, 2, 1})
+		stack.Push(6)
+		require.Equal(t, 6, stack.Count())
+	})
+}
+
 func TestTryStackOperations(t *testing.T) {
 
 	seed := int64(2163)
@@ -542,3 +641,595 @@ func BenchmarkStack(b *testing.B) {
 	})
 
 }
+
+func TestIndexable(t *testing.T) {
+
+	t.Run("At returns values from top of stack", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		require.Equal(t, 3, s.At(0))
+		require.Equal(t, 1, s.At(2))
+	})
+
+	t.Run("Swap exchanges values", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		s.Swap(0, 2)
+		require.Equal(t, []int{1, 2, 3}, s.ToSlice())
+	})
+
+	t.Run("CompareAt compares values", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		require.Greater(t, s.CompareAt(0, 1), 0)
+		require.Less(t, s.CompareAt(2, 1), 0)
+		require.Equal(t, 0, s.CompareAt(1, 1))
+	})
+
+	t.Run("Index out of range panics", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		require.Panics(t, func() { s.At(3) })
+		require.Panics(t, func() { s.At(-1) })
+		require.Panics(t, func() { s.Swap(0, 3) })
+		require.Panics(t, func() { s.CompareAt(3, 0) })
+	})
+}
+
+func TestApplyOps(t *testing.T) {
+
+	t.Run("Script is applied in order", func(t *testing.T) {
+		s := New[int]()
+		s.Push(10)
+		s.ApplyOps([]ops.Op[int]{
+			ops.Clear[int](),
+			ops.Add(1),
+			ops.Add(2),
+			ops.Add(1),
+			ops.Remove(1),
+			ops.Remove(5),
+		})
+
+		require.Equal(t, []int{2, 1}, s.ToSlice())
+	})
+
+	t.Run("Invalid op kind panics", func(t *testing.T) {
+		s := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.OP_KIND_INVALID_FMT, 9), func() {
+			s.ApplyOps([]ops.Op[int]{ops.Add(1), {Kind: 9}})
+		})
+		require.Equal(t, []int{1}, s.ToSlice())
+	})
+}
+
+func TestRecording(t *testing.T) {
+
+	t.Run("Replaying recorded ops reproduces stack", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{9, 8})
+		s.StartRecording()
+		require.True(t, s.IsRecording())
+
+		for i := 0; i < 20; i++ {
+			s.Push(i)
+		}
+		s.AddRange([]int{2, 3, 2})
+		s.Pop()
+		s.TryPop()
+		s.Remove(2)
+		s.Swap(0, 3)
+		s.Sort()
+		s.Pop()
+		s.ReplaceAll(New[int]())
+		s.Add(5)
+
+		recorded := s.StopRecording()
+		require.False(t, s.IsRecording())
+
+		replica := New[int]()
+		replica.AddRange([]int{9, 8})
+		replica.ApplyOps(recorded)
+		require.Equal(t, s.ToSlice(), replica.ToSlice())
+	})
+
+	t.Run("Push and pop are recorded compactly", func(t *testing.T) {
+		s := New[int]()
+		s.StartRecording()
+		s.Push(1)
+		s.Push(2)
+		s.Pop()
+		s.Clear()
+
+		require.Equal(t, []ops.Op[int]{ops.Add(1), ops.Add(2), ops.Remove(2), ops.Clear[int]()}, s.StopRecording())
+	})
+}
+
+func TestPartitionInPlace(t *testing.T) {
+
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Matching values are moved to top", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3, 4, 5, 6})
+		split := s.PartitionInPlace(isEven)
+		require.Equal(t, 3, split)
+
+		values := s.ToSlice()
+		require.Equal(t, []int{6, 4, 2}, values[:split])
+		require.Equal(t, []int{5, 3, 1}, values[split:])
+		require.Equal(t, 6, s.Pop())
+	})
+
+	t.Run("Non-matching values keep their order", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{2, 3, 1})
+		require.Equal(t, 1, s.PartitionInPlace(isEven))
+		require.Equal(t, []int{2, 1, 3}, s.ToSlice())
+	})
+
+	t.Run("Replaying recorded ops reproduces stack", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3, 4})
+		s.StartRecording()
+		s.PartitionInPlace(isEven)
+		s1 := New[int]()
+		s1.ApplyOps(s.StopRecording())
+		require.Equal(t, s.ToSlice(), s1.ToSlice())
+	})
+}
+
+func TestExtractWhere(t *testing.T) {
+
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Matching values are extracted in order", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3, 4, 5, 6})
+		extracted := s.ExtractWhere(isEven)
+		require.Equal(t, []int{6, 4, 2}, extracted.ToSlice())
+		require.Equal(t, []int{5, 3, 1}, s.ToSlice())
+		require.Equal(t, 5, s.Pop())
+	})
+
+	t.Run("No match leaves stack unchanged", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 3})
+		require.True(t, s.ExtractWhere(isEven).IsEmpty())
+		require.Equal(t, []int{3, 1}, s.ToSlice())
+	})
+
+	t.Run("Replaying recorded ops reproduces stack", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3, 4})
+		s.StartRecording()
+		s.ExtractWhere(isEven)
+		s1 := New[int]()
+		s1.ApplyOps(s.StopRecording())
+		require.Equal(t, s.ToSlice(), s1.ToSlice())
+	})
+}
+
+func TestCloneInto(t *testing.T) {
+
+	t.Run("Destination receives copy of values", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		dst := New[int](WithThreadSafe[int]())
+		dst.AddRange([]int{9, 8, 7, 6, 5})
+		s.CloneInto(dst)
+
+		require.Equal(t, s.ToSlice(), dst.ToSlice())
+		require.Equal(t, 3, dst.Pop())
+		require.Equal(t, 3, s.Count())
+		require.NotNil(t, dst.lock)
+	})
+
+	t.Run("Destination storage is reused", func(t *testing.T) {
+		s := New[int]()
+		s.AddRange([]int{1, 2, 3})
+		dst := New[int]()
+		s.CloneInto(dst)
+
+		if util.Debug {
+			t.Skip("assertions allocate in debug builds")
+		}
+
+		allocs := testing.AllocsPerRun(10, func() { s.CloneInto(dst) })
+		require.Zero(t, allocs)
+	})
+
+	t.Run("Values are deep copied", func(t *testing.T) {
+		s := New[*int](WithDeepCopy(func(v *int) *int { c := *v; return &c }))
+		v := 1
+		s.Push(&v)
+		dst := New[*int]()
+		s.CloneInto(dst)
+
+		require.NotSame(t, s.Peek(), dst.Peek())
+		require.Equal(t, 1, *dst.Peek())
+	})
+
+	t.Run("Nil destination panics", func(t *testing.T) {
+		s := New[int]()
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "dst"), func() { s.CloneInto(nil) })
+	})
+}
+
+func TestOf(t *testing.T) {
+
+	s := Of(1, 2, 3)
+	require.Equal(t, 3, s.Pop())
+	require.Equal(t, []int{2, 1}, s.ToSlice())
+
+	s = OfWith([]StackOptionFunc[int]{WithThreadSafe[int]()}, 1, 2)
+	require.NotNil(t, s.lock)
+	require.Equal(t, 2, s.Count())
+	require.True(t, Of[int]().IsEmpty())
+}
+
+func TestToImmutableSlice(t *testing.T) {
+
+	t.Run("Repeated calls return the same backing array", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc1)
+		require.Same(t, &slc1[0], &slc2[0])
+		require.Equal(t, len(slc1), cap(slc1))
+	})
+
+	t.Run("Recomputed after modification", func(t *testing.T) {
+		col := Of(1, 2, 3)
+		slc1 := col.ToImmutableSlice()
+		col.Remove(2)
+		slc2 := col.ToImmutableSlice()
+
+		require.Equal(t, col.ToSlice(), slc2)
+		require.Len(t, slc1, 3)
+		require.Len(t, slc2, 2)
+
+		col.Clear()
+		require.Empty(t, col.ToImmutableSlice())
+	})
+
+	t.Run("Empty collection", func(t *testing.T) {
+		require.Empty(t, New[int]().ToImmutableSlice())
+	})
+}
+
+func TestWeigher(t *testing.T) {
+
+	byLength := func(s string) int { return len(s) }
+
+	t.Run("Weight is tracked", func(t *testing.T) {
+		s := New(WithWeigher(byLength, 10))
+		s.Push("abc")
+		s.AddRange([]string{"de", "fghi"})
+		require.Equal(t, 9, s.Weight())
+		require.Equal(t, 10, s.MaxWeight())
+
+		s.Pop()
+		s.Remove("abc")
+		require.Equal(t, 2, s.Weight())
+
+		s.Clear()
+		require.Equal(t, 0, s.Weight())
+	})
+
+	t.Run("Offer refuses values over budget", func(t *testing.T) {
+		s := New(WithWeigher(byLength, 5))
+		require.True(t, s.Offer("abc"))
+		require.False(t, s.Offer("def"))
+		require.True(t, s.Offer("de"))
+		require.Equal(t, "de", s.Peek())
+
+		s.Push("fgh")
+		require.Equal(t, 8, s.Weight())
+	})
+
+	t.Run("Bulk operations are reweighed", func(t *testing.T) {
+		s := New(WithWeigher(byLength, 100))
+		s.AddRange([]string{"a", "bb", "ccc"})
+		s.ExtractWhere(func(v string) bool { return len(v) > 1 })
+		require.Equal(t, 1, s.Weight())
+
+		s.ReplaceAll(Of("dddd", "e"))
+		require.Equal(t, 5, s.Weight())
+
+		dst := New(WithWeigher(byLength, 100))
+		s.CloneInto(dst)
+		require.Equal(t, 5, dst.Weight())
+	})
+
+	t.Run("No weigher", func(t *testing.T) {
+		s := Of("abc")
+		require.True(t, s.Offer("def"))
+		require.Equal(t, 0, s.Weight())
+		require.Equal(t, 0, s.MaxWeight())
+	})
+
+	t.Run("Invalid arguments panic", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "weigher"), func() { WithWeigher[string](nil, 1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxWeight"), func() { WithWeigher(byLength, 0) })
+	})
+}
+
+func TestZeroValue(t *testing.T) {
+
+	t.Run("Usable without New", func(t *testing.T) {
+		var s Stack[int]
+
+		require.True(t, s.IsEmpty())
+		require.False(t, s.Contains(1))
+		require.Empty(t, s.ToSlice())
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { s.Pop() })
+
+		for i := 1; i <= 40; i++ {
+			s.Push(i)
+		}
+
+		require.Equal(t, 40, s.Count())
+		require.True(t, s.Contains(2))
+		require.Equal(t, 40, s.Pop())
+		require.Equal(t, 39, s.Peek())
+	})
+
+	t.Run("Panics on first modification if no default comparer", func(t *testing.T) {
+		type point struct{ x, y int }
+		var s Stack[point]
+
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ZERO_VALUE_COMPARER_FMT, point{}), func() { s.Push(point{}) })
+	})
+}
+
+func TestSoftDelete(t *testing.T) {
+
+	t.Run("Removed values are hidden", func(t *testing.T) {
+		s := OfWith([]StackOptionFunc[int]{WithSoftDelete[int]()}, 1, 2, 3, 4, 5, 6)
+		buffer := s.buffer
+
+		require.True(t, s.Remove(2))
+		require.True(t, s.Remove(4))
+		require.False(t, s.Remove(4))
+
+		require.Equal(t, 2, s.Tombstones())
+		require.Equal(t, 4, s.Count())
+		require.Same(t, &buffer[0], &s.buffer[0], "buffer should not be reallocated")
+
+		require.Equal(t, []int{6, 5, 3, 1}, s.ToSlice())
+		require.False(t, s.Contains(4))
+		require.Equal(t, 3, s.At(2))
+		require.Equal(t, 2, s.IndexOf(3))
+		require.Equal(t, 1, s.Min())
+		require.Equal(t, 6, s.Max())
+		require.Len(t, s.FindAll(func(int) bool { return true }), 4)
+		require.False(t, s.ContainsFunc(func(v int) bool { return v == 0 }))
+
+		var reversed []int
+		iter := s.ReverseIterator()
+		for e := iter.Start(); e != nil; e = iter.Next() {
+			reversed = append(reversed, e.Value())
+		}
+		require.Equal(t, []int{1, 3, 5, 6}, reversed)
+	})
+
+	t.Run("Tombstones at top are reclaimed", func(t *testing.T) {
+		s := OfWith([]StackOptionFunc[int]{WithSoftDelete[int]()}, 1, 2, 3, 4)
+
+		require.True(t, s.Remove(3))
+		require.True(t, s.Remove(2))
+		require.Equal(t, 2, s.Tombstones())
+
+		require.Equal(t, 4, s.Pop())
+		require.Equal(t, 0, s.Tombstones())
+		require.Equal(t, 1, s.Peek())
+
+		s.Push(5)
+		require.True(t, s.Remove(5))
+		require.Equal(t, 0, s.Tombstones())
+		require.Equal(t, []int{1}, s.ToSlice())
+	})
+
+	t.Run("Compact reclaims all tombstones", func(t *testing.T) {
+		s := OfWith([]StackOptionFunc[int]{WithSoftDelete[int]()}, 1, 2, 3, 4, 5)
+		s.Remove(1)
+		s.Remove(3)
+
+		iter := s.Iterator()
+		s.Compact()
+
+		require.Equal(t, 0, s.Tombstones())
+		require.Equal(t, 3, s.size)
+		require.Equal(t, []int{5, 4, 2}, s.ToSlice())
+		require.PanicsWithValue(t, messages.COLLECTION_MODIFIED, func() { iter.Start() })
+	})
+
+	t.Run("Whole stack operations reclaim tombstones", func(t *testing.T) {
+		s := OfWith([]StackOptionFunc[int]{WithSoftDelete[int]()}, 5, 1, 4, 2, 3)
+		s.Remove(4)
+		s.Remove(1)
+
+		require.Equal(t, []int{2, 3, 5}, s.Sorted().ToSlice())
+		require.Equal(t, 2, s.Tombstones())
+
+		s.Sort()
+		require.Equal(t, 0, s.Tombstones())
+		require.Equal(t, []int{2, 3, 5}, s.ToSlice())
+	})
+
+	t.Run("Clone, gob and weight exclude removed values", func(t *testing.T) {
+		s := New(WithSoftDelete[string](), WithWeigher(func(v string) int { return len(v) }, 100))
+		s.AddRange([]string{"a", "bb", "ccc"})
+		s.Remove("bb")
+
+		require.Equal(t, 4, s.Weight())
+		s.reweigh()
+		require.Equal(t, 4, s.Weight())
+
+		dst := New[string]()
+		s.CloneInto(dst)
+		require.Equal(t, []string{"ccc", "a"}, dst.ToSlice())
+
+		data, err := s.GobEncode()
+		require.NoError(t, err)
+
+		decoded := New[string]()
+		require.NoError(t, decoded.GobDecode(data))
+		require.Equal(t, []string{"ccc", "a"}, decoded.ToSlice())
+	})
+
+	t.Run("Matches hard delete", func(t *testing.T) {
+		r := rand.New(rand.NewSource(42))
+		soft := New(WithSoftDelete[int]())
+		hard := New[int]()
+
+		for i := 0; i < 2000; i++ {
+			v := r.Intn(50)
+
+			switch r.Intn(4) {
+			case 0:
+				require.Equal(t, hard.Remove(v), soft.Remove(v))
+			case 1:
+				if !hard.IsEmpty() {
+					require.Equal(t, hard.Pop(), soft.Pop())
+				}
+			default:
+				hard.Push(v)
+				soft.Push(v)
+			}
+
+			if i%500 == 499 {
+				soft.Compact()
+			}
+
+			require.Equal(t, hard.Count(), soft.Count())
+		}
+
+		require.Equal(t, hard.ToSlice(), soft.ToSlice())
+	})
+}
+
+func TestConformance(t *testing.T) {
+	compare := func(a, b *int) int { return *a - *b }
+	deepCopy := func(v *int) *int { c := *v; return &c }
+	one, two, three, four := 1, 2, 3, 4
+
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy))
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+		NewValidated: func(validator functions.ValidatorFunc[*int], policy collections.ValidationPolicy) collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithValidator(validator, policy))
+		},
+	})
+	collectiontest.RunCollectionTests(t, collectiontest.Config[*int]{
+		New: func() collections.Collection[*int] {
+			return New(WithComparer(compare), WithDeepCopy(deepCopy), WithSoftDelete[*int]())
+		},
+		Values:     []*int{&one, &two, &three, &four},
+		DeepCopied: func(v, c *int) bool { return v != c && *v == *c },
+	})
+}
+
+func TestChannels(t *testing.T) {
+
+	s := Of(1, 2, 3)
+	received := make([]int, 0)
+
+	for v := range s.ToChan(context.Background()) {
+		received = append(received, v)
+	}
+
+	require.Equal(t, []int{3, 2, 1}, received)
+	require.True(t, s.IsEmpty())
+
+	src := make(chan int)
+	filled, done := FromChan(context.Background(), src)
+
+	for i := 0; i < 3; i++ {
+		src <- i
+	}
+
+	close(src)
+	require.NoError(t, <-done)
+	require.Equal(t, 2, filled.Pop())
+	require.NotNil(t, filled.lock)
+}
+
+func TestTryAdd(t *testing.T) {
+
+	s := New(WithWeigher(func(s string) int { return len(s) }, 5))
+
+	added, err := s.TryAdd("abc")
+	require.True(t, added)
+	require.NoError(t, err)
+
+	added, err = s.TryAdd("def")
+	require.False(t, added)
+	require.ErrorIs(t, err, collections.ErrFull)
+	require.Equal(t, "abc", s.Peek())
+}
+
+func TestPopNPeekN(t *testing.T) {
+
+	t.Run("Top value first", func(t *testing.T) {
+		s := Of(1, 2, 3, 4)
+
+		require.Equal(t, []int{4, 3}, s.PeekN(2))
+		require.Equal(t, 4, s.Count(), "PeekN should not remove values")
+		require.Equal(t, s.At(1), s.PeekN(2)[1])
+
+		require.Equal(t, []int{4, 3, 2}, s.PopN(3))
+		require.Equal(t, []int{1}, s.ToSlice())
+	})
+
+	t.Run("Zero and all values", func(t *testing.T) {
+		s := Of(1, 2)
+		version := s.version
+
+		require.Empty(t, s.PopN(0))
+		require.Equal(t, version, s.version)
+		require.Equal(t, []int{2, 1}, s.PeekN(2))
+		require.Equal(t, []int{2, 1}, s.PopN(2))
+		require.True(t, s.IsEmpty())
+	})
+
+	t.Run("Version is incremented once", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		version := s.version
+		s.PopN(3)
+
+		require.Equal(t, version+1, s.version)
+	})
+
+	t.Run("Weight is adjusted", func(t *testing.T) {
+		s := New(WithWeigher(func(s string) int { return len(s) }, 100))
+		s.AddRange([]string{"a", "bb", "ccc"})
+		s.PopN(2)
+
+		require.Equal(t, 1, s.Weight())
+	})
+
+	t.Run("Soft deleted values are skipped", func(t *testing.T) {
+		s := New(WithSoftDelete[int]())
+		s.AddRange([]int{1, 2, 3, 4, 5})
+		s.Remove(4)
+		s.Remove(2)
+
+		require.Equal(t, []int{5, 3, 1}, s.PeekN(3))
+		require.Equal(t, []int{5, 3}, s.PopN(2))
+		require.Equal(t, []int{1}, s.ToSlice())
+		require.Zero(t, s.Tombstones())
+	})
+
+	t.Run("Out of range panics", func(t *testing.T) {
+		s := Of(1, 2)
+
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { s.PopN(3) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { s.PeekN(-1) })
+		require.Equal(t, 2, s.Count())
+	})
+}
@@ -0,0 +1,40 @@
+package stack
+
+import (
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Assert interface implementation.
+var _ collections.TryAdder[int] = (*Stack[int])(nil)
+
+// TryAdd pushes a value onto the stack if it fits within the weight budget set by [WithWeigher],
+// as [Stack.Offer] does.
+//
+// Returns true and nil if the value was pushed; else false and [collections.ErrFull] if the value
+// would take the total weight of the stack over the budget, or an error wrapping [collections.ErrInvalidValue]
+// and the error of the validator if it was rejected, whatever the validation policy.
+func (s *Stack[T]) TryAdd(value T) (bool, error) {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if err := s.validator.Check(value); err != nil {
+		return false, err
+	}
+
+	if s.weigher != nil && !s.weigher.Fits(s.weigher.Weigh(value)) {
+		return false, collections.ErrFull
+	}
+
+	s.push(value)
+	return true, nil
+}
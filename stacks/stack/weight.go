@@ -0,0 +1,101 @@
+package stack
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+)
+
+// Option function for New to weigh values, e.g. by their size in bytes, so that the stack may be
+// bounded by the total weight of its values rather than their number. The total weight is tracked as
+// values are added and removed. [Stack.Offer] refuses a value that would take the total over maxWeight,
+// whereas Push and Add always push the value, so may exceed it.
+//
+//	s := stack.New(stack.WithWeigher(func(p []byte) int { return len(p) }, 1<<20))
+//
+// Values modified in place, e.g. via [collections.Element.ValuePtr], are not reweighed.
+//
+// Panics if weigher is nil or maxWeight is less than 1. Operations that add or remove values
+// panic if weigher returns a negative weight.
+func WithWeigher[T any](weigher func(T) int, maxWeight int) StackOptionFunc[T] {
+	if weigher == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "weigher"))
+	}
+
+	if maxWeight < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "maxWeight"))
+	}
+
+	return func(s *Stack[T]) {
+		s.weigher = util.NewWeigher(weigher, maxWeight)
+	}
+}
+
+// Offer pushes a value onto the stack if it fits within the weight budget set by [WithWeigher].
+//
+// Returns false if the value is rejected by the validator, or would take the total weight of the stack over the budget;
+// else the value is pushed and true is returned. Without a weigher, Offer is equivalent to Add.
+func (s *Stack[T]) Offer(value T) bool {
+
+	if s.lock != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Write(s.lock != nil, s.verifyInvariants)()
+	}
+
+	s.lazyInit()
+
+	if !s.validator.Accept(value) {
+		return false
+	}
+
+	if s.weigher != nil && !s.weigher.Fits(s.weigher.Weigh(value)) {
+		return false
+	}
+
+	s.push(value)
+	return true
+}
+
+// Weight returns the total weight of the values in the stack,
+// or zero if the stack was not created [WithWeigher].
+func (s *Stack[T]) Weight() int {
+
+	if s.lock != nil {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if util.Debug {
+		defer s.guard.Read(s.lock != nil)()
+	}
+
+	if s.weigher == nil {
+		return 0
+	}
+
+	return s.weigher.Total()
+}
+
+// MaxWeight returns the weight budget set by [WithWeigher],
+// or zero if the stack was not created with a weigher.
+func (s *Stack[T]) MaxWeight() int {
+
+	if s.weigher == nil {
+		return 0
+	}
+
+	return s.weigher.Max()
+}
+
+// reweigh recalculates the total weight after values have been replaced in bulk.
+func (s *Stack[T]) reweigh() {
+	if s.weigher != nil {
+		values := s.values()
+		s.weigher.Reset(len(values), func(index int) T { return values[index] })
+	}
+}
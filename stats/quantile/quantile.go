@@ -0,0 +1,433 @@
+/*
+Package quantile provides a collection that tracks the median and arbitrary quantiles of the values added to it.
+
+Values are held in an order-statistics tree (a treap in which each node records the size of its subtree),
+so adding a value and querying any quantile are both O(log n). Optionally the tracker may be limited to
+a sliding window of the most recently added values, in which case the oldest value is evicted as each
+new value is added once the window is full.
+
+Quantiles are computed with the nearest-rank method, so the result is always one of the values that was added
+and no arithmetic is required on T. For an even number of values, [Tracker.Median] returns the lower median.
+Where an interpolated median is required for numeric types, use [Tracker.Nth] to retrieve both middle values.
+*/
+package quantile
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/queues/ringbuffer"
+	"golang.org/x/exp/constraints"
+)
+
+// TrackerOptionFunc is the signature of a function
+// for providing options to the Tracker constructor.
+type TrackerOptionFunc[T any] func(*Tracker[T])
+
+// Tracker maintains a multiset of values ordered for quantile queries.
+type Tracker[T any] struct {
+	lock    *sync.RWMutex
+	root    *node[T]
+	compare functions.ComparerFunc[T]
+	window  *ringbuffer.RingBuffer[T]
+	seed    uint32
+}
+
+// node is a single value within the treap.
+type node[T any] struct {
+	value    T
+	priority uint32
+	size     int
+	left     *node[T]
+	right    *node[T]
+}
+
+// New constructs a new, empty Tracker.
+func New[T any](options ...TrackerOptionFunc[T]) *Tracker[T] {
+	tr := &Tracker[T]{
+		seed: 2463534242,
+	}
+
+	for _, o := range options {
+		o(tr)
+	}
+
+	if tr.compare == nil {
+		tr.compare = util.GetDefaultComparer[T]()
+	}
+
+	return tr
+}
+
+// NewFunc is as [New], using compare to order the tracked values.
+// The function may be any with the signature of cmp.Compare, e.g. strings.Compare.
+func NewFunc[T any](compare functions.ComparerFunc[T], options ...TrackerOptionFunc[T]) *Tracker[T] {
+	return New(append([]TrackerOptionFunc[T]{WithComparer(compare)}, options...)...)
+}
+
+// NewOrdered is as [New] for ordered types, selecting the default comparer at
+// compile time rather than by reflection. Types defined on a built-in type,
+// such as type Celsius float64, are compared with [functions.Compare].
+func NewOrdered[T constraints.Ordered](options ...TrackerOptionFunc[T]) *Tracker[T] {
+	return NewFunc(util.GetOrderedComparer[T](), options...)
+}
+
+// Option function for New to make the collection thread-safe. Adds overhead.
+func WithThreadSafe[T any]() TrackerOptionFunc[T] {
+	return func(tr *Tracker[T]) {
+		tr.lock = &sync.RWMutex{}
+	}
+}
+
+// Option function for New to provide a comparer function for values of type T.
+// Required if the element type is not numeric, bool, pointer or string.
+func WithComparer[T any](comparer functions.ComparerFunc[T]) TrackerOptionFunc[T] {
+	if comparer == nil {
+		panic(messages.COMP_FN_NIL)
+	}
+	return func(tr *Tracker[T]) {
+		tr.compare = comparer
+	}
+}
+
+// Option function for New to limit the tracker to a sliding window of the given number
+// of most recently added values.
+//
+// Panics if size is less than 1.
+func WithWindow[T any](size int) TrackerOptionFunc[T] {
+	if size < 1 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"))
+	}
+	return func(tr *Tracker[T]) {
+		tr.window = ringbuffer.New[T](size)
+	}
+}
+
+// Option function for New to apply options declared once in a [collections.CommonOptions],
+// for example to construct several collections with identical settings.
+//
+// Concurrent, Capacity and DeepCopy are ignored by this collection.
+func WithOptions[T any](options collections.CommonOptions[T]) TrackerOptionFunc[T] {
+	opts := make([]TrackerOptionFunc[T], 0, 2)
+
+	if options.ThreadSafe {
+		opts = append(opts, WithThreadSafe[T]())
+	}
+
+	if options.Comparer != nil {
+		opts = append(opts, WithComparer(options.Comparer))
+	}
+
+	return func(tr *Tracker[T]) {
+		for _, o := range opts {
+			o(tr)
+		}
+	}
+}
+
+// Add adds a value to the tracker. O(log n).
+//
+// If the tracker has a sliding window which is full, the oldest value is evicted.
+func (tr *Tracker[T]) Add(value T) {
+
+	if tr.lock != nil {
+		tr.lock.Lock()
+		defer tr.lock.Unlock()
+	}
+
+	tr.add(value)
+}
+
+// AddRange adds a slice of values to the tracker in order.
+func (tr *Tracker[T]) AddRange(values []T) {
+
+	if tr.lock != nil {
+		tr.lock.Lock()
+		defer tr.lock.Unlock()
+	}
+
+	for _, v := range values {
+		tr.add(v)
+	}
+}
+
+// Remove removes one occurrence of the given value from the tracker,
+// returning true if it was present. O(log n).
+//
+// Panics if the tracker has a sliding window, as the window would then evict
+// the wrong occurrence of a duplicated value.
+func (tr *Tracker[T]) Remove(value T) bool {
+
+	if tr.window != nil {
+		panic(messages.REMOVE_FROM_WINDOW)
+	}
+
+	if tr.lock != nil {
+		tr.lock.Lock()
+		defer tr.lock.Unlock()
+	}
+
+	count := size(tr.root)
+	tr.root = tr.remove(tr.root, value)
+	return size(tr.root) < count
+}
+
+// Count returns the number of values being tracked.
+func (tr *Tracker[T]) Count() int {
+
+	if tr.lock != nil {
+		tr.lock.RLock()
+		defer tr.lock.RUnlock()
+	}
+
+	return size(tr.root)
+}
+
+// IsEmpty returns true if no values are being tracked.
+func (tr *Tracker[T]) IsEmpty() bool {
+	return tr.Count() == 0
+}
+
+// Clear removes all values from the tracker.
+func (tr *Tracker[T]) Clear() {
+
+	if tr.lock != nil {
+		tr.lock.Lock()
+		defer tr.lock.Unlock()
+	}
+
+	tr.root = nil
+
+	if tr.window != nil {
+		tr.window.Clear()
+	}
+}
+
+// Nth returns the value with the given zero-based rank, i.e. Nth(0) is the minimum
+// and Nth(Count()-1) is the maximum. O(log n).
+//
+// Panics if n is out of range.
+func (tr *Tracker[T]) Nth(n int) T {
+
+	if tr.lock != nil {
+		tr.lock.RLock()
+		defer tr.lock.RUnlock()
+	}
+
+	util.ValidateIndex(n, size(tr.root))
+	return tr.nth(n)
+}
+
+// Min returns the minimum value being tracked.
+//
+// Panics if the tracker is empty.
+func (tr *Tracker[T]) Min() T {
+	return tr.Quantile(0)
+}
+
+// Max returns the maximum value being tracked.
+//
+// Panics if the tracker is empty.
+func (tr *Tracker[T]) Max() T {
+	return tr.Quantile(1)
+}
+
+// Median returns the median of the values being tracked.
+// If there is an even number of values, the lower of the two middle values is returned.
+//
+// Panics if the tracker is empty.
+func (tr *Tracker[T]) Median() T {
+	return tr.Quantile(0.5)
+}
+
+// Quantile returns the value at the given quantile q of the values being tracked,
+// where q is in the range 0 to 1, using the nearest-rank method. O(log n).
+//
+// Panics if the tracker is empty or q is out of range.
+func (tr *Tracker[T]) Quantile(q float64) T {
+
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "q"))
+	}
+
+	if tr.lock != nil {
+		tr.lock.RLock()
+		defer tr.lock.RUnlock()
+	}
+
+	n := size(tr.root)
+
+	if n == 0 {
+		panic(messages.COLLECTION_EMPTY)
+	}
+
+	rank := int(math.Ceil(q*float64(n))) - 1
+
+	if rank < 0 {
+		rank = 0
+	}
+
+	return tr.nth(rank)
+}
+
+// Rank returns the number of values being tracked that are less than the given value. O(log n).
+func (tr *Tracker[T]) Rank(value T) int {
+
+	if tr.lock != nil {
+		tr.lock.RLock()
+		defer tr.lock.RUnlock()
+	}
+
+	rank := 0
+
+	for n := tr.root; n != nil; {
+		if tr.compare(value, n.value) <= 0 {
+			n = n.left
+		} else {
+			rank += size(n.left) + 1
+			n = n.right
+		}
+	}
+
+	return rank
+}
+
+// ToSlice returns the values being tracked as a slice in ascending order.
+func (tr *Tracker[T]) ToSlice() []T {
+
+	if tr.lock != nil {
+		tr.lock.RLock()
+		defer tr.lock.RUnlock()
+	}
+
+	slc := make([]T, 0, size(tr.root))
+	return appendInOrder(slc, tr.root)
+}
+
+func (tr *Tracker[T]) add(value T) {
+
+	if tr.window != nil {
+		if tr.window.Full() {
+			tr.root = tr.remove(tr.root, tr.window.Dequeue())
+		}
+
+		tr.window.Enqueue(value)
+	}
+
+	left, right := tr.split(tr.root, value)
+	tr.root = merge(merge(left, &node[T]{value: value, priority: tr.nextPriority(), size: 1}), right)
+}
+
+func (tr *Tracker[T]) nth(k int) T {
+	n := tr.root
+
+	for {
+		leftSize := size(n.left)
+
+		switch {
+		case k < leftSize:
+			n = n.left
+		case k == leftSize:
+			return n.value
+		default:
+			k -= leftSize + 1
+			n = n.right
+		}
+	}
+}
+
+// split divides the subtree rooted at n into values less than value, and values greater than or equal to value.
+func (tr *Tracker[T]) split(n *node[T], value T) (*node[T], *node[T]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if tr.compare(n.value, value) < 0 {
+		left, right := tr.split(n.right, value)
+		n.right = left
+		n.update()
+		return n, right
+	}
+
+	left, right := tr.split(n.left, value)
+	n.left = right
+	n.update()
+	return left, n
+}
+
+// remove removes one occurrence of value from the subtree rooted at n, and returns the new root of the subtree.
+func (tr *Tracker[T]) remove(n *node[T], value T) *node[T] {
+	if n == nil {
+		return nil
+	}
+
+	switch c := tr.compare(value, n.value); {
+	case c < 0:
+		n.left = tr.remove(n.left, value)
+	case c > 0:
+		n.right = tr.remove(n.right, value)
+	default:
+		return merge(n.left, n.right)
+	}
+
+	n.update()
+	return n
+}
+
+// nextPriority returns a pseudo-random priority for a new node (xorshift32).
+func (tr *Tracker[T]) nextPriority() uint32 {
+	x := tr.seed
+	x ^= x << 13
+	x ^= x >> 17
+	x ^= x << 5
+	tr.seed = x
+	return x
+}
+
+// merge joins two subtrees where all values in left are less than or equal to all values in right.
+func merge[T any](left, right *node[T]) *node[T] {
+	if left == nil {
+		return right
+	}
+
+	if right == nil {
+		return left
+	}
+
+	if left.priority > right.priority {
+		left.right = merge(left.right, right)
+		left.update()
+		return left
+	}
+
+	right.left = merge(left, right.left)
+	right.update()
+	return right
+}
+
+func appendInOrder[T any](slc []T, n *node[T]) []T {
+	if n == nil {
+		return slc
+	}
+
+	slc = appendInOrder(slc, n.left)
+	slc = append(slc, n.value)
+	return appendInOrder(slc, n.right)
+}
+
+func (n *node[T]) update() {
+	n.size = size(n.left) + size(n.right) + 1
+}
+
+func size[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
@@ -0,0 +1,153 @@
+package quantile
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantile(t *testing.T) {
+
+	t.Run("Median of odd and even counts", func(t *testing.T) {
+		tr := New[int]()
+		tr.AddRange([]int{5, 1, 3})
+		require.Equal(t, 3, tr.Median())
+		tr.Add(4)
+		require.Equal(t, 3, tr.Median())
+		require.Equal(t, 4, tr.Nth(2))
+	})
+
+	t.Run("Quantiles use nearest rank", func(t *testing.T) {
+		tr := New[int]()
+
+		for i := 1; i <= 100; i++ {
+			tr.Add(i)
+		}
+
+		require.Equal(t, 1, tr.Quantile(0))
+		require.Equal(t, 1, tr.Quantile(0.01))
+		require.Equal(t, 25, tr.Quantile(0.25))
+		require.Equal(t, 90, tr.Quantile(0.9))
+		require.Equal(t, 99, tr.Quantile(0.99))
+		require.Equal(t, 100, tr.Quantile(1))
+		require.Equal(t, 1, tr.Min())
+		require.Equal(t, 100, tr.Max())
+	})
+
+	t.Run("Random values match sorted model", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(2163))
+		tr := New[int]()
+		model := make([]int, 0, 1000)
+
+		for i := 0; i < 1000; i++ {
+			v := rnd.Intn(200)
+			tr.Add(v)
+			model = append(model, v)
+		}
+
+		sort.Ints(model)
+		require.Equal(t, model, tr.ToSlice())
+
+		for _, q := range []float64{0, 0.1, 0.5, 0.75, 0.999, 1} {
+			rank := int(math.Ceil(q*float64(len(model)))) - 1
+			if rank < 0 {
+				rank = 0
+			}
+			require.Equal(t, model[rank], tr.Quantile(q))
+		}
+
+		require.Equal(t, sort.SearchInts(model, 100), tr.Rank(100))
+	})
+
+	t.Run("Empty tracker panics", func(t *testing.T) {
+		tr := New[int]()
+		require.True(t, tr.IsEmpty())
+		require.PanicsWithValue(t, messages.COLLECTION_EMPTY, func() { tr.Median() })
+		require.Panics(t, func() { tr.Nth(0) })
+	})
+
+	t.Run("Quantile out of range panics", func(t *testing.T) {
+		tr := New[int]()
+		tr.Add(1)
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "q"), func() { tr.Quantile(-0.1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "q"), func() { tr.Quantile(1.1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "q"), func() { tr.Quantile(math.NaN()) })
+	})
+
+	t.Run("Remove one occurrence", func(t *testing.T) {
+		tr := New[int]()
+		tr.AddRange([]int{3, 1, 3, 2})
+		require.True(t, tr.Remove(3))
+		require.False(t, tr.Remove(4))
+		require.Equal(t, []int{1, 2, 3}, tr.ToSlice())
+		require.PanicsWithValue(t, messages.REMOVE_FROM_WINDOW, func() { New(WithWindow[int](2)).Remove(1) })
+	})
+
+	t.Run("Custom comparer", func(t *testing.T) {
+		tr := New(WithThreadSafe[string](), WithComparer(func(a, b string) int { return len(a) - len(b) }))
+		tr.AddRange([]string{"ccc", "a", "bb"})
+		require.Equal(t, "bb", tr.Median())
+		tr.Clear()
+		require.Equal(t, 0, tr.Count())
+	})
+}
+
+func TestWindow(t *testing.T) {
+
+	t.Run("Oldest values are evicted", func(t *testing.T) {
+		tr := New(WithWindow[int](3))
+		tr.AddRange([]int{10, 1, 2})
+		require.Equal(t, 2, tr.Median())
+		tr.Add(3)
+		require.Equal(t, []int{1, 2, 3}, tr.ToSlice())
+		tr.AddRange([]int{100, 101})
+		require.Equal(t, []int{3, 100, 101}, tr.ToSlice())
+		require.Equal(t, 100, tr.Median())
+	})
+
+	t.Run("Duplicate values are evicted one at a time", func(t *testing.T) {
+		tr := New(WithWindow[int](2))
+		tr.AddRange([]int{5, 5, 5})
+		require.Equal(t, []int{5, 5}, tr.ToSlice())
+	})
+
+	t.Run("Sliding window matches model", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(2163))
+		const window = 50
+		tr := New(WithWindow[int](window))
+		values := make([]int, 0, 500)
+
+		for i := 0; i < 500; i++ {
+			v := rnd.Intn(100)
+			tr.Add(v)
+			values = append(values, v)
+
+			start := len(values) - window
+			if start < 0 {
+				start = 0
+			}
+
+			model := append([]int{}, values[start:]...)
+			sort.Ints(model)
+			require.Equal(t, len(model), tr.Count())
+			require.Equal(t, model[(len(model)+1)/2-1], tr.Median())
+		}
+	})
+
+	t.Run("Clear empties the window", func(t *testing.T) {
+		tr := New(WithWindow[int](2))
+		tr.AddRange([]int{1, 2})
+		tr.Clear()
+		tr.AddRange([]int{3, 4})
+		require.Equal(t, []int{3, 4}, tr.ToSlice())
+	})
+
+	t.Run("Invalid window size panics", func(t *testing.T) {
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "size"), func() { WithWindow[int](0) })
+	})
+}
@@ -0,0 +1,247 @@
+/*
+Package trending provides a counter of the activity of keys whose scores decay exponentially over time,
+for features such as the most active keys of the last few minutes.
+
+Each event adds to the score of its key, and every score halves with each half-life that passes, so a key
+that was busy an hour ago ranks below one that is busy now. Scores are not updated as time passes. Instead
+each is held scaled up by the decay since a fixed epoch, so that the order of the held scores is that of
+the current scores, and a score is only scaled down to the present when it is read. The epoch is advanced
+whenever the scale factor would grow large enough to lose precision.
+
+	hot := trending.New[string](5 * time.Minute)
+	hot.Add(path)
+
+	for _, e := range hot.TopN(10) {
+		fmt.Printf("%s: %.1f\n", e.Key, e.Value)
+	}
+*/
+package trending
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/maps"
+	"github.com/fireflycons/generic_collections/queues/priorityqueue"
+)
+
+// maxExponent is the number of half-lives after which the epoch is advanced, limiting held scores
+// to 2^maxExponent times the current scores.
+const maxExponent = 64
+
+// OptionFunc is the signature of a function
+// for providing options to the Counter constructor.
+type OptionFunc func(*config)
+
+// config holds the options of a counter.
+type config struct {
+	clock functions.Clock
+}
+
+// Option function for New to supply the clock against which scores decay.
+// The default is [functions.SystemClock].
+//
+// Panics if clock is nil.
+func WithClock(clock functions.Clock) OptionFunc {
+	if clock == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "clock"))
+	}
+
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// Counter holds an exponentially decayed score for each key. It is always thread-safe.
+//
+// A score is held for each key that has been added until it is removed. Call [Counter.Prune]
+// periodically to discard the keys whose scores have decayed to insignificance.
+type Counter[K comparable] struct {
+	lock     sync.Mutex
+	clock    functions.Clock
+	halfLife time.Duration
+	epoch    time.Time
+	scores   map[K]float64
+}
+
+// New constructs a Counter whose scores halve with each halfLife that passes.
+//
+// Panics if halfLife is not positive.
+func New[K comparable](halfLife time.Duration, options ...OptionFunc) *Counter[K] {
+	if halfLife <= 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "halfLife"))
+	}
+
+	c := &config{clock: functions.SystemClock}
+
+	for _, o := range options {
+		o(c)
+	}
+
+	return &Counter[K]{
+		clock:    c.clock,
+		halfLife: halfLife,
+		epoch:    c.clock.Now(),
+		scores:   make(map[K]float64),
+	}
+}
+
+// Add adds 1 to the score of key, and returns its new score.
+func (c *Counter[K]) Add(key K) float64 {
+
+	return c.AddN(key, 1)
+}
+
+// AddN adds weight to the score of key, and returns its new score.
+//
+// Panics if weight is negative, infinite or NaN.
+func (c *Counter[K]) AddN(key K, weight float64) float64 {
+
+	if weight < 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "weight"))
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	scale := c.scale(c.clock.Now())
+	c.scores[key] += weight * scale
+
+	return c.scores[key] / scale
+}
+
+// Score returns the current score of key, which is zero if the key is not held.
+func (c *Counter[K]) Score(key K) float64 {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.scores[key] / c.scale(c.clock.Now())
+}
+
+// TopN returns up to n keys with the highest current scores, highest first, as entries whose
+// Value is the score. Keys with equal scores are returned in no particular order.
+// O(k log n), where k is the number of keys held.
+//
+// Panics if n is negative.
+func (c *Counter[K]) TopN(n int) []maps.Entry[K, float64] {
+
+	if n < 0 {
+		panic(fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"))
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if n == 0 || len(c.scores) == 0 {
+		return []maps.Entry[K, float64]{}
+	}
+
+	scale := c.scale(c.clock.Now())
+
+	// No more keys than are held can be returned, and the heap is sized for them.
+	k := n
+	if k > len(c.scores) {
+		k = len(c.scores)
+	}
+
+	// Held scores are in the order of current scores, so the k highest are
+	// selected with a min-heap of those seen so far, evicting the lowest.
+	top := priorityqueue.NewFunc(
+		func(a, b maps.Entry[K, float64]) int { return functions.Compare(a.Value, b.Value) },
+		priorityqueue.WithCapacity[maps.Entry[K, float64]](k+1),
+	)
+
+	for key, score := range c.scores {
+		top.Enqueue(maps.Entry[K, float64]{Key: key, Value: score})
+
+		if top.Count() > k {
+			top.Dequeue()
+		}
+	}
+
+	entries := make([]maps.Entry[K, float64], top.Count())
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := top.Dequeue()
+		e.Value /= scale
+		entries[i] = e
+	}
+
+	return entries
+}
+
+// Remove discards the score of key.
+//
+// Returns true if the key was held; else false.
+func (c *Counter[K]) Remove(key K) bool {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_, ok := c.scores[key]
+	delete(c.scores, key)
+	return ok
+}
+
+// Prune discards the keys whose current scores are below threshold,
+// and returns the number of keys discarded. O(k), where k is the number of keys held.
+func (c *Counter[K]) Prune(threshold float64) int {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	cutoff := threshold * c.scale(c.clock.Now())
+	pruned := 0
+
+	for key, score := range c.scores {
+		if score < cutoff {
+			delete(c.scores, key)
+			pruned++
+		}
+	}
+
+	return pruned
+}
+
+// Count returns the number of keys held.
+func (c *Counter[K]) Count() int {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.scores)
+}
+
+// Clear discards all keys.
+func (c *Counter[K]) Clear() {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.scores = make(map[K]float64)
+	c.epoch = c.clock.Now()
+}
+
+// scale returns the factor by which held scores exceed the current scores at now,
+// first advancing the epoch to now if the factor has grown too large.
+func (c *Counter[K]) scale(now time.Time) float64 {
+	exponent := float64(now.Sub(c.epoch)) / float64(c.halfLife)
+
+	if exponent > maxExponent {
+		rebase := math.Exp2(-exponent)
+
+		for key, score := range c.scores {
+			c.scores[key] = score * rebase
+		}
+
+		c.epoch = now
+		exponent = 0
+	}
+
+	return math.Exp2(exponent)
+}
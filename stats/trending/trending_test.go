@@ -0,0 +1,155 @@
+package trending
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fireflycons/generic_collections/functions"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/maps"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a clock advanced manually by tests.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func keys(entries []maps.Entry[string, float64]) []string {
+	k := make([]string, len(entries))
+	for i, e := range entries {
+		k[i] = e.Key
+	}
+	return k
+}
+
+func TestCounter(t *testing.T) {
+
+	t.Run("Scores halve with each half-life", func(t *testing.T) {
+		clock := newTestClock()
+		c := New[string](time.Minute, WithClock(clock))
+
+		require.Equal(t, 1.0, c.Add("a"))
+		require.Equal(t, 4.0, c.AddN("a", 3))
+		require.Equal(t, 0.0, c.Score("b"))
+
+		clock.advance(time.Minute)
+		require.InDelta(t, 2.0, c.Score("a"), 1e-9)
+
+		clock.advance(30 * time.Second)
+		require.InDelta(t, 2.0/math.Sqrt2, c.Score("a"), 1e-9)
+
+		require.InDelta(t, 1.0+2.0/math.Sqrt2, c.Add("a"), 1e-9)
+	})
+
+	t.Run("TopN ranks by current score", func(t *testing.T) {
+		clock := newTestClock()
+		c := New[string](time.Minute, WithClock(clock))
+
+		c.AddN("old", 10)
+		clock.advance(3 * time.Minute)
+		c.AddN("new", 2)
+		c.AddN("newer", 3)
+		c.AddN("least", 0.5)
+
+		top := c.TopN(3)
+		require.Equal(t, []string{"newer", "new", "old"}, keys(top))
+		require.InDelta(t, 3.0, top[0].Value, 1e-9)
+		require.InDelta(t, 1.25, top[2].Value, 1e-9)
+
+		require.Len(t, c.TopN(10), 4)
+		require.Equal(t, keys(top), keys(c.TopN(3)))
+		require.Equal(t, keys(c.TopN(4)), keys(c.TopN(1<<34)))
+		require.Equal(t, keys(c.TopN(4)), keys(c.TopN(math.MaxInt)))
+		require.Empty(t, c.TopN(0))
+		require.Empty(t, New[string](time.Minute).TopN(5))
+	})
+
+	t.Run("Scores survive many half-lives", func(t *testing.T) {
+		clock := newTestClock()
+		c := New[string](time.Second, WithClock(clock))
+
+		c.AddN("a", 1<<20)
+		for i := 0; i < 10; i++ {
+			clock.advance(maxExponent * time.Second / 2)
+			c.Add("b")
+		}
+
+		require.InDelta(t, 1.0, c.Score("b"), 1e-9)
+		require.InDelta(t, 0.0, c.Score("a"), 1e-9)
+		require.Equal(t, []string{"b", "a"}, keys(c.TopN(2)))
+	})
+
+	t.Run("Prune, Remove and Clear", func(t *testing.T) {
+		clock := newTestClock()
+		c := New[string](time.Minute, WithClock(clock))
+
+		c.AddN("a", 1)
+		c.AddN("b", 8)
+		clock.advance(2 * time.Minute)
+
+		require.Equal(t, 1, c.Prune(1))
+		require.Equal(t, 1, c.Count())
+		require.Equal(t, []string{"b"}, keys(c.TopN(2)))
+
+		require.True(t, c.Remove("b"))
+		require.False(t, c.Remove("b"))
+
+		c.Add("c")
+		c.Clear()
+		require.Equal(t, 0, c.Count())
+		require.Equal(t, 0.0, c.Score("c"))
+	})
+
+	t.Run("Is thread-safe", func(t *testing.T) {
+		c := New[int](time.Minute)
+		var wg sync.WaitGroup
+
+		for w := 0; w < 8; w++ {
+			w := w
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					c.Add(w)
+					c.TopN(3)
+				}
+			}()
+		}
+
+		wg.Wait()
+		require.Equal(t, 8, c.Count())
+	})
+
+	t.Run("Accepts a clock function", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := New[string](time.Hour, WithClock(functions.ClockFunc(func() time.Time { return now })))
+		c.Add("a")
+		now = now.Add(time.Hour)
+		require.InDelta(t, 0.5, c.Score("a"), 1e-9)
+	})
+
+	t.Run("Panics on invalid arguments", func(t *testing.T) {
+		c := New[string](time.Minute)
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "halfLife"), func() { New[string](0) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "clock"), func() { WithClock(nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "weight"), func() { c.AddN("a", -1) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "weight"), func() { c.AddN("a", math.NaN()) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_OUT_OF_RANGE_FMT, "n"), func() { c.TopN(-1) })
+	})
+}
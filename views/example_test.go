@@ -0,0 +1,28 @@
+package views_test
+
+import (
+	"fmt"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/sets/orderedset"
+	"github.com/fireflycons/generic_collections/views"
+)
+
+func ExampleDerived() {
+	words := dlist.Of("apple", "fig", "kiwi", "pear")
+
+	lengths := views.Derived[string](words, func(src collections.Collection[string]) *orderedset.OrderedSet[int] {
+		s := orderedset.New[int]()
+		collections.Map[string, int](src, func(w string) int { return len(w) }, s)
+		return s
+	})
+
+	fmt.Println(lengths.Get().ToSlice())
+
+	words.Add("banana")
+	fmt.Println(lengths.Get().ToSlice())
+	// Output:
+	// [3 4 5]
+	// [3 4 5 6]
+}
@@ -0,0 +1,196 @@
+/*
+Package views provides derived views of collections, which cache the result of a transformation
+of a source collection and recompute it only when the source has been modified.
+
+	active := views.Derived[User](users, func(src collections.Collection[User]) *hashset.HashSet[string] {
+		names := hashset.New[string]()
+		for _, u := range src.ToSlice() {
+			if u.Active {
+				names.Add(u.Name)
+			}
+		}
+		return names
+	})
+
+	// Recomputed only if users has been modified since the previous call
+	names := active.Get()
+
+Each collection of this module holds a version that is incremented by each modification, which the view
+compares with the version at which its result was computed. Where the transformation can be expressed
+as an update of the previous result, [WithIncremental] applies only the mutations recorded since.
+
+A view returned by [collections.Chain] and an empty collection have no version of their own, so a view
+of either recomputes its result on every call. Where this is costly, derive a view from each underlying
+collection of the chain instead.
+*/
+package views
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/internal/util"
+	"github.com/fireflycons/generic_collections/ops"
+)
+
+// TransformFunc is the signature of a function that computes the result of a view from its source.
+type TransformFunc[T, R any] func(source collections.Collection[T]) R
+
+// IncrementalFunc is the signature of a function that updates the result of a view
+// with the mutations of its source recorded since the result was computed, returning the updated result.
+// It may modify and return result, which is owned by the view.
+type IncrementalFunc[T, R any] func(result R, ops []ops.Op[T]) R
+
+// ViewOptionFunc is the signature of a function
+// for providing options to the Derived constructor.
+type ViewOptionFunc[T, R any] func(*View[T, R])
+
+// View is a cached result of a transformation of a source collection. It is safe for concurrent use
+// if the source is thread-safe.
+type View[T, R any] struct {
+	lock      sync.Mutex
+	source    collections.Collection[T]
+	transform TransformFunc[T, R]
+	apply     IncrementalFunc[T, R]
+	result    R
+	version   int
+	valid     bool
+
+	// unversioned is true if the source has no version to compare.
+	unversioned bool
+}
+
+// Derived constructs a view of the result of transform applied to source.
+// The result is computed on the first call to [View.Get], and again only when source has been modified.
+//
+// Values modified in place, e.g. via [collections.Element.ValuePtr], do not modify the version of
+// the source, so call [View.Invalidate] after doing so.
+//
+// Panics if source or transform is nil.
+func Derived[T, R any](source collections.Collection[T], transform TransformFunc[T, R], options ...ViewOptionFunc[T, R]) *View[T, R] {
+	if source == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "source"))
+	}
+
+	if transform == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "transform"))
+	}
+
+	v := &View[T, R]{
+		source:      source,
+		transform:   transform,
+		unversioned: source.Type() == collections.COLLECTION_CHAIN || source.Type() == collections.COLLECTION_EMPTY,
+	}
+
+	for _, o := range options {
+		o(v)
+	}
+
+	return v
+}
+
+// Option function for Derived to update the result with the mutations of the source
+// since it was last computed, rather than recomputing it.
+//
+// The view records the mutations of the source with its StartRecording method, so the source
+// must not otherwise be recorded while the view is in use. Call [View.Close] to stop recording.
+// Mutations that cannot be expressed as a single Add or Remove, such as sorting, are recorded as
+// a Clear followed by an Add of each value, so apply should handle ops.KindClear.
+//
+// Ignored if the source is a chain or an empty collection, which cannot be recorded.
+//
+// Panics if apply is nil.
+func WithIncremental[T, R any](apply IncrementalFunc[T, R]) ViewOptionFunc[T, R] {
+	if apply == nil {
+		panic(fmt.Sprintf(messages.ARG_NIL_FMT, "apply"))
+	}
+
+	return func(v *View[T, R]) {
+		v.apply = apply
+	}
+}
+
+// Get returns the result of the view, which is recomputed, or updated if the view was created
+// [WithIncremental], if the source has been modified since it was last computed.
+//
+// The result is shared by all callers until it is next recomputed, so must not be modified.
+// A result updated [WithIncremental] may be modified in place by a later call.
+func (v *View[T, R]) Get() R {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.unversioned {
+		v.result = v.transform(v.source)
+		return v.result
+	}
+
+	version := util.CurrentVersion(v.source)
+
+	if v.valid && version == v.version {
+		return v.result
+	}
+
+	if v.valid && v.apply != nil {
+		recorded := v.source.StopRecording()
+		v.source.StartRecording()
+
+		// A modification between reading the version and restarting the recording
+		// may not have been recorded, so recompute if there was one.
+		if util.CurrentVersion(v.source) == version {
+			v.result = v.apply(v.result, recorded)
+			v.version = version
+			return v.result
+		}
+	}
+
+	v.recompute()
+	return v.result
+}
+
+// IsStale returns true if the source has been modified since the result was last computed,
+// or the view has been invalidated, so that the next call to [View.Get] will update it.
+func (v *View[T, R]) IsStale() bool {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.unversioned || !v.valid || util.CurrentVersion(v.source) != v.version
+}
+
+// Invalidate causes the next call to [View.Get] to recompute the result in full,
+// e.g. after values of the source have been modified in place.
+func (v *View[T, R]) Invalidate() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.valid = false
+}
+
+// Close stops recording the mutations of the source if the view was created [WithIncremental],
+// and invalidates the view. The view may still be used, and will resume recording on the next call to [View.Get].
+func (v *View[T, R]) Close() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.apply != nil && !v.unversioned && v.source.IsRecording() {
+		v.source.StopRecording()
+	}
+
+	v.valid = false
+}
+
+func (v *View[T, R]) recompute() {
+	if v.apply != nil {
+		v.source.StopRecording()
+		v.source.StartRecording()
+	}
+
+	version := util.CurrentVersion(v.source)
+	v.result = v.transform(v.source)
+	v.version = version
+
+	// Without recording, a modification during the transform is detected by the version on the next call.
+	// With recording, it would also be applied again from the recorded ops, so recompute instead.
+	v.valid = v.apply == nil || util.CurrentVersion(v.source) == version
+}
@@ -0,0 +1,190 @@
+package views_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/fireflycons/generic_collections/collections"
+	"github.com/fireflycons/generic_collections/internal/messages"
+	"github.com/fireflycons/generic_collections/lists/dlist"
+	"github.com/fireflycons/generic_collections/ops"
+	"github.com/fireflycons/generic_collections/sets/hashset"
+	"github.com/fireflycons/generic_collections/views"
+	"github.com/stretchr/testify/require"
+)
+
+// sum returns a transform that sums the values of its source, counting the calls made to it.
+func sum(calls *int) views.TransformFunc[int, int] {
+	return func(src collections.Collection[int]) int {
+		*calls++
+		total := 0
+		for _, v := range src.ToSlice() {
+			total += v
+		}
+		return total
+	}
+}
+
+// applySum updates a sum with recorded ops.
+func applySum() views.IncrementalFunc[int, int] {
+	return func(total int, recorded []ops.Op[int]) int {
+		for _, op := range recorded {
+			switch op.Kind {
+			case ops.KindAdd:
+				total += op.Value
+			case ops.KindRemove:
+				total -= op.Value
+			case ops.KindClear:
+				total = 0
+			}
+		}
+		return total
+	}
+}
+
+func TestDerived(t *testing.T) {
+
+	t.Run("Recomputes only when the source is modified", func(t *testing.T) {
+		calls := 0
+		l := dlist.Of(1, 2, 3)
+		v := views.Derived[int](l, sum(&calls))
+
+		require.Equal(t, 0, calls, "result should not be computed until Get")
+		require.True(t, v.IsStale())
+		require.Equal(t, 6, v.Get())
+		require.Equal(t, 6, v.Get())
+		require.Equal(t, 1, calls)
+		require.False(t, v.IsStale())
+
+		l.Add(4)
+		require.True(t, v.IsStale())
+		require.Equal(t, 10, v.Get())
+		require.Equal(t, 2, calls)
+
+		v.Invalidate()
+		require.Equal(t, 10, v.Get())
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("Derives a collection", func(t *testing.T) {
+		words := dlist.Of("a", "bb", "cc")
+		lengths := views.Derived[string](words, func(src collections.Collection[string]) *hashset.HashSet[int] {
+			s := hashset.New[int]()
+			collections.Map[string, int](src, func(w string) int { return len(w) }, s)
+			return s
+		})
+
+		require.ElementsMatch(t, []int{1, 2}, lengths.Get().ToSlice())
+		words.Add("ddd")
+		require.ElementsMatch(t, []int{1, 2, 3}, lengths.Get().ToSlice())
+	})
+
+	t.Run("Updates incrementally", func(t *testing.T) {
+		calls := 0
+		s := hashset.Of(1, 2, 3)
+		v := views.Derived[int](s, sum(&calls), views.WithIncremental[int, int](applySum()))
+
+		require.Equal(t, 6, v.Get())
+		require.True(t, s.IsRecording())
+
+		s.Add(10)
+		s.Remove(1)
+		require.Equal(t, 15, v.Get())
+		require.Equal(t, 1, calls, "should apply ops rather than recompute")
+
+		s.Clear()
+		s.AddRange([]int{4, 5})
+		require.Equal(t, 9, v.Get())
+		require.Equal(t, 1, calls)
+
+		v.Invalidate()
+		require.Equal(t, 9, v.Get())
+		require.Equal(t, 2, calls)
+
+		v.Close()
+		require.False(t, s.IsRecording())
+		s.Add(1)
+		require.Equal(t, 10, v.Get())
+		require.Equal(t, 3, calls)
+		require.True(t, s.IsRecording())
+	})
+
+	t.Run("Tracks copy-on-write sources", func(t *testing.T) {
+		calls := 0
+		l := dlist.NewCopyOnWrite[int]()
+		l.AddRange([]int{1, 2})
+		v := views.Derived[int](l, sum(&calls))
+
+		require.Equal(t, 3, v.Get())
+		require.Equal(t, 3, v.Get())
+		require.Equal(t, 1, calls)
+
+		l.Add(3)
+		require.Equal(t, 6, v.Get())
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("Recomputes views of chains on every call", func(t *testing.T) {
+		calls := 0
+		a, b := dlist.Of(1), dlist.Of(2)
+		v := views.Derived[int](collections.Chain[int](a, b), sum(&calls), views.WithIncremental[int, int](applySum()))
+
+		require.Equal(t, 3, v.Get())
+		b.Add(3)
+		require.Equal(t, 6, v.Get())
+		require.Equal(t, 2, calls)
+		require.True(t, v.IsStale())
+		require.NotPanics(t, v.Close)
+
+		e := views.Derived[int](collections.Empty[int](), sum(&calls))
+		require.Equal(t, 0, e.Get())
+	})
+
+	t.Run("Is safe for concurrent use with a thread-safe source", func(t *testing.T) {
+		s := hashset.New(hashset.WithThreadSafe[int]())
+		v := views.Derived[int](s, func(src collections.Collection[int]) int { return src.Count() },
+			views.WithIncremental[int, int](func(count int, recorded []ops.Op[int]) int {
+				for _, op := range recorded {
+					switch op.Kind {
+					case ops.KindAdd:
+						count++
+					case ops.KindRemove:
+						count--
+					case ops.KindClear:
+						count = 0
+					}
+				}
+				return count
+			}))
+
+		var wg sync.WaitGroup
+
+		for w := 0; w < 4; w++ {
+			w := w
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 500; i++ {
+					s.Add(w*500 + i)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 500; i++ {
+					require.LessOrEqual(t, v.Get(), 2000)
+				}
+			}()
+		}
+
+		wg.Wait()
+		require.Equal(t, 2000, v.Get())
+	})
+
+	t.Run("Panics on nil arguments", func(t *testing.T) {
+		calls := 0
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "source"), func() { views.Derived[int, int](nil, sum(&calls)) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "transform"), func() { views.Derived[int, int](dlist.New[int](), nil) })
+		require.PanicsWithValue(t, fmt.Sprintf(messages.ARG_NIL_FMT, "apply"), func() { views.WithIncremental[int, int](nil) })
+	})
+}